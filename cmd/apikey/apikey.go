@@ -0,0 +1,163 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/spf13/cobra"
+
+	"music-service/internal/apikeys"
+	"music-service/internal/authz"
+	"music-service/internal/config"
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+	"music-service/pkg/postgres/orm/db"
+)
+
+func NewAPIKeyCommand() *cobra.Command {
+	var token string
+
+	cmd := &cobra.Command{
+		Use:   "apikey",
+		Short: "manages the API keys of service-to-service clients",
+		Long:  `creates, lists and revokes the API keys clients send in the X-API-Key header or the x-api-key gRPC metadata; requires sql/ddl/create_table_api_keys.sql and, when auth is enabled, the --token of an admin`,
+	}
+	cmd.PersistentFlags().StringVar(&token, "token", os.Getenv(authz.TokenEnv), "bearer token of the caller, defaults to $"+authz.TokenEnv)
+
+	cmd.AddCommand(newCreateCommand(&token))
+	cmd.AddCommand(newListCommand(&token))
+	cmd.AddCommand(newRevokeCommand(&token))
+	return cmd
+}
+
+func newCreateCommand(token *string) *cobra.Command {
+	var scopes []string
+	var expiresIn time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "creates an API key",
+		Long:  `creates an API key acting with the roles of its scopes and shows it; the key cannot be shown again`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, service, closeDB := newService(cmd, *token)
+			defer closeDB()
+
+			key := &models.APIKey{
+				Name:   args[0],
+				Scopes: scopes,
+			}
+			if expiresIn > 0 {
+				expiresAt := time.Now().Add(expiresIn)
+				key.ExpiresAt = &expiresAt
+			}
+			key.Normalize()
+			if err := key.Validate(time.Now()); err != nil {
+				log.Fatalf("invalid API key: %v", err)
+			}
+			policy, err := authz.LoadPolicy(cfg.Auth.PolicyFile)
+			if err != nil {
+				log.Fatalf("failed to load policy: %v", err)
+			}
+			for _, scope := range key.Scopes {
+				if !policy.HasRole(scope) {
+					log.Fatalf("invalid API key: scope %q is not a role of the policy", scope)
+				}
+			}
+
+			plain, err := service.Create(key)
+			if err != nil {
+				log.Fatalf("failed to create API key: %v", err)
+			}
+			log.Printf("created %s", key)
+			fmt.Println(plain)
+		},
+	}
+	cmd.Flags().StringSliceVar(&scopes, "scopes", []string{authz.RoleViewer}, "roles the key acts with")
+	cmd.Flags().DurationVar(&expiresIn, "expires-in", 0, "how long the key is valid, 0 for no expiry")
+	return cmd
+}
+
+func newListCommand(token *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "lists the API keys",
+		Long:  `lists every API key with its scopes, expiry, last use and revocation`,
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			_, service, closeDB := newService(cmd, *token)
+			defer closeDB()
+
+			keys, err := service.List()
+			if err != nil {
+				log.Fatalf("failed to list API keys: %v", err)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tNAME\tPREFIX\tSCOPES\tCREATED\tEXPIRES\tLAST USED\tREVOKED")
+			for _, k := range keys {
+				fmt.Fprintf(w, "%d\t%s\t%s\t%v\t%s\t%s\t%s\t%s\n", k.Id, k.Name, apikeys.KeyPrefix+k.Prefix, k.Scopes,
+					k.CreatedAt.Format(time.RFC3339), formatTime(k.ExpiresAt), formatTime(k.LastUsedAt), formatTime(k.RevokedAt))
+			}
+			w.Flush()
+		},
+	}
+}
+
+func newRevokeCommand(token *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke <id>",
+		Short: "revokes an API key",
+		Long:  `revokes an API key for good; it is kept for auditing`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				log.Fatalf("invalid id %q", args[0])
+			}
+
+			_, service, closeDB := newService(cmd, *token)
+			defer closeDB()
+
+			key, err := service.Revoke(id)
+			if errors.Is(err, pg.ErrNoRows) {
+				log.Fatalf("API key %d not found", id)
+			}
+			if err != nil {
+				log.Fatalf("failed to revoke API key %d: %v", id, err)
+			}
+			log.Printf("revoked %s", key)
+		},
+	}
+}
+
+// newService authorizes the caller to manage API keys and returns the config,
+// the service and the function closing its db.
+func newService(cmd *cobra.Command, token string) (*config.Config, *apikeys.Service, func()) {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("failed to load config %v", err)
+	}
+	if err := authz.AuthorizeCommand(context.Background(), cfg.Auth, token, authz.Admin, cmd.CommandPath()); err != nil {
+		log.Fatalf("not allowed to manage API keys: %v", err)
+	}
+
+	db := db.NewDB(cfg.Postgres)
+	return cfg, apikeys.NewService(orm.NewAPIKeyRepository(db)), func() {
+		db.Close()
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
@@ -4,17 +4,24 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 
 	"music-service/gen/pb"
+	"music-service/internal/apikeys"
 	"music-service/internal/config"
+	"music-service/pkg/auth"
 )
 
 func NewGrpcClientCommand() *cobra.Command {
-	return &cobra.Command{
+	var apiKey string
+
+	cmd := &cobra.Command{
 		Use:   "grpc-client",
 		Short: "shows the albums returned from the MusicService gRPC server",
 		Long:  `calls the MusicService gRPC server and shows the albums returned`,
@@ -35,8 +42,13 @@ func NewGrpcClientCommand() *cobra.Command {
 			}
 			defer conn.Close()
 
+			ctx := context.Background()
+			if apiKey != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, strings.ToLower(auth.APIKeyHeader), apiKey)
+			}
+
 			client := pb.NewMusicServiceClient(conn)
-			getAlbumsResponse, err := client.GetAlbumList(context.Background(), &pb.GetAlbumsRequest{})
+			getAlbumsResponse, err := client.GetAlbumList(ctx, &pb.GetAlbumsRequest{})
 			if err != nil {
 				log.Fatalf("failed to get album list: %v", err)
 			}
@@ -46,4 +58,6 @@ func NewGrpcClientCommand() *cobra.Command {
 			}
 		},
 	}
+	cmd.Flags().StringVar(&apiKey, "api-key", os.Getenv(apikeys.KeyEnv), "API key sent in the x-api-key metadata, defaults to $"+apikeys.KeyEnv)
+	return cmd
 }
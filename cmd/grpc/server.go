@@ -1,6 +1,7 @@
 package grpc
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
@@ -10,9 +11,21 @@ import (
 	"google.golang.org/grpc/reflection"
 
 	"music-service/gen/pb"
+	"music-service/internal/apikeys"
+	"music-service/internal/authz"
 	"music-service/internal/config"
 	handler "music-service/internal/handler/grpc"
+	"music-service/internal/handler/kafka/confluent/producer"
+	memory_producer "music-service/internal/handler/kafka/memory/producer"
+	"music-service/internal/orders"
+	"music-service/internal/playlists"
+	"music-service/internal/repository/postgres/orm"
 	"music-service/internal/repository/postgres/sqlx"
+	"music-service/pkg/auth"
+	"music-service/pkg/kafka"
+	"music-service/pkg/kafka/memory"
+	"music-service/pkg/payment"
+	orm_db "music-service/pkg/postgres/orm/db"
 	"music-service/pkg/postgres/sqlx/db"
 )
 
@@ -20,7 +33,7 @@ func NewGrpcServerCommand() *cobra.Command {
 	return &cobra.Command{
 		Use:   "grpc-server",
 		Short: "starts the gRPC server",
-		Long:  `starts the gRPC server which hosts MusicService which returns albums`,
+		Long:  `starts the gRPC server which hosts MusicService which returns albums, ArtistService which manages artists, InventoryService which reserves stock, OrderService which checks out carts into orders, UserService which keeps reviews and wishlists and PlaylistService which keeps playlists`,
 		Run: func(cmd *cobra.Command, args []string) {
 			cfg, err := config.Load()
 			if err != nil {
@@ -34,7 +47,21 @@ func NewGrpcServerCommand() *cobra.Command {
 				panic(err)
 			}
 
-			s := grpc.NewServer()
+			ormDB := orm_db.NewDB(cfg.Postgres)
+			defer ormDB.Close()
+
+			var verifier *auth.Verifier
+			if cfg.Auth.Enabled {
+				if verifier, err = auth.NewVerifier(context.Background(), cfg.Auth); err != nil {
+					log.Fatalf("failed to create token verifier: %v", err)
+				}
+			}
+			authorizer, err := authz.New(cfg.Auth)
+			if err != nil {
+				log.Fatalf("failed to load policy: %v", err)
+			}
+			keys := apikeys.NewAuthenticator(cfg.Auth, ormDB)
+			s := grpc.NewServer(authz.ServerOptions(verifier, keys, authorizer, cfg.Auth.Methods())...)
 			reflection.Register(s)
 
 			db, err := db.NewDB(cfg.Postgres)
@@ -45,9 +72,36 @@ func NewGrpcServerCommand() *cobra.Command {
 			defer db.Close()
 
 			repository := sqlx.NewRepository(db)
-			handler := handler.NewAlbumHandler(repository)
+			pb.RegisterMusicServiceServer(s, handler.NewAlbumHandler(repository))
+
+			pb.RegisterArtistServiceServer(s, handler.NewArtistHandler(orm.NewArtistRepository(ormDB)))
+			pb.RegisterLabelServiceServer(s, handler.NewLabelHandler(orm.NewLabelRepository(ormDB)))
+
+			// InventoryService publishes low-stock events, OrderService order
+			// events as well.
+			var producerHandler kafka.ProducerHandler
+			if cfg.Kafka.Driver == kafka.DriverMemory {
+				producerHandler, err = memory_producer.NewProducerHandler(cfg.Kafka, memory.DefaultBroker())
+			} else {
+				producerHandler, err = producer.NewProducerHandler(cfg.Kafka)
+			}
+			if err != nil {
+				log.Fatalf("failed to create Kafka producer: %v", err)
+			}
+			inventoryRepository := orm.NewInventoryRepository(ormDB)
+			pb.RegisterInventoryServiceServer(s, handler.NewInventoryHandler(inventoryRepository, producerHandler))
+
+			paymentProvider, err := payment.NewProvider(cfg.Payment)
+			if err != nil {
+				log.Fatalf("failed to create payment provider: %v", err)
+			}
+			orderRepository := orm.NewOrderRepository(ormDB)
+			orderService := orders.NewService(orderRepository, inventoryRepository, paymentProvider, producerHandler)
+			pb.RegisterOrderServiceServer(s, handler.NewOrderHandler(orderRepository, orderService))
+			pb.RegisterUserServiceServer(s, handler.NewUserHandler(orm.NewUserRepository(ormDB), orm.NewReviewRepository(ormDB), orm.NewWishlistRepository(ormDB)))
+			playlistService := playlists.NewService(orm.NewPlaylistRepository(ormDB))
+			pb.RegisterPlaylistServiceServer(s, handler.NewPlaylistHandler(playlistService, "http://"+cfg.Rest.ServerUrl))
 
-			pb.RegisterMusicServiceServer(s, handler)
 			if err := s.Serve(listener); err != nil {
 				log.Fatalf("failed to serve: %v", err)
 			}
@@ -0,0 +1,46 @@
+package inventory
+
+import (
+	"context"
+	"log"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"music-service/internal/config"
+	"music-service/internal/inventory"
+	"music-service/internal/repository/postgres/orm"
+	"music-service/pkg/postgres/orm/db"
+)
+
+func NewInventoryExpirerCommand() *cobra.Command {
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "inventory-expirer",
+		Short: "expires stock reservations once they are due",
+		Long:  `expires the pending reservations made on /api/v1/inventory/reservations and InventoryService every interval, returning their units to the available stock; requires sql/ddl/create_table_inventory.sql`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+
+			cfg, err := config.Load()
+			if err != nil {
+				log.Fatalf("failed to load config %v", err)
+			}
+			if interval <= 0 {
+				log.Fatalf("invalid --interval %s", interval)
+			}
+
+			db := db.NewDB(cfg.Postgres)
+			defer db.Close()
+
+			log.Printf("expiring reservations every %s", interval)
+			inventory.NewExpirer(orm.NewInventoryRepository(db), interval).Run(ctx)
+		},
+	}
+	cmd.Flags().DurationVar(&interval, "interval", 30*time.Second, "how often to expire the due reservations")
+	return cmd
+}
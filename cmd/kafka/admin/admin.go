@@ -0,0 +1,248 @@
+package admin
+
+import (
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"music-service/internal/config"
+	"music-service/pkg/kafka"
+	"music-service/pkg/kafka/sarama"
+)
+
+func NewKafkaAdminCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "kafka-admin",
+		Short: "manages kafka topics and consumer groups",
+		Long:  `creates, describes and deletes topics and lists and resets consumer groups of the configured kafka cluster`,
+	}
+
+	cmd.AddCommand(newCreateTopicCommand())
+	cmd.AddCommand(newDescribeTopicsCommand())
+	cmd.AddCommand(newDeleteTopicCommand())
+	cmd.AddCommand(newEnsureTopicsCommand())
+	cmd.AddCommand(newListGroupsCommand())
+	cmd.AddCommand(newResetOffsetsCommand())
+	return cmd
+}
+
+func newCreateTopicCommand() *cobra.Command {
+	var partitions int32
+	var replicationFactor int16
+	var topicConfig []string
+
+	cmd := &cobra.Command{
+		Use:   "create-topic <topic>",
+		Short: "creates a topic",
+		Long:  `creates a topic with the spec from kafka.topic_specs, overridden by the flags`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, admin := newAdmin()
+			defer admin.Close()
+
+			spec := cfg.TopicSpec(args[0])
+			if cmd.Flags().Changed("partitions") {
+				spec.Partitions = partitions
+			}
+			if cmd.Flags().Changed("replication-factor") {
+				spec.ReplicationFactor = replicationFactor
+			}
+			for _, entry := range topicConfig {
+				name, value, ok := strings.Cut(entry, "=")
+				if !ok {
+					log.Fatalf("invalid topic config %q, expected name=value", entry)
+				}
+				if spec.Config == nil {
+					spec.Config = make(map[string]string)
+				}
+				spec.Config[name] = value
+			}
+
+			if err := admin.CreateTopic(spec); err != nil {
+				log.Fatalf("failed to create topic %s: %v", spec.Name, err)
+			}
+			log.Printf("created topic %s", spec.Name)
+		},
+	}
+
+	cmd.Flags().Int32Var(&partitions, "partitions", 0, "number of partitions, 0 for the broker default")
+	cmd.Flags().Int16Var(&replicationFactor, "replication-factor", 0, "replication factor, 0 for the broker default")
+	cmd.Flags().StringArrayVar(&topicConfig, "config", nil, "topic config as name=value, e.g. cleanup.policy=compact")
+	return cmd
+}
+
+func newDescribeTopicsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "describe-topics [topic...]",
+		Short: "describes topics",
+		Long:  `shows the partitions, replication factor and config of the given topics, or of the configured topics`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, admin := newAdmin()
+			defer admin.Close()
+
+			names := args
+			if len(names) == 0 {
+				for _, spec := range cfg.EnsuredTopics() {
+					names = append(names, spec.Name)
+				}
+			}
+
+			descriptions, err := admin.DescribeTopics(names)
+			if err != nil {
+				log.Fatalf("failed to describe topics: %v", err)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "TOPIC\tPARTITIONS\tREPLICATION\tCONFIG")
+			for _, d := range descriptions {
+				config := []string{}
+				for _, name := range slices.Sorted(maps.Keys(d.Config)) {
+					config = append(config, name+"="+d.Config[name])
+				}
+				fmt.Fprintf(w, "%s\t%d\t%d\t%s\n", d.Name, d.Partitions, d.ReplicationFactor, strings.Join(config, ","))
+			}
+			w.Flush()
+		},
+	}
+}
+
+func newDeleteTopicCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete-topic <topic>",
+		Short: "deletes a topic",
+		Long:  `deletes a topic and all of its messages`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			_, admin := newAdmin()
+			defer admin.Close()
+
+			if err := admin.DeleteTopic(args[0]); err != nil {
+				log.Fatalf("failed to delete topic %s: %v", args[0], err)
+			}
+			log.Printf("deleted topic %s", args[0])
+		},
+	}
+}
+
+func newEnsureTopicsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "ensure-topics",
+		Short: "creates the missing configured topics",
+		Long:  `creates the topics of kafka.topics and kafka.topic_specs that do not exist yet`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, admin := newAdmin()
+			defer admin.Close()
+
+			created, err := admin.EnsureTopics(cfg.EnsuredTopics())
+			if err != nil {
+				log.Fatalf("failed to ensure topics: %v", err)
+			}
+			log.Printf("created topics: %v", created)
+		},
+	}
+}
+
+func newListGroupsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list-groups",
+		Short: "lists consumer groups with their lag",
+		Long:  `shows every consumer group with the committed offset and lag of each of its partitions`,
+		Run: func(cmd *cobra.Command, args []string) {
+			_, admin := newAdmin()
+			defer admin.Close()
+
+			groups, err := admin.ListGroups()
+			if err != nil {
+				log.Fatalf("failed to list consumer groups: %v", err)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "GROUP\tSTATE\tMEMBERS\tTOPIC\tPARTITION\tOFFSET\tHIGH WATERMARK\tLAG")
+			for _, g := range groups {
+				fmt.Fprintf(w, "%s\t%s\t%d\t\t\t\t\t%d\n", g.Group, g.State, g.Members, g.Lag())
+				for _, p := range g.Partitions {
+					fmt.Fprintf(w, "\t\t\t%s\t%d\t%d\t%d\t%d\n", p.Topic, p.Partition, p.Offset, p.HighWatermark, p.Lag)
+				}
+			}
+			w.Flush()
+		},
+	}
+}
+
+func newResetOffsetsCommand() *cobra.Command {
+	var group, topic, timestamp string
+	var partitions []int32
+	var offset int64
+	var earliest, latest bool
+
+	cmd := &cobra.Command{
+		Use:   "reset-offsets",
+		Short: "resets the offsets of an inactive consumer group",
+		Long:  `commits new offsets for the partitions of a consumer group without active members; running consumers are reset through the consumer control API`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, admin := newAdmin()
+			defer admin.Close()
+
+			if group == "" {
+				group = cfg.ConsumerGroup
+			}
+
+			reset := kafka.OffsetReset{Topic: topic, Partitions: partitions, Offset: offset}
+			switch {
+			case earliest:
+				reset.Offset = -2
+			case latest:
+				reset.Offset = -1
+			case timestamp != "":
+				t, err := time.Parse(time.RFC3339, timestamp)
+				if err != nil {
+					log.Fatalf("invalid timestamp %q: %v", timestamp, err)
+				}
+				reset.Timestamp = t
+			case !cmd.Flags().Changed("offset"):
+				log.Fatalf("one of --offset, --to-earliest, --to-latest or --timestamp is required")
+			}
+
+			statuses, err := admin.ResetGroupOffsets(group, reset)
+			if err != nil {
+				log.Fatalf("failed to reset offsets of group %s: %v", group, err)
+			}
+			for _, s := range statuses {
+				log.Printf("%s[%d] reset to %d, lag %d", s.Topic, s.Partition, s.Offset, s.Lag)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&group, "group", "", "consumer group, defaults to kafka.consumer_group")
+	cmd.Flags().StringVar(&topic, "topic", "", "topic, defaults to every topic the group has committed")
+	cmd.Flags().Int32SliceVar(&partitions, "partitions", nil, "partitions, defaults to every partition of the topic")
+	cmd.Flags().Int64Var(&offset, "offset", 0, "offset to reset to")
+	cmd.Flags().BoolVar(&earliest, "to-earliest", false, "reset to the beginning of the partitions")
+	cmd.Flags().BoolVar(&latest, "to-latest", false, "reset to the end of the partitions")
+	cmd.Flags().StringVar(&timestamp, "timestamp", "", "reset to the first offset at or after an RFC 3339 timestamp")
+	cmd.MarkFlagsMutuallyExclusive("offset", "to-earliest", "to-latest", "timestamp")
+	return cmd
+}
+
+func newAdmin() (kafka.Config, *sarama.Admin) {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("failed to load config %v", err)
+	}
+	if cfg.Kafka.Driver == kafka.DriverMemory {
+		log.Fatalf("the memory driver has no cluster to administer")
+	}
+
+	admin, err := sarama.NewAdmin(cfg.Kafka)
+	if err != nil {
+		log.Fatalf("failed to create kafka admin: %v", err)
+	}
+	return cfg.Kafka, admin
+}
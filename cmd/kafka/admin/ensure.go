@@ -0,0 +1,43 @@
+package admin
+
+import (
+	"log"
+
+	"music-service/pkg/kafka"
+	"music-service/pkg/kafka/memory"
+	"music-service/pkg/kafka/sarama"
+)
+
+// EnsureTopics creates the missing configured topics when kafka.ensure_topics
+// is set. With the memory driver they are created in the in-process broker.
+func EnsureTopics(cfg kafka.Config) {
+	if !cfg.EnsureTopics {
+		return
+	}
+
+	if cfg.Driver == kafka.DriverMemory {
+		broker := memory.DefaultBroker()
+		for _, spec := range cfg.EnsuredTopics() {
+			partitions := int(spec.Partitions)
+			if partitions <= 0 {
+				partitions = memory.DefaultPartitions
+			}
+			broker.CreateTopic(spec.Name, partitions)
+		}
+		return
+	}
+
+	admin, err := sarama.NewAdmin(cfg)
+	if err != nil {
+		log.Panicf("error creating kafka admin: %v", err)
+	}
+	defer admin.Close()
+
+	created, err := admin.EnsureTopics(cfg.EnsuredTopics())
+	if err != nil {
+		log.Panicf("error ensuring topics: %v", err)
+	}
+	if len(created) > 0 {
+		log.Printf("created topics: %v", created)
+	}
+}
@@ -0,0 +1,83 @@
+package cdc
+
+import (
+	"context"
+	"log"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/spf13/cobra"
+
+	"music-service/cmd/kafka/admin"
+	"music-service/internal/cdc"
+	"music-service/internal/config"
+	"music-service/internal/handler/kafka/sarama/producer"
+	"music-service/internal/repository/postgres/orm"
+	"music-service/pkg/kafka"
+	"music-service/pkg/postgres/orm/db"
+)
+
+// pingInterval is how often an idle listener checks its connection, so that
+// a lost connection is noticed and changes missed meanwhile are republished.
+const pingInterval = 90 * time.Second
+
+func NewKafkaCdcCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "kafka-cdc",
+		Short: "publishes the albums in postgres to the compacted snapshot topic",
+		Long:  `publishes every album and then every change to music.albums, including writes that bypass kafka, to kafka.snapshot_topic keyed by id with tombstones for deleted albums; requires the triggers of sql/ddl/create_trigger_albums_changes.sql`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+
+			cfg, err := config.Load()
+			if err != nil {
+				log.Fatalf("failed to load config %v", err)
+			}
+			if cfg.Kafka.Driver == kafka.DriverMemory {
+				log.Fatalf("the memory driver cannot be shared with downstream services")
+			}
+			admin.EnsureTopics(cfg.Kafka)
+
+			snapshotProducer, err := producer.NewSnapshotProducer(cfg.Kafka)
+			if err != nil {
+				log.Fatalf("error creating snapshot producer: %v", err)
+			}
+			defer snapshotProducer.Close()
+
+			db := db.NewDB(cfg.Postgres)
+			defer db.Close()
+
+			listener := pq.NewListener(cfg.Postgres.DataSourceName(), time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+				if err != nil {
+					log.Printf("postgres listener: %v", err)
+				}
+			})
+			defer listener.Close()
+			if err := listener.Listen(cdc.Channel); err != nil {
+				log.Fatalf("failed to listen on %s: %v", cdc.Channel, err)
+			}
+
+			go func() {
+				ticker := time.NewTicker(pingInterval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ticker.C:
+						listener.Ping()
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+
+			publisher := cdc.NewPublisher(orm.NewRepository(db), orm.NewTrackRepository(db), snapshotProducer)
+			log.Printf("publishing changes to %s", cfg.Kafka.SnapshotTopic)
+			if err := publisher.Run(ctx, listener.Notify); err != nil {
+				log.Fatalf("failed to publish album changes: %v", err)
+			}
+		},
+	}
+}
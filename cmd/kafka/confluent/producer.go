@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 
+	"music-service/cmd/kafka/admin"
 	"music-service/gen/pb"
 	"music-service/internal/config"
 	"music-service/internal/handler/kafka/confluent/producer"
@@ -25,6 +26,7 @@ func NewKafkaProducerCommand() *cobra.Command {
 			if err != nil {
 				log.Panicf("failed to load config %v", err)
 			}
+			admin.EnsureTopics(cfg.Kafka)
 
 			producerHandler, err := producer.NewProducerHandler(cfg.Kafka)
 			if err != nil {
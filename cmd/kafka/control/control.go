@@ -0,0 +1,73 @@
+package control
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	"music-service/gen/pb"
+	"music-service/internal/authz"
+	handler "music-service/internal/handler/grpc"
+	"music-service/internal/routes/admin"
+	"music-service/pkg/auth"
+	"music-service/pkg/kafka"
+	"music-service/pkg/kafka/metrics"
+	"music-service/pkg/rest"
+)
+
+// Serve starts the consumer control API in the background on the configured
+// REST and gRPC addresses, so a running consumer can be paused, resumed and
+// inspected without signals. The REST server also exposes the consumer
+// metrics on /metrics. When authentication is enabled only admins may use the
+// control API, with a token or an API key, the metrics stay public.
+func Serve(cfg kafka.ControlConfig, controller kafka.ConsumerController, authCfg auth.Config, keys auth.KeyAuthenticator) {
+	var verifier *auth.Verifier
+	if authCfg.Enabled {
+		var err error
+		if verifier, err = auth.NewVerifier(context.Background(), authCfg); err != nil {
+			log.Panicf("failed to create token verifier for consumer control: %v", err)
+		}
+	}
+	authorizer, err := authz.New(authCfg)
+	if err != nil {
+		log.Panicf("failed to load policy for consumer control: %v", err)
+	}
+
+	if cfg.HttpUrl != "" {
+		prometheus.MustRegister(metrics.NewLagCollector(controller))
+
+		app := fiber.New(fiber.Config{DisableStartupMessage: true})
+		if verifier != nil {
+			app.Use(auth.NewMiddleware(verifier, keys, []string{"/metrics"}))
+		}
+		admin.RegisterConsumerRoutes(app.Group("/admin", authz.Require(authorizer, authz.Admin)), controller)
+		app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
+
+		go rest.StartServer(app, rest.Config{ServerUrl: cfg.HttpUrl})
+	}
+
+	if cfg.GrpcPort != "" {
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.GrpcPort))
+		if err != nil {
+			log.Panicf("failed to listen for consumer control: %v", err)
+		}
+
+		s := grpc.NewServer(authz.ServerOptions(verifier, keys, authorizer, authCfg.Methods())...)
+		reflection.Register(s)
+		pb.RegisterConsumerAdminServiceServer(s, handler.NewConsumerAdminHandler(controller))
+
+		go func() {
+			if err := s.Serve(listener); err != nil {
+				log.Printf("consumer control server stopped: %v", err)
+			}
+		}()
+	}
+}
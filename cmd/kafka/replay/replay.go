@@ -0,0 +1,140 @@
+package replay
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"music-service/internal/authz"
+	"music-service/internal/config"
+	"music-service/internal/handler/kafka/message"
+	"music-service/internal/replay"
+	"music-service/internal/repository/postgres/orm"
+	"music-service/pkg/kafka"
+	"music-service/pkg/kafka/codec"
+	"music-service/pkg/kafka/sarama"
+	"music-service/pkg/postgres/orm/db"
+)
+
+func NewKafkaReplayCommand() *cobra.Command {
+	var topic, table, fromTime, toTime string
+	var partitions []int32
+	var from, to int64
+	var replace bool
+	var maxDiffs int
+	var token string
+
+	cmd := &cobra.Command{
+		Use:   "kafka-replay",
+		Short: "rebuilds the albums from the album topic into a shadow table",
+		Long:  `replays the album topic into a fresh table without joining the consumer group and reports how the rebuilt albums differ from music.albums; when auth is enabled the --token must identify an admin`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+
+			cfg, err := config.Load()
+			if err != nil {
+				log.Fatalf("failed to load config %v", err)
+			}
+			if err := authz.AuthorizeCommand(ctx, cfg.Auth, token, authz.Admin, cmd.Name()); err != nil {
+				log.Fatalf("not allowed to replay albums: %v", err)
+			}
+			if cfg.Kafka.Driver == kafka.DriverMemory {
+				log.Fatalf("the memory driver keeps no event log to replay")
+			}
+
+			if topic == "" {
+				topic, _, _ = strings.Cut(cfg.Kafka.Topics, ",")
+			}
+			rng := kafka.ReplayRange{Partitions: partitions, From: from, To: to}
+			if rng.FromTime, err = parseTime(fromTime); err != nil {
+				log.Fatalf("invalid --from-time: %v", err)
+			}
+			if rng.ToTime, err = parseTime(toTime); err != nil {
+				log.Fatalf("invalid --to-time: %v", err)
+			}
+
+			db := db.NewDB(cfg.Postgres)
+			defer db.Close()
+
+			if err := orm.CreateShadowTable(db, table, replace); err != nil {
+				log.Fatalf("failed to create %s: %v", table, err)
+			}
+			shadow := orm.NewTableRepository(db, table)
+
+			decoder, err := codec.NewDecoder(cfg.Kafka)
+			if err != nil {
+				log.Fatalf("failed to create decoder: %v", err)
+			}
+			// The shadow table only holds albums, so track changes are not replayed.
+			processor := message.NewMessageValueProcessor(shadow, nil, nil, decoder)
+
+			result, err := sarama.Replay(ctx, cfg.Kafka, topic, rng, processor)
+			for _, p := range result.Partitions {
+				log.Printf("replayed %s[%d] offsets %d-%d: %d processed, %d skipped", p.Topic, p.Partition, p.From, p.To, p.Processed, p.Skipped)
+			}
+			if err != nil {
+				log.Fatalf("failed to replay %s: %v", topic, err)
+			}
+			log.Printf("replayed %d messages into %s, skipped %d", result.Processed(), table, result.Skipped())
+
+			live, err := orm.NewRepository(db).Get()
+			if err != nil {
+				log.Fatalf("failed to read %s: %v", orm.LiveTable, err)
+			}
+			replayed, err := shadow.Get()
+			if err != nil {
+				log.Fatalf("failed to read %s: %v", table, err)
+			}
+
+			report := replay.Diff(live, replayed)
+			log.Printf(
+				"%s has %d albums, %s has %d: %d missing, %d extra, %d changed",
+				orm.LiveTable, report.Live, table, report.Replayed, len(report.Missing), len(report.Extra), len(report.Changed),
+			)
+			if len(report.Missing) > 0 {
+				log.Printf("missing from the replay: %v", truncate(report.Missing, maxDiffs))
+			}
+			if len(report.Extra) > 0 {
+				log.Printf("only in the replay: %v", truncate(report.Extra, maxDiffs))
+			}
+			for _, change := range truncate(report.Changed, maxDiffs) {
+				log.Printf("album %d differs: live %s, replayed %s", change.Id, change.Live.String(), change.Replayed.String())
+			}
+			if !report.Consistent() {
+				log.Fatalf("the event log and %s disagree", orm.LiveTable)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&topic, "topic", "", "topic to replay, defaults to the first of kafka.topics")
+	cmd.Flags().Int32SliceVar(&partitions, "partitions", nil, "partitions to replay, defaults to every partition")
+	cmd.Flags().Int64Var(&from, "from", 0, "first offset to replay, defaults to the beginning of each partition")
+	cmd.Flags().StringVar(&fromTime, "from-time", "", "replay from the first message at or after an RFC 3339 timestamp")
+	cmd.Flags().Int64Var(&to, "to", 0, "offset to stop before, defaults to the end of each partition")
+	cmd.Flags().StringVar(&toTime, "to-time", "", "stop before the first message at or after an RFC 3339 timestamp")
+	cmd.Flags().StringVar(&table, "table", "replay.albums", "schema-qualified table to rebuild the albums into, outside the music schema")
+	cmd.Flags().BoolVar(&replace, "replace", false, "drop the table first when it exists")
+	cmd.Flags().IntVar(&maxDiffs, "max-diffs", 20, "maximum number of differences to show per kind")
+	cmd.Flags().StringVar(&token, "token", os.Getenv(authz.TokenEnv), "bearer token of the caller, defaults to $"+authz.TokenEnv)
+	cmd.MarkFlagsMutuallyExclusive("from", "from-time")
+	cmd.MarkFlagsMutuallyExclusive("to", "to-time")
+	return cmd
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+func truncate[T any](values []T, n int) []T {
+	return values[:min(len(values), max(n, 0))]
+}
@@ -6,9 +6,13 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"music-service/cmd/kafka/admin"
+	"music-service/cmd/kafka/control"
+	"music-service/internal/apikeys"
 	"music-service/internal/config"
 	"music-service/internal/handler/kafka/sarama/consumer"
 	"music-service/internal/repository/postgres/orm"
+	"music-service/pkg/kafka"
 	"music-service/pkg/postgres/orm/db"
 )
 
@@ -24,17 +28,23 @@ func NewKafkaConsumerCommand() *cobra.Command {
 			if err != nil {
 				log.Panicf("failed to load config %v", err)
 			}
+			if cfg.Kafka.Driver == kafka.DriverMemory {
+				log.Panicf("the memory driver runs its consumer inside rest-server")
+			}
+			admin.EnsureTopics(cfg.Kafka)
 
 			db := db.NewDB(cfg.Postgres)
 			defer db.Close()
 
 			repository := orm.NewRepository(db)
 
-			handler, err := consumer.NewConsumerHandler(cfg.Kafka, repository)
+			handler, err := consumer.NewConsumerHandler(cfg.Kafka, repository, orm.NewPriceRepository(db), orm.NewTrackRepository(db))
 			if err != nil {
 				log.Panicf("error creating consumer handler: %v", err)
 			}
 
+			control.Serve(cfg.Kafka.Control, handler, cfg.Auth, apikeys.NewAuthenticator(cfg.Auth, db))
+
 			handler.Consume(ctx)
 		},
 	}
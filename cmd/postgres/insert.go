@@ -1,13 +1,16 @@
 package postgres
 
 import (
+	"context"
 	"log"
 	"math/rand/v2"
+	"os"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/spf13/cobra"
 
+	"music-service/internal/authz"
 	"music-service/internal/config"
 	"music-service/internal/models"
 	"music-service/internal/repository/postgres/orm"
@@ -15,15 +18,20 @@ import (
 )
 
 func NewPostgresInsertCommand() *cobra.Command {
-	return &cobra.Command{
+	var token string
+
+	cmd := &cobra.Command{
 		Use:   "postgres-insert",
 		Short: "inserts a new album",
-		Long:  `inserts a new album into the postgres db directly`,
+		Long:  `inserts a new album into the postgres db directly; when auth is enabled the --token must identify an editor`,
 		Run: func(cmd *cobra.Command, args []string) {
 			cfg, err := config.Load()
 			if err != nil {
 				log.Fatalf("failed to load config %v", err)
 			}
+			if err := authz.AuthorizeCommand(context.Background(), cfg.Auth, token, authz.CatalogWrite, cmd.Name()); err != nil {
+				log.Fatalf("not allowed to insert albums: %v", err)
+			}
 
 			db := db.NewDB(cfg.Postgres)
 			defer db.Close()
@@ -41,4 +49,6 @@ func NewPostgresInsertCommand() *cobra.Command {
 			log.Println(album)
 		},
 	}
+	cmd.Flags().StringVar(&token, "token", os.Getenv(authz.TokenEnv), "bearer token of the caller, defaults to $"+authz.TokenEnv)
+	return cmd
 }
@@ -0,0 +1,46 @@
+package pricing
+
+import (
+	"context"
+	"log"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"music-service/internal/config"
+	"music-service/internal/pricing"
+	"music-service/internal/repository/postgres/orm"
+	"music-service/pkg/postgres/orm/db"
+)
+
+func NewPriceSchedulerCommand() *cobra.Command {
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "price-scheduler",
+		Short: "applies scheduled price changes once they are due",
+		Long:  `applies the price changes scheduled on /api/v1/albums/:id/prices every interval, recording them in music.price_history with the time they were scheduled to take effect; requires sql/ddl/create_function_prices.sql`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+
+			cfg, err := config.Load()
+			if err != nil {
+				log.Fatalf("failed to load config %v", err)
+			}
+			if interval <= 0 {
+				log.Fatalf("invalid --interval %s", interval)
+			}
+
+			db := db.NewDB(cfg.Postgres)
+			defer db.Close()
+
+			log.Printf("applying scheduled prices every %s", interval)
+			pricing.NewScheduler(orm.NewPriceRepository(db), interval).Run(ctx)
+		},
+	}
+	cmd.Flags().DurationVar(&interval, "interval", time.Minute, "how often to apply the due price changes")
+	return cmd
+}
@@ -6,16 +6,21 @@ import (
 	"log"
 	"math/rand/v2"
 	"net/http"
+	"os"
 
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 
 	"music-service/gen/pb"
+	"music-service/internal/apikeys"
 	"music-service/internal/config"
+	"music-service/pkg/auth"
 )
 
 func NewRestClientMultiCommand() *cobra.Command {
-	return &cobra.Command{
+	var apiKey string
+
+	cmd := &cobra.Command{
 		Use:   "rest-client-multi",
 		Short: "sends requests to the MusicService REST server",
 		Long:  `calls the MusicService REST server to create multiple albums and shows the response`,
@@ -27,13 +32,15 @@ func NewRestClientMultiCommand() *cobra.Command {
 
 			url := "http://" + cfg.Rest.ServerUrl + "/api/v1/albums"
 
-			sendAlbumsRequest("POST", url)
-			sendAlbumsRequest("PUT", url)
+			sendAlbumsRequest("POST", url, apiKey)
+			sendAlbumsRequest("PUT", url, apiKey)
 		},
 	}
+	cmd.Flags().StringVar(&apiKey, "api-key", os.Getenv(apikeys.KeyEnv), "API key sent in the X-API-Key header, defaults to $"+apikeys.KeyEnv)
+	return cmd
 }
 
-func sendAlbumsRequest(method string, url string) {
+func sendAlbumsRequest(method string, url string, apiKey string) {
 	albums := []*pb.Album{}
 
 	for i := 0; i < 10; i++ {
@@ -56,6 +63,9 @@ func sendAlbumsRequest(method string, url string) {
 		log.Fatalf("failed to %s albums %v", method, err)
 	}
 	request.Header.Set("Content-Type", "application/json")
+	if apiKey != "" {
+		request.Header.Set(auth.APIKeyHeader, apiKey)
+	}
 
 	client := &http.Client{}
 	response, err := client.Do(request)
@@ -6,16 +6,21 @@ import (
 	"log"
 	"math/rand/v2"
 	"net/http"
+	"os"
 
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 
 	"music-service/gen/pb"
+	"music-service/internal/apikeys"
 	"music-service/internal/config"
+	"music-service/pkg/auth"
 )
 
 func NewRestClientSingleCommand() *cobra.Command {
-	return &cobra.Command{
+	var apiKey string
+
+	cmd := &cobra.Command{
 		Use:   "rest-client-single",
 		Short: "sends requests to the MusicService REST server",
 		Long:  `calls the MusicService REST server to create an album and shows the response`,
@@ -27,13 +32,15 @@ func NewRestClientSingleCommand() *cobra.Command {
 
 			url := "http://" + cfg.Rest.ServerUrl + "/api/v1/album"
 
-			sendAlbumRequest("POST", url)
-			sendAlbumRequest("PUT", url)
+			sendAlbumRequest("POST", url, apiKey)
+			sendAlbumRequest("PUT", url, apiKey)
 		},
 	}
+	cmd.Flags().StringVar(&apiKey, "api-key", os.Getenv(apikeys.KeyEnv), "API key sent in the X-API-Key header, defaults to $"+apikeys.KeyEnv)
+	return cmd
 }
 
-func sendAlbumRequest(method string, url string) {
+func sendAlbumRequest(method string, url string, apiKey string) {
 	album := &pb.Album{
 		Id:     rand.Int32(),
 		Title:  uuid.NewString(),
@@ -50,6 +57,9 @@ func sendAlbumRequest(method string, url string) {
 		log.Fatalf("failed to %s album %v", method, err)
 	}
 	request.Header.Set("Content-Type", "application/json")
+	if apiKey != "" {
+		request.Header.Set(auth.APIKeyHeader, apiKey)
+	}
 
 	client := &http.Client{}
 	response, err := client.Do(request)
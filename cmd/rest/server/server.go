@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"log"
 	"time"
 
@@ -8,11 +9,23 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/spf13/cobra"
 
+	"music-service/cmd/kafka/admin"
+	"music-service/cmd/kafka/control"
+	"music-service/internal/apikeys"
+	"music-service/internal/authz"
 	"music-service/internal/config"
 	"music-service/internal/handler/kafka/confluent/producer"
+	memory_consumer "music-service/internal/handler/kafka/memory/consumer"
+	memory_producer "music-service/internal/handler/kafka/memory/producer"
+	"music-service/internal/orders"
+	"music-service/internal/playlists"
 	"music-service/internal/repository/postgres/orm"
 	"music-service/internal/routes"
 	v1 "music-service/internal/routes/v1"
+	"music-service/pkg/auth"
+	"music-service/pkg/kafka"
+	"music-service/pkg/kafka/memory"
+	"music-service/pkg/payment"
 	"music-service/pkg/postgres/orm/db"
 	"music-service/pkg/rest"
 )
@@ -40,17 +53,50 @@ func NewRestServerCommand() *cobra.Command {
 				WriteTimeout: time.Duration(cfg.Rest.WriteTimeout) * time.Second,
 			}
 
-			producerHandler, err := producer.NewProducerHandler(cfg.Kafka)
-			if err != nil {
-				log.Panicf("Error creating Kafka producer: %v", err)
-			}
-
 			db := db.NewDB(cfg.Postgres)
 			defer db.Close()
 			repository := orm.NewRepository(db)
+			trackRepository := orm.NewTrackRepository(db)
+			priceRepository := orm.NewPriceRepository(db)
+
+			admin.EnsureTopics(cfg.Kafka)
+
+			var producerHandler kafka.ProducerHandler
+			if cfg.Kafka.Driver == kafka.DriverMemory {
+				// The in-memory broker only lives in this process, so the
+				// consumer has to run alongside the producer.
+				broker := memory.DefaultBroker()
+				producerHandler, err = memory_producer.NewProducerHandler(cfg.Kafka, broker)
+				if err != nil {
+					log.Panicf("Error creating Kafka producer: %v", err)
+				}
+
+				consumerHandler, err := memory_consumer.NewConsumerHandler(cfg.Kafka, broker, repository, priceRepository, trackRepository)
+				if err != nil {
+					log.Panicf("Error creating Kafka consumer: %v", err)
+				}
+				control.Serve(cfg.Kafka.Control, consumerHandler, cfg.Auth, apikeys.NewAuthenticator(cfg.Auth, db))
+				go consumerHandler.Consume(context.Background())
+			} else {
+				producerHandler, err = producer.NewProducerHandler(cfg.Kafka)
+				if err != nil {
+					log.Panicf("Error creating Kafka producer: %v", err)
+				}
+			}
 
 			app := fiber.New(fiberCfg)
 			app.Use(cors.New())
+			if cfg.Auth.Enabled {
+				verifier, err := auth.NewVerifier(context.Background(), cfg.Auth)
+				if err != nil {
+					log.Panicf("Error creating token verifier: %v", err)
+				}
+				app.Use(auth.NewMiddleware(verifier, apikeys.NewAuthenticator(cfg.Auth, db), cfg.Auth.Routes()))
+			}
+			authorizer, err := authz.New(cfg.Auth)
+			if err != nil {
+				log.Panicf("Error loading policy: %v", err)
+			}
 
 			app.Get("/", func(c *fiber.Ctx) error {
 				return c.SendString("Hello, World!")
@@ -61,7 +107,26 @@ func NewRestServerCommand() *cobra.Command {
 
 			v1Router := app.Group("/api/v1")
 			v1.RegisterHealthRoute(v1Router)
-			v1.RegisterPublicRoutes(v1Router, producerHandler, repository)
+			v1.RegisterPublicRoutes(v1Router, producerHandler, repository, orm.NewBrowseRepository(db), authorizer)
+			v1.RegisterSearchRoutes(v1Router, orm.NewSearchRepository(db), authorizer)
+			v1.RegisterArtistRoutes(v1Router, orm.NewArtistRepository(db), authorizer)
+			v1.RegisterTrackRoutes(v1Router, repository, trackRepository, authorizer)
+			v1.RegisterGenreRoutes(v1Router, orm.NewGenreRepository(db), repository, authorizer)
+			v1.RegisterTagRoutes(v1Router, orm.NewTagRepository(db), repository, authorizer)
+			v1.RegisterLabelRoutes(v1Router, orm.NewLabelRepository(db), authorizer)
+			v1.RegisterEditionRoutes(v1Router, repository, orm.NewEditionRepository(db), authorizer)
+			v1.RegisterPriceRoutes(v1Router, priceRepository, authorizer)
+			inventoryRepository := orm.NewInventoryRepository(db)
+			v1.RegisterInventoryRoutes(v1Router, inventoryRepository, repository, producerHandler, authorizer)
+
+			paymentProvider, err := payment.NewProvider(cfg.Payment)
+			if err != nil {
+				log.Panicf("Error creating payment provider: %v", err)
+			}
+			orderRepository := orm.NewOrderRepository(db)
+			v1.RegisterOrderRoutes(v1Router, orderRepository, orders.NewService(orderRepository, inventoryRepository, paymentProvider, producerHandler), authorizer)
+			v1.RegisterUserRoutes(v1Router, orm.NewUserRepository(db), orm.NewReviewRepository(db), orm.NewWishlistRepository(db), repository, authorizer)
+			v1.RegisterPlaylistRoutes(v1Router, playlists.NewService(orm.NewPlaylistRepository(db)), authorizer)
 
 			rest.StartServer(app, cfg.Rest)
 		},
@@ -5,10 +5,16 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"music-service/cmd/apikey"
 	"music-service/cmd/grpc"
+	"music-service/cmd/inventory"
+	"music-service/cmd/kafka/admin"
+	"music-service/cmd/kafka/cdc"
 	"music-service/cmd/kafka/confluent"
+	"music-service/cmd/kafka/replay"
 	"music-service/cmd/kafka/sarama"
 	"music-service/cmd/postgres"
+	"music-service/cmd/pricing"
 	rest_client "music-service/cmd/rest/client"
 	rest_server "music-service/cmd/rest/server"
 )
@@ -31,10 +37,19 @@ func init() {
 	rootCmd.AddCommand(sarama.NewKafkaConsumerCommand())
 	rootCmd.AddCommand(sarama.NewKafkaProducerCommand())
 
+	rootCmd.AddCommand(admin.NewKafkaAdminCommand())
+	rootCmd.AddCommand(replay.NewKafkaReplayCommand())
+	rootCmd.AddCommand(cdc.NewKafkaCdcCommand())
+
 	rootCmd.AddCommand(postgres.NewPostgresGetAllCommand())
 	rootCmd.AddCommand(postgres.NewPostgresGetByIdCommand())
 	rootCmd.AddCommand(postgres.NewPostgresInsertCommand())
 
+	rootCmd.AddCommand(apikey.NewAPIKeyCommand())
+
+	rootCmd.AddCommand(pricing.NewPriceSchedulerCommand())
+	rootCmd.AddCommand(inventory.NewInventoryExpirerCommand())
+
 	rootCmd.AddCommand(rest_client.NewRestClientSingleCommand())
 	rootCmd.AddCommand(rest_client.NewRestClientMultiCommand())
 	rootCmd.AddCommand(rest_server.NewRestServerCommand())
@@ -24,22 +24,297 @@ var File_service_proto protoreflect.FileDescriptor
 
 const file_service_proto_rawDesc = "" +
 	"\n" +
-	"\rservice.proto\x12\aservice\x1a\fmodels.proto2W\n" +
+	"\rservice.proto\x12\aservice\x1a\fmodels.proto2\xde\x01\n" +
 	"\fMusicService\x12G\n" +
-	"\fGetAlbumList\x12\x19.service.GetAlbumsRequest\x1a\x1a.service.GetAlbumsResponse\"\x00B\bZ\x06gen/pbb\x06proto3"
+	"\fGetAlbumList\x12\x19.service.GetAlbumsRequest\x1a\x1a.service.GetAlbumsResponse\"\x00\x126\n" +
+	"\bGetAlbum\x12\x18.service.GetAlbumRequest\x1a\x0e.service.Album\"\x00\x12M\n" +
+	"\fSearchAlbums\x12\x1c.service.SearchAlbumsRequest\x1a\x1d.service.SearchAlbumsResponse\"\x002\xa2\x03\n" +
+	"\rArtistService\x122\n" +
+	"\fCreateArtist\x12\x0f.service.Artist\x1a\x0f.service.Artist\"\x00\x129\n" +
+	"\tGetArtist\x12\x19.service.GetArtistRequest\x1a\x0f.service.Artist\"\x00\x12J\n" +
+	"\rGetArtistList\x12\x1a.service.GetArtistsRequest\x1a\x1b.service.GetArtistsResponse\"\x00\x122\n" +
+	"\fUpdateArtist\x12\x0f.service.Artist\x1a\x0f.service.Artist\"\x00\x12M\n" +
+	"\fDeleteArtist\x12\x1c.service.DeleteArtistRequest\x1a\x1d.service.DeleteArtistResponse\"\x00\x12S\n" +
+	"\x12GetArtistAlbumList\x12\x1f.service.GetArtistAlbumsRequest\x1a\x1a.service.GetAlbumsResponse\"\x002\xbd\x02\n" +
+	"\fLabelService\x12/\n" +
+	"\vCreateLabel\x12\x0e.service.Label\x1a\x0e.service.Label\"\x00\x126\n" +
+	"\bGetLabel\x12\x18.service.GetLabelRequest\x1a\x0e.service.Label\"\x00\x12G\n" +
+	"\fGetLabelList\x12\x19.service.GetLabelsRequest\x1a\x1a.service.GetLabelsResponse\"\x00\x12/\n" +
+	"\vUpdateLabel\x12\x0e.service.Label\x1a\x0e.service.Label\"\x00\x12J\n" +
+	"\vDeleteLabel\x12\x1b.service.DeleteLabelRequest\x1a\x1c.service.DeleteLabelResponse\"\x002\x94\x04\n" +
+	"\x10InventoryService\x12S\n" +
+	"\x10GetWarehouseList\x12\x1d.service.GetWarehousesRequest\x1a\x1e.service.GetWarehousesResponse\"\x00\x12A\n" +
+	"\bGetStock\x12\x18.service.GetStockRequest\x1a\x19.service.GetStockResponse\"\x00\x126\n" +
+	"\bSetStock\x12\x13.service.StockLevel\x1a\x13.service.StockLevel\"\x00\x12D\n" +
+	"\fReserveStock\x12\x1c.service.ReserveStockRequest\x1a\x14.service.Reservation\"\x00\x12H\n" +
+	"\x0eGetReservation\x12\x1e.service.GetReservationRequest\x1a\x14.service.Reservation\"\x00\x12N\n" +
+	"\x11CommitReservation\x12!.service.CommitReservationRequest\x1a\x14.service.Reservation\"\x00\x12P\n" +
+	"\x12ReleaseReservation\x12\".service.ReleaseReservationRequest\x1a\x14.service.Reservation\"\x002\xa5\x05\n" +
+	"\fOrderService\x129\n" +
+	"\n" +
+	"CreateCart\x12\x1a.service.CreateCartRequest\x1a\r.service.Cart\"\x00\x123\n" +
+	"\aGetCart\x12\x17.service.GetCartRequest\x1a\r.service.Cart\"\x00\x12;\n" +
+	"\vAddCartItem\x12\x1b.service.AddCartItemRequest\x1a\r.service.Cart\"\x00\x12A\n" +
+	"\x0eRemoveCartItem\x12\x1e.service.RemoveCartItemRequest\x1a\r.service.Cart\"\x00\x126\n" +
+	"\bCheckout\x12\x18.service.CheckoutRequest\x1a\x0e.service.Order\"\x00\x126\n" +
+	"\bGetOrder\x12\x18.service.GetOrderRequest\x1a\x0e.service.Order\"\x00\x12G\n" +
+	"\fGetOrderList\x12\x19.service.GetOrdersRequest\x1a\x1a.service.GetOrdersResponse\"\x00\x126\n" +
+	"\bPayOrder\x12\x18.service.PayOrderRequest\x1a\x0e.service.Order\"\x00\x128\n" +
+	"\tShipOrder\x12\x19.service.ShipOrderRequest\x1a\x0e.service.Order\"\x00\x12<\n" +
+	"\vCancelOrder\x12\x1b.service.CancelOrderRequest\x1a\x0e.service.Order\"\x00\x12<\n" +
+	"\vRefundOrder\x12\x1b.service.RefundOrderRequest\x1a\x0e.service.Order\"\x002\xfe\x06\n" +
+	"\vUserService\x12,\n" +
+	"\n" +
+	"CreateUser\x12\r.service.User\x1a\r.service.User\"\x00\x123\n" +
+	"\aGetUser\x12\x17.service.GetUserRequest\x1a\r.service.User\"\x00\x12/\n" +
+	"\tSetReview\x12\x0f.service.Review\x1a\x0f.service.Review\"\x00\x12T\n" +
+	"\x12GetAlbumReviewList\x12\x1f.service.GetAlbumReviewsRequest\x1a\x1b.service.GetReviewsResponse\"\x00\x12R\n" +
+	"\x11GetUserReviewList\x12\x1e.service.GetUserReviewsRequest\x1a\x1b.service.GetReviewsResponse\"\x00\x12C\n" +
+	"\x0eModerateReview\x12\x1e.service.ModerateReviewRequest\x1a\x0f.service.Review\"\x00\x12M\n" +
+	"\fDeleteReview\x12\x1c.service.DeleteReviewRequest\x1a\x1d.service.DeleteReviewResponse\"\x00\x12F\n" +
+	"\rAddToWishlist\x12\x18.service.WishlistRequest\x1a\x19.service.WishlistResponse\"\x00\x12K\n" +
+	"\x12RemoveFromWishlist\x12\x18.service.WishlistRequest\x1a\x19.service.WishlistResponse\"\x00\x12J\n" +
+	"\vGetWishlist\x12\x1b.service.GetWishlistRequest\x1a\x1c.service.GetWishlistResponse\"\x00\x12\\\n" +
+	"\x13GetNotificationList\x12 .service.GetNotificationsRequest\x1a!.service.GetNotificationsResponse\"\x00\x12^\n" +
+	"\x14MarkNotificationRead\x12$.service.MarkNotificationReadRequest\x1a\x1e.service.PriceDropNotification\"\x002\xae\t\n" +
+	"\x0fPlaylistService\x12E\n" +
+	"\x0eCreatePlaylist\x12\x1e.service.CreatePlaylistRequest\x1a\x11.service.Playlist\"\x00\x12?\n" +
+	"\vGetPlaylist\x12\x1b.service.GetPlaylistRequest\x1a\x11.service.Playlist\"\x00\x12K\n" +
+	"\x11GetSharedPlaylist\x12!.service.GetSharedPlaylistRequest\x1a\x11.service.Playlist\"\x00\x12\\\n" +
+	"\x15GetPublicPlaylistList\x12\".service.GetPublicPlaylistsRequest\x1a\x1d.service.GetPlaylistsResponse\"\x00\x12X\n" +
+	"\x13GetUserPlaylistList\x12 .service.GetUserPlaylistsRequest\x1a\x1d.service.GetPlaylistsResponse\"\x00\x12E\n" +
+	"\x0eUpdatePlaylist\x12\x1e.service.UpdatePlaylistRequest\x1a\x11.service.Playlist\"\x00\x12S\n" +
+	"\x0eDeletePlaylist\x12\x1e.service.DeletePlaylistRequest\x1a\x1f.service.DeletePlaylistResponse\"\x00\x12I\n" +
+	"\x10RotateShareToken\x12 .service.RotateShareTokenRequest\x1a\x11.service.Playlist\"\x00\x12K\n" +
+	"\x0fAddPlaylistItem\x12\x1f.service.AddPlaylistItemRequest\x1a\x15.service.PlaylistItem\"\x00\x12_\n" +
+	"\x12RemovePlaylistItem\x12\".service.RemovePlaylistItemRequest\x1a#.service.RemovePlaylistItemResponse\"\x00\x12M\n" +
+	"\x10MovePlaylistItem\x12 .service.MovePlaylistItemRequest\x1a\x15.service.PlaylistItem\"\x00\x12h\n" +
+	"\x17AddPlaylistCollaborator\x12$.service.PlaylistCollaboratorRequest\x1a%.service.PlaylistCollaboratorResponse\"\x00\x12k\n" +
+	"\x1aRemovePlaylistCollaborator\x12$.service.PlaylistCollaboratorRequest\x1a%.service.PlaylistCollaboratorResponse\"\x00\x12S\n" +
+	"\x0eExportPlaylist\x12\x1e.service.ExportPlaylistRequest\x1a\x1f.service.ExportPlaylistResponse\"\x002\xfb\x02\n" +
+	"\x14ConsumerAdminService\x12\\\n" +
+	"\x11GetConsumerStatus\x12!.service.GetConsumerStatusRequest\x1a\".service.GetConsumerStatusResponse\"\x00\x12P\n" +
+	"\rPauseConsumer\x12\x1b.service.PartitionSelection\x1a .service.ConsumerControlResponse\"\x00\x12Q\n" +
+	"\x0eResumeConsumer\x12\x1b.service.PartitionSelection\x1a .service.ConsumerControlResponse\"\x00\x12`\n" +
+	"\x14ResetConsumerOffsets\x12$.service.ResetConsumerOffsetsRequest\x1a .service.ConsumerControlResponse\"\x00B\bZ\x06gen/pbb\x06proto3"
 
 var file_service_proto_goTypes = []any{
-	(*GetAlbumsRequest)(nil),  // 0: service.GetAlbumsRequest
-	(*GetAlbumsResponse)(nil), // 1: service.GetAlbumsResponse
+	(*GetAlbumsRequest)(nil),             // 0: service.GetAlbumsRequest
+	(*GetAlbumRequest)(nil),              // 1: service.GetAlbumRequest
+	(*SearchAlbumsRequest)(nil),          // 2: service.SearchAlbumsRequest
+	(*Artist)(nil),                       // 3: service.Artist
+	(*GetArtistRequest)(nil),             // 4: service.GetArtistRequest
+	(*GetArtistsRequest)(nil),            // 5: service.GetArtistsRequest
+	(*DeleteArtistRequest)(nil),          // 6: service.DeleteArtistRequest
+	(*GetArtistAlbumsRequest)(nil),       // 7: service.GetArtistAlbumsRequest
+	(*Label)(nil),                        // 8: service.Label
+	(*GetLabelRequest)(nil),              // 9: service.GetLabelRequest
+	(*GetLabelsRequest)(nil),             // 10: service.GetLabelsRequest
+	(*DeleteLabelRequest)(nil),           // 11: service.DeleteLabelRequest
+	(*GetWarehousesRequest)(nil),         // 12: service.GetWarehousesRequest
+	(*GetStockRequest)(nil),              // 13: service.GetStockRequest
+	(*StockLevel)(nil),                   // 14: service.StockLevel
+	(*ReserveStockRequest)(nil),          // 15: service.ReserveStockRequest
+	(*GetReservationRequest)(nil),        // 16: service.GetReservationRequest
+	(*CommitReservationRequest)(nil),     // 17: service.CommitReservationRequest
+	(*ReleaseReservationRequest)(nil),    // 18: service.ReleaseReservationRequest
+	(*CreateCartRequest)(nil),            // 19: service.CreateCartRequest
+	(*GetCartRequest)(nil),               // 20: service.GetCartRequest
+	(*AddCartItemRequest)(nil),           // 21: service.AddCartItemRequest
+	(*RemoveCartItemRequest)(nil),        // 22: service.RemoveCartItemRequest
+	(*CheckoutRequest)(nil),              // 23: service.CheckoutRequest
+	(*GetOrderRequest)(nil),              // 24: service.GetOrderRequest
+	(*GetOrdersRequest)(nil),             // 25: service.GetOrdersRequest
+	(*PayOrderRequest)(nil),              // 26: service.PayOrderRequest
+	(*ShipOrderRequest)(nil),             // 27: service.ShipOrderRequest
+	(*CancelOrderRequest)(nil),           // 28: service.CancelOrderRequest
+	(*RefundOrderRequest)(nil),           // 29: service.RefundOrderRequest
+	(*User)(nil),                         // 30: service.User
+	(*GetUserRequest)(nil),               // 31: service.GetUserRequest
+	(*Review)(nil),                       // 32: service.Review
+	(*GetAlbumReviewsRequest)(nil),       // 33: service.GetAlbumReviewsRequest
+	(*GetUserReviewsRequest)(nil),        // 34: service.GetUserReviewsRequest
+	(*ModerateReviewRequest)(nil),        // 35: service.ModerateReviewRequest
+	(*DeleteReviewRequest)(nil),          // 36: service.DeleteReviewRequest
+	(*WishlistRequest)(nil),              // 37: service.WishlistRequest
+	(*GetWishlistRequest)(nil),           // 38: service.GetWishlistRequest
+	(*GetNotificationsRequest)(nil),      // 39: service.GetNotificationsRequest
+	(*MarkNotificationReadRequest)(nil),  // 40: service.MarkNotificationReadRequest
+	(*CreatePlaylistRequest)(nil),        // 41: service.CreatePlaylistRequest
+	(*GetPlaylistRequest)(nil),           // 42: service.GetPlaylistRequest
+	(*GetSharedPlaylistRequest)(nil),     // 43: service.GetSharedPlaylistRequest
+	(*GetPublicPlaylistsRequest)(nil),    // 44: service.GetPublicPlaylistsRequest
+	(*GetUserPlaylistsRequest)(nil),      // 45: service.GetUserPlaylistsRequest
+	(*UpdatePlaylistRequest)(nil),        // 46: service.UpdatePlaylistRequest
+	(*DeletePlaylistRequest)(nil),        // 47: service.DeletePlaylistRequest
+	(*RotateShareTokenRequest)(nil),      // 48: service.RotateShareTokenRequest
+	(*AddPlaylistItemRequest)(nil),       // 49: service.AddPlaylistItemRequest
+	(*RemovePlaylistItemRequest)(nil),    // 50: service.RemovePlaylistItemRequest
+	(*MovePlaylistItemRequest)(nil),      // 51: service.MovePlaylistItemRequest
+	(*PlaylistCollaboratorRequest)(nil),  // 52: service.PlaylistCollaboratorRequest
+	(*ExportPlaylistRequest)(nil),        // 53: service.ExportPlaylistRequest
+	(*GetConsumerStatusRequest)(nil),     // 54: service.GetConsumerStatusRequest
+	(*PartitionSelection)(nil),           // 55: service.PartitionSelection
+	(*ResetConsumerOffsetsRequest)(nil),  // 56: service.ResetConsumerOffsetsRequest
+	(*GetAlbumsResponse)(nil),            // 57: service.GetAlbumsResponse
+	(*Album)(nil),                        // 58: service.Album
+	(*SearchAlbumsResponse)(nil),         // 59: service.SearchAlbumsResponse
+	(*GetArtistsResponse)(nil),           // 60: service.GetArtistsResponse
+	(*DeleteArtistResponse)(nil),         // 61: service.DeleteArtistResponse
+	(*GetLabelsResponse)(nil),            // 62: service.GetLabelsResponse
+	(*DeleteLabelResponse)(nil),          // 63: service.DeleteLabelResponse
+	(*GetWarehousesResponse)(nil),        // 64: service.GetWarehousesResponse
+	(*GetStockResponse)(nil),             // 65: service.GetStockResponse
+	(*Reservation)(nil),                  // 66: service.Reservation
+	(*Cart)(nil),                         // 67: service.Cart
+	(*Order)(nil),                        // 68: service.Order
+	(*GetOrdersResponse)(nil),            // 69: service.GetOrdersResponse
+	(*GetReviewsResponse)(nil),           // 70: service.GetReviewsResponse
+	(*DeleteReviewResponse)(nil),         // 71: service.DeleteReviewResponse
+	(*WishlistResponse)(nil),             // 72: service.WishlistResponse
+	(*GetWishlistResponse)(nil),          // 73: service.GetWishlistResponse
+	(*GetNotificationsResponse)(nil),     // 74: service.GetNotificationsResponse
+	(*PriceDropNotification)(nil),        // 75: service.PriceDropNotification
+	(*Playlist)(nil),                     // 76: service.Playlist
+	(*GetPlaylistsResponse)(nil),         // 77: service.GetPlaylistsResponse
+	(*DeletePlaylistResponse)(nil),       // 78: service.DeletePlaylistResponse
+	(*PlaylistItem)(nil),                 // 79: service.PlaylistItem
+	(*RemovePlaylistItemResponse)(nil),   // 80: service.RemovePlaylistItemResponse
+	(*PlaylistCollaboratorResponse)(nil), // 81: service.PlaylistCollaboratorResponse
+	(*ExportPlaylistResponse)(nil),       // 82: service.ExportPlaylistResponse
+	(*GetConsumerStatusResponse)(nil),    // 83: service.GetConsumerStatusResponse
+	(*ConsumerControlResponse)(nil),      // 84: service.ConsumerControlResponse
 }
 var file_service_proto_depIdxs = []int32{
-	0, // 0: service.MusicService.GetAlbumList:input_type -> service.GetAlbumsRequest
-	1, // 1: service.MusicService.GetAlbumList:output_type -> service.GetAlbumsResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: service.MusicService.GetAlbumList:input_type -> service.GetAlbumsRequest
+	1,  // 1: service.MusicService.GetAlbum:input_type -> service.GetAlbumRequest
+	2,  // 2: service.MusicService.SearchAlbums:input_type -> service.SearchAlbumsRequest
+	3,  // 3: service.ArtistService.CreateArtist:input_type -> service.Artist
+	4,  // 4: service.ArtistService.GetArtist:input_type -> service.GetArtistRequest
+	5,  // 5: service.ArtistService.GetArtistList:input_type -> service.GetArtistsRequest
+	3,  // 6: service.ArtistService.UpdateArtist:input_type -> service.Artist
+	6,  // 7: service.ArtistService.DeleteArtist:input_type -> service.DeleteArtistRequest
+	7,  // 8: service.ArtistService.GetArtistAlbumList:input_type -> service.GetArtistAlbumsRequest
+	8,  // 9: service.LabelService.CreateLabel:input_type -> service.Label
+	9,  // 10: service.LabelService.GetLabel:input_type -> service.GetLabelRequest
+	10, // 11: service.LabelService.GetLabelList:input_type -> service.GetLabelsRequest
+	8,  // 12: service.LabelService.UpdateLabel:input_type -> service.Label
+	11, // 13: service.LabelService.DeleteLabel:input_type -> service.DeleteLabelRequest
+	12, // 14: service.InventoryService.GetWarehouseList:input_type -> service.GetWarehousesRequest
+	13, // 15: service.InventoryService.GetStock:input_type -> service.GetStockRequest
+	14, // 16: service.InventoryService.SetStock:input_type -> service.StockLevel
+	15, // 17: service.InventoryService.ReserveStock:input_type -> service.ReserveStockRequest
+	16, // 18: service.InventoryService.GetReservation:input_type -> service.GetReservationRequest
+	17, // 19: service.InventoryService.CommitReservation:input_type -> service.CommitReservationRequest
+	18, // 20: service.InventoryService.ReleaseReservation:input_type -> service.ReleaseReservationRequest
+	19, // 21: service.OrderService.CreateCart:input_type -> service.CreateCartRequest
+	20, // 22: service.OrderService.GetCart:input_type -> service.GetCartRequest
+	21, // 23: service.OrderService.AddCartItem:input_type -> service.AddCartItemRequest
+	22, // 24: service.OrderService.RemoveCartItem:input_type -> service.RemoveCartItemRequest
+	23, // 25: service.OrderService.Checkout:input_type -> service.CheckoutRequest
+	24, // 26: service.OrderService.GetOrder:input_type -> service.GetOrderRequest
+	25, // 27: service.OrderService.GetOrderList:input_type -> service.GetOrdersRequest
+	26, // 28: service.OrderService.PayOrder:input_type -> service.PayOrderRequest
+	27, // 29: service.OrderService.ShipOrder:input_type -> service.ShipOrderRequest
+	28, // 30: service.OrderService.CancelOrder:input_type -> service.CancelOrderRequest
+	29, // 31: service.OrderService.RefundOrder:input_type -> service.RefundOrderRequest
+	30, // 32: service.UserService.CreateUser:input_type -> service.User
+	31, // 33: service.UserService.GetUser:input_type -> service.GetUserRequest
+	32, // 34: service.UserService.SetReview:input_type -> service.Review
+	33, // 35: service.UserService.GetAlbumReviewList:input_type -> service.GetAlbumReviewsRequest
+	34, // 36: service.UserService.GetUserReviewList:input_type -> service.GetUserReviewsRequest
+	35, // 37: service.UserService.ModerateReview:input_type -> service.ModerateReviewRequest
+	36, // 38: service.UserService.DeleteReview:input_type -> service.DeleteReviewRequest
+	37, // 39: service.UserService.AddToWishlist:input_type -> service.WishlistRequest
+	37, // 40: service.UserService.RemoveFromWishlist:input_type -> service.WishlistRequest
+	38, // 41: service.UserService.GetWishlist:input_type -> service.GetWishlistRequest
+	39, // 42: service.UserService.GetNotificationList:input_type -> service.GetNotificationsRequest
+	40, // 43: service.UserService.MarkNotificationRead:input_type -> service.MarkNotificationReadRequest
+	41, // 44: service.PlaylistService.CreatePlaylist:input_type -> service.CreatePlaylistRequest
+	42, // 45: service.PlaylistService.GetPlaylist:input_type -> service.GetPlaylistRequest
+	43, // 46: service.PlaylistService.GetSharedPlaylist:input_type -> service.GetSharedPlaylistRequest
+	44, // 47: service.PlaylistService.GetPublicPlaylistList:input_type -> service.GetPublicPlaylistsRequest
+	45, // 48: service.PlaylistService.GetUserPlaylistList:input_type -> service.GetUserPlaylistsRequest
+	46, // 49: service.PlaylistService.UpdatePlaylist:input_type -> service.UpdatePlaylistRequest
+	47, // 50: service.PlaylistService.DeletePlaylist:input_type -> service.DeletePlaylistRequest
+	48, // 51: service.PlaylistService.RotateShareToken:input_type -> service.RotateShareTokenRequest
+	49, // 52: service.PlaylistService.AddPlaylistItem:input_type -> service.AddPlaylistItemRequest
+	50, // 53: service.PlaylistService.RemovePlaylistItem:input_type -> service.RemovePlaylistItemRequest
+	51, // 54: service.PlaylistService.MovePlaylistItem:input_type -> service.MovePlaylistItemRequest
+	52, // 55: service.PlaylistService.AddPlaylistCollaborator:input_type -> service.PlaylistCollaboratorRequest
+	52, // 56: service.PlaylistService.RemovePlaylistCollaborator:input_type -> service.PlaylistCollaboratorRequest
+	53, // 57: service.PlaylistService.ExportPlaylist:input_type -> service.ExportPlaylistRequest
+	54, // 58: service.ConsumerAdminService.GetConsumerStatus:input_type -> service.GetConsumerStatusRequest
+	55, // 59: service.ConsumerAdminService.PauseConsumer:input_type -> service.PartitionSelection
+	55, // 60: service.ConsumerAdminService.ResumeConsumer:input_type -> service.PartitionSelection
+	56, // 61: service.ConsumerAdminService.ResetConsumerOffsets:input_type -> service.ResetConsumerOffsetsRequest
+	57, // 62: service.MusicService.GetAlbumList:output_type -> service.GetAlbumsResponse
+	58, // 63: service.MusicService.GetAlbum:output_type -> service.Album
+	59, // 64: service.MusicService.SearchAlbums:output_type -> service.SearchAlbumsResponse
+	3,  // 65: service.ArtistService.CreateArtist:output_type -> service.Artist
+	3,  // 66: service.ArtistService.GetArtist:output_type -> service.Artist
+	60, // 67: service.ArtistService.GetArtistList:output_type -> service.GetArtistsResponse
+	3,  // 68: service.ArtistService.UpdateArtist:output_type -> service.Artist
+	61, // 69: service.ArtistService.DeleteArtist:output_type -> service.DeleteArtistResponse
+	57, // 70: service.ArtistService.GetArtistAlbumList:output_type -> service.GetAlbumsResponse
+	8,  // 71: service.LabelService.CreateLabel:output_type -> service.Label
+	8,  // 72: service.LabelService.GetLabel:output_type -> service.Label
+	62, // 73: service.LabelService.GetLabelList:output_type -> service.GetLabelsResponse
+	8,  // 74: service.LabelService.UpdateLabel:output_type -> service.Label
+	63, // 75: service.LabelService.DeleteLabel:output_type -> service.DeleteLabelResponse
+	64, // 76: service.InventoryService.GetWarehouseList:output_type -> service.GetWarehousesResponse
+	65, // 77: service.InventoryService.GetStock:output_type -> service.GetStockResponse
+	14, // 78: service.InventoryService.SetStock:output_type -> service.StockLevel
+	66, // 79: service.InventoryService.ReserveStock:output_type -> service.Reservation
+	66, // 80: service.InventoryService.GetReservation:output_type -> service.Reservation
+	66, // 81: service.InventoryService.CommitReservation:output_type -> service.Reservation
+	66, // 82: service.InventoryService.ReleaseReservation:output_type -> service.Reservation
+	67, // 83: service.OrderService.CreateCart:output_type -> service.Cart
+	67, // 84: service.OrderService.GetCart:output_type -> service.Cart
+	67, // 85: service.OrderService.AddCartItem:output_type -> service.Cart
+	67, // 86: service.OrderService.RemoveCartItem:output_type -> service.Cart
+	68, // 87: service.OrderService.Checkout:output_type -> service.Order
+	68, // 88: service.OrderService.GetOrder:output_type -> service.Order
+	69, // 89: service.OrderService.GetOrderList:output_type -> service.GetOrdersResponse
+	68, // 90: service.OrderService.PayOrder:output_type -> service.Order
+	68, // 91: service.OrderService.ShipOrder:output_type -> service.Order
+	68, // 92: service.OrderService.CancelOrder:output_type -> service.Order
+	68, // 93: service.OrderService.RefundOrder:output_type -> service.Order
+	30, // 94: service.UserService.CreateUser:output_type -> service.User
+	30, // 95: service.UserService.GetUser:output_type -> service.User
+	32, // 96: service.UserService.SetReview:output_type -> service.Review
+	70, // 97: service.UserService.GetAlbumReviewList:output_type -> service.GetReviewsResponse
+	70, // 98: service.UserService.GetUserReviewList:output_type -> service.GetReviewsResponse
+	32, // 99: service.UserService.ModerateReview:output_type -> service.Review
+	71, // 100: service.UserService.DeleteReview:output_type -> service.DeleteReviewResponse
+	72, // 101: service.UserService.AddToWishlist:output_type -> service.WishlistResponse
+	72, // 102: service.UserService.RemoveFromWishlist:output_type -> service.WishlistResponse
+	73, // 103: service.UserService.GetWishlist:output_type -> service.GetWishlistResponse
+	74, // 104: service.UserService.GetNotificationList:output_type -> service.GetNotificationsResponse
+	75, // 105: service.UserService.MarkNotificationRead:output_type -> service.PriceDropNotification
+	76, // 106: service.PlaylistService.CreatePlaylist:output_type -> service.Playlist
+	76, // 107: service.PlaylistService.GetPlaylist:output_type -> service.Playlist
+	76, // 108: service.PlaylistService.GetSharedPlaylist:output_type -> service.Playlist
+	77, // 109: service.PlaylistService.GetPublicPlaylistList:output_type -> service.GetPlaylistsResponse
+	77, // 110: service.PlaylistService.GetUserPlaylistList:output_type -> service.GetPlaylistsResponse
+	76, // 111: service.PlaylistService.UpdatePlaylist:output_type -> service.Playlist
+	78, // 112: service.PlaylistService.DeletePlaylist:output_type -> service.DeletePlaylistResponse
+	76, // 113: service.PlaylistService.RotateShareToken:output_type -> service.Playlist
+	79, // 114: service.PlaylistService.AddPlaylistItem:output_type -> service.PlaylistItem
+	80, // 115: service.PlaylistService.RemovePlaylistItem:output_type -> service.RemovePlaylistItemResponse
+	79, // 116: service.PlaylistService.MovePlaylistItem:output_type -> service.PlaylistItem
+	81, // 117: service.PlaylistService.AddPlaylistCollaborator:output_type -> service.PlaylistCollaboratorResponse
+	81, // 118: service.PlaylistService.RemovePlaylistCollaborator:output_type -> service.PlaylistCollaboratorResponse
+	82, // 119: service.PlaylistService.ExportPlaylist:output_type -> service.ExportPlaylistResponse
+	83, // 120: service.ConsumerAdminService.GetConsumerStatus:output_type -> service.GetConsumerStatusResponse
+	84, // 121: service.ConsumerAdminService.PauseConsumer:output_type -> service.ConsumerControlResponse
+	84, // 122: service.ConsumerAdminService.ResumeConsumer:output_type -> service.ConsumerControlResponse
+	84, // 123: service.ConsumerAdminService.ResetConsumerOffsets:output_type -> service.ConsumerControlResponse
+	62, // [62:124] is the sub-list for method output_type
+	0,  // [0:62] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_service_proto_init() }
@@ -56,7 +331,7 @@ func file_service_proto_init() {
 			NumEnums:      0,
 			NumMessages:   0,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   8,
 		},
 		GoTypes:           file_service_proto_goTypes,
 		DependencyIndexes: file_service_proto_depIdxs,
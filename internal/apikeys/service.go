@@ -0,0 +1,130 @@
+package apikeys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+	"music-service/pkg/auth"
+)
+
+// KeyPrefix starts every key, so leaked keys are easy to scan for.
+const KeyPrefix = "msk_"
+
+// KeyEnv is the environment variable the REST clients read their API key
+// from when the --api-key flag is not given.
+const KeyEnv = "MUSIC_SERVICE_API_KEY"
+
+// touchInterval is how often the last use of a busy key is recorded.
+const touchInterval = time.Minute
+
+// Service creates, lists and revokes the API keys of service-to-service
+// clients and authenticates them. A key is msk_, a random prefix identifying
+// it, _ and a random secret. Only the SHA-256 hash of a key is kept, which is
+// enough for keys this random, so the key is shown once when it is created.
+type Service struct {
+	keys orm.APIKeyRepository
+	now  func() time.Time
+}
+
+func NewService(keys orm.APIKeyRepository) *Service {
+	return &Service{
+		keys: keys,
+		now:  time.Now,
+	}
+}
+
+// Create generates a key for the normalized, valid key and creates it,
+// returning the key.
+func (s *Service) Create(key *models.APIKey) (string, error) {
+	prefix := make([]byte, 6)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(prefix); err != nil {
+		return "", err
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	plain := KeyPrefix + hex.EncodeToString(prefix) + "_" + base64.RawURLEncoding.EncodeToString(secret)
+	hash := sha256.Sum256([]byte(plain))
+	key.Id = 0
+	key.Prefix = hex.EncodeToString(prefix)
+	key.KeyHash = hash[:]
+	key.LastUsedAt = nil
+	key.RevokedAt = nil
+	if err := s.keys.Create(key); err != nil {
+		return "", err
+	}
+	return plain, nil
+}
+
+// List returns every key, revoked and expired ones included.
+func (s *Service) List() ([]*models.APIKey, error) {
+	return s.keys.Get()
+}
+
+// Revoke revokes the key for good. It returns pg.ErrNoRows when the key does
+// not exist.
+func (s *Service) Revoke(id int) (*models.APIKey, error) {
+	return s.keys.Revoke(id)
+}
+
+// Authenticate returns the identity of the client presenting the key, which
+// acts with the scopes of the key as its roles, and records the use of the
+// key. It returns auth.ErrInvalidKey when the key is unknown, revoked or
+// expired.
+func (s *Service) Authenticate(ctx context.Context, plain string) (*auth.Identity, error) {
+	prefix, _, ok := strings.Cut(strings.TrimPrefix(plain, KeyPrefix), "_")
+	if !ok || !strings.HasPrefix(plain, KeyPrefix) {
+		return nil, fmt.Errorf("%w: malformed key", auth.ErrInvalidKey)
+	}
+
+	key, err := s.keys.GetByPrefix(prefix)
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, fmt.Errorf("%w: unknown key", auth.ErrInvalidKey)
+		}
+		return nil, err
+	}
+	hash := sha256.Sum256([]byte(plain))
+	if subtle.ConstantTimeCompare(hash[:], key.KeyHash) != 1 {
+		return nil, fmt.Errorf("%w: unknown key", auth.ErrInvalidKey)
+	}
+	if !key.Active(s.now()) {
+		return nil, fmt.Errorf("%w: key %s is revoked or expired", auth.ErrInvalidKey, key.Prefix)
+	}
+
+	if err := s.keys.Touch(key.Id, touchInterval); err != nil {
+		log.Printf("failed to record the use of API key %s: %v", key.Prefix, err)
+	}
+	return &auth.Identity{
+		Subject: "apikey:" + key.Prefix,
+		Roles:   key.Scopes,
+		Claims: map[string]interface{}{
+			"key_id": key.Id,
+			"name":   key.Name,
+		},
+	}, nil
+}
+
+// NewAuthenticator returns the authenticator of the API keys kept in the db
+// when the auth config accepts them, nil otherwise.
+func NewAuthenticator(cfg auth.Config, db *pg.DB) auth.KeyAuthenticator {
+	if !cfg.Enabled || !cfg.APIKeys {
+		return nil
+	}
+	return NewService(orm.NewAPIKeyRepository(db))
+}
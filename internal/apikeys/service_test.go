@@ -0,0 +1,118 @@
+package apikeys
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+	"music-service/pkg/auth"
+)
+
+// fakeAPIKeyRepository keeps the keys in memory
+type fakeAPIKeyRepository struct {
+	orm.APIKeyRepository
+	keys    map[string]*models.APIKey
+	touched []int
+	err     error
+}
+
+func newFakeAPIKeyRepository() *fakeAPIKeyRepository {
+	return &fakeAPIKeyRepository{keys: map[string]*models.APIKey{}}
+}
+
+func (r *fakeAPIKeyRepository) Create(key *models.APIKey) error {
+	key.Id = len(r.keys) + 1
+	r.keys[key.Prefix] = key
+	return nil
+}
+
+func (r *fakeAPIKeyRepository) GetByPrefix(prefix string) (*models.APIKey, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	key, ok := r.keys[prefix]
+	if !ok {
+		return nil, pg.ErrNoRows
+	}
+	return key, nil
+}
+
+func (r *fakeAPIKeyRepository) Touch(id int, interval time.Duration) error {
+	r.touched = append(r.touched, id)
+	return nil
+}
+
+func TestService_CreateAuthenticate(t *testing.T) {
+	repository := newFakeAPIKeyRepository()
+	service := NewService(repository)
+
+	key := &models.APIKey{Name: "nightly import", Scopes: []string{"editor"}}
+	plain, err := service.Create(key)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(plain, KeyPrefix+key.Prefix+"_"))
+	assert.Len(t, key.KeyHash, 32)
+	assert.NotContains(t, string(key.KeyHash), plain)
+
+	identity, err := service.Authenticate(context.Background(), plain)
+	require.NoError(t, err)
+	assert.Equal(t, "apikey:"+key.Prefix, identity.Subject)
+	assert.Equal(t, []string{"editor"}, identity.Roles)
+	assert.Equal(t, "nightly import", identity.Claims["name"])
+	assert.Equal(t, []int{key.Id}, repository.touched)
+
+	other, err := service.Create(&models.APIKey{Name: "backfill", Scopes: []string{"viewer"}})
+	require.NoError(t, err)
+	assert.NotEqual(t, plain, other)
+}
+
+func TestService_AuthenticateInvalid(t *testing.T) {
+	repository := newFakeAPIKeyRepository()
+	service := NewService(repository)
+	now := time.Now()
+	service.now = func() time.Time { return now }
+
+	create := func(key *models.APIKey) string {
+		plain, err := service.Create(key)
+		require.NoError(t, err)
+		return plain
+	}
+	valid := create(&models.APIKey{Name: "valid", Scopes: []string{"viewer"}})
+	expiresAt := now.Add(time.Hour)
+	expiring := create(&models.APIKey{Name: "expiring", Scopes: []string{"viewer"}, ExpiresAt: &expiresAt})
+	revoked := create(&models.APIKey{Name: "revoked", Scopes: []string{"viewer"}})
+	revokedAt := now
+	repository.keys[strings.Split(revoked, "_")[1]].RevokedAt = &revokedAt
+	service.now = func() time.Time { return now.Add(2 * time.Hour) }
+
+	tests := []struct {
+		name string
+		key  string
+	}{
+		{name: "malformed", key: "not-a-key"},
+		{name: "unknown prefix", key: KeyPrefix + "000000000000_secret"},
+		{name: "wrong secret", key: valid[:strings.LastIndex(valid, "_")+1] + "secret"},
+		{name: "expired", key: expiring},
+		{name: "revoked", key: revoked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.Authenticate(context.Background(), tt.key)
+			assert.ErrorIs(t, err, auth.ErrInvalidKey)
+		})
+	}
+	assert.Empty(t, repository.touched)
+
+	repository.err = errors.New("connection refused")
+	_, err := service.Authenticate(context.Background(), valid)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, auth.ErrInvalidKey)
+}
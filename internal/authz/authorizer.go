@@ -0,0 +1,63 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"music-service/pkg/auth"
+)
+
+var (
+	// ErrUnauthenticated is returned when a request has no identity.
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrForbidden is returned when no role of the caller is granted the
+	// permission.
+	ErrForbidden = errors.New("forbidden")
+)
+
+// Authorizer checks the permissions of the callers identified by
+// pkg/auth against a policy and logs the denials for auditing. A nil
+// Authorizer, used when authentication is disabled, permits everything.
+type Authorizer struct {
+	policy *Policy
+}
+
+func NewAuthorizer(policy *Policy) *Authorizer {
+	return &Authorizer{
+		policy: policy,
+	}
+}
+
+// New returns the authorizer for the auth config with the policy of its
+// policy file, nil when authentication is disabled.
+func New(cfg auth.Config) (*Authorizer, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	policy, err := LoadPolicy(cfg.PolicyFile)
+	if err != nil {
+		return nil, err
+	}
+	return NewAuthorizer(policy), nil
+}
+
+// Authorize returns ErrUnauthenticated when the context carries no identity
+// and ErrForbidden when the identity lacks the permission. The resource names
+// what was denied in the audit log, such as the route or gRPC method.
+func (a *Authorizer) Authorize(ctx context.Context, permission Permission, resource string) error {
+	if a == nil {
+		return nil
+	}
+
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		log.Printf("audit: denied %s on %s to an unauthenticated caller", permission, resource)
+		return ErrUnauthenticated
+	}
+	if !a.policy.Allows(identity.Roles, permission) {
+		log.Printf("audit: denied %s on %s to %q with roles %v", permission, resource, identity.Subject, identity.Roles)
+		return ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package authz
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"music-service/pkg/auth"
+)
+
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() {
+		log.SetOutput(os.Stderr)
+	})
+	return &buf
+}
+
+func withRoles(subject string, roles ...string) context.Context {
+	return auth.NewContext(context.Background(), &auth.Identity{Subject: subject, Roles: roles})
+}
+
+func TestAuthorizer_Authorize(t *testing.T) {
+	authorizer := NewAuthorizer(DefaultPolicy())
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		permission Permission
+		wantErr    error
+		wantLog    string
+	}{
+		{name: "viewer reads", ctx: withRoles("jane"), permission: CatalogRead},
+		{name: "editor writes", ctx: withRoles("jane", RoleEditor), permission: CatalogWrite},
+		{name: "viewer writes", ctx: withRoles("jane", RoleViewer), permission: CatalogWrite, wantErr: ErrForbidden, wantLog: `audit: denied catalog:write on POST /api/v1/albums to "jane" with roles [viewer]`},
+		{name: "editor changes prices", ctx: withRoles("joe", RoleEditor), permission: PricesWrite, wantErr: ErrForbidden, wantLog: `audit: denied prices:write on POST /api/v1/albums to "joe" with roles [editor]`},
+		{name: "unauthenticated", ctx: context.Background(), permission: CatalogRead, wantErr: ErrUnauthenticated, wantLog: "audit: denied catalog:read on POST /api/v1/albums to an unauthenticated caller"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := captureLog(t)
+
+			err := authorizer.Authorize(tt.ctx, tt.permission, "POST /api/v1/albums")
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantLog == "" {
+				assert.Empty(t, buf.String())
+			} else {
+				assert.Contains(t, buf.String(), tt.wantLog)
+			}
+		})
+	}
+}
+
+func TestAuthorizer_Nil(t *testing.T) {
+	var authorizer *Authorizer
+	assert.NoError(t, authorizer.Authorize(context.Background(), Admin, "PauseConsumer"))
+}
+
+func TestNew(t *testing.T) {
+	authorizer, err := New(auth.Config{})
+	require.NoError(t, err)
+	assert.Nil(t, authorizer)
+
+	authorizer, err = New(auth.Config{Enabled: true, Secret: "s3cret"})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPolicy(), authorizer.policy)
+
+	_, err = New(auth.Config{Enabled: true, Secret: "s3cret", PolicyFile: "missing.yaml"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"music-service/pkg/auth"
+)
+
+// ErrNotUser is returned when the caller is authenticated as something else
+// than a user, such as an API key.
+var ErrNotUser = errors.New("the caller is not a user")
+
+// UserId returns the id of the calling user, the subject of its token, and
+// false when the context carries no identity, as when authentication is
+// disabled. Only then may a request name the user it acts as.
+func UserId(ctx context.Context) (int, bool, error) {
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		return 0, false, nil
+	}
+	userId, err := strconv.Atoi(identity.Subject)
+	if err != nil || userId < 1 {
+		return 0, true, ErrNotUser
+	}
+	return userId, true, nil
+}
+
+// IsUser reports whether the caller may act as the user: any caller when the
+// context carries no identity, otherwise only the user itself.
+func IsUser(ctx context.Context, userId int) bool {
+	callerId, ok, err := UserId(ctx)
+	if !ok {
+		return true
+	}
+	return err == nil && callerId == userId
+}
+
+// Customer returns the customer the caller orders as, the subject of its
+// token, and false when the context carries no identity.
+func Customer(ctx context.Context) (string, bool) {
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	return identity.Subject, true
+}
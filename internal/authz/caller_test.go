@@ -0,0 +1,45 @@
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserId(t *testing.T) {
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		wantId       int
+		wantIdentity bool
+		wantErr      error
+		wantIsUser   bool
+		wantIsOther  bool
+	}{
+		{name: "user", ctx: withRoles("7"), wantId: 7, wantIdentity: true, wantIsUser: true},
+		{name: "api key", ctx: withRoles("apikey:msk_abcd"), wantIdentity: true, wantErr: ErrNotUser},
+		{name: "invalid id", ctx: withRoles("0"), wantIdentity: true, wantErr: ErrNotUser},
+		{name: "no identity", ctx: context.Background(), wantIsUser: true, wantIsOther: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userId, ok, err := UserId(tt.ctx)
+			assert.Equal(t, tt.wantId, userId)
+			assert.Equal(t, tt.wantIdentity, ok)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantIsUser, IsUser(tt.ctx, 7))
+			assert.Equal(t, tt.wantIsOther, IsUser(tt.ctx, 8))
+		})
+	}
+}
+
+func TestCustomer(t *testing.T) {
+	customer, ok := Customer(withRoles("jane"))
+	assert.True(t, ok)
+	assert.Equal(t, "jane", customer)
+
+	_, ok = Customer(context.Background())
+	assert.False(t, ok)
+}
@@ -0,0 +1,34 @@
+package authz
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"music-service/pkg/auth"
+)
+
+func TestAuthorizeCommand(t *testing.T) {
+	captureLog(t)
+	cfg := auth.Config{Enabled: true, Secret: "0123456789abcdef0123456789abcdef"}
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: []byte(cfg.Secret)}, nil)
+	require.NoError(t, err)
+	token := func(roles ...string) string {
+		claims := jwt.Claims{Subject: "batch", Expiry: jwt.NewNumericDate(time.Now().Add(time.Hour))}
+		token, err := jwt.Signed(signer).Claims(claims).Claims(map[string]interface{}{"roles": roles}).Serialize()
+		require.NoError(t, err)
+		return token
+	}
+
+	assert.NoError(t, AuthorizeCommand(context.Background(), auth.Config{}, "", CatalogWrite, "postgres-insert"))
+	assert.NoError(t, AuthorizeCommand(context.Background(), cfg, token(RoleEditor), CatalogWrite, "postgres-insert"))
+	assert.ErrorIs(t, AuthorizeCommand(context.Background(), cfg, token(RoleViewer), CatalogWrite, "postgres-insert"), ErrForbidden)
+	assert.ErrorIs(t, AuthorizeCommand(context.Background(), cfg, "", CatalogWrite, "postgres-insert"), ErrUnauthenticated)
+	assert.ErrorIs(t, AuthorizeCommand(context.Background(), cfg, "not-a-token", CatalogWrite, "postgres-insert"), auth.ErrInvalidToken)
+}
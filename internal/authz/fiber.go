@@ -0,0 +1,35 @@
+package authz
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Require returns Fiber middleware that answers requests whose caller lacks
+// the permission with 401 or 403.
+func Require(authorizer *Authorizer, permission Permission) fiber.Handler {
+	return RequireIf(authorizer, permission, nil)
+}
+
+// RequireIf is Require for the requests the condition holds for, such as
+// those whose body sets a field only the permission may change. A nil
+// condition holds for every request.
+func RequireIf(authorizer *Authorizer, permission Permission, condition func(ctx *fiber.Ctx) bool) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if condition != nil && !condition(ctx) {
+			return ctx.Next()
+		}
+		resource := ctx.Method() + " " + ctx.Path()
+		if err := authorizer.Authorize(ctx.UserContext(), permission, resource); err != nil {
+			status := fiber.StatusForbidden
+			if errors.Is(err, ErrUnauthenticated) {
+				status = fiber.StatusUnauthorized
+			}
+			return ctx.Status(status).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return ctx.Next()
+	}
+}
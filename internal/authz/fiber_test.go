@@ -0,0 +1,76 @@
+package authz
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"music-service/pkg/auth"
+)
+
+func TestRequire(t *testing.T) {
+	captureLog(t)
+
+	tests := []struct {
+		name       string
+		identity   *auth.Identity
+		authorizer *Authorizer
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "allowed", identity: &auth.Identity{Subject: "jane", Roles: []string{RoleEditor}}, authorizer: NewAuthorizer(DefaultPolicy()), wantStatus: fiber.StatusCreated, wantBody: "created"},
+		{name: "forbidden", identity: &auth.Identity{Subject: "jane"}, authorizer: NewAuthorizer(DefaultPolicy()), wantStatus: fiber.StatusForbidden, wantBody: `{"error":"forbidden"}`},
+		{name: "unauthenticated", authorizer: NewAuthorizer(DefaultPolicy()), wantStatus: fiber.StatusUnauthorized, wantBody: `{"error":"unauthenticated"}`},
+		{name: "disabled", wantStatus: fiber.StatusCreated, wantBody: "created"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(func(ctx *fiber.Ctx) error {
+				if tt.identity != nil {
+					ctx.SetUserContext(auth.NewContext(ctx.UserContext(), tt.identity))
+				}
+				return ctx.Next()
+			})
+			app.Post("/albums", Require(tt.authorizer, CatalogWrite), func(ctx *fiber.Ctx) error {
+				return ctx.Status(fiber.StatusCreated).SendString("created")
+			})
+
+			req, _ := http.NewRequest(http.MethodPost, "/albums", nil)
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			body, _ := io.ReadAll(resp.Body)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantBody, string(body))
+		})
+	}
+}
+
+func TestRequireIf(t *testing.T) {
+	captureLog(t)
+
+	app := fiber.New()
+	app.Use(func(ctx *fiber.Ctx) error {
+		ctx.SetUserContext(auth.NewContext(ctx.UserContext(), &auth.Identity{Subject: "jane", Roles: []string{RoleEditor}}))
+		return ctx.Next()
+	})
+	setsPrice := func(ctx *fiber.Ctx) bool { return ctx.Query("price") != "" }
+	app.Post("/albums", RequireIf(NewAuthorizer(DefaultPolicy()), PricesWrite, setsPrice), func(ctx *fiber.Ctx) error {
+		return ctx.Status(fiber.StatusCreated).SendString("created")
+	})
+
+	for path, wantStatus := range map[string]int{
+		"/albums":            fiber.StatusCreated,
+		"/albums?price=9.99": fiber.StatusForbidden,
+	} {
+		req, _ := http.NewRequest(http.MethodPost, path, nil)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, wantStatus, resp.StatusCode, path)
+	}
+}
@@ -0,0 +1,150 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"music-service/gen/pb"
+	"music-service/pkg/auth"
+)
+
+// MethodPermissions are the permissions required by the gRPC methods. Methods
+// not listed are denied to every caller, so every registered method must be
+// listed.
+var MethodPermissions = map[string]Permission{
+	pb.MusicService_GetAlbumList_FullMethodName: CatalogRead,
+	pb.MusicService_GetAlbum_FullMethodName:     CatalogRead,
+	pb.MusicService_SearchAlbums_FullMethodName: CatalogRead,
+
+	pb.ArtistService_CreateArtist_FullMethodName:       CatalogWrite,
+	pb.ArtistService_GetArtist_FullMethodName:          CatalogRead,
+	pb.ArtistService_GetArtistList_FullMethodName:      CatalogRead,
+	pb.ArtistService_UpdateArtist_FullMethodName:       CatalogWrite,
+	pb.ArtistService_DeleteArtist_FullMethodName:       CatalogDelete,
+	pb.ArtistService_GetArtistAlbumList_FullMethodName: CatalogRead,
+	pb.LabelService_CreateLabel_FullMethodName:         CatalogWrite,
+	pb.LabelService_GetLabel_FullMethodName:            CatalogRead,
+	pb.LabelService_GetLabelList_FullMethodName:        CatalogRead,
+	pb.LabelService_UpdateLabel_FullMethodName:         CatalogWrite,
+	pb.LabelService_DeleteLabel_FullMethodName:         CatalogDelete,
+
+	pb.InventoryService_GetWarehouseList_FullMethodName:   CatalogRead,
+	pb.InventoryService_GetStock_FullMethodName:           CatalogRead,
+	pb.InventoryService_SetStock_FullMethodName:           CatalogWrite,
+	pb.InventoryService_ReserveStock_FullMethodName:       CatalogWrite,
+	pb.InventoryService_GetReservation_FullMethodName:     CatalogRead,
+	pb.InventoryService_CommitReservation_FullMethodName:  CatalogWrite,
+	pb.InventoryService_ReleaseReservation_FullMethodName: CatalogWrite,
+
+	pb.OrderService_CreateCart_FullMethodName:     CatalogRead,
+	pb.OrderService_GetCart_FullMethodName:        CatalogRead,
+	pb.OrderService_AddCartItem_FullMethodName:    CatalogRead,
+	pb.OrderService_RemoveCartItem_FullMethodName: CatalogRead,
+	pb.OrderService_Checkout_FullMethodName:       CatalogRead,
+	pb.OrderService_GetOrder_FullMethodName:       CatalogRead,
+	pb.OrderService_GetOrderList_FullMethodName:   CatalogRead,
+	pb.OrderService_PayOrder_FullMethodName:       CatalogRead,
+	pb.OrderService_ShipOrder_FullMethodName:      CatalogWrite,
+	pb.OrderService_CancelOrder_FullMethodName:    CatalogRead,
+	pb.OrderService_RefundOrder_FullMethodName:    Admin,
+
+	pb.UserService_CreateUser_FullMethodName:           CatalogRead,
+	pb.UserService_GetUser_FullMethodName:              CatalogRead,
+	pb.UserService_SetReview_FullMethodName:            CatalogRead,
+	pb.UserService_GetAlbumReviewList_FullMethodName:   CatalogRead,
+	pb.UserService_GetUserReviewList_FullMethodName:    CatalogRead,
+	pb.UserService_ModerateReview_FullMethodName:       Admin,
+	pb.UserService_DeleteReview_FullMethodName:         CatalogRead,
+	pb.UserService_AddToWishlist_FullMethodName:        CatalogRead,
+	pb.UserService_RemoveFromWishlist_FullMethodName:   CatalogRead,
+	pb.UserService_GetWishlist_FullMethodName:          CatalogRead,
+	pb.UserService_GetNotificationList_FullMethodName:  CatalogRead,
+	pb.UserService_MarkNotificationRead_FullMethodName: CatalogRead,
+
+	pb.PlaylistService_CreatePlaylist_FullMethodName:             CatalogRead,
+	pb.PlaylistService_GetPlaylist_FullMethodName:                CatalogRead,
+	pb.PlaylistService_GetSharedPlaylist_FullMethodName:          CatalogRead,
+	pb.PlaylistService_GetPublicPlaylistList_FullMethodName:      CatalogRead,
+	pb.PlaylistService_GetUserPlaylistList_FullMethodName:        CatalogRead,
+	pb.PlaylistService_UpdatePlaylist_FullMethodName:             CatalogRead,
+	pb.PlaylistService_DeletePlaylist_FullMethodName:             CatalogRead,
+	pb.PlaylistService_RotateShareToken_FullMethodName:           CatalogRead,
+	pb.PlaylistService_AddPlaylistItem_FullMethodName:            CatalogRead,
+	pb.PlaylistService_RemovePlaylistItem_FullMethodName:         CatalogRead,
+	pb.PlaylistService_MovePlaylistItem_FullMethodName:           CatalogRead,
+	pb.PlaylistService_AddPlaylistCollaborator_FullMethodName:    CatalogRead,
+	pb.PlaylistService_RemovePlaylistCollaborator_FullMethodName: CatalogRead,
+	pb.PlaylistService_ExportPlaylist_FullMethodName:             CatalogRead,
+
+	pb.ConsumerAdminService_GetConsumerStatus_FullMethodName:    Admin,
+	pb.ConsumerAdminService_PauseConsumer_FullMethodName:        Admin,
+	pb.ConsumerAdminService_ResumeConsumer_FullMethodName:       Admin,
+	pb.ConsumerAdminService_ResetConsumerOffsets_FullMethodName: Admin,
+}
+
+// UnaryServerInterceptor fails the unary calls whose caller lacks the
+// permission the methods map requires with Unauthenticated or
+// PermissionDenied, and those of methods the map does not list with
+// PermissionDenied. The public methods, served without a token, are not
+// checked. It must run after the authentication interceptor.
+func UnaryServerInterceptor(authorizer *Authorizer, methods map[string]Permission, publicMethods []string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := authorizeMethod(ctx, authorizer, methods, publicMethods, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming calls.
+func StreamServerInterceptor(authorizer *Authorizer, methods map[string]Permission, publicMethods []string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorizeMethod(stream.Context(), authorizer, methods, publicMethods, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+func authorizeMethod(ctx context.Context, authorizer *Authorizer, methods map[string]Permission, publicMethods []string, fullMethod string) error {
+	if authorizer == nil || auth.IsPublicMethod(fullMethod, publicMethods) {
+		return nil
+	}
+	permission, ok := methods[fullMethod]
+	if !ok {
+		log.Printf("audit: denied %s, which requires no listed permission", fullMethod)
+		return status.Error(codes.PermissionDenied, ErrForbidden.Error())
+	}
+	if err := authorizer.Authorize(ctx, permission, fullMethod); err != nil {
+		if errors.Is(err, ErrUnauthenticated) {
+			return status.Error(codes.Unauthenticated, err.Error())
+		}
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return nil
+}
+
+// ServerOptions returns the options of a gRPC server authenticating its
+// callers with the verifier or the API keys, apart from the public methods,
+// and authorizing them for MethodPermissions. A nil verifier, when
+// authentication is disabled, returns none.
+func ServerOptions(verifier *auth.Verifier, keys auth.KeyAuthenticator, authorizer *Authorizer, publicMethods []string) []grpc.ServerOption {
+	if verifier == nil {
+		return nil
+	}
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(
+			auth.UnaryServerInterceptor(verifier, keys, publicMethods),
+			UnaryServerInterceptor(authorizer, MethodPermissions, publicMethods),
+		),
+		grpc.ChainStreamInterceptor(
+			auth.StreamServerInterceptor(verifier, keys, publicMethods),
+			StreamServerInterceptor(authorizer, MethodPermissions, publicMethods),
+		),
+	}
+}
@@ -0,0 +1,71 @@
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"music-service/gen/pb"
+	"music-service/pkg/auth"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	captureLog(t)
+	interceptor := UnaryServerInterceptor(NewAuthorizer(DefaultPolicy()), MethodPermissions, auth.DefaultPublicMethods)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		method   string
+		wantCode codes.Code
+	}{
+		{name: "viewer reads albums", ctx: withRoles("jane"), method: pb.MusicService_GetAlbum_FullMethodName},
+		{name: "viewer deletes artist", ctx: withRoles("jane", RoleViewer), method: pb.ArtistService_DeleteArtist_FullMethodName, wantCode: codes.PermissionDenied},
+		{name: "editor pauses consumer", ctx: withRoles("jane", RoleEditor), method: pb.ConsumerAdminService_PauseConsumer_FullMethodName, wantCode: codes.PermissionDenied},
+		{name: "admin pauses consumer", ctx: withRoles("jane", RoleAdmin), method: pb.ConsumerAdminService_PauseConsumer_FullMethodName},
+		{name: "unauthenticated", ctx: context.Background(), method: pb.MusicService_GetAlbumList_FullMethodName, wantCode: codes.Unauthenticated},
+		{name: "viewer reads cart", ctx: withRoles("jane", RoleViewer), method: pb.OrderService_GetCart_FullMethodName},
+		{name: "viewer sets stock", ctx: withRoles("jane", RoleViewer), method: pb.InventoryService_SetStock_FullMethodName, wantCode: codes.PermissionDenied},
+		{name: "viewer reserves stock", ctx: withRoles("jane", RoleViewer), method: pb.InventoryService_ReserveStock_FullMethodName, wantCode: codes.PermissionDenied},
+		{name: "viewer ships order", ctx: withRoles("jane", RoleViewer), method: pb.OrderService_ShipOrder_FullMethodName, wantCode: codes.PermissionDenied},
+		{name: "editor ships order", ctx: withRoles("jane", RoleEditor), method: pb.OrderService_ShipOrder_FullMethodName},
+		{name: "editor refunds order", ctx: withRoles("jane", RoleEditor), method: pb.OrderService_RefundOrder_FullMethodName, wantCode: codes.PermissionDenied},
+		{name: "editor moderates review", ctx: withRoles("jane", RoleEditor), method: pb.UserService_ModerateReview_FullMethodName, wantCode: codes.PermissionDenied},
+		{name: "admin moderates review", ctx: withRoles("jane", RoleAdmin), method: pb.UserService_ModerateReview_FullMethodName},
+		{name: "unauthenticated playlist", ctx: context.Background(), method: pb.PlaylistService_CreatePlaylist_FullMethodName, wantCode: codes.Unauthenticated},
+		{name: "viewer reads artist", ctx: withRoles("jane", RoleViewer), method: pb.ArtistService_GetArtist_FullMethodName},
+		{name: "unauthenticated label", ctx: context.Background(), method: pb.LabelService_GetLabelList_FullMethodName, wantCode: codes.Unauthenticated},
+		{name: "unlisted method", ctx: withRoles("jane", RoleAdmin), method: "/service.MusicService/DeleteAlbum", wantCode: codes.PermissionDenied},
+		{name: "public method", ctx: context.Background(), method: "/grpc.health.v1.Health/Check"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := interceptor(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode == codes.OK {
+				assert.Equal(t, "ok", resp)
+			}
+		})
+	}
+}
+
+func TestMethodPermissions_EveryMethod(t *testing.T) {
+	services := pb.File_service_proto.Services()
+	for i := 0; i < services.Len(); i++ {
+		service := services.Get(i)
+		methods := service.Methods()
+		for j := 0; j < methods.Len(); j++ {
+			fullMethod := "/" + string(service.FullName()) + "/" + string(methods.Get(j).Name())
+			_, ok := MethodPermissions[fullMethod]
+			assert.True(t, ok, "%s is not in MethodPermissions", fullMethod)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package authz
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Permission is an operation a role may be granted.
+type Permission string
+
+const (
+	// CatalogRead reads albums and the rest of the catalog, and uses the
+	// carts, orders, users, reviews, wishlists and playlists.
+	CatalogRead Permission = "catalog:read"
+	// CatalogWrite creates and updates albums and their tracks, editions,
+	// genres and tags, and the artists, labels, genres and tags themselves.
+	// It also keeps the stock, warehouses and reservations and ships orders.
+	CatalogWrite Permission = "catalog:write"
+	// CatalogDelete deletes from the catalog.
+	CatalogDelete Permission = "catalog:delete"
+	// PricesWrite sets, removes and schedules the prices of albums.
+	PricesWrite Permission = "prices:write"
+	// Admin controls the Kafka consumers through the admin endpoints, replays
+	// the album topic, manages the API keys, refunds orders and moderates
+	// reviews.
+	Admin Permission = "admin"
+)
+
+var permissions = map[Permission]bool{
+	CatalogRead:   true,
+	CatalogWrite:  true,
+	CatalogDelete: true,
+	PricesWrite:   true,
+	Admin:         true,
+}
+
+const (
+	RoleViewer = "viewer"
+	RoleEditor = "editor"
+	RoleAdmin  = "admin"
+)
+
+// Policy grants permissions to roles. Callers without a known role get the
+// DefaultRole, if any.
+type Policy struct {
+	DefaultRole string                  `yaml:"default_role"`
+	Roles       map[string][]Permission `yaml:"roles"`
+}
+
+// DefaultPolicy lets everyone read, editors write albums and admins also
+// delete, change prices and use the admin endpoints.
+func DefaultPolicy() *Policy {
+	return &Policy{
+		DefaultRole: RoleViewer,
+		Roles: map[string][]Permission{
+			RoleViewer: {CatalogRead},
+			RoleEditor: {CatalogRead, CatalogWrite},
+			RoleAdmin:  {CatalogRead, CatalogWrite, CatalogDelete, PricesWrite, Admin},
+		},
+	}
+}
+
+// LoadPolicy reads the policy from the YAML file, DefaultPolicy when the
+// file name is empty.
+func LoadPolicy(file string) (*Policy, error) {
+	if file == "" {
+		return DefaultPolicy(), nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+	var policy Policy
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file %s: %w", file, err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %w", file, err)
+	}
+	return &policy, nil
+}
+
+func (p *Policy) Validate() error {
+	if p.DefaultRole != "" {
+		if _, ok := p.Roles[p.DefaultRole]; !ok {
+			return fmt.Errorf("default role %q is not defined", p.DefaultRole)
+		}
+	}
+	for role, granted := range p.Roles {
+		for _, permission := range granted {
+			if !permissions[permission] {
+				return fmt.Errorf("role %q is granted unknown permission %q", role, permission)
+			}
+		}
+	}
+	return nil
+}
+
+// HasRole reports whether the policy defines the role.
+func (p *Policy) HasRole(role string) bool {
+	_, ok := p.Roles[role]
+	return ok
+}
+
+// Allows reports whether any of the roles, or the default role when none of
+// them is known, is granted the permission.
+func (p *Policy) Allows(roles []string, permission Permission) bool {
+	known := false
+	for _, role := range roles {
+		granted, ok := p.Roles[role]
+		if !ok {
+			continue
+		}
+		known = true
+		if grants(granted, permission) {
+			return true
+		}
+	}
+	if !known && p.DefaultRole != "" {
+		return grants(p.Roles[p.DefaultRole], permission)
+	}
+	return false
+}
+
+func grants(granted []Permission, permission Permission) bool {
+	for _, v := range granted {
+		if v == permission {
+			return true
+		}
+	}
+	return false
+}
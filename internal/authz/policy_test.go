@@ -0,0 +1,95 @@
+package authz
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultPolicy_Allows(t *testing.T) {
+	policy := DefaultPolicy()
+	require.NoError(t, policy.Validate())
+
+	tests := []struct {
+		name    string
+		roles   []string
+		allowed []Permission
+		denied  []Permission
+	}{
+		{name: "no roles", allowed: []Permission{CatalogRead}, denied: []Permission{CatalogWrite, CatalogDelete, PricesWrite, Admin}},
+		{name: "unknown role", roles: []string{"guest"}, allowed: []Permission{CatalogRead}, denied: []Permission{CatalogWrite}},
+		{name: "viewer", roles: []string{RoleViewer}, allowed: []Permission{CatalogRead}, denied: []Permission{CatalogWrite, PricesWrite}},
+		{name: "editor", roles: []string{RoleEditor}, allowed: []Permission{CatalogRead, CatalogWrite}, denied: []Permission{CatalogDelete, PricesWrite, Admin}},
+		{name: "admin", roles: []string{RoleAdmin}, allowed: []Permission{CatalogRead, CatalogWrite, CatalogDelete, PricesWrite, Admin}},
+		{name: "viewer and editor", roles: []string{RoleViewer, RoleEditor}, allowed: []Permission{CatalogWrite}, denied: []Permission{CatalogDelete}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, permission := range tt.allowed {
+				assert.True(t, policy.Allows(tt.roles, permission), permission)
+			}
+			for _, permission := range tt.denied {
+				assert.False(t, policy.Allows(tt.roles, permission), permission)
+			}
+		})
+	}
+}
+
+func TestPolicy_NoDefaultRole(t *testing.T) {
+	policy := &Policy{Roles: map[string][]Permission{RoleEditor: {CatalogRead, CatalogWrite}}}
+	assert.False(t, policy.Allows(nil, CatalogRead))
+	assert.True(t, policy.Allows([]string{RoleEditor}, CatalogWrite))
+}
+
+func TestLoadPolicy(t *testing.T) {
+	policy, err := LoadPolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPolicy(), policy)
+
+	file := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+default_role: viewer
+roles:
+  viewer: [catalog:read]
+  pricing: [catalog:read, prices:write]
+`), 0600))
+	policy, err = LoadPolicy(file)
+	require.NoError(t, err)
+	assert.True(t, policy.Allows([]string{"pricing"}, PricesWrite))
+	assert.False(t, policy.Allows([]string{"pricing"}, CatalogWrite))
+
+	_, err = LoadPolicy(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+	}{
+		{name: "unknown permission", policy: Policy{Roles: map[string][]Permission{RoleAdmin: {"catalog:purge"}}}},
+		{name: "undefined default role", policy: Policy{DefaultRole: RoleViewer, Roles: map[string][]Permission{RoleAdmin: {Admin}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, tt.policy.Validate())
+		})
+	}
+}
+
+func TestLoadPolicy_RepositoryFile(t *testing.T) {
+	policy, err := LoadPolicy("../../policy.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPolicy(), policy)
+}
+
+func TestPolicy_HasRole(t *testing.T) {
+	policy := DefaultPolicy()
+	assert.True(t, policy.HasRole(RoleEditor))
+	assert.False(t, policy.HasRole("guest"))
+}
@@ -0,0 +1,203 @@
+package cdc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"maps"
+	"slices"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/lib/pq"
+
+	"music-service/gen/pb"
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+	"music-service/pkg/kafka"
+)
+
+// Channel is the channel the triggers of sql/ddl/create_trigger_albums_changes.sql
+// notify on.
+const Channel = "album_changes"
+
+const (
+	OpInsert   = "INSERT"
+	OpUpdate   = "UPDATE"
+	OpDelete   = "DELETE"
+	OpTruncate = "TRUNCATE"
+)
+
+// Change is the payload of a notification. Id is zero for truncates.
+type Change struct {
+	Op string `json:"op"`
+	Id int    `json:"id"`
+}
+
+// Publisher keeps the snapshot topic in line with music.albums and the tracks
+// of the albums. Notifications only carry the id, so the current state is read
+// back from the tables and changes that were superseded before being published
+// collapse into one.
+type Publisher struct {
+	repository orm.Repository
+	tracks     orm.TrackRepository
+	producer   kafka.SnapshotProducer
+	published  map[int]struct{}
+	// loaded is whether the ids in the snapshot topic were added to
+	// published, which the first sync does.
+	loaded bool
+}
+
+func NewPublisher(repository orm.Repository, tracks orm.TrackRepository, producer kafka.SnapshotProducer) *Publisher {
+	return &Publisher{repository: repository, tracks: tracks, producer: producer, published: make(map[int]struct{})}
+}
+
+// Run publishes every album and then the changes of the notifications until
+// the context is done. A nil notification, which pq.Listener sends after it
+// reconnected, publishes every album again because changes may have been
+// missed in the meantime.
+func (p *Publisher) Run(ctx context.Context, notifications <-chan *pq.Notification) error {
+	if err := p.Sync(ctx); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case notification := <-notifications:
+			if notification == nil {
+				log.Printf("reconnected to postgres, publishing every album again")
+				if err := p.Sync(ctx); err != nil {
+					return err
+				}
+				continue
+			}
+
+			change := Change{}
+			if err := json.Unmarshal([]byte(notification.Extra), &change); err != nil {
+				log.Printf("skipping notification %q: %v", notification.Extra, err)
+				continue
+			}
+			if err := p.Apply(ctx, change); err != nil {
+				return err
+			}
+
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Sync publishes every album and a tombstone for every published album that
+// no longer exists. The first sync reads the albums published so far from the
+// snapshot topic, so albums deleted while the publisher was not running are
+// tombstoned too.
+func (p *Publisher) Sync(ctx context.Context) error {
+	if !p.loaded {
+		ids, err := p.producer.Published(ctx)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			p.published[int(id)] = struct{}{}
+		}
+		p.loaded = true
+	}
+
+	albums, err := p.repository.Get()
+	if err != nil {
+		return err
+	}
+	tracks, err := p.tracks.Get()
+	if err != nil {
+		return err
+	}
+	tracksByAlbum := make(map[int][]*models.Track)
+	for _, track := range tracks {
+		tracksByAlbum[track.AlbumId] = append(tracksByAlbum[track.AlbumId], track)
+	}
+
+	current := make(map[int]struct{}, len(albums))
+	for _, album := range albums {
+		if err := p.publish(ctx, album, tracksByAlbum[album.Id]); err != nil {
+			return err
+		}
+		current[album.Id] = struct{}{}
+	}
+	for _, id := range slices.Sorted(maps.Keys(p.published)) {
+		if _, ok := current[id]; !ok {
+			if err := p.tombstone(ctx, id); err != nil {
+				return err
+			}
+		}
+	}
+	log.Printf("published %d albums", len(albums))
+	return nil
+}
+
+// Apply publishes the current state of the changed album.
+func (p *Publisher) Apply(ctx context.Context, change Change) error {
+	switch change.Op {
+	case OpTruncate:
+		return p.Sync(ctx)
+	case OpDelete:
+		return p.tombstone(ctx, change.Id)
+	}
+
+	album, err := p.repository.GetById(change.Id)
+	if errors.Is(err, pg.ErrNoRows) {
+		// The album was deleted before the notification arrived.
+		return p.tombstone(ctx, change.Id)
+	}
+	if err != nil {
+		return err
+	}
+	tracks, err := p.tracks.GetByAlbum(change.Id)
+	if err != nil {
+		return err
+	}
+	return p.publish(ctx, album, tracks)
+}
+
+func (p *Publisher) publish(ctx context.Context, album *models.Album, tracks []*models.Track) error {
+	price, _ := album.Price.Float64()
+	rating, _ := album.AverageRating.Float64()
+	var trackList []*pb.Track
+	for _, track := range tracks {
+		trackList = append(trackList, &pb.Track{
+			Id:         int32(track.Id),
+			AlbumId:    int32(track.AlbumId),
+			Disc:       int32(track.Disc),
+			Number:     int32(track.Number),
+			Title:      track.Title,
+			DurationMs: int32(track.DurationMs),
+			Isrc:       track.Isrc,
+		})
+	}
+	err := p.producer.Publish(ctx, int32(album.Id), &pb.Album{
+		Id:            int32(album.Id),
+		Title:         album.Title,
+		Artist:        album.Artist,
+		Price:         float32(price),
+		Currency:      album.Currency,
+		ArtistId:      int32(album.ArtistId),
+		Tracks:        trackList,
+		ReleaseDate:   album.ReleaseDate.String(),
+		LabelId:       int32(album.LabelId),
+		AverageRating: float32(rating),
+		RatingCount:   int32(album.RatingCount),
+	})
+	if err != nil {
+		return err
+	}
+	p.published[album.Id] = struct{}{}
+	return nil
+}
+
+func (p *Publisher) tombstone(ctx context.Context, id int) error {
+	if err := p.producer.Publish(ctx, int32(id), nil); err != nil {
+		return err
+	}
+	delete(p.published, id)
+	log.Printf("published tombstone for album %d", id)
+	return nil
+}
@@ -0,0 +1,235 @@
+package cdc
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"music-service/gen/pb"
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+)
+
+// fakeRepository serves albums from a map, ordered by id
+type fakeRepository struct {
+	albums map[int]models.Album
+}
+
+func (r *fakeRepository) Create(album models.Album) error {
+	r.albums[album.Id] = album
+	return nil
+}
+
+func (r *fakeRepository) GetById(id int) (*models.Album, error) {
+	album, ok := r.albums[id]
+	if !ok {
+		return &models.Album{}, pg.ErrNoRows
+	}
+	return &album, nil
+}
+
+func (r *fakeRepository) Get() ([]*models.Album, error) {
+	albums := []*models.Album{}
+	for _, album := range r.albums {
+		albums = append(albums, &album)
+	}
+	slices.SortFunc(albums, func(a, b *models.Album) int { return a.Id - b.Id })
+	return albums, nil
+}
+
+func (r *fakeRepository) Update(album models.Album) error {
+	r.albums[album.Id] = album
+	return nil
+}
+
+func (r *fakeRepository) Upsert(album models.Album) error {
+	r.albums[album.Id] = album
+	return nil
+}
+
+// fakeTrackRepository serves the tracks of album 1
+type fakeTrackRepository struct {
+	orm.TrackRepository
+}
+
+func (r *fakeTrackRepository) GetByAlbum(albumId int) ([]*models.Track, error) {
+	tracks := []*models.Track{}
+	for _, track := range newTracks() {
+		if track.AlbumId == albumId {
+			tracks = append(tracks, track)
+		}
+	}
+	return tracks, nil
+}
+
+func (r *fakeTrackRepository) Get() ([]*models.Track, error) {
+	return newTracks(), nil
+}
+
+func newTracks() []*models.Track {
+	return []*models.Track{
+		{Id: 1, AlbumId: 1, Disc: 1, Number: 1, Title: "Blue Train", DurationMs: 643000},
+		{Id: 2, AlbumId: 1, Disc: 1, Number: 2, Title: "Moment's Notice", DurationMs: 551000},
+	}
+}
+
+type published struct {
+	id    int32
+	album *pb.Album
+}
+
+// recordingProducer records the published messages, and serves the ids in
+// the topic when the publisher starts
+type recordingProducer struct {
+	messages []published
+	inTopic  []int32
+	err      error
+}
+
+func (p *recordingProducer) Publish(ctx context.Context, id int32, album *pb.Album) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.messages = append(p.messages, published{id: id, album: album})
+	return nil
+}
+
+func (p *recordingProducer) Published(ctx context.Context) ([]int32, error) {
+	return p.inTopic, p.err
+}
+
+func (p *recordingProducer) Close() error {
+	return nil
+}
+
+func newRepository() *fakeRepository {
+	return &fakeRepository{albums: map[int]models.Album{
+		1: {Id: 1, Title: "Blue Train", Artist: "John Coltrane", Price: decimal.NewFromFloat(56.99)},
+		2: {Id: 2, Title: "Jeru", Artist: "Gerry Mulligan", Price: decimal.NewFromFloat(17.99)},
+	}}
+}
+
+var blueTrainTracks = []*pb.Track{
+	{Id: 1, AlbumId: 1, Disc: 1, Number: 1, Title: "Blue Train", DurationMs: 643000},
+	{Id: 2, AlbumId: 1, Disc: 1, Number: 2, Title: "Moment's Notice", DurationMs: 551000},
+}
+
+func TestPublisher_Sync(t *testing.T) {
+	repository := newRepository()
+	producer := &recordingProducer{}
+	publisher := NewPublisher(repository, &fakeTrackRepository{}, producer)
+
+	assert.NoError(t, publisher.Sync(context.Background()))
+	assert.Equal(t, []published{
+		{id: 1, album: &pb.Album{Id: 1, Title: "Blue Train", Artist: "John Coltrane", Price: 56.99, Tracks: blueTrainTracks}},
+		{id: 2, album: &pb.Album{Id: 2, Title: "Jeru", Artist: "Gerry Mulligan", Price: 17.99}},
+	}, producer.messages)
+
+	// albums deleted while no notifications arrived are tombstoned on the next sync
+	delete(repository.albums, 1)
+	producer.messages = nil
+
+	assert.NoError(t, publisher.Sync(context.Background()))
+	assert.Len(t, producer.messages, 2)
+	assert.Equal(t, published{id: 1}, producer.messages[1])
+}
+
+func TestPublisher_Sync_Restarted(t *testing.T) {
+	// album 5 was deleted while the publisher was not running
+	producer := &recordingProducer{inTopic: []int32{1, 5}}
+	publisher := NewPublisher(newRepository(), &fakeTrackRepository{}, producer)
+
+	assert.NoError(t, publisher.Sync(context.Background()))
+	assert.Len(t, producer.messages, 3)
+	assert.Equal(t, published{id: 5}, producer.messages[2])
+
+	producer.messages = nil
+	assert.NoError(t, publisher.Sync(context.Background()))
+	assert.Len(t, producer.messages, 2)
+}
+
+func TestPublisher_Apply(t *testing.T) {
+	tests := []struct {
+		name   string
+		change Change
+		want   []published
+	}{
+		{
+			name:   "insert publishes the current state",
+			change: Change{Op: OpInsert, Id: 2},
+			want:   []published{{id: 2, album: &pb.Album{Id: 2, Title: "Jeru", Artist: "Gerry Mulligan", Price: 17.99}}},
+		},
+		{
+			name:   "update publishes the tracks of the album",
+			change: Change{Op: OpUpdate, Id: 1},
+			want:   []published{{id: 1, album: &pb.Album{Id: 1, Title: "Blue Train", Artist: "John Coltrane", Price: 56.99, Tracks: blueTrainTracks}}},
+		},
+		{
+			name:   "delete publishes a tombstone",
+			change: Change{Op: OpDelete, Id: 1},
+			want:   []published{{id: 1}},
+		},
+		{
+			name:   "update of a deleted album publishes a tombstone",
+			change: Change{Op: OpUpdate, Id: 3},
+			want:   []published{{id: 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			producer := &recordingProducer{}
+			publisher := NewPublisher(newRepository(), &fakeTrackRepository{}, producer)
+
+			assert.NoError(t, publisher.Apply(context.Background(), tt.change))
+			assert.Equal(t, tt.want, producer.messages)
+		})
+	}
+}
+
+func TestPublisher_Apply_Error(t *testing.T) {
+	publisher := NewPublisher(newRepository(), &fakeTrackRepository{}, &recordingProducer{err: errors.New("broker unavailable")})
+
+	err := publisher.Apply(context.Background(), Change{Op: OpInsert, Id: 1})
+
+	assert.EqualError(t, err, "broker unavailable")
+}
+
+func TestPublisher_Run(t *testing.T) {
+	producer := &recordingProducer{}
+	publisher := NewPublisher(newRepository(), &fakeTrackRepository{}, producer)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	notifications := make(chan *pq.Notification)
+	done := make(chan error)
+	go func() { done <- publisher.Run(ctx, notifications) }()
+
+	notifications <- &pq.Notification{Channel: Channel, Extra: `{"op":"DELETE","id":2}`}
+	notifications <- &pq.Notification{Channel: Channel, Extra: `not json`}
+	notifications <- nil
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("publisher did not stop")
+	}
+	assert.Equal(t, []int32{1, 2, 2, 1, 2}, ids(producer.messages))
+	assert.Nil(t, producer.messages[2].album)
+}
+
+func ids(messages []published) []int32 {
+	ids := []int32{}
+	for _, m := range messages {
+		ids = append(ids, m.id)
+	}
+	return ids
+}
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"music-service/pkg/auth"
 	"music-service/pkg/grpc"
 	"music-service/pkg/kafka"
+	"music-service/pkg/payment"
 	"music-service/pkg/postgres"
 	"music-service/pkg/rest"
 )
@@ -12,4 +14,6 @@ type Config struct {
 	Postgres postgres.Config `yaml:"postgres"`
 	Kafka    kafka.Config    `yaml:"kafka"`
 	Rest     rest.Config     `yaml:"rest"`
+	Payment  payment.Config  `yaml:"payment"`
+	Auth     auth.Config     `yaml:"auth"`
 }
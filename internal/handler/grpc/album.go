@@ -2,9 +2,17 @@ package grpc
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"log"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"music-service/gen/pb"
+	"music-service/internal/models"
 	"music-service/internal/repository/postgres/sqlx"
 )
 
@@ -26,13 +34,133 @@ func (h *albumHandler) GetAlbumList(ctx context.Context, req *pb.GetAlbumsReques
 		return nil, err
 	}
 
-	albums, err := getAlbumList(h.Repository)
+	filter := models.AlbumFilter{
+		ArtistId: int(req.ArtistId),
+		GenreId:  int(req.GenreId),
+		Tag:      strings.TrimSpace(req.Tag),
+		MinPrice: decimal.NewFromFloat32(req.MinPrice),
+		MaxPrice: decimal.NewFromFloat32(req.MaxPrice),
+	}
+
+	var albums []*pb.Album
+	var err error
+	if filter.IsZero() {
+		albums, err = getAlbumList(h.Repository)
+	} else {
+		albums, err = getFilteredAlbumList(h.Repository, filter)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	return &pb.GetAlbumsResponse{
+	resp := &pb.GetAlbumsResponse{
 		Albums: albums,
+	}
+	if req.Facets {
+		facets, err := h.Repository.ReadFacets(filter)
+		if err != nil {
+			return nil, err
+		}
+		resp.Facets = toFacetsProto(facets)
+	}
+	return resp, nil
+}
+
+// GetAlbum returns the album with its tracks ordered by disc and number, its
+// editions and its prices.
+func (h *albumHandler) GetAlbum(ctx context.Context, req *pb.GetAlbumRequest) (*pb.Album, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	album, err := h.Repository.ReadById(int(req.Id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "album not found")
+		}
+		return nil, err
+	}
+	tracks, err := h.Repository.ReadTracks(album.Id)
+	if err != nil {
+		return nil, err
+	}
+	editions, err := h.Repository.ReadEditions(album.Id)
+	if err != nil {
+		return nil, err
+	}
+	prices, err := h.Repository.ReadPrices(album.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	trackList := make([]*pb.Track, len(tracks))
+	for i, v := range tracks {
+		trackList[i] = &pb.Track{
+			Id:         int32(v.Id),
+			AlbumId:    int32(v.AlbumId),
+			Disc:       int32(v.Disc),
+			Number:     int32(v.Number),
+			Title:      v.Title,
+			DurationMs: int32(v.DurationMs),
+			Isrc:       v.Isrc,
+		}
+	}
+	editionList := make([]*pb.Edition, len(editions))
+	for i, v := range editions {
+		priceF64, _ := v.Price.Float64()
+		editionList[i] = &pb.Edition{
+			Id:            int32(v.Id),
+			AlbumId:       int32(v.AlbumId),
+			Format:        v.Format,
+			Name:          v.Name,
+			Sku:           v.Sku,
+			Barcode:       v.Barcode,
+			CatalogNumber: v.CatalogNumber,
+			Price:         float32(priceF64),
+		}
+	}
+	priceList := make([]*pb.Price, len(prices))
+	for i, v := range prices {
+		priceF64, _ := v.Price.Float64()
+		priceList[i] = &pb.Price{
+			Currency: v.Currency,
+			Price:    float32(priceF64),
+		}
+	}
+	resp := toAlbumProto(album)
+	resp.Tracks = trackList
+	resp.Editions = editionList
+	resp.Prices = priceList
+	return resp, nil
+}
+
+// SearchAlbums returns the albums whose title or artist name matches the
+// query, best first.
+func (h *albumHandler) SearchAlbums(ctx context.Context, req *pb.SearchAlbumsRequest) (*pb.SearchAlbumsResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+	results, err := h.Repository.Search(query, models.SearchLimit(int(req.Limit)))
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]*pb.AlbumMatch, len(results))
+	for i, v := range results {
+		matches[i] = &pb.AlbumMatch{
+			Album:           toAlbumProto(v.Album),
+			Rank:            float32(v.Rank),
+			TitleHighlight:  v.TitleHighlight,
+			ArtistHighlight: v.ArtistHighlight,
+		}
+	}
+	return &pb.SearchAlbumsResponse{
+		Results: matches,
 	}, nil
 }
 
@@ -41,16 +169,70 @@ func getAlbumList(repository sqlx.Repository) ([]*pb.Album, error) {
 	if err != nil {
 		return nil, err
 	}
+	return toAlbumList(albums), nil
+}
 
+func getFilteredAlbumList(repository sqlx.Repository, filter models.AlbumFilter) ([]*pb.Album, error) {
+	albums, err := repository.ReadFiltered(filter)
+	if err != nil {
+		return nil, err
+	}
+	return toAlbumList(albums), nil
+}
+
+func toAlbumList(albums []models.Album) []*pb.Album {
 	albumList := make([]*pb.Album, len(albums))
 	for i, v := range albums {
-		priceF64, _ := v.Price.Float64()
-		albumList[i] = &pb.Album{
-			Id:     int32(v.Id),
-			Title:  v.Title,
-			Artist: v.Artist,
-			Price:  float32(priceF64),
+		albumList[i] = toAlbumProto(v)
+	}
+	return albumList
+}
+
+func toAlbumProto(album models.Album) *pb.Album {
+	priceF64, _ := album.Price.Float64()
+	ratingF64, _ := album.AverageRating.Float64()
+	return &pb.Album{
+		Id:            int32(album.Id),
+		Title:         album.Title,
+		Artist:        album.Artist,
+		Price:         float32(priceF64),
+		Currency:      album.Currency,
+		ArtistId:      int32(album.ArtistId),
+		ReleaseDate:   album.ReleaseDate.String(),
+		LabelId:       int32(album.LabelId),
+		AverageRating: float32(ratingF64),
+		RatingCount:   int32(album.RatingCount),
+	}
+}
+
+func toFacetsProto(facets *models.Facets) *pb.Facets {
+	prices := make([]*pb.PriceFacet, len(facets.Prices))
+	for i, v := range facets.Prices {
+		minF64, _ := v.Min.Float64()
+		maxF64, _ := v.Max.Float64()
+		prices[i] = &pb.PriceFacet{
+			Min:   float32(minF64),
+			Max:   float32(maxF64),
+			Count: int32(v.Count),
+		}
+	}
+	return &pb.Facets{
+		Genres:  toFacetCounts(facets.Genres),
+		Tags:    toFacetCounts(facets.Tags),
+		Artists: toFacetCounts(facets.Artists),
+		Prices:  prices,
+	}
+}
+
+func toFacetCounts(counts []models.FacetCount) []*pb.FacetCount {
+	list := make([]*pb.FacetCount, len(counts))
+	for i, v := range counts {
+		list[i] = &pb.FacetCount{
+			Id:       int32(v.Id),
+			Name:     v.Name,
+			ParentId: int32(v.ParentId),
+			Count:    int32(v.Count),
 		}
 	}
-	return albumList, nil
+	return list
 }
@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 
@@ -9,10 +10,19 @@ import (
 	"music-service/internal/models"
 
 	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type MockRepository struct {
-	ReadFunc func() ([]models.Album, error)
+	ReadFunc         func() ([]models.Album, error)
+	ReadByIdFunc     func(id int) (models.Album, error)
+	ReadTracksFunc   func(albumId int) ([]models.Track, error)
+	ReadEditionsFunc func(albumId int) ([]models.Edition, error)
+	ReadPricesFunc   func(albumId int) ([]models.Price, error)
+	ReadFilteredFunc func(filter models.AlbumFilter) ([]models.Album, error)
+	ReadFacetsFunc   func(filter models.AlbumFilter) (*models.Facets, error)
+	SearchFunc       func(query string, limit int) ([]models.SearchResult, error)
 }
 
 func (m *MockRepository) Read() ([]models.Album, error) {
@@ -22,6 +32,55 @@ func (m *MockRepository) Read() ([]models.Album, error) {
 	return []models.Album{}, nil
 }
 
+func (m *MockRepository) ReadById(id int) (models.Album, error) {
+	if m.ReadByIdFunc != nil {
+		return m.ReadByIdFunc(id)
+	}
+	return models.Album{Id: id}, nil
+}
+
+func (m *MockRepository) ReadTracks(albumId int) ([]models.Track, error) {
+	if m.ReadTracksFunc != nil {
+		return m.ReadTracksFunc(albumId)
+	}
+	return []models.Track{}, nil
+}
+
+func (m *MockRepository) ReadEditions(albumId int) ([]models.Edition, error) {
+	if m.ReadEditionsFunc != nil {
+		return m.ReadEditionsFunc(albumId)
+	}
+	return []models.Edition{}, nil
+}
+
+func (m *MockRepository) ReadPrices(albumId int) ([]models.Price, error) {
+	if m.ReadPricesFunc != nil {
+		return m.ReadPricesFunc(albumId)
+	}
+	return []models.Price{}, nil
+}
+
+func (m *MockRepository) ReadFiltered(filter models.AlbumFilter) ([]models.Album, error) {
+	if m.ReadFilteredFunc != nil {
+		return m.ReadFilteredFunc(filter)
+	}
+	return []models.Album{}, nil
+}
+
+func (m *MockRepository) ReadFacets(filter models.AlbumFilter) (*models.Facets, error) {
+	if m.ReadFacetsFunc != nil {
+		return m.ReadFacetsFunc(filter)
+	}
+	return models.NewFacets(nil), nil
+}
+
+func (m *MockRepository) Search(query string, limit int) ([]models.SearchResult, error) {
+	if m.SearchFunc != nil {
+		return m.SearchFunc(query, limit)
+	}
+	return []models.SearchResult{}, nil
+}
+
 func TestNewHandler(t *testing.T) {
 	mockRepo := &MockRepository{}
 	srv := NewAlbumHandler(mockRepo)
@@ -244,6 +303,122 @@ func TestServer_GetAlbumList_ContextHandling(t *testing.T) {
 	}
 }
 
+func TestHandler_GetAlbumList_Filtered(t *testing.T) {
+	var filtered models.AlbumFilter
+	mockRepo := &MockRepository{
+		ReadFunc: func() ([]models.Album, error) {
+			t.Error("Expected the filtered albums to be read")
+			return nil, nil
+		},
+		ReadFilteredFunc: func(filter models.AlbumFilter) ([]models.Album, error) {
+			filtered = filter
+			return []models.Album{{Id: 1, Title: "Blue Train", Artist: "John Coltrane", Price: decimal.NewFromFloat(56.99)}}, nil
+		},
+		ReadFacetsFunc: func(filter models.AlbumFilter) (*models.Facets, error) {
+			return models.NewFacets([]models.FacetRow{
+				{Facet: "genre", Id: 2, Name: "Hard Bop", ParentId: 1, Count: 1},
+				{Facet: "price", Id: 4, LowerPrice: decimal.NewFromInt(50), UpperPrice: decimal.NewFromInt(100), Count: 1},
+			}), nil
+		},
+	}
+	srv := NewAlbumHandler(mockRepo)
+
+	resp, err := srv.GetAlbumList(context.Background(), &pb.GetAlbumsRequest{GenreId: 1, Tag: " live ", MaxPrice: 60, Facets: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if filtered.GenreId != 1 || filtered.Tag != "live" || !filtered.MaxPrice.Equal(decimal.NewFromInt(60)) {
+		t.Errorf("Expected genre 1, tag 'live' and a maximum price of 60, got %v", filtered)
+	}
+	if len(resp.Albums) != 1 {
+		t.Errorf("Expected 1 album, got %d", len(resp.Albums))
+	}
+	if len(resp.Facets.GetGenres()) != 1 || resp.Facets.Genres[0].ParentId != 1 {
+		t.Errorf("Expected the Hard Bop facet, got %v", resp.Facets.GetGenres())
+	}
+	if len(resp.Facets.GetPrices()) != 1 || resp.Facets.Prices[0].Max != 100 {
+		t.Errorf("Expected the price facet up to 100, got %v", resp.Facets.GetPrices())
+	}
+}
+
+func TestHandler_GetAlbumList_NoFacets(t *testing.T) {
+	srv := NewAlbumHandler(&MockRepository{
+		ReadFacetsFunc: func(filter models.AlbumFilter) (*models.Facets, error) {
+			t.Error("Expected facets not to be read")
+			return nil, nil
+		},
+	})
+
+	resp, err := srv.GetAlbumList(context.Background(), &pb.GetAlbumsRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Facets != nil {
+		t.Errorf("Expected no facets, got %v", resp.Facets)
+	}
+}
+
+func TestHandler_GetAlbum(t *testing.T) {
+	mockRepo := &MockRepository{
+		ReadByIdFunc: func(id int) (models.Album, error) {
+			releaseDate, _ := models.ParseDate("1958-01-01")
+			return models.Album{Id: id, Title: "Blue Train", Artist: "John Coltrane", Price: decimal.NewFromFloat(56.99), LabelId: 4, ReleaseDate: releaseDate}, nil
+		},
+		ReadTracksFunc: func(albumId int) ([]models.Track, error) {
+			return []models.Track{
+				{Id: 1, AlbumId: albumId, Disc: 1, Number: 1, Title: "Blue Train", DurationMs: 643000, Isrc: "USBN15700001"},
+				{Id: 2, AlbumId: albumId, Disc: 1, Number: 2, Title: "Moment's Notice", DurationMs: 551000},
+			}, nil
+		},
+		ReadEditionsFunc: func(albumId int) ([]models.Edition, error) {
+			return []models.Edition{
+				{Id: 1, AlbumId: albumId, Format: models.FormatVinyl, Sku: "BN-1577-LP", Barcode: "0602537335138", Price: decimal.NewFromFloat(29.99)},
+			}, nil
+		},
+		ReadPricesFunc: func(albumId int) ([]models.Price, error) {
+			return []models.Price{
+				{AlbumId: albumId, Currency: "USD", Price: decimal.NewFromFloat(56.99)},
+				{AlbumId: albumId, Currency: "EUR", Price: decimal.NewFromFloat(52.99)},
+			}, nil
+		},
+	}
+	srv := NewAlbumHandler(mockRepo)
+
+	album, err := srv.GetAlbum(context.Background(), &pb.GetAlbumRequest{Id: 1})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if album.Title != "Blue Train" {
+		t.Errorf("Expected title 'Blue Train', got '%s'", album.Title)
+	}
+	if len(album.Tracks) != 2 || album.Tracks[1].Number != 2 || album.Tracks[0].Isrc != "USBN15700001" {
+		t.Errorf("Expected the two tracks of the album, got %v", album.Tracks)
+	}
+	if album.ReleaseDate != "1958-01-01" || album.LabelId != 4 {
+		t.Errorf("Expected release date 1958-01-01 of label 4, got %s of label %d", album.ReleaseDate, album.LabelId)
+	}
+	if len(album.Editions) != 1 || album.Editions[0].Sku != "BN-1577-LP" {
+		t.Errorf("Expected the vinyl edition of the album, got %v", album.Editions)
+	}
+	if len(album.Prices) != 2 || album.Prices[1].Currency != "EUR" {
+		t.Errorf("Expected the USD and EUR prices of the album, got %v", album.Prices)
+	}
+}
+
+func TestHandler_GetAlbum_NotFound(t *testing.T) {
+	mockRepo := &MockRepository{
+		ReadByIdFunc: func(id int) (models.Album, error) {
+			return models.Album{}, sql.ErrNoRows
+		},
+	}
+	srv := NewAlbumHandler(mockRepo)
+
+	_, err := srv.GetAlbum(context.Background(), &pb.GetAlbumRequest{Id: 1})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
 func BenchmarkServer_GetAlbumList(b *testing.B) {
 	mockRepo := &MockRepository{
 		ReadFunc: func() ([]models.Album, error) {
@@ -264,3 +439,37 @@ func BenchmarkServer_GetAlbumList(b *testing.B) {
 		_, _ = srv.GetAlbumList(ctx, req)
 	}
 }
+
+func TestHandler_SearchAlbums(t *testing.T) {
+	var searched string
+	var limit int
+	mockRepo := &MockRepository{
+		SearchFunc: func(query string, l int) ([]models.SearchResult, error) {
+			searched, limit = query, l
+			return []models.SearchResult{
+				{
+					Album:          models.Album{Id: 2, Title: "Giant Steps", Artist: "John Coltrane"},
+					Rank:           0.6,
+					TitleHighlight: "<mark>Giant</mark> Steps",
+				},
+			}, nil
+		},
+	}
+	srv := NewAlbumHandler(mockRepo)
+
+	resp, err := srv.SearchAlbums(context.Background(), &pb.SearchAlbumsRequest{Query: " giant "})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if searched != "giant" || limit != models.DefaultSearchLimit {
+		t.Errorf("Expected a search for 'giant' limited to %d, got '%s' limited to %d", models.DefaultSearchLimit, searched, limit)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].Album.Title != "Giant Steps" || resp.Results[0].TitleHighlight != "<mark>Giant</mark> Steps" {
+		t.Errorf("Expected 'Giant Steps' with its highlight, got %v", resp.Results)
+	}
+
+	_, err = srv.SearchAlbums(context.Background(), &pb.SearchAlbumsRequest{Query: " "})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an empty query, got %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/go-pg/pg/v10"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"music-service/gen/pb"
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+)
+
+type artistHandler struct {
+	pb.UnimplementedArtistServiceServer
+	repository orm.ArtistRepository
+}
+
+func NewArtistHandler(repository orm.ArtistRepository) pb.ArtistServiceServer {
+	return &artistHandler{
+		repository: repository,
+	}
+}
+
+func (h *artistHandler) CreateArtist(ctx context.Context, req *pb.Artist) (*pb.Artist, error) {
+	artist := toArtistModel(req)
+	artist.Id = 0
+	if artist.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	if err := h.repository.Create(&artist); err != nil {
+		return nil, toArtistStatusError(err)
+	}
+	return toArtistProto(&artist), nil
+}
+
+func (h *artistHandler) GetArtist(ctx context.Context, req *pb.GetArtistRequest) (*pb.Artist, error) {
+	artist, err := h.repository.GetById(int(req.Id))
+	if err != nil {
+		return nil, toArtistStatusError(err)
+	}
+	return toArtistProto(artist), nil
+}
+
+func (h *artistHandler) GetArtistList(ctx context.Context, req *pb.GetArtistsRequest) (*pb.GetArtistsResponse, error) {
+	artists, err := h.repository.Get()
+	if err != nil {
+		return nil, toArtistStatusError(err)
+	}
+
+	artistList := make([]*pb.Artist, len(artists))
+	for i, artist := range artists {
+		artistList[i] = toArtistProto(artist)
+	}
+	return &pb.GetArtistsResponse{
+		Artists: artistList,
+	}, nil
+}
+
+func (h *artistHandler) UpdateArtist(ctx context.Context, req *pb.Artist) (*pb.Artist, error) {
+	artist := toArtistModel(req)
+	if artist.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	if err := h.repository.Update(artist); err != nil {
+		return nil, toArtistStatusError(err)
+	}
+	return toArtistProto(&artist), nil
+}
+
+func (h *artistHandler) DeleteArtist(ctx context.Context, req *pb.DeleteArtistRequest) (*pb.DeleteArtistResponse, error) {
+	if err := h.repository.Delete(int(req.Id)); err != nil {
+		return nil, toArtistStatusError(err)
+	}
+	return &pb.DeleteArtistResponse{}, nil
+}
+
+func (h *artistHandler) GetArtistAlbumList(ctx context.Context, req *pb.GetArtistAlbumsRequest) (*pb.GetAlbumsResponse, error) {
+	if _, err := h.repository.GetById(int(req.ArtistId)); err != nil {
+		return nil, toArtistStatusError(err)
+	}
+	albums, err := h.repository.GetAlbums(int(req.ArtistId))
+	if err != nil {
+		return nil, toArtistStatusError(err)
+	}
+
+	albumList := make([]*pb.Album, len(albums))
+	for i, v := range albums {
+		albumList[i] = toAlbumProto(*v)
+	}
+	return &pb.GetAlbumsResponse{
+		Albums: albumList,
+	}, nil
+}
+
+func toArtistModel(artist *pb.Artist) models.Artist {
+	return models.Artist{
+		Id:      int(artist.Id),
+		Name:    strings.TrimSpace(artist.Name),
+		Country: artist.Country,
+		Bio:     artist.Bio,
+	}
+}
+
+func toArtistProto(artist *models.Artist) *pb.Artist {
+	return &pb.Artist{
+		Id:      int32(artist.Id),
+		Name:    artist.Name,
+		Country: artist.Country,
+		Bio:     artist.Bio,
+	}
+}
+
+func toArtistStatusError(err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return status.Error(codes.NotFound, "artist not found")
+	case orm.IsConflict(err):
+		return status.Error(codes.FailedPrecondition, "artist conflicts with an existing artist or still has albums")
+	case orm.IsInvalid(err):
+		return status.Error(codes.InvalidArgument, "invalid artist")
+	}
+	return status.Error(codes.Internal, err.Error())
+}
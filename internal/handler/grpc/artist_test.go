@@ -0,0 +1,115 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"music-service/gen/pb"
+	"music-service/internal/models"
+)
+
+type MockArtistRepository struct {
+	CreateFunc    func(artist *models.Artist) error
+	GetByIdFunc   func(id int) (*models.Artist, error)
+	GetFunc       func() ([]*models.Artist, error)
+	UpdateFunc    func(artist models.Artist) error
+	DeleteFunc    func(id int) error
+	GetAlbumsFunc func(id int) ([]*models.Album, error)
+}
+
+func (m *MockArtistRepository) Create(artist *models.Artist) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(artist)
+	}
+	return nil
+}
+
+func (m *MockArtistRepository) GetById(id int) (*models.Artist, error) {
+	if m.GetByIdFunc != nil {
+		return m.GetByIdFunc(id)
+	}
+	return &models.Artist{Id: id}, nil
+}
+
+func (m *MockArtistRepository) Get() ([]*models.Artist, error) {
+	if m.GetFunc != nil {
+		return m.GetFunc()
+	}
+	return []*models.Artist{}, nil
+}
+
+func (m *MockArtistRepository) Update(artist models.Artist) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(artist)
+	}
+	return nil
+}
+
+func (m *MockArtistRepository) Delete(id int) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(id)
+	}
+	return nil
+}
+
+func (m *MockArtistRepository) GetAlbums(id int) ([]*models.Album, error) {
+	if m.GetAlbumsFunc != nil {
+		return m.GetAlbumsFunc(id)
+	}
+	return []*models.Album{}, nil
+}
+
+func TestArtistHandler_CreateArtist(t *testing.T) {
+	srv := NewArtistHandler(&MockArtistRepository{
+		CreateFunc: func(artist *models.Artist) error {
+			artist.Id = 7
+			return nil
+		},
+	})
+
+	artist, err := srv.CreateArtist(context.Background(), &pb.Artist{Name: " John Coltrane ", Country: "US"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if artist.Id != 7 || artist.Name != "John Coltrane" {
+		t.Errorf("Expected artist 7 named 'John Coltrane', got %v", artist)
+	}
+
+	_, err = srv.CreateArtist(context.Background(), &pb.Artist{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a missing name, got %v", err)
+	}
+}
+
+func TestArtistHandler_GetArtist_NotFound(t *testing.T) {
+	srv := NewArtistHandler(&MockArtistRepository{
+		GetByIdFunc: func(id int) (*models.Artist, error) {
+			return nil, pg.ErrNoRows
+		},
+	})
+
+	_, err := srv.GetArtist(context.Background(), &pb.GetArtistRequest{Id: 7})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestArtistHandler_GetArtistAlbumList(t *testing.T) {
+	srv := NewArtistHandler(&MockArtistRepository{
+		GetAlbumsFunc: func(id int) ([]*models.Album, error) {
+			return []*models.Album{{Id: 1, Title: "Blue Train", ArtistId: id, Artist: "John Coltrane"}}, nil
+		},
+	})
+
+	resp, err := srv.GetArtistAlbumList(context.Background(), &pb.GetArtistAlbumsRequest{ArtistId: 7})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Albums) != 1 || resp.Albums[0].ArtistId != 7 || resp.Albums[0].Artist != "John Coltrane" {
+		t.Errorf("Expected the album of artist 7, got %v", resp.Albums)
+	}
+}
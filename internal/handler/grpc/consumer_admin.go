@@ -0,0 +1,84 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"music-service/gen/pb"
+	"music-service/pkg/kafka"
+)
+
+type consumerAdminHandler struct {
+	pb.UnimplementedConsumerAdminServiceServer
+	controller kafka.ConsumerController
+}
+
+func NewConsumerAdminHandler(controller kafka.ConsumerController) pb.ConsumerAdminServiceServer {
+	return &consumerAdminHandler{
+		controller: controller,
+	}
+}
+
+func (h *consumerAdminHandler) GetConsumerStatus(ctx context.Context, req *pb.GetConsumerStatusRequest) (*pb.GetConsumerStatusResponse, error) {
+	statuses, err := h.controller.Status(ctx)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	partitions := make([]*pb.PartitionStatus, len(statuses))
+	for i, s := range statuses {
+		partitions[i] = &pb.PartitionStatus{
+			Topic:         s.Topic,
+			Partition:     s.Partition,
+			Offset:        s.Offset,
+			HighWatermark: s.HighWatermark,
+			Lag:           s.Lag,
+			Paused:        s.Paused,
+		}
+	}
+
+	return &pb.GetConsumerStatusResponse{
+		Partitions: partitions,
+	}, nil
+}
+
+func (h *consumerAdminHandler) PauseConsumer(ctx context.Context, req *pb.PartitionSelection) (*pb.ConsumerControlResponse, error) {
+	if err := h.controller.Pause(req.Topic, req.Partitions); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.ConsumerControlResponse{}, nil
+}
+
+func (h *consumerAdminHandler) ResumeConsumer(ctx context.Context, req *pb.PartitionSelection) (*pb.ConsumerControlResponse, error) {
+	if err := h.controller.Resume(req.Topic, req.Partitions); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.ConsumerControlResponse{}, nil
+}
+
+func (h *consumerAdminHandler) ResetConsumerOffsets(ctx context.Context, req *pb.ResetConsumerOffsetsRequest) (*pb.ConsumerControlResponse, error) {
+	reset := kafka.OffsetReset{
+		Topic:      req.Topic,
+		Partitions: req.Partitions,
+		Offset:     req.Offset,
+	}
+	if req.TimestampMs > 0 {
+		reset.Timestamp = time.UnixMilli(req.TimestampMs)
+	}
+
+	if err := h.controller.ResetOffsets(ctx, reset); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.ConsumerControlResponse{}, nil
+}
+
+func toStatusError(err error) error {
+	if errors.Is(err, kafka.ErrConsumerNotRunning) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return status.Error(codes.FailedPrecondition, err.Error())
+}
@@ -0,0 +1,154 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"music-service/gen/pb"
+	"music-service/pkg/kafka"
+)
+
+type MockConsumerController struct {
+	PauseFunc        func(topic string, partitions []int32) error
+	ResumeFunc       func(topic string, partitions []int32) error
+	StatusFunc       func(ctx context.Context) ([]kafka.PartitionStatus, error)
+	ResetOffsetsFunc func(ctx context.Context, reset kafka.OffsetReset) error
+}
+
+func (m *MockConsumerController) Pause(topic string, partitions []int32) error {
+	if m.PauseFunc != nil {
+		return m.PauseFunc(topic, partitions)
+	}
+	return nil
+}
+
+func (m *MockConsumerController) Resume(topic string, partitions []int32) error {
+	if m.ResumeFunc != nil {
+		return m.ResumeFunc(topic, partitions)
+	}
+	return nil
+}
+
+func (m *MockConsumerController) Status(ctx context.Context) ([]kafka.PartitionStatus, error) {
+	if m.StatusFunc != nil {
+		return m.StatusFunc(ctx)
+	}
+	return []kafka.PartitionStatus{}, nil
+}
+
+func (m *MockConsumerController) ResetOffsets(ctx context.Context, reset kafka.OffsetReset) error {
+	if m.ResetOffsetsFunc != nil {
+		return m.ResetOffsetsFunc(ctx, reset)
+	}
+	return nil
+}
+
+func TestNewConsumerAdminHandler(t *testing.T) {
+	srv := NewConsumerAdminHandler(&MockConsumerController{})
+
+	if srv == nil {
+		t.Fatal("Expected non-nil server, got nil")
+	}
+
+	var _ pb.ConsumerAdminServiceServer = srv
+}
+
+func TestConsumerAdminHandler_GetConsumerStatus(t *testing.T) {
+	controller := &MockConsumerController{
+		StatusFunc: func(ctx context.Context) ([]kafka.PartitionStatus, error) {
+			return []kafka.PartitionStatus{
+				{Topic: "test-topic", Partition: 0, Offset: 5, HighWatermark: 10, Lag: 5, Paused: true},
+				{Topic: "test-topic", Partition: 1, Offset: 3, HighWatermark: 3},
+			}, nil
+		},
+	}
+
+	resp, err := NewConsumerAdminHandler(controller).GetConsumerStatus(context.Background(), &pb.GetConsumerStatusRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(resp.Partitions) != 2 {
+		t.Fatalf("Expected 2 partitions, got %d", len(resp.Partitions))
+	}
+	if resp.Partitions[0].Lag != 5 || !resp.Partitions[0].Paused {
+		t.Errorf("Unexpected first partition: %v", resp.Partitions[0])
+	}
+	if resp.Partitions[1].HighWatermark != 3 || resp.Partitions[1].Paused {
+		t.Errorf("Unexpected second partition: %v", resp.Partitions[1])
+	}
+}
+
+func TestConsumerAdminHandler_GetConsumerStatus_NotRunning(t *testing.T) {
+	controller := &MockConsumerController{
+		StatusFunc: func(ctx context.Context) ([]kafka.PartitionStatus, error) {
+			return nil, kafka.ErrConsumerNotRunning
+		},
+	}
+
+	_, err := NewConsumerAdminHandler(controller).GetConsumerStatus(context.Background(), &pb.GetConsumerStatusRequest{})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable, got %v", err)
+	}
+}
+
+func TestConsumerAdminHandler_PauseAndResume(t *testing.T) {
+	var paused, resumed []int32
+	controller := &MockConsumerController{
+		PauseFunc: func(topic string, partitions []int32) error {
+			paused = partitions
+			return nil
+		},
+		ResumeFunc: func(topic string, partitions []int32) error {
+			resumed = partitions
+			return errors.New("no assigned partitions match")
+		},
+	}
+	srv := NewConsumerAdminHandler(controller)
+
+	if _, err := srv.PauseConsumer(context.Background(), &pb.PartitionSelection{Topic: "test-topic", Partitions: []int32{1}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(paused) != 1 || paused[0] != 1 {
+		t.Errorf("Expected partition 1 to be paused, got %v", paused)
+	}
+
+	_, err := srv.ResumeConsumer(context.Background(), &pb.PartitionSelection{Topic: "test-topic", Partitions: []int32{2}})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition, got %v", err)
+	}
+	if len(resumed) != 1 || resumed[0] != 2 {
+		t.Errorf("Expected partition 2 to be resumed, got %v", resumed)
+	}
+}
+
+func TestConsumerAdminHandler_ResetConsumerOffsets(t *testing.T) {
+	var got kafka.OffsetReset
+	controller := &MockConsumerController{
+		ResetOffsetsFunc: func(ctx context.Context, reset kafka.OffsetReset) error {
+			got = reset
+			return nil
+		},
+	}
+	srv := NewConsumerAdminHandler(controller)
+
+	_, err := srv.ResetConsumerOffsets(context.Background(), &pb.ResetConsumerOffsetsRequest{Topic: "test-topic", Offset: 42})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got.Offset != 42 || !got.Timestamp.IsZero() {
+		t.Errorf("Expected offset reset to 42, got %+v", got)
+	}
+
+	_, err = srv.ResetConsumerOffsets(context.Background(), &pb.ResetConsumerOffsetsRequest{Topic: "test-topic", TimestampMs: 1700000000000})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got.Timestamp.UnixMilli() != 1700000000000 {
+		t.Errorf("Expected timestamp reset, got %+v", got)
+	}
+}
@@ -0,0 +1,172 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"music-service/gen/pb"
+	"music-service/internal/inventory"
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+	"music-service/pkg/kafka"
+)
+
+type inventoryHandler struct {
+	pb.UnimplementedInventoryServiceServer
+	repository orm.InventoryRepository
+	producer   kafka.ProducerHandler
+}
+
+func NewInventoryHandler(repository orm.InventoryRepository, producer kafka.ProducerHandler) pb.InventoryServiceServer {
+	return &inventoryHandler{
+		repository: repository,
+		producer:   producer,
+	}
+}
+
+func (h *inventoryHandler) GetWarehouseList(ctx context.Context, req *pb.GetWarehousesRequest) (*pb.GetWarehousesResponse, error) {
+	warehouses, err := h.repository.GetWarehouses()
+	if err != nil {
+		return nil, toStockStatusError(err)
+	}
+
+	warehouseList := make([]*pb.Warehouse, len(warehouses))
+	for i, warehouse := range warehouses {
+		warehouseList[i] = &pb.Warehouse{
+			Id:   int32(warehouse.Id),
+			Code: warehouse.Code,
+			Name: warehouse.Name,
+		}
+	}
+	return &pb.GetWarehousesResponse{
+		Warehouses: warehouseList,
+	}, nil
+}
+
+func (h *inventoryHandler) GetStock(ctx context.Context, req *pb.GetStockRequest) (*pb.GetStockResponse, error) {
+	stock, err := h.repository.GetStock(int(req.AlbumId))
+	if err != nil {
+		return nil, toStockStatusError(err)
+	}
+
+	stockList := make([]*pb.StockLevel, len(stock))
+	for i, s := range stock {
+		stockList[i] = inventory.ToStockLevel(s)
+	}
+	return &pb.GetStockResponse{
+		Stock: stockList,
+	}, nil
+}
+
+// SetStock publishes a low-stock event when the available units are at or
+// below the threshold.
+func (h *inventoryHandler) SetStock(ctx context.Context, req *pb.StockLevel) (*pb.StockLevel, error) {
+	stock := &models.Stock{
+		AlbumId:           int(req.AlbumId),
+		Format:            req.Format,
+		WarehouseId:       int(req.WarehouseId),
+		OnHand:            int(req.OnHand),
+		LowStockThreshold: int(req.LowStockThreshold),
+	}
+	stock.Normalize()
+	if err := stock.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := h.repository.SetStock(stock); err != nil {
+		return nil, toStockStatusError(err)
+	}
+	inventory.PublishLowStock(ctx, h.producer, stock)
+	return inventory.ToStockLevel(stock), nil
+}
+
+// ReserveStock publishes a low-stock event when the reservation leaves the
+// available units at or below the threshold.
+func (h *inventoryHandler) ReserveStock(ctx context.Context, req *pb.ReserveStockRequest) (*pb.Reservation, error) {
+	reservation := &models.Reservation{
+		AlbumId:     int(req.AlbumId),
+		Format:      req.Format,
+		WarehouseId: int(req.WarehouseId),
+		Quantity:    int(req.Quantity),
+	}
+	reservation.Normalize()
+	if err := reservation.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ttl, err := models.ReservationTTL(int64(req.TtlSeconds))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	stock, err := h.repository.Reserve(reservation, ttl)
+	if err != nil {
+		return nil, toStockStatusError(err)
+	}
+	inventory.PublishLowStock(ctx, h.producer, stock)
+	return toReservationProto(reservation), nil
+}
+
+func (h *inventoryHandler) GetReservation(ctx context.Context, req *pb.GetReservationRequest) (*pb.Reservation, error) {
+	reservation, err := h.repository.GetReservation(int(req.Id))
+	if err != nil {
+		return nil, toReservationStatusError(err)
+	}
+	return toReservationProto(reservation), nil
+}
+
+func (h *inventoryHandler) CommitReservation(ctx context.Context, req *pb.CommitReservationRequest) (*pb.Reservation, error) {
+	reservation, err := h.repository.Commit(int(req.Id))
+	if err != nil {
+		return nil, toReservationStatusError(err)
+	}
+	return toReservationProto(reservation), nil
+}
+
+func (h *inventoryHandler) ReleaseReservation(ctx context.Context, req *pb.ReleaseReservationRequest) (*pb.Reservation, error) {
+	reservation, err := h.repository.Release(int(req.Id))
+	if err != nil {
+		return nil, toReservationStatusError(err)
+	}
+	return toReservationProto(reservation), nil
+}
+
+func toReservationProto(reservation *models.Reservation) *pb.Reservation {
+	return &pb.Reservation{
+		Id:          int32(reservation.Id),
+		AlbumId:     int32(reservation.AlbumId),
+		Format:      reservation.Format,
+		WarehouseId: int32(reservation.WarehouseId),
+		Quantity:    int32(reservation.Quantity),
+		Status:      reservation.Status,
+		ExpiresAt:   reservation.ExpiresAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func toStockStatusError(err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return status.Error(codes.NotFound, "the album format is not stocked in the warehouse")
+	case errors.Is(err, orm.ErrInsufficientStock):
+		return status.Error(codes.FailedPrecondition, "insufficient stock")
+	case orm.IsForeignKeyViolation(err):
+		return status.Error(codes.NotFound, "album or warehouse not found")
+	case orm.IsInvalid(err):
+		return status.Error(codes.FailedPrecondition, "fewer units would be on hand than are reserved")
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func toReservationStatusError(err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return status.Error(codes.NotFound, "reservation not found")
+	case errors.Is(err, orm.ErrReservationClosed):
+		return status.Error(codes.FailedPrecondition, "reservation is no longer pending")
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,157 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"music-service/gen/pb"
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+)
+
+type MockInventoryRepository struct {
+	orm.InventoryRepository
+	SetStockFunc  func(stock *models.Stock) error
+	ReserveFunc   func(reservation *models.Reservation, ttl time.Duration) (*models.Stock, error)
+	CommitFunc    func(id int) (*models.Reservation, error)
+	CommitAllFunc func(ids []int) ([]*models.Reservation, error)
+}
+
+func (m *MockInventoryRepository) SetStock(stock *models.Stock) error {
+	if m.SetStockFunc != nil {
+		return m.SetStockFunc(stock)
+	}
+	return nil
+}
+
+func (m *MockInventoryRepository) Reserve(reservation *models.Reservation, ttl time.Duration) (*models.Stock, error) {
+	return m.ReserveFunc(reservation, ttl)
+}
+
+func (m *MockInventoryRepository) Commit(id int) (*models.Reservation, error) {
+	return m.CommitFunc(id)
+}
+
+func (m *MockInventoryRepository) CommitAll(ids []int) ([]*models.Reservation, error) {
+	return m.CommitAllFunc(ids)
+}
+
+type MockProducerHandler struct {
+	StockEvents []*pb.StockEvent
+	OrderEvents []*pb.OrderEvent
+}
+
+func (m *MockProducerHandler) Produce(ctx context.Context, album *pb.Album) {
+}
+
+func (m *MockProducerHandler) ProduceStockEvent(ctx context.Context, event *pb.StockEvent) {
+	m.StockEvents = append(m.StockEvents, event)
+}
+
+func (m *MockProducerHandler) ProduceOrderEvent(ctx context.Context, event *pb.OrderEvent) {
+	m.OrderEvents = append(m.OrderEvents, event)
+}
+
+func TestInventoryHandler_ReserveStock(t *testing.T) {
+	producer := &MockProducerHandler{}
+	srv := NewInventoryHandler(&MockInventoryRepository{
+		ReserveFunc: func(reservation *models.Reservation, ttl time.Duration) (*models.Stock, error) {
+			if reservation.Format != "vinyl" || ttl != 2*time.Minute {
+				t.Errorf("Expected a vinyl reservation for 2m, got %s for %s", reservation.String(), ttl)
+			}
+			reservation.Id = 11
+			reservation.Status = models.ReservationPending
+			reservation.ExpiresAt = time.Date(2024, 5, 1, 12, 2, 0, 0, time.UTC)
+			return &models.Stock{AlbumId: 7, Format: "vinyl", WarehouseId: 2, OnHand: 5, Reserved: 4, LowStockThreshold: 1}, nil
+		},
+	}, producer)
+
+	reservation, err := srv.ReserveStock(context.Background(), &pb.ReserveStockRequest{AlbumId: 7, Format: "Vinyl", WarehouseId: 2, Quantity: 4, TtlSeconds: 120})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if reservation.Id != 11 || reservation.ExpiresAt != "2024-05-01T12:02:00Z" {
+		t.Errorf("Expected reservation 11 expiring at 12:02, got %v", reservation)
+	}
+	if len(producer.StockEvents) != 1 || producer.StockEvents[0].Stock.Available != 1 {
+		t.Errorf("Expected a low-stock event with 1 available unit, got %v", producer.StockEvents)
+	}
+
+	_, err = srv.ReserveStock(context.Background(), &pb.ReserveStockRequest{AlbumId: 7, Format: "vinyl", WarehouseId: 2})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a zero quantity, got %v", err)
+	}
+}
+
+func TestInventoryHandler_ReserveStock_Insufficient(t *testing.T) {
+	producer := &MockProducerHandler{}
+	srv := NewInventoryHandler(&MockInventoryRepository{
+		ReserveFunc: func(reservation *models.Reservation, ttl time.Duration) (*models.Stock, error) {
+			return nil, orm.ErrInsufficientStock
+		},
+	}, producer)
+
+	_, err := srv.ReserveStock(context.Background(), &pb.ReserveStockRequest{AlbumId: 7, Format: "cd", WarehouseId: 2, Quantity: 40})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition, got %v", err)
+	}
+	if len(producer.StockEvents) != 0 {
+		t.Errorf("Expected no stock events, got %v", producer.StockEvents)
+	}
+}
+
+func TestInventoryHandler_SetStock(t *testing.T) {
+	producer := &MockProducerHandler{}
+	srv := NewInventoryHandler(&MockInventoryRepository{
+		SetStockFunc: func(stock *models.Stock) error {
+			stock.Reserved = 2
+			return nil
+		},
+	}, producer)
+
+	stock, err := srv.SetStock(context.Background(), &pb.StockLevel{AlbumId: 7, Format: "cd", WarehouseId: 2, OnHand: 10, Reserved: 9, LowStockThreshold: 3})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if stock.Reserved != 2 || stock.Available != 8 {
+		t.Errorf("Expected the reserved units of the repository, got %v", stock)
+	}
+	if len(producer.StockEvents) != 0 {
+		t.Errorf("Expected no stock events, got %v", producer.StockEvents)
+	}
+}
+
+func TestInventoryHandler_CommitReservation(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected codes.Code
+	}{
+		{name: "commits", expected: codes.OK},
+		{name: "unknown reservation", err: pg.ErrNoRows, expected: codes.NotFound},
+		{name: "expired reservation", err: orm.ErrReservationClosed, expected: codes.FailedPrecondition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := NewInventoryHandler(&MockInventoryRepository{
+				CommitFunc: func(id int) (*models.Reservation, error) {
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &models.Reservation{Id: id, Status: models.ReservationCommitted}, nil
+				},
+			}, &MockProducerHandler{})
+
+			_, err := srv.CommitReservation(context.Background(), &pb.CommitReservationRequest{Id: 11})
+			if status.Code(err) != tt.expected {
+				t.Errorf("Expected %s, got %v", tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/go-pg/pg/v10"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"music-service/gen/pb"
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+)
+
+type labelHandler struct {
+	pb.UnimplementedLabelServiceServer
+	repository orm.LabelRepository
+}
+
+func NewLabelHandler(repository orm.LabelRepository) pb.LabelServiceServer {
+	return &labelHandler{
+		repository: repository,
+	}
+}
+
+func (h *labelHandler) CreateLabel(ctx context.Context, req *pb.Label) (*pb.Label, error) {
+	label := toLabelModel(req)
+	label.Id = 0
+	if label.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	if err := h.repository.Create(&label); err != nil {
+		return nil, toLabelStatusError(err)
+	}
+	return toLabelProto(&label), nil
+}
+
+func (h *labelHandler) GetLabel(ctx context.Context, req *pb.GetLabelRequest) (*pb.Label, error) {
+	label, err := h.repository.GetById(int(req.Id))
+	if err != nil {
+		return nil, toLabelStatusError(err)
+	}
+	return toLabelProto(label), nil
+}
+
+func (h *labelHandler) GetLabelList(ctx context.Context, req *pb.GetLabelsRequest) (*pb.GetLabelsResponse, error) {
+	labels, err := h.repository.Get()
+	if err != nil {
+		return nil, toLabelStatusError(err)
+	}
+
+	labelList := make([]*pb.Label, len(labels))
+	for i, label := range labels {
+		labelList[i] = toLabelProto(label)
+	}
+	return &pb.GetLabelsResponse{
+		Labels: labelList,
+	}, nil
+}
+
+func (h *labelHandler) UpdateLabel(ctx context.Context, req *pb.Label) (*pb.Label, error) {
+	label := toLabelModel(req)
+	if label.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	if err := h.repository.Update(label); err != nil {
+		return nil, toLabelStatusError(err)
+	}
+	return toLabelProto(&label), nil
+}
+
+func (h *labelHandler) DeleteLabel(ctx context.Context, req *pb.DeleteLabelRequest) (*pb.DeleteLabelResponse, error) {
+	if err := h.repository.Delete(int(req.Id)); err != nil {
+		return nil, toLabelStatusError(err)
+	}
+	return &pb.DeleteLabelResponse{}, nil
+}
+
+func toLabelModel(label *pb.Label) models.Label {
+	return models.Label{
+		Id:      int(label.Id),
+		Name:    strings.TrimSpace(label.Name),
+		Country: strings.TrimSpace(label.Country),
+	}
+}
+
+func toLabelProto(label *models.Label) *pb.Label {
+	return &pb.Label{
+		Id:      int32(label.Id),
+		Name:    label.Name,
+		Country: label.Country,
+	}
+}
+
+func toLabelStatusError(err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return status.Error(codes.NotFound, "label not found")
+	case orm.IsForeignKeyViolation(err):
+		return status.Error(codes.FailedPrecondition, "label still has albums")
+	case orm.IsConflict(err):
+		return status.Error(codes.AlreadyExists, "label already exists")
+	case orm.IsInvalid(err):
+		return status.Error(codes.InvalidArgument, "invalid label")
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,120 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"music-service/gen/pb"
+	"music-service/internal/models"
+)
+
+type MockLabelRepository struct {
+	CreateFunc  func(label *models.Label) error
+	GetByIdFunc func(id int) (*models.Label, error)
+	DeleteFunc  func(id int) error
+}
+
+func (m *MockLabelRepository) Create(label *models.Label) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(label)
+	}
+	return nil
+}
+
+func (m *MockLabelRepository) GetById(id int) (*models.Label, error) {
+	if m.GetByIdFunc != nil {
+		return m.GetByIdFunc(id)
+	}
+	return &models.Label{Id: id}, nil
+}
+
+func (m *MockLabelRepository) Get() ([]*models.Label, error) {
+	return []*models.Label{}, nil
+}
+
+func (m *MockLabelRepository) Update(label models.Label) error {
+	return nil
+}
+
+func (m *MockLabelRepository) Delete(id int) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(id)
+	}
+	return nil
+}
+
+func (m *MockLabelRepository) GetAlbums(id int) ([]*models.Album, error) {
+	return []*models.Album{}, nil
+}
+
+// pgError is a postgres error with the given SQLSTATE
+type pgError struct {
+	code string
+}
+
+func (e pgError) Error() string {
+	return "ERROR #" + e.code
+}
+
+func (e pgError) Field(field byte) string {
+	if field == 'C' {
+		return e.code
+	}
+	return ""
+}
+
+func (e pgError) IntegrityViolation() bool {
+	return e.code[:2] == "23"
+}
+
+func TestLabelHandler_CreateLabel(t *testing.T) {
+	srv := NewLabelHandler(&MockLabelRepository{
+		CreateFunc: func(label *models.Label) error {
+			label.Id = 4
+			return nil
+		},
+	})
+
+	label, err := srv.CreateLabel(context.Background(), &pb.Label{Name: " Blue Note ", Country: "US"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if label.Id != 4 || label.Name != "Blue Note" {
+		t.Errorf("Expected label 4 named 'Blue Note', got %v", label)
+	}
+
+	_, err = srv.CreateLabel(context.Background(), &pb.Label{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a missing name, got %v", err)
+	}
+}
+
+func TestLabelHandler_GetLabel_NotFound(t *testing.T) {
+	srv := NewLabelHandler(&MockLabelRepository{
+		GetByIdFunc: func(id int) (*models.Label, error) {
+			return nil, pg.ErrNoRows
+		},
+	})
+
+	_, err := srv.GetLabel(context.Background(), &pb.GetLabelRequest{Id: 4})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestLabelHandler_DeleteLabel_WithAlbums(t *testing.T) {
+	srv := NewLabelHandler(&MockLabelRepository{
+		DeleteFunc: func(id int) error {
+			return pgError{code: "23503"}
+		},
+	})
+
+	_, err := srv.DeleteLabel(context.Background(), &pb.DeleteLabelRequest{Id: 4})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition, got %v", err)
+	}
+}
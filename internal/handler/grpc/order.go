@@ -0,0 +1,194 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-pg/pg/v10"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"music-service/gen/pb"
+	"music-service/internal/authz"
+	"music-service/internal/models"
+	"music-service/internal/orders"
+	"music-service/internal/repository/postgres/orm"
+	"music-service/pkg/payment"
+)
+
+type orderHandler struct {
+	pb.UnimplementedOrderServiceServer
+	repository orm.OrderRepository
+	service    *orders.Service
+}
+
+func NewOrderHandler(repository orm.OrderRepository, service *orders.Service) pb.OrderServiceServer {
+	return &orderHandler{
+		repository: repository,
+		service:    service,
+	}
+}
+
+func (h *orderHandler) CreateCart(ctx context.Context, req *pb.CreateCartRequest) (*pb.Cart, error) {
+	cart := &models.Cart{
+		Customer: req.Customer,
+		Currency: req.Currency,
+	}
+	if customer, ok := authz.Customer(ctx); ok {
+		cart.Customer = customer
+	}
+	cart.Normalize()
+	if err := cart.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := h.repository.CreateCart(cart); err != nil {
+		return nil, toCartStatusError(err)
+	}
+	return orders.ToCartProto(cart), nil
+}
+
+func (h *orderHandler) GetCart(ctx context.Context, req *pb.GetCartRequest) (*pb.Cart, error) {
+	cart, err := h.repository.GetCart(int(req.Id))
+	if err != nil {
+		return nil, toCartStatusError(err)
+	}
+	return orders.ToCartProto(cart), nil
+}
+
+// AddCartItem prices the item at the price of the album in the currency of
+// the cart.
+func (h *orderHandler) AddCartItem(ctx context.Context, req *pb.AddCartItemRequest) (*pb.Cart, error) {
+	item := &models.CartItem{
+		CartId:   int(req.CartId),
+		AlbumId:  int(req.AlbumId),
+		Format:   req.Format,
+		Quantity: int(req.Quantity),
+	}
+	item.Normalize()
+	if err := item.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := h.repository.AddCartItem(item); err != nil {
+		return nil, toCartStatusError(err)
+	}
+	return h.GetCart(ctx, &pb.GetCartRequest{Id: req.CartId})
+}
+
+func (h *orderHandler) RemoveCartItem(ctx context.Context, req *pb.RemoveCartItemRequest) (*pb.Cart, error) {
+	item := &models.CartItem{Format: req.Format}
+	item.Normalize()
+	if err := h.repository.RemoveCartItem(int(req.CartId), int(req.AlbumId), item.Format); err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "cart or item not found")
+		}
+		return nil, toCartStatusError(err)
+	}
+	return h.GetCart(ctx, &pb.GetCartRequest{Id: req.CartId})
+}
+
+// Checkout reserves the items of the cart and creates a pending order of
+// them, see orders.Service.Checkout.
+func (h *orderHandler) Checkout(ctx context.Context, req *pb.CheckoutRequest) (*pb.Order, error) {
+	order, err := h.service.Checkout(ctx, int(req.CartId))
+	if err != nil {
+		return nil, toCartStatusError(err)
+	}
+	return orders.ToOrderProto(order), nil
+}
+
+func (h *orderHandler) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.Order, error) {
+	order, err := h.repository.GetOrder(int(req.Id))
+	if err != nil {
+		return nil, toOrderStatusError(err)
+	}
+	return orders.ToOrderProto(order), nil
+}
+
+func (h *orderHandler) GetOrderList(ctx context.Context, req *pb.GetOrdersRequest) (*pb.GetOrdersResponse, error) {
+	customer := req.Customer
+	if caller, ok := authz.Customer(ctx); ok {
+		if customer != "" && customer != caller {
+			return nil, status.Error(codes.PermissionDenied, "cannot read the orders of another customer")
+		}
+		customer = caller
+	}
+	if customer == "" {
+		return nil, status.Error(codes.InvalidArgument, "customer is required")
+	}
+
+	orderList, err := h.repository.GetOrders(customer)
+	if err != nil {
+		return nil, toOrderStatusError(err)
+	}
+	resp := &pb.GetOrdersResponse{Orders: make([]*pb.Order, len(orderList))}
+	for i, order := range orderList {
+		resp.Orders[i] = orders.ToOrderProto(order)
+	}
+	return resp, nil
+}
+
+func (h *orderHandler) PayOrder(ctx context.Context, req *pb.PayOrderRequest) (*pb.Order, error) {
+	if req.PaymentToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "payment_token is required")
+	}
+
+	order, err := h.service.Pay(ctx, int(req.Id), req.PaymentToken)
+	if err != nil {
+		return nil, toOrderStatusError(err)
+	}
+	return orders.ToOrderProto(order), nil
+}
+
+func (h *orderHandler) ShipOrder(ctx context.Context, req *pb.ShipOrderRequest) (*pb.Order, error) {
+	return h.transition(ctx, int(req.Id), h.service.Ship)
+}
+
+func (h *orderHandler) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.Order, error) {
+	return h.transition(ctx, int(req.Id), h.service.Cancel)
+}
+
+func (h *orderHandler) RefundOrder(ctx context.Context, req *pb.RefundOrderRequest) (*pb.Order, error) {
+	return h.transition(ctx, int(req.Id), h.service.Refund)
+}
+
+func (h *orderHandler) transition(ctx context.Context, id int, change func(ctx context.Context, id int) (*models.Order, error)) (*pb.Order, error) {
+	order, err := change(ctx, id)
+	if err != nil {
+		return nil, toOrderStatusError(err)
+	}
+	return orders.ToOrderProto(order), nil
+}
+
+func toCartStatusError(err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return status.Error(codes.NotFound, "cart not found")
+	case errors.Is(err, orm.ErrCartClosed):
+		return status.Error(codes.FailedPrecondition, "cart is checked out")
+	case errors.Is(err, orm.ErrCartChanged):
+		return status.Error(codes.Aborted, "cart changed during checkout, check it out again")
+	case errors.Is(err, orm.ErrNoPrice):
+		return status.Error(codes.InvalidArgument, "the album does not exist or has no price in the currency of the cart")
+	case errors.Is(err, orders.ErrEmptyCart):
+		return status.Error(codes.FailedPrecondition, "cart is empty")
+	case errors.Is(err, orm.ErrInsufficientStock):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func toOrderStatusError(err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return status.Error(codes.NotFound, "order not found")
+	case errors.Is(err, orm.ErrOrderStatus):
+		return status.Error(codes.FailedPrecondition, "the order cannot change to the status from its status")
+	case errors.Is(err, payment.ErrDeclined):
+		return status.Error(codes.FailedPrecondition, "payment declined")
+	case errors.Is(err, orders.ErrReservationsExpired):
+		return status.Error(codes.Aborted, "the reservations of the order expired, the payment was refunded and the order cancelled")
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,226 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"music-service/gen/pb"
+	"music-service/internal/models"
+	"music-service/internal/orders"
+	"music-service/internal/repository/postgres/orm"
+	"music-service/pkg/auth"
+	"music-service/pkg/payment"
+)
+
+type MockOrderRepository struct {
+	orm.OrderRepository
+	GetCartFunc      func(id int) (*models.Cart, error)
+	AddCartItemFunc  func(item *models.CartItem) error
+	UpdateStatusFunc func(id int, from, to string) (*models.Order, error)
+}
+
+func (m *MockOrderRepository) GetCart(id int) (*models.Cart, error) {
+	return m.GetCartFunc(id)
+}
+
+func (m *MockOrderRepository) AddCartItem(item *models.CartItem) error {
+	return m.AddCartItemFunc(item)
+}
+
+func (m *MockOrderRepository) CreateOrder(order *models.Order) error {
+	order.Id = 3
+	order.Status = models.OrderPending
+	return nil
+}
+
+func (m *MockOrderRepository) UpdateStatus(id int, from, to string) (*models.Order, error) {
+	return m.UpdateStatusFunc(id, from, to)
+}
+
+func (m *MockOrderRepository) GetOrders(customer string) ([]*models.Order, error) {
+	return []*models.Order{{Id: 3, Customer: customer, Status: models.OrderPending}}, nil
+}
+
+func (m *MockOrderRepository) SetPayment(id int, paymentId string) (*models.Order, error) {
+	return &models.Order{Id: id, Status: models.OrderPaid, PaymentId: paymentId}, nil
+}
+
+func newOrderTestHandler(repository *MockOrderRepository, inventory *MockInventoryRepository, producer *MockProducerHandler) pb.OrderServiceServer {
+	return NewOrderHandler(repository, orders.NewService(repository, inventory, payment.NewFakeProvider(), producer))
+}
+
+func openCart(id int) (*models.Cart, error) {
+	return &models.Cart{Id: id, Customer: "ada", Currency: "EUR", Status: models.CartOpen, Items: []*models.CartItem{
+		{CartId: id, AlbumId: 7, Format: models.FormatVinyl, Quantity: 2, UnitPrice: decimal.RequireFromString("21.50")},
+	}}, nil
+}
+
+func TestOrderHandler_AddCartItem(t *testing.T) {
+	srv := newOrderTestHandler(&MockOrderRepository{
+		GetCartFunc: openCart,
+		AddCartItemFunc: func(item *models.CartItem) error {
+			if item.AlbumId == 8 {
+				return orm.ErrNoPrice
+			}
+			if item.Format != models.FormatVinyl {
+				t.Errorf("Expected a normalized format, got %q", item.Format)
+			}
+			return nil
+		},
+	}, &MockInventoryRepository{}, &MockProducerHandler{})
+
+	cart, err := srv.AddCartItem(context.Background(), &pb.AddCartItemRequest{CartId: 1, AlbumId: 7, Format: "VINYL", Quantity: 2})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cart.Total != 43 || len(cart.Items) != 1 || cart.Items[0].UnitPrice != 21.5 {
+		t.Errorf("Expected a cart of 43.00, got %v", cart)
+	}
+
+	_, err = srv.AddCartItem(context.Background(), &pb.AddCartItemRequest{CartId: 1, AlbumId: 8, Format: "cd", Quantity: 1})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an album without price, got %v", err)
+	}
+
+	_, err = srv.AddCartItem(context.Background(), &pb.AddCartItemRequest{CartId: 1, AlbumId: 7, Format: "cd"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a zero quantity, got %v", err)
+	}
+}
+
+func TestOrderHandler_Checkout(t *testing.T) {
+	producer := &MockProducerHandler{}
+	srv := newOrderTestHandler(&MockOrderRepository{GetCartFunc: openCart}, &MockInventoryRepository{
+		ReserveFunc: func(reservation *models.Reservation, ttl time.Duration) (*models.Stock, error) {
+			if reservation.WarehouseId != 0 {
+				t.Errorf("Expected a reservation in any warehouse, got %s", reservation.String())
+			}
+			reservation.Id = 11
+			reservation.WarehouseId = 2
+			return &models.Stock{AlbumId: 7, Format: "vinyl", WarehouseId: 2, OnHand: 10, Reserved: 2, LowStockThreshold: 1}, nil
+		},
+	}, producer)
+
+	order, err := srv.Checkout(context.Background(), &pb.CheckoutRequest{CartId: 1})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if order.Id != 3 || order.Status != models.OrderPending || order.Total != 43 || order.Currency != "EUR" {
+		t.Errorf("Expected pending order 3 of 43.00 EUR, got %v", order)
+	}
+	if len(order.Items) != 1 || order.Items[0].ReservationId != 11 || order.Items[0].WarehouseId != 2 {
+		t.Errorf("Expected the item held by reservation 11 in warehouse 2, got %v", order.Items)
+	}
+	if len(producer.OrderEvents) != 1 || producer.OrderEvents[0].Type != orders.EventCreated {
+		t.Errorf("Expected a created event, got %v", producer.OrderEvents)
+	}
+
+	srv = newOrderTestHandler(&MockOrderRepository{
+		GetCartFunc: func(id int) (*models.Cart, error) {
+			return nil, pg.ErrNoRows
+		},
+	}, &MockInventoryRepository{}, producer)
+	_, err = srv.Checkout(context.Background(), &pb.CheckoutRequest{CartId: 9})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestOrderHandler_PayOrder(t *testing.T) {
+	producer := &MockProducerHandler{}
+	var committed []int
+	srv := newOrderTestHandler(&MockOrderRepository{
+		UpdateStatusFunc: func(id int, from, to string) (*models.Order, error) {
+			return &models.Order{Id: id, Status: to, Currency: "EUR", Total: decimal.RequireFromString("43"), Items: []*models.OrderItem{
+				{OrderId: id, AlbumId: 7, Format: "vinyl", Quantity: 2, ReservationId: 11},
+			}}, nil
+		},
+	}, &MockInventoryRepository{
+		CommitAllFunc: func(ids []int) ([]*models.Reservation, error) {
+			committed = append(committed, ids...)
+			return nil, nil
+		},
+	}, producer)
+
+	order, err := srv.PayOrder(context.Background(), &pb.PayOrderRequest{Id: 3, PaymentToken: "tok_visa"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if order.Status != models.OrderPaid || order.PaymentId == "" {
+		t.Errorf("Expected a paid order with a payment, got %v", order)
+	}
+	if len(committed) != 1 || committed[0] != 11 {
+		t.Errorf("Expected reservation 11 to be committed, got %v", committed)
+	}
+
+	_, err = srv.PayOrder(context.Background(), &pb.PayOrderRequest{Id: 3, PaymentToken: payment.DeclinedToken})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition for a declined payment, got %v", err)
+	}
+	_, err = srv.PayOrder(context.Background(), &pb.PayOrderRequest{Id: 3})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument without a payment token, got %v", err)
+	}
+}
+
+func TestOrderHandler_ShipOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected codes.Code
+	}{
+		{name: "ships", expected: codes.OK},
+		{name: "unknown order", err: pg.ErrNoRows, expected: codes.NotFound},
+		{name: "unpaid order", err: orm.ErrOrderStatus, expected: codes.FailedPrecondition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newOrderTestHandler(&MockOrderRepository{
+				UpdateStatusFunc: func(id int, from, to string) (*models.Order, error) {
+					if from != models.OrderPaid || to != models.OrderShipped {
+						t.Errorf("Expected a change from paid to shipped, got %s to %s", from, to)
+					}
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &models.Order{Id: id, Status: to}, nil
+				},
+			}, &MockInventoryRepository{}, &MockProducerHandler{})
+
+			_, err := srv.ShipOrder(context.Background(), &pb.ShipOrderRequest{Id: 3})
+			if status.Code(err) != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestOrderHandler_GetOrderList(t *testing.T) {
+	srv := newOrderTestHandler(&MockOrderRepository{}, &MockInventoryRepository{}, &MockProducerHandler{})
+	caller := auth.NewContext(context.Background(), &auth.Identity{Subject: "ada"})
+
+	resp, err := srv.GetOrderList(caller, &pb.GetOrdersRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Orders) != 1 || resp.Orders[0].Customer != "ada" {
+		t.Errorf("Expected the orders of the caller ada, got %v", resp.Orders)
+	}
+
+	_, err = srv.GetOrderList(caller, &pb.GetOrdersRequest{Customer: "charles"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for the orders of another customer, got %v", err)
+	}
+
+	_, err = srv.GetOrderList(context.Background(), &pb.GetOrdersRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument without a customer, got %v", err)
+	}
+}
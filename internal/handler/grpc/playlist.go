@@ -0,0 +1,274 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"music-service/gen/pb"
+	"music-service/internal/authz"
+	"music-service/internal/models"
+	"music-service/internal/playlists"
+	"music-service/internal/repository/postgres/orm"
+)
+
+type playlistHandler struct {
+	pb.UnimplementedPlaylistServiceServer
+	service *playlists.Service
+	baseURL string
+}
+
+// NewPlaylistHandler serves the playlists. Exports locate their entries in
+// the REST API at baseURL.
+func NewPlaylistHandler(service *playlists.Service, baseURL string) pb.PlaylistServiceServer {
+	return &playlistHandler{
+		service: service,
+		baseURL: baseURL,
+	}
+}
+
+func (h *playlistHandler) CreatePlaylist(ctx context.Context, req *pb.CreatePlaylistRequest) (*pb.Playlist, error) {
+	if _, ok, err := authz.UserId(ctx); ok && err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	userId := actingUser(ctx, req.UserId)
+	if userId < 1 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	playlist := &models.Playlist{
+		Name:        req.Name,
+		Description: req.Description,
+		Visibility:  req.Visibility,
+	}
+	playlist.Normalize()
+	if err := playlist.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := h.service.Create(playlist, userId); err != nil {
+		if orm.IsForeignKeyViolation(err) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, toPlaylistStatusError(err)
+	}
+	return toPlaylistProto(playlist), nil
+}
+
+func (h *playlistHandler) GetPlaylist(ctx context.Context, req *pb.GetPlaylistRequest) (*pb.Playlist, error) {
+	playlist, err := h.service.Get(int(req.Id), actingUser(ctx, req.UserId))
+	if err != nil {
+		return nil, toPlaylistStatusError(err)
+	}
+	return toPlaylistProto(playlist), nil
+}
+
+func (h *playlistHandler) GetSharedPlaylist(ctx context.Context, req *pb.GetSharedPlaylistRequest) (*pb.Playlist, error) {
+	playlist, err := h.service.GetShared(req.ShareToken)
+	if err != nil {
+		return nil, toPlaylistStatusError(err)
+	}
+	return toPlaylistProto(playlist), nil
+}
+
+func (h *playlistHandler) GetPublicPlaylistList(ctx context.Context, req *pb.GetPublicPlaylistsRequest) (*pb.GetPlaylistsResponse, error) {
+	playlistList, err := h.service.GetPublic()
+	if err != nil {
+		return nil, toPlaylistStatusError(err)
+	}
+	return toPlaylistsResponse(playlistList), nil
+}
+
+func (h *playlistHandler) GetUserPlaylistList(ctx context.Context, req *pb.GetUserPlaylistsRequest) (*pb.GetPlaylistsResponse, error) {
+	playlistList, err := h.service.GetByUser(int(req.OwnerId), actingUser(ctx, req.UserId))
+	if err != nil {
+		return nil, toPlaylistStatusError(err)
+	}
+	return toPlaylistsResponse(playlistList), nil
+}
+
+func (h *playlistHandler) UpdatePlaylist(ctx context.Context, req *pb.UpdatePlaylistRequest) (*pb.Playlist, error) {
+	playlist := &models.Playlist{
+		Id:          int(req.Id),
+		Name:        req.Name,
+		Description: req.Description,
+		Visibility:  req.Visibility,
+	}
+	playlist.Normalize()
+	if err := playlist.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := h.service.Update(playlist, actingUser(ctx, req.UserId)); err != nil {
+		return nil, toPlaylistStatusError(err)
+	}
+	return toPlaylistProto(playlist), nil
+}
+
+func (h *playlistHandler) DeletePlaylist(ctx context.Context, req *pb.DeletePlaylistRequest) (*pb.DeletePlaylistResponse, error) {
+	if err := h.service.Delete(int(req.Id), actingUser(ctx, req.UserId)); err != nil {
+		return nil, toPlaylistStatusError(err)
+	}
+	return &pb.DeletePlaylistResponse{}, nil
+}
+
+func (h *playlistHandler) RotateShareToken(ctx context.Context, req *pb.RotateShareTokenRequest) (*pb.Playlist, error) {
+	playlist, err := h.service.RotateShareToken(int(req.Id), actingUser(ctx, req.UserId))
+	if err != nil {
+		return nil, toPlaylistStatusError(err)
+	}
+	return toPlaylistProto(playlist), nil
+}
+
+func (h *playlistHandler) AddPlaylistItem(ctx context.Context, req *pb.AddPlaylistItemRequest) (*pb.PlaylistItem, error) {
+	item := &models.PlaylistItem{
+		PlaylistId: int(req.PlaylistId),
+		AlbumId:    int(req.AlbumId),
+		TrackId:    int(req.TrackId),
+		Position:   int(req.Position),
+	}
+	if err := item.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := h.service.AddItem(item, actingUser(ctx, req.UserId)); err != nil {
+		return nil, toPlaylistStatusError(err)
+	}
+	return toPlaylistItemProto(item), nil
+}
+
+func (h *playlistHandler) RemovePlaylistItem(ctx context.Context, req *pb.RemovePlaylistItemRequest) (*pb.RemovePlaylistItemResponse, error) {
+	if err := h.service.RemoveItem(int(req.PlaylistId), int(req.ItemId), actingUser(ctx, req.UserId)); err != nil {
+		return nil, toPlaylistStatusError(err)
+	}
+	return &pb.RemovePlaylistItemResponse{}, nil
+}
+
+func (h *playlistHandler) MovePlaylistItem(ctx context.Context, req *pb.MovePlaylistItemRequest) (*pb.PlaylistItem, error) {
+	if req.Position < 1 {
+		return nil, status.Error(codes.InvalidArgument, "position must be positive")
+	}
+
+	item, err := h.service.MoveItem(int(req.PlaylistId), int(req.ItemId), int(req.Position), actingUser(ctx, req.UserId))
+	if err != nil {
+		return nil, toPlaylistStatusError(err)
+	}
+	return toPlaylistItemProto(item), nil
+}
+
+func (h *playlistHandler) AddPlaylistCollaborator(ctx context.Context, req *pb.PlaylistCollaboratorRequest) (*pb.PlaylistCollaboratorResponse, error) {
+	if err := h.service.AddCollaborator(int(req.PlaylistId), int(req.CollaboratorId), actingUser(ctx, req.UserId)); err != nil {
+		return nil, toPlaylistStatusError(err)
+	}
+	return &pb.PlaylistCollaboratorResponse{}, nil
+}
+
+func (h *playlistHandler) RemovePlaylistCollaborator(ctx context.Context, req *pb.PlaylistCollaboratorRequest) (*pb.PlaylistCollaboratorResponse, error) {
+	if err := h.service.RemoveCollaborator(int(req.PlaylistId), int(req.CollaboratorId), actingUser(ctx, req.UserId)); err != nil {
+		return nil, toPlaylistStatusError(err)
+	}
+	return &pb.PlaylistCollaboratorResponse{}, nil
+}
+
+// ExportPlaylist exports the playlist with the share token when it is set,
+// otherwise the one with the id.
+func (h *playlistHandler) ExportPlaylist(ctx context.Context, req *pb.ExportPlaylistRequest) (*pb.ExportPlaylistResponse, error) {
+	var playlist *models.Playlist
+	var err error
+	if req.ShareToken != "" {
+		playlist, err = h.service.GetShared(req.ShareToken)
+	} else {
+		playlist, err = h.service.Get(int(req.Id), actingUser(ctx, req.UserId))
+	}
+	if err != nil {
+		return nil, toPlaylistStatusError(err)
+	}
+
+	format := req.Format
+	if format == "" {
+		format = playlists.FormatJSON
+	}
+	data, contentType, err := h.service.Export(playlist, format, h.baseURL)
+	if err != nil {
+		return nil, toPlaylistStatusError(err)
+	}
+	return &pb.ExportPlaylistResponse{
+		ContentType: contentType,
+		Data:        data,
+	}, nil
+}
+
+// actingUser returns the user reading or changing a playlist, zero for an
+// anonymous user. It is the caller when authentication is enabled, callers
+// other than users being anonymous, and the user of the request otherwise.
+func actingUser(ctx context.Context, userId int32) int {
+	if callerId, ok, err := authz.UserId(ctx); ok {
+		if err != nil {
+			return 0
+		}
+		return callerId
+	}
+	return int(userId)
+}
+
+func toPlaylistProto(playlist *models.Playlist) *pb.Playlist {
+	collaboratorIds := make([]int32, len(playlist.Collaborators))
+	for i, id := range playlist.Collaborators {
+		collaboratorIds[i] = int32(id)
+	}
+	items := make([]*pb.PlaylistItem, len(playlist.Items))
+	for i, item := range playlist.Items {
+		items[i] = toPlaylistItemProto(item)
+	}
+	return &pb.Playlist{
+		Id:              int32(playlist.Id),
+		OwnerId:         int32(playlist.OwnerId),
+		Name:            playlist.Name,
+		Description:     playlist.Description,
+		Visibility:      playlist.Visibility,
+		ShareToken:      playlist.ShareToken,
+		CollaboratorIds: collaboratorIds,
+		Items:           items,
+		CreatedAt:       playlist.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:       playlist.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func toPlaylistItemProto(item *models.PlaylistItem) *pb.PlaylistItem {
+	return &pb.PlaylistItem{
+		Id:         int32(item.Id),
+		PlaylistId: int32(item.PlaylistId),
+		Position:   int32(item.Position),
+		AlbumId:    int32(item.AlbumId),
+		TrackId:    int32(item.TrackId),
+		AddedBy:    int32(item.AddedBy),
+		AddedAt:    item.AddedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func toPlaylistsResponse(playlistList []*models.Playlist) *pb.GetPlaylistsResponse {
+	list := make([]*pb.Playlist, len(playlistList))
+	for i, playlist := range playlistList {
+		list[i] = toPlaylistProto(playlist)
+	}
+	return &pb.GetPlaylistsResponse{
+		Playlists: list,
+	}
+}
+
+func toPlaylistStatusError(err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return status.Error(codes.NotFound, "playlist or item not found")
+	case errors.Is(err, playlists.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, playlists.ErrOwnerCollaborator), errors.Is(err, playlists.ErrUnknownFormat):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case orm.IsForeignKeyViolation(err):
+		return status.Error(codes.NotFound, "album, track or user not found")
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,149 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"music-service/gen/pb"
+	"music-service/internal/models"
+	"music-service/internal/playlists"
+	"music-service/internal/repository/postgres/orm"
+	"music-service/pkg/auth"
+)
+
+// MockPlaylistRepository holds a private playlist of user 1 with
+// collaborator 2, shared as "abc" when unlisted
+type MockPlaylistRepository struct {
+	orm.PlaylistRepository
+	Visibility  string
+	AddItemFunc func(item *models.PlaylistItem) error
+}
+
+func (m *MockPlaylistRepository) GetById(id int) (*models.Playlist, error) {
+	if id != 1 {
+		return nil, pg.ErrNoRows
+	}
+	return &models.Playlist{Id: 1, OwnerId: 1, Name: "Road trip", Visibility: m.Visibility, ShareToken: "abc", Collaborators: []int{2}}, nil
+}
+
+func (m *MockPlaylistRepository) GetByShareToken(token string) (*models.Playlist, error) {
+	if token != "abc" {
+		return nil, pg.ErrNoRows
+	}
+	return m.GetById(1)
+}
+
+func (m *MockPlaylistRepository) AddItem(item *models.PlaylistItem) error {
+	return m.AddItemFunc(item)
+}
+
+func (m *MockPlaylistRepository) GetEntries(playlistId int) ([]*models.PlaylistEntry, error) {
+	return []*models.PlaylistEntry{{ItemId: 1, Position: 1, AlbumId: 7, AlbumTitle: "Kind of Blue", Artist: "Miles Davis"}}, nil
+}
+
+func newPlaylistTestHandler(repository *MockPlaylistRepository) pb.PlaylistServiceServer {
+	return NewPlaylistHandler(playlists.NewService(repository), "http://localhost:3000")
+}
+
+func TestPlaylistHandler_GetPlaylist(t *testing.T) {
+	srv := newPlaylistTestHandler(&MockPlaylistRepository{Visibility: models.PlaylistPrivate})
+
+	playlist, err := srv.GetPlaylist(context.Background(), &pb.GetPlaylistRequest{Id: 1, UserId: 2})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if playlist.OwnerId != 1 || len(playlist.CollaboratorIds) != 1 || playlist.CollaboratorIds[0] != 2 {
+		t.Errorf("Expected playlist of user 1 with collaborator 2, got %v", playlist)
+	}
+
+	_, err = srv.GetPlaylist(context.Background(), &pb.GetPlaylistRequest{Id: 1, UserId: 3})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for a private playlist of another user, got %v", err)
+	}
+
+	_, err = srv.GetSharedPlaylist(context.Background(), &pb.GetSharedPlaylistRequest{ShareToken: "abc"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for a private playlist by share token, got %v", err)
+	}
+}
+
+func TestPlaylistHandler_AuthenticatedUser(t *testing.T) {
+	srv := newPlaylistTestHandler(&MockPlaylistRepository{Visibility: models.PlaylistPrivate})
+
+	playlist, err := srv.GetPlaylist(auth.NewContext(context.Background(), &auth.Identity{Subject: "2"}), &pb.GetPlaylistRequest{Id: 1})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if playlist.OwnerId != 1 {
+		t.Errorf("Expected playlist of user 1, got %v", playlist)
+	}
+
+	_, err = srv.GetPlaylist(auth.NewContext(context.Background(), &auth.Identity{Subject: "3"}), &pb.GetPlaylistRequest{Id: 1, UserId: 1})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for a private playlist of another user naming the owner, got %v", err)
+	}
+
+	_, err = srv.CreatePlaylist(auth.NewContext(context.Background(), &auth.Identity{Subject: "apikey:msk_abcd"}), &pb.CreatePlaylistRequest{Name: "Road trip", UserId: 1})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for a caller that is not a user, got %v", err)
+	}
+}
+
+func TestPlaylistHandler_AddPlaylistItem(t *testing.T) {
+	srv := newPlaylistTestHandler(&MockPlaylistRepository{
+		Visibility: models.PlaylistPublic,
+		AddItemFunc: func(item *models.PlaylistItem) error {
+			if item.TrackId == 12 {
+				return pgError{code: "23503"}
+			}
+			item.Id = 5
+			item.Position = 1
+			return nil
+		},
+	})
+
+	item, err := srv.AddPlaylistItem(context.Background(), &pb.AddPlaylistItemRequest{PlaylistId: 1, UserId: 2, TrackId: 11})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if item.Id != 5 || item.AddedBy != 2 {
+		t.Errorf("Expected item 5 added by user 2, got %v", item)
+	}
+
+	_, err = srv.AddPlaylistItem(context.Background(), &pb.AddPlaylistItemRequest{PlaylistId: 1, UserId: 3, TrackId: 11})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for a user who does not collaborate, got %v", err)
+	}
+
+	_, err = srv.AddPlaylistItem(context.Background(), &pb.AddPlaylistItemRequest{PlaylistId: 1, UserId: 2})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an item without album or track, got %v", err)
+	}
+
+	_, err = srv.AddPlaylistItem(context.Background(), &pb.AddPlaylistItemRequest{PlaylistId: 1, UserId: 2, TrackId: 12})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for an unknown track, got %v", err)
+	}
+}
+
+func TestPlaylistHandler_ExportPlaylist(t *testing.T) {
+	srv := newPlaylistTestHandler(&MockPlaylistRepository{Visibility: models.PlaylistUnlisted})
+
+	export, err := srv.ExportPlaylist(context.Background(), &pb.ExportPlaylistRequest{ShareToken: "abc", Format: playlists.FormatM3U})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if export.ContentType != "audio/x-mpegurl" || !strings.Contains(string(export.Data), "http://localhost:3000/api/v1/albums/7\n") {
+		t.Errorf("Expected an m3u playlist locating album 7, got %s: %s", export.ContentType, export.Data)
+	}
+
+	_, err = srv.ExportPlaylist(context.Background(), &pb.ExportPlaylistRequest{Id: 1, UserId: 1, Format: "pls"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an unknown format, got %v", err)
+	}
+}
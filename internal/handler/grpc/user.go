@@ -0,0 +1,292 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"music-service/gen/pb"
+	"music-service/internal/authz"
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+)
+
+// errOtherUser answers the requests of a user acting as another user.
+var errOtherUser = status.Error(codes.PermissionDenied, "cannot act as another user")
+
+type userHandler struct {
+	pb.UnimplementedUserServiceServer
+	users     orm.UserRepository
+	reviews   orm.ReviewRepository
+	wishlists orm.WishlistRepository
+}
+
+func NewUserHandler(users orm.UserRepository, reviews orm.ReviewRepository, wishlists orm.WishlistRepository) pb.UserServiceServer {
+	return &userHandler{
+		users:     users,
+		reviews:   reviews,
+		wishlists: wishlists,
+	}
+}
+
+func (h *userHandler) CreateUser(ctx context.Context, req *pb.User) (*pb.User, error) {
+	user := &models.User{
+		Name:     req.Name,
+		Email:    req.Email,
+		Currency: req.Currency,
+	}
+	user.Normalize()
+	if err := user.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := h.users.Create(user); err != nil {
+		return nil, toUserStatusError(err)
+	}
+	return toUserProto(user), nil
+}
+
+func (h *userHandler) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	user, err := h.users.GetById(int(req.Id))
+	if err != nil {
+		return nil, toUserStatusError(err)
+	}
+	return toUserProto(user), nil
+}
+
+// SetReview creates or replaces the review of the album by the user. A review
+// with a text is pending until it is moderated.
+func (h *userHandler) SetReview(ctx context.Context, req *pb.Review) (*pb.Review, error) {
+	if !authz.IsUser(ctx, int(req.UserId)) {
+		return nil, errOtherUser
+	}
+
+	review := &models.Review{
+		UserId:  int(req.UserId),
+		AlbumId: int(req.AlbumId),
+		Rating:  int(req.Rating),
+		Body:    req.Body,
+	}
+	review.Normalize()
+	if err := review.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := h.reviews.Set(review); err != nil {
+		return nil, toReviewStatusError(err)
+	}
+	return toReviewProto(review), nil
+}
+
+// GetAlbumReviewList returns the approved reviews of the album unless the
+// request asks for another status.
+func (h *userHandler) GetAlbumReviewList(ctx context.Context, req *pb.GetAlbumReviewsRequest) (*pb.GetReviewsResponse, error) {
+	reviewStatus := req.Status
+	if reviewStatus == "" {
+		reviewStatus = models.ReviewApproved
+	}
+	if reviewStatus != models.ReviewPending && !models.ValidReviewStatus(reviewStatus) {
+		return nil, status.Error(codes.InvalidArgument, "status must be one of pending, approved or rejected")
+	}
+
+	reviews, err := h.reviews.GetByAlbum(int(req.AlbumId), reviewStatus)
+	if err != nil {
+		return nil, toReviewStatusError(err)
+	}
+	return toReviewsResponse(reviews), nil
+}
+
+func (h *userHandler) GetUserReviewList(ctx context.Context, req *pb.GetUserReviewsRequest) (*pb.GetReviewsResponse, error) {
+	reviews, err := h.reviews.GetByUser(int(req.UserId))
+	if err != nil {
+		return nil, toReviewStatusError(err)
+	}
+	return toReviewsResponse(reviews), nil
+}
+
+func (h *userHandler) ModerateReview(ctx context.Context, req *pb.ModerateReviewRequest) (*pb.Review, error) {
+	if !models.ValidReviewStatus(req.Status) {
+		return nil, status.Error(codes.InvalidArgument, "status must be approved or rejected")
+	}
+
+	review, err := h.reviews.Moderate(int(req.UserId), int(req.AlbumId), req.Status)
+	if err != nil {
+		return nil, toReviewStatusError(err)
+	}
+	return toReviewProto(review), nil
+}
+
+func (h *userHandler) DeleteReview(ctx context.Context, req *pb.DeleteReviewRequest) (*pb.DeleteReviewResponse, error) {
+	if !authz.IsUser(ctx, int(req.UserId)) {
+		return nil, errOtherUser
+	}
+
+	if err := h.reviews.Delete(int(req.UserId), int(req.AlbumId)); err != nil {
+		return nil, toReviewStatusError(err)
+	}
+	return &pb.DeleteReviewResponse{}, nil
+}
+
+func (h *userHandler) AddToWishlist(ctx context.Context, req *pb.WishlistRequest) (*pb.WishlistResponse, error) {
+	if !authz.IsUser(ctx, int(req.UserId)) {
+		return nil, errOtherUser
+	}
+
+	if err := h.wishlists.Add(int(req.UserId), int(req.AlbumId)); err != nil {
+		return nil, toWishlistStatusError(err)
+	}
+	return &pb.WishlistResponse{}, nil
+}
+
+func (h *userHandler) RemoveFromWishlist(ctx context.Context, req *pb.WishlistRequest) (*pb.WishlistResponse, error) {
+	if !authz.IsUser(ctx, int(req.UserId)) {
+		return nil, errOtherUser
+	}
+
+	if err := h.wishlists.Remove(int(req.UserId), int(req.AlbumId)); err != nil {
+		return nil, toWishlistStatusError(err)
+	}
+	return &pb.WishlistResponse{}, nil
+}
+
+func (h *userHandler) GetWishlist(ctx context.Context, req *pb.GetWishlistRequest) (*pb.GetWishlistResponse, error) {
+	if !authz.IsUser(ctx, int(req.UserId)) {
+		return nil, errOtherUser
+	}
+
+	albums, err := h.wishlists.GetAlbums(int(req.UserId))
+	if err != nil {
+		return nil, toWishlistStatusError(err)
+	}
+
+	albumList := make([]*pb.Album, len(albums))
+	for i, album := range albums {
+		albumList[i] = toAlbumProto(*album)
+	}
+	return &pb.GetWishlistResponse{
+		Albums: albumList,
+	}, nil
+}
+
+func (h *userHandler) GetNotificationList(ctx context.Context, req *pb.GetNotificationsRequest) (*pb.GetNotificationsResponse, error) {
+	if !authz.IsUser(ctx, int(req.UserId)) {
+		return nil, errOtherUser
+	}
+
+	notifications, err := h.wishlists.GetNotifications(int(req.UserId), req.UnreadOnly)
+	if err != nil {
+		return nil, toNotificationStatusError(err)
+	}
+
+	notificationList := make([]*pb.PriceDropNotification, len(notifications))
+	for i, notification := range notifications {
+		notificationList[i] = toNotificationProto(notification)
+	}
+	return &pb.GetNotificationsResponse{
+		Notifications: notificationList,
+	}, nil
+}
+
+func (h *userHandler) MarkNotificationRead(ctx context.Context, req *pb.MarkNotificationReadRequest) (*pb.PriceDropNotification, error) {
+	if !authz.IsUser(ctx, int(req.UserId)) {
+		return nil, errOtherUser
+	}
+
+	notification, err := h.wishlists.MarkRead(int(req.UserId), req.Id)
+	if err != nil {
+		return nil, toNotificationStatusError(err)
+	}
+	return toNotificationProto(notification), nil
+}
+
+func toUserProto(user *models.User) *pb.User {
+	return &pb.User{
+		Id:        int32(user.Id),
+		Name:      user.Name,
+		Email:     user.Email,
+		Currency:  user.Currency,
+		CreatedAt: user.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func toReviewProto(review *models.Review) *pb.Review {
+	return &pb.Review{
+		UserId:    int32(review.UserId),
+		AlbumId:   int32(review.AlbumId),
+		Rating:    int32(review.Rating),
+		Body:      review.Body,
+		Status:    review.Status,
+		CreatedAt: review.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt: review.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func toReviewsResponse(reviews []*models.Review) *pb.GetReviewsResponse {
+	reviewList := make([]*pb.Review, len(reviews))
+	for i, review := range reviews {
+		reviewList[i] = toReviewProto(review)
+	}
+	return &pb.GetReviewsResponse{
+		Reviews: reviewList,
+	}
+}
+
+func toNotificationProto(notification *models.PriceDropNotification) *pb.PriceDropNotification {
+	oldPrice, _ := notification.OldPrice.Float64()
+	newPrice, _ := notification.NewPrice.Float64()
+	var readAt string
+	if notification.ReadAt != nil {
+		readAt = notification.ReadAt.UTC().Format(time.RFC3339)
+	}
+	return &pb.PriceDropNotification{
+		Id:        notification.Id,
+		UserId:    int32(notification.UserId),
+		AlbumId:   int32(notification.AlbumId),
+		Currency:  notification.Currency,
+		OldPrice:  float32(oldPrice),
+		NewPrice:  float32(newPrice),
+		CreatedAt: notification.CreatedAt.UTC().Format(time.RFC3339),
+		ReadAt:    readAt,
+	}
+}
+
+func toUserStatusError(err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return status.Error(codes.NotFound, "user not found")
+	case orm.IsConflict(err):
+		return status.Error(codes.AlreadyExists, "email is already taken")
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func toReviewStatusError(err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return status.Error(codes.NotFound, "review not found")
+	case orm.IsForeignKeyViolation(err):
+		return status.Error(codes.NotFound, "user or album not found")
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func toWishlistStatusError(err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return status.Error(codes.NotFound, "album is not on the wishlist")
+	case orm.IsForeignKeyViolation(err):
+		return status.Error(codes.NotFound, "user or album not found")
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func toNotificationStatusError(err error) error {
+	if errors.Is(err, pg.ErrNoRows) {
+		return status.Error(codes.NotFound, "notification not found")
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,203 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"music-service/gen/pb"
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+	"music-service/pkg/auth"
+)
+
+type MockUserRepository struct {
+	orm.UserRepository
+	CreateFunc func(user *models.User) error
+}
+
+func (m *MockUserRepository) Create(user *models.User) error {
+	return m.CreateFunc(user)
+}
+
+type MockReviewRepository struct {
+	orm.ReviewRepository
+	SetFunc        func(review *models.Review) error
+	GetByAlbumFunc func(albumId int, status string) ([]*models.Review, error)
+	ModerateFunc   func(userId, albumId int, status string) (*models.Review, error)
+}
+
+func (m *MockReviewRepository) Set(review *models.Review) error {
+	return m.SetFunc(review)
+}
+
+func (m *MockReviewRepository) GetByAlbum(albumId int, status string) ([]*models.Review, error) {
+	return m.GetByAlbumFunc(albumId, status)
+}
+
+func (m *MockReviewRepository) Moderate(userId, albumId int, status string) (*models.Review, error) {
+	return m.ModerateFunc(userId, albumId, status)
+}
+
+type MockWishlistRepository struct {
+	orm.WishlistRepository
+	AddFunc              func(userId, albumId int) error
+	GetNotificationsFunc func(userId int, unreadOnly bool) ([]*models.PriceDropNotification, error)
+}
+
+func (m *MockWishlistRepository) Add(userId, albumId int) error {
+	return m.AddFunc(userId, albumId)
+}
+
+func (m *MockWishlistRepository) GetNotifications(userId int, unreadOnly bool) ([]*models.PriceDropNotification, error) {
+	return m.GetNotificationsFunc(userId, unreadOnly)
+}
+
+func TestUserHandler_CreateUser(t *testing.T) {
+	srv := NewUserHandler(&MockUserRepository{
+		CreateFunc: func(user *models.User) error {
+			if user.Email == "taken@example.com" {
+				return pgError{code: "23505"}
+			}
+			user.Id = 1
+			return nil
+		},
+	}, &MockReviewRepository{}, &MockWishlistRepository{})
+
+	user, err := srv.CreateUser(context.Background(), &pb.User{Name: " Ada ", Email: "ada@example.com", Currency: "eur"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user.Id != 1 || user.Name != "Ada" || user.Currency != "EUR" {
+		t.Errorf("Expected normalized user 1, got %v", user)
+	}
+
+	_, err = srv.CreateUser(context.Background(), &pb.User{Name: "Ada", Email: "not an email"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an invalid email, got %v", err)
+	}
+
+	_, err = srv.CreateUser(context.Background(), &pb.User{Name: "Ada", Email: "taken@example.com"})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists for a taken email, got %v", err)
+	}
+}
+
+func TestUserHandler_Reviews(t *testing.T) {
+	srv := NewUserHandler(&MockUserRepository{}, &MockReviewRepository{
+		SetFunc: func(review *models.Review) error {
+			if review.AlbumId == 8 {
+				return pgError{code: "23503"}
+			}
+			review.Status = models.ReviewPending
+			return nil
+		},
+		GetByAlbumFunc: func(albumId int, status string) ([]*models.Review, error) {
+			if status != models.ReviewApproved {
+				t.Errorf("Expected approved reviews by default, got %q", status)
+			}
+			return []*models.Review{{UserId: 1, AlbumId: albumId, Rating: 5, Status: status}}, nil
+		},
+		ModerateFunc: func(userId, albumId int, status string) (*models.Review, error) {
+			return nil, pg.ErrNoRows
+		},
+	}, &MockWishlistRepository{})
+
+	review, err := srv.SetReview(context.Background(), &pb.Review{UserId: 1, AlbumId: 7, Rating: 4, Body: " Great "})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if review.Body != "Great" || review.Status != models.ReviewPending {
+		t.Errorf("Expected pending review, got %v", review)
+	}
+
+	_, err = srv.SetReview(context.Background(), &pb.Review{UserId: 1, AlbumId: 7, Rating: 0})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a zero rating, got %v", err)
+	}
+
+	_, err = srv.SetReview(context.Background(), &pb.Review{UserId: 1, AlbumId: 8, Rating: 3})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for an unknown album, got %v", err)
+	}
+
+	reviews, err := srv.GetAlbumReviewList(context.Background(), &pb.GetAlbumReviewsRequest{AlbumId: 7})
+	if err != nil || len(reviews.Reviews) != 1 {
+		t.Errorf("Expected one approved review, got %v, %v", reviews, err)
+	}
+
+	_, err = srv.ModerateReview(context.Background(), &pb.ModerateReviewRequest{UserId: 1, AlbumId: 7, Status: models.ReviewPending})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for moderation to pending, got %v", err)
+	}
+
+	_, err = srv.ModerateReview(context.Background(), &pb.ModerateReviewRequest{UserId: 1, AlbumId: 7, Status: models.ReviewRejected})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for an unknown review, got %v", err)
+	}
+}
+
+func TestUserHandler_Wishlist(t *testing.T) {
+	readAt := time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC)
+	srv := NewUserHandler(&MockUserRepository{}, &MockReviewRepository{}, &MockWishlistRepository{
+		AddFunc: func(userId, albumId int) error {
+			if albumId == 8 {
+				return pgError{code: "23503"}
+			}
+			return nil
+		},
+		GetNotificationsFunc: func(userId int, unreadOnly bool) ([]*models.PriceDropNotification, error) {
+			return []*models.PriceDropNotification{{
+				Id: 5, UserId: userId, AlbumId: 7, Currency: "EUR",
+				OldPrice: decimal.RequireFromString("24.99"), NewPrice: decimal.RequireFromString("19.99"),
+				ReadAt: &readAt,
+			}}, nil
+		},
+	})
+
+	if _, err := srv.AddToWishlist(context.Background(), &pb.WishlistRequest{UserId: 1, AlbumId: 7}); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	_, err := srv.AddToWishlist(context.Background(), &pb.WishlistRequest{UserId: 1, AlbumId: 8})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for an unknown album, got %v", err)
+	}
+
+	notifications, err := srv.GetNotificationList(context.Background(), &pb.GetNotificationsRequest{UserId: 1})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(notifications.Notifications) != 1 || notifications.Notifications[0].NewPrice != 19.99 || notifications.Notifications[0].ReadAt != "2024-05-02T10:00:00Z" {
+		t.Errorf("Expected a read price drop to 19.99, got %v", notifications.Notifications)
+	}
+}
+
+func TestUserHandler_AuthenticatedUser(t *testing.T) {
+	srv := NewUserHandler(&MockUserRepository{}, &MockReviewRepository{}, &MockWishlistRepository{
+		AddFunc: func(userId, albumId int) error {
+			return nil
+		},
+	})
+	caller := auth.NewContext(context.Background(), &auth.Identity{Subject: "2"})
+
+	if _, err := srv.AddToWishlist(caller, &pb.WishlistRequest{UserId: 2, AlbumId: 7}); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	_, err := srv.AddToWishlist(caller, &pb.WishlistRequest{UserId: 1, AlbumId: 7})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for the wishlist of another user, got %v", err)
+	}
+	_, err = srv.GetNotificationList(caller, &pb.GetNotificationsRequest{UserId: 1})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for the notifications of another user, got %v", err)
+	}
+	_, err = srv.SetReview(caller, &pb.Review{UserId: 1, AlbumId: 7, Rating: 1})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for a review as another user, got %v", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"music-service/internal/handler/kafka/message"
 	"music-service/internal/repository/postgres/orm"
 	"music-service/pkg/kafka"
+	"music-service/pkg/kafka/codec"
 	"music-service/pkg/kafka/confluent"
 )
 
@@ -15,16 +16,10 @@ type consumerHandler struct {
 	consumer kafka.ConsumerHandler
 }
 
-func NewConsumerHandler(cfg kafka.Config, repository orm.Repository) (kafka.ConsumerHandler, error) {
-	extCfg := &ext_kafka.ConfigMap{
-		"bootstrap.servers":             cfg.Brokers,
-		"group.id":                      cfg.ConsumerGroup,
-		"auto.offset.reset":             "earliest",
-		"enable.auto.commit":            false,
-		"enable.auto.offset.store":      false,
-		"partition.assignment.strategy": "cooperative-sticky",
-		"session.timeout.ms":            30000,
-		"max.poll.interval.ms":          300000,
+func NewConsumerHandler(cfg kafka.Config, repository orm.Repository, prices orm.PriceRepository, tracks orm.TrackRepository) (kafka.ConsumerHandler, error) {
+	extCfg, err := confluent.NewConsumerConfig(cfg)
+	if err != nil {
+		return nil, err
 	}
 
 	confluentConsumer, err := ext_kafka.NewConsumer(extCfg)
@@ -37,7 +32,12 @@ func NewConsumerHandler(cfg kafka.Config, repository orm.Repository) (kafka.Cons
 		return nil, err
 	}
 
-	messageValueProcessor := message.NewMessageValueProcessor(repository)
+	decoder, err := codec.NewDecoder(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	messageValueProcessor := message.NewMessageValueProcessor(repository, prices, tracks, decoder)
 
 	consumerHandler := confluent.NewConsumer(confluentConsumer, messageValueProcessor, 5)
 	return consumerHandler, nil
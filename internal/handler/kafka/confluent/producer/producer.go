@@ -3,42 +3,83 @@ package producer
 import (
 	"context"
 	"log"
+	"strconv"
 
 	ext_kafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"google.golang.org/protobuf/proto"
 
 	"music-service/gen/pb"
 	"music-service/pkg/kafka"
+	"music-service/pkg/kafka/codec"
+	"music-service/pkg/kafka/confluent"
+	kafka_message "music-service/pkg/kafka/message"
 )
 
 type producerHandler struct {
 	cfg               kafka.Config
 	confluentProducer *ext_kafka.Producer
+	encoder           codec.Codec
+	stockEncoder      codec.Codec
+	orderEncoder      codec.Codec
 }
 
 func NewProducerHandler(cfg kafka.Config) (kafka.ProducerHandler, error) {
-	extCfg := &ext_kafka.ConfigMap{"bootstrap.servers": cfg.Brokers}
+	encoder, err := codec.NewEncoder(cfg, cfg.Topics, &pb.Album{})
+	if err != nil {
+		return nil, err
+	}
+
+	stockEncoder, err := newEventEncoder(cfg, cfg.StockTopic, &pb.StockEvent{})
+	if err != nil {
+		return nil, err
+	}
+	orderEncoder, err := newEventEncoder(cfg, cfg.OrderTopic, &pb.OrderEvent{})
+	if err != nil {
+		return nil, err
+	}
+
+	extCfg, err := confluent.NewProducerConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	confluentProducer, err := ext_kafka.NewProducer(extCfg)
 	if err != nil {
 		return nil, err
 	}
 
-	return &producerHandler{cfg: cfg, confluentProducer: confluentProducer}, nil
+	return &producerHandler{cfg: cfg, confluentProducer: confluentProducer, encoder: encoder, stockEncoder: stockEncoder, orderEncoder: orderEncoder}, nil
+}
+
+// newEventEncoder returns the encoder of an event topic, or nil when the
+// topic is not set.
+func newEventEncoder(cfg kafka.Config, topic string, msg proto.Message) (codec.Codec, error) {
+	if topic == "" {
+		return nil, nil
+	}
+	return codec.NewEncoder(cfg, topic, msg)
 }
 
 func (p *producerHandler) Produce(ctx context.Context, album *pb.Album) {
 	deliveryChan := make(chan ext_kafka.Event)
 
-	marshaledAlbum, err := proto.Marshal(album)
+	marshaledAlbum, err := p.encoder.Encode(album)
 	if err != nil {
 		log.Panicf("failed to marshal album: %v", err)
 	}
 
+	headers := []ext_kafka.Header{
+		{Key: "myTestHeader", Value: []byte("header values are binary")},
+		{Key: codec.ContentTypeHeader, Value: []byte(p.encoder.ContentType())},
+	}
+	if principal := kafka_message.Principal(ctx); principal != "" {
+		headers = append(headers, ext_kafka.Header{Key: kafka_message.PrincipalHeader, Value: []byte(principal)})
+	}
 	err = p.confluentProducer.Produce(&ext_kafka.Message{
 		TopicPartition: ext_kafka.TopicPartition{Topic: &p.cfg.Topics, Partition: ext_kafka.PartitionAny},
+		Key:            []byte(strconv.Itoa(int(album.Id))),
 		Value:          marshaledAlbum,
-		Headers:        []ext_kafka.Header{{Key: "myTestHeader", Value: []byte("header values are binary")}},
+		Headers:        headers,
 	}, deliveryChan)
 	if err != nil {
 		log.Panicf("failed to produce album: %v", err)
@@ -56,3 +97,49 @@ func (p *producerHandler) Produce(ctx context.Context, album *pb.Album) {
 
 	close(deliveryChan)
 }
+
+func (p *producerHandler) ProduceStockEvent(ctx context.Context, event *pb.StockEvent) {
+	p.produceEvent(p.cfg.StockTopic, p.stockEncoder, event.Stock.GetAlbumId(), event.Type, event)
+}
+
+func (p *producerHandler) ProduceOrderEvent(ctx context.Context, event *pb.OrderEvent) {
+	p.produceEvent(p.cfg.OrderTopic, p.orderEncoder, event.Order.GetId(), event.Type, event)
+}
+
+// produceEvent sends the event to the topic keyed by id and waits for its
+// delivery. Failures are logged and events are dropped when the topic is not
+// set.
+func (p *producerHandler) produceEvent(topic string, encoder codec.Codec, id int32, eventType string, event proto.Message) {
+	if encoder == nil {
+		log.Printf("dropped %s event of %d, no topic is set for it", eventType, id)
+		return
+	}
+	marshaledEvent, err := encoder.Encode(event)
+	if err != nil {
+		log.Printf("failed to marshal %s event: %v", eventType, err)
+		return
+	}
+
+	deliveryChan := make(chan ext_kafka.Event, 1)
+	defer close(deliveryChan)
+	err = p.confluentProducer.Produce(&ext_kafka.Message{
+		TopicPartition: ext_kafka.TopicPartition{Topic: &topic, Partition: ext_kafka.PartitionAny},
+		Key:            []byte(strconv.Itoa(int(id))),
+		Value:          marshaledEvent,
+		Headers: []ext_kafka.Header{
+			{Key: codec.ContentTypeHeader, Value: []byte(encoder.ContentType())},
+		},
+	}, deliveryChan)
+	if err != nil {
+		log.Printf("failed to produce %s event: %v", eventType, err)
+		return
+	}
+
+	message := (<-deliveryChan).(*ext_kafka.Message)
+	if message.TopicPartition.Error != nil {
+		log.Printf("failed to deliver %s event: %v\n", eventType, message.TopicPartition.Error)
+	} else {
+		log.Printf("delivered %s event to topic %s [%d] at offset %v\n",
+			eventType, *message.TopicPartition.Topic, message.TopicPartition.Partition, message.TopicPartition.Offset)
+	}
+}
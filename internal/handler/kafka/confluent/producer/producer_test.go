@@ -3,7 +3,9 @@ package producer
 import (
 	"testing"
 
+	"music-service/gen/pb"
 	"music-service/pkg/kafka"
+	"music-service/pkg/kafka/codec"
 )
 
 func TestNewProducerHandler_InvalidBrokers(t *testing.T) {
@@ -95,6 +97,7 @@ func TestProducerHandler_ConfigStorage(t *testing.T) {
 	ph := &producerHandler{
 		cfg:               cfg,
 		confluentProducer: nil,
+		encoder:           protobufEncoder(t),
 	}
 
 	if ph.cfg.Brokers != cfg.Brokers {
@@ -105,3 +108,12 @@ func TestProducerHandler_ConfigStorage(t *testing.T) {
 		t.Errorf("Topics mismatch: got %s, want %s", ph.cfg.Topics, cfg.Topics)
 	}
 }
+
+func protobufEncoder(t *testing.T) codec.Codec {
+	t.Helper()
+	encoder, err := codec.NewEncoder(kafka.Config{}, "test-topic", &pb.Album{})
+	if err != nil {
+		t.Fatalf("Failed to create encoder: %v", err)
+	}
+	return encoder
+}
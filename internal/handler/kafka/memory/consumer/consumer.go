@@ -0,0 +1,19 @@
+package consumer
+
+import (
+	"music-service/internal/handler/kafka/message"
+	"music-service/internal/repository/postgres/orm"
+	"music-service/pkg/kafka"
+	"music-service/pkg/kafka/codec"
+	"music-service/pkg/kafka/memory"
+)
+
+func NewConsumerHandler(cfg kafka.Config, broker *memory.Broker, repository orm.Repository, prices orm.PriceRepository, tracks orm.TrackRepository) (kafka.ConsumerHandler, error) {
+	decoder, err := codec.NewDecoder(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	messageValueProcessor := message.NewMessageValueProcessor(repository, prices, tracks, decoder)
+	return memory.NewConsumer(broker, cfg, messageValueProcessor), nil
+}
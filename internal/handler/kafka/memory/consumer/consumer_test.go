@@ -0,0 +1,183 @@
+package consumer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/handler/kafka/memory/producer"
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+	v1 "music-service/internal/routes/v1"
+	"music-service/pkg/auth"
+	"music-service/pkg/kafka"
+	"music-service/pkg/kafka/memory"
+)
+
+// albumStore is an in-memory orm.Repository
+type albumStore struct {
+	mu     sync.Mutex
+	albums map[int]models.Album
+}
+
+func (s *albumStore) Create(album models.Album) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.albums[album.Id] = album
+	return nil
+}
+
+func (s *albumStore) GetById(id int) (*models.Album, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	album, ok := s.albums[id]
+	if !ok {
+		return nil, errors.New("pg: no rows in result set")
+	}
+	return &album, nil
+}
+
+func (s *albumStore) Get() ([]*models.Album, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	albums := []*models.Album{}
+	for _, album := range s.albums {
+		albums = append(albums, &album)
+	}
+	return albums, nil
+}
+
+func (s *albumStore) Update(album models.Album) error {
+	return s.Create(album)
+}
+
+func (s *albumStore) Upsert(album models.Album) error {
+	return s.Create(album)
+}
+
+func (s *albumStore) get(id int) (models.Album, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	album, ok := s.albums[id]
+	return album, ok
+}
+
+// priceStore records who saved the albums through the price repository
+type priceStore struct {
+	orm.PriceRepository
+	mu        sync.Mutex
+	changedBy []string
+}
+
+func (s *priceStore) SaveAlbum(album models.Album, changedBy string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.changedBy = append(s.changedBy, changedBy)
+	return nil
+}
+
+func (s *priceStore) saved() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.changedBy...)
+}
+
+// TestPipeline_RestToRepository tests REST -> Kafka -> consumer -> repository on the in-memory broker
+func TestPipeline_RestToRepository(t *testing.T) {
+	cfg := kafka.Config{Driver: kafka.DriverMemory, Topics: "albums", ConsumerGroup: "pipeline", Oldest: true}
+	broker := memory.NewBroker(memory.DefaultPartitions)
+	store := &albumStore{albums: make(map[int]models.Album)}
+
+	consumerHandler, err := NewConsumerHandler(cfg, broker, store, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go consumerHandler.Consume(ctx)
+
+	app := fiber.New()
+	producerHandler, err := producer.NewProducerHandler(cfg, broker)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	v1.RegisterPublicRoutes(app.Group("/api/v1"), producerHandler, store, nil, nil)
+
+	for _, body := range []string{
+		`{"id": 1, "title": "Blue Train", "artist": "John Coltrane"}`,
+		`{"id": 1, "title": "Blue Train (Remastered)", "artist": "John Coltrane"}`,
+	} {
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/album", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.StatusCode != fiber.StatusCreated {
+			t.Fatalf("Expected status %d, got %d", fiber.StatusCreated, resp.StatusCode)
+		}
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		album, ok := store.get(1)
+		if ok && album.Title == "Blue Train (Remastered)" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected updated album to reach the repository, got %+v (found=%v)", album, ok)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// TestPipeline_Principal tests that price changes are recorded as made by the caller of the REST API
+func TestPipeline_Principal(t *testing.T) {
+	cfg := kafka.Config{Driver: kafka.DriverMemory, Topics: "albums", ConsumerGroup: "pipeline", Oldest: true}
+	broker := memory.NewBroker(memory.DefaultPartitions)
+	store := &albumStore{albums: make(map[int]models.Album)}
+	prices := &priceStore{}
+
+	consumerHandler, err := NewConsumerHandler(cfg, broker, store, prices, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go consumerHandler.Consume(ctx)
+
+	app := fiber.New()
+	app.Use(func(ctx *fiber.Ctx) error {
+		ctx.SetUserContext(auth.NewContext(ctx.UserContext(), &auth.Identity{Subject: "jane"}))
+		return ctx.Next()
+	})
+	producerHandler, err := producer.NewProducerHandler(cfg, broker)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	v1.RegisterPublicRoutes(app.Group("/api/v1"), producerHandler, store, nil, nil)
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/album", bytes.NewBufferString(`{"id": 1, "title": "Blue Train", "price": 9.99}`))
+	req.Header.Set("Content-Type", "application/json")
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(prices.saved()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the album to reach the price repository")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if changedBy := prices.saved(); changedBy[0] != "jane" {
+		t.Errorf("Expected the price to be changed by jane, got %v", changedBy)
+	}
+}
@@ -1,53 +1,168 @@
 package message
 
 import (
+	"errors"
 	"log"
-	"math/rand/v2"
+	"strings"
 
 	"github.com/shopspring/decimal"
-	"google.golang.org/protobuf/proto"
 
 	"music-service/gen/pb"
 	"music-service/internal/models"
 	"music-service/internal/repository/postgres/orm"
+	"music-service/pkg/kafka/codec"
+	"music-service/pkg/kafka/registry"
 )
 
+// ChangedBy is recorded in music.price_history as the writer of the prices
+// the processor stores from messages without a principal header.
+const ChangedBy = "kafka-consumer"
+
 type MessageValueProcessor struct {
 	repository orm.Repository
+	prices     orm.PriceRepository
+	tracks     orm.TrackRepository
+	decoder    *codec.Decoder
 }
 
-func NewMessageValueProcessor(repository orm.Repository) *MessageValueProcessor {
-	return &MessageValueProcessor{repository: repository}
+// NewMessageValueProcessor returns a processor that decodes values with the
+// decoder, which resolves the schema of framed protobuf values. A nil decoder
+// decodes protobuf values without checking their schema. The albums are saved
+// through prices, which records their price changes as made by the principal
+// of the message, or ChangedBy, and through repository alone when prices is
+// nil. The track changes of the albums are
+// ignored when tracks is nil.
+func NewMessageValueProcessor(repository orm.Repository, prices orm.PriceRepository, tracks orm.TrackRepository, decoder *codec.Decoder) *MessageValueProcessor {
+	return &MessageValueProcessor{repository: repository, prices: prices, tracks: tracks, decoder: decoder}
 }
 
-func (p *MessageValueProcessor) Process(messageValue []byte) {
+func (p *MessageValueProcessor) Process(messageValue []byte, contentType, principal string) error {
 	protoAlbum := &pb.Album{}
-	if err := proto.Unmarshal(messageValue, protoAlbum); err != nil {
+	if err := p.decoder.Decode(contentType, messageValue, protoAlbum); err != nil {
+		if errors.Is(err, registry.ErrIncompatible) || errors.Is(err, codec.ErrUnsupportedContentType) {
+			log.Printf("skipping album that cannot be decoded: %v", err)
+			return err
+		}
 		log.Fatalf("failed to unmarshal to album: %v", err)
 	}
 
-	_, err := p.repository.GetById(int(protoAlbum.Id))
+	existing, err := p.repository.GetById(int(protoAlbum.Id))
 	if err != nil && err.Error() != "pg: no rows in result set" {
 		log.Fatalf("failed to read album from postgres: %v", err)
 	}
 
+	found := err == nil
+	if !found || existing == nil {
+		existing = &models.Album{}
+	}
+
+	// The album is written as a whole, so the price, label and release date
+	// of the stored album are kept when the event has none.
 	album := models.Album{
-		Id:     int(protoAlbum.Id),
-		Title:  protoAlbum.Title,
-		Artist: protoAlbum.Artist,
-		Price:  decimal.NewFromFloat(rand.Float64()),
-	}
-	if err != nil && err.Error() == "pg: no rows in result set" {
-		err = p.repository.Create(album)
-		if err != nil {
+		Id:          int(protoAlbum.Id),
+		Title:       protoAlbum.Title,
+		ArtistId:    int(protoAlbum.ArtistId),
+		Artist:      protoAlbum.Artist,
+		Price:       existing.Price,
+		Currency:    currency(protoAlbum.Currency, existing),
+		LabelId:     existing.LabelId,
+		ReleaseDate: existing.ReleaseDate,
+	}
+	if protoAlbum.Price > 0 {
+		album.Price = decimal.NewFromFloat32(protoAlbum.Price).Round(2)
+	}
+	if protoAlbum.LabelId > 0 {
+		album.LabelId = int(protoAlbum.LabelId)
+	}
+	if protoAlbum.ReleaseDate != "" {
+		if releaseDate, err := models.ParseDate(protoAlbum.ReleaseDate); err != nil {
+			log.Printf("skipping release date of album %d: %v", album.Id, err)
+		} else {
+			album.ReleaseDate = releaseDate
+		}
+	}
+
+	switch {
+	case p.prices != nil:
+		changedBy := principal
+		if changedBy == "" {
+			changedBy = ChangedBy
+		}
+		if err := p.prices.SaveAlbum(album, changedBy); err != nil {
+			log.Fatalf("failed to save album in postgres: %v", err)
+		}
+		log.Printf("saved album in postgres: %s", album.String())
+	case !found:
+		if err := p.repository.Create(album); err != nil {
 			log.Fatalf("failed to create album in postgres: %v", err)
 		}
 		log.Printf("created album in postgres: %s", album.String())
-	} else {
-		err = p.repository.Update(album)
-		if err != nil {
+	default:
+		if err := p.repository.Update(album); err != nil {
 			log.Fatalf("failed to update album in postgres: %v", err)
 		}
 		log.Printf("updated album in postgres: %s", album.String())
 	}
+
+	if p.tracks != nil && (len(protoAlbum.Tracks) > 0 || len(protoAlbum.RemovedTracks) > 0) {
+		tracks := toTracks(protoAlbum.Tracks)
+		removed := toRemovedTracks(protoAlbum.RemovedTracks)
+		if err := p.tracks.Save(album.Id, tracks, removed); err != nil {
+			log.Fatalf("failed to save tracks of album %d in postgres: %v", album.Id, err)
+		}
+		log.Printf("saved %d and removed %d tracks of album %d in postgres", len(tracks), len(removed), album.Id)
+	}
+	return nil
+}
+
+// currency returns the currency of the album, keeping the currency of the
+// stored album when the album has none or an invalid one.
+func currency(code string, existing *models.Album) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if models.ValidCurrency(code) {
+		return code
+	}
+	if code != "" {
+		log.Printf("skipping invalid currency %q", code)
+	}
+	if existing != nil && existing.Currency != "" {
+		return existing.Currency
+	}
+	return models.DefaultCurrency
+}
+
+// toTracks returns the valid tracks, skipping the others.
+func toTracks(protoTracks []*pb.Track) []models.Track {
+	tracks := []models.Track{}
+	for _, protoTrack := range protoTracks {
+		track := models.Track{
+			Disc:       int(protoTrack.Disc),
+			Number:     int(protoTrack.Number),
+			Title:      protoTrack.Title,
+			DurationMs: int(protoTrack.DurationMs),
+			Isrc:       protoTrack.Isrc,
+		}
+		track.Normalize()
+		if err := track.Validate(); err != nil {
+			log.Printf("skipping track %s: %v", track.String(), err)
+			continue
+		}
+		tracks = append(tracks, track)
+	}
+	return tracks
+}
+
+// toRemovedTracks returns the disc and number of the removed tracks.
+func toRemovedTracks(protoTracks []*pb.Track) []models.Track {
+	tracks := []models.Track{}
+	for _, protoTrack := range protoTracks {
+		track := models.Track{Disc: int(protoTrack.Disc), Number: int(protoTrack.Number)}
+		track.Normalize()
+		if track.Disc < 1 || track.Number < 1 {
+			log.Printf("skipping removal of track %s: disc and number must be positive", track.String())
+			continue
+		}
+		tracks = append(tracks, track)
+	}
+	return tracks
 }
@@ -1,13 +1,20 @@
 package message
 
 import (
+	"context"
 	"errors"
+	"path/filepath"
 	"testing"
 
+	"github.com/shopspring/decimal"
 	"google.golang.org/protobuf/proto"
 
 	"music-service/gen/pb"
 	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+	"music-service/pkg/kafka"
+	"music-service/pkg/kafka/codec"
+	"music-service/pkg/kafka/registry"
 )
 
 // mockRepository is a mock implementation of orm.Repository
@@ -64,10 +71,55 @@ func (m *mockRepository) Upsert(album models.Album) error {
 	return nil
 }
 
+// mockTrackRepository is a mock implementation of orm.TrackRepository that
+// records the track changes it saves
+type mockTrackRepository struct {
+	albumId   int
+	saved     []models.Track
+	removed   []models.Track
+	saveCalls int
+}
+
+func (m *mockTrackRepository) Create(track *models.Track) error { return nil }
+
+func (m *mockTrackRepository) GetById(albumId, id int) (*models.Track, error) { return nil, nil }
+
+func (m *mockTrackRepository) GetByAlbum(albumId int) ([]*models.Track, error) { return nil, nil }
+
+func (m *mockTrackRepository) Get() ([]*models.Track, error) { return nil, nil }
+
+func (m *mockTrackRepository) Update(track models.Track) error { return nil }
+
+func (m *mockTrackRepository) Delete(albumId, id int) error { return nil }
+
+func (m *mockTrackRepository) Save(albumId int, tracks []models.Track, removed []models.Track) error {
+	m.saveCalls++
+	m.albumId = albumId
+	m.saved = tracks
+	m.removed = removed
+	return nil
+}
+
+// mockPriceRepository is a mock implementation of orm.PriceRepository that
+// records the album it saves
+type mockPriceRepository struct {
+	orm.PriceRepository
+	saved     models.Album
+	changedBy string
+	saveCalls int
+}
+
+func (m *mockPriceRepository) SaveAlbum(album models.Album, changedBy string) error {
+	m.saveCalls++
+	m.saved = album
+	m.changedBy = changedBy
+	return nil
+}
+
 func TestNewMessageValueProcessor(t *testing.T) {
 	t.Run("creates new message value processor successfully", func(t *testing.T) {
 		mockRepo := &mockRepository{}
-		processor := NewMessageValueProcessor(mockRepo)
+		processor := NewMessageValueProcessor(mockRepo, nil, nil, nil)
 
 		if processor == nil {
 			t.Fatal("Expected processor to be non-nil")
@@ -82,7 +134,7 @@ func TestNewMessageValueProcessor(t *testing.T) {
 func TestMessageValueProcessor_ProcessMessageValue_CreateNewAlbum(t *testing.T) {
 	t.Run("creates new album when not found in database", func(t *testing.T) {
 		mockRepo := &mockRepository{}
-		processor := NewMessageValueProcessor(mockRepo)
+		processor := NewMessageValueProcessor(mockRepo, nil, nil, nil)
 
 		// Setup mock to return "no rows in result set" error for GetById
 		mockRepo.getByIdFunc = func(id int) (*models.Album, error) {
@@ -119,7 +171,7 @@ func TestMessageValueProcessor_ProcessMessageValue_CreateNewAlbum(t *testing.T)
 		}
 
 		// Process the message value
-		processor.Process(messageValue)
+		processor.Process(messageValue, "", "")
 
 		// Verify GetById was called
 		if mockRepo.getByIdCalls != 1 {
@@ -141,7 +193,7 @@ func TestMessageValueProcessor_ProcessMessageValue_CreateNewAlbum(t *testing.T)
 func TestMessageValueProcessor_ProcessMessageValue_UpdateExistingAlbum(t *testing.T) {
 	t.Run("updates existing album when found in database", func(t *testing.T) {
 		mockRepo := &mockRepository{}
-		processor := NewMessageValueProcessor(mockRepo)
+		processor := NewMessageValueProcessor(mockRepo, nil, nil, nil)
 
 		// Setup mock to return an existing album for GetById
 		mockRepo.getByIdFunc = func(id int) (*models.Album, error) {
@@ -182,7 +234,7 @@ func TestMessageValueProcessor_ProcessMessageValue_UpdateExistingAlbum(t *testin
 		}
 
 		// Process the message value
-		processor.Process(messageValue)
+		processor.Process(messageValue, "", "")
 
 		// Verify GetById was called
 		if mockRepo.getByIdCalls != 1 {
@@ -204,7 +256,7 @@ func TestMessageValueProcessor_ProcessMessageValue_UpdateExistingAlbum(t *testin
 func TestMessageValueProcessor_ProcessMessageValue_WithZeroValues(t *testing.T) {
 	t.Run("handles album with zero values", func(t *testing.T) {
 		mockRepo := &mockRepository{}
-		processor := NewMessageValueProcessor(mockRepo)
+		processor := NewMessageValueProcessor(mockRepo, nil, nil, nil)
 
 		// Setup mock to return "no rows in result set" error for GetById
 		mockRepo.getByIdFunc = func(id int) (*models.Album, error) {
@@ -238,7 +290,7 @@ func TestMessageValueProcessor_ProcessMessageValue_WithZeroValues(t *testing.T)
 		}
 
 		// Process the message value
-		processor.Process(messageValue)
+		processor.Process(messageValue, "", "")
 
 		// Verify GetById was called
 		if mockRepo.getByIdCalls != 1 {
@@ -252,50 +304,159 @@ func TestMessageValueProcessor_ProcessMessageValue_WithZeroValues(t *testing.T)
 	})
 }
 
-func TestMessageValueProcessor_ProcessMessageValue_PriceGeneration(t *testing.T) {
-	t.Run("generates random price for album", func(t *testing.T) {
-		mockRepo := &mockRepository{}
-		processor := NewMessageValueProcessor(mockRepo)
+func TestMessageValueProcessor_ProcessMessageValue_Price(t *testing.T) {
+	tests := []struct {
+		name     string
+		price    float32
+		stored   *models.Album
+		expected string
+	}{
+		{name: "stores the price of the event", price: 17.99, stored: &models.Album{Id: 1, Price: decimal.RequireFromString("9.99")}, expected: "17.99"},
+		{name: "keeps the stored price without one", price: 0, stored: &models.Album{Id: 1, Price: decimal.RequireFromString("9.99")}, expected: "9.99"},
+		{name: "stores the price of a new album", price: 56.99, expected: "56.99"},
+		{name: "stores no price for a new album without one", price: 0, expected: "0"},
+	}
 
-		// Setup mock to return "no rows in result set" error for GetById
-		mockRepo.getByIdFunc = func(id int) (*models.Album, error) {
-			return nil, errors.New("pg: no rows in result set")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var written models.Album
+			mockRepo := &mockRepository{
+				getByIdFunc: func(id int) (*models.Album, error) {
+					if tt.stored == nil {
+						return nil, errors.New("pg: no rows in result set")
+					}
+					return tt.stored, nil
+				},
+				createFunc: func(album models.Album) error {
+					written = album
+					return nil
+				},
+				updateFunc: func(album models.Album) error {
+					written = album
+					return nil
+				},
+			}
+			processor := NewMessageValueProcessor(mockRepo, nil, nil, nil)
+
+			messageValue, err := proto.Marshal(&pb.Album{Id: 1, Title: "Blue Train", Price: tt.price})
+			if err != nil {
+				t.Fatalf("Failed to marshal proto album: %v", err)
+			}
+			if err := processor.Process(messageValue, "", ""); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if written.Price.String() != tt.expected {
+				t.Errorf("Expected price %s, got %s", tt.expected, written.Price)
+			}
+		})
+	}
+}
+
+func TestMessageValueProcessor_ProcessMessageValue_Prices(t *testing.T) {
+	t.Run("saves the album through the price repository", func(t *testing.T) {
+		mockRepo := &mockRepository{
+			getByIdFunc: func(id int) (*models.Album, error) {
+				return &models.Album{Id: id, Price: decimal.RequireFromString("9.99"), Currency: "EUR"}, nil
+			},
 		}
+		mockPrices := &mockPriceRepository{}
+		processor := NewMessageValueProcessor(mockRepo, mockPrices, nil, nil)
 
-		// Setup mock to capture the price
-		var capturedPrice float64
-		mockRepo.createFunc = func(album models.Album) error {
-			priceFloat, _ := album.Price.Float64()
-			capturedPrice = priceFloat
-			return nil
+		messageValue, err := proto.Marshal(&pb.Album{Id: 1, Title: "Blue Train", Price: 17.99})
+		if err != nil {
+			t.Fatalf("Failed to marshal proto album: %v", err)
+		}
+		if err := processor.Process(messageValue, "", ""); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
 		}
 
-		// Create protobuf album and marshal it
-		protoAlbum := &pb.Album{
-			Id:     1,
-			Title:  "Test Album",
-			Artist: "Test Artist",
-			Price:  100.00, // This will be replaced with random price
+		if mockPrices.saveCalls != 1 {
+			t.Fatalf("Expected SaveAlbum to be called once, got %d calls", mockPrices.saveCalls)
 		}
-		messageValue, err := proto.Marshal(protoAlbum)
+		if mockPrices.changedBy != ChangedBy {
+			t.Errorf("Expected the album to be saved by %s, got %s", ChangedBy, mockPrices.changedBy)
+		}
+		if mockPrices.saved.Price.String() != "17.99" || mockPrices.saved.Currency != "EUR" {
+			t.Errorf("Expected price 17.99 EUR, got %s %s", mockPrices.saved.Price, mockPrices.saved.Currency)
+		}
+		if mockRepo.createCalls != 0 || mockRepo.updateCalls != 0 {
+			t.Errorf("Expected the album to be written only through the price repository, got %d creates and %d updates", mockRepo.createCalls, mockRepo.updateCalls)
+		}
+	})
+
+	t.Run("keeps the stored price of an event without one", func(t *testing.T) {
+		mockRepo := &mockRepository{
+			getByIdFunc: func(id int) (*models.Album, error) {
+				return &models.Album{Id: id, Price: decimal.RequireFromString("9.99"), Currency: "EUR"}, nil
+			},
+		}
+		mockPrices := &mockPriceRepository{}
+		processor := NewMessageValueProcessor(mockRepo, mockPrices, nil, nil)
+
+		messageValue, err := proto.Marshal(&pb.Album{Id: 1, Title: "Blue Train"})
 		if err != nil {
 			t.Fatalf("Failed to marshal proto album: %v", err)
 		}
+		if err := processor.Process(messageValue, "", ""); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
 
-		// Process the message value
-		processor.Process(messageValue)
-
-		// Verify price is within range [0, 1)
-		if capturedPrice < 0 || capturedPrice >= 1 {
-			t.Errorf("Expected price to be in range [0, 1), got %f", capturedPrice)
+		if mockPrices.saved.Price.String() != "9.99" {
+			t.Errorf("Expected the stored price 9.99 to be kept, got %s", mockPrices.saved.Price)
 		}
 	})
 }
 
+// music.notify_price_drop notifies the wishlists of an album only for a price
+// change recorded in music.price_history, which set_album_price records only
+// for a price distinct from the stored one.
+func TestMessageValueProcessor_ProcessMessageValue_PriceDrop(t *testing.T) {
+	tests := []struct {
+		name    string
+		price   float32
+		saved   string
+		dropped bool
+	}{
+		{name: "drops the price for a lower one", price: 7.99, saved: "7.99", dropped: true},
+		{name: "keeps the price for the same one", price: 9.99, saved: "9.99"},
+		{name: "keeps the price without one", price: 0, saved: "9.99"},
+		{name: "raises the price for a higher one", price: 12.99, saved: "12.99"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored := decimal.RequireFromString("9.99")
+			mockRepo := &mockRepository{
+				getByIdFunc: func(id int) (*models.Album, error) {
+					return &models.Album{Id: id, Price: stored, Currency: "EUR"}, nil
+				},
+			}
+			mockPrices := &mockPriceRepository{}
+			processor := NewMessageValueProcessor(mockRepo, mockPrices, nil, nil)
+
+			messageValue, err := proto.Marshal(&pb.Album{Id: 1, Title: "Blue Train", Price: tt.price})
+			if err != nil {
+				t.Fatalf("Failed to marshal proto album: %v", err)
+			}
+			if err := processor.Process(messageValue, "", ""); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if mockPrices.saved.Price.String() != tt.saved {
+				t.Errorf("Expected price %s to be saved, got %s", tt.saved, mockPrices.saved.Price)
+			}
+			if dropped := mockPrices.saved.Price.LessThan(stored); dropped != tt.dropped {
+				t.Errorf("Expected price drop %v from %s, got %v", tt.dropped, stored, dropped)
+			}
+		})
+	}
+}
+
 func TestMessageValueProcessor_ProcessMessageValue_MultipleAlbums(t *testing.T) {
 	t.Run("processes multiple albums correctly", func(t *testing.T) {
 		mockRepo := &mockRepository{}
-		processor := NewMessageValueProcessor(mockRepo)
+		processor := NewMessageValueProcessor(mockRepo, nil, nil, nil)
 
 		albums := []*pb.Album{
 			{Id: 1, Title: "Album 1", Artist: "Artist 1", Price: 10.99},
@@ -323,7 +484,7 @@ func TestMessageValueProcessor_ProcessMessageValue_MultipleAlbums(t *testing.T)
 			if err != nil {
 				t.Fatalf("Failed to marshal proto album: %v", err)
 			}
-			processor.Process(messageValue)
+			processor.Process(messageValue, "", "")
 		}
 
 		// Verify all albums were created
@@ -346,3 +507,312 @@ func TestMessageValueProcessor_ProcessMessageValue_MultipleAlbums(t *testing.T)
 		}
 	})
 }
+
+func TestMessageValueProcessor_ProcessMessageValue_SchemaRegistry(t *testing.T) {
+	cfg := kafka.Config{
+		Topics: "albums",
+		SchemaRegistry: kafka.SchemaRegistryConfig{
+			File:         filepath.Join(t.TempDir(), "schemas.json"),
+			AutoRegister: true,
+		},
+	}
+
+	encoder, err := codec.NewEncoder(cfg, cfg.Topics, &pb.Album{})
+	if err != nil {
+		t.Fatalf("Failed to create encoder: %v", err)
+	}
+	decoder, err := codec.NewDecoder(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create decoder: %v", err)
+	}
+
+	t.Run("decodes framed album after resolving its schema", func(t *testing.T) {
+		mockRepo := &mockRepository{}
+		processor := NewMessageValueProcessor(mockRepo, nil, nil, decoder)
+
+		mockRepo.getByIdFunc = func(id int) (*models.Album, error) {
+			return nil, errors.New("pg: no rows in result set")
+		}
+
+		messageValue, err := encoder.Encode(&pb.Album{Id: 7, Title: "Kind of Blue", Artist: "Miles Davis"})
+		if err != nil {
+			t.Fatalf("Failed to encode album: %v", err)
+		}
+
+		processor.Process(messageValue, encoder.ContentType(), "")
+
+		if mockRepo.createCalls != 1 {
+			t.Errorf("Expected Create to be called once, got %d", mockRepo.createCalls)
+		}
+	})
+
+	t.Run("skips album written with an incompatible schema", func(t *testing.T) {
+		mockRepo := &mockRepository{}
+		processor := NewMessageValueProcessor(mockRepo, nil, nil, decoder)
+
+		incompatible := registry.Schema{Type: registry.SchemaTypeProtobuf, Schema: "message Album {\n  string id = 1;\n}\n"}
+		id, err := registry.NewFileRegistry(cfg.SchemaRegistry.File).Register(context.Background(), "legacy-value", incompatible)
+		if err != nil {
+			t.Fatalf("Failed to register schema: %v", err)
+		}
+
+		processor.Process(registry.Frame(id, []int{0}, []byte{0x0a, 0x01, 0x37}), "", "")
+
+		if mockRepo.getByIdCalls != 0 || mockRepo.createCalls != 0 {
+			t.Error("Expected incompatible album not to reach the repository")
+		}
+	})
+}
+
+func TestMessageValueProcessor_ProcessMessageValue_Encodings(t *testing.T) {
+	for _, encoding := range []string{codec.EncodingProtobuf, codec.EncodingJSON, codec.EncodingAvro} {
+		t.Run(encoding, func(t *testing.T) {
+			cfg := kafka.Config{Topics: "albums", Encodings: map[string]string{"albums": encoding}}
+			encoder, err := codec.NewEncoder(cfg, cfg.Topics, &pb.Album{})
+			if err != nil {
+				t.Fatalf("Failed to create encoder: %v", err)
+			}
+
+			mockRepo := &mockRepository{}
+			processor := NewMessageValueProcessor(mockRepo, nil, nil, nil)
+
+			mockRepo.getByIdFunc = func(id int) (*models.Album, error) {
+				return nil, errors.New("pg: no rows in result set")
+			}
+			mockRepo.createFunc = func(album models.Album) error {
+				if album.Id != 9 || album.Title != "A Love Supreme" {
+					t.Errorf("Expected album 9 A Love Supreme, got %d %s", album.Id, album.Title)
+				}
+				return nil
+			}
+
+			messageValue, err := encoder.Encode(&pb.Album{Id: 9, Title: "A Love Supreme", Artist: "John Coltrane"})
+			if err != nil {
+				t.Fatalf("Failed to encode album: %v", err)
+			}
+
+			processor.Process(messageValue, encoder.ContentType(), "")
+
+			if mockRepo.createCalls != 1 {
+				t.Errorf("Expected Create to be called once, got %d", mockRepo.createCalls)
+			}
+		})
+	}
+
+	t.Run("unsupported content type is skipped", func(t *testing.T) {
+		mockRepo := &mockRepository{}
+		processor := NewMessageValueProcessor(mockRepo, nil, nil, nil)
+
+		processor.Process([]byte("<album/>"), "application/xml", "")
+
+		if mockRepo.getByIdCalls != 0 {
+			t.Error("Expected album with unsupported content type not to reach the repository")
+		}
+	})
+}
+
+func TestMessageValueProcessor_ProcessMessageValue_Tracks(t *testing.T) {
+	t.Run("saves the valid track changes of the album", func(t *testing.T) {
+		mockTracks := &mockTrackRepository{}
+		processor := NewMessageValueProcessor(&mockRepository{}, nil, mockTracks, nil)
+
+		protoAlbum := &pb.Album{
+			Id:     1,
+			Title:  "Blue Train",
+			Artist: "John Coltrane",
+			Tracks: []*pb.Track{
+				{Number: 1, Title: "Blue Train", DurationMs: 643000, Isrc: "us-s1z-99-00001"},
+				{Number: 2, Title: ""},
+			},
+			RemovedTracks: []*pb.Track{{Disc: 2, Number: 1}},
+		}
+		messageValue, err := proto.Marshal(protoAlbum)
+		if err != nil {
+			t.Fatalf("Failed to marshal proto album: %v", err)
+		}
+
+		if err := processor.Process(messageValue, "", ""); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if mockTracks.saveCalls != 1 || mockTracks.albumId != 1 {
+			t.Fatalf("Expected the tracks of album 1 to be saved once, got %d calls for album %d", mockTracks.saveCalls, mockTracks.albumId)
+		}
+		expected := []models.Track{{Disc: 1, Number: 1, Title: "Blue Train", DurationMs: 643000, Isrc: "USS1Z9900001"}}
+		if len(mockTracks.saved) != 1 || mockTracks.saved[0] != expected[0] {
+			t.Errorf("Expected saved tracks %v, got %v", expected, mockTracks.saved)
+		}
+		if len(mockTracks.removed) != 1 || mockTracks.removed[0].Disc != 2 || mockTracks.removed[0].Number != 1 {
+			t.Errorf("Expected track 1 of disc 2 to be removed, got %v", mockTracks.removed)
+		}
+	})
+
+	t.Run("leaves the tracks alone when the album carries no track changes", func(t *testing.T) {
+		mockTracks := &mockTrackRepository{}
+		processor := NewMessageValueProcessor(&mockRepository{}, nil, mockTracks, nil)
+
+		messageValue, err := proto.Marshal(&pb.Album{Id: 1, Title: "Blue Train", Artist: "John Coltrane"})
+		if err != nil {
+			t.Fatalf("Failed to marshal proto album: %v", err)
+		}
+		processor.Process(messageValue, "", "")
+
+		if mockTracks.saveCalls != 0 {
+			t.Errorf("Expected Save not to be called, got %d calls", mockTracks.saveCalls)
+		}
+	})
+}
+
+func TestMessageValueProcessor_ProcessMessageValue_Release(t *testing.T) {
+	tests := []struct {
+		name        string
+		releaseDate string
+		expected    string
+	}{
+		{name: "stores the release date and label", releaseDate: "1958-01-01", expected: "1958-01-01"},
+		{name: "skips an invalid release date", releaseDate: "January 1958", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated models.Album
+			mockRepo := &mockRepository{
+				updateFunc: func(album models.Album) error {
+					updated = album
+					return nil
+				},
+			}
+			processor := NewMessageValueProcessor(mockRepo, nil, nil, nil)
+
+			messageValue, err := proto.Marshal(&pb.Album{Id: 1, Title: "Blue Train", LabelId: 4, ReleaseDate: tt.releaseDate})
+			if err != nil {
+				t.Fatalf("Failed to marshal proto album: %v", err)
+			}
+			if err := processor.Process(messageValue, "", ""); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if updated.LabelId != 4 || updated.ReleaseDate.String() != tt.expected {
+				t.Errorf("Expected label 4 and release date %q, got label %d and %q", tt.expected, updated.LabelId, updated.ReleaseDate)
+			}
+		})
+	}
+}
+
+func TestMessageValueProcessor_ProcessMessageValue_KeepsRelease(t *testing.T) {
+	releaseDate, err := models.ParseDate("1958-01-01")
+	if err != nil {
+		t.Fatalf("Failed to parse release date: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		album    *pb.Album
+		label    int
+		expected string
+	}{
+		{name: "keeps the stored label and release date without them", album: &pb.Album{Id: 1, Title: "Blue Train"}, label: 4, expected: "1958-01-01"},
+		{name: "keeps the stored release date for an invalid one", album: &pb.Album{Id: 1, Title: "Blue Train", ReleaseDate: "January 1958"}, label: 4, expected: "1958-01-01"},
+		{name: "replaces the stored label and release date", album: &pb.Album{Id: 1, Title: "Blue Train", LabelId: 7, ReleaseDate: "1957-09-15"}, label: 7, expected: "1957-09-15"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated models.Album
+			mockRepo := &mockRepository{
+				getByIdFunc: func(id int) (*models.Album, error) {
+					return &models.Album{Id: id, LabelId: 4, ReleaseDate: releaseDate}, nil
+				},
+				updateFunc: func(album models.Album) error {
+					updated = album
+					return nil
+				},
+			}
+			processor := NewMessageValueProcessor(mockRepo, nil, nil, nil)
+
+			messageValue, err := proto.Marshal(tt.album)
+			if err != nil {
+				t.Fatalf("Failed to marshal proto album: %v", err)
+			}
+			if err := processor.Process(messageValue, "", ""); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if updated.LabelId != tt.label || updated.ReleaseDate.String() != tt.expected {
+				t.Errorf("Expected label %d and release date %q, got label %d and %q", tt.label, tt.expected, updated.LabelId, updated.ReleaseDate)
+			}
+		})
+	}
+}
+
+func TestMessageValueProcessor_ProcessMessageValue_Currency(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency string
+		stored   string
+		expected string
+	}{
+		{name: "stores the currency in upper case", currency: "eur", stored: "USD", expected: "EUR"},
+		{name: "keeps the stored currency without one", currency: "", stored: "GBP", expected: "GBP"},
+		{name: "keeps the stored currency for an invalid one", currency: "EURO", stored: "GBP", expected: "GBP"},
+		{name: "defaults the currency of a new album", currency: "", stored: "", expected: models.DefaultCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated models.Album
+			mockRepo := &mockRepository{
+				getByIdFunc: func(id int) (*models.Album, error) {
+					return &models.Album{Id: id, Currency: tt.stored}, nil
+				},
+				updateFunc: func(album models.Album) error {
+					updated = album
+					return nil
+				},
+			}
+			processor := NewMessageValueProcessor(mockRepo, nil, nil, nil)
+
+			messageValue, err := proto.Marshal(&pb.Album{Id: 1, Title: "Blue Train", Currency: tt.currency})
+			if err != nil {
+				t.Fatalf("Failed to marshal proto album: %v", err)
+			}
+			if err := processor.Process(messageValue, "", ""); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if updated.Currency != tt.expected {
+				t.Errorf("Expected currency %s, got %s", tt.expected, updated.Currency)
+			}
+		})
+	}
+}
+
+func TestMessageValueProcessor_ProcessMessageValue_Principal(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal string
+		expected  string
+	}{
+		{name: "records the principal of the message", principal: "7", expected: "7"},
+		{name: "falls back without a principal", expected: ChangedBy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPrices := &mockPriceRepository{}
+			processor := NewMessageValueProcessor(&mockRepository{}, mockPrices, nil, nil)
+
+			messageValue, err := proto.Marshal(&pb.Album{Id: 1, Title: "Blue Train", Price: 17.99})
+			if err != nil {
+				t.Fatalf("Failed to marshal proto album: %v", err)
+			}
+			if err := processor.Process(messageValue, "", tt.principal); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if mockPrices.changedBy != tt.expected {
+				t.Errorf("Expected the album to be saved by %s, got %s", tt.expected, mockPrices.changedBy)
+			}
+		})
+	}
+}
@@ -15,41 +15,75 @@ import (
 	"music-service/internal/handler/kafka/message"
 	"music-service/internal/repository/postgres/orm"
 	"music-service/pkg/kafka"
+	"music-service/pkg/kafka/codec"
 	sarama_wrapper "music-service/pkg/kafka/sarama"
 )
 
 type consumerHandler struct {
 	cfg           kafka.Config
 	consumerGroup sarama.ConsumerGroup
+	client        sarama.Client
 	repository    orm.Repository
+	prices        orm.PriceRepository
+	tracks        orm.TrackRepository
+	decoder       *codec.Decoder
+
+	mu           sync.Mutex
+	groupHandler *consumerGroupHandler
+	restart      context.CancelFunc
 }
 
-func NewConsumerHandler(cfg kafka.Config, repository orm.Repository) (kafka.ConsumerHandler, error) {
+func NewConsumerHandler(cfg kafka.Config, repository orm.Repository, prices orm.PriceRepository, tracks orm.TrackRepository) (kafka.ConsumerHandler, error) {
+	decoder, err := codec.NewDecoder(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	consumerGroup, err := sarama_wrapper.NewConsumerGroup(cfg)
 	if err != nil {
 		return nil, err
 	}
 
+	client, err := sarama_wrapper.NewClient(cfg)
+	if err != nil {
+		consumerGroup.Close()
+		return nil, err
+	}
+
 	return &consumerHandler{
 		cfg:           cfg,
 		consumerGroup: consumerGroup,
+		client:        client,
 		repository:    repository,
+		prices:        prices,
+		tracks:        tracks,
+		decoder:       decoder,
 	}, nil
 }
 
 func (h *consumerHandler) Consume(ctx context.Context) error {
 	ctx, cancel := context.WithCancel(ctx)
 
-	messageValueProcessor := message.NewMessageValueProcessor(h.repository)
+	messageValueProcessor := message.NewMessageValueProcessor(h.repository, h.prices, h.tracks, h.decoder)
 	consumerGroupHandler := NewConsumerGroupHandler(make(chan bool), messageValueProcessor)
 
-	consumptionIsPaused := false
+	h.mu.Lock()
+	h.groupHandler = consumerGroupHandler
+	h.mu.Unlock()
+
 	wg := &sync.WaitGroup{}
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 		for {
-			if err := h.consumerGroup.Consume(ctx, strings.Split(h.cfg.Topics, ","), consumerGroupHandler); err != nil {
+			sessionCtx, restart := context.WithCancel(ctx)
+			h.mu.Lock()
+			h.restart = restart
+			h.mu.Unlock()
+
+			err := h.consumerGroup.Consume(sessionCtx, strings.Split(h.cfg.Topics, ","), consumerGroupHandler)
+			restart()
+			if err != nil {
 				if errors.Is(err, sarama.ErrClosedConsumerGroup) {
 					return
 				}
@@ -79,7 +113,9 @@ func (h *consumerHandler) Consume(ctx context.Context) error {
 			log.Println("terminating: via signal")
 			keepRunning = false
 		case <-sigusr1:
-			toggleConsumptionFlow(h.consumerGroup, &consumptionIsPaused)
+			consumerGroupHandler.mu.Lock()
+			toggleConsumptionFlow(h.consumerGroup, &consumerGroupHandler.allPaused)
+			consumerGroupHandler.mu.Unlock()
 		}
 	}
 	cancel()
@@ -87,6 +123,11 @@ func (h *consumerHandler) Consume(ctx context.Context) error {
 	if err := h.consumerGroup.Close(); err != nil {
 		log.Panicf("Error closing client: %v", err)
 	}
+	if h.client != nil {
+		if err := h.client.Close(); err != nil {
+			log.Printf("Error closing offsets client: %v", err)
+		}
+	}
 
 	return nil
 }
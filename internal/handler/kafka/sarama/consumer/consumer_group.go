@@ -2,34 +2,85 @@ package consumer
 
 import (
 	"log"
+	"sync"
+	"time"
 
 	"github.com/IBM/sarama"
 
 	"music-service/internal/handler/kafka/message"
+	"music-service/pkg/kafka/metrics"
+	sarama_wrapper "music-service/pkg/kafka/sarama"
 )
 
+// commitInterval matches the sarama auto commit interval, which is disabled so
+// that commit latency can be measured.
+const commitInterval = time.Second
+
+type topicPartition struct {
+	topic     string
+	partition int32
+}
+
 type consumerGroupHandler struct {
 	Ready                 chan bool
 	MessageValueProcessor *message.MessageValueProcessor
+
+	mu        sync.Mutex
+	session   sarama.ConsumerGroupSession
+	claims    map[string][]int32
+	offsets   map[topicPartition]int64
+	paused    map[topicPartition]bool
+	allPaused bool
+
+	stopCommits func()
 }
 
 func NewConsumerGroupHandler(ready chan bool, messageValueProcessor *message.MessageValueProcessor) *consumerGroupHandler {
 	return &consumerGroupHandler{
 		Ready:                 ready,
 		MessageValueProcessor: messageValueProcessor,
+		offsets:               make(map[topicPartition]int64),
+		paused:                make(map[topicPartition]bool),
 	}
 }
 
-func (cgh *consumerGroupHandler) Setup(sarama.ConsumerGroupSession) error {
+func (cgh *consumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
+	if session != nil {
+		cgh.mu.Lock()
+		cgh.session = session
+		cgh.claims = session.Claims()
+		cgh.stopCommits = commitPeriodically(session)
+		cgh.mu.Unlock()
+	}
+	metrics.Rebalanced(metrics.RebalanceAssigned)
 	close(cgh.Ready)
 	return nil
 }
 
-func (_ *consumerGroupHandler) Cleanup(sarama.ConsumerGroupSession) error {
+func (cgh *consumerGroupHandler) Cleanup(session sarama.ConsumerGroupSession) error {
+	cgh.mu.Lock()
+	defer cgh.mu.Unlock()
+
+	if cgh.stopCommits != nil {
+		cgh.stopCommits()
+		cgh.stopCommits = nil
+	}
+	if session != nil {
+		commit(session)
+	}
+	metrics.Rebalanced(metrics.RebalanceRevoked)
+
+	cgh.session = nil
+	cgh.claims = nil
+	clear(cgh.offsets)
+	clear(cgh.paused)
 	return nil
 }
 
 func (h *consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	tp := topicPartition{topic: claim.Topic(), partition: claim.Partition()}
+	h.setOffset(tp, claim.InitialOffset())
+
 	for {
 		select {
 		case message, ok := <-claim.Messages():
@@ -38,10 +89,59 @@ func (h *consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession,
 				return nil
 			}
 
-			h.MessageValueProcessor.Process(message.Value)
+			start := time.Now()
+			err := h.MessageValueProcessor.Process(message.Value, sarama_wrapper.ContentType(message.Headers), sarama_wrapper.Principal(message.Headers))
+			metrics.ObserveMessage(message.Topic, start, err)
 			session.MarkMessage(message, "")
+			h.setOffset(tp, message.Offset+1)
 		case <-session.Context().Done():
 			return nil
 		}
 	}
 }
+
+func (h *consumerGroupHandler) setOffset(tp topicPartition, offset int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.offsets[tp] = offset
+}
+
+func (h *consumerGroupHandler) assignment() map[string][]int32 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.claims
+}
+
+// commitPeriodically commits the marked offsets of the session every
+// commitInterval until the returned function is called.
+func commitPeriodically(session sarama.ConsumerGroupSession) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(commitInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				commit(session)
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// commit synchronously commits the marked offsets. Sarama logs commit errors
+// instead of returning them, so every commit is recorded as successful.
+func commit(session sarama.ConsumerGroupSession) {
+	start := time.Now()
+	session.Commit()
+	metrics.ObserveCommit(start, nil)
+}
@@ -13,6 +13,7 @@ import (
 // MockConsumerGroupSession is a mock implementation of sarama.ConsumerGroupSession
 type MockConsumerGroupSession struct {
 	markedMessages []*sarama.ConsumerMessage
+	commits        int
 	ctx            context.Context
 	cancel         context.CancelFunc
 }
@@ -42,6 +43,7 @@ func (m *MockConsumerGroupSession) MarkOffset(topic string, partition int32, off
 }
 
 func (m *MockConsumerGroupSession) Commit() {
+	m.commits++
 }
 
 func (m *MockConsumerGroupSession) ResetOffset(topic string, partition int32, offset int64, metadata string) {
@@ -182,6 +184,27 @@ func TestConsumerGroupHandler_Cleanup(t *testing.T) {
 			t.Errorf("Expected no error, got: %v", err)
 		}
 	})
+
+	t.Run("cleanup commits the marked offsets", func(t *testing.T) {
+		processor := &message.MessageValueProcessor{}
+		handler := NewConsumerGroupHandler(make(chan bool), processor)
+
+		session := NewMockConsumerGroupSession()
+		if err := handler.Setup(session); err != nil {
+			t.Fatalf("Setup should not return error, got: %v", err)
+		}
+		commits := session.commits
+
+		if err := handler.Cleanup(session); err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if session.commits != commits+1 {
+			t.Errorf("Expected a final commit on cleanup, got %d commits", session.commits-commits)
+		}
+		if handler.stopCommits != nil {
+			t.Error("Expected periodic commits to be stopped")
+		}
+	})
 }
 
 func TestConsumerGroupHandler_ConsumeClaim(t *testing.T) {
@@ -0,0 +1,157 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IBM/sarama"
+
+	"music-service/pkg/kafka"
+	sarama_wrapper "music-service/pkg/kafka/sarama"
+)
+
+func (h *consumerHandler) Pause(topic string, partitions []int32) error {
+	gh, selected, err := h.selectAssigned(topic, partitions)
+	if err != nil {
+		return err
+	}
+
+	h.consumerGroup.Pause(selected)
+
+	gh.mu.Lock()
+	defer gh.mu.Unlock()
+	for t, ps := range selected {
+		for _, p := range ps {
+			gh.paused[topicPartition{topic: t, partition: p}] = true
+		}
+	}
+	return nil
+}
+
+func (h *consumerHandler) Resume(topic string, partitions []int32) error {
+	gh, selected, err := h.selectAssigned(topic, partitions)
+	if err != nil {
+		return err
+	}
+
+	gh.mu.Lock()
+	defer gh.mu.Unlock()
+
+	if topic == "" && len(partitions) == 0 {
+		h.consumerGroup.ResumeAll()
+		gh.allPaused = false
+		clear(gh.paused)
+		return nil
+	}
+
+	h.consumerGroup.Resume(selected)
+	for t, ps := range selected {
+		for _, p := range ps {
+			delete(gh.paused, topicPartition{topic: t, partition: p})
+		}
+	}
+	return nil
+}
+
+func (h *consumerHandler) Status(ctx context.Context) ([]kafka.PartitionStatus, error) {
+	gh := h.running()
+	if gh == nil || h.client == nil {
+		return nil, kafka.ErrConsumerNotRunning
+	}
+
+	gh.mu.Lock()
+	defer gh.mu.Unlock()
+
+	statuses := []kafka.PartitionStatus{}
+	for t, ps := range gh.claims {
+		for _, p := range ps {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			high, err := h.client.GetOffset(t, p, sarama.OffsetNewest)
+			if err != nil {
+				return nil, err
+			}
+
+			tp := topicPartition{topic: t, partition: p}
+			offset, ok := gh.offsets[tp]
+			if !ok || offset < 0 {
+				offset = high
+			}
+
+			statuses = append(statuses, kafka.PartitionStatus{
+				Topic:         t,
+				Partition:     p,
+				Offset:        offset,
+				HighWatermark: high,
+				Lag:           max(high-offset, 0),
+				Paused:        gh.allPaused || gh.paused[tp],
+			})
+		}
+	}
+	return statuses, nil
+}
+
+// ResetOffsets commits the new position through the current session and then
+// restarts the session, because sarama only picks up committed offsets when
+// partitions are claimed.
+func (h *consumerHandler) ResetOffsets(ctx context.Context, reset kafka.OffsetReset) error {
+	gh, selected, err := h.selectAssigned(reset.Topic, reset.Partitions)
+	if err != nil {
+		return err
+	}
+	if h.client == nil {
+		return kafka.ErrConsumerNotRunning
+	}
+
+	gh.mu.Lock()
+	session := gh.session
+	gh.mu.Unlock()
+	if session == nil {
+		return kafka.ErrConsumerNotRunning
+	}
+
+	for t, ps := range selected {
+		for _, p := range ps {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			offset, err := sarama_wrapper.ResolveOffset(h.client, t, p, reset)
+			if err != nil {
+				return fmt.Errorf("failed to resolve offset for %s[%d]: %w", t, p, err)
+			}
+
+			session.ResetOffset(t, p, offset, "")
+		}
+	}
+	session.Commit()
+
+	h.mu.Lock()
+	restart := h.restart
+	h.mu.Unlock()
+	if restart != nil {
+		restart()
+	}
+	return nil
+}
+
+func (h *consumerHandler) running() *consumerGroupHandler {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.groupHandler
+}
+
+func (h *consumerHandler) selectAssigned(topic string, partitions []int32) (*consumerGroupHandler, map[string][]int32, error) {
+	gh := h.running()
+	if gh == nil {
+		return nil, nil, kafka.ErrConsumerNotRunning
+	}
+
+	selected := kafka.SelectPartitions(gh.assignment(), topic, partitions)
+	if len(selected) == 0 {
+		return nil, nil, fmt.Errorf("no assigned partitions match topic %q partitions %v", topic, partitions)
+	}
+	return gh, selected, nil
+}
@@ -0,0 +1,84 @@
+package consumer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"music-service/pkg/kafka"
+)
+
+func newRunningHandler(mockCG *MockConsumerGroup) *consumerHandler {
+	gh := NewConsumerGroupHandler(make(chan bool), nil)
+	gh.claims = map[string][]int32{"test-topic": {0, 1}, "other-topic": {0}}
+
+	return &consumerHandler{
+		cfg:           kafka.Config{Topics: "test-topic,other-topic"},
+		consumerGroup: mockCG,
+		groupHandler:  gh,
+	}
+}
+
+func TestController_NotRunning(t *testing.T) {
+	h := &consumerHandler{consumerGroup: new(MockConsumerGroup)}
+
+	assert.ErrorIs(t, h.Pause("", nil), kafka.ErrConsumerNotRunning)
+	assert.ErrorIs(t, h.Resume("", nil), kafka.ErrConsumerNotRunning)
+	assert.ErrorIs(t, h.ResetOffsets(context.Background(), kafka.OffsetReset{}), kafka.ErrConsumerNotRunning)
+
+	_, err := h.Status(context.Background())
+	assert.ErrorIs(t, err, kafka.ErrConsumerNotRunning)
+}
+
+func TestController_PausePartitions(t *testing.T) {
+	mockCG := new(MockConsumerGroup)
+	h := newRunningHandler(mockCG)
+
+	mockCG.On("Pause", map[string][]int32{"test-topic": {1}}).Return()
+
+	err := h.Pause("test-topic", []int32{1})
+
+	assert.NoError(t, err)
+	assert.True(t, h.groupHandler.paused[topicPartition{topic: "test-topic", partition: 1}])
+	assert.False(t, h.groupHandler.paused[topicPartition{topic: "test-topic", partition: 0}])
+	mockCG.AssertExpectations(t)
+}
+
+func TestController_PauseUnassignedPartition(t *testing.T) {
+	mockCG := new(MockConsumerGroup)
+	h := newRunningHandler(mockCG)
+
+	err := h.Pause("test-topic", []int32{7})
+
+	assert.Error(t, err)
+	mockCG.AssertNotCalled(t, "Pause")
+}
+
+func TestController_ResumeTopic(t *testing.T) {
+	mockCG := new(MockConsumerGroup)
+	h := newRunningHandler(mockCG)
+	h.groupHandler.paused[topicPartition{topic: "other-topic", partition: 0}] = true
+
+	mockCG.On("Resume", map[string][]int32{"other-topic": {0}}).Return()
+
+	err := h.Resume("other-topic", nil)
+
+	assert.NoError(t, err)
+	assert.Empty(t, h.groupHandler.paused)
+	mockCG.AssertExpectations(t)
+}
+
+func TestController_ResumeAll(t *testing.T) {
+	mockCG := new(MockConsumerGroup)
+	h := newRunningHandler(mockCG)
+	h.groupHandler.allPaused = true
+
+	mockCG.On("ResumeAll").Return()
+
+	err := h.Resume("", nil)
+
+	assert.NoError(t, err)
+	assert.False(t, h.groupHandler.allPaused)
+	mockCG.AssertExpectations(t)
+}
@@ -3,36 +3,72 @@ package producer
 import (
 	"context"
 	"log"
+	"strconv"
 
 	"github.com/IBM/sarama"
 	"google.golang.org/protobuf/proto"
 
 	"music-service/gen/pb"
 	"music-service/pkg/kafka"
+	"music-service/pkg/kafka/codec"
+	"music-service/pkg/kafka/message"
 	sarama_wrapper "music-service/pkg/kafka/sarama"
 )
 
 type producerHandler struct {
 	cfg          kafka.Config
 	syncProducer sarama.SyncProducer
+	encoder      codec.Codec
+	stockEncoder codec.Codec
+	orderEncoder codec.Codec
 }
 
 func NewProducerHandler(cfg kafka.Config) (kafka.ProducerHandler, error) {
+	encoder, err := codec.NewEncoder(cfg, cfg.Topics, &pb.Album{})
+	if err != nil {
+		return nil, err
+	}
+
+	stockEncoder, err := newEventEncoder(cfg, cfg.StockTopic, &pb.StockEvent{})
+	if err != nil {
+		return nil, err
+	}
+	orderEncoder, err := newEventEncoder(cfg, cfg.OrderTopic, &pb.OrderEvent{})
+	if err != nil {
+		return nil, err
+	}
+
 	syncProducer, err := sarama_wrapper.NewSyncProducer(cfg)
 	if err != nil {
 		return nil, err
 	}
-	return &producerHandler{cfg: cfg, syncProducer: syncProducer}, nil
+	return &producerHandler{cfg: cfg, syncProducer: syncProducer, encoder: encoder, stockEncoder: stockEncoder, orderEncoder: orderEncoder}, nil
+}
+
+// newEventEncoder returns the encoder of an event topic, or nil when the
+// topic is not set.
+func newEventEncoder(cfg kafka.Config, topic string, msg proto.Message) (codec.Codec, error) {
+	if topic == "" {
+		return nil, nil
+	}
+	return codec.NewEncoder(cfg, topic, msg)
 }
 
 func (p *producerHandler) Produce(ctx context.Context, album *pb.Album) {
-	marshaledAlbum, err := proto.Marshal(album)
+	marshaledAlbum, err := p.encoder.Encode(album)
 	if err != nil {
 		log.Panicf("Failed to marshal album: %v", err)
 	}
 	msg := &sarama.ProducerMessage{
 		Topic: p.cfg.Topics,
+		Key:   sarama.StringEncoder(strconv.Itoa(int(album.Id))),
 		Value: sarama.ByteEncoder(marshaledAlbum),
+		Headers: []sarama.RecordHeader{
+			{Key: []byte(codec.ContentTypeHeader), Value: []byte(p.encoder.ContentType())},
+		},
+	}
+	if principal := message.Principal(ctx); principal != "" {
+		msg.Headers = append(msg.Headers, sarama.RecordHeader{Key: []byte(message.PrincipalHeader), Value: []byte(principal)})
 	}
 	partition, offset, err := p.syncProducer.SendMessage(msg)
 	if err != nil {
@@ -41,3 +77,39 @@ func (p *producerHandler) Produce(ctx context.Context, album *pb.Album) {
 		log.Printf("message sent (Id=%d, Title=%s, Artist=%s, Price=%.2f); partition=%d,offset=%d", album.Id, album.Title, album.Artist, album.Price, partition, offset)
 	}
 }
+
+func (p *producerHandler) ProduceStockEvent(ctx context.Context, event *pb.StockEvent) {
+	p.produceEvent(p.cfg.StockTopic, p.stockEncoder, event.Stock.GetAlbumId(), event.Type, event)
+}
+
+func (p *producerHandler) ProduceOrderEvent(ctx context.Context, event *pb.OrderEvent) {
+	p.produceEvent(p.cfg.OrderTopic, p.orderEncoder, event.Order.GetId(), event.Type, event)
+}
+
+// produceEvent sends the event to the topic keyed by id. Failures are logged
+// and events are dropped when the topic is not set.
+func (p *producerHandler) produceEvent(topic string, encoder codec.Codec, id int32, eventType string, event proto.Message) {
+	if encoder == nil {
+		log.Printf("dropped %s event of %d, no topic is set for it", eventType, id)
+		return
+	}
+	marshaledEvent, err := encoder.Encode(event)
+	if err != nil {
+		log.Printf("Failed to marshal %s event: %v", eventType, err)
+		return
+	}
+	msg := &sarama.ProducerMessage{
+		Topic: topic,
+		Key:   sarama.StringEncoder(strconv.Itoa(int(id))),
+		Value: sarama.ByteEncoder(marshaledEvent),
+		Headers: []sarama.RecordHeader{
+			{Key: []byte(codec.ContentTypeHeader), Value: []byte(encoder.ContentType())},
+		},
+	}
+	partition, offset, err := p.syncProducer.SendMessage(msg)
+	if err != nil {
+		log.Printf("Failed to send %s event: %v", eventType, err)
+	} else {
+		log.Printf("event sent (Topic=%s, Type=%s, Key=%d); partition=%d,offset=%d", topic, eventType, id, partition, offset)
+	}
+}
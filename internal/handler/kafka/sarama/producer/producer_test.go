@@ -14,6 +14,7 @@ import (
 
 	"music-service/gen/pb"
 	"music-service/pkg/kafka"
+	"music-service/pkg/kafka/codec"
 )
 
 // MockSyncProducer is a mock implementation of sarama.SyncProducer
@@ -162,6 +163,7 @@ func TestNewProducer_WithMock(t *testing.T) {
 		p := &producerHandler{
 			cfg:          cfg,
 			syncProducer: mockSP,
+			encoder:      protobufEncoder(t),
 		}
 
 		assert.NotNil(t, p)
@@ -185,6 +187,7 @@ func TestProduce_Success(t *testing.T) {
 	p := &producerHandler{
 		cfg:          cfg,
 		syncProducer: mockSP,
+		encoder:      protobufEncoder(t),
 	}
 
 	ctx := context.Background()
@@ -222,6 +225,7 @@ func TestProduce_Error(t *testing.T) {
 	p := &producerHandler{
 		cfg:          cfg,
 		syncProducer: mockSP,
+		encoder:      protobufEncoder(t),
 	}
 
 	ctx := context.Background()
@@ -258,6 +262,7 @@ func TestProduce_MessageContent(t *testing.T) {
 	p := &producerHandler{
 		cfg:          cfg,
 		syncProducer: mockSP,
+		encoder:      protobufEncoder(t),
 	}
 
 	ctx := context.Background()
@@ -303,6 +308,7 @@ func TestProduce_MultipleMessages(t *testing.T) {
 	p := &producerHandler{
 		cfg:          cfg,
 		syncProducer: mockSP,
+		encoder:      protobufEncoder(t),
 	}
 
 	ctx := context.Background()
@@ -391,6 +397,7 @@ func TestProduce_DifferentTopics(t *testing.T) {
 			p := &producerHandler{
 				cfg:          cfg,
 				syncProducer: mockSP,
+				encoder:      protobufEncoder(t),
 			}
 
 			ctx := context.Background()
@@ -451,6 +458,7 @@ func TestProduce_DifferentPartitions(t *testing.T) {
 			p := &producerHandler{
 				cfg:          cfg,
 				syncProducer: mockSP,
+				encoder:      protobufEncoder(t),
 			}
 
 			ctx := context.Background()
@@ -486,6 +494,7 @@ func TestProducerStructure(t *testing.T) {
 	p := &producerHandler{
 		cfg:          cfg,
 		syncProducer: mockSP,
+		encoder:      protobufEncoder(t),
 	}
 
 	assert.NotNil(t, p)
@@ -511,6 +520,7 @@ func TestProduce_WithContext(t *testing.T) {
 	p := &producerHandler{
 		cfg:          cfg,
 		syncProducer: mockSP,
+		encoder:      protobufEncoder(t),
 	}
 
 	// Test with background context
@@ -571,6 +581,7 @@ func TestProduce_MessageEncoding(t *testing.T) {
 	p := &producerHandler{
 		cfg:          cfg,
 		syncProducer: mockSP,
+		encoder:      protobufEncoder(t),
 	}
 
 	ctx := context.Background()
@@ -614,6 +625,7 @@ func TestProduce_EmptyTopic(t *testing.T) {
 	p := &producerHandler{
 		cfg:          cfg,
 		syncProducer: mockSP,
+		encoder:      protobufEncoder(t),
 	}
 
 	ctx := context.Background()
@@ -636,3 +648,96 @@ func TestProduce_EmptyTopic(t *testing.T) {
 
 	mockSP.AssertExpectations(t)
 }
+
+// TestProduceStockEvent tests that stock events are sent to the stock topic
+// keyed by album id
+func TestProduceStockEvent(t *testing.T) {
+	mockSP := new(MockSyncProducer)
+
+	p := &producerHandler{
+		cfg:          kafka.Config{Topics: "test-topic", StockTopic: "stock-events"},
+		syncProducer: mockSP,
+		encoder:      protobufEncoder(t),
+		stockEncoder: protobufEncoder(t),
+	}
+
+	var capturedMessage *sarama.ProducerMessage
+	mockSP.On("SendMessage", mock.Anything).Run(func(args mock.Arguments) {
+		capturedMessage = args.Get(0).(*sarama.ProducerMessage)
+	}).Return(0, 0, nil)
+
+	p.ProduceStockEvent(context.Background(), &pb.StockEvent{
+		Type:  "low_stock",
+		Stock: &pb.StockLevel{AlbumId: 7, Format: "vinyl", WarehouseId: 1, OnHand: 3, Reserved: 2, Available: 1, LowStockThreshold: 2},
+	})
+
+	mockSP.AssertExpectations(t)
+	assert.Equal(t, "stock-events", capturedMessage.Topic)
+	key, _ := capturedMessage.Key.Encode()
+	assert.Equal(t, "7", string(key))
+}
+
+// TestProduceOrderEvent tests that order events are sent to the order topic
+// keyed by order id
+func TestProduceOrderEvent(t *testing.T) {
+	mockSP := new(MockSyncProducer)
+
+	p := &producerHandler{
+		cfg:          kafka.Config{Topics: "test-topic", OrderTopic: "order-events"},
+		syncProducer: mockSP,
+		encoder:      protobufEncoder(t),
+		orderEncoder: protobufEncoder(t),
+	}
+
+	var capturedMessage *sarama.ProducerMessage
+	mockSP.On("SendMessage", mock.Anything).Run(func(args mock.Arguments) {
+		capturedMessage = args.Get(0).(*sarama.ProducerMessage)
+	}).Return(0, 0, nil)
+
+	p.ProduceOrderEvent(context.Background(), &pb.OrderEvent{Type: "paid", Order: &pb.Order{Id: 12, Status: "paid"}})
+
+	mockSP.AssertExpectations(t)
+	assert.Equal(t, "order-events", capturedMessage.Topic)
+	key, _ := capturedMessage.Key.Encode()
+	assert.Equal(t, "12", string(key))
+}
+
+// TestProduceStockEvent_Errors tests that stock events are dropped without a
+// stock topic and that send errors do not panic
+func TestProduceStockEvent_Errors(t *testing.T) {
+	event := &pb.StockEvent{Type: "low_stock", Stock: &pb.StockLevel{AlbumId: 7}}
+
+	t.Run("no stock topic", func(t *testing.T) {
+		mockSP := new(MockSyncProducer)
+		p := &producerHandler{cfg: kafka.Config{Topics: "test-topic"}, syncProducer: mockSP, encoder: protobufEncoder(t)}
+
+		p.ProduceStockEvent(context.Background(), event)
+
+		mockSP.AssertNotCalled(t, "SendMessage", mock.Anything)
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		mockSP := new(MockSyncProducer)
+		mockSP.On("SendMessage", mock.Anything).Return(0, 0, errors.New("send error"))
+		p := &producerHandler{
+			cfg:          kafka.Config{Topics: "test-topic", StockTopic: "stock-events"},
+			syncProducer: mockSP,
+			encoder:      protobufEncoder(t),
+			stockEncoder: protobufEncoder(t),
+		}
+
+		assert.NotPanics(t, func() {
+			p.ProduceStockEvent(context.Background(), event)
+		})
+		mockSP.AssertExpectations(t)
+	})
+}
+
+func protobufEncoder(t *testing.T) codec.Codec {
+	t.Helper()
+	encoder, err := codec.NewEncoder(kafka.Config{}, "test-topic", &pb.Album{})
+	if err != nil {
+		t.Fatalf("Failed to create encoder: %v", err)
+	}
+	return encoder
+}
@@ -0,0 +1,79 @@
+package producer
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+
+	"github.com/IBM/sarama"
+	"music-service/gen/pb"
+	"music-service/pkg/kafka"
+	"music-service/pkg/kafka/codec"
+	sarama_wrapper "music-service/pkg/kafka/sarama"
+)
+
+type snapshotProducer struct {
+	cfg          kafka.Config
+	topic        string
+	syncProducer sarama.SyncProducer
+	encoder      codec.Codec
+}
+
+func NewSnapshotProducer(cfg kafka.Config) (kafka.SnapshotProducer, error) {
+	if cfg.SnapshotTopic == "" {
+		return nil, errors.New("kafka.snapshot_topic is not set")
+	}
+
+	encoder, err := codec.NewEncoder(cfg, cfg.SnapshotTopic, &pb.Album{})
+	if err != nil {
+		return nil, err
+	}
+
+	syncProducer, err := sarama_wrapper.NewSyncProducer(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshotProducer{cfg: cfg, topic: cfg.SnapshotTopic, syncProducer: syncProducer, encoder: encoder}, nil
+}
+
+func (p *snapshotProducer) Publish(ctx context.Context, id int32, album *pb.Album) error {
+	msg := &sarama.ProducerMessage{
+		Topic: p.topic,
+		Key:   sarama.StringEncoder(strconv.Itoa(int(id))),
+	}
+	if album != nil {
+		marshaledAlbum, err := p.encoder.Encode(album)
+		if err != nil {
+			return err
+		}
+		msg.Value = sarama.ByteEncoder(marshaledAlbum)
+		msg.Headers = []sarama.RecordHeader{
+			{Key: []byte(codec.ContentTypeHeader), Value: []byte(p.encoder.ContentType())},
+		}
+	}
+
+	_, _, err := p.syncProducer.SendMessage(msg)
+	return err
+}
+
+func (p *snapshotProducer) Published(ctx context.Context) ([]int32, error) {
+	keys, err := sarama_wrapper.LiveKeys(ctx, p.cfg, p.topic)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int32, 0, len(keys))
+	for _, key := range keys {
+		id, err := strconv.ParseInt(key, 10, 32)
+		if err != nil {
+			log.Printf("skipping key %q of %s: not an album id", key, p.topic)
+			continue
+		}
+		ids = append(ids, int32(id))
+	}
+	return ids, nil
+}
+
+func (p *snapshotProducer) Close() error {
+	return p.syncProducer.Close()
+}
@@ -0,0 +1,53 @@
+package producer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"music-service/gen/pb"
+	"music-service/pkg/kafka"
+)
+
+// TestNewSnapshotProducer_NoTopic tests that a snapshot topic is required
+func TestNewSnapshotProducer_NoTopic(t *testing.T) {
+	p, err := NewSnapshotProducer(kafka.Config{Brokers: "localhost:9092"})
+
+	assert.ErrorContains(t, err, "snapshot_topic")
+	assert.Nil(t, p)
+}
+
+// TestSnapshotProducer_Publish tests that albums are published keyed by id
+func TestSnapshotProducer_Publish(t *testing.T) {
+	mockSP := new(MockSyncProducer)
+	p := &snapshotProducer{topic: "album-snapshots", syncProducer: mockSP, encoder: protobufEncoder(t)}
+
+	mockSP.On("SendMessage", mock.MatchedBy(func(msg *sarama.ProducerMessage) bool {
+		key, _ := msg.Key.Encode()
+		return msg.Topic == "album-snapshots" && string(key) == "7" && msg.Value != nil && len(msg.Headers) == 1
+	})).Return(0, 1, nil)
+
+	err := p.Publish(context.Background(), 7, &pb.Album{Id: 7, Title: "Blue Train", Artist: "John Coltrane"})
+
+	assert.NoError(t, err)
+	mockSP.AssertExpectations(t)
+}
+
+// TestSnapshotProducer_Publish_Tombstone tests that a nil album publishes a message without a value
+func TestSnapshotProducer_Publish_Tombstone(t *testing.T) {
+	mockSP := new(MockSyncProducer)
+	p := &snapshotProducer{topic: "album-snapshots", syncProducer: mockSP, encoder: protobufEncoder(t)}
+
+	mockSP.On("SendMessage", mock.MatchedBy(func(msg *sarama.ProducerMessage) bool {
+		key, _ := msg.Key.Encode()
+		return string(key) == "7" && msg.Value == nil
+	})).Return(0, 2, nil)
+
+	err := p.Publish(context.Background(), 7, nil)
+
+	assert.NoError(t, err)
+	mockSP.AssertExpectations(t)
+}
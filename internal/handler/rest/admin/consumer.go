@@ -0,0 +1,104 @@
+package admin
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/pkg/kafka"
+)
+
+type partitionSelection struct {
+	Topic      string  `json:"topic"`
+	Partitions []int32 `json:"partitions"`
+}
+
+type consumerHandler struct {
+	controller kafka.ConsumerController
+}
+
+func NewConsumerHandler(controller kafka.ConsumerController) *consumerHandler {
+	return &consumerHandler{
+		controller: controller,
+	}
+}
+
+// @Summary Gets the consumer assignment, lag and paused state
+// @ID get-consumer-status
+// @Produce json
+// @Success 200 {array} kafka.PartitionStatus
+// @Router /admin/consumer [get]
+func (h *consumerHandler) GetStatus(ctx *fiber.Ctx) error {
+	statuses, err := h.controller.Status(ctx.Context())
+	if err != nil {
+		return controlError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(statuses)
+}
+
+// @Summary Pauses consumption of a topic or partitions
+// @ID pause-consumer
+// @Produce json
+// @Success 204
+// @Router /admin/consumer/pause [post]
+func (h *consumerHandler) Pause(ctx *fiber.Ctx) error {
+	selection := partitionSelection{}
+	if err := ctx.BodyParser(&selection); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse JSON",
+		})
+	}
+
+	if err := h.controller.Pause(selection.Topic, selection.Partitions); err != nil {
+		return controlError(ctx, err)
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Resumes consumption of a topic or partitions
+// @ID resume-consumer
+// @Produce json
+// @Success 204
+// @Router /admin/consumer/resume [post]
+func (h *consumerHandler) Resume(ctx *fiber.Ctx) error {
+	selection := partitionSelection{}
+	if err := ctx.BodyParser(&selection); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse JSON",
+		})
+	}
+
+	if err := h.controller.Resume(selection.Topic, selection.Partitions); err != nil {
+		return controlError(ctx, err)
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Resets the consumer group offsets to an offset or timestamp
+// @ID reset-consumer-offsets
+// @Produce json
+// @Success 204
+// @Router /admin/consumer/offsets [post]
+func (h *consumerHandler) ResetOffsets(ctx *fiber.Ctx) error {
+	reset := kafka.OffsetReset{}
+	if err := ctx.BodyParser(&reset); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse JSON",
+		})
+	}
+
+	if err := h.controller.ResetOffsets(ctx.Context(), reset); err != nil {
+		return controlError(ctx, err)
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func controlError(ctx *fiber.Ctx, err error) error {
+	status := fiber.StatusConflict
+	if errors.Is(err, kafka.ErrConsumerNotRunning) {
+		status = fiber.StatusServiceUnavailable
+	}
+	return ctx.Status(status).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
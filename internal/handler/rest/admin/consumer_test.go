@@ -0,0 +1,184 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/pkg/kafka"
+)
+
+// mockConsumerController is a mock implementation of kafka.ConsumerController
+type mockConsumerController struct {
+	pauseFunc        func(topic string, partitions []int32) error
+	resumeFunc       func(topic string, partitions []int32) error
+	statusFunc       func(ctx context.Context) ([]kafka.PartitionStatus, error)
+	resetOffsetsFunc func(ctx context.Context, reset kafka.OffsetReset) error
+}
+
+func (m *mockConsumerController) Pause(topic string, partitions []int32) error {
+	if m.pauseFunc != nil {
+		return m.pauseFunc(topic, partitions)
+	}
+	return nil
+}
+
+func (m *mockConsumerController) Resume(topic string, partitions []int32) error {
+	if m.resumeFunc != nil {
+		return m.resumeFunc(topic, partitions)
+	}
+	return nil
+}
+
+func (m *mockConsumerController) Status(ctx context.Context) ([]kafka.PartitionStatus, error) {
+	if m.statusFunc != nil {
+		return m.statusFunc(ctx)
+	}
+	return []kafka.PartitionStatus{}, nil
+}
+
+func (m *mockConsumerController) ResetOffsets(ctx context.Context, reset kafka.OffsetReset) error {
+	if m.resetOffsetsFunc != nil {
+		return m.resetOffsetsFunc(ctx, reset)
+	}
+	return nil
+}
+
+func newTestApp(controller kafka.ConsumerController) *fiber.App {
+	app := fiber.New()
+	handler := NewConsumerHandler(controller)
+	app.Get("/consumer", handler.GetStatus)
+	app.Post("/consumer/pause", handler.Pause)
+	app.Post("/consumer/resume", handler.Resume)
+	app.Post("/consumer/offsets", handler.ResetOffsets)
+	return app
+}
+
+func TestConsumerHandler_GetStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		statusFunc     func(ctx context.Context) ([]kafka.PartitionStatus, error)
+		expectedStatus int
+	}{
+		{
+			name: "returns partition status",
+			statusFunc: func(ctx context.Context) ([]kafka.PartitionStatus, error) {
+				return []kafka.PartitionStatus{{Topic: "test-topic", Partition: 0, Lag: 3}}, nil
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name: "returns service unavailable when consumer is not running",
+			statusFunc: func(ctx context.Context) ([]kafka.PartitionStatus, error) {
+				return nil, kafka.ErrConsumerNotRunning
+			},
+			expectedStatus: fiber.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(&mockConsumerController{statusFunc: tt.statusFunc})
+
+			req, _ := http.NewRequest("GET", "/consumer", nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestConsumerHandler_PauseAndResume(t *testing.T) {
+	var pausedTopic string
+	var resumedPartitions []int32
+	controller := &mockConsumerController{
+		pauseFunc: func(topic string, partitions []int32) error {
+			pausedTopic = topic
+			return nil
+		},
+		resumeFunc: func(topic string, partitions []int32) error {
+			resumedPartitions = partitions
+			return errors.New("no assigned partitions match")
+		},
+	}
+	app := newTestApp(controller)
+
+	body, _ := json.Marshal(partitionSelection{Topic: "test-topic"})
+	req, _ := http.NewRequest("POST", "/consumer/pause", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusNoContent {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusNoContent, resp.StatusCode)
+	}
+	if pausedTopic != "test-topic" {
+		t.Errorf("Expected topic 'test-topic' to be paused, got '%s'", pausedTopic)
+	}
+
+	body, _ = json.Marshal(partitionSelection{Topic: "test-topic", Partitions: []int32{4}})
+	req, _ = http.NewRequest("POST", "/consumer/resume", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusConflict {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusConflict, resp.StatusCode)
+	}
+	if len(resumedPartitions) != 1 || resumedPartitions[0] != 4 {
+		t.Errorf("Expected partition 4 to be resumed, got %v", resumedPartitions)
+	}
+}
+
+func TestConsumerHandler_ResetOffsets(t *testing.T) {
+	var got kafka.OffsetReset
+	app := newTestApp(&mockConsumerController{
+		resetOffsetsFunc: func(ctx context.Context, reset kafka.OffsetReset) error {
+			got = reset
+			return nil
+		},
+	})
+
+	body := []byte(`{"topic": "test-topic", "partitions": [0], "timestamp": "2024-01-02T03:04:05Z"}`)
+	req, _ := http.NewRequest("POST", "/consumer/offsets", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusNoContent {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusNoContent, resp.StatusCode)
+	}
+	if got.Topic != "test-topic" || got.Timestamp.Year() != 2024 {
+		t.Errorf("Unexpected reset: %+v", got)
+	}
+}
+
+func TestConsumerHandler_InvalidJSON(t *testing.T) {
+	app := newTestApp(&mockConsumerController{})
+
+	for _, path := range []string{"/consumer/pause", "/consumer/resume", "/consumer/offsets"} {
+		req, _ := http.NewRequest("POST", path, bytes.NewReader([]byte("invalid json")))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to test request: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("%s: expected status code %d, got %d", path, fiber.StatusBadRequest, resp.StatusCode)
+		}
+	}
+}
@@ -29,6 +29,6 @@ func (h *albumHandler) CreateAlbum(ctx *fiber.Ctx) error {
 			"error": "cannot parse JSON",
 		})
 	}
-	h.producerHandler.Produce(ctx.Context(), newAlbum)
+	h.producerHandler.Produce(ctx.UserContext(), newAlbum)
 	return ctx.Status(fiber.StatusCreated).JSON(newAlbum)
 }
@@ -16,6 +16,8 @@ import (
 type mockProducerHandler struct {
 	produceFunc  func(ctx context.Context, album *pb.Album)
 	produceCalls int
+	stockEvents  []*pb.StockEvent
+	orderEvents  []*pb.OrderEvent
 }
 
 func (m *mockProducerHandler) Produce(ctx context.Context, album *pb.Album) {
@@ -25,6 +27,14 @@ func (m *mockProducerHandler) Produce(ctx context.Context, album *pb.Album) {
 	}
 }
 
+func (m *mockProducerHandler) ProduceStockEvent(ctx context.Context, event *pb.StockEvent) {
+	m.stockEvents = append(m.stockEvents, event)
+}
+
+func (m *mockProducerHandler) ProduceOrderEvent(ctx context.Context, event *pb.OrderEvent) {
+	m.orderEvents = append(m.orderEvents, event)
+}
+
 func TestNewAlbumHandler(t *testing.T) {
 	t.Run("creates new album handler successfully", func(t *testing.T) {
 		mockProducer := &mockProducerHandler{}
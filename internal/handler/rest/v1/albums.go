@@ -1,10 +1,16 @@
 package v1
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
 
 	"music-service/gen/pb"
 
+	"music-service/internal/models"
 	"music-service/internal/repository/postgres/orm"
 	"music-service/pkg/kafka"
 )
@@ -12,12 +18,14 @@ import (
 type albumsHandler struct {
 	producerHandler kafka.ProducerHandler
 	repository      orm.Repository
+	browse          orm.BrowseRepository
 }
 
-func NewAlbumsHandler(producerHandler kafka.ProducerHandler, repository orm.Repository) *albumsHandler {
+func NewAlbumsHandler(producerHandler kafka.ProducerHandler, repository orm.Repository, browse orm.BrowseRepository) *albumsHandler {
 	return &albumsHandler{
 		producerHandler: producerHandler,
 		repository:      repository,
+		browse:          browse,
 	}
 }
 
@@ -34,22 +42,73 @@ func (h *albumsHandler) CreateAlbums(ctx *fiber.Ctx) error {
 		})
 	}
 	for _, newAlbum := range newAlbums {
-		h.producerHandler.Produce(ctx.Context(), newAlbum)
+		h.producerHandler.Produce(ctx.UserContext(), newAlbum)
 	}
 	return ctx.Status(fiber.StatusCreated).JSON(newAlbums)
 }
 
 // @Summary Gets all albums
+// @Description Filters by artist_id, genre_id (including the genres below it),
+// @Description tag, min_price and max_price. With facets=true the albums are
+// @Description returned with their counts per genre, tag, artist and price.
 // @ID get-albums
 // @Produce json
 // @Success 200 {array} pb.Album
 // @Router /albums [get]
 func (h *albumsHandler) GetAlbums(ctx *fiber.Ctx) error {
-	albums, err := h.repository.Get()
+	filter, err := parseAlbumFilter(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	var albums []*models.Album
+	if filter.IsZero() {
+		albums, err = h.repository.Get()
+	} else {
+		albums, err = h.browse.Find(filter)
+	}
 	if err != nil {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "failed to get albums",
 		})
 	}
-	return ctx.Status(fiber.StatusOK).JSON(albums)
+	if !ctx.QueryBool("facets") {
+		return ctx.Status(fiber.StatusOK).JSON(albums)
+	}
+
+	facets, err := h.browse.Facets(filter)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get facets",
+		})
+	}
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"albums": albums,
+		"facets": facets,
+	})
+}
+
+func parseAlbumFilter(ctx *fiber.Ctx) (models.AlbumFilter, error) {
+	filter := models.AlbumFilter{Tag: strings.TrimSpace(ctx.Query("tag"))}
+	for key, id := range map[string]*int{"artist_id": &filter.ArtistId, "genre_id": &filter.GenreId} {
+		if value := ctx.Query(key); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return filter, fmt.Errorf("invalid %s", key)
+			}
+			*id = n
+		}
+	}
+	for key, price := range map[string]*decimal.Decimal{"min_price": &filter.MinPrice, "max_price": &filter.MaxPrice} {
+		if value := ctx.Query(key); value != "" {
+			d, err := decimal.NewFromString(value)
+			if err != nil || d.IsNegative() {
+				return filter, fmt.Errorf("invalid %s", key)
+			}
+			*price = d
+		}
+	}
+	return filter, nil
 }
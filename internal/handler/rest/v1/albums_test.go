@@ -61,11 +61,31 @@ func (m *mockRepository) Upsert(album models.Album) error {
 	return nil
 }
 
+// mockBrowseRepository is a mock implementation of orm.BrowseRepository
+type mockBrowseRepository struct {
+	findFunc   func(filter models.AlbumFilter) ([]*models.Album, error)
+	facetsFunc func(filter models.AlbumFilter) (*models.Facets, error)
+}
+
+func (m *mockBrowseRepository) Find(filter models.AlbumFilter) ([]*models.Album, error) {
+	if m.findFunc != nil {
+		return m.findFunc(filter)
+	}
+	return []*models.Album{}, nil
+}
+
+func (m *mockBrowseRepository) Facets(filter models.AlbumFilter) (*models.Facets, error) {
+	if m.facetsFunc != nil {
+		return m.facetsFunc(filter)
+	}
+	return models.NewFacets(nil), nil
+}
+
 func TestNewAlbumsHandler(t *testing.T) {
 	t.Run("creates new albums handler successfully", func(t *testing.T) {
 		mockProducer := &mockProducerHandler{}
 		mockRepo := &mockRepository{}
-		handler := NewAlbumsHandler(mockProducer, mockRepo)
+		handler := NewAlbumsHandler(mockProducer, mockRepo, &mockBrowseRepository{})
 
 		if handler == nil {
 			t.Fatal("Expected handler to be non-nil")
@@ -212,7 +232,7 @@ func TestAlbumsHandler_CreateAlbums(t *testing.T) {
 			if tt.setupMocks != nil {
 				tt.setupMocks(mockProducer, mockRepo)
 			}
-			handler := NewAlbumsHandler(mockProducer, mockRepo)
+			handler := NewAlbumsHandler(mockProducer, mockRepo, &mockBrowseRepository{})
 
 			// Register route
 			app.Post("/albums", handler.CreateAlbums)
@@ -363,7 +383,7 @@ func TestAlbumsHandler_GetAlbums(t *testing.T) {
 			if tt.setupMock != nil {
 				tt.setupMock(mockRepo)
 			}
-			handler := NewAlbumsHandler(mockProducer, mockRepo)
+			handler := NewAlbumsHandler(mockProducer, mockRepo, &mockBrowseRepository{})
 
 			// Register route
 			app.Get("/albums", handler.GetAlbums)
@@ -439,7 +459,7 @@ func TestAlbumsHandler_GetAlbums_RepositoryPanic(t *testing.T) {
 				return nil, errors.New("simulated error instead of panic")
 			},
 		}
-		handler := NewAlbumsHandler(mockProducer, mockRepo)
+		handler := NewAlbumsHandler(mockProducer, mockRepo, &mockBrowseRepository{})
 
 		// Register route
 		app.Get("/albums", handler.GetAlbums)
@@ -459,3 +479,104 @@ func TestAlbumsHandler_GetAlbums_RepositoryPanic(t *testing.T) {
 		}
 	})
 }
+
+func TestAlbumsHandler_GetAlbums_Filtered(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedFilter models.AlbumFilter
+	}{
+		{
+			name:           "filters by genre and tag",
+			query:          "?genre_id=1&tag=%20live%20",
+			expectedStatus: fiber.StatusOK,
+			expectedFilter: models.AlbumFilter{GenreId: 1, Tag: "live"},
+		},
+		{
+			name:           "filters by artist and price",
+			query:          "?artist_id=7&min_price=10&max_price=59.99",
+			expectedStatus: fiber.StatusOK,
+			expectedFilter: models.AlbumFilter{ArtistId: 7, MinPrice: decimal.NewFromInt(10), MaxPrice: decimal.RequireFromString("59.99")},
+		},
+		{
+			name:           "rejects invalid genre",
+			query:          "?genre_id=jazz",
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "rejects negative price",
+			query:          "?min_price=-1",
+			expectedStatus: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var filtered models.AlbumFilter
+			app := fiber.New()
+			handler := NewAlbumsHandler(&mockProducerHandler{}, &mockRepository{}, &mockBrowseRepository{
+				findFunc: func(filter models.AlbumFilter) ([]*models.Album, error) {
+					filtered = filter
+					return []*models.Album{{Id: 1, Title: "Blue Train"}}, nil
+				},
+			})
+			app.Get("/albums", handler.GetAlbums)
+
+			req, _ := http.NewRequest("GET", "/albums"+tt.query, nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if resp.StatusCode == fiber.StatusOK {
+				if filtered.ArtistId != tt.expectedFilter.ArtistId || filtered.GenreId != tt.expectedFilter.GenreId ||
+					filtered.Tag != tt.expectedFilter.Tag || !filtered.MinPrice.Equal(tt.expectedFilter.MinPrice) ||
+					!filtered.MaxPrice.Equal(tt.expectedFilter.MaxPrice) {
+					t.Errorf("Expected filter %v, got %v", tt.expectedFilter, filtered)
+				}
+			}
+		})
+	}
+}
+
+func TestAlbumsHandler_GetAlbums_Facets(t *testing.T) {
+	app := fiber.New()
+	mockRepo := &mockRepository{
+		getFunc: func() ([]*models.Album, error) {
+			return []*models.Album{{Id: 1, Title: "Blue Train"}}, nil
+		},
+	}
+	handler := NewAlbumsHandler(&mockProducerHandler{}, mockRepo, &mockBrowseRepository{
+		facetsFunc: func(filter models.AlbumFilter) (*models.Facets, error) {
+			return models.NewFacets([]models.FacetRow{{Facet: "tag", Id: 4, Name: "live", Count: 1}}), nil
+		},
+	})
+	app.Get("/albums", handler.GetAlbums)
+
+	req, _ := http.NewRequest("GET", "/albums?facets=true", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+	result := struct {
+		Albums []models.Album
+		Facets models.Facets
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(result.Albums) != 1 {
+		t.Errorf("Expected 1 album, got %d", len(result.Albums))
+	}
+	if len(result.Facets.Tags) != 1 || result.Facets.Tags[0].Name != "live" || result.Facets.Tags[0].Count != 1 {
+		t.Errorf("Expected one album tagged live, got %v", result.Facets.Tags)
+	}
+}
@@ -0,0 +1,177 @@
+package v1
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+)
+
+type artistsHandler struct {
+	repository orm.ArtistRepository
+}
+
+func NewArtistsHandler(repository orm.ArtistRepository) *artistsHandler {
+	return &artistsHandler{
+		repository: repository,
+	}
+}
+
+// @Summary Creates an artist
+// @ID create-artist
+// @Produce json
+// @Success 201 {object} models.Artist
+// @Router /artists [post]
+func (h *artistsHandler) CreateArtist(ctx *fiber.Ctx) error {
+	artist := &models.Artist{}
+	if err := ctx.BodyParser(artist); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse JSON",
+		})
+	}
+	artist.Id = 0
+	artist.Name = strings.TrimSpace(artist.Name)
+	if artist.Name == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "name is required",
+		})
+	}
+
+	if err := h.repository.Create(artist); err != nil {
+		return artistError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(artist)
+}
+
+// @Summary Gets all artists
+// @ID get-artists
+// @Produce json
+// @Success 200 {array} models.Artist
+// @Router /artists [get]
+func (h *artistsHandler) GetArtists(ctx *fiber.Ctx) error {
+	artists, err := h.repository.Get()
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get artists",
+		})
+	}
+	return ctx.Status(fiber.StatusOK).JSON(artists)
+}
+
+// @Summary Gets an artist
+// @ID get-artist
+// @Produce json
+// @Success 200 {object} models.Artist
+// @Router /artists/{id} [get]
+func (h *artistsHandler) GetArtist(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	artist, err := h.repository.GetById(id)
+	if err != nil {
+		return artistError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(artist)
+}
+
+// @Summary Updates an artist, renaming the artist of its albums
+// @ID update-artist
+// @Produce json
+// @Success 200 {object} models.Artist
+// @Router /artists/{id} [put]
+func (h *artistsHandler) UpdateArtist(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	artist := models.Artist{}
+	if err := ctx.BodyParser(&artist); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse JSON",
+		})
+	}
+	artist.Id = id
+	artist.Name = strings.TrimSpace(artist.Name)
+	if artist.Name == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "name is required",
+		})
+	}
+
+	if err := h.repository.Update(artist); err != nil {
+		return artistError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(artist)
+}
+
+// @Summary Deletes an artist without albums
+// @ID delete-artist
+// @Success 204
+// @Router /artists/{id} [delete]
+func (h *artistsHandler) DeleteArtist(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	if err := h.repository.Delete(id); err != nil {
+		return artistError(ctx, err)
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Gets the albums of an artist
+// @ID get-artist-albums
+// @Produce json
+// @Success 200 {array} models.Album
+// @Router /artists/{id}/albums [get]
+func (h *artistsHandler) GetArtistAlbums(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	if _, err := h.repository.GetById(id); err != nil {
+		return artistError(ctx, err)
+	}
+	albums, err := h.repository.GetAlbums(id)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get albums",
+		})
+	}
+	return ctx.Status(fiber.StatusOK).JSON(albums)
+}
+
+func invalidId(ctx *fiber.Ctx) error {
+	return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error": "invalid id",
+	})
+}
+
+func artistError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "artist not found",
+		})
+	case orm.IsConflict(err):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "artist conflicts with an existing artist or still has albums",
+		})
+	case orm.IsInvalid(err):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid artist",
+		})
+	}
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "failed to access artists",
+	})
+}
@@ -0,0 +1,304 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/models"
+)
+
+// mockArtistRepository is a mock implementation of orm.ArtistRepository
+type mockArtistRepository struct {
+	createFunc    func(artist *models.Artist) error
+	getByIdFunc   func(id int) (*models.Artist, error)
+	getFunc       func() ([]*models.Artist, error)
+	updateFunc    func(artist models.Artist) error
+	deleteFunc    func(id int) error
+	getAlbumsFunc func(id int) ([]*models.Album, error)
+}
+
+func (m *mockArtistRepository) Create(artist *models.Artist) error {
+	if m.createFunc != nil {
+		return m.createFunc(artist)
+	}
+	return nil
+}
+
+func (m *mockArtistRepository) GetById(id int) (*models.Artist, error) {
+	if m.getByIdFunc != nil {
+		return m.getByIdFunc(id)
+	}
+	return &models.Artist{Id: id}, nil
+}
+
+func (m *mockArtistRepository) Get() ([]*models.Artist, error) {
+	if m.getFunc != nil {
+		return m.getFunc()
+	}
+	return []*models.Artist{}, nil
+}
+
+func (m *mockArtistRepository) Update(artist models.Artist) error {
+	if m.updateFunc != nil {
+		return m.updateFunc(artist)
+	}
+	return nil
+}
+
+func (m *mockArtistRepository) Delete(id int) error {
+	if m.deleteFunc != nil {
+		return m.deleteFunc(id)
+	}
+	return nil
+}
+
+func (m *mockArtistRepository) GetAlbums(id int) ([]*models.Album, error) {
+	if m.getAlbumsFunc != nil {
+		return m.getAlbumsFunc(id)
+	}
+	return []*models.Album{}, nil
+}
+
+// pgError is a postgres error with the given SQLSTATE
+type pgError struct {
+	code string
+}
+
+func (e pgError) Error() string {
+	return "ERROR #" + e.code
+}
+
+func (e pgError) Field(field byte) string {
+	if field == 'C' {
+		return e.code
+	}
+	return ""
+}
+
+func (e pgError) IntegrityViolation() bool {
+	return e.code[:2] == "23"
+}
+
+func newArtistsTestApp(repository *mockArtistRepository) *fiber.App {
+	app := fiber.New()
+	handler := NewArtistsHandler(repository)
+	app.Post("/artists", handler.CreateArtist)
+	app.Get("/artists", handler.GetArtists)
+	app.Get("/artists/:id", handler.GetArtist)
+	app.Put("/artists/:id", handler.UpdateArtist)
+	app.Delete("/artists/:id", handler.DeleteArtist)
+	app.Get("/artists/:id/albums", handler.GetArtistAlbums)
+	return app
+}
+
+func TestArtistsHandler_CreateArtist(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		createFunc     func(artist *models.Artist) error
+		expectedStatus int
+	}{
+		{
+			name: "creates artist",
+			body: `{"name": " John Coltrane ", "country": "US"}`,
+			createFunc: func(artist *models.Artist) error {
+				if artist.Name != "John Coltrane" {
+					t.Errorf("Expected trimmed name 'John Coltrane', got '%s'", artist.Name)
+				}
+				artist.Id = 7
+				return nil
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:           "rejects missing name",
+			body:           `{"country": "US"}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "rejects invalid JSON",
+			body:           `{"name":`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "rejects duplicate name",
+			body: `{"name": "john coltrane"}`,
+			createFunc: func(artist *models.Artist) error {
+				return pgError{code: "23505"}
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newArtistsTestApp(&mockArtistRepository{createFunc: tt.createFunc})
+
+			req, _ := http.NewRequest("POST", "/artists", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if resp.StatusCode == fiber.StatusCreated {
+				artist := models.Artist{}
+				json.NewDecoder(resp.Body).Decode(&artist)
+				if artist.Id != 7 {
+					t.Errorf("Expected created artist id 7, got %d", artist.Id)
+				}
+			}
+		})
+	}
+}
+
+func TestArtistsHandler_GetArtist(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		getByIdFunc    func(id int) (*models.Artist, error)
+		expectedStatus int
+	}{
+		{
+			name:           "returns artist",
+			path:           "/artists/7",
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name: "returns not found for unknown artist",
+			path: "/artists/8",
+			getByIdFunc: func(id int) (*models.Artist, error) {
+				return nil, pg.ErrNoRows
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:           "rejects invalid id",
+			path:           "/artists/coltrane",
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "returns internal server error on repository error",
+			path: "/artists/7",
+			getByIdFunc: func(id int) (*models.Artist, error) {
+				return nil, errors.New("connection refused")
+			},
+			expectedStatus: fiber.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newArtistsTestApp(&mockArtistRepository{getByIdFunc: tt.getByIdFunc})
+
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestArtistsHandler_UpdateArtist(t *testing.T) {
+	var updated models.Artist
+	app := newArtistsTestApp(&mockArtistRepository{
+		updateFunc: func(artist models.Artist) error {
+			updated = artist
+			return nil
+		},
+	})
+
+	req, _ := http.NewRequest("PUT", "/artists/7", bytes.NewBufferString(`{"Id": 9, "name": "John Coltrane"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Expected status %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+	if updated.Id != 7 {
+		t.Errorf("Expected the id of the path, got %d", updated.Id)
+	}
+}
+
+func TestArtistsHandler_DeleteArtist(t *testing.T) {
+	tests := []struct {
+		name           string
+		deleteFunc     func(id int) error
+		expectedStatus int
+	}{
+		{
+			name:           "deletes artist",
+			expectedStatus: fiber.StatusNoContent,
+		},
+		{
+			name: "rejects artist with albums",
+			deleteFunc: func(id int) error {
+				return pgError{code: "23503"}
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name: "returns not found for unknown artist",
+			deleteFunc: func(id int) error {
+				return pg.ErrNoRows
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newArtistsTestApp(&mockArtistRepository{deleteFunc: tt.deleteFunc})
+
+			req, _ := http.NewRequest("DELETE", "/artists/7", nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestArtistsHandler_GetArtistAlbums(t *testing.T) {
+	app := newArtistsTestApp(&mockArtistRepository{
+		getAlbumsFunc: func(id int) ([]*models.Album, error) {
+			return []*models.Album{{Id: 1, Title: "Blue Train", ArtistId: id, Artist: "John Coltrane"}}, nil
+		},
+	})
+
+	req, _ := http.NewRequest("GET", "/artists/7/albums", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Expected status %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+	albums := []models.Album{}
+	json.NewDecoder(resp.Body).Decode(&albums)
+	if len(albums) != 1 || albums[0].ArtistId != 7 || albums[0].Artist != "John Coltrane" {
+		t.Errorf("Expected the album of artist 7, got %v", albums)
+	}
+}
@@ -0,0 +1,174 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+)
+
+type editionsHandler struct {
+	albums   orm.Repository
+	editions orm.EditionRepository
+}
+
+func NewEditionsHandler(albums orm.Repository, editions orm.EditionRepository) *editionsHandler {
+	return &editionsHandler{
+		albums:   albums,
+		editions: editions,
+	}
+}
+
+// @Summary Gets the editions of an album
+// @ID get-editions
+// @Produce json
+// @Success 200 {array} models.Edition
+// @Router /albums/{id}/editions [get]
+func (h *editionsHandler) GetEditions(ctx *fiber.Ctx) error {
+	albumId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	if _, err := h.albums.GetById(albumId); err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return albumNotFound(ctx)
+		}
+		return editionError(ctx, err)
+	}
+	editions, err := h.editions.GetByAlbum(albumId)
+	if err != nil {
+		return editionError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(editions)
+}
+
+// @Summary Adds an edition to an album
+// @Description The format is one of vinyl, cd, cassette or digital. The barcode is an optional UPC-A, EAN-8 or EAN-13 with a valid check digit.
+// @ID create-edition
+// @Produce json
+// @Success 201 {object} models.Edition
+// @Router /albums/{id}/editions [post]
+func (h *editionsHandler) CreateEdition(ctx *fiber.Ctx) error {
+	albumId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	edition := &models.Edition{}
+	if err := parseEdition(ctx, edition); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	edition.Id = 0
+	edition.AlbumId = albumId
+
+	if err := h.editions.Create(edition); err != nil {
+		return editionError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(edition)
+}
+
+// @Summary Gets an edition of an album
+// @ID get-edition
+// @Produce json
+// @Success 200 {object} models.Edition
+// @Router /albums/{id}/editions/{editionId} [get]
+func (h *editionsHandler) GetEdition(ctx *fiber.Ctx) error {
+	albumId, editionId, err := editionIds(ctx)
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	edition, err := h.editions.GetById(albumId, editionId)
+	if err != nil {
+		return editionError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(edition)
+}
+
+// @Summary Updates an edition of an album
+// @ID update-edition
+// @Produce json
+// @Success 200 {object} models.Edition
+// @Router /albums/{id}/editions/{editionId} [put]
+func (h *editionsHandler) UpdateEdition(ctx *fiber.Ctx) error {
+	albumId, editionId, err := editionIds(ctx)
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	edition := models.Edition{}
+	if err := parseEdition(ctx, &edition); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	edition.Id = editionId
+	edition.AlbumId = albumId
+
+	if err := h.editions.Update(edition); err != nil {
+		return editionError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(edition)
+}
+
+// @Summary Removes an edition from an album
+// @ID delete-edition
+// @Success 204
+// @Router /albums/{id}/editions/{editionId} [delete]
+func (h *editionsHandler) DeleteEdition(ctx *fiber.Ctx) error {
+	albumId, editionId, err := editionIds(ctx)
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	if err := h.editions.Delete(albumId, editionId); err != nil {
+		return editionError(ctx, err)
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func editionIds(ctx *fiber.Ctx) (int, int, error) {
+	albumId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return 0, 0, err
+	}
+	editionId, err := ctx.ParamsInt("editionId")
+	return albumId, editionId, err
+}
+
+// parseEdition parses, normalizes and validates the edition of the body.
+func parseEdition(ctx *fiber.Ctx, edition *models.Edition) error {
+	if err := ctx.BodyParser(edition); err != nil {
+		return errors.New("cannot parse JSON")
+	}
+	edition.Normalize()
+	return edition.Validate()
+}
+
+func editionError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "edition not found",
+		})
+	case orm.IsForeignKeyViolation(err):
+		return albumNotFound(ctx)
+	case orm.IsConflict(err):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "another edition already has this sku or barcode",
+		})
+	case orm.IsInvalid(err):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid edition",
+		})
+	}
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "failed to access editions",
+	})
+}
@@ -0,0 +1,219 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+
+	"music-service/internal/models"
+)
+
+// mockEditionRepository is a mock implementation of orm.EditionRepository
+type mockEditionRepository struct {
+	createFunc     func(edition *models.Edition) error
+	getByAlbumFunc func(albumId int) ([]*models.Edition, error)
+	updateFunc     func(edition models.Edition) error
+	deleteFunc     func(albumId, id int) error
+}
+
+func (m *mockEditionRepository) Create(edition *models.Edition) error {
+	if m.createFunc != nil {
+		return m.createFunc(edition)
+	}
+	return nil
+}
+
+func (m *mockEditionRepository) GetById(albumId, id int) (*models.Edition, error) {
+	return &models.Edition{Id: id, AlbumId: albumId}, nil
+}
+
+func (m *mockEditionRepository) GetByAlbum(albumId int) ([]*models.Edition, error) {
+	if m.getByAlbumFunc != nil {
+		return m.getByAlbumFunc(albumId)
+	}
+	return []*models.Edition{}, nil
+}
+
+func (m *mockEditionRepository) Update(edition models.Edition) error {
+	if m.updateFunc != nil {
+		return m.updateFunc(edition)
+	}
+	return nil
+}
+
+func (m *mockEditionRepository) Delete(albumId, id int) error {
+	if m.deleteFunc != nil {
+		return m.deleteFunc(albumId, id)
+	}
+	return nil
+}
+
+func newEditionsTestApp(albums *mockRepository, editions *mockEditionRepository) *fiber.App {
+	app := fiber.New()
+	handler := NewEditionsHandler(albums, editions)
+	app.Get("/albums/:id/editions", handler.GetEditions)
+	app.Post("/albums/:id/editions", handler.CreateEdition)
+	app.Get("/albums/:id/editions/:editionId", handler.GetEdition)
+	app.Put("/albums/:id/editions/:editionId", handler.UpdateEdition)
+	app.Delete("/albums/:id/editions/:editionId", handler.DeleteEdition)
+	return app
+}
+
+func TestEditionsHandler_GetEditions(t *testing.T) {
+	tests := []struct {
+		name           string
+		getByIdFunc    func(id int) (*models.Album, error)
+		expectedStatus int
+	}{
+		{
+			name: "returns editions of album",
+			getByIdFunc: func(id int) (*models.Album, error) {
+				return &models.Album{Id: id}, nil
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name: "returns not found for unknown album",
+			getByIdFunc: func(id int) (*models.Album, error) {
+				return nil, pg.ErrNoRows
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newEditionsTestApp(&mockRepository{getByIdFunc: tt.getByIdFunc}, &mockEditionRepository{
+				getByAlbumFunc: func(albumId int) ([]*models.Edition, error) {
+					return []*models.Edition{{Id: 1, AlbumId: albumId, Format: models.FormatVinyl, Sku: "BN-1577-LP"}}, nil
+				},
+			})
+
+			req, _ := http.NewRequest("GET", "/albums/7/editions", nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if resp.StatusCode == fiber.StatusOK {
+				editions := []models.Edition{}
+				json.NewDecoder(resp.Body).Decode(&editions)
+				if len(editions) != 1 || editions[0].AlbumId != 7 {
+					t.Errorf("Expected the edition of album 7, got %v", editions)
+				}
+			}
+		})
+	}
+}
+
+func TestEditionsHandler_CreateEdition(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		createFunc     func(edition *models.Edition) error
+		expectedStatus int
+	}{
+		{
+			name: "creates edition",
+			body: `{"format": " Vinyl ", "sku": "BN-1577-LP", "barcode": "0 602537 33513 8", "catalogNumber": "BLP 1577", "price": "29.99"}`,
+			createFunc: func(edition *models.Edition) error {
+				if edition.AlbumId != 7 || edition.Format != models.FormatVinyl || edition.Barcode != "0602537335138" {
+					t.Errorf("Expected a normalized edition of album 7, got %v", edition)
+				}
+				if !edition.Price.Equal(decimal.RequireFromString("29.99")) {
+					t.Errorf("Expected price 29.99, got %s", edition.Price)
+				}
+				edition.Id = 2
+				return nil
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:           "rejects barcode with wrong check digit",
+			body:           `{"format": "cd", "sku": "BN-1577-CD", "barcode": "0602537335133"}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "rejects unknown format",
+			body:           `{"format": "minidisc", "sku": "BN-1577-MD"}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "rejects missing sku",
+			body:           `{"format": "cd"}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "rejects taken sku",
+			body: `{"format": "cd", "sku": "BN-1577-CD"}`,
+			createFunc: func(edition *models.Edition) error {
+				return pgError{code: "23505"}
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name: "returns not found for unknown album",
+			body: `{"format": "cd", "sku": "BN-1577-CD"}`,
+			createFunc: func(edition *models.Edition) error {
+				return pgError{code: "23503"}
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newEditionsTestApp(&mockRepository{}, &mockEditionRepository{createFunc: tt.createFunc})
+
+			req, _ := http.NewRequest("POST", "/albums/7/editions", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if resp.StatusCode == fiber.StatusCreated {
+				edition := models.Edition{}
+				json.NewDecoder(resp.Body).Decode(&edition)
+				if edition.Id != 2 {
+					t.Errorf("Expected created edition id 2, got %d", edition.Id)
+				}
+			}
+		})
+	}
+}
+
+func TestEditionsHandler_UpdateEdition(t *testing.T) {
+	var updated models.Edition
+	app := newEditionsTestApp(&mockRepository{}, &mockEditionRepository{
+		updateFunc: func(edition models.Edition) error {
+			updated = edition
+			return nil
+		},
+	})
+
+	req, _ := http.NewRequest("PUT", "/albums/7/editions/2", bytes.NewBufferString(`{"id": 9, "albumId": 8, "format": "digital", "sku": "BN-1577-DL"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Expected status %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+	if updated.Id != 2 || updated.AlbumId != 7 {
+		t.Errorf("Expected the ids of the path, got edition %d of album %d", updated.Id, updated.AlbumId)
+	}
+}
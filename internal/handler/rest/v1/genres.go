@@ -0,0 +1,217 @@
+package v1
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+)
+
+type genresHandler struct {
+	genres orm.GenreRepository
+	albums orm.Repository
+}
+
+func NewGenresHandler(genres orm.GenreRepository, albums orm.Repository) *genresHandler {
+	return &genresHandler{
+		genres: genres,
+		albums: albums,
+	}
+}
+
+// @Summary Creates a genre, below its parent genre if any
+// @ID create-genre
+// @Produce json
+// @Success 201 {object} models.Genre
+// @Router /genres [post]
+func (h *genresHandler) CreateGenre(ctx *fiber.Ctx) error {
+	genre := &models.Genre{}
+	if err := parseGenre(ctx, genre); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	genre.Id = 0
+
+	if err := h.genres.Create(genre); err != nil {
+		return genreError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(genre)
+}
+
+// @Summary Gets all genres
+// @ID get-genres
+// @Produce json
+// @Success 200 {array} models.Genre
+// @Router /genres [get]
+func (h *genresHandler) GetGenres(ctx *fiber.Ctx) error {
+	genres, err := h.genres.Get()
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get genres",
+		})
+	}
+	return ctx.Status(fiber.StatusOK).JSON(genres)
+}
+
+// @Summary Gets a genre
+// @ID get-genre
+// @Produce json
+// @Success 200 {object} models.Genre
+// @Router /genres/{id} [get]
+func (h *genresHandler) GetGenre(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	genre, err := h.genres.GetById(id)
+	if err != nil {
+		return genreError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(genre)
+}
+
+// @Summary Updates a genre
+// @ID update-genre
+// @Produce json
+// @Success 200 {object} models.Genre
+// @Router /genres/{id} [put]
+func (h *genresHandler) UpdateGenre(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	genre := models.Genre{}
+	if err := parseGenre(ctx, &genre); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	genre.Id = id
+
+	if err := h.genres.Update(genre); err != nil {
+		return genreError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(genre)
+}
+
+// @Summary Deletes a genre without genres below it
+// @ID delete-genre
+// @Success 204
+// @Router /genres/{id} [delete]
+func (h *genresHandler) DeleteGenre(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	if err := h.genres.Delete(id); err != nil {
+		if orm.IsForeignKeyViolation(err) {
+			return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "genre has genres below it",
+			})
+		}
+		return genreError(ctx, err)
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Gets the genres of an album
+// @ID get-album-genres
+// @Produce json
+// @Success 200 {array} models.Genre
+// @Router /albums/{id}/genres [get]
+func (h *genresHandler) GetAlbumGenres(ctx *fiber.Ctx) error {
+	albumId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	if _, err := h.albums.GetById(albumId); err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return albumNotFound(ctx)
+		}
+		return genreError(ctx, err)
+	}
+	genres, err := h.genres.GetByAlbum(albumId)
+	if err != nil {
+		return genreError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(genres)
+}
+
+// @Summary Replaces the genres of an album with the genre ids of the body
+// @ID set-album-genres
+// @Produce json
+// @Success 200 {array} models.Genre
+// @Router /albums/{id}/genres [put]
+func (h *genresHandler) SetAlbumGenres(ctx *fiber.Ctx) error {
+	albumId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	genreIds := []int{}
+	if err := ctx.BodyParser(&genreIds); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse JSON",
+		})
+	}
+
+	if _, err := h.albums.GetById(albumId); err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return albumNotFound(ctx)
+		}
+		return genreError(ctx, err)
+	}
+	if err := h.genres.SetAlbumGenres(albumId, genreIds); err != nil {
+		return genreError(ctx, err)
+	}
+	genres, err := h.genres.GetByAlbum(albumId)
+	if err != nil {
+		return genreError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(genres)
+}
+
+// parseGenre parses and validates the genre of the body.
+func parseGenre(ctx *fiber.Ctx, genre *models.Genre) error {
+	if err := ctx.BodyParser(genre); err != nil {
+		return errors.New("cannot parse JSON")
+	}
+	genre.Name = strings.TrimSpace(genre.Name)
+	if genre.Name == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
+
+func genreError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "genre not found",
+		})
+	case orm.IsForeignKeyViolation(err):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "unknown genre",
+		})
+	case orm.IsConflict(err):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "genre already exists below the parent genre",
+		})
+	case orm.IsInvalid(err):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid genre",
+		})
+	}
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "failed to access genres",
+	})
+}
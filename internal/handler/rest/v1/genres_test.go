@@ -0,0 +1,264 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/models"
+)
+
+// mockGenreRepository is a mock implementation of orm.GenreRepository
+type mockGenreRepository struct {
+	createFunc         func(genre *models.Genre) error
+	getByIdFunc        func(id int) (*models.Genre, error)
+	updateFunc         func(genre models.Genre) error
+	deleteFunc         func(id int) error
+	getByAlbumFunc     func(albumId int) ([]*models.Genre, error)
+	setAlbumGenresFunc func(albumId int, genreIds []int) error
+}
+
+func (m *mockGenreRepository) Create(genre *models.Genre) error {
+	if m.createFunc != nil {
+		return m.createFunc(genre)
+	}
+	return nil
+}
+
+func (m *mockGenreRepository) GetById(id int) (*models.Genre, error) {
+	if m.getByIdFunc != nil {
+		return m.getByIdFunc(id)
+	}
+	return &models.Genre{Id: id}, nil
+}
+
+func (m *mockGenreRepository) Get() ([]*models.Genre, error) {
+	return []*models.Genre{}, nil
+}
+
+func (m *mockGenreRepository) Update(genre models.Genre) error {
+	if m.updateFunc != nil {
+		return m.updateFunc(genre)
+	}
+	return nil
+}
+
+func (m *mockGenreRepository) Delete(id int) error {
+	if m.deleteFunc != nil {
+		return m.deleteFunc(id)
+	}
+	return nil
+}
+
+func (m *mockGenreRepository) GetByAlbum(albumId int) ([]*models.Genre, error) {
+	if m.getByAlbumFunc != nil {
+		return m.getByAlbumFunc(albumId)
+	}
+	return []*models.Genre{}, nil
+}
+
+func (m *mockGenreRepository) SetAlbumGenres(albumId int, genreIds []int) error {
+	if m.setAlbumGenresFunc != nil {
+		return m.setAlbumGenresFunc(albumId, genreIds)
+	}
+	return nil
+}
+
+func newGenresTestApp(genres *mockGenreRepository, albums *mockRepository) *fiber.App {
+	app := fiber.New()
+	handler := NewGenresHandler(genres, albums)
+	app.Post("/genres", handler.CreateGenre)
+	app.Get("/genres", handler.GetGenres)
+	app.Get("/genres/:id", handler.GetGenre)
+	app.Put("/genres/:id", handler.UpdateGenre)
+	app.Delete("/genres/:id", handler.DeleteGenre)
+	app.Get("/albums/:id/genres", handler.GetAlbumGenres)
+	app.Put("/albums/:id/genres", handler.SetAlbumGenres)
+	return app
+}
+
+func TestGenresHandler_CreateGenre(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		createFunc     func(genre *models.Genre) error
+		expectedStatus int
+	}{
+		{
+			name: "creates genre below parent",
+			body: `{"name": " Hard Bop ", "parentId": 1}`,
+			createFunc: func(genre *models.Genre) error {
+				if genre.Name != "Hard Bop" || genre.ParentId != 1 {
+					t.Errorf("Expected Hard Bop below genre 1, got %v", genre)
+				}
+				genre.Id = 2
+				return nil
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:           "rejects missing name",
+			body:           `{"parentId": 1}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "rejects unknown parent",
+			body: `{"name": "Hard Bop", "parentId": 9}`,
+			createFunc: func(genre *models.Genre) error {
+				return pgError{code: "23503"}
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "rejects duplicate sibling",
+			body: `{"name": "hard bop", "parentId": 1}`,
+			createFunc: func(genre *models.Genre) error {
+				return pgError{code: "23505"}
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newGenresTestApp(&mockGenreRepository{createFunc: tt.createFunc}, &mockRepository{})
+
+			req, _ := http.NewRequest("POST", "/genres", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestGenresHandler_UpdateGenre_BelowItself(t *testing.T) {
+	app := newGenresTestApp(&mockGenreRepository{
+		updateFunc: func(genre models.Genre) error {
+			return pgError{code: "23514"}
+		},
+	}, &mockRepository{})
+
+	req, _ := http.NewRequest("PUT", "/genres/1", bytes.NewBufferString(`{"name": "Jazz", "parentId": 2}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+	}
+}
+
+func TestGenresHandler_DeleteGenre(t *testing.T) {
+	tests := []struct {
+		name           string
+		deleteFunc     func(id int) error
+		expectedStatus int
+	}{
+		{
+			name:           "deletes genre",
+			expectedStatus: fiber.StatusNoContent,
+		},
+		{
+			name: "rejects genre with genres below it",
+			deleteFunc: func(id int) error {
+				return pgError{code: "23503"}
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name: "returns not found for unknown genre",
+			deleteFunc: func(id int) error {
+				return pg.ErrNoRows
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newGenresTestApp(&mockGenreRepository{deleteFunc: tt.deleteFunc}, &mockRepository{})
+
+			req, _ := http.NewRequest("DELETE", "/genres/1", nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestGenresHandler_SetAlbumGenres(t *testing.T) {
+	var set []int
+	app := newGenresTestApp(&mockGenreRepository{
+		setAlbumGenresFunc: func(albumId int, genreIds []int) error {
+			set = genreIds
+			return nil
+		},
+		getByAlbumFunc: func(albumId int) ([]*models.Genre, error) {
+			return []*models.Genre{{Id: 2, Name: "Hard Bop", ParentId: 1}}, nil
+		},
+	}, &mockRepository{
+		getByIdFunc: func(id int) (*models.Album, error) {
+			return &models.Album{Id: id}, nil
+		},
+	})
+
+	req, _ := http.NewRequest("PUT", "/albums/7/genres", bytes.NewBufferString(`[2]`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Expected status %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+	if len(set) != 1 || set[0] != 2 {
+		t.Errorf("Expected genre 2 to be set, got %v", set)
+	}
+	genres := []models.Genre{}
+	json.NewDecoder(resp.Body).Decode(&genres)
+	if len(genres) != 1 || genres[0].Name != "Hard Bop" {
+		t.Errorf("Expected the genres of the album, got %v", genres)
+	}
+}
+
+func TestGenresHandler_SetAlbumGenres_UnknownAlbum(t *testing.T) {
+	app := newGenresTestApp(&mockGenreRepository{
+		setAlbumGenresFunc: func(albumId int, genreIds []int) error {
+			t.Error("Expected the genres of an unknown album not to be set")
+			return nil
+		},
+	}, &mockRepository{
+		getByIdFunc: func(id int) (*models.Album, error) {
+			return nil, pg.ErrNoRows
+		},
+	})
+
+	req, _ := http.NewRequest("PUT", "/albums/7/genres", bytes.NewBufferString(`[2]`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", fiber.StatusNotFound, resp.StatusCode)
+	}
+}
@@ -0,0 +1,284 @@
+package v1
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/inventory"
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+	"music-service/pkg/kafka"
+)
+
+type inventoryHandler struct {
+	inventory orm.InventoryRepository
+	albums    orm.Repository
+	producer  kafka.ProducerHandler
+}
+
+func NewInventoryHandler(inventory orm.InventoryRepository, albums orm.Repository, producer kafka.ProducerHandler) *inventoryHandler {
+	return &inventoryHandler{
+		inventory: inventory,
+		albums:    albums,
+		producer:  producer,
+	}
+}
+
+// reservationRequest is the body of a reservation. The units are held for
+// TtlSeconds, or models.DefaultReservationTTL when it is zero, and at most
+// models.MaxReservationTTL.
+type reservationRequest struct {
+	AlbumId     int
+	Format      string
+	WarehouseId int
+	Quantity    int
+	TtlSeconds  int
+}
+
+// @Summary Creates a warehouse
+// @ID create-warehouse
+// @Produce json
+// @Success 201 {object} models.Warehouse
+// @Router /warehouses [post]
+func (h *inventoryHandler) CreateWarehouse(ctx *fiber.Ctx) error {
+	warehouse := &models.Warehouse{}
+	if err := ctx.BodyParser(warehouse); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse JSON",
+		})
+	}
+	warehouse.Id = 0
+	warehouse.Code = strings.ToUpper(strings.TrimSpace(warehouse.Code))
+	warehouse.Name = strings.TrimSpace(warehouse.Name)
+	if warehouse.Code == "" || warehouse.Name == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "code and name are required",
+		})
+	}
+
+	if err := h.inventory.CreateWarehouse(warehouse); err != nil {
+		return stockError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(warehouse)
+}
+
+// @Summary Gets all warehouses
+// @ID get-warehouses
+// @Produce json
+// @Success 200 {array} models.Warehouse
+// @Router /warehouses [get]
+func (h *inventoryHandler) GetWarehouses(ctx *fiber.Ctx) error {
+	warehouses, err := h.inventory.GetWarehouses()
+	if err != nil {
+		return stockError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(warehouses)
+}
+
+// @Summary Gets the stock of every format of an album in every warehouse
+// @ID get-stock
+// @Produce json
+// @Success 200 {array} models.Stock
+// @Router /albums/{id}/stock [get]
+func (h *inventoryHandler) GetStock(ctx *fiber.Ctx) error {
+	albumId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	if _, err := h.albums.GetById(albumId); err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return albumNotFound(ctx)
+		}
+		return stockError(ctx, err)
+	}
+	stock, err := h.inventory.GetStock(albumId)
+	if err != nil {
+		return stockError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(stock)
+}
+
+// @Summary Sets the units on hand and the low-stock threshold of an album format in a warehouse
+// @Description The reserved units are kept. A low-stock event is published when the available units are at or below the threshold.
+// @ID set-stock
+// @Produce json
+// @Success 200 {object} models.Stock
+// @Router /albums/{id}/stock/{format}/{warehouseId} [put]
+func (h *inventoryHandler) SetStock(ctx *fiber.Ctx) error {
+	albumId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	warehouseId, err := ctx.ParamsInt("warehouseId")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	stock := &models.Stock{}
+	if err := ctx.BodyParser(stock); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse JSON",
+		})
+	}
+	stock.AlbumId = albumId
+	stock.Format = ctx.Params("format")
+	stock.WarehouseId = warehouseId
+	stock.Reserved = 0
+	stock.Normalize()
+	if err := stock.Validate(); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := h.inventory.SetStock(stock); err != nil {
+		return stockError(ctx, err)
+	}
+	inventory.PublishLowStock(ctx.Context(), h.producer, stock)
+	return ctx.Status(fiber.StatusOK).JSON(stock)
+}
+
+// @Summary Reserves units of an album format in a warehouse
+// @Description The units are held until the reservation is committed, released or expires after ttlSeconds, 15 minutes by default. Without a warehouseId they are held in the warehouse with the most available units. A low-stock event is published when the available units are left at or below the threshold.
+// @ID create-reservation
+// @Produce json
+// @Success 201 {object} models.Reservation
+// @Router /inventory/reservations [post]
+func (h *inventoryHandler) Reserve(ctx *fiber.Ctx) error {
+	request := reservationRequest{}
+	if err := ctx.BodyParser(&request); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse JSON",
+		})
+	}
+	reservation := &models.Reservation{
+		AlbumId:     request.AlbumId,
+		Format:      request.Format,
+		WarehouseId: request.WarehouseId,
+		Quantity:    request.Quantity,
+	}
+	reservation.Normalize()
+	err := reservation.Validate()
+	if err == nil && reservation.AlbumId < 1 {
+		err = errors.New("albumId is required")
+	}
+	var ttl time.Duration
+	if err == nil {
+		ttl, err = models.ReservationTTL(int64(request.TtlSeconds))
+	}
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	stock, err := h.inventory.Reserve(reservation, ttl)
+	if err != nil {
+		return stockError(ctx, err)
+	}
+	inventory.PublishLowStock(ctx.Context(), h.producer, stock)
+	return ctx.Status(fiber.StatusCreated).JSON(reservation)
+}
+
+// @Summary Gets a reservation
+// @ID get-reservation
+// @Produce json
+// @Success 200 {object} models.Reservation
+// @Router /inventory/reservations/{id} [get]
+func (h *inventoryHandler) GetReservation(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	reservation, err := h.inventory.GetReservation(id)
+	if err != nil {
+		return reservationError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(reservation)
+}
+
+// @Summary Commits a pending reservation, taking its units off hand
+// @ID commit-reservation
+// @Produce json
+// @Success 200 {object} models.Reservation
+// @Router /inventory/reservations/{id}/commit [post]
+func (h *inventoryHandler) CommitReservation(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	reservation, err := h.inventory.Commit(id)
+	if err != nil {
+		return reservationError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(reservation)
+}
+
+// @Summary Releases a pending reservation, making its units available again
+// @ID release-reservation
+// @Produce json
+// @Success 200 {object} models.Reservation
+// @Router /inventory/reservations/{id}/release [post]
+func (h *inventoryHandler) ReleaseReservation(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	reservation, err := h.inventory.Release(id)
+	if err != nil {
+		return reservationError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(reservation)
+}
+
+func stockError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "the album format is not stocked in the warehouse",
+		})
+	case errors.Is(err, orm.ErrInsufficientStock):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "insufficient stock",
+		})
+	case orm.IsForeignKeyViolation(err):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "album or warehouse not found",
+		})
+	case orm.IsConflict(err):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "warehouse already exists",
+		})
+	case orm.IsInvalid(err):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "fewer units would be on hand than are reserved",
+		})
+	}
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "failed to access inventory",
+	})
+}
+
+func reservationError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "reservation not found",
+		})
+	case errors.Is(err, orm.ErrReservationClosed):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "reservation is no longer pending",
+		})
+	}
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "failed to access reservations",
+	})
+}
@@ -0,0 +1,473 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+)
+
+// mockInventoryRepository is a mock implementation of orm.InventoryRepository
+type mockInventoryRepository struct {
+	createWarehouseFunc func(warehouse *models.Warehouse) error
+	getStockFunc        func(albumId int) ([]*models.Stock, error)
+	setStockFunc        func(stock *models.Stock) error
+	reserveFunc         func(reservation *models.Reservation, ttl time.Duration) (*models.Stock, error)
+	getReservationFunc  func(id int) (*models.Reservation, error)
+	commitFunc          func(id int) (*models.Reservation, error)
+	releaseFunc         func(id int) (*models.Reservation, error)
+}
+
+func (m *mockInventoryRepository) CreateWarehouse(warehouse *models.Warehouse) error {
+	if m.createWarehouseFunc != nil {
+		return m.createWarehouseFunc(warehouse)
+	}
+	return nil
+}
+
+func (m *mockInventoryRepository) GetWarehouses() ([]*models.Warehouse, error) {
+	return []*models.Warehouse{}, nil
+}
+
+func (m *mockInventoryRepository) GetStock(albumId int) ([]*models.Stock, error) {
+	if m.getStockFunc != nil {
+		return m.getStockFunc(albumId)
+	}
+	return []*models.Stock{}, nil
+}
+
+func (m *mockInventoryRepository) SetStock(stock *models.Stock) error {
+	if m.setStockFunc != nil {
+		return m.setStockFunc(stock)
+	}
+	return nil
+}
+
+func (m *mockInventoryRepository) Reserve(reservation *models.Reservation, ttl time.Duration) (*models.Stock, error) {
+	if m.reserveFunc != nil {
+		return m.reserveFunc(reservation, ttl)
+	}
+	return &models.Stock{AlbumId: reservation.AlbumId, Format: reservation.Format, WarehouseId: reservation.WarehouseId, OnHand: 100, Reserved: reservation.Quantity}, nil
+}
+
+func (m *mockInventoryRepository) GetReservation(id int) (*models.Reservation, error) {
+	if m.getReservationFunc != nil {
+		return m.getReservationFunc(id)
+	}
+	return &models.Reservation{Id: id, Status: models.ReservationPending}, nil
+}
+
+func (m *mockInventoryRepository) Commit(id int) (*models.Reservation, error) {
+	if m.commitFunc != nil {
+		return m.commitFunc(id)
+	}
+	return &models.Reservation{Id: id, Status: models.ReservationCommitted}, nil
+}
+
+func (m *mockInventoryRepository) CommitAll(ids []int) ([]*models.Reservation, error) {
+	reservations := make([]*models.Reservation, len(ids))
+	for i, id := range ids {
+		reservation, err := m.Commit(id)
+		if err != nil {
+			return nil, err
+		}
+		reservations[i] = reservation
+	}
+	return reservations, nil
+}
+
+func (m *mockInventoryRepository) Release(id int) (*models.Reservation, error) {
+	if m.releaseFunc != nil {
+		return m.releaseFunc(id)
+	}
+	return &models.Reservation{Id: id, Status: models.ReservationReleased}, nil
+}
+
+func (m *mockInventoryRepository) ReturnAll(ids []int) ([]*models.Reservation, error) {
+	reservations := make([]*models.Reservation, len(ids))
+	for i, id := range ids {
+		reservations[i] = &models.Reservation{Id: id, Status: models.ReservationReturned}
+	}
+	return reservations, nil
+}
+
+func (m *mockInventoryRepository) ExpireReservations() (int, error) {
+	return 0, nil
+}
+
+func newInventoryTestApp(inventory *mockInventoryRepository, albums *mockRepository, producer *mockProducerHandler) *fiber.App {
+	app := fiber.New()
+	handler := NewInventoryHandler(inventory, albums, producer)
+	app.Post("/warehouses", handler.CreateWarehouse)
+	app.Get("/warehouses", handler.GetWarehouses)
+	app.Get("/albums/:id/stock", handler.GetStock)
+	app.Put("/albums/:id/stock/:format/:warehouseId", handler.SetStock)
+	app.Post("/inventory/reservations", handler.Reserve)
+	app.Get("/inventory/reservations/:id", handler.GetReservation)
+	app.Post("/inventory/reservations/:id/commit", handler.CommitReservation)
+	app.Post("/inventory/reservations/:id/release", handler.ReleaseReservation)
+	return app
+}
+
+func TestInventoryHandler_CreateWarehouse(t *testing.T) {
+	tests := []struct {
+		name                string
+		body                string
+		createWarehouseFunc func(warehouse *models.Warehouse) error
+		expectedStatus      int
+	}{
+		{
+			name: "creates warehouse",
+			body: `{"code": " ams-1 ", "name": "Amsterdam"}`,
+			createWarehouseFunc: func(warehouse *models.Warehouse) error {
+				if warehouse.Code != "AMS-1" {
+					t.Errorf("Expected code AMS-1, got %q", warehouse.Code)
+				}
+				warehouse.Id = 2
+				return nil
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:           "rejects missing name",
+			body:           `{"code": "AMS-1"}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "rejects taken code",
+			body: `{"code": "AMS-1", "name": "Amsterdam"}`,
+			createWarehouseFunc: func(warehouse *models.Warehouse) error {
+				return pgError{code: "23505"}
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newInventoryTestApp(&mockInventoryRepository{createWarehouseFunc: tt.createWarehouseFunc}, &mockRepository{}, &mockProducerHandler{})
+
+			req, _ := http.NewRequest("POST", "/warehouses", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestInventoryHandler_GetStock(t *testing.T) {
+	tests := []struct {
+		name           string
+		getByIdFunc    func(id int) (*models.Album, error)
+		expectedStatus int
+	}{
+		{
+			name: "returns stock of album",
+			getByIdFunc: func(id int) (*models.Album, error) {
+				return &models.Album{Id: id}, nil
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name: "returns not found for unknown album",
+			getByIdFunc: func(id int) (*models.Album, error) {
+				return nil, pg.ErrNoRows
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newInventoryTestApp(&mockInventoryRepository{
+				getStockFunc: func(albumId int) ([]*models.Stock, error) {
+					return []*models.Stock{{AlbumId: albumId, Format: models.FormatVinyl, WarehouseId: 1, OnHand: 5}}, nil
+				},
+			}, &mockRepository{getByIdFunc: tt.getByIdFunc}, &mockProducerHandler{})
+
+			req, _ := http.NewRequest("GET", "/albums/7/stock", nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if resp.StatusCode == fiber.StatusOK {
+				stock := []models.Stock{}
+				json.NewDecoder(resp.Body).Decode(&stock)
+				if len(stock) != 1 || stock[0].AlbumId != 7 {
+					t.Errorf("Expected the stock of album 7, got %v", stock)
+				}
+			}
+		})
+	}
+}
+
+func TestInventoryHandler_SetStock(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		setStockFunc   func(stock *models.Stock) error
+		expectedStatus int
+		expectedEvents int
+	}{
+		{
+			name: "sets stock",
+			path: "/albums/7/stock/Vinyl/2",
+			body: `{"onHand": 20, "reserved": 15, "lowStockThreshold": 5}`,
+			setStockFunc: func(stock *models.Stock) error {
+				if stock.AlbumId != 7 || stock.Format != "vinyl" || stock.WarehouseId != 2 || stock.OnHand != 20 || stock.Reserved != 0 {
+					t.Errorf("Expected 20 units of the vinyl of album 7 in warehouse 2, got %s", stock.String())
+				}
+				stock.Reserved = 3
+				return nil
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name: "publishes low stock",
+			path: "/albums/7/stock/vinyl/2",
+			body: `{"onHand": 4, "lowStockThreshold": 5}`,
+			setStockFunc: func(stock *models.Stock) error {
+				stock.Reserved = 1
+				return nil
+			},
+			expectedStatus: fiber.StatusOK,
+			expectedEvents: 1,
+		},
+		{
+			name:           "rejects unknown format",
+			path:           "/albums/7/stock/8-track/2",
+			body:           `{"onHand": 4}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "rejects negative units",
+			path:           "/albums/7/stock/vinyl/2",
+			body:           `{"onHand": -1}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "rejects fewer units than reserved",
+			path: "/albums/7/stock/vinyl/2",
+			body: `{"onHand": 1}`,
+			setStockFunc: func(stock *models.Stock) error {
+				return pgError{code: "23514"}
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name: "returns not found for unknown warehouse",
+			path: "/albums/7/stock/vinyl/9",
+			body: `{"onHand": 1}`,
+			setStockFunc: func(stock *models.Stock) error {
+				return pgError{code: "23503"}
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			producer := &mockProducerHandler{}
+			app := newInventoryTestApp(&mockInventoryRepository{setStockFunc: tt.setStockFunc}, &mockRepository{}, producer)
+
+			req, _ := http.NewRequest("PUT", tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if len(producer.stockEvents) != tt.expectedEvents {
+				t.Errorf("Expected %d stock events, got %d", tt.expectedEvents, len(producer.stockEvents))
+			}
+		})
+	}
+}
+
+func TestInventoryHandler_Reserve(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		reserveFunc    func(reservation *models.Reservation, ttl time.Duration) (*models.Stock, error)
+		expectedStatus int
+		expectedEvents int
+	}{
+		{
+			name: "reserves units",
+			body: `{"albumId": 7, "format": "CD", "warehouseId": 2, "quantity": 2}`,
+			reserveFunc: func(reservation *models.Reservation, ttl time.Duration) (*models.Stock, error) {
+				if reservation.Format != "cd" || reservation.Quantity != 2 || ttl != models.DefaultReservationTTL {
+					t.Errorf("Expected 2 CDs held for the default ttl, got %s held for %s", reservation.String(), ttl)
+				}
+				reservation.Id = 11
+				reservation.Status = models.ReservationPending
+				return &models.Stock{OnHand: 10, Reserved: 2, LowStockThreshold: 3}, nil
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name: "publishes low stock",
+			body: `{"albumId": 7, "format": "cd", "warehouseId": 2, "quantity": 2, "ttlSeconds": 60}`,
+			reserveFunc: func(reservation *models.Reservation, ttl time.Duration) (*models.Stock, error) {
+				if ttl != time.Minute {
+					t.Errorf("Expected a ttl of 1m, got %s", ttl)
+				}
+				return &models.Stock{OnHand: 10, Reserved: 8, LowStockThreshold: 3}, nil
+			},
+			expectedStatus: fiber.StatusCreated,
+			expectedEvents: 1,
+		},
+		{
+			name:           "rejects zero quantity",
+			body:           `{"albumId": 7, "format": "cd", "warehouseId": 2}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "rejects missing album",
+			body:           `{"format": "cd", "warehouseId": 2, "quantity": 1}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "clamps too long ttl",
+			body: `{"albumId": 7, "format": "cd", "warehouseId": 2, "quantity": 1, "ttlSeconds": 172800}`,
+			reserveFunc: func(reservation *models.Reservation, ttl time.Duration) (*models.Stock, error) {
+				if ttl != models.MaxReservationTTL {
+					t.Errorf("Expected a ttl of %s, got %s", models.MaxReservationTTL, ttl)
+				}
+				return &models.Stock{OnHand: 10, Reserved: 1, LowStockThreshold: 3}, nil
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:           "rejects negative ttl",
+			body:           `{"albumId": 7, "format": "cd", "warehouseId": 2, "quantity": 1, "ttlSeconds": -1}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "rejects insufficient stock",
+			body: `{"albumId": 7, "format": "cd", "warehouseId": 2, "quantity": 50}`,
+			reserveFunc: func(reservation *models.Reservation, ttl time.Duration) (*models.Stock, error) {
+				return nil, orm.ErrInsufficientStock
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name: "returns not found for unstocked format",
+			body: `{"albumId": 7, "format": "cassette", "warehouseId": 2, "quantity": 1}`,
+			reserveFunc: func(reservation *models.Reservation, ttl time.Duration) (*models.Stock, error) {
+				return nil, pg.ErrNoRows
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			producer := &mockProducerHandler{}
+			app := newInventoryTestApp(&mockInventoryRepository{reserveFunc: tt.reserveFunc}, &mockRepository{}, producer)
+
+			req, _ := http.NewRequest("POST", "/inventory/reservations", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if len(producer.stockEvents) != tt.expectedEvents {
+				t.Errorf("Expected %d stock events, got %d", tt.expectedEvents, len(producer.stockEvents))
+			}
+		})
+	}
+}
+
+func TestInventoryHandler_CloseReservation(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		commitFunc     func(id int) (*models.Reservation, error)
+		releaseFunc    func(id int) (*models.Reservation, error)
+		expectedStatus int
+		expectedState  string
+	}{
+		{
+			name:           "commits reservation",
+			path:           "/inventory/reservations/11/commit",
+			expectedStatus: fiber.StatusOK,
+			expectedState:  models.ReservationCommitted,
+		},
+		{
+			name:           "releases reservation",
+			path:           "/inventory/reservations/11/release",
+			expectedStatus: fiber.StatusOK,
+			expectedState:  models.ReservationReleased,
+		},
+		{
+			name: "rejects committing expired reservation",
+			path: "/inventory/reservations/11/commit",
+			commitFunc: func(id int) (*models.Reservation, error) {
+				return nil, orm.ErrReservationClosed
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name: "returns not found for unknown reservation",
+			path: "/inventory/reservations/12/release",
+			releaseFunc: func(id int) (*models.Reservation, error) {
+				return nil, pg.ErrNoRows
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:           "rejects invalid id",
+			path:           "/inventory/reservations/first/commit",
+			expectedStatus: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newInventoryTestApp(&mockInventoryRepository{commitFunc: tt.commitFunc, releaseFunc: tt.releaseFunc}, &mockRepository{}, &mockProducerHandler{})
+
+			req, _ := http.NewRequest("POST", tt.path, nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if resp.StatusCode == fiber.StatusOK {
+				reservation := models.Reservation{}
+				json.NewDecoder(resp.Body).Decode(&reservation)
+				if reservation.Id != 11 || reservation.Status != tt.expectedState {
+					t.Errorf("Expected reservation 11 to be %s, got %s", tt.expectedState, reservation.String())
+				}
+			}
+		})
+	}
+}
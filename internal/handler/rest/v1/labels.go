@@ -0,0 +1,177 @@
+package v1
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+)
+
+type labelsHandler struct {
+	repository orm.LabelRepository
+}
+
+func NewLabelsHandler(repository orm.LabelRepository) *labelsHandler {
+	return &labelsHandler{
+		repository: repository,
+	}
+}
+
+// @Summary Creates a record label
+// @ID create-label
+// @Produce json
+// @Success 201 {object} models.Label
+// @Router /labels [post]
+func (h *labelsHandler) CreateLabel(ctx *fiber.Ctx) error {
+	label := &models.Label{}
+	if err := parseLabel(ctx, label); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	label.Id = 0
+
+	if err := h.repository.Create(label); err != nil {
+		return labelError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(label)
+}
+
+// @Summary Gets all record labels
+// @ID get-labels
+// @Produce json
+// @Success 200 {array} models.Label
+// @Router /labels [get]
+func (h *labelsHandler) GetLabels(ctx *fiber.Ctx) error {
+	labels, err := h.repository.Get()
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get labels",
+		})
+	}
+	return ctx.Status(fiber.StatusOK).JSON(labels)
+}
+
+// @Summary Gets a record label
+// @ID get-label
+// @Produce json
+// @Success 200 {object} models.Label
+// @Router /labels/{id} [get]
+func (h *labelsHandler) GetLabel(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	label, err := h.repository.GetById(id)
+	if err != nil {
+		return labelError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(label)
+}
+
+// @Summary Updates a record label
+// @ID update-label
+// @Produce json
+// @Success 200 {object} models.Label
+// @Router /labels/{id} [put]
+func (h *labelsHandler) UpdateLabel(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	label := models.Label{}
+	if err := parseLabel(ctx, &label); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	label.Id = id
+
+	if err := h.repository.Update(label); err != nil {
+		return labelError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(label)
+}
+
+// @Summary Deletes a record label without albums
+// @ID delete-label
+// @Success 204
+// @Router /labels/{id} [delete]
+func (h *labelsHandler) DeleteLabel(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	if err := h.repository.Delete(id); err != nil {
+		if orm.IsForeignKeyViolation(err) {
+			return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "label still has albums",
+			})
+		}
+		return labelError(ctx, err)
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Gets the albums released by a record label
+// @ID get-label-albums
+// @Produce json
+// @Success 200 {array} models.Album
+// @Router /labels/{id}/albums [get]
+func (h *labelsHandler) GetLabelAlbums(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	if _, err := h.repository.GetById(id); err != nil {
+		return labelError(ctx, err)
+	}
+	albums, err := h.repository.GetAlbums(id)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get albums",
+		})
+	}
+	return ctx.Status(fiber.StatusOK).JSON(albums)
+}
+
+// parseLabel parses and validates the label of the body.
+func parseLabel(ctx *fiber.Ctx, label *models.Label) error {
+	if err := ctx.BodyParser(label); err != nil {
+		return errors.New("cannot parse JSON")
+	}
+	label.Name = strings.TrimSpace(label.Name)
+	label.Country = strings.TrimSpace(label.Country)
+	if label.Name == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
+
+func labelError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "label not found",
+		})
+	case orm.IsConflict(err):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "label already exists",
+		})
+	case orm.IsInvalid(err):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid label",
+		})
+	}
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "failed to access labels",
+	})
+}
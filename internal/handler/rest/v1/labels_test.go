@@ -0,0 +1,198 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/models"
+)
+
+// mockLabelRepository is a mock implementation of orm.LabelRepository
+type mockLabelRepository struct {
+	createFunc    func(label *models.Label) error
+	getByIdFunc   func(id int) (*models.Label, error)
+	updateFunc    func(label models.Label) error
+	deleteFunc    func(id int) error
+	getAlbumsFunc func(id int) ([]*models.Album, error)
+}
+
+func (m *mockLabelRepository) Create(label *models.Label) error {
+	if m.createFunc != nil {
+		return m.createFunc(label)
+	}
+	return nil
+}
+
+func (m *mockLabelRepository) GetById(id int) (*models.Label, error) {
+	if m.getByIdFunc != nil {
+		return m.getByIdFunc(id)
+	}
+	return &models.Label{Id: id}, nil
+}
+
+func (m *mockLabelRepository) Get() ([]*models.Label, error) {
+	return []*models.Label{}, nil
+}
+
+func (m *mockLabelRepository) Update(label models.Label) error {
+	if m.updateFunc != nil {
+		return m.updateFunc(label)
+	}
+	return nil
+}
+
+func (m *mockLabelRepository) Delete(id int) error {
+	if m.deleteFunc != nil {
+		return m.deleteFunc(id)
+	}
+	return nil
+}
+
+func (m *mockLabelRepository) GetAlbums(id int) ([]*models.Album, error) {
+	if m.getAlbumsFunc != nil {
+		return m.getAlbumsFunc(id)
+	}
+	return []*models.Album{}, nil
+}
+
+func newLabelsTestApp(repository *mockLabelRepository) *fiber.App {
+	app := fiber.New()
+	handler := NewLabelsHandler(repository)
+	app.Post("/labels", handler.CreateLabel)
+	app.Get("/labels", handler.GetLabels)
+	app.Get("/labels/:id", handler.GetLabel)
+	app.Put("/labels/:id", handler.UpdateLabel)
+	app.Delete("/labels/:id", handler.DeleteLabel)
+	app.Get("/labels/:id/albums", handler.GetLabelAlbums)
+	return app
+}
+
+func TestLabelsHandler_CreateLabel(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		createFunc     func(label *models.Label) error
+		expectedStatus int
+	}{
+		{
+			name: "creates label",
+			body: `{"name": " Blue Note ", "country": "US"}`,
+			createFunc: func(label *models.Label) error {
+				if label.Name != "Blue Note" {
+					t.Errorf("Expected trimmed name 'Blue Note', got '%s'", label.Name)
+				}
+				label.Id = 4
+				return nil
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:           "rejects missing name",
+			body:           `{"country": "US"}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "rejects duplicate name",
+			body: `{"name": "blue note"}`,
+			createFunc: func(label *models.Label) error {
+				return pgError{code: "23505"}
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newLabelsTestApp(&mockLabelRepository{createFunc: tt.createFunc})
+
+			req, _ := http.NewRequest("POST", "/labels", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if resp.StatusCode == fiber.StatusCreated {
+				label := models.Label{}
+				json.NewDecoder(resp.Body).Decode(&label)
+				if label.Id != 4 {
+					t.Errorf("Expected created label id 4, got %d", label.Id)
+				}
+			}
+		})
+	}
+}
+
+func TestLabelsHandler_DeleteLabel(t *testing.T) {
+	tests := []struct {
+		name           string
+		deleteFunc     func(id int) error
+		expectedStatus int
+	}{
+		{
+			name:           "deletes label",
+			expectedStatus: fiber.StatusNoContent,
+		},
+		{
+			name: "returns not found for unknown label",
+			deleteFunc: func(id int) error {
+				return pg.ErrNoRows
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name: "rejects label with albums",
+			deleteFunc: func(id int) error {
+				return pgError{code: "23503"}
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newLabelsTestApp(&mockLabelRepository{deleteFunc: tt.deleteFunc})
+
+			req, _ := http.NewRequest("DELETE", "/labels/4", nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestLabelsHandler_GetLabelAlbums(t *testing.T) {
+	app := newLabelsTestApp(&mockLabelRepository{
+		getAlbumsFunc: func(id int) ([]*models.Album, error) {
+			return []*models.Album{{Id: 1, Title: "Blue Train", LabelId: id}}, nil
+		},
+	})
+
+	req, _ := http.NewRequest("GET", "/labels/4/albums", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Expected status %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+	albums := []models.Album{}
+	json.NewDecoder(resp.Body).Decode(&albums)
+	if len(albums) != 1 || albums[0].LabelId != 4 {
+		t.Errorf("Expected the album of label 4, got %v", albums)
+	}
+}
@@ -0,0 +1,359 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+
+	"music-service/internal/authz"
+	"music-service/internal/models"
+	"music-service/internal/orders"
+	"music-service/internal/repository/postgres/orm"
+	"music-service/pkg/payment"
+)
+
+type ordersHandler struct {
+	orders  orm.OrderRepository
+	service *orders.Service
+}
+
+func NewOrdersHandler(repository orm.OrderRepository, service *orders.Service) *ordersHandler {
+	return &ordersHandler{
+		orders:  repository,
+		service: service,
+	}
+}
+
+// cartResponse is a cart with the sum of the prices of its items.
+type cartResponse struct {
+	*models.Cart
+	Total decimal.Decimal
+}
+
+// checkoutRequest is the body of a checkout.
+type checkoutRequest struct {
+	CartId int
+}
+
+// paymentRequest is the body of a payment, the token of the payment method
+// handed to the payment provider.
+type paymentRequest struct {
+	PaymentToken string
+}
+
+// @Summary Creates a cart for a customer
+// @Description The body holds the customer and the currency the albums are priced in, USD by default. The customer is the caller when authentication is enabled.
+// @ID create-cart
+// @Produce json
+// @Success 201 {object} cartResponse
+// @Router /orders/carts [post]
+func (h *ordersHandler) CreateCart(ctx *fiber.Ctx) error {
+	cart := &models.Cart{}
+	if err := ctx.BodyParser(cart); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse JSON",
+		})
+	}
+	cart.Id = 0
+	cart.Items = nil
+	if customer, ok := authz.Customer(ctx.UserContext()); ok {
+		cart.Customer = customer
+	}
+	cart.Normalize()
+	if err := cart.Validate(); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := h.orders.CreateCart(cart); err != nil {
+		return cartError(ctx, err)
+	}
+	cart.Items = []*models.CartItem{}
+	return ctx.Status(fiber.StatusCreated).JSON(cartResponse{Cart: cart, Total: cart.Total()})
+}
+
+// @Summary Gets a cart with its items
+// @ID get-cart
+// @Produce json
+// @Success 200 {object} cartResponse
+// @Router /orders/carts/{id} [get]
+func (h *ordersHandler) GetCart(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	return h.respondCart(ctx, id)
+}
+
+// @Summary Adds a quantity of an album format to a cart
+// @Description The item is priced at the price of the album in the currency of the cart. Adding an album format already in the cart adds to its quantity at the price it was first added at.
+// @ID add-cart-item
+// @Produce json
+// @Success 200 {object} cartResponse
+// @Router /orders/carts/{id}/items [post]
+func (h *ordersHandler) AddCartItem(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	item := &models.CartItem{}
+	if err := ctx.BodyParser(item); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse JSON",
+		})
+	}
+	item.CartId = id
+	item.UnitPrice = decimal.Zero
+	item.Normalize()
+	if err := item.Validate(); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := h.orders.AddCartItem(item); err != nil {
+		return cartError(ctx, err)
+	}
+	return h.respondCart(ctx, id)
+}
+
+// @Summary Removes an album format from a cart
+// @ID remove-cart-item
+// @Produce json
+// @Success 200 {object} cartResponse
+// @Router /orders/carts/{id}/items/{albumId}/{format} [delete]
+func (h *ordersHandler) RemoveCartItem(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	albumId, err := ctx.ParamsInt("albumId")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	format := strings.ToLower(ctx.Params("format"))
+
+	if err := h.orders.RemoveCartItem(id, albumId, format); err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "cart or item not found",
+			})
+		}
+		return cartError(ctx, err)
+	}
+	return h.respondCart(ctx, id)
+}
+
+// @Summary Checks out a cart into a pending order
+// @Description The items of the cart are reserved in the warehouses with the most available units until the order is paid or cancelled, or the reservations expire after 15 minutes.
+// @ID checkout
+// @Produce json
+// @Success 201 {object} models.Order
+// @Router /orders [post]
+func (h *ordersHandler) Checkout(ctx *fiber.Ctx) error {
+	request := checkoutRequest{}
+	if err := ctx.BodyParser(&request); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse JSON",
+		})
+	}
+	if request.CartId < 1 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cartId is required",
+		})
+	}
+
+	order, err := h.service.Checkout(ctx.Context(), request.CartId)
+	if err != nil {
+		return cartError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(order)
+}
+
+// @Summary Gets the orders of a customer, the latest first
+// @Description The customer is the caller when authentication is enabled.
+// @ID get-orders
+// @Produce json
+// @Param customer query string false "the customer, required without authentication"
+// @Success 200 {array} models.Order
+// @Router /orders [get]
+func (h *ordersHandler) GetOrders(ctx *fiber.Ctx) error {
+	customer := strings.TrimSpace(ctx.Query("customer"))
+	if caller, ok := authz.Customer(ctx.UserContext()); ok {
+		if customer != "" && customer != caller {
+			return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "cannot read the orders of another customer",
+			})
+		}
+		customer = caller
+	}
+	if customer == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "customer is required",
+		})
+	}
+
+	orderList, err := h.orders.GetOrders(customer)
+	if err != nil {
+		return orderError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(orderList)
+}
+
+// @Summary Gets an order with its items
+// @ID get-order
+// @Produce json
+// @Success 200 {object} models.Order
+// @Router /orders/{id} [get]
+func (h *ordersHandler) GetOrder(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	order, err := h.orders.GetOrder(id)
+	if err != nil {
+		return orderError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(order)
+}
+
+// @Summary Pays a pending order
+// @Description The total is charged with the paymentToken and the reservations of the order are committed. A declined payment returns 402 and leaves the order pending. When the reservations expired the payment is refunded and the order cancelled.
+// @ID pay-order
+// @Produce json
+// @Success 200 {object} models.Order
+// @Router /orders/{id}/pay [post]
+func (h *ordersHandler) Pay(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	request := paymentRequest{}
+	if err := ctx.BodyParser(&request); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse JSON",
+		})
+	}
+	if strings.TrimSpace(request.PaymentToken) == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "paymentToken is required",
+		})
+	}
+
+	order, err := h.service.Pay(ctx.Context(), id, request.PaymentToken)
+	if err != nil {
+		return orderError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(order)
+}
+
+// @Summary Ships a paid order
+// @ID ship-order
+// @Produce json
+// @Success 200 {object} models.Order
+// @Router /orders/{id}/ship [post]
+func (h *ordersHandler) Ship(ctx *fiber.Ctx) error {
+	return h.transition(ctx, h.service.Ship)
+}
+
+// @Summary Cancels a pending order, releasing its reservations
+// @ID cancel-order
+// @Produce json
+// @Success 200 {object} models.Order
+// @Router /orders/{id}/cancel [post]
+func (h *ordersHandler) Cancel(ctx *fiber.Ctx) error {
+	return h.transition(ctx, h.service.Cancel)
+}
+
+// @Summary Refunds a paid or shipped order
+// @ID refund-order
+// @Produce json
+// @Success 200 {object} models.Order
+// @Router /orders/{id}/refund [post]
+func (h *ordersHandler) Refund(ctx *fiber.Ctx) error {
+	return h.transition(ctx, h.service.Refund)
+}
+
+func (h *ordersHandler) transition(ctx *fiber.Ctx, change func(ctx context.Context, id int) (*models.Order, error)) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	order, err := change(ctx.Context(), id)
+	if err != nil {
+		return orderError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(order)
+}
+
+func (h *ordersHandler) respondCart(ctx *fiber.Ctx, id int) error {
+	cart, err := h.orders.GetCart(id)
+	if err != nil {
+		return cartError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(cartResponse{Cart: cart, Total: cart.Total()})
+}
+
+func cartError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "cart not found",
+		})
+	case errors.Is(err, orm.ErrCartClosed):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "cart is checked out",
+		})
+	case errors.Is(err, orm.ErrCartChanged):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "cart changed during checkout, check it out again",
+		})
+	case errors.Is(err, orm.ErrNoPrice):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "the album does not exist or has no price in the currency of the cart",
+		})
+	case errors.Is(err, orders.ErrEmptyCart):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cart is empty",
+		})
+	case errors.Is(err, orm.ErrInsufficientStock):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "failed to access carts",
+	})
+}
+
+func orderError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "order not found",
+		})
+	case errors.Is(err, orm.ErrOrderStatus):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "the order cannot change to the status from its status",
+		})
+	case errors.Is(err, payment.ErrDeclined):
+		return ctx.Status(fiber.StatusPaymentRequired).JSON(fiber.Map{
+			"error": "payment declined",
+		})
+	case errors.Is(err, orders.ErrReservationsExpired):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "the reservations of the order expired, the payment was refunded and the order cancelled",
+		})
+	}
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "failed to access orders",
+	})
+}
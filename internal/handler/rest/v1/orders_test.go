@@ -0,0 +1,466 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+
+	"music-service/internal/models"
+	"music-service/internal/orders"
+	"music-service/internal/repository/postgres/orm"
+	"music-service/pkg/payment"
+)
+
+// mockOrderRepository is a mock implementation of orm.OrderRepository
+type mockOrderRepository struct {
+	getCartFunc        func(id int) (*models.Cart, error)
+	addCartItemFunc    func(item *models.CartItem) error
+	removeCartItemFunc func(cartId, albumId int, format string) error
+	getOrderFunc       func(id int) (*models.Order, error)
+	updateStatusFunc   func(id int, from, to string) (*models.Order, error)
+}
+
+func (m *mockOrderRepository) CreateCart(cart *models.Cart) error {
+	cart.Id = 1
+	cart.Status = models.CartOpen
+	return nil
+}
+
+func (m *mockOrderRepository) GetCart(id int) (*models.Cart, error) {
+	if m.getCartFunc != nil {
+		return m.getCartFunc(id)
+	}
+	return &models.Cart{Id: id, Customer: "ada", Currency: "USD", Status: models.CartOpen, Items: []*models.CartItem{
+		{CartId: id, AlbumId: 7, Format: models.FormatVinyl, Quantity: 2, UnitPrice: decimal.RequireFromString("24.99")},
+	}}, nil
+}
+
+func (m *mockOrderRepository) AddCartItem(item *models.CartItem) error {
+	if m.addCartItemFunc != nil {
+		return m.addCartItemFunc(item)
+	}
+	return nil
+}
+
+func (m *mockOrderRepository) RemoveCartItem(cartId, albumId int, format string) error {
+	if m.removeCartItemFunc != nil {
+		return m.removeCartItemFunc(cartId, albumId, format)
+	}
+	return nil
+}
+
+func (m *mockOrderRepository) CreateOrder(order *models.Order) error {
+	order.Id = 3
+	order.Status = models.OrderPending
+	return nil
+}
+
+func (m *mockOrderRepository) GetOrder(id int) (*models.Order, error) {
+	if m.getOrderFunc != nil {
+		return m.getOrderFunc(id)
+	}
+	return &models.Order{Id: id, Status: models.OrderPending, Currency: "USD"}, nil
+}
+
+func (m *mockOrderRepository) GetOrders(customer string) ([]*models.Order, error) {
+	return []*models.Order{{Id: 3, Customer: customer}}, nil
+}
+
+func (m *mockOrderRepository) UpdateStatus(id int, from, to string) (*models.Order, error) {
+	if m.updateStatusFunc != nil {
+		return m.updateStatusFunc(id, from, to)
+	}
+	return &models.Order{Id: id, Status: to, Currency: "USD", Total: decimal.RequireFromString("49.98"), Items: []*models.OrderItem{
+		{OrderId: id, AlbumId: 7, Format: models.FormatVinyl, Quantity: 2, ReservationId: 11},
+	}}, nil
+}
+
+func (m *mockOrderRepository) SetPayment(id int, paymentId string) (*models.Order, error) {
+	return &models.Order{Id: id, Status: models.OrderPaid, PaymentId: paymentId}, nil
+}
+
+func newOrdersTestApp(repository *mockOrderRepository, inventory *mockInventoryRepository, producer *mockProducerHandler) *fiber.App {
+	app := fiber.New()
+	handler := NewOrdersHandler(repository, orders.NewService(repository, inventory, payment.NewFakeProvider(), producer))
+	app.Post("/orders/carts", handler.CreateCart)
+	app.Get("/orders/carts/:id", handler.GetCart)
+	app.Post("/orders/carts/:id/items", handler.AddCartItem)
+	app.Delete("/orders/carts/:id/items/:albumId/:format", handler.RemoveCartItem)
+	app.Post("/orders", handler.Checkout)
+	app.Get("/orders", handler.GetOrders)
+	app.Get("/orders/:id", handler.GetOrder)
+	app.Post("/orders/:id/pay", handler.Pay)
+	app.Post("/orders/:id/ship", handler.Ship)
+	app.Post("/orders/:id/cancel", handler.Cancel)
+	app.Post("/orders/:id/refund", handler.Refund)
+	return app
+}
+
+func TestOrdersHandler_CreateCart(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{
+			name:           "creates cart in default currency",
+			body:           `{"customer": " ada "}`,
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:           "rejects missing customer",
+			body:           `{"currency": "EUR"}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "rejects invalid currency",
+			body:           `{"customer": "ada", "currency": "EURO"}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newOrdersTestApp(&mockOrderRepository{}, &mockInventoryRepository{}, &mockProducerHandler{})
+
+			req, _ := http.NewRequest("POST", "/orders/carts", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if resp.StatusCode == fiber.StatusCreated {
+				var cart map[string]interface{}
+				json.NewDecoder(resp.Body).Decode(&cart)
+				if cart["Customer"] != "ada" || cart["Currency"] != models.DefaultCurrency {
+					t.Errorf("Expected normalized cart, got %v", cart)
+				}
+			}
+		})
+	}
+}
+
+func TestOrdersHandler_AddCartItem(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		addCartItemFunc func(item *models.CartItem) error
+		expectedStatus  int
+	}{
+		{
+			name: "adds item",
+			body: `{"albumId": 7, "format": "Vinyl", "quantity": 2, "unitPrice": 0.01}`,
+			addCartItemFunc: func(item *models.CartItem) error {
+				if item.CartId != 1 || item.Format != models.FormatVinyl || !item.UnitPrice.IsZero() {
+					t.Errorf("Expected normalized item of cart 1 without price, got %+v", item)
+				}
+				return nil
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "rejects invalid format",
+			body:           `{"albumId": 7, "format": "8-track", "quantity": 2}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "rejects album without price",
+			body: `{"albumId": 7, "format": "cd", "quantity": 1}`,
+			addCartItemFunc: func(item *models.CartItem) error {
+				return orm.ErrNoPrice
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "rejects checked out cart",
+			body: `{"albumId": 7, "format": "cd", "quantity": 1}`,
+			addCartItemFunc: func(item *models.CartItem) error {
+				return orm.ErrCartClosed
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name: "returns not found for unknown cart",
+			body: `{"albumId": 7, "format": "cd", "quantity": 1}`,
+			addCartItemFunc: func(item *models.CartItem) error {
+				return pg.ErrNoRows
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newOrdersTestApp(&mockOrderRepository{addCartItemFunc: tt.addCartItemFunc}, &mockInventoryRepository{}, &mockProducerHandler{})
+
+			req, _ := http.NewRequest("POST", "/orders/carts/1/items", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestOrdersHandler_RemoveCartItem(t *testing.T) {
+	app := newOrdersTestApp(&mockOrderRepository{
+		removeCartItemFunc: func(cartId, albumId int, format string) error {
+			if albumId == 8 {
+				return pg.ErrNoRows
+			}
+			return nil
+		},
+	}, &mockInventoryRepository{}, &mockProducerHandler{})
+
+	for path, expectedStatus := range map[string]int{
+		"/orders/carts/1/items/7/vinyl": fiber.StatusOK,
+		"/orders/carts/1/items/8/vinyl": fiber.StatusNotFound,
+	} {
+		req, _ := http.NewRequest("DELETE", path, nil)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode != expectedStatus {
+			t.Errorf("%s: expected status %d, got %d", path, expectedStatus, resp.StatusCode)
+		}
+	}
+}
+
+func TestOrdersHandler_Checkout(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		getCartFunc    func(id int) (*models.Cart, error)
+		stock          int
+		expectedStatus int
+		expectedEvents int
+	}{
+		{
+			name:           "creates order",
+			body:           `{"cartId": 1}`,
+			stock:          10,
+			expectedStatus: fiber.StatusCreated,
+			expectedEvents: 1,
+		},
+		{
+			name:           "rejects missing cart id",
+			body:           `{}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "rejects insufficient stock",
+			body:           `{"cartId": 1}`,
+			stock:          1,
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name: "rejects empty cart",
+			body: `{"cartId": 1}`,
+			getCartFunc: func(id int) (*models.Cart, error) {
+				return &models.Cart{Id: id, Status: models.CartOpen}, nil
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "returns not found for unknown cart",
+			body: `{"cartId": 9}`,
+			getCartFunc: func(id int) (*models.Cart, error) {
+				return nil, pg.ErrNoRows
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			producer := &mockProducerHandler{}
+			inventory := &mockInventoryRepository{
+				reserveFunc: func(reservation *models.Reservation, ttl time.Duration) (*models.Stock, error) {
+					if reservation.Quantity > tt.stock {
+						return nil, orm.ErrInsufficientStock
+					}
+					reservation.Id = 11
+					reservation.WarehouseId = 2
+					return &models.Stock{OnHand: tt.stock, Reserved: reservation.Quantity, LowStockThreshold: 0}, nil
+				},
+			}
+			app := newOrdersTestApp(&mockOrderRepository{getCartFunc: tt.getCartFunc}, inventory, producer)
+
+			req, _ := http.NewRequest("POST", "/orders", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if len(producer.orderEvents) != tt.expectedEvents {
+				t.Errorf("Expected %d order events, got %d", tt.expectedEvents, len(producer.orderEvents))
+			}
+		})
+	}
+}
+
+func TestOrdersHandler_Pay(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{
+			name:           "pays order",
+			body:           `{"paymentToken": "tok_visa"}`,
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "rejects missing token",
+			body:           `{}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "returns payment required when declined",
+			body:           `{"paymentToken": "tok_declined"}`,
+			expectedStatus: fiber.StatusPaymentRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newOrdersTestApp(&mockOrderRepository{}, &mockInventoryRepository{}, &mockProducerHandler{})
+
+			req, _ := http.NewRequest("POST", "/orders/3/pay", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestOrdersHandler_Transitions(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		updateStatusFunc func(id int, from, to string) (*models.Order, error)
+		expectedStatus   int
+	}{
+		{
+			name:           "cancels order",
+			path:           "/orders/3/cancel",
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name: "rejects shipping unpaid order",
+			path: "/orders/3/ship",
+			updateStatusFunc: func(id int, from, to string) (*models.Order, error) {
+				return nil, orm.ErrOrderStatus
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name:           "rejects refunding pending order",
+			path:           "/orders/3/refund",
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name: "returns not found for unknown order",
+			path: "/orders/9/ship",
+			updateStatusFunc: func(id int, from, to string) (*models.Order, error) {
+				return nil, pg.ErrNoRows
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newOrdersTestApp(&mockOrderRepository{updateStatusFunc: tt.updateStatusFunc}, &mockInventoryRepository{}, &mockProducerHandler{})
+
+			req, _ := http.NewRequest("POST", tt.path, nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestOrdersHandler_GetOrders(t *testing.T) {
+	app := newOrdersTestApp(&mockOrderRepository{}, &mockInventoryRepository{}, &mockProducerHandler{})
+
+	for path, expectedStatus := range map[string]int{
+		"/orders?customer=ada": fiber.StatusOK,
+		"/orders":              fiber.StatusBadRequest,
+	} {
+		req, _ := http.NewRequest("GET", path, nil)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode != expectedStatus {
+			t.Errorf("%s: expected status %d, got %d", path, expectedStatus, resp.StatusCode)
+		}
+	}
+}
+
+func TestOrdersHandler_AuthenticatedCustomer(t *testing.T) {
+	app := fiber.New()
+	app.Use(withIdentity("ada"))
+	app.Mount("/", newOrdersTestApp(&mockOrderRepository{}, &mockInventoryRepository{}, &mockProducerHandler{}))
+
+	for path, expectedStatus := range map[string]int{
+		"/orders":                  fiber.StatusOK,
+		"/orders?customer=ada":     fiber.StatusOK,
+		"/orders?customer=charles": fiber.StatusForbidden,
+	} {
+		req, _ := http.NewRequest("GET", path, nil)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode != expectedStatus {
+			t.Errorf("%s: expected status %d, got %d", path, expectedStatus, resp.StatusCode)
+		}
+	}
+
+	req, _ := http.NewRequest("POST", "/orders/carts", bytes.NewBufferString(`{"customer": "charles"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	cart := cartResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(&cart); err != nil {
+		t.Fatalf("Failed to decode cart: %v", err)
+	}
+	if cart.Cart == nil || cart.Customer != "ada" {
+		t.Errorf("Expected cart of the caller ada, got %+v", cart.Cart)
+	}
+}
@@ -0,0 +1,504 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/authz"
+	"music-service/internal/models"
+	"music-service/internal/playlists"
+	"music-service/internal/repository/postgres/orm"
+)
+
+type playlistsHandler struct {
+	service *playlists.Service
+}
+
+func NewPlaylistsHandler(service *playlists.Service) *playlistsHandler {
+	return &playlistsHandler{service: service}
+}
+
+// moveRequest is the body of a move of a playlist item.
+type moveRequest struct {
+	Position int
+}
+
+// @Summary Gets the public playlists
+// @ID get-public-playlists
+// @Produce json
+// @Success 200 {array} models.Playlist
+// @Router /playlists [get]
+func (h *playlistsHandler) GetPublicPlaylists(ctx *fiber.Ctx) error {
+	playlistList, err := h.service.GetPublic()
+	if err != nil {
+		return playlistError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(playlistList)
+}
+
+// @Summary Creates a playlist owned by the user
+// @Description The visibility is one of public, unlisted or private, private by default.
+// @ID create-playlist
+// @Produce json
+// @Success 201 {object} models.Playlist
+// @Router /playlists [post]
+func (h *playlistsHandler) CreatePlaylist(ctx *fiber.Ctx) error {
+	userId, err := requiredUser(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	playlist := &models.Playlist{}
+	if err := ctx.BodyParser(playlist); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse JSON",
+		})
+	}
+	playlist.Normalize()
+	if err := playlist.Validate(); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := h.service.Create(playlist, userId); err != nil {
+		if orm.IsForeignKeyViolation(err) {
+			return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "user not found",
+			})
+		}
+		return playlistError(ctx, err)
+	}
+	playlist.Collaborators = []int{}
+	playlist.Items = []*models.PlaylistItem{}
+	return ctx.Status(fiber.StatusCreated).JSON(playlist)
+}
+
+// @Summary Gets a playlist the user can read with its items
+// @Description Public playlists are read by anyone, others only by their owner and collaborators.
+// @ID get-playlist
+// @Produce json
+// @Success 200 {object} models.Playlist
+// @Router /playlists/{id} [get]
+func (h *playlistsHandler) GetPlaylist(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	userId, err := actingUser(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	playlist, err := h.service.Get(id, userId)
+	if err != nil {
+		return playlistError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(playlist)
+}
+
+// @Summary Gets a public or unlisted playlist by its share token
+// @ID get-shared-playlist
+// @Produce json
+// @Success 200 {object} models.Playlist
+// @Router /playlists/shared/{token} [get]
+func (h *playlistsHandler) GetSharedPlaylist(ctx *fiber.Ctx) error {
+	playlist, err := h.service.GetShared(ctx.Params("token"))
+	if err != nil {
+		return playlistError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(playlist)
+}
+
+// @Summary Gets the playlists a user owns or collaborates on that the acting user can read
+// @ID get-user-playlists
+// @Produce json
+// @Success 200 {array} models.Playlist
+// @Router /users/{id}/playlists [get]
+func (h *playlistsHandler) GetUserPlaylists(ctx *fiber.Ctx) error {
+	ownerId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	userId, err := actingUser(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	playlistList, err := h.service.GetByUser(ownerId, userId)
+	if err != nil {
+		return playlistError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(playlistList)
+}
+
+// @Summary Sets the name, description and visibility of a playlist
+// @Description Only the owner can change them.
+// @ID update-playlist
+// @Produce json
+// @Success 200 {object} models.Playlist
+// @Router /playlists/{id} [put]
+func (h *playlistsHandler) UpdatePlaylist(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	userId, err := requiredUser(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	playlist := &models.Playlist{}
+	if err := ctx.BodyParser(playlist); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse JSON",
+		})
+	}
+	playlist.Id = id
+	playlist.Normalize()
+	if err := playlist.Validate(); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := h.service.Update(playlist, userId); err != nil {
+		return playlistError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(playlist)
+}
+
+// @Summary Deletes a playlist
+// @Description Only the owner can delete it.
+// @ID delete-playlist
+// @Produce json
+// @Success 204
+// @Router /playlists/{id} [delete]
+func (h *playlistsHandler) DeletePlaylist(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	userId, err := requiredUser(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := h.service.Delete(id, userId); err != nil {
+		return playlistError(ctx, err)
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Replaces the share token of a playlist, revoking the links shared so far
+// @Description Only the owner can replace it.
+// @ID rotate-share-token
+// @Produce json
+// @Success 200 {object} models.Playlist
+// @Router /playlists/{id}/share-token [post]
+func (h *playlistsHandler) RotateShareToken(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	userId, err := requiredUser(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	playlist, err := h.service.RotateShareToken(id, userId)
+	if err != nil {
+		return playlistError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(playlist)
+}
+
+// @Summary Adds an album or a track to a playlist
+// @Description The item is inserted at its position, or appended without one. The owner and collaborators can add items.
+// @ID add-playlist-item
+// @Produce json
+// @Success 201 {object} models.PlaylistItem
+// @Router /playlists/{id}/items [post]
+func (h *playlistsHandler) AddItem(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	userId, err := requiredUser(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	item := &models.PlaylistItem{}
+	if err := ctx.BodyParser(item); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse JSON",
+		})
+	}
+	item.Id = 0
+	item.PlaylistId = id
+	if err := item.Validate(); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := h.service.AddItem(item, userId); err != nil {
+		return playlistError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(item)
+}
+
+// @Summary Removes an item from a playlist, moving the items after it up
+// @ID remove-playlist-item
+// @Produce json
+// @Success 204
+// @Router /playlists/{id}/items/{itemId} [delete]
+func (h *playlistsHandler) RemoveItem(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	itemId, err := ctx.ParamsInt("itemId")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	userId, err := requiredUser(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := h.service.RemoveItem(id, itemId, userId); err != nil {
+		return playlistError(ctx, err)
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Moves an item of a playlist to a position, shifting the items in between
+// @Description A position past the end moves the item to the end.
+// @ID move-playlist-item
+// @Produce json
+// @Success 200 {object} models.PlaylistItem
+// @Router /playlists/{id}/items/{itemId}/position [put]
+func (h *playlistsHandler) MoveItem(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	itemId, err := ctx.ParamsInt("itemId")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	userId, err := requiredUser(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	request := moveRequest{}
+	if err := ctx.BodyParser(&request); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse JSON",
+		})
+	}
+	if request.Position < 1 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "position must be positive",
+		})
+	}
+
+	item, err := h.service.MoveItem(id, itemId, request.Position, userId)
+	if err != nil {
+		return playlistError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(item)
+}
+
+// @Summary Lets a user change the items of a playlist
+// @Description Only the owner can add collaborators.
+// @ID add-playlist-collaborator
+// @Produce json
+// @Success 204
+// @Router /playlists/{id}/collaborators/{collaboratorId} [put]
+func (h *playlistsHandler) AddCollaborator(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	collaboratorId, err := ctx.ParamsInt("collaboratorId")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	userId, err := requiredUser(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := h.service.AddCollaborator(id, collaboratorId, userId); err != nil {
+		return playlistError(ctx, err)
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Removes a collaborator from a playlist
+// @Description The owner removes any collaborator, a collaborator only themselves.
+// @ID remove-playlist-collaborator
+// @Produce json
+// @Success 204
+// @Router /playlists/{id}/collaborators/{collaboratorId} [delete]
+func (h *playlistsHandler) RemoveCollaborator(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	collaboratorId, err := ctx.ParamsInt("collaboratorId")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	userId, err := requiredUser(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := h.service.RemoveCollaborator(id, collaboratorId, userId); err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "playlist or collaborator not found",
+			})
+		}
+		return playlistError(ctx, err)
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Exports a playlist the user can read as json, m3u or xspf
+// @Description Album items are expanded into their tracks. The entries are located by their track in this API.
+// @ID export-playlist
+// @Produce json,audio/x-mpegurl,application/xspf+xml
+// @Success 200 {string} string
+// @Router /playlists/{id}/export [get]
+func (h *playlistsHandler) ExportPlaylist(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	userId, err := actingUser(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	playlist, err := h.service.Get(id, userId)
+	if err != nil {
+		return playlistError(ctx, err)
+	}
+	return h.export(ctx, playlist)
+}
+
+// @Summary Exports a public or unlisted playlist by its share token as json, m3u or xspf
+// @ID export-shared-playlist
+// @Produce json,audio/x-mpegurl,application/xspf+xml
+// @Success 200 {string} string
+// @Router /playlists/shared/{token}/export [get]
+func (h *playlistsHandler) ExportSharedPlaylist(ctx *fiber.Ctx) error {
+	playlist, err := h.service.GetShared(ctx.Params("token"))
+	if err != nil {
+		return playlistError(ctx, err)
+	}
+	return h.export(ctx, playlist)
+}
+
+func (h *playlistsHandler) export(ctx *fiber.Ctx, playlist *models.Playlist) error {
+	format := ctx.Query("format", playlists.FormatJSON)
+	data, contentType, err := h.service.Export(playlist, format, ctx.BaseURL())
+	if err != nil {
+		return playlistError(ctx, err)
+	}
+	ctx.Set(fiber.HeaderContentType, contentType)
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="playlist-%d.%s"`, playlist.Id, format))
+	return ctx.Status(fiber.StatusOK).Send(data)
+}
+
+// actingUser returns the user reading or changing a playlist, zero for an
+// anonymous user. It is the caller when authentication is enabled, callers
+// other than users being anonymous, and the userId query parameter otherwise.
+func actingUser(ctx *fiber.Ctx) (int, error) {
+	if userId, ok, err := authz.UserId(ctx.UserContext()); ok {
+		if err != nil {
+			return 0, nil
+		}
+		return userId, nil
+	}
+
+	value := ctx.Query("userId")
+	if value == "" {
+		return 0, nil
+	}
+	userId, err := strconv.Atoi(value)
+	if err != nil || userId < 1 {
+		return 0, errors.New("invalid userId")
+	}
+	return userId, nil
+}
+
+// requiredUser is actingUser for changes, which anonymous users cannot make.
+func requiredUser(ctx *fiber.Ctx) (int, error) {
+	if _, ok, err := authz.UserId(ctx.UserContext()); ok && err != nil {
+		return 0, err
+	}
+	userId, err := actingUser(ctx)
+	if err == nil && userId == 0 {
+		err = errors.New("userId is required")
+	}
+	return userId, err
+}
+
+func playlistError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "playlist or item not found",
+		})
+	case errors.Is(err, playlists.ErrForbidden):
+		return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, playlists.ErrOwnerCollaborator), errors.Is(err, playlists.ErrUnknownFormat):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case orm.IsForeignKeyViolation(err):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "album, track or user not found",
+		})
+	}
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "failed to access playlists",
+	})
+}
@@ -0,0 +1,432 @@
+package v1
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/models"
+	"music-service/internal/playlists"
+	"music-service/pkg/auth"
+)
+
+// mockPlaylistRepository is a mock implementation of orm.PlaylistRepository
+// holding a private playlist of user 1 with collaborator 2
+type mockPlaylistRepository struct {
+	createFunc   func(playlist *models.Playlist) error
+	addItemFunc  func(item *models.PlaylistItem) error
+	moveItemFunc func(playlistId, itemId, position int) (*models.PlaylistItem, error)
+}
+
+func (m *mockPlaylistRepository) Create(playlist *models.Playlist) error {
+	if m.createFunc != nil {
+		return m.createFunc(playlist)
+	}
+	playlist.Id = 1
+	return nil
+}
+
+func (m *mockPlaylistRepository) GetById(id int) (*models.Playlist, error) {
+	if id != 1 {
+		return nil, pg.ErrNoRows
+	}
+	return &models.Playlist{Id: 1, OwnerId: 1, Name: "Road trip", Visibility: models.PlaylistPrivate, ShareToken: "abc", Collaborators: []int{2}}, nil
+}
+
+func (m *mockPlaylistRepository) GetByShareToken(token string) (*models.Playlist, error) {
+	if token != "abc" {
+		return nil, pg.ErrNoRows
+	}
+	return &models.Playlist{Id: 1, OwnerId: 1, Name: "Road trip", Visibility: models.PlaylistUnlisted, ShareToken: token}, nil
+}
+
+func (m *mockPlaylistRepository) GetPublic() ([]*models.Playlist, error) {
+	return []*models.Playlist{}, nil
+}
+
+func (m *mockPlaylistRepository) GetByUser(userId int) ([]*models.Playlist, error) {
+	playlist, _ := m.GetById(1)
+	return []*models.Playlist{playlist}, nil
+}
+
+func (m *mockPlaylistRepository) Update(playlist *models.Playlist) error {
+	return nil
+}
+
+func (m *mockPlaylistRepository) RotateShareToken(id int) (*models.Playlist, error) {
+	return m.GetById(id)
+}
+
+func (m *mockPlaylistRepository) Delete(id int) error {
+	return nil
+}
+
+func (m *mockPlaylistRepository) AddItem(item *models.PlaylistItem) error {
+	if m.addItemFunc != nil {
+		return m.addItemFunc(item)
+	}
+	item.Id = 5
+	return nil
+}
+
+func (m *mockPlaylistRepository) RemoveItem(playlistId, itemId int) error {
+	return nil
+}
+
+func (m *mockPlaylistRepository) MoveItem(playlistId, itemId, position int) (*models.PlaylistItem, error) {
+	if m.moveItemFunc != nil {
+		return m.moveItemFunc(playlistId, itemId, position)
+	}
+	return &models.PlaylistItem{Id: itemId, PlaylistId: playlistId, Position: position}, nil
+}
+
+func (m *mockPlaylistRepository) AddCollaborator(playlistId, userId int) error {
+	return nil
+}
+
+func (m *mockPlaylistRepository) RemoveCollaborator(playlistId, userId int) error {
+	return nil
+}
+
+func (m *mockPlaylistRepository) GetEntries(playlistId int) ([]*models.PlaylistEntry, error) {
+	return []*models.PlaylistEntry{
+		{ItemId: 1, Position: 1, AlbumId: 7, AlbumTitle: "Kind of Blue", Artist: "Miles Davis", TrackId: 11, TrackTitle: "So What", DurationMs: 562400},
+	}, nil
+}
+
+func newPlaylistsTestApp(repository *mockPlaylistRepository) *fiber.App {
+	app := fiber.New()
+	handler := NewPlaylistsHandler(playlists.NewService(repository))
+	app.Get("/playlists", handler.GetPublicPlaylists)
+	app.Post("/playlists", handler.CreatePlaylist)
+	app.Get("/playlists/shared/:token", handler.GetSharedPlaylist)
+	app.Get("/playlists/shared/:token/export", handler.ExportSharedPlaylist)
+	app.Get("/playlists/:id", handler.GetPlaylist)
+	app.Put("/playlists/:id", handler.UpdatePlaylist)
+	app.Delete("/playlists/:id", handler.DeletePlaylist)
+	app.Get("/playlists/:id/export", handler.ExportPlaylist)
+	app.Post("/playlists/:id/share-token", handler.RotateShareToken)
+	app.Post("/playlists/:id/items", handler.AddItem)
+	app.Delete("/playlists/:id/items/:itemId", handler.RemoveItem)
+	app.Put("/playlists/:id/items/:itemId/position", handler.MoveItem)
+	app.Put("/playlists/:id/collaborators/:collaboratorId", handler.AddCollaborator)
+	app.Delete("/playlists/:id/collaborators/:collaboratorId", handler.RemoveCollaborator)
+	app.Get("/users/:id/playlists", handler.GetUserPlaylists)
+	return app
+}
+
+func TestPlaylistsHandler_CreatePlaylist(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		body           string
+		createFunc     func(playlist *models.Playlist) error
+		expectedStatus int
+	}{
+		{
+			name: "creates private playlist owned by the user",
+			url:  "/playlists?userId=1",
+			body: `{"name": " Road trip ", "ownerId": 9}`,
+			createFunc: func(playlist *models.Playlist) error {
+				if playlist.OwnerId != 1 || playlist.Name != "Road trip" || playlist.Visibility != models.PlaylistPrivate {
+					t.Errorf("Expected normalized private playlist of user 1, got %s", playlist.String())
+				}
+				return nil
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:           "rejects anonymous user",
+			url:            "/playlists",
+			body:           `{"name": "Road trip"}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "rejects invalid user",
+			url:            "/playlists?userId=abc",
+			body:           `{"name": "Road trip"}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "rejects invalid visibility",
+			url:            "/playlists?userId=1",
+			body:           `{"name": "Road trip", "visibility": "friends"}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "returns not found for unknown user",
+			url:  "/playlists?userId=1",
+			body: `{"name": "Road trip"}`,
+			createFunc: func(playlist *models.Playlist) error {
+				return pgError{code: "23503"}
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newPlaylistsTestApp(&mockPlaylistRepository{createFunc: tt.createFunc})
+
+			req, _ := http.NewRequest("POST", tt.url, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestPlaylistsHandler_Access(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		expectedStatus int
+	}{
+		{
+			name:           "owner reads private playlist",
+			method:         "GET",
+			url:            "/playlists/1?userId=1",
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "hides private playlist from others",
+			method:         "GET",
+			url:            "/playlists/1?userId=3",
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:           "reads unlisted playlist by share token",
+			method:         "GET",
+			url:            "/playlists/shared/abc",
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "collaborator adds item",
+			method:         "POST",
+			url:            "/playlists/1/items?userId=2",
+			body:           `{"trackId": 11}`,
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:           "rejects item that is both album and track",
+			method:         "POST",
+			url:            "/playlists/1/items?userId=2",
+			body:           `{"albumId": 7, "trackId": 11}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "forbids collaborator to rename",
+			method:         "PUT",
+			url:            "/playlists/1?userId=2",
+			body:           `{"name": "Mine"}`,
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:           "owner renames",
+			method:         "PUT",
+			url:            "/playlists/1?userId=1",
+			body:           `{"name": "Mine", "visibility": "unlisted"}`,
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "forbids collaborator to delete",
+			method:         "DELETE",
+			url:            "/playlists/1?userId=2",
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:           "collaborator moves item",
+			method:         "PUT",
+			url:            "/playlists/1/items/5/position?userId=2",
+			body:           `{"position": 1}`,
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "rejects move to position zero",
+			method:         "PUT",
+			url:            "/playlists/1/items/5/position?userId=2",
+			body:           `{"position": 0}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "rejects owner as collaborator",
+			method:         "PUT",
+			url:            "/playlists/1/collaborators/1?userId=1",
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "collaborator leaves",
+			method:         "DELETE",
+			url:            "/playlists/1/collaborators/2?userId=2",
+			expectedStatus: fiber.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newPlaylistsTestApp(&mockPlaylistRepository{})
+
+			req, _ := http.NewRequest(tt.method, tt.url, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+// withIdentity returns middleware authenticating the requests as the subject,
+// as pkg/auth does when authentication is enabled
+func withIdentity(subject string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		ctx.SetUserContext(auth.NewContext(ctx.UserContext(), &auth.Identity{Subject: subject}))
+		return ctx.Next()
+	}
+}
+
+func TestPlaylistsHandler_AuthenticatedUser(t *testing.T) {
+	tests := []struct {
+		name           string
+		subject        string
+		method         string
+		url            string
+		body           string
+		expectedStatus int
+	}{
+		{
+			name:           "owner renames without userId",
+			subject:        "1",
+			method:         "PUT",
+			url:            "/playlists/1",
+			body:           `{"name": "Mine"}`,
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "ignores userId of another user",
+			subject:        "2",
+			method:         "PUT",
+			url:            "/playlists/1?userId=1",
+			body:           `{"name": "Mine"}`,
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:           "hides private playlist from others naming the owner",
+			subject:        "3",
+			method:         "GET",
+			url:            "/playlists/1?userId=1",
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:           "rejects playlist of a caller that is not a user",
+			subject:        "apikey:msk_abcd",
+			method:         "POST",
+			url:            "/playlists?userId=1",
+			body:           `{"name": "Road trip"}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(withIdentity(tt.subject))
+			app.Mount("/", newPlaylistsTestApp(&mockPlaylistRepository{}))
+
+			req, _ := http.NewRequest(tt.method, tt.url, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestPlaylistsHandler_ExportPlaylist(t *testing.T) {
+	tests := []struct {
+		name                string
+		url                 string
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "exports m3u",
+			url:                 "/playlists/1/export?format=m3u&userId=1",
+			expectedStatus:      fiber.StatusOK,
+			expectedContentType: "audio/x-mpegurl",
+			expectedBody:        "#EXTINF:562,Miles Davis - So What\nhttp://example.com/api/v1/albums/7/tracks/11\n",
+		},
+		{
+			name:                "exports xspf by share token",
+			url:                 "/playlists/shared/abc/export?format=xspf",
+			expectedStatus:      fiber.StatusOK,
+			expectedContentType: "application/xspf+xml",
+			expectedBody:        "<title>So What</title>",
+		},
+		{
+			name:                "exports json by default",
+			url:                 "/playlists/1/export?userId=2",
+			expectedStatus:      fiber.StatusOK,
+			expectedContentType: "application/json",
+			expectedBody:        `"TrackTitle": "So What"`,
+		},
+		{
+			name:           "rejects unknown format",
+			url:            "/playlists/1/export?format=pls&userId=1",
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "hides private playlist from anonymous user",
+			url:            "/playlists/1/export?format=m3u",
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newPlaylistsTestApp(&mockPlaylistRepository{})
+
+			req, _ := http.NewRequest("GET", "http://example.com"+tt.url, nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if resp.StatusCode == fiber.StatusOK {
+				if contentType := resp.Header.Get("Content-Type"); contentType != tt.expectedContentType {
+					t.Errorf("Expected content type %s, got %s", tt.expectedContentType, contentType)
+				}
+				body, _ := io.ReadAll(resp.Body)
+				if !strings.Contains(string(body), tt.expectedBody) {
+					t.Errorf("Expected body containing %q, got %s", tt.expectedBody, body)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package v1
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+)
+
+type pricesHandler struct {
+	prices orm.PriceRepository
+}
+
+func NewPricesHandler(prices orm.PriceRepository) *pricesHandler {
+	return &pricesHandler{
+		prices: prices,
+	}
+}
+
+// priceChange is the body of a price change. The change takes effect at
+// EffectiveAt when it is in the future and immediately otherwise.
+type priceChange struct {
+	Price       *decimal.Decimal
+	ChangedBy   string
+	EffectiveAt *time.Time
+}
+
+// @Summary Gets the prices of an album
+// @Description The price in the currency of the album comes first, followed by the prices in other currencies.
+// @ID get-prices
+// @Produce json
+// @Success 200 {array} models.Price
+// @Router /albums/{id}/prices [get]
+func (h *pricesHandler) GetPrices(ctx *fiber.Ctx) error {
+	albumId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	prices, err := h.prices.GetByAlbum(albumId)
+	if err != nil {
+		return priceError(ctx, err)
+	}
+	// An album always has a price in its own currency.
+	if len(prices) == 0 {
+		return albumNotFound(ctx)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(prices)
+}
+
+// @Summary Sets or schedules the price of an album in a currency
+// @Description The body holds the price, changedBy and an optional effectiveAt. A change with an effectiveAt in the future is scheduled and returned with 202.
+// @ID set-price
+// @Produce json
+// @Success 200 {object} models.Price
+// @Success 202 {object} models.ScheduledPrice
+// @Router /albums/{id}/prices/{currency} [put]
+func (h *pricesHandler) SetPrice(ctx *fiber.Ctx) error {
+	albumId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	currency, err := parseCurrency(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	change, err := parsePriceChange(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if change.EffectiveAt != nil && change.EffectiveAt.After(time.Now()) {
+		scheduled := &models.ScheduledPrice{
+			AlbumId:     albumId,
+			Currency:    currency,
+			Price:       *change.Price,
+			EffectiveAt: *change.EffectiveAt,
+			CreatedBy:   change.ChangedBy,
+		}
+		if err := h.prices.Schedule(scheduled); err != nil {
+			return priceError(ctx, err)
+		}
+		return ctx.Status(fiber.StatusAccepted).JSON(scheduled)
+	}
+
+	price := models.Price{AlbumId: albumId, Currency: currency, Price: *change.Price}
+	if err := h.prices.SetPrice(price, change.ChangedBy); err != nil {
+		return priceError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(price)
+}
+
+// @Summary Removes the price of an album in a currency other than its own
+// @ID remove-price
+// @Param changedBy query string true "who removes the price"
+// @Success 204
+// @Router /albums/{id}/prices/{currency} [delete]
+func (h *pricesHandler) RemovePrice(ctx *fiber.Ctx) error {
+	albumId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	currency, err := parseCurrency(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	changedBy := strings.TrimSpace(ctx.Query("changedBy"))
+	if changedBy == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "changedBy is required",
+		})
+	}
+
+	if err := h.prices.RemovePrice(albumId, currency, changedBy); err != nil {
+		if orm.IsInvalid(err) {
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "the price in the currency of the album cannot be removed",
+			})
+		}
+		return priceError(ctx, err)
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Gets the price changes of an album
+// @Description Every change of a price with when it took effect and who made it, in the order the changes took effect, optionally only in one currency. The history of deleted albums is kept.
+// @ID get-price-history
+// @Produce json
+// @Param currency query string false "ISO 4217 currency code"
+// @Success 200 {array} models.PriceChange
+// @Router /albums/{id}/prices/history [get]
+func (h *pricesHandler) GetPriceHistory(ctx *fiber.Ctx) error {
+	albumId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	currency := strings.ToUpper(strings.TrimSpace(ctx.Query("currency")))
+	if currency != "" && !models.ValidCurrency(currency) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid currency",
+		})
+	}
+
+	changes, err := h.prices.GetHistory(albumId, currency)
+	if err != nil {
+		return priceError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(changes)
+}
+
+// @Summary Gets the pending scheduled price changes of an album
+// @ID get-scheduled-prices
+// @Produce json
+// @Success 200 {array} models.ScheduledPrice
+// @Router /albums/{id}/prices/scheduled [get]
+func (h *pricesHandler) GetScheduledPrices(ctx *fiber.Ctx) error {
+	albumId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	changes, err := h.prices.GetScheduled(albumId)
+	if err != nil {
+		return priceError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(changes)
+}
+
+// @Summary Cancels a pending scheduled price change of an album
+// @ID cancel-scheduled-price
+// @Success 204
+// @Router /albums/{id}/prices/scheduled/{scheduleId} [delete]
+func (h *pricesHandler) CancelScheduledPrice(ctx *fiber.Ctx) error {
+	albumId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	scheduleId, err := ctx.ParamsInt("scheduleId")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	if err := h.prices.CancelScheduled(albumId, scheduleId); err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "scheduled price change not found",
+			})
+		}
+		return priceError(ctx, err)
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// parseCurrency returns the upper case currency of the path.
+func parseCurrency(ctx *fiber.Ctx) (string, error) {
+	currency := strings.ToUpper(ctx.Params("currency"))
+	if !models.ValidCurrency(currency) {
+		return "", errors.New("invalid currency, expected an ISO 4217 code such as USD")
+	}
+	return currency, nil
+}
+
+// parsePriceChange parses and validates the price change of the body.
+func parsePriceChange(ctx *fiber.Ctx) (priceChange, error) {
+	change := priceChange{}
+	if err := ctx.BodyParser(&change); err != nil {
+		return change, errors.New("cannot parse JSON")
+	}
+	change.ChangedBy = strings.TrimSpace(change.ChangedBy)
+	switch {
+	case change.Price == nil:
+		return change, errors.New("price is required")
+	case change.Price.IsNegative():
+		return change, errors.New("price must not be negative")
+	case change.ChangedBy == "":
+		return change, errors.New("changedBy is required")
+	}
+	return change, nil
+}
+
+func priceError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "price not found",
+		})
+	case orm.IsForeignKeyViolation(err):
+		return albumNotFound(ctx)
+	case orm.IsInvalid(err):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid price",
+		})
+	}
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "failed to access prices",
+	})
+}
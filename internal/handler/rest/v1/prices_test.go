@@ -0,0 +1,332 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+
+	"music-service/internal/models"
+)
+
+// mockPriceRepository is a mock implementation of orm.PriceRepository
+type mockPriceRepository struct {
+	getByAlbumFunc  func(albumId int) ([]*models.Price, error)
+	setPriceFunc    func(price models.Price, changedBy string) error
+	removePriceFunc func(albumId int, currency string, changedBy string) error
+	getHistoryFunc  func(albumId int, currency string) ([]*models.PriceChange, error)
+	scheduleFunc    func(change *models.ScheduledPrice) error
+	cancelFunc      func(albumId, id int) error
+}
+
+func (m *mockPriceRepository) GetByAlbum(albumId int) ([]*models.Price, error) {
+	if m.getByAlbumFunc != nil {
+		return m.getByAlbumFunc(albumId)
+	}
+	return []*models.Price{}, nil
+}
+
+func (m *mockPriceRepository) SetPrice(price models.Price, changedBy string) error {
+	if m.setPriceFunc != nil {
+		return m.setPriceFunc(price, changedBy)
+	}
+	return nil
+}
+
+func (m *mockPriceRepository) SaveAlbum(album models.Album, changedBy string) error {
+	return nil
+}
+
+func (m *mockPriceRepository) RemovePrice(albumId int, currency string, changedBy string) error {
+	if m.removePriceFunc != nil {
+		return m.removePriceFunc(albumId, currency, changedBy)
+	}
+	return nil
+}
+
+func (m *mockPriceRepository) GetHistory(albumId int, currency string) ([]*models.PriceChange, error) {
+	if m.getHistoryFunc != nil {
+		return m.getHistoryFunc(albumId, currency)
+	}
+	return []*models.PriceChange{}, nil
+}
+
+func (m *mockPriceRepository) Schedule(change *models.ScheduledPrice) error {
+	if m.scheduleFunc != nil {
+		return m.scheduleFunc(change)
+	}
+	return nil
+}
+
+func (m *mockPriceRepository) GetScheduled(albumId int) ([]*models.ScheduledPrice, error) {
+	return []*models.ScheduledPrice{}, nil
+}
+
+func (m *mockPriceRepository) CancelScheduled(albumId, id int) error {
+	if m.cancelFunc != nil {
+		return m.cancelFunc(albumId, id)
+	}
+	return nil
+}
+
+func (m *mockPriceRepository) ApplyScheduled() (int, error) {
+	return 0, nil
+}
+
+func newPricesTestApp(prices *mockPriceRepository) *fiber.App {
+	app := fiber.New()
+	handler := NewPricesHandler(prices)
+	app.Get("/albums/:id/prices", handler.GetPrices)
+	app.Get("/albums/:id/prices/history", handler.GetPriceHistory)
+	app.Get("/albums/:id/prices/scheduled", handler.GetScheduledPrices)
+	app.Delete("/albums/:id/prices/scheduled/:scheduleId", handler.CancelScheduledPrice)
+	app.Put("/albums/:id/prices/:currency", handler.SetPrice)
+	app.Delete("/albums/:id/prices/:currency", handler.RemovePrice)
+	return app
+}
+
+func TestPricesHandler_GetPrices(t *testing.T) {
+	tests := []struct {
+		name           string
+		prices         []*models.Price
+		expectedStatus int
+	}{
+		{
+			name: "returns prices of album",
+			prices: []*models.Price{
+				{AlbumId: 7, Currency: "USD", Price: decimal.NewFromFloat(56.99)},
+				{AlbumId: 7, Currency: "EUR", Price: decimal.NewFromFloat(52.99)},
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "returns not found for unknown album",
+			prices:         []*models.Price{},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newPricesTestApp(&mockPriceRepository{
+				getByAlbumFunc: func(albumId int) ([]*models.Price, error) {
+					return tt.prices, nil
+				},
+			})
+
+			req, _ := http.NewRequest("GET", "/albums/7/prices", nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestPricesHandler_SetPrice(t *testing.T) {
+	tomorrow := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		setPriceFunc   func(price models.Price, changedBy string) error
+		scheduleFunc   func(change *models.ScheduledPrice) error
+		expectedStatus int
+	}{
+		{
+			name: "sets price immediately",
+			path: "/albums/7/prices/eur",
+			body: `{"price": "52.99", "changedBy": "finance@example.com"}`,
+			setPriceFunc: func(price models.Price, changedBy string) error {
+				if price.AlbumId != 7 || price.Currency != "EUR" || price.Price.String() != "52.99" || changedBy != "finance@example.com" {
+					t.Errorf("Expected EUR 52.99 for album 7 by finance@example.com, got %v by %s", price, changedBy)
+				}
+				return nil
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name: "schedules future price",
+			path: "/albums/7/prices/USD",
+			body: `{"price": 49.99, "changedBy": "finance@example.com", "effectiveAt": "` + tomorrow + `"}`,
+			setPriceFunc: func(price models.Price, changedBy string) error {
+				t.Error("Expected a future price not to be set immediately")
+				return nil
+			},
+			scheduleFunc: func(change *models.ScheduledPrice) error {
+				if change.AlbumId != 7 || change.Currency != "USD" || change.CreatedBy != "finance@example.com" {
+					t.Errorf("Expected a USD change of album 7 by finance@example.com, got %v", change)
+				}
+				change.Id = 3
+				return nil
+			},
+			expectedStatus: fiber.StatusAccepted,
+		},
+		{
+			name:           "rejects unknown currency",
+			path:           "/albums/7/prices/EURO",
+			body:           `{"price": "52.99", "changedBy": "finance@example.com"}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "rejects missing price",
+			path:           "/albums/7/prices/EUR",
+			body:           `{"changedBy": "finance@example.com"}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "rejects negative price",
+			path:           "/albums/7/prices/EUR",
+			body:           `{"price": "-1", "changedBy": "finance@example.com"}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "rejects missing changedBy",
+			path:           "/albums/7/prices/EUR",
+			body:           `{"price": "52.99"}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "returns not found for unknown album",
+			path: "/albums/7/prices/EUR",
+			body: `{"price": "52.99", "changedBy": "finance@example.com"}`,
+			setPriceFunc: func(price models.Price, changedBy string) error {
+				return pgError{code: "23503"}
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newPricesTestApp(&mockPriceRepository{setPriceFunc: tt.setPriceFunc, scheduleFunc: tt.scheduleFunc})
+
+			req, _ := http.NewRequest("PUT", tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if resp.StatusCode == fiber.StatusAccepted {
+				scheduled := models.ScheduledPrice{}
+				json.NewDecoder(resp.Body).Decode(&scheduled)
+				if scheduled.Id != 3 {
+					t.Errorf("Expected scheduled change id 3, got %d", scheduled.Id)
+				}
+			}
+		})
+	}
+}
+
+func TestPricesHandler_RemovePrice(t *testing.T) {
+	tests := []struct {
+		name            string
+		path            string
+		removePriceFunc func(albumId int, currency string, changedBy string) error
+		expectedStatus  int
+	}{
+		{
+			name:           "removes price",
+			path:           "/albums/7/prices/EUR?changedBy=finance",
+			expectedStatus: fiber.StatusNoContent,
+		},
+		{
+			name:           "rejects missing changedBy",
+			path:           "/albums/7/prices/EUR",
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "rejects currency of album",
+			path: "/albums/7/prices/USD?changedBy=finance",
+			removePriceFunc: func(albumId int, currency string, changedBy string) error {
+				return pgError{code: "23514"}
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "returns not found for missing price",
+			path: "/albums/7/prices/JPY?changedBy=finance",
+			removePriceFunc: func(albumId int, currency string, changedBy string) error {
+				return pg.ErrNoRows
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newPricesTestApp(&mockPriceRepository{removePriceFunc: tt.removePriceFunc})
+
+			req, _ := http.NewRequest("DELETE", tt.path, nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestPricesHandler_GetPriceHistory(t *testing.T) {
+	var currency string
+	app := newPricesTestApp(&mockPriceRepository{
+		getHistoryFunc: func(albumId int, c string) ([]*models.PriceChange, error) {
+			currency = c
+			return []*models.PriceChange{
+				{Id: 1, AlbumId: albumId, Currency: "EUR", NewPrice: decimal.NewNullDecimal(decimal.NewFromFloat(52.99)), ChangedBy: "finance"},
+			}, nil
+		},
+	})
+
+	req, _ := http.NewRequest("GET", "/albums/7/prices/history?currency=eur", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Expected status %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+	if currency != "EUR" {
+		t.Errorf("Expected the history in EUR, got '%s'", currency)
+	}
+	changes := []models.PriceChange{}
+	json.NewDecoder(resp.Body).Decode(&changes)
+	if len(changes) != 1 || changes[0].OldPrice.Valid || changes[0].ChangedBy != "finance" {
+		t.Errorf("Expected the new EUR price set by finance, got %v", changes)
+	}
+}
+
+func TestPricesHandler_CancelScheduledPrice(t *testing.T) {
+	app := newPricesTestApp(&mockPriceRepository{
+		cancelFunc: func(albumId, id int) error {
+			return pg.ErrNoRows
+		},
+	})
+
+	req, _ := http.NewRequest("DELETE", "/albums/7/prices/scheduled/3", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", fiber.StatusNotFound, resp.StatusCode)
+	}
+}
@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+)
+
+type searchHandler struct {
+	repository orm.SearchRepository
+}
+
+func NewSearchHandler(repository orm.SearchRepository) *searchHandler {
+	return &searchHandler{
+		repository: repository,
+	}
+}
+
+// @Summary Searches albums by title and artist name
+// @Description Matches whole words and, for typos and partial words, similar
+// @Description titles and names. Returns at most limit albums (20 by default,
+// @Description at most 100), best first, with the matching words of the title
+// @Description and artist name, escaped for HTML, in <mark> tags.
+// @ID search-albums
+// @Produce json
+// @Success 200 {array} models.SearchResult
+// @Router /search [get]
+func (h *searchHandler) SearchAlbums(ctx *fiber.Ctx) error {
+	query := strings.TrimSpace(ctx.Query("q"))
+	if query == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "q is required",
+		})
+	}
+	limit := 0
+	if value := ctx.Query("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid limit",
+			})
+		}
+		limit = n
+	}
+
+	results, err := h.repository.Search(query, models.SearchLimit(limit))
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to search albums",
+		})
+	}
+	return ctx.Status(fiber.StatusOK).JSON(results)
+}
@@ -0,0 +1,98 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/models"
+)
+
+// mockSearchRepository is a mock implementation of orm.SearchRepository
+type mockSearchRepository struct {
+	searchFunc func(query string, limit int) ([]*models.SearchResult, error)
+}
+
+func (m *mockSearchRepository) Search(query string, limit int) ([]*models.SearchResult, error) {
+	if m.searchFunc != nil {
+		return m.searchFunc(query, limit)
+	}
+	return []*models.SearchResult{}, nil
+}
+
+func TestSearchHandler_SearchAlbums(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		searchFunc     func(query string, limit int) ([]*models.SearchResult, error)
+		expectedStatus int
+	}{
+		{
+			name: "returns matching albums",
+			path: "/search?q=gaint%20steps",
+			searchFunc: func(query string, limit int) ([]*models.SearchResult, error) {
+				if query != "gaint steps" || limit != models.DefaultSearchLimit {
+					t.Errorf("Expected a search for 'gaint steps' limited to %d, got '%s' limited to %d", models.DefaultSearchLimit, query, limit)
+				}
+				return []*models.SearchResult{{Album: models.Album{Id: 2, Title: "Giant Steps"}, Rank: 0.5, TitleHighlight: "Giant Steps"}}, nil
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name: "bounds the limit",
+			path: "/search?q=coltrane&limit=1000",
+			searchFunc: func(query string, limit int) ([]*models.SearchResult, error) {
+				if limit != models.MaxSearchLimit {
+					t.Errorf("Expected limit %d, got %d", models.MaxSearchLimit, limit)
+				}
+				return []*models.SearchResult{}, nil
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "rejects missing query",
+			path:           "/search?q=%20",
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "rejects invalid limit",
+			path:           "/search?q=coltrane&limit=ten",
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "returns internal server error on repository error",
+			path: "/search?q=coltrane",
+			searchFunc: func(query string, limit int) ([]*models.SearchResult, error) {
+				return nil, errors.New("connection refused")
+			},
+			expectedStatus: fiber.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/search", NewSearchHandler(&mockSearchRepository{searchFunc: tt.searchFunc}).SearchAlbums)
+
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if tt.name == "returns matching albums" {
+				results := []models.SearchResult{}
+				json.NewDecoder(resp.Body).Decode(&results)
+				if len(results) != 1 || results[0].Title != "Giant Steps" {
+					t.Errorf("Expected 'Giant Steps', got %v", results)
+				}
+			}
+		})
+	}
+}
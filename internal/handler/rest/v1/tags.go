@@ -0,0 +1,207 @@
+package v1
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+)
+
+type tagsHandler struct {
+	tags   orm.TagRepository
+	albums orm.Repository
+}
+
+func NewTagsHandler(tags orm.TagRepository, albums orm.Repository) *tagsHandler {
+	return &tagsHandler{
+		tags:   tags,
+		albums: albums,
+	}
+}
+
+// @Summary Creates a tag
+// @ID create-tag
+// @Produce json
+// @Success 201 {object} models.Tag
+// @Router /tags [post]
+func (h *tagsHandler) CreateTag(ctx *fiber.Ctx) error {
+	tag := &models.Tag{}
+	if err := parseTag(ctx, tag); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	tag.Id = 0
+
+	if err := h.tags.Create(tag); err != nil {
+		return tagError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(tag)
+}
+
+// @Summary Gets all tags
+// @ID get-tags
+// @Produce json
+// @Success 200 {array} models.Tag
+// @Router /tags [get]
+func (h *tagsHandler) GetTags(ctx *fiber.Ctx) error {
+	tags, err := h.tags.Get()
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to get tags",
+		})
+	}
+	return ctx.Status(fiber.StatusOK).JSON(tags)
+}
+
+// @Summary Renames a tag
+// @ID update-tag
+// @Produce json
+// @Success 200 {object} models.Tag
+// @Router /tags/{id} [put]
+func (h *tagsHandler) UpdateTag(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	tag := models.Tag{}
+	if err := parseTag(ctx, &tag); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	tag.Id = id
+
+	if err := h.tags.Update(tag); err != nil {
+		return tagError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(tag)
+}
+
+// @Summary Deletes a tag and removes it from its albums
+// @ID delete-tag
+// @Success 204
+// @Router /tags/{id} [delete]
+func (h *tagsHandler) DeleteTag(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	if err := h.tags.Delete(id); err != nil {
+		return tagError(ctx, err)
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Gets the tags of an album
+// @ID get-album-tags
+// @Produce json
+// @Success 200 {array} models.Tag
+// @Router /albums/{id}/tags [get]
+func (h *tagsHandler) GetAlbumTags(ctx *fiber.Ctx) error {
+	albumId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	if _, err := h.albums.GetById(albumId); err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return albumNotFound(ctx)
+		}
+		return tagError(ctx, err)
+	}
+	tags, err := h.tags.GetByAlbum(albumId)
+	if err != nil {
+		return tagError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(tags)
+}
+
+// @Summary Replaces the tags of an album with the tag names of the body
+// @Description Tags that do not exist yet are created.
+// @ID set-album-tags
+// @Produce json
+// @Success 200 {array} models.Tag
+// @Router /albums/{id}/tags [put]
+func (h *tagsHandler) SetAlbumTags(ctx *fiber.Ctx) error {
+	albumId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	names := []string{}
+	if err := ctx.BodyParser(&names); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse JSON",
+		})
+	}
+
+	if _, err := h.albums.GetById(albumId); err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return albumNotFound(ctx)
+		}
+		return tagError(ctx, err)
+	}
+	tags, err := h.tags.SetAlbumTags(albumId, tagNames(names))
+	if err != nil {
+		return tagError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(tags)
+}
+
+// tagNames trims the names and drops empty names and names repeated in
+// another case.
+func tagNames(names []string) []string {
+	seen := make(map[string]struct{}, len(names))
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if _, ok := seen[key]; ok || name == "" {
+			continue
+		}
+		seen[key] = struct{}{}
+		result = append(result, name)
+	}
+	return result
+}
+
+// parseTag parses and validates the tag of the body.
+func parseTag(ctx *fiber.Ctx, tag *models.Tag) error {
+	if err := ctx.BodyParser(tag); err != nil {
+		return errors.New("cannot parse JSON")
+	}
+	tag.Name = strings.TrimSpace(tag.Name)
+	if tag.Name == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
+
+func tagError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "tag not found",
+		})
+	case orm.IsForeignKeyViolation(err):
+		return albumNotFound(ctx)
+	case orm.IsConflict(err):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "tag already exists",
+		})
+	case orm.IsInvalid(err):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid tag",
+		})
+	}
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "failed to access tags",
+	})
+}
@@ -0,0 +1,143 @@
+package v1
+
+import (
+	"bytes"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/models"
+)
+
+// mockTagRepository is a mock implementation of orm.TagRepository
+type mockTagRepository struct {
+	createFunc       func(tag *models.Tag) error
+	updateFunc       func(tag models.Tag) error
+	setAlbumTagsFunc func(albumId int, names []string) ([]*models.Tag, error)
+}
+
+func (m *mockTagRepository) Create(tag *models.Tag) error {
+	if m.createFunc != nil {
+		return m.createFunc(tag)
+	}
+	return nil
+}
+
+func (m *mockTagRepository) Get() ([]*models.Tag, error) {
+	return []*models.Tag{}, nil
+}
+
+func (m *mockTagRepository) Update(tag models.Tag) error {
+	if m.updateFunc != nil {
+		return m.updateFunc(tag)
+	}
+	return nil
+}
+
+func (m *mockTagRepository) Delete(id int) error {
+	return nil
+}
+
+func (m *mockTagRepository) GetByAlbum(albumId int) ([]*models.Tag, error) {
+	return []*models.Tag{}, nil
+}
+
+func (m *mockTagRepository) SetAlbumTags(albumId int, names []string) ([]*models.Tag, error) {
+	if m.setAlbumTagsFunc != nil {
+		return m.setAlbumTagsFunc(albumId, names)
+	}
+	return []*models.Tag{}, nil
+}
+
+func newTagsTestApp(tags *mockTagRepository, albums *mockRepository) *fiber.App {
+	app := fiber.New()
+	handler := NewTagsHandler(tags, albums)
+	app.Post("/tags", handler.CreateTag)
+	app.Get("/tags", handler.GetTags)
+	app.Put("/tags/:id", handler.UpdateTag)
+	app.Delete("/tags/:id", handler.DeleteTag)
+	app.Get("/albums/:id/tags", handler.GetAlbumTags)
+	app.Put("/albums/:id/tags", handler.SetAlbumTags)
+	return app
+}
+
+func TestTagsHandler_CreateTag(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		createFunc     func(tag *models.Tag) error
+		expectedStatus int
+	}{
+		{
+			name: "creates tag",
+			body: `{"name": " live "}`,
+			createFunc: func(tag *models.Tag) error {
+				if tag.Name != "live" {
+					t.Errorf("Expected trimmed name 'live', got '%s'", tag.Name)
+				}
+				return nil
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:           "rejects missing name",
+			body:           `{"name": " "}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "rejects duplicate tag",
+			body: `{"name": "Live"}`,
+			createFunc: func(tag *models.Tag) error {
+				return pgError{code: "23505"}
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTagsTestApp(&mockTagRepository{createFunc: tt.createFunc}, &mockRepository{})
+
+			req, _ := http.NewRequest("POST", "/tags", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestTagsHandler_SetAlbumTags(t *testing.T) {
+	var set []string
+	app := newTagsTestApp(&mockTagRepository{
+		setAlbumTagsFunc: func(albumId int, names []string) ([]*models.Tag, error) {
+			set = names
+			return []*models.Tag{{Id: 4, Name: "live"}}, nil
+		},
+	}, &mockRepository{
+		getByIdFunc: func(id int) (*models.Album, error) {
+			return &models.Album{Id: id}, nil
+		},
+	})
+
+	req, _ := http.NewRequest("PUT", "/albums/7/tags", bytes.NewBufferString(`[" live ", "Live", "", "remastered"]`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Expected status %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+	if !reflect.DeepEqual(set, []string{"live", "remastered"}) {
+		t.Errorf("Expected the tags 'live' and 'remastered', got %v", set)
+	}
+}
@@ -0,0 +1,179 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+)
+
+type tracksHandler struct {
+	albums orm.Repository
+	tracks orm.TrackRepository
+}
+
+func NewTracksHandler(albums orm.Repository, tracks orm.TrackRepository) *tracksHandler {
+	return &tracksHandler{
+		albums: albums,
+		tracks: tracks,
+	}
+}
+
+// @Summary Gets the tracks of an album
+// @ID get-tracks
+// @Produce json
+// @Success 200 {array} models.Track
+// @Router /albums/{id}/tracks [get]
+func (h *tracksHandler) GetTracks(ctx *fiber.Ctx) error {
+	albumId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	if _, err := h.albums.GetById(albumId); err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return albumNotFound(ctx)
+		}
+		return trackError(ctx, err)
+	}
+	tracks, err := h.tracks.GetByAlbum(albumId)
+	if err != nil {
+		return trackError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(tracks)
+}
+
+// @Summary Adds a track to an album
+// @ID create-track
+// @Produce json
+// @Success 201 {object} models.Track
+// @Router /albums/{id}/tracks [post]
+func (h *tracksHandler) CreateTrack(ctx *fiber.Ctx) error {
+	albumId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	track := &models.Track{}
+	if err := parseTrack(ctx, track); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	track.Id = 0
+	track.AlbumId = albumId
+
+	if err := h.tracks.Create(track); err != nil {
+		return trackError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(track)
+}
+
+// @Summary Gets a track of an album
+// @ID get-track
+// @Produce json
+// @Success 200 {object} models.Track
+// @Router /albums/{id}/tracks/{trackId} [get]
+func (h *tracksHandler) GetTrack(ctx *fiber.Ctx) error {
+	albumId, trackId, err := trackIds(ctx)
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	track, err := h.tracks.GetById(albumId, trackId)
+	if err != nil {
+		return trackError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(track)
+}
+
+// @Summary Updates a track of an album
+// @ID update-track
+// @Produce json
+// @Success 200 {object} models.Track
+// @Router /albums/{id}/tracks/{trackId} [put]
+func (h *tracksHandler) UpdateTrack(ctx *fiber.Ctx) error {
+	albumId, trackId, err := trackIds(ctx)
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	track := models.Track{}
+	if err := parseTrack(ctx, &track); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	track.Id = trackId
+	track.AlbumId = albumId
+
+	if err := h.tracks.Update(track); err != nil {
+		return trackError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(track)
+}
+
+// @Summary Removes a track from an album
+// @ID delete-track
+// @Success 204
+// @Router /albums/{id}/tracks/{trackId} [delete]
+func (h *tracksHandler) DeleteTrack(ctx *fiber.Ctx) error {
+	albumId, trackId, err := trackIds(ctx)
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	if err := h.tracks.Delete(albumId, trackId); err != nil {
+		return trackError(ctx, err)
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func trackIds(ctx *fiber.Ctx) (int, int, error) {
+	albumId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return 0, 0, err
+	}
+	trackId, err := ctx.ParamsInt("trackId")
+	return albumId, trackId, err
+}
+
+// parseTrack parses, normalizes and validates the track of the body.
+func parseTrack(ctx *fiber.Ctx, track *models.Track) error {
+	if err := ctx.BodyParser(track); err != nil {
+		return errors.New("cannot parse JSON")
+	}
+	track.Normalize()
+	return track.Validate()
+}
+
+func albumNotFound(ctx *fiber.Ctx) error {
+	return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+		"error": "album not found",
+	})
+}
+
+func trackError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "track not found",
+		})
+	case orm.IsForeignKeyViolation(err):
+		return albumNotFound(ctx)
+	case orm.IsConflict(err):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "the album already has a track with this disc and number",
+		})
+	case orm.IsInvalid(err):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid track",
+		})
+	}
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "failed to access tracks",
+	})
+}
@@ -0,0 +1,266 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/models"
+)
+
+// mockTrackRepository is a mock implementation of orm.TrackRepository
+type mockTrackRepository struct {
+	createFunc     func(track *models.Track) error
+	getByIdFunc    func(albumId, id int) (*models.Track, error)
+	getByAlbumFunc func(albumId int) ([]*models.Track, error)
+	updateFunc     func(track models.Track) error
+	deleteFunc     func(albumId, id int) error
+}
+
+func (m *mockTrackRepository) Create(track *models.Track) error {
+	if m.createFunc != nil {
+		return m.createFunc(track)
+	}
+	return nil
+}
+
+func (m *mockTrackRepository) GetById(albumId, id int) (*models.Track, error) {
+	if m.getByIdFunc != nil {
+		return m.getByIdFunc(albumId, id)
+	}
+	return &models.Track{Id: id, AlbumId: albumId}, nil
+}
+
+func (m *mockTrackRepository) GetByAlbum(albumId int) ([]*models.Track, error) {
+	if m.getByAlbumFunc != nil {
+		return m.getByAlbumFunc(albumId)
+	}
+	return []*models.Track{}, nil
+}
+
+func (m *mockTrackRepository) Get() ([]*models.Track, error) {
+	return []*models.Track{}, nil
+}
+
+func (m *mockTrackRepository) Update(track models.Track) error {
+	if m.updateFunc != nil {
+		return m.updateFunc(track)
+	}
+	return nil
+}
+
+func (m *mockTrackRepository) Delete(albumId, id int) error {
+	if m.deleteFunc != nil {
+		return m.deleteFunc(albumId, id)
+	}
+	return nil
+}
+
+func (m *mockTrackRepository) Save(albumId int, tracks []models.Track, removed []models.Track) error {
+	return nil
+}
+
+func newTracksTestApp(albums *mockRepository, tracks *mockTrackRepository) *fiber.App {
+	app := fiber.New()
+	handler := NewTracksHandler(albums, tracks)
+	app.Get("/albums/:id/tracks", handler.GetTracks)
+	app.Post("/albums/:id/tracks", handler.CreateTrack)
+	app.Get("/albums/:id/tracks/:trackId", handler.GetTrack)
+	app.Put("/albums/:id/tracks/:trackId", handler.UpdateTrack)
+	app.Delete("/albums/:id/tracks/:trackId", handler.DeleteTrack)
+	return app
+}
+
+func TestTracksHandler_GetTracks(t *testing.T) {
+	tests := []struct {
+		name           string
+		getByIdFunc    func(id int) (*models.Album, error)
+		expectedStatus int
+	}{
+		{
+			name: "returns tracks of album",
+			getByIdFunc: func(id int) (*models.Album, error) {
+				return &models.Album{Id: id}, nil
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name: "returns not found for unknown album",
+			getByIdFunc: func(id int) (*models.Album, error) {
+				return nil, pg.ErrNoRows
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTracksTestApp(&mockRepository{getByIdFunc: tt.getByIdFunc}, &mockTrackRepository{
+				getByAlbumFunc: func(albumId int) ([]*models.Track, error) {
+					return []*models.Track{{Id: 1, AlbumId: albumId, Disc: 1, Number: 1, Title: "Blue Train"}}, nil
+				},
+			})
+
+			req, _ := http.NewRequest("GET", "/albums/7/tracks", nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if resp.StatusCode == fiber.StatusOK {
+				tracks := []models.Track{}
+				json.NewDecoder(resp.Body).Decode(&tracks)
+				if len(tracks) != 1 || tracks[0].AlbumId != 7 {
+					t.Errorf("Expected the track of album 7, got %v", tracks)
+				}
+			}
+		})
+	}
+}
+
+func TestTracksHandler_CreateTrack(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		createFunc     func(track *models.Track) error
+		expectedStatus int
+	}{
+		{
+			name: "creates track",
+			body: `{"number": 1, "title": " Blue Train ", "durationMs": 643000, "isrc": "us-bn1-57-00001"}`,
+			createFunc: func(track *models.Track) error {
+				if track.AlbumId != 7 || track.Disc != 1 || track.Title != "Blue Train" || track.Isrc != "USBN15700001" {
+					t.Errorf("Expected a normalized track of album 7, got %v", track)
+				}
+				track.Id = 3
+				return nil
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:           "rejects missing title",
+			body:           `{"number": 1}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "rejects invalid JSON",
+			body:           `{"number":`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "rejects taken position",
+			body: `{"number": 1, "title": "Blue Train"}`,
+			createFunc: func(track *models.Track) error {
+				return pgError{code: "23505"}
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name: "returns not found for unknown album",
+			body: `{"number": 1, "title": "Blue Train"}`,
+			createFunc: func(track *models.Track) error {
+				return pgError{code: "23503"}
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTracksTestApp(&mockRepository{}, &mockTrackRepository{createFunc: tt.createFunc})
+
+			req, _ := http.NewRequest("POST", "/albums/7/tracks", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if resp.StatusCode == fiber.StatusCreated {
+				track := models.Track{}
+				json.NewDecoder(resp.Body).Decode(&track)
+				if track.Id != 3 {
+					t.Errorf("Expected created track id 3, got %d", track.Id)
+				}
+			}
+		})
+	}
+}
+
+func TestTracksHandler_UpdateTrack(t *testing.T) {
+	var updated models.Track
+	app := newTracksTestApp(&mockRepository{}, &mockTrackRepository{
+		updateFunc: func(track models.Track) error {
+			updated = track
+			return nil
+		},
+	})
+
+	req, _ := http.NewRequest("PUT", "/albums/7/tracks/3", bytes.NewBufferString(`{"id": 9, "albumId": 8, "number": 2, "title": "Moment's Notice"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Expected status %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+	if updated.Id != 3 || updated.AlbumId != 7 {
+		t.Errorf("Expected the ids of the path, got track %d of album %d", updated.Id, updated.AlbumId)
+	}
+}
+
+func TestTracksHandler_DeleteTrack(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		deleteFunc     func(albumId, id int) error
+		expectedStatus int
+	}{
+		{
+			name:           "deletes track",
+			path:           "/albums/7/tracks/3",
+			expectedStatus: fiber.StatusNoContent,
+		},
+		{
+			name: "returns not found for unknown track",
+			path: "/albums/7/tracks/4",
+			deleteFunc: func(albumId, id int) error {
+				return pg.ErrNoRows
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:           "rejects invalid track id",
+			path:           "/albums/7/tracks/first",
+			expectedStatus: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTracksTestApp(&mockRepository{}, &mockTrackRepository{deleteFunc: tt.deleteFunc})
+
+			req, _ := http.NewRequest("DELETE", tt.path, nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
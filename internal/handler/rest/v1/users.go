@@ -0,0 +1,418 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/authz"
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+)
+
+type usersHandler struct {
+	users     orm.UserRepository
+	reviews   orm.ReviewRepository
+	wishlists orm.WishlistRepository
+	albums    orm.Repository
+}
+
+func NewUsersHandler(users orm.UserRepository, reviews orm.ReviewRepository, wishlists orm.WishlistRepository, albums orm.Repository) *usersHandler {
+	return &usersHandler{
+		users:     users,
+		reviews:   reviews,
+		wishlists: wishlists,
+		albums:    albums,
+	}
+}
+
+// moderationRequest is the body of a review moderation.
+type moderationRequest struct {
+	Status string
+}
+
+// @Summary Creates a user
+// @Description The currency is USD by default. Price drops of wishlisted albums are notified in it.
+// @ID create-user
+// @Produce json
+// @Success 201 {object} models.User
+// @Router /users [post]
+func (h *usersHandler) CreateUser(ctx *fiber.Ctx) error {
+	user := &models.User{}
+	if err := ctx.BodyParser(user); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse JSON",
+		})
+	}
+	user.Id = 0
+	user.Normalize()
+	if err := user.Validate(); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := h.users.Create(user); err != nil {
+		return userError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(user)
+}
+
+// @Summary Gets a user
+// @ID get-user
+// @Produce json
+// @Success 200 {object} models.User
+// @Router /users/{id} [get]
+func (h *usersHandler) GetUser(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	user, err := h.users.GetById(id)
+	if err != nil {
+		return userError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(user)
+}
+
+// @Summary Rates and reviews an album, replacing the review of the user
+// @Description A review with a text is pending until it is moderated, a rating without one is approved. The ratings of all but rejected reviews count towards the average rating of the album.
+// @ID set-review
+// @Produce json
+// @Success 200 {object} models.Review
+// @Router /albums/{id}/reviews/{userId} [put]
+func (h *usersHandler) SetReview(ctx *fiber.Ctx) error {
+	albumId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	userId, err := ctx.ParamsInt("userId")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	if !authz.IsUser(ctx.UserContext(), userId) {
+		return otherUser(ctx)
+	}
+
+	review := &models.Review{}
+	if err := ctx.BodyParser(review); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse JSON",
+		})
+	}
+	review.UserId = userId
+	review.AlbumId = albumId
+	review.Status = ""
+	review.Normalize()
+	if err := review.Validate(); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := h.reviews.Set(review); err != nil {
+		return reviewError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(review)
+}
+
+// @Summary Gets the reviews of an album in a status
+// @Description The status is approved by default.
+// @ID get-album-reviews
+// @Produce json
+// @Success 200 {array} models.Review
+// @Router /albums/{id}/reviews [get]
+func (h *usersHandler) GetAlbumReviews(ctx *fiber.Ctx) error {
+	albumId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	status := ctx.Query("status", models.ReviewApproved)
+	if status != models.ReviewPending && !models.ValidReviewStatus(status) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "status must be one of pending, approved or rejected",
+		})
+	}
+
+	if _, err := h.albums.GetById(albumId); err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return albumNotFound(ctx)
+		}
+		return reviewError(ctx, err)
+	}
+	reviews, err := h.reviews.GetByAlbum(albumId, status)
+	if err != nil {
+		return reviewError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(reviews)
+}
+
+// @Summary Approves or rejects a review
+// @ID moderate-review
+// @Produce json
+// @Success 200 {object} models.Review
+// @Router /albums/{id}/reviews/{userId}/status [put]
+func (h *usersHandler) ModerateReview(ctx *fiber.Ctx) error {
+	albumId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	userId, err := ctx.ParamsInt("userId")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	request := moderationRequest{}
+	if err := ctx.BodyParser(&request); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "cannot parse JSON",
+		})
+	}
+	if !models.ValidReviewStatus(request.Status) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "status must be approved or rejected",
+		})
+	}
+
+	review, err := h.reviews.Moderate(userId, albumId, request.Status)
+	if err != nil {
+		return reviewError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(review)
+}
+
+// @Summary Deletes a review
+// @ID delete-review
+// @Produce json
+// @Success 204
+// @Router /albums/{id}/reviews/{userId} [delete]
+func (h *usersHandler) DeleteReview(ctx *fiber.Ctx) error {
+	albumId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	userId, err := ctx.ParamsInt("userId")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	if !authz.IsUser(ctx.UserContext(), userId) {
+		return otherUser(ctx)
+	}
+
+	if err := h.reviews.Delete(userId, albumId); err != nil {
+		return reviewError(ctx, err)
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Gets the reviews of a user in every status
+// @ID get-user-reviews
+// @Produce json
+// @Success 200 {array} models.Review
+// @Router /users/{id}/reviews [get]
+func (h *usersHandler) GetUserReviews(ctx *fiber.Ctx) error {
+	userId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+
+	if _, err := h.users.GetById(userId); err != nil {
+		return userError(ctx, err)
+	}
+	reviews, err := h.reviews.GetByUser(userId)
+	if err != nil {
+		return reviewError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(reviews)
+}
+
+// @Summary Adds an album to the wishlist of a user
+// @Description The user is notified when the price of the album drops in their currency.
+// @ID add-to-wishlist
+// @Produce json
+// @Success 204
+// @Router /users/{id}/wishlist/{albumId} [put]
+func (h *usersHandler) AddToWishlist(ctx *fiber.Ctx) error {
+	userId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	albumId, err := ctx.ParamsInt("albumId")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	if !authz.IsUser(ctx.UserContext(), userId) {
+		return otherUser(ctx)
+	}
+
+	if err := h.wishlists.Add(userId, albumId); err != nil {
+		return wishlistError(ctx, err)
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Removes an album from the wishlist of a user
+// @ID remove-from-wishlist
+// @Produce json
+// @Success 204
+// @Router /users/{id}/wishlist/{albumId} [delete]
+func (h *usersHandler) RemoveFromWishlist(ctx *fiber.Ctx) error {
+	userId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	albumId, err := ctx.ParamsInt("albumId")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	if !authz.IsUser(ctx.UserContext(), userId) {
+		return otherUser(ctx)
+	}
+
+	if err := h.wishlists.Remove(userId, albumId); err != nil {
+		return wishlistError(ctx, err)
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Gets the albums on the wishlist of a user
+// @ID get-wishlist
+// @Produce json
+// @Success 200 {array} models.Album
+// @Router /users/{id}/wishlist [get]
+func (h *usersHandler) GetWishlist(ctx *fiber.Ctx) error {
+	userId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	if !authz.IsUser(ctx.UserContext(), userId) {
+		return otherUser(ctx)
+	}
+
+	if _, err := h.users.GetById(userId); err != nil {
+		return userError(ctx, err)
+	}
+	albums, err := h.wishlists.GetAlbums(userId)
+	if err != nil {
+		return wishlistError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(albums)
+}
+
+// @Summary Gets the price drop notifications of a user
+// @Description With unread=true only the notifications not yet read are returned.
+// @ID get-notifications
+// @Produce json
+// @Success 200 {array} models.PriceDropNotification
+// @Router /users/{id}/notifications [get]
+func (h *usersHandler) GetNotifications(ctx *fiber.Ctx) error {
+	userId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	if !authz.IsUser(ctx.UserContext(), userId) {
+		return otherUser(ctx)
+	}
+
+	if _, err := h.users.GetById(userId); err != nil {
+		return userError(ctx, err)
+	}
+	notifications, err := h.wishlists.GetNotifications(userId, ctx.QueryBool("unread"))
+	if err != nil {
+		return notificationError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(notifications)
+}
+
+// @Summary Marks a price drop notification read
+// @ID mark-notification-read
+// @Produce json
+// @Success 200 {object} models.PriceDropNotification
+// @Router /users/{id}/notifications/{notificationId}/read [post]
+func (h *usersHandler) MarkNotificationRead(ctx *fiber.Ctx) error {
+	userId, err := ctx.ParamsInt("id")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	id, err := ctx.ParamsInt("notificationId")
+	if err != nil {
+		return invalidId(ctx)
+	}
+	if !authz.IsUser(ctx.UserContext(), userId) {
+		return otherUser(ctx)
+	}
+
+	notification, err := h.wishlists.MarkRead(userId, int64(id))
+	if err != nil {
+		return notificationError(ctx, err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(notification)
+}
+
+// otherUser answers the requests of a user acting as another user.
+func otherUser(ctx *fiber.Ctx) error {
+	return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
+		"error": "cannot act as another user",
+	})
+}
+
+func userError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "user not found",
+		})
+	case orm.IsConflict(err):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "email is already taken",
+		})
+	}
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "failed to access users",
+	})
+}
+
+func reviewError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "review not found",
+		})
+	case orm.IsForeignKeyViolation(err):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "user or album not found",
+		})
+	}
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "failed to access reviews",
+	})
+}
+
+func wishlistError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, pg.ErrNoRows):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "album is not on the wishlist",
+		})
+	case orm.IsForeignKeyViolation(err):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "user or album not found",
+		})
+	}
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "failed to access wishlist",
+	})
+}
+
+func notificationError(ctx *fiber.Ctx, err error) error {
+	if errors.Is(err, pg.ErrNoRows) {
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "notification not found",
+		})
+	}
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "failed to access notifications",
+	})
+}
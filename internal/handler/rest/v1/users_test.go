@@ -0,0 +1,576 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/models"
+)
+
+// mockUserRepository is a mock implementation of orm.UserRepository
+type mockUserRepository struct {
+	createFunc  func(user *models.User) error
+	getByIdFunc func(id int) (*models.User, error)
+}
+
+func (m *mockUserRepository) Create(user *models.User) error {
+	if m.createFunc != nil {
+		return m.createFunc(user)
+	}
+	user.Id = 1
+	return nil
+}
+
+func (m *mockUserRepository) GetById(id int) (*models.User, error) {
+	if m.getByIdFunc != nil {
+		return m.getByIdFunc(id)
+	}
+	return &models.User{Id: id}, nil
+}
+
+// mockReviewRepository is a mock implementation of orm.ReviewRepository
+type mockReviewRepository struct {
+	setFunc        func(review *models.Review) error
+	getByAlbumFunc func(albumId int, status string) ([]*models.Review, error)
+	moderateFunc   func(userId, albumId int, status string) (*models.Review, error)
+	deleteFunc     func(userId, albumId int) error
+}
+
+func (m *mockReviewRepository) Set(review *models.Review) error {
+	if m.setFunc != nil {
+		return m.setFunc(review)
+	}
+	return nil
+}
+
+func (m *mockReviewRepository) GetByAlbum(albumId int, status string) ([]*models.Review, error) {
+	if m.getByAlbumFunc != nil {
+		return m.getByAlbumFunc(albumId, status)
+	}
+	return []*models.Review{}, nil
+}
+
+func (m *mockReviewRepository) GetByUser(userId int) ([]*models.Review, error) {
+	return []*models.Review{}, nil
+}
+
+func (m *mockReviewRepository) Moderate(userId, albumId int, status string) (*models.Review, error) {
+	if m.moderateFunc != nil {
+		return m.moderateFunc(userId, albumId, status)
+	}
+	return &models.Review{UserId: userId, AlbumId: albumId, Status: status}, nil
+}
+
+func (m *mockReviewRepository) Delete(userId, albumId int) error {
+	if m.deleteFunc != nil {
+		return m.deleteFunc(userId, albumId)
+	}
+	return nil
+}
+
+// mockWishlistRepository is a mock implementation of orm.WishlistRepository
+type mockWishlistRepository struct {
+	addFunc              func(userId, albumId int) error
+	removeFunc           func(userId, albumId int) error
+	getNotificationsFunc func(userId int, unreadOnly bool) ([]*models.PriceDropNotification, error)
+	markReadFunc         func(userId int, id int64) (*models.PriceDropNotification, error)
+}
+
+func (m *mockWishlistRepository) Add(userId, albumId int) error {
+	if m.addFunc != nil {
+		return m.addFunc(userId, albumId)
+	}
+	return nil
+}
+
+func (m *mockWishlistRepository) Remove(userId, albumId int) error {
+	if m.removeFunc != nil {
+		return m.removeFunc(userId, albumId)
+	}
+	return nil
+}
+
+func (m *mockWishlistRepository) GetAlbums(userId int) ([]*models.Album, error) {
+	return []*models.Album{}, nil
+}
+
+func (m *mockWishlistRepository) GetNotifications(userId int, unreadOnly bool) ([]*models.PriceDropNotification, error) {
+	if m.getNotificationsFunc != nil {
+		return m.getNotificationsFunc(userId, unreadOnly)
+	}
+	return []*models.PriceDropNotification{}, nil
+}
+
+func (m *mockWishlistRepository) MarkRead(userId int, id int64) (*models.PriceDropNotification, error) {
+	if m.markReadFunc != nil {
+		return m.markReadFunc(userId, id)
+	}
+	return &models.PriceDropNotification{Id: id, UserId: userId}, nil
+}
+
+func newUsersTestApp(users *mockUserRepository, reviews *mockReviewRepository, wishlists *mockWishlistRepository, albums *mockRepository) *fiber.App {
+	app := fiber.New()
+	handler := NewUsersHandler(users, reviews, wishlists, albums)
+	app.Post("/users", handler.CreateUser)
+	app.Get("/users/:id", handler.GetUser)
+	app.Get("/users/:id/reviews", handler.GetUserReviews)
+	app.Get("/users/:id/wishlist", handler.GetWishlist)
+	app.Put("/users/:id/wishlist/:albumId", handler.AddToWishlist)
+	app.Delete("/users/:id/wishlist/:albumId", handler.RemoveFromWishlist)
+	app.Get("/users/:id/notifications", handler.GetNotifications)
+	app.Post("/users/:id/notifications/:notificationId/read", handler.MarkNotificationRead)
+	app.Get("/albums/:id/reviews", handler.GetAlbumReviews)
+	app.Put("/albums/:id/reviews/:userId", handler.SetReview)
+	app.Delete("/albums/:id/reviews/:userId", handler.DeleteReview)
+	app.Put("/albums/:id/reviews/:userId/status", handler.ModerateReview)
+	return app
+}
+
+func TestUsersHandler_CreateUser(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		createFunc     func(user *models.User) error
+		expectedStatus int
+	}{
+		{
+			name:           "creates user in default currency",
+			body:           `{"name": " Ada ", "email": "ada@example.com"}`,
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:           "rejects invalid email",
+			body:           `{"name": "Ada", "email": "Ada <ada@example.com>"}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "rejects invalid currency",
+			body:           `{"name": "Ada", "email": "ada@example.com", "currency": "EURO"}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "rejects taken email",
+			body: `{"name": "Ada", "email": "ada@example.com"}`,
+			createFunc: func(user *models.User) error {
+				return pgError{code: "23505"}
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newUsersTestApp(&mockUserRepository{createFunc: tt.createFunc}, &mockReviewRepository{}, &mockWishlistRepository{}, &mockRepository{})
+
+			req, _ := http.NewRequest("POST", "/users", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if resp.StatusCode == fiber.StatusCreated {
+				var user map[string]interface{}
+				json.NewDecoder(resp.Body).Decode(&user)
+				if user["Name"] != "Ada" || user["Currency"] != models.DefaultCurrency {
+					t.Errorf("Expected normalized user, got %v", user)
+				}
+			}
+		})
+	}
+}
+
+func TestUsersHandler_SetReview(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		body           string
+		setFunc        func(review *models.Review) error
+		expectedStatus int
+	}{
+		{
+			name: "sets review",
+			url:  "/albums/7/reviews/1",
+			body: `{"rating": 4, "body": " Great ", "status": "approved"}`,
+			setFunc: func(review *models.Review) error {
+				if review.UserId != 1 || review.AlbumId != 7 || review.Body != "Great" || review.Status != "" {
+					t.Errorf("Expected normalized review of album 7 by user 1 without status, got %+v", review)
+				}
+				review.Status = models.ReviewPending
+				return nil
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "rejects rating out of range",
+			url:            "/albums/7/reviews/1",
+			body:           `{"rating": 6}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "rejects invalid user id",
+			url:            "/albums/7/reviews/abc",
+			body:           `{"rating": 3}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "returns not found for unknown user or album",
+			url:  "/albums/7/reviews/1",
+			body: `{"rating": 3}`,
+			setFunc: func(review *models.Review) error {
+				return pgError{code: "23503"}
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newUsersTestApp(&mockUserRepository{}, &mockReviewRepository{setFunc: tt.setFunc}, &mockWishlistRepository{}, &mockRepository{})
+
+			req, _ := http.NewRequest("PUT", tt.url, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestUsersHandler_GetAlbumReviews(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		getByIdFunc    func(id int) (*models.Album, error)
+		expectedStatus int
+		expectedQuery  string
+	}{
+		{
+			name:           "gets approved reviews by default",
+			url:            "/albums/7/reviews",
+			expectedStatus: fiber.StatusOK,
+			expectedQuery:  models.ReviewApproved,
+		},
+		{
+			name:           "gets pending reviews",
+			url:            "/albums/7/reviews?status=pending",
+			expectedStatus: fiber.StatusOK,
+			expectedQuery:  models.ReviewPending,
+		},
+		{
+			name:           "rejects invalid status",
+			url:            "/albums/7/reviews?status=spam",
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "returns not found for unknown album",
+			url:  "/albums/7/reviews",
+			getByIdFunc: func(id int) (*models.Album, error) {
+				return nil, pg.ErrNoRows
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			albums := &mockRepository{getByIdFunc: tt.getByIdFunc}
+			reviews := &mockReviewRepository{getByAlbumFunc: func(albumId int, status string) ([]*models.Review, error) {
+				if status != tt.expectedQuery {
+					t.Errorf("Expected status %q, got %q", tt.expectedQuery, status)
+				}
+				return []*models.Review{}, nil
+			}}
+			app := newUsersTestApp(&mockUserRepository{}, reviews, &mockWishlistRepository{}, albums)
+
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestUsersHandler_ModerateReview(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		moderateFunc   func(userId, albumId int, status string) (*models.Review, error)
+		expectedStatus int
+	}{
+		{
+			name:           "approves review",
+			body:           `{"status": "approved"}`,
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "rejects moderation back to pending",
+			body:           `{"status": "pending"}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "returns not found for unknown review",
+			body: `{"status": "rejected"}`,
+			moderateFunc: func(userId, albumId int, status string) (*models.Review, error) {
+				return nil, pg.ErrNoRows
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newUsersTestApp(&mockUserRepository{}, &mockReviewRepository{moderateFunc: tt.moderateFunc}, &mockWishlistRepository{}, &mockRepository{})
+
+			req, _ := http.NewRequest("PUT", "/albums/7/reviews/1/status", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestUsersHandler_Wishlist(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		addFunc        func(userId, albumId int) error
+		removeFunc     func(userId, albumId int) error
+		expectedStatus int
+	}{
+		{
+			name:           "adds album",
+			method:         "PUT",
+			url:            "/users/1/wishlist/7",
+			expectedStatus: fiber.StatusNoContent,
+		},
+		{
+			name:   "returns not found when adding unknown album",
+			method: "PUT",
+			url:    "/users/1/wishlist/7",
+			addFunc: func(userId, albumId int) error {
+				return pgError{code: "23503"}
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:           "removes album",
+			method:         "DELETE",
+			url:            "/users/1/wishlist/7",
+			expectedStatus: fiber.StatusNoContent,
+		},
+		{
+			name:   "returns not found when removing album not on wishlist",
+			method: "DELETE",
+			url:    "/users/1/wishlist/7",
+			removeFunc: func(userId, albumId int) error {
+				return pg.ErrNoRows
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:           "rejects invalid album id",
+			method:         "PUT",
+			url:            "/users/1/wishlist/abc",
+			expectedStatus: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wishlists := &mockWishlistRepository{addFunc: tt.addFunc, removeFunc: tt.removeFunc}
+			app := newUsersTestApp(&mockUserRepository{}, &mockReviewRepository{}, wishlists, &mockRepository{})
+
+			req, _ := http.NewRequest(tt.method, tt.url, nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestUsersHandler_Notifications(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		getByIdFunc    func(id int) (*models.User, error)
+		markReadFunc   func(userId int, id int64) (*models.PriceDropNotification, error)
+		expectedStatus int
+	}{
+		{
+			name:           "gets unread notifications",
+			method:         "GET",
+			url:            "/users/1/notifications?unread=true",
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:   "returns not found for unknown user",
+			method: "GET",
+			url:    "/users/1/notifications",
+			getByIdFunc: func(id int) (*models.User, error) {
+				return nil, pg.ErrNoRows
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:           "marks notification read",
+			method:         "POST",
+			url:            "/users/1/notifications/5/read",
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:   "returns not found for notification of another user",
+			method: "POST",
+			url:    "/users/1/notifications/5/read",
+			markReadFunc: func(userId int, id int64) (*models.PriceDropNotification, error) {
+				return nil, pg.ErrNoRows
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wishlists := &mockWishlistRepository{
+				markReadFunc: tt.markReadFunc,
+				getNotificationsFunc: func(userId int, unreadOnly bool) ([]*models.PriceDropNotification, error) {
+					if !unreadOnly {
+						t.Errorf("Expected unread notifications only")
+					}
+					return []*models.PriceDropNotification{}, nil
+				},
+			}
+			app := newUsersTestApp(&mockUserRepository{getByIdFunc: tt.getByIdFunc}, &mockReviewRepository{}, wishlists, &mockRepository{})
+
+			req, _ := http.NewRequest(tt.method, tt.url, nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestUsersHandler_AuthenticatedUser(t *testing.T) {
+	tests := []struct {
+		name           string
+		subject        string
+		method         string
+		url            string
+		body           string
+		expectedStatus int
+	}{
+		{
+			name:           "reads own wishlist",
+			subject:        "1",
+			method:         "GET",
+			url:            "/users/1/wishlist",
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "forbids wishlist of another user",
+			subject:        "2",
+			method:         "GET",
+			url:            "/users/1/wishlist",
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:           "forbids adding to wishlist of another user",
+			subject:        "2",
+			method:         "PUT",
+			url:            "/users/1/wishlist/7",
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:           "forbids notifications of another user",
+			subject:        "2",
+			method:         "GET",
+			url:            "/users/1/notifications",
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:           "forbids notifications to a caller that is not a user",
+			subject:        "apikey:msk_abcd",
+			method:         "POST",
+			url:            "/users/1/notifications/5/read",
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:           "reviews as the caller",
+			subject:        "1",
+			method:         "PUT",
+			url:            "/albums/7/reviews/1",
+			body:           `{"rating": 4}`,
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "forbids review as another user",
+			subject:        "2",
+			method:         "PUT",
+			url:            "/albums/7/reviews/1",
+			body:           `{"rating": 1}`,
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:           "forbids deleting the review of another user",
+			subject:        "2",
+			method:         "DELETE",
+			url:            "/albums/7/reviews/1",
+			expectedStatus: fiber.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(withIdentity(tt.subject))
+			app.Mount("/", newUsersTestApp(&mockUserRepository{}, &mockReviewRepository{}, &mockWishlistRepository{}, &mockRepository{}))
+
+			req, _ := http.NewRequest(tt.method, tt.url, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
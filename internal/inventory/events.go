@@ -0,0 +1,40 @@
+package inventory
+
+import (
+	"context"
+	"time"
+
+	"music-service/gen/pb"
+	"music-service/internal/models"
+	"music-service/pkg/kafka"
+)
+
+// EventLowStock is the type of the events published when the available units
+// of a stock are at or below its low-stock threshold.
+const EventLowStock = "low_stock"
+
+// PublishLowStock publishes a low-stock event when the stock is low after a
+// reservation or stock update, and reports whether it did.
+func PublishLowStock(ctx context.Context, producer kafka.ProducerHandler, stock *models.Stock) bool {
+	if !stock.LowStock() {
+		return false
+	}
+	producer.ProduceStockEvent(ctx, &pb.StockEvent{
+		Type:       EventLowStock,
+		Stock:      ToStockLevel(stock),
+		OccurredAt: time.Now().UTC().Format(time.RFC3339),
+	})
+	return true
+}
+
+func ToStockLevel(stock *models.Stock) *pb.StockLevel {
+	return &pb.StockLevel{
+		AlbumId:           int32(stock.AlbumId),
+		Format:            stock.Format,
+		WarehouseId:       int32(stock.WarehouseId),
+		OnHand:            int32(stock.OnHand),
+		Reserved:          int32(stock.Reserved),
+		Available:         int32(stock.Available()),
+		LowStockThreshold: int32(stock.LowStockThreshold),
+	}
+}
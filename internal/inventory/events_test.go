@@ -0,0 +1,66 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"music-service/gen/pb"
+	"music-service/internal/models"
+	"music-service/pkg/kafka"
+)
+
+// fakeProducerHandler records the stock events
+type fakeProducerHandler struct {
+	kafka.ProducerHandler
+	events []*pb.StockEvent
+}
+
+func (p *fakeProducerHandler) ProduceStockEvent(ctx context.Context, event *pb.StockEvent) {
+	p.events = append(p.events, event)
+}
+
+func TestPublishLowStock(t *testing.T) {
+	tests := []struct {
+		name      string
+		stock     models.Stock
+		published bool
+	}{
+		{
+			name:      "above threshold",
+			stock:     models.Stock{OnHand: 10, Reserved: 2, LowStockThreshold: 5},
+			published: false,
+		},
+		{
+			name:      "at threshold",
+			stock:     models.Stock{OnHand: 10, Reserved: 5, LowStockThreshold: 5},
+			published: true,
+		},
+		{
+			name:      "sold out without threshold",
+			stock:     models.Stock{OnHand: 4, Reserved: 4},
+			published: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			producer := &fakeProducerHandler{}
+			tt.stock.AlbumId = 7
+			tt.stock.Format = models.FormatVinyl
+			tt.stock.WarehouseId = 2
+
+			assert.Equal(t, tt.published, PublishLowStock(context.Background(), producer, &tt.stock))
+			if !tt.published {
+				assert.Empty(t, producer.events)
+				return
+			}
+			assert.Len(t, producer.events, 1)
+			assert.Equal(t, EventLowStock, producer.events[0].Type)
+			assert.Equal(t, int32(7), producer.events[0].Stock.AlbumId)
+			assert.Equal(t, int32(tt.stock.Available()), producer.events[0].Stock.Available)
+			assert.NotEmpty(t, producer.events[0].OccurredAt)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package inventory
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"music-service/internal/repository/postgres/orm"
+)
+
+// Expirer expires the pending reservations past their expiry, returning their
+// units to the available units. Several expirers may run at once since each
+// reservation is only expired by one of them.
+type Expirer struct {
+	inventory orm.InventoryRepository
+	interval  time.Duration
+}
+
+func NewExpirer(inventory orm.InventoryRepository, interval time.Duration) *Expirer {
+	return &Expirer{inventory: inventory, interval: interval}
+}
+
+// Run expires the due reservations right away and then every interval until
+// the context is done. Failures are logged and retried at the next tick.
+func (e *Expirer) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		e.Expire()
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Expire expires the due reservations and returns how many expired.
+func (e *Expirer) Expire() int {
+	expired, err := e.inventory.ExpireReservations()
+	if err != nil {
+		log.Printf("failed to expire reservations: %v", err)
+		return 0
+	}
+	if expired > 0 {
+		log.Printf("expired %d reservations", expired)
+	}
+	return expired
+}
@@ -0,0 +1,51 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"music-service/internal/repository/postgres/orm"
+)
+
+// fakeInventoryRepository counts the calls of ExpireReservations
+type fakeInventoryRepository struct {
+	orm.InventoryRepository
+	calls atomic.Int32
+	err   error
+}
+
+func (r *fakeInventoryRepository) ExpireReservations() (int, error) {
+	r.calls.Add(1)
+	return 3, r.err
+}
+
+func TestExpirer_Expire(t *testing.T) {
+	inventory := &fakeInventoryRepository{}
+	assert.Equal(t, 3, NewExpirer(inventory, time.Minute).Expire())
+
+	inventory.err = errors.New("connection refused")
+	assert.Equal(t, 0, NewExpirer(inventory, time.Minute).Expire())
+}
+
+func TestExpirer_Run(t *testing.T) {
+	inventory := &fakeInventoryRepository{}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		NewExpirer(inventory, time.Millisecond).Run(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool { return inventory.calls.Load() >= 3 }, time.Second, time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected Run to return once the context is done")
+	}
+}
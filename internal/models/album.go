@@ -6,12 +6,25 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// Album embeds the name of its artist next to the artist id. Postgres keeps
+// the name in line with music.artists and resolves the artist by name when
+// the id is zero, see sql/ddl/alter_table_albums_artist_id.sql. The formats an
+// album is sold in are its editions. The price is in the currency of the album,
+// prices in other currencies are Prices. The average rating and the number of
+// ratings are kept by postgres from the reviews and never written by the
+// services, see sql/ddl/create_table_users.sql.
 type Album struct {
-	tableName struct{}        `pg:"music.albums"`
-	Id        int             `db:"id"`
-	Title     string          `db:"title"`
-	Artist    string          `db:"artist"`
-	Price     decimal.Decimal `db:"price"`
+	tableName     struct{}        `pg:"music.albums"`
+	Id            int             `db:"id"`
+	Title         string          `db:"title"`
+	ArtistId      int             `db:"artist_id"`
+	Artist        string          `db:"artist"`
+	Price         decimal.Decimal `db:"price"`
+	Currency      string          `db:"currency"`
+	LabelId       int             `db:"label_id"`
+	ReleaseDate   Date            `db:"release_date" pg:"type:date"`
+	AverageRating decimal.Decimal `db:"average_rating"`
+	RatingCount   int             `db:"rating_count"`
 }
 
 func (a *Album) String() string {
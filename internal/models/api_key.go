@@ -0,0 +1,66 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MaxAPIKeyName bounds the name of an API key.
+const MaxAPIKeyName = 200
+
+// APIKey authenticates a service-to-service client that cannot get tokens.
+// Only the hash of the key is kept, see sql/ddl/create_table_api_keys.sql.
+// The scopes are the roles the client acts with.
+type APIKey struct {
+	tableName  struct{}   `pg:"music.api_keys"`
+	Id         int        `db:"id"`
+	Name       string     `db:"name"`
+	Prefix     string     `db:"prefix"`
+	KeyHash    []byte     `db:"key_hash"`
+	Scopes     []string   `db:"scopes" pg:",array"`
+	ExpiresAt  *time.Time `db:"expires_at"`
+	LastUsedAt *time.Time `db:"last_used_at"`
+	RevokedAt  *time.Time `db:"revoked_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+}
+
+// Normalize trims the name and sorts the scopes in lower case without
+// duplicates.
+func (k *APIKey) Normalize() {
+	k.Name = strings.TrimSpace(k.Name)
+	scopes := make([]string, 0, len(k.Scopes))
+	for _, scope := range k.Scopes {
+		if scope = strings.ToLower(strings.TrimSpace(scope)); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	slices.Sort(scopes)
+	k.Scopes = slices.Compact(scopes)
+}
+
+// Validate checks a normalized key about to be created at now.
+func (k *APIKey) Validate(now time.Time) error {
+	switch {
+	case k.Name == "":
+		return errors.New("name is required")
+	case len([]rune(k.Name)) > MaxAPIKeyName:
+		return fmt.Errorf("name must not be longer than %d characters", MaxAPIKeyName)
+	case len(k.Scopes) == 0:
+		return errors.New("at least one scope is required")
+	case k.ExpiresAt != nil && !k.ExpiresAt.After(now):
+		return errors.New("expiry must be in the future")
+	}
+	return nil
+}
+
+// Active reports whether the key is neither revoked nor expired at now.
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+func (k *APIKey) String() string {
+	return fmt.Sprintf("APIKey{Id: %d, Name: %s, Prefix: %s, Scopes: %v}", k.Id, k.Name, k.Prefix, k.Scopes)
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAPIKey_NormalizeValidate(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		key     APIKey
+		wantErr bool
+	}{
+		{name: "valid", key: APIKey{Name: " nightly import ", Scopes: []string{" Editor", "viewer", "editor", ""}}},
+		{name: "expiring", key: APIKey{Name: "nightly import", Scopes: []string{"editor", "viewer"}, ExpiresAt: &future}},
+		{name: "missing name", key: APIKey{Name: " ", Scopes: []string{"viewer"}}, wantErr: true},
+		{name: "long name", key: APIKey{Name: strings.Repeat("a", MaxAPIKeyName+1), Scopes: []string{"viewer"}}, wantErr: true},
+		{name: "no scopes", key: APIKey{Name: "nightly import", Scopes: []string{" "}}, wantErr: true},
+		{name: "expired", key: APIKey{Name: "nightly import", Scopes: []string{"viewer"}, ExpiresAt: &past}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.key.Normalize()
+			err := tt.key.Validate(now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if err == nil && (tt.key.Name != "nightly import" || !reflect.DeepEqual(tt.key.Scopes, []string{"editor", "viewer"})) {
+				t.Errorf("Expected a normalized key, got %s", tt.key.String())
+			}
+		})
+	}
+}
+
+func TestAPIKey_Active(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	tests := []struct {
+		name string
+		key  APIKey
+		want bool
+	}{
+		{name: "no expiry", key: APIKey{}, want: true},
+		{name: "not expired", key: APIKey{ExpiresAt: &future}, want: true},
+		{name: "expired", key: APIKey{ExpiresAt: &past}, want: false},
+		{name: "revoked", key: APIKey{RevokedAt: &past}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.key.Active(now); got != tt.want {
+				t.Errorf("Expected Active to be %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package models
+
+import "fmt"
+
+type Artist struct {
+	tableName struct{} `pg:"music.artists"`
+	Id        int      `db:"id"`
+	Name      string   `db:"name"`
+	Country   string   `db:"country"`
+	Bio       string   `db:"bio"`
+}
+
+func (a *Artist) String() string {
+	return fmt.Sprintf("Artist{Id: %d, Name: %s, Country: %s}", a.Id, a.Name, a.Country)
+}
@@ -0,0 +1,31 @@
+package models
+
+import "strings"
+
+// DefaultCurrency is the currency of album prices given without one.
+const DefaultCurrency = "USD"
+
+// isoCurrencies are the ISO 4217 codes of the currencies in circulation,
+// without the codes of funds, precious metals and testing.
+const isoCurrencies = `AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB
+BRL BSD BTN BWP BYN BZD CAD CDF CHF CLP CNY COP CRC CUC CUP CVE CZK DJF DKK DOP DZD EGP ERN ETB
+EUR FJD FKP GBP GEL GHS GIP GMD GNF GTQ GYD HKD HNL HTG HUF IDR ILS INR IQD IRR ISK JMD JOD JPY
+KES KGS KHR KMF KPW KRW KWD KYD KZT LAK LBP LKR LRD LSL LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR
+MVR MWK MXN MYR MZN NAD NGN NIO NOK NPR NZD OMR PAB PEN PGK PHP PKR PLN PYG QAR RON RSD RUB RWF
+SAR SBD SCR SDG SEK SGD SHP SLE SLL SOS SRD SSP STN SVC SYP SZL THB TJS TMT TND TOP TRY TTD TWD
+TZS UAH UGX USD UYU UZS VED VES VND VUV WST XAF XCD XCG XOF XPF YER ZAR ZMW ZWG ZWL`
+
+var currencies = func() map[string]struct{} {
+	codes := map[string]struct{}{}
+	for _, code := range strings.Fields(isoCurrencies) {
+		codes[code] = struct{}{}
+	}
+	return codes
+}()
+
+// ValidCurrency reports whether the code is the upper case ISO 4217 code of a
+// currency in circulation.
+func ValidCurrency(code string) bool {
+	_, ok := currencies[code]
+	return ok
+}
@@ -0,0 +1,24 @@
+package models
+
+import "testing"
+
+func TestValidCurrency(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{code: "USD", want: true},
+		{code: "EUR", want: true},
+		{code: "JPY", want: true},
+		{code: "usd", want: false},
+		{code: "XAU", want: false}, // gold
+		{code: "ABC", want: false},
+		{code: "", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidCurrency(tt.code); got != tt.want {
+			t.Errorf("ValidCurrency(%q) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
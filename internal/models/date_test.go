@@ -0,0 +1,73 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	date, err := ParseDate("1958-01-15")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if date.String() != "1958-01-15" {
+		t.Errorf("Expected 1958-01-15, got %s", date)
+	}
+
+	if date, err := ParseDate(""); err != nil || !date.IsZero() {
+		t.Errorf("Expected the zero date for an empty string, got %v, %v", date, err)
+	}
+	if _, err := ParseDate("15/01/1958"); err == nil {
+		t.Error("Expected an error for a date that is not YYYY-MM-DD")
+	}
+}
+
+func TestDate_JSON(t *testing.T) {
+	data, _ := json.Marshal(struct{ ReleaseDate Date }{})
+	if string(data) != `{"ReleaseDate":null}` {
+		t.Errorf("Expected the zero date to encode as null, got %s", data)
+	}
+
+	var album struct{ ReleaseDate Date }
+	if err := json.Unmarshal([]byte(`{"releaseDate":"1958-01-15"}`), &album); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	data, _ = json.Marshal(album)
+	if string(data) != `{"ReleaseDate":"1958-01-15"}` {
+		t.Errorf("Expected the date to round trip, got %s", data)
+	}
+
+	if err := json.Unmarshal([]byte(`{"releaseDate":"January 1958"}`), &album); err == nil {
+		t.Error("Expected an error for an invalid date")
+	}
+}
+
+func TestDate_Scan(t *testing.T) {
+	tests := []struct {
+		name string
+		src  any
+		want string
+	}{
+		{name: "time", src: time.Date(1958, 1, 15, 0, 0, 0, 0, time.Local), want: "1958-01-15"},
+		{name: "text", src: []byte("1958-01-15"), want: "1958-01-15"},
+		{name: "timestamp text", src: "1958-01-15T00:00:00Z", want: "1958-01-15"},
+		{name: "null", src: nil, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date := Date{}
+			if err := date.Scan(tt.src); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if date.String() != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, date.String())
+			}
+		})
+	}
+
+	if value, _ := (Date{}).Value(); value != nil {
+		t.Errorf("Expected the zero date to be stored as NULL, got %v", value)
+	}
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Formats an edition can be sold in.
+const (
+	FormatVinyl    = "vinyl"
+	FormatCD       = "cd"
+	FormatCassette = "cassette"
+	FormatDigital  = "digital"
+)
+
+var formats = map[string]struct{}{
+	FormatVinyl:    {},
+	FormatCD:       {},
+	FormatCassette: {},
+	FormatDigital:  {},
+}
+
+// Edition is a format an album is sold in, such as the vinyl or CD release,
+// with its own SKU, barcode, catalog number and price.
+type Edition struct {
+	tableName     struct{}        `pg:"music.editions"`
+	Id            int             `db:"id"`
+	AlbumId       int             `db:"album_id"`
+	Format        string          `db:"format"`
+	Name          string          `db:"name"`
+	Sku           string          `db:"sku"`
+	Barcode       string          `db:"barcode"`
+	CatalogNumber string          `db:"catalog_number"`
+	Price         decimal.Decimal `db:"price" pg:",use_zero"`
+}
+
+// Normalize writes the format in lower case and the barcode without spaces
+// and hyphens, and trims the other texts.
+func (e *Edition) Normalize() {
+	e.Format = strings.ToLower(strings.TrimSpace(e.Format))
+	e.Name = strings.TrimSpace(e.Name)
+	e.Sku = strings.TrimSpace(e.Sku)
+	e.Barcode = strings.NewReplacer(" ", "", "-", "").Replace(e.Barcode)
+	e.CatalogNumber = strings.TrimSpace(e.CatalogNumber)
+}
+
+// Validate checks a normalized edition.
+func (e *Edition) Validate() error {
+	if _, ok := formats[e.Format]; !ok {
+		return fmt.Errorf("invalid format %q, expected one of vinyl, cd, cassette or digital", e.Format)
+	}
+	switch {
+	case e.Sku == "":
+		return errors.New("sku is required")
+	case e.Barcode != "" && !ValidBarcode(e.Barcode):
+		return fmt.Errorf("invalid UPC/EAN barcode %q", e.Barcode)
+	case e.Price.IsNegative():
+		return errors.New("price must not be negative")
+	}
+	return nil
+}
+
+// ValidBarcode reports whether the code is an EAN-8, UPC-A or EAN-13 barcode
+// with a valid check digit.
+func ValidBarcode(code string) bool {
+	if len(code) != 8 && len(code) != 12 && len(code) != 13 {
+		return false
+	}
+	sum := 0
+	for i := len(code) - 2; i >= 0; i-- {
+		digit := int(code[i] - '0')
+		if digit < 0 || digit > 9 {
+			return false
+		}
+		// Digits are weighted 3 and 1 alternately from the right of the
+		// check digit.
+		if (len(code)-2-i)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	check := int(code[len(code)-1] - '0')
+	return check >= 0 && check <= 9 && (10-sum%10)%10 == check
+}
+
+func (e *Edition) String() string {
+	return fmt.Sprintf("Edition{Id: %d, AlbumId: %d, Format: %s, Sku: %s, Barcode: %s, Price: %s}", e.Id, e.AlbumId, e.Format, e.Sku, e.Barcode, e.Price.String())
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestValidBarcode(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{code: "036000291452", want: true},   // UPC-A
+		{code: "4006381333931", want: true},  // EAN-13
+		{code: "96385074", want: true},       // EAN-8
+		{code: "036000291453", want: false},  // wrong check digit
+		{code: "4006381333932", want: false}, // wrong check digit
+		{code: "03600029145X", want: false},
+		{code: "0360002914", want: false},
+		{code: "", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidBarcode(tt.code); got != tt.want {
+			t.Errorf("ValidBarcode(%q) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestEdition_NormalizeValidate(t *testing.T) {
+	edition := Edition{Format: " Vinyl ", Sku: " BLP-1577 ", Barcode: "4006381-333931", Price: decimal.NewFromFloat(34.99)}
+	edition.Normalize()
+
+	if edition.Format != "vinyl" || edition.Sku != "BLP-1577" || edition.Barcode != "4006381333931" {
+		t.Errorf("Expected a normalized edition, got %s", edition.String())
+	}
+	if err := edition.Validate(); err != nil {
+		t.Errorf("Expected a valid edition, got %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		edition Edition
+	}{
+		{name: "unknown format", edition: Edition{Format: "8-track", Sku: "A"}},
+		{name: "missing sku", edition: Edition{Format: FormatCD}},
+		{name: "invalid barcode", edition: Edition{Format: FormatCD, Sku: "A", Barcode: "4006381333932"}},
+		{name: "negative price", edition: Edition{Format: FormatDigital, Sku: "A", Price: decimal.NewFromInt(-1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.edition.Validate(); err == nil {
+				t.Errorf("Expected %s to be invalid", tt.edition.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// AlbumFilter narrows the albums of a list. Zero fields do not filter, and a
+// genre includes the genres below it.
+type AlbumFilter struct {
+	ArtistId int
+	GenreId  int
+	Tag      string
+	MinPrice decimal.Decimal
+	MaxPrice decimal.Decimal
+}
+
+func (f AlbumFilter) IsZero() bool {
+	return f.ArtistId == 0 && f.GenreId == 0 && f.Tag == "" && f.MinPrice.IsZero() && f.MaxPrice.IsZero()
+}
+
+// FacetCount is the number of albums of a genre, tag or artist.
+type FacetCount struct {
+	Id       int
+	Name     string
+	ParentId int
+	Count    int
+}
+
+// PriceFacet is the number of albums priced from Min up to but excluding Max.
+// Max is zero for the last bucket, which has no upper bound.
+type PriceFacet struct {
+	Min   decimal.Decimal
+	Max   decimal.Decimal
+	Count int
+}
+
+// Facets counts the albums of a filtered list for browsing. Counts are
+// ordered from the largest.
+type Facets struct {
+	Genres  []FacetCount
+	Tags    []FacetCount
+	Artists []FacetCount
+	Prices  []PriceFacet
+}
+
+// FacetRow is a row of music.album_facets, see
+// sql/ddl/create_function_browse_albums.sql.
+type FacetRow struct {
+	Facet      string          `db:"facet"`
+	Id         int             `db:"id"`
+	Name       string          `db:"name"`
+	ParentId   int             `db:"parent_id"`
+	LowerPrice decimal.Decimal `db:"lower_price"`
+	UpperPrice decimal.Decimal `db:"upper_price"`
+	Count      int             `db:"count"`
+}
+
+// NewFacets groups the rows by facet, keeping their order.
+func NewFacets(rows []FacetRow) *Facets {
+	facets := &Facets{
+		Genres:  []FacetCount{},
+		Tags:    []FacetCount{},
+		Artists: []FacetCount{},
+		Prices:  []PriceFacet{},
+	}
+	for _, row := range rows {
+		count := FacetCount{Id: row.Id, Name: row.Name, ParentId: row.ParentId, Count: row.Count}
+		switch row.Facet {
+		case "genre":
+			facets.Genres = append(facets.Genres, count)
+		case "tag":
+			facets.Tags = append(facets.Tags, count)
+		case "artist":
+			facets.Artists = append(facets.Artists, count)
+		case "price":
+			facets.Prices = append(facets.Prices, PriceFacet{Min: row.LowerPrice, Max: row.UpperPrice, Count: row.Count})
+		}
+	}
+	return facets
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestAlbumFilter_IsZero(t *testing.T) {
+	if !(AlbumFilter{}).IsZero() {
+		t.Error("Expected the empty filter to be zero")
+	}
+	if (AlbumFilter{MaxPrice: decimal.NewFromInt(20)}).IsZero() {
+		t.Error("Expected a filter with a maximum price not to be zero")
+	}
+}
+
+func TestNewFacets(t *testing.T) {
+	facets := NewFacets([]FacetRow{
+		{Facet: "artist", Id: 7, Name: "John Coltrane", Count: 2},
+		{Facet: "genre", Id: 1, Name: "Jazz", Count: 3},
+		{Facet: "genre", Id: 2, Name: "Hard Bop", ParentId: 1, Count: 2},
+		{Facet: "price", Id: 5, LowerPrice: decimal.NewFromInt(100), Count: 1},
+		{Facet: "tag", Id: 4, Name: "live", Count: 1},
+	})
+
+	if len(facets.Genres) != 2 || facets.Genres[1].Name != "Hard Bop" || facets.Genres[1].ParentId != 1 {
+		t.Errorf("Expected Jazz and Hard Bop below it, got %v", facets.Genres)
+	}
+	if len(facets.Tags) != 1 || facets.Tags[0].Name != "live" {
+		t.Errorf("Expected the live tag, got %v", facets.Tags)
+	}
+	if len(facets.Artists) != 1 || facets.Artists[0].Count != 2 {
+		t.Errorf("Expected two albums of John Coltrane, got %v", facets.Artists)
+	}
+	if len(facets.Prices) != 1 || !facets.Prices[0].Min.Equal(decimal.NewFromInt(100)) || !facets.Prices[0].Max.IsZero() {
+		t.Errorf("Expected the open price bucket from 100, got %v", facets.Prices)
+	}
+}
+
+func TestNewFacets_Empty(t *testing.T) {
+	facets := NewFacets(nil)
+
+	if facets.Genres == nil || facets.Tags == nil || facets.Artists == nil || facets.Prices == nil {
+		t.Errorf("Expected empty facets to encode as empty lists, got %v", facets)
+	}
+}
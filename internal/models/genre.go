@@ -0,0 +1,23 @@
+package models
+
+import "fmt"
+
+// Genre is a node of the genre hierarchy, such as Hard Bop below Jazz. Top
+// level genres have no parent.
+type Genre struct {
+	tableName struct{} `pg:"music.genres"`
+	Id        int      `db:"id"`
+	Name      string   `db:"name"`
+	ParentId  int      `db:"parent_id"`
+}
+
+// AlbumGenre relates an album to one of its genres.
+type AlbumGenre struct {
+	tableName struct{} `pg:"music.album_genres"`
+	AlbumId   int      `db:"album_id"`
+	GenreId   int      `db:"genre_id"`
+}
+
+func (g *Genre) String() string {
+	return fmt.Sprintf("Genre{Id: %d, Name: %s, ParentId: %d}", g.Id, g.Name, g.ParentId)
+}
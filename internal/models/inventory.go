@@ -0,0 +1,139 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Statuses of a stock reservation. Only pending reservations hold units.
+// Returned reservations were committed and their units put back on hand.
+const (
+	ReservationPending   = "pending"
+	ReservationCommitted = "committed"
+	ReservationReleased  = "released"
+	ReservationExpired   = "expired"
+	ReservationReturned  = "returned"
+)
+
+// DefaultReservationTTL is how long a reservation holds its units when the
+// request does not say, MaxReservationTTL the longest it may hold them.
+const (
+	DefaultReservationTTL = 15 * time.Minute
+	MaxReservationTTL     = 24 * time.Hour
+)
+
+// Warehouse is a location album formats are stocked in.
+type Warehouse struct {
+	tableName struct{} `pg:"music.warehouses"`
+	Id        int      `db:"id"`
+	Code      string   `db:"code"`
+	Name      string   `db:"name"`
+}
+
+func (w *Warehouse) String() string {
+	return fmt.Sprintf("Warehouse{Id: %d, Code: %s, Name: %s}", w.Id, w.Code, w.Name)
+}
+
+// Stock is the stock of an album format in a warehouse. Reserved units are on
+// hand but held by pending reservations.
+type Stock struct {
+	tableName         struct{} `pg:"music.stock"`
+	AlbumId           int      `db:"album_id" pg:",pk"`
+	Format            string   `db:"format" pg:",pk"`
+	WarehouseId       int      `db:"warehouse_id" pg:",pk"`
+	OnHand            int      `db:"on_hand" pg:",use_zero"`
+	Reserved          int      `db:"reserved" pg:",use_zero"`
+	LowStockThreshold int      `db:"low_stock_threshold" pg:",use_zero"`
+}
+
+// Available returns the units that can still be reserved.
+func (s *Stock) Available() int {
+	return s.OnHand - s.Reserved
+}
+
+// LowStock reports whether the available units are at or below the low-stock
+// threshold, which includes being sold out.
+func (s *Stock) LowStock() bool {
+	return s.Available() <= s.LowStockThreshold
+}
+
+// Normalize writes the format in lower case.
+func (s *Stock) Normalize() {
+	s.Format = strings.ToLower(strings.TrimSpace(s.Format))
+}
+
+// Validate checks a normalized stock.
+func (s *Stock) Validate() error {
+	if _, ok := formats[s.Format]; !ok {
+		return fmt.Errorf("invalid format %q, expected one of vinyl, cd, cassette or digital", s.Format)
+	}
+	switch {
+	case s.WarehouseId < 1:
+		return errors.New("warehouseId is required")
+	case s.OnHand < 0:
+		return errors.New("onHand must not be negative")
+	case s.LowStockThreshold < 0:
+		return errors.New("lowStockThreshold must not be negative")
+	}
+	return nil
+}
+
+func (s *Stock) String() string {
+	return fmt.Sprintf("Stock{AlbumId: %d, Format: %s, WarehouseId: %d, OnHand: %d, Reserved: %d, LowStockThreshold: %d}", s.AlbumId, s.Format, s.WarehouseId, s.OnHand, s.Reserved, s.LowStockThreshold)
+}
+
+// Reservation holds units of the stock of an album format in a warehouse
+// until it is committed, released or expires.
+type Reservation struct {
+	tableName   struct{}  `pg:"music.stock_reservations"`
+	Id          int       `db:"id"`
+	AlbumId     int       `db:"album_id"`
+	Format      string    `db:"format"`
+	WarehouseId int       `db:"warehouse_id"`
+	Quantity    int       `db:"quantity"`
+	Status      string    `db:"status"`
+	ExpiresAt   time.Time `db:"expires_at"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// Normalize writes the format in lower case.
+func (r *Reservation) Normalize() {
+	r.Format = strings.ToLower(strings.TrimSpace(r.Format))
+}
+
+// Validate checks a normalized reservation. A reservation without a
+// warehouse is held in any warehouse.
+func (r *Reservation) Validate() error {
+	if _, ok := formats[r.Format]; !ok {
+		return fmt.Errorf("invalid format %q, expected one of vinyl, cd, cassette or digital", r.Format)
+	}
+	switch {
+	case r.WarehouseId < 0:
+		return errors.New("warehouseId must not be negative")
+	case r.Quantity < 1:
+		return errors.New("quantity must be positive")
+	}
+	return nil
+}
+
+// ReservationTTL returns the ttl of the seconds, DefaultReservationTTL when
+// they are zero and at most MaxReservationTTL, or an error when they are
+// negative. The seconds are clamped before they become a time.Duration, so
+// that no request overflows it.
+func ReservationTTL(seconds int64) (time.Duration, error) {
+	switch {
+	case seconds == 0:
+		return DefaultReservationTTL, nil
+	case seconds < 0:
+		return 0, errors.New("ttl must not be negative")
+	case seconds > int64(MaxReservationTTL/time.Second):
+		return MaxReservationTTL, nil
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+func (r *Reservation) String() string {
+	return fmt.Sprintf("Reservation{Id: %d, AlbumId: %d, Format: %s, WarehouseId: %d, Quantity: %d, Status: %s, ExpiresAt: %s}", r.Id, r.AlbumId, r.Format, r.WarehouseId, r.Quantity, r.Status, r.ExpiresAt.Format(time.RFC3339))
+}
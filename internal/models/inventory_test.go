@@ -0,0 +1,63 @@
+package models
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestStock_LowStock(t *testing.T) {
+	tests := []struct {
+		stock Stock
+		want  bool
+	}{
+		{stock: Stock{OnHand: 10, Reserved: 4, LowStockThreshold: 5}, want: false},
+		{stock: Stock{OnHand: 10, Reserved: 5, LowStockThreshold: 5}, want: true},
+		{stock: Stock{OnHand: 3, Reserved: 3}, want: true},
+		{stock: Stock{OnHand: 1}, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.stock.LowStock(); got != tt.want {
+			t.Errorf("%s.LowStock() = %v, want %v", tt.stock.String(), got, tt.want)
+		}
+	}
+}
+
+func TestReservation_NormalizeValidate(t *testing.T) {
+	reservation := Reservation{AlbumId: 7, Format: " CD ", WarehouseId: 2, Quantity: 1}
+	reservation.Normalize()
+
+	if reservation.Format != FormatCD {
+		t.Errorf("Expected format cd, got %q", reservation.Format)
+	}
+	if err := reservation.Validate(); err != nil {
+		t.Errorf("Expected a valid reservation, got %v", err)
+	}
+
+	reservation.Quantity = 0
+	if err := reservation.Validate(); err == nil {
+		t.Error("Expected an error for a zero quantity")
+	}
+}
+
+func TestReservationTTL(t *testing.T) {
+	tests := []struct {
+		seconds int64
+		want    time.Duration
+		wantErr bool
+	}{
+		{seconds: 0, want: DefaultReservationTTL},
+		{seconds: 60, want: time.Minute},
+		{seconds: -1, wantErr: true},
+		{seconds: int64(MaxReservationTTL/time.Second) + 1, want: MaxReservationTTL},
+		{seconds: math.MaxInt64, want: MaxReservationTTL},
+	}
+
+	for _, tt := range tests {
+		got, err := ReservationTTL(tt.seconds)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ReservationTTL(%d) = %s, %v, want %s", tt.seconds, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package models
+
+import "fmt"
+
+// Label is a record label releasing albums.
+type Label struct {
+	tableName struct{} `pg:"music.labels"`
+	Id        int      `db:"id"`
+	Name      string   `db:"name"`
+	Country   string   `db:"country"`
+}
+
+func (l *Label) String() string {
+	return fmt.Sprintf("Label{Id: %d, Name: %s, Country: %s}", l.Id, l.Name, l.Country)
+}
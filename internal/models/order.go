@@ -0,0 +1,150 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Statuses of a cart. Only open carts can be changed and checked out.
+const (
+	CartOpen       = "open"
+	CartCheckedOut = "checked_out"
+)
+
+// Statuses of an order, see CanTransition.
+const (
+	OrderPending   = "pending"
+	OrderPaid      = "paid"
+	OrderShipped   = "shipped"
+	OrderCancelled = "cancelled"
+	OrderRefunded  = "refunded"
+)
+
+// orderTransitions are the statuses an order can change to from each status.
+// Pending orders hold reservations and are cancelled, paid orders have been
+// charged and are refunded.
+var orderTransitions = map[string][]string{
+	OrderPending: {OrderPaid, OrderCancelled},
+	OrderPaid:    {OrderShipped, OrderRefunded},
+	OrderShipped: {OrderRefunded},
+}
+
+// CanTransition reports whether an order can change from one status to the
+// other.
+func CanTransition(from, to string) bool {
+	return slices.Contains(orderTransitions[from], to)
+}
+
+// Cart holds the albums a customer is about to order, priced in the currency
+// of the cart.
+type Cart struct {
+	tableName struct{}    `pg:"music.carts"`
+	Id        int         `db:"id"`
+	Customer  string      `db:"customer"`
+	Currency  string      `db:"currency"`
+	Status    string      `db:"status"`
+	CreatedAt time.Time   `db:"created_at"`
+	Items     []*CartItem `pg:"-"`
+}
+
+// Total returns the sum of the prices of the items.
+func (c *Cart) Total() decimal.Decimal {
+	total := decimal.Zero
+	for _, item := range c.Items {
+		total = total.Add(item.UnitPrice.Mul(decimal.NewFromInt(int64(item.Quantity))))
+	}
+	return total
+}
+
+// Normalize trims the customer and writes the currency in upper case,
+// DefaultCurrency when it is empty.
+func (c *Cart) Normalize() {
+	c.Customer = strings.TrimSpace(c.Customer)
+	c.Currency = strings.ToUpper(strings.TrimSpace(c.Currency))
+	if c.Currency == "" {
+		c.Currency = DefaultCurrency
+	}
+}
+
+// Validate checks a normalized cart.
+func (c *Cart) Validate() error {
+	switch {
+	case c.Customer == "":
+		return errors.New("customer is required")
+	case !ValidCurrency(c.Currency):
+		return fmt.Errorf("invalid ISO 4217 currency %q", c.Currency)
+	}
+	return nil
+}
+
+func (c *Cart) String() string {
+	return fmt.Sprintf("Cart{Id: %d, Customer: %s, Currency: %s, Status: %s, Items: %d}", c.Id, c.Customer, c.Currency, c.Status, len(c.Items))
+}
+
+// CartItem is a quantity of an album format in a cart, at the price of the
+// album in the currency of the cart when it was first added.
+type CartItem struct {
+	tableName struct{}        `pg:"music.cart_items"`
+	CartId    int             `db:"cart_id" pg:",pk"`
+	AlbumId   int             `db:"album_id" pg:",pk"`
+	Format    string          `db:"format" pg:",pk"`
+	Quantity  int             `db:"quantity"`
+	UnitPrice decimal.Decimal `db:"unit_price" pg:",use_zero"`
+}
+
+// Normalize writes the format in lower case.
+func (i *CartItem) Normalize() {
+	i.Format = strings.ToLower(strings.TrimSpace(i.Format))
+}
+
+// Validate checks a normalized cart item.
+func (i *CartItem) Validate() error {
+	if _, ok := formats[i.Format]; !ok {
+		return fmt.Errorf("invalid format %q, expected one of vinyl, cd, cassette or digital", i.Format)
+	}
+	switch {
+	case i.AlbumId < 1:
+		return errors.New("albumId is required")
+	case i.Quantity < 1:
+		return errors.New("quantity must be positive")
+	}
+	return nil
+}
+
+// Order is a checked out cart. Its items hold reservations until the order is
+// paid, which commits them, or cancelled, which releases them.
+type Order struct {
+	tableName struct{}        `pg:"music.orders"`
+	Id        int             `db:"id"`
+	CartId    int             `db:"cart_id"`
+	Customer  string          `db:"customer"`
+	Status    string          `db:"status"`
+	Currency  string          `db:"currency"`
+	Total     decimal.Decimal `db:"total" pg:",use_zero"`
+	PaymentId string          `db:"payment_id"`
+	CreatedAt time.Time       `db:"created_at"`
+	UpdatedAt time.Time       `db:"updated_at"`
+	Items     []*OrderItem    `pg:"-"`
+}
+
+func (o *Order) String() string {
+	return fmt.Sprintf("Order{Id: %d, CartId: %d, Customer: %s, Status: %s, Total: %s %s}", o.Id, o.CartId, o.Customer, o.Status, o.Total.String(), o.Currency)
+}
+
+// OrderItem is a quantity of an album format in an order and the reservation
+// holding it.
+type OrderItem struct {
+	tableName     struct{}        `pg:"music.order_items"`
+	OrderId       int             `db:"order_id" pg:",pk"`
+	AlbumId       int             `db:"album_id" pg:",pk"`
+	Format        string          `db:"format" pg:",pk"`
+	Quantity      int             `db:"quantity"`
+	UnitPrice     decimal.Decimal `db:"unit_price" pg:",use_zero"`
+	WarehouseId   int             `db:"warehouse_id"`
+	ReservationId int             `db:"reservation_id"`
+}
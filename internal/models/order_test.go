@@ -0,0 +1,73 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{from: OrderPending, to: OrderPaid, want: true},
+		{from: OrderPending, to: OrderCancelled, want: true},
+		{from: OrderPending, to: OrderShipped, want: false},
+		{from: OrderPending, to: OrderRefunded, want: false},
+		{from: OrderPaid, to: OrderShipped, want: true},
+		{from: OrderPaid, to: OrderRefunded, want: true},
+		{from: OrderPaid, to: OrderCancelled, want: false},
+		{from: OrderShipped, to: OrderRefunded, want: true},
+		{from: OrderCancelled, to: OrderPending, want: false},
+		{from: OrderRefunded, to: OrderPaid, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestCart_Total(t *testing.T) {
+	cart := Cart{Items: []*CartItem{
+		{Quantity: 2, UnitPrice: decimal.RequireFromString("24.99")},
+		{Quantity: 1, UnitPrice: decimal.RequireFromString("9.99")},
+	}}
+
+	if got := cart.Total().StringFixed(2); got != "59.97" {
+		t.Errorf("Expected a total of 59.97, got %s", got)
+	}
+}
+
+func TestCart_NormalizeValidate(t *testing.T) {
+	cart := Cart{Customer: " ada "}
+	cart.Normalize()
+
+	if cart.Customer != "ada" || cart.Currency != DefaultCurrency {
+		t.Errorf("Expected customer ada in %s, got %s", DefaultCurrency, cart.String())
+	}
+	if err := cart.Validate(); err != nil {
+		t.Errorf("Expected a valid cart, got %v", err)
+	}
+
+	cart.Currency = "EURO"
+	if err := cart.Validate(); err == nil {
+		t.Error("Expected an error for an invalid currency")
+	}
+}
+
+func TestCartItem_NormalizeValidate(t *testing.T) {
+	item := CartItem{AlbumId: 7, Format: " Vinyl ", Quantity: 1}
+	item.Normalize()
+
+	if err := item.Validate(); err != nil {
+		t.Errorf("Expected a valid item, got %v", err)
+	}
+
+	item.Quantity = 0
+	if err := item.Validate(); err == nil {
+		t.Error("Expected an error for a zero quantity")
+	}
+}
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Visibilities of a playlist. Public playlists are listed and read by anyone,
+// unlisted ones are read by anyone with their share token and private ones
+// only by their owner and collaborators.
+const (
+	PlaylistPublic   = "public"
+	PlaylistUnlisted = "unlisted"
+	PlaylistPrivate  = "private"
+)
+
+// Bounds of the name and the description of a playlist.
+const (
+	MaxPlaylistName        = 200
+	MaxPlaylistDescription = 2000
+)
+
+// Playlist is an ordered collection of albums and tracks of a user, changed by
+// the owner and the collaborators.
+type Playlist struct {
+	tableName     struct{}        `pg:"music.playlists"`
+	Id            int             `db:"id"`
+	OwnerId       int             `db:"owner_id"`
+	Name          string          `db:"name"`
+	Description   string          `db:"description" pg:",use_zero"`
+	Visibility    string          `db:"visibility"`
+	ShareToken    string          `db:"share_token"`
+	CreatedAt     time.Time       `db:"created_at"`
+	UpdatedAt     time.Time       `db:"updated_at"`
+	Collaborators []int           `pg:"-"`
+	Items         []*PlaylistItem `pg:"-"`
+}
+
+// Normalize trims the name and the description and writes the visibility in
+// lower case, PlaylistPrivate when it is empty.
+func (p *Playlist) Normalize() {
+	p.Name = strings.TrimSpace(p.Name)
+	p.Description = strings.TrimSpace(p.Description)
+	p.Visibility = strings.ToLower(strings.TrimSpace(p.Visibility))
+	if p.Visibility == "" {
+		p.Visibility = PlaylistPrivate
+	}
+}
+
+// Validate checks a normalized playlist.
+func (p *Playlist) Validate() error {
+	switch {
+	case p.Name == "":
+		return errors.New("name is required")
+	case len([]rune(p.Name)) > MaxPlaylistName:
+		return fmt.Errorf("name must not be longer than %d characters", MaxPlaylistName)
+	case len([]rune(p.Description)) > MaxPlaylistDescription:
+		return fmt.Errorf("description must not be longer than %d characters", MaxPlaylistDescription)
+	case p.Visibility != PlaylistPublic && p.Visibility != PlaylistUnlisted && p.Visibility != PlaylistPrivate:
+		return fmt.Errorf("invalid visibility %q, expected one of public, unlisted or private", p.Visibility)
+	}
+	return nil
+}
+
+// CanEdit reports whether the user owns or collaborates on the playlist.
+func (p *Playlist) CanEdit(userId int) bool {
+	return userId > 0 && (userId == p.OwnerId || slices.Contains(p.Collaborators, userId))
+}
+
+// CanView reports whether the user can read the playlist by its id. Unlisted
+// playlists are read by others through their share token.
+func (p *Playlist) CanView(userId int) bool {
+	return p.Visibility == PlaylistPublic || p.CanEdit(userId)
+}
+
+func (p *Playlist) String() string {
+	return fmt.Sprintf("Playlist{Id: %d, OwnerId: %d, Name: %s, Visibility: %s, Items: %d}", p.Id, p.OwnerId, p.Name, p.Visibility, len(p.Items))
+}
+
+// PlaylistItem is an album or a track at a position of a playlist, counted
+// from 1.
+type PlaylistItem struct {
+	tableName  struct{}  `pg:"music.playlist_items"`
+	Id         int       `db:"id"`
+	PlaylistId int       `db:"playlist_id"`
+	Position   int       `db:"position"`
+	AlbumId    int       `db:"album_id"`
+	TrackId    int       `db:"track_id"`
+	AddedBy    int       `db:"added_by"`
+	AddedAt    time.Time `db:"added_at"`
+}
+
+// Validate checks that the item is either an album or a track and that its
+// position, if any, is positive. An item without a position is appended.
+func (i *PlaylistItem) Validate() error {
+	switch {
+	case (i.AlbumId > 0) == (i.TrackId > 0):
+		return errors.New("exactly one of albumId and trackId is required")
+	case i.AlbumId < 0 || i.TrackId < 0:
+		return errors.New("albumId and trackId must not be negative")
+	case i.Position < 0:
+		return errors.New("position must not be negative")
+	}
+	return nil
+}
+
+func (i *PlaylistItem) String() string {
+	return fmt.Sprintf("PlaylistItem{Id: %d, PlaylistId: %d, Position: %d, AlbumId: %d, TrackId: %d}", i.Id, i.PlaylistId, i.Position, i.AlbumId, i.TrackId)
+}
+
+// PlaylistCollaborator is a user other than the owner who may change the
+// items of a playlist.
+type PlaylistCollaborator struct {
+	tableName  struct{}  `pg:"music.playlist_collaborators"`
+	PlaylistId int       `db:"playlist_id" pg:",pk"`
+	UserId     int       `db:"user_id" pg:",pk"`
+	AddedAt    time.Time `db:"added_at"`
+}
+
+// PlaylistEntry is a track of a playlist as it is played, with the album and
+// artist it belongs to. An album item is expanded into an entry per track,
+// or a single entry without a track when the album has no tracks.
+type PlaylistEntry struct {
+	ItemId     int    `db:"item_id"`
+	Position   int    `db:"position"`
+	AlbumId    int    `db:"album_id"`
+	AlbumTitle string `db:"album_title"`
+	Artist     string `db:"artist"`
+	TrackId    int    `db:"track_id"`
+	TrackTitle string `db:"track_title"`
+	DurationMs int    `db:"duration_ms"`
+	Isrc       string `db:"isrc"`
+}
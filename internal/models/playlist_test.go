@@ -0,0 +1,77 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPlaylist_NormalizeValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		playlist Playlist
+		wantErr  bool
+	}{
+		{name: "private by default", playlist: Playlist{Name: " Road trip "}},
+		{name: "public", playlist: Playlist{Name: "Road trip", Visibility: " Public "}},
+		{name: "missing name", playlist: Playlist{Name: "  "}, wantErr: true},
+		{name: "long name", playlist: Playlist{Name: strings.Repeat("a", MaxPlaylistName+1)}, wantErr: true},
+		{name: "invalid visibility", playlist: Playlist{Name: "Road trip", Visibility: "friends"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.playlist.Normalize()
+			err := tt.playlist.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if err == nil && (tt.playlist.Name != "Road trip" || tt.playlist.Visibility == "") {
+				t.Errorf("Expected a normalized playlist, got %s", tt.playlist.String())
+			}
+		})
+	}
+}
+
+func TestPlaylist_Access(t *testing.T) {
+	playlist := Playlist{OwnerId: 1, Visibility: PlaylistPrivate, Collaborators: []int{2}}
+
+	for userId, canEdit := range map[int]bool{0: false, 1: true, 2: true, 3: false} {
+		if playlist.CanEdit(userId) != canEdit {
+			t.Errorf("Expected CanEdit(%d) to be %v", userId, canEdit)
+		}
+		if playlist.CanView(userId) != canEdit {
+			t.Errorf("Expected CanView(%d) of a private playlist to be %v", userId, canEdit)
+		}
+	}
+
+	playlist.Visibility = PlaylistUnlisted
+	if playlist.CanView(3) {
+		t.Errorf("Expected an unlisted playlist to be hidden by id")
+	}
+	playlist.Visibility = PlaylistPublic
+	if !playlist.CanView(0) {
+		t.Errorf("Expected a public playlist to be visible to anyone")
+	}
+}
+
+func TestPlaylistItem_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		item    PlaylistItem
+		wantErr bool
+	}{
+		{name: "album", item: PlaylistItem{AlbumId: 7}},
+		{name: "track at a position", item: PlaylistItem{TrackId: 3, Position: 2}},
+		{name: "neither", item: PlaylistItem{}, wantErr: true},
+		{name: "both", item: PlaylistItem{AlbumId: 7, TrackId: 3}, wantErr: true},
+		{name: "negative position", item: PlaylistItem{AlbumId: 7, Position: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.item.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
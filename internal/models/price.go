@@ -0,0 +1,54 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Price is the price of an album in a currency. The price in the currency of
+// the album is the price of the album, the prices in other currencies are
+// kept in music.album_prices.
+type Price struct {
+	tableName struct{}        `pg:"music.album_prices"`
+	AlbumId   int             `db:"album_id"`
+	Currency  string          `db:"currency"`
+	Price     decimal.Decimal `db:"price" pg:",use_zero"`
+}
+
+func (p *Price) String() string {
+	return fmt.Sprintf("Price{AlbumId: %d, Currency: %s, Price: %s}", p.AlbumId, p.Currency, p.Price.String())
+}
+
+// PriceChange is a change of the price of an album in a currency, recorded by
+// postgres for every write, see sql/ddl/create_table_prices.sql. The old price
+// is null for a new price and the new price is null for a removed price.
+type PriceChange struct {
+	tableName   struct{}            `pg:"music.price_history"`
+	Id          int64               `db:"id"`
+	AlbumId     int                 `db:"album_id"`
+	Currency    string              `db:"currency"`
+	OldPrice    decimal.NullDecimal `db:"old_price"`
+	NewPrice    decimal.NullDecimal `db:"new_price"`
+	EffectiveAt time.Time           `db:"effective_at"`
+	ChangedAt   time.Time           `db:"changed_at"`
+	ChangedBy   string              `db:"changed_by"`
+}
+
+// ScheduledPrice is a price change that takes effect at EffectiveAt.
+type ScheduledPrice struct {
+	tableName   struct{}        `pg:"music.scheduled_prices"`
+	Id          int             `db:"id"`
+	AlbumId     int             `db:"album_id"`
+	Currency    string          `db:"currency"`
+	Price       decimal.Decimal `db:"price" pg:",use_zero"`
+	EffectiveAt time.Time       `db:"effective_at"`
+	CreatedBy   string          `db:"created_by"`
+	CreatedAt   time.Time       `db:"created_at"`
+	AppliedAt   *time.Time      `db:"applied_at"`
+}
+
+func (s *ScheduledPrice) String() string {
+	return fmt.Sprintf("ScheduledPrice{Id: %d, AlbumId: %d, Currency: %s, Price: %s, EffectiveAt: %s}", s.Id, s.AlbumId, s.Currency, s.Price.String(), s.EffectiveAt.Format(time.RFC3339))
+}
@@ -0,0 +1,36 @@
+package models
+
+import "fmt"
+
+// Bounds of the number of albums a search returns.
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+)
+
+// SearchResult is an album found by a search, see
+// sql/ddl/create_function_search_albums.sql. The highlights are the title and
+// the artist name, escaped for HTML, with the words matching the search in
+// <mark> tags.
+type SearchResult struct {
+	Album
+	Rank            float64 `db:"rank"`
+	TitleHighlight  string  `db:"title_highlight"`
+	ArtistHighlight string  `db:"artist_highlight"`
+}
+
+// SearchLimit returns the limit bounded by MaxSearchLimit, or
+// DefaultSearchLimit when the limit is not positive.
+func SearchLimit(limit int) int {
+	switch {
+	case limit <= 0:
+		return DefaultSearchLimit
+	case limit > MaxSearchLimit:
+		return MaxSearchLimit
+	}
+	return limit
+}
+
+func (r *SearchResult) String() string {
+	return fmt.Sprintf("SearchResult{Album: %s, Rank: %f}", r.Album.String(), r.Rank)
+}
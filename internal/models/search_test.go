@@ -0,0 +1,21 @@
+package models
+
+import "testing"
+
+func TestSearchLimit(t *testing.T) {
+	tests := []struct {
+		limit int
+		want  int
+	}{
+		{limit: 0, want: DefaultSearchLimit},
+		{limit: -5, want: DefaultSearchLimit},
+		{limit: 5, want: 5},
+		{limit: MaxSearchLimit + 1, want: MaxSearchLimit},
+	}
+
+	for _, tt := range tests {
+		if got := SearchLimit(tt.limit); got != tt.want {
+			t.Errorf("SearchLimit(%d) = %d, want %d", tt.limit, got, tt.want)
+		}
+	}
+}
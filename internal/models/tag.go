@@ -0,0 +1,21 @@
+package models
+
+import "fmt"
+
+// Tag is a free-form label of albums, such as live or remastered.
+type Tag struct {
+	tableName struct{} `pg:"music.tags"`
+	Id        int      `db:"id"`
+	Name      string   `db:"name"`
+}
+
+// AlbumTag relates an album to one of its tags.
+type AlbumTag struct {
+	tableName struct{} `pg:"music.album_tags"`
+	AlbumId   int      `db:"album_id"`
+	TagId     int      `db:"tag_id"`
+}
+
+func (t *Tag) String() string {
+	return fmt.Sprintf("Tag{Id: %d, Name: %s}", t.Id, t.Name)
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var isrcPattern = regexp.MustCompile(`^[A-Z]{2}[A-Z0-9]{3}[0-9]{7}$`)
+
+// Track is a track of an album, identified within the album by its disc and
+// number.
+type Track struct {
+	tableName  struct{} `pg:"music.tracks"`
+	Id         int      `db:"id"`
+	AlbumId    int      `db:"album_id"`
+	Disc       int      `db:"disc"`
+	Number     int      `db:"number"`
+	Title      string   `db:"title"`
+	DurationMs int      `db:"duration_ms" pg:",use_zero"`
+	Isrc       string   `db:"isrc"`
+}
+
+// Normalize defaults the disc to the first disc and writes the ISRC without
+// hyphens in upper case, so that US-S1Z-99-00001 is stored as USS1Z9900001.
+func (t *Track) Normalize() {
+	if t.Disc == 0 {
+		t.Disc = 1
+	}
+	t.Title = strings.TrimSpace(t.Title)
+	t.Isrc = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(t.Isrc), "-", ""))
+}
+
+// Validate checks a normalized track.
+func (t *Track) Validate() error {
+	switch {
+	case t.Disc < 1 || t.Number < 1:
+		return errors.New("disc and number must be positive")
+	case t.Title == "":
+		return errors.New("title is required")
+	case t.DurationMs < 0:
+		return errors.New("duration must not be negative")
+	case t.Isrc != "" && !isrcPattern.MatchString(t.Isrc):
+		return fmt.Errorf("invalid ISRC %q", t.Isrc)
+	}
+	return nil
+}
+
+func (t *Track) String() string {
+	return fmt.Sprintf("Track{Id: %d, AlbumId: %d, Disc: %d, Number: %d, Title: %s, DurationMs: %d, Isrc: %s}", t.Id, t.AlbumId, t.Disc, t.Number, t.Title, t.DurationMs, t.Isrc)
+}
@@ -0,0 +1,42 @@
+package models
+
+import "testing"
+
+func TestTrack_Normalize(t *testing.T) {
+	track := Track{Number: 1, Title: " Blue Train ", Isrc: "us-s1z-99-00001"}
+	track.Normalize()
+
+	if track.Disc != 1 {
+		t.Errorf("Expected Disc 1, got %d", track.Disc)
+	}
+	if track.Title != "Blue Train" {
+		t.Errorf("Expected Title 'Blue Train', got '%s'", track.Title)
+	}
+	if track.Isrc != "USS1Z9900001" {
+		t.Errorf("Expected Isrc 'USS1Z9900001', got '%s'", track.Isrc)
+	}
+}
+
+func TestTrack_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		track   Track
+		wantErr bool
+	}{
+		{"valid track", Track{Disc: 1, Number: 1, Title: "Blue Train", DurationMs: 643000, Isrc: "USS1Z9900001"}, false},
+		{"valid track without ISRC", Track{Disc: 2, Number: 3, Title: "Moment's Notice"}, false},
+		{"missing number", Track{Disc: 1, Title: "Blue Train"}, true},
+		{"missing title", Track{Disc: 1, Number: 1}, true},
+		{"negative duration", Track{Disc: 1, Number: 1, Title: "Blue Train", DurationMs: -1}, true},
+		{"invalid ISRC", Track{Disc: 1, Number: 1, Title: "Blue Train", Isrc: "US123"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.track.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Statuses of a review. Reviews with a text are pending until a moderator
+// approves or rejects them, reviews without one are approved. The ratings of
+// rejected reviews do not count towards the rating of the album.
+const (
+	ReviewPending  = "pending"
+	ReviewApproved = "approved"
+	ReviewRejected = "rejected"
+)
+
+// Bounds of a rating and of the text of a review.
+const (
+	MinRating     = 1
+	MaxRating     = 5
+	MaxReviewBody = 5000
+)
+
+// User rates, reviews and wishlists albums. Price drops are notified in the
+// currency of the user.
+type User struct {
+	tableName struct{}  `pg:"music.users"`
+	Id        int       `db:"id"`
+	Name      string    `db:"name"`
+	Email     string    `db:"email"`
+	Currency  string    `db:"currency"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// Normalize trims the name and the email and writes the currency in upper
+// case, DefaultCurrency when it is empty.
+func (u *User) Normalize() {
+	u.Name = strings.TrimSpace(u.Name)
+	u.Email = strings.TrimSpace(u.Email)
+	u.Currency = strings.ToUpper(strings.TrimSpace(u.Currency))
+	if u.Currency == "" {
+		u.Currency = DefaultCurrency
+	}
+}
+
+// Validate checks a normalized user.
+func (u *User) Validate() error {
+	switch {
+	case u.Name == "":
+		return errors.New("name is required")
+	case !validEmail(u.Email):
+		return fmt.Errorf("invalid email %q", u.Email)
+	case !ValidCurrency(u.Currency):
+		return fmt.Errorf("invalid ISO 4217 currency %q", u.Currency)
+	}
+	return nil
+}
+
+func validEmail(email string) bool {
+	address, err := mail.ParseAddress(email)
+	return err == nil && address.Address == email
+}
+
+func (u *User) String() string {
+	return fmt.Sprintf("User{Id: %d, Name: %s, Email: %s, Currency: %s}", u.Id, u.Name, u.Email, u.Currency)
+}
+
+// Review is the rating of an album by a user with an optional text. Postgres
+// sets the status when the text changes, see sql/ddl/create_table_users.sql.
+type Review struct {
+	tableName struct{}  `pg:"music.reviews"`
+	UserId    int       `db:"user_id" pg:",pk"`
+	AlbumId   int       `db:"album_id" pg:",pk"`
+	Rating    int       `db:"rating"`
+	Body      string    `db:"body" pg:",use_zero"`
+	Status    string    `db:"status"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Normalize trims the text.
+func (r *Review) Normalize() {
+	r.Body = strings.TrimSpace(r.Body)
+}
+
+// Validate checks a normalized review.
+func (r *Review) Validate() error {
+	switch {
+	case r.Rating < MinRating || r.Rating > MaxRating:
+		return fmt.Errorf("rating must be between %d and %d", MinRating, MaxRating)
+	case len([]rune(r.Body)) > MaxReviewBody:
+		return fmt.Errorf("body must not be longer than %d characters", MaxReviewBody)
+	}
+	return nil
+}
+
+func (r *Review) String() string {
+	return fmt.Sprintf("Review{UserId: %d, AlbumId: %d, Rating: %d, Status: %s}", r.UserId, r.AlbumId, r.Rating, r.Status)
+}
+
+// ValidReviewStatus reports whether a review can be moderated to the status.
+func ValidReviewStatus(status string) bool {
+	return status == ReviewApproved || status == ReviewRejected
+}
+
+// WishlistItem is an album on the wishlist of a user.
+type WishlistItem struct {
+	tableName struct{}  `pg:"music.wishlists"`
+	UserId    int       `db:"user_id" pg:",pk"`
+	AlbumId   int       `db:"album_id" pg:",pk"`
+	AddedAt   time.Time `db:"added_at"`
+}
+
+// PriceDropNotification tells a user that the price of an album on their
+// wishlist dropped in their currency. Postgres writes one for every drop, see
+// sql/ddl/create_table_users.sql.
+type PriceDropNotification struct {
+	tableName struct{}        `pg:"music.price_drop_notifications"`
+	Id        int64           `db:"id"`
+	UserId    int             `db:"user_id"`
+	AlbumId   int             `db:"album_id"`
+	Currency  string          `db:"currency"`
+	OldPrice  decimal.Decimal `db:"old_price"`
+	NewPrice  decimal.Decimal `db:"new_price"`
+	CreatedAt time.Time       `db:"created_at"`
+	ReadAt    *time.Time      `db:"read_at"`
+}
+
+func (n *PriceDropNotification) String() string {
+	return fmt.Sprintf("PriceDropNotification{Id: %d, UserId: %d, AlbumId: %d, Price: %s -> %s %s}", n.Id, n.UserId, n.AlbumId, n.OldPrice.String(), n.NewPrice.String(), n.Currency)
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUser_NormalizeValidate(t *testing.T) {
+	user := User{Name: " Ada ", Email: " ada@example.com ", Currency: "eur"}
+	user.Normalize()
+
+	if user.Name != "Ada" || user.Email != "ada@example.com" || user.Currency != "EUR" {
+		t.Errorf("Expected a normalized user, got %s", user.String())
+	}
+	if err := user.Validate(); err != nil {
+		t.Errorf("Expected a valid user, got %v", err)
+	}
+
+	for _, email := range []string{"", "ada", "Ada <ada@example.com>"} {
+		user.Email = email
+		if err := user.Validate(); err == nil {
+			t.Errorf("Expected an error for email %q", email)
+		}
+	}
+
+	user = User{Name: "Ada", Email: "ada@example.com"}
+	user.Normalize()
+	if user.Currency != DefaultCurrency {
+		t.Errorf("Expected currency %s, got %s", DefaultCurrency, user.Currency)
+	}
+}
+
+func TestReview_NormalizeValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		review  Review
+		wantErr bool
+	}{
+		{name: "rating only", review: Review{Rating: 5}},
+		{name: "rating and text", review: Review{Rating: 1, Body: "  Scratched.  "}},
+		{name: "zero rating", review: Review{Rating: 0}, wantErr: true},
+		{name: "rating above five", review: Review{Rating: 6}, wantErr: true},
+		{name: "text too long", review: Review{Rating: 3, Body: strings.Repeat("é", MaxReviewBody+1)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.review.Normalize()
+			if err := tt.review.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidReviewStatus(t *testing.T) {
+	for status, want := range map[string]bool{
+		ReviewApproved: true,
+		ReviewRejected: true,
+		ReviewPending:  false,
+		"spam":         false,
+	} {
+		if got := ValidReviewStatus(status); got != want {
+			t.Errorf("ValidReviewStatus(%q) = %v, want %v", status, got, want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package orders
+
+import (
+	"context"
+	"time"
+
+	"music-service/gen/pb"
+	"music-service/internal/models"
+	"music-service/pkg/kafka"
+)
+
+// Types of the events published when an order is created or changes status.
+const (
+	EventCreated   = "created"
+	EventPaid      = models.OrderPaid
+	EventShipped   = models.OrderShipped
+	EventCancelled = models.OrderCancelled
+	EventRefunded  = models.OrderRefunded
+)
+
+// Publish publishes an event of the type for the order.
+func Publish(ctx context.Context, producer kafka.ProducerHandler, eventType string, order *models.Order) {
+	producer.ProduceOrderEvent(ctx, &pb.OrderEvent{
+		Type:       eventType,
+		Order:      ToOrderProto(order),
+		OccurredAt: time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+func ToOrderProto(order *models.Order) *pb.Order {
+	total, _ := order.Total.Float64()
+	items := make([]*pb.OrderItem, len(order.Items))
+	for i, v := range order.Items {
+		unitPrice, _ := v.UnitPrice.Float64()
+		items[i] = &pb.OrderItem{
+			AlbumId:       int32(v.AlbumId),
+			Format:        v.Format,
+			Quantity:      int32(v.Quantity),
+			UnitPrice:     float32(unitPrice),
+			WarehouseId:   int32(v.WarehouseId),
+			ReservationId: int32(v.ReservationId),
+		}
+	}
+	return &pb.Order{
+		Id:        int32(order.Id),
+		CartId:    int32(order.CartId),
+		Customer:  order.Customer,
+		Status:    order.Status,
+		Currency:  order.Currency,
+		Total:     float32(total),
+		PaymentId: order.PaymentId,
+		Items:     items,
+		CreatedAt: order.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt: order.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func ToCartProto(cart *models.Cart) *pb.Cart {
+	total, _ := cart.Total().Float64()
+	items := make([]*pb.CartItem, len(cart.Items))
+	for i, v := range cart.Items {
+		unitPrice, _ := v.UnitPrice.Float64()
+		items[i] = &pb.CartItem{
+			AlbumId:   int32(v.AlbumId),
+			Format:    v.Format,
+			Quantity:  int32(v.Quantity),
+			UnitPrice: float32(unitPrice),
+		}
+	}
+	return &pb.Cart{
+		Id:       int32(cart.Id),
+		Customer: cart.Customer,
+		Currency: cart.Currency,
+		Status:   cart.Status,
+		Items:    items,
+		Total:    float32(total),
+	}
+}
@@ -0,0 +1,229 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/inventory"
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+	"music-service/pkg/kafka"
+	"music-service/pkg/payment"
+)
+
+var (
+	// ErrEmptyCart is returned when a cart without items is checked out.
+	ErrEmptyCart = errors.New("cart is empty")
+	// ErrReservationsExpired is returned when an order is paid after its
+	// reservations expired. The payment is refunded and the order cancelled.
+	ErrReservationsExpired = errors.New("the reservations of the order expired")
+)
+
+// Service checks out carts and moves orders through their statuses, see
+// models.CanTransition. Checking out reserves the items of the cart, paying
+// commits the reservations and cancelling releases them. Every change is
+// published as an order event.
+type Service struct {
+	orders    orm.OrderRepository
+	inventory orm.InventoryRepository
+	payments  payment.Provider
+	producer  kafka.ProducerHandler
+}
+
+func NewService(orders orm.OrderRepository, inventory orm.InventoryRepository, payments payment.Provider, producer kafka.ProducerHandler) *Service {
+	return &Service{
+		orders:    orders,
+		inventory: inventory,
+		payments:  payments,
+		producer:  producer,
+	}
+}
+
+// Checkout reserves the items of the open cart, in the warehouses with the
+// most available units, and creates a pending order of them. When an item
+// cannot be reserved, the items reserved so far are released and
+// orm.ErrInsufficientStock is returned. When the items of the cart change
+// before the order is created, the reservations are released and
+// orm.ErrCartChanged is returned.
+func (s *Service) Checkout(ctx context.Context, cartId int) (*models.Order, error) {
+	cart, err := s.orders.GetCart(cartId)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case cart.Status != models.CartOpen:
+		return nil, orm.ErrCartClosed
+	case len(cart.Items) == 0:
+		return nil, ErrEmptyCart
+	}
+
+	order := &models.Order{
+		CartId:   cart.Id,
+		Customer: cart.Customer,
+		Currency: cart.Currency,
+		Total:    cart.Total(),
+	}
+	for _, item := range cart.Items {
+		reservation := &models.Reservation{
+			AlbumId:  item.AlbumId,
+			Format:   item.Format,
+			Quantity: item.Quantity,
+		}
+		stock, err := s.inventory.Reserve(reservation, models.DefaultReservationTTL)
+		if err != nil {
+			s.release(order)
+			if errors.Is(err, pg.ErrNoRows) {
+				err = orm.ErrInsufficientStock
+			}
+			return nil, fmt.Errorf("album %d %s: %w", item.AlbumId, item.Format, err)
+		}
+		inventory.PublishLowStock(ctx, s.producer, stock)
+		order.Items = append(order.Items, &models.OrderItem{
+			AlbumId:       item.AlbumId,
+			Format:        item.Format,
+			Quantity:      item.Quantity,
+			UnitPrice:     item.UnitPrice,
+			WarehouseId:   reservation.WarehouseId,
+			ReservationId: reservation.Id,
+		})
+	}
+
+	if err := s.orders.CreateOrder(order); err != nil {
+		s.release(order)
+		return nil, err
+	}
+	Publish(ctx, s.producer, EventCreated, order)
+	return order, nil
+}
+
+// Pay charges the total of the pending order with the payment token and
+// commits its reservations. The order is marked paid before it is charged, so
+// of concurrent payments only one charges, and is back to pending when the
+// charge fails, with payment.ErrDeclined when it was declined. When the
+// reservations expired, the payment is refunded, the order cancelled and
+// ErrReservationsExpired returned.
+func (s *Service) Pay(ctx context.Context, id int, token string) (*models.Order, error) {
+	order, err := s.orders.UpdateStatus(id, models.OrderPending, models.OrderPaid)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentId, err := s.payments.Charge(ctx, payment.Charge{
+		Reference: fmt.Sprintf("order-%d", order.Id),
+		Amount:    order.Total,
+		Currency:  order.Currency,
+		Token:     token,
+	})
+	if err != nil {
+		s.revert(order, models.OrderPending)
+		return nil, err
+	}
+
+	if _, err := s.inventory.CommitAll(reservationIds(order)); err != nil {
+		if refundErr := s.payments.Refund(ctx, paymentId); refundErr != nil {
+			log.Printf("failed to refund payment %s of order %d: %v", paymentId, order.Id, refundErr)
+		}
+		if !errors.Is(err, orm.ErrReservationClosed) && !errors.Is(err, pg.ErrNoRows) {
+			s.revert(order, models.OrderPending)
+			return nil, err
+		}
+		s.release(order)
+		cancelled, err := s.orders.UpdateStatus(order.Id, models.OrderPaid, models.OrderCancelled)
+		if err != nil {
+			log.Printf("failed to cancel order %d: %v", order.Id, err)
+		} else {
+			Publish(ctx, s.producer, EventCancelled, cancelled)
+		}
+		return nil, ErrReservationsExpired
+	}
+
+	order, err = s.orders.SetPayment(order.Id, paymentId)
+	if err != nil {
+		log.Printf("failed to record payment %s of order %d: %v", paymentId, id, err)
+		return nil, err
+	}
+	Publish(ctx, s.producer, EventPaid, order)
+	return order, nil
+}
+
+// Ship marks the paid order shipped.
+func (s *Service) Ship(ctx context.Context, id int) (*models.Order, error) {
+	order, err := s.orders.UpdateStatus(id, models.OrderPaid, models.OrderShipped)
+	if err != nil {
+		return nil, err
+	}
+	Publish(ctx, s.producer, EventShipped, order)
+	return order, nil
+}
+
+// Cancel cancels the pending order and releases its reservations.
+func (s *Service) Cancel(ctx context.Context, id int) (*models.Order, error) {
+	order, err := s.orders.UpdateStatus(id, models.OrderPending, models.OrderCancelled)
+	if err != nil {
+		return nil, err
+	}
+	s.release(order)
+	Publish(ctx, s.producer, EventCancelled, order)
+	return order, nil
+}
+
+// Refund refunds the payment of the paid or shipped order and marks it
+// refunded. The order is back in its status when the refund fails. The units
+// of a paid order are put back on hand, those of a shipped order are not.
+func (s *Service) Refund(ctx context.Context, id int) (*models.Order, error) {
+	order, err := s.orders.GetOrder(id)
+	if err != nil {
+		return nil, err
+	}
+	if !models.CanTransition(order.Status, models.OrderRefunded) {
+		return nil, orm.ErrOrderStatus
+	}
+	status := order.Status
+	order, err = s.orders.UpdateStatus(id, status, models.OrderRefunded)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.payments.Refund(ctx, order.PaymentId); err != nil {
+		s.revert(order, status)
+		return nil, err
+	}
+	if status == models.OrderPaid {
+		if _, err := s.inventory.ReturnAll(reservationIds(order)); err != nil {
+			log.Printf("failed to return the units of order %d: %v", order.Id, err)
+		}
+	}
+	Publish(ctx, s.producer, EventRefunded, order)
+	return order, nil
+}
+
+func reservationIds(order *models.Order) []int {
+	ids := make([]int, len(order.Items))
+	for i, item := range order.Items {
+		ids[i] = item.ReservationId
+	}
+	return ids
+}
+
+// release releases the pending reservations of the order. Reservations that
+// are no longer pending, such as expired ones, are skipped.
+func (s *Service) release(order *models.Order) {
+	for _, item := range order.Items {
+		_, err := s.inventory.Release(item.ReservationId)
+		if err != nil && !errors.Is(err, orm.ErrReservationClosed) && !errors.Is(err, pg.ErrNoRows) {
+			log.Printf("failed to release reservation %d: %v", item.ReservationId, err)
+		}
+	}
+}
+
+// revert changes the order back to the status it had before a change that
+// failed half way.
+func (s *Service) revert(order *models.Order, status string) {
+	if _, err := s.orders.UpdateStatus(order.Id, order.Status, status); err != nil {
+		log.Printf("failed to revert order %d to %s: %v", order.Id, status, err)
+	}
+}
@@ -0,0 +1,350 @@
+package orders
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"music-service/gen/pb"
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+	"music-service/pkg/kafka"
+	"music-service/pkg/payment"
+)
+
+// fakeOrderRepository keeps one cart and the orders in memory
+type fakeOrderRepository struct {
+	orm.OrderRepository
+	cart      *models.Cart
+	orders    map[int]*models.Order
+	createErr error
+}
+
+func (r *fakeOrderRepository) GetCart(id int) (*models.Cart, error) {
+	if r.cart == nil || r.cart.Id != id {
+		return nil, pg.ErrNoRows
+	}
+	return r.cart, nil
+}
+
+func (r *fakeOrderRepository) CreateOrder(order *models.Order) error {
+	if r.createErr != nil {
+		return r.createErr
+	}
+	r.cart.Status = models.CartCheckedOut
+	order.Id = len(r.orders) + 1
+	order.Status = models.OrderPending
+	r.orders[order.Id] = order
+	return nil
+}
+
+func (r *fakeOrderRepository) GetOrder(id int) (*models.Order, error) {
+	order, ok := r.orders[id]
+	if !ok {
+		return nil, pg.ErrNoRows
+	}
+	copied := *order
+	return &copied, nil
+}
+
+func (r *fakeOrderRepository) UpdateStatus(id int, from, to string) (*models.Order, error) {
+	order, ok := r.orders[id]
+	switch {
+	case !ok:
+		return nil, pg.ErrNoRows
+	case order.Status != from:
+		return nil, orm.ErrOrderStatus
+	}
+	order.Status = to
+	return r.GetOrder(id)
+}
+
+func (r *fakeOrderRepository) SetPayment(id int, paymentId string) (*models.Order, error) {
+	r.orders[id].PaymentId = paymentId
+	return r.GetOrder(id)
+}
+
+// fakeInventoryRepository reserves the albums in stock and records the
+// reservations committed, released and returned
+type fakeInventoryRepository struct {
+	orm.InventoryRepository
+	stock     map[int]*models.Stock
+	next      int
+	released  []int
+	committed []int
+	returned  []int
+	commitErr error
+}
+
+func (r *fakeInventoryRepository) Reserve(reservation *models.Reservation, ttl time.Duration) (*models.Stock, error) {
+	stock, ok := r.stock[reservation.AlbumId]
+	switch {
+	case !ok:
+		return nil, pg.ErrNoRows
+	case stock.Available() < reservation.Quantity:
+		return nil, orm.ErrInsufficientStock
+	}
+	stock.Reserved += reservation.Quantity
+	r.next++
+	reservation.Id = r.next
+	reservation.WarehouseId = stock.WarehouseId
+	return stock, nil
+}
+
+func (r *fakeInventoryRepository) CommitAll(ids []int) ([]*models.Reservation, error) {
+	if r.commitErr != nil {
+		return nil, r.commitErr
+	}
+	r.committed = append(r.committed, ids...)
+	return nil, nil
+}
+
+func (r *fakeInventoryRepository) Release(id int) (*models.Reservation, error) {
+	r.released = append(r.released, id)
+	return &models.Reservation{Id: id}, nil
+}
+
+func (r *fakeInventoryRepository) ReturnAll(ids []int) ([]*models.Reservation, error) {
+	r.returned = append(r.returned, ids...)
+	return nil, nil
+}
+
+// fakeProducerHandler records the types of the order and stock events
+type fakeProducerHandler struct {
+	kafka.ProducerHandler
+	orderEvents []string
+	stockEvents int
+}
+
+func (p *fakeProducerHandler) ProduceOrderEvent(ctx context.Context, event *pb.OrderEvent) {
+	p.orderEvents = append(p.orderEvents, event.Type)
+}
+
+func (p *fakeProducerHandler) ProduceStockEvent(ctx context.Context, event *pb.StockEvent) {
+	p.stockEvents++
+}
+
+type fixture struct {
+	orders    *fakeOrderRepository
+	inventory *fakeInventoryRepository
+	payments  *payment.FakeProvider
+	producer  *fakeProducerHandler
+	service   *Service
+}
+
+func newFixture() *fixture {
+	f := &fixture{
+		orders: &fakeOrderRepository{
+			cart: &models.Cart{Id: 1, Customer: "ada", Currency: "USD", Status: models.CartOpen, Items: []*models.CartItem{
+				{CartId: 1, AlbumId: 1, Format: models.FormatVinyl, Quantity: 2, UnitPrice: decimal.RequireFromString("24.99")},
+				{CartId: 1, AlbumId: 2, Format: models.FormatCD, Quantity: 1, UnitPrice: decimal.RequireFromString("9.99")},
+			}},
+			orders: map[int]*models.Order{},
+		},
+		inventory: &fakeInventoryRepository{stock: map[int]*models.Stock{
+			1: {AlbumId: 1, Format: models.FormatVinyl, WarehouseId: 3, OnHand: 10},
+			2: {AlbumId: 2, Format: models.FormatCD, WarehouseId: 4, OnHand: 1},
+		}},
+		payments: payment.NewFakeProvider(),
+		producer: &fakeProducerHandler{},
+	}
+	f.service = NewService(f.orders, f.inventory, f.payments, f.producer)
+	return f
+}
+
+func (f *fixture) checkout(t *testing.T) *models.Order {
+	order, err := f.service.Checkout(context.Background(), 1)
+	require.NoError(t, err)
+	return order
+}
+
+func TestService_Checkout(t *testing.T) {
+	f := newFixture()
+
+	order := f.checkout(t)
+
+	assert.Equal(t, models.OrderPending, order.Status)
+	assert.Equal(t, "ada", order.Customer)
+	assert.Equal(t, "59.97", order.Total.StringFixed(2))
+	require.Len(t, order.Items, 2)
+	assert.Equal(t, 3, order.Items[0].WarehouseId)
+	assert.Equal(t, 1, order.Items[0].ReservationId)
+	assert.Equal(t, 4, order.Items[1].WarehouseId)
+	assert.Equal(t, 2, order.Items[1].ReservationId)
+	assert.Equal(t, models.CartCheckedOut, f.orders.cart.Status)
+	assert.Equal(t, []string{EventCreated}, f.producer.orderEvents)
+	// The CD is sold out by the order
+	assert.Equal(t, 1, f.producer.stockEvents)
+}
+
+func TestService_Checkout_InsufficientStock(t *testing.T) {
+	f := newFixture()
+	f.inventory.stock[2].OnHand = 0
+
+	_, err := f.service.Checkout(context.Background(), 1)
+
+	assert.ErrorIs(t, err, orm.ErrInsufficientStock)
+	assert.Equal(t, []int{1}, f.inventory.released)
+	assert.Empty(t, f.orders.orders)
+	assert.Equal(t, models.CartOpen, f.orders.cart.Status)
+}
+
+func TestService_Checkout_NotStocked(t *testing.T) {
+	f := newFixture()
+	delete(f.inventory.stock, 2)
+
+	_, err := f.service.Checkout(context.Background(), 1)
+
+	assert.ErrorIs(t, err, orm.ErrInsufficientStock)
+	assert.Equal(t, []int{1}, f.inventory.released)
+}
+
+func TestService_Checkout_Cart(t *testing.T) {
+	f := newFixture()
+	_, err := f.service.Checkout(context.Background(), 2)
+	assert.ErrorIs(t, err, pg.ErrNoRows)
+
+	f.orders.cart.Items = nil
+	_, err = f.service.Checkout(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrEmptyCart)
+
+	f.orders.cart.Status = models.CartCheckedOut
+	_, err = f.service.Checkout(context.Background(), 1)
+	assert.ErrorIs(t, err, orm.ErrCartClosed)
+}
+
+func TestService_Checkout_CartChanged(t *testing.T) {
+	f := newFixture()
+	f.orders.createErr = orm.ErrCartChanged
+
+	_, err := f.service.Checkout(context.Background(), 1)
+
+	assert.ErrorIs(t, err, orm.ErrCartChanged)
+	assert.Equal(t, []int{1, 2}, f.inventory.released)
+	assert.Empty(t, f.orders.orders)
+	assert.Empty(t, f.producer.orderEvents)
+}
+
+func TestService_Pay(t *testing.T) {
+	f := newFixture()
+	order := f.checkout(t)
+
+	paid, err := f.service.Pay(context.Background(), order.Id, "tok_visa")
+
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderPaid, paid.Status)
+	assert.Equal(t, []int{1, 2}, f.inventory.committed)
+	charge, ok := f.payments.Payment(paid.PaymentId)
+	require.True(t, ok)
+	assert.Equal(t, "order-1", charge.Reference)
+	assert.True(t, charge.Amount.Equal(order.Total))
+	assert.Equal(t, []string{EventCreated, EventPaid}, f.producer.orderEvents)
+
+	_, err = f.service.Pay(context.Background(), order.Id, "tok_visa")
+	assert.ErrorIs(t, err, orm.ErrOrderStatus)
+}
+
+func TestService_Pay_Declined(t *testing.T) {
+	f := newFixture()
+	order := f.checkout(t)
+
+	_, err := f.service.Pay(context.Background(), order.Id, payment.DeclinedToken)
+
+	assert.ErrorIs(t, err, payment.ErrDeclined)
+	assert.Equal(t, models.OrderPending, f.orders.orders[order.Id].Status)
+	assert.Empty(t, f.inventory.committed)
+	assert.Equal(t, []string{EventCreated}, f.producer.orderEvents)
+}
+
+func TestService_Pay_ReservationsExpired(t *testing.T) {
+	f := newFixture()
+	order := f.checkout(t)
+	f.inventory.commitErr = orm.ErrReservationClosed
+
+	_, err := f.service.Pay(context.Background(), order.Id, "tok_visa")
+
+	assert.ErrorIs(t, err, ErrReservationsExpired)
+	assert.Equal(t, models.OrderCancelled, f.orders.orders[order.Id].Status)
+	assert.Equal(t, []int{1, 2}, f.inventory.released)
+	charge, ok := f.payments.Payment("fake_1")
+	require.True(t, ok)
+	assert.True(t, charge.Refunded)
+	assert.Equal(t, []string{EventCreated, EventCancelled}, f.producer.orderEvents)
+}
+
+func TestService_ShipAndRefund(t *testing.T) {
+	f := newFixture()
+	order := f.checkout(t)
+
+	_, err := f.service.Ship(context.Background(), order.Id)
+	assert.ErrorIs(t, err, orm.ErrOrderStatus)
+	_, err = f.service.Refund(context.Background(), order.Id)
+	assert.ErrorIs(t, err, orm.ErrOrderStatus)
+
+	paid, err := f.service.Pay(context.Background(), order.Id, "tok_visa")
+	require.NoError(t, err)
+	shipped, err := f.service.Ship(context.Background(), order.Id)
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderShipped, shipped.Status)
+	refunded, err := f.service.Refund(context.Background(), order.Id)
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderRefunded, refunded.Status)
+
+	charge, _ := f.payments.Payment(paid.PaymentId)
+	assert.True(t, charge.Refunded)
+	// Shipped units are not returned to the stock
+	assert.Empty(t, f.inventory.returned)
+	assert.Equal(t, []string{EventCreated, EventPaid, EventShipped, EventRefunded}, f.producer.orderEvents)
+}
+
+func TestService_Refund_Paid(t *testing.T) {
+	f := newFixture()
+	order := f.checkout(t)
+	paid, err := f.service.Pay(context.Background(), order.Id, "tok_visa")
+	require.NoError(t, err)
+
+	refunded, err := f.service.Refund(context.Background(), order.Id)
+
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderRefunded, refunded.Status)
+	assert.Equal(t, []int{1, 2}, f.inventory.returned)
+	charge, _ := f.payments.Payment(paid.PaymentId)
+	assert.True(t, charge.Refunded)
+	assert.Equal(t, []string{EventCreated, EventPaid, EventRefunded}, f.producer.orderEvents)
+}
+
+func TestService_Refund_Failed(t *testing.T) {
+	f := newFixture()
+	order := f.checkout(t)
+	_, err := f.service.Pay(context.Background(), order.Id, "tok_visa")
+	require.NoError(t, err)
+	f.orders.orders[order.Id].PaymentId = "fake_404"
+
+	_, err = f.service.Refund(context.Background(), order.Id)
+
+	assert.ErrorIs(t, err, payment.ErrUnknownPayment)
+	assert.Equal(t, models.OrderPaid, f.orders.orders[order.Id].Status)
+	assert.Empty(t, f.inventory.returned)
+}
+
+func TestService_Cancel(t *testing.T) {
+	f := newFixture()
+	order := f.checkout(t)
+
+	cancelled, err := f.service.Cancel(context.Background(), order.Id)
+
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderCancelled, cancelled.Status)
+	assert.Equal(t, []int{1, 2}, f.inventory.released)
+	assert.Equal(t, []string{EventCreated, EventCancelled}, f.producer.orderEvents)
+
+	_, err = f.service.Cancel(context.Background(), order.Id)
+	assert.ErrorIs(t, err, orm.ErrOrderStatus)
+	_, err = f.service.Cancel(context.Background(), 9)
+	assert.ErrorIs(t, err, pg.ErrNoRows)
+}
@@ -0,0 +1,161 @@
+package playlists
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"music-service/internal/models"
+)
+
+// Formats a playlist is exported in.
+const (
+	FormatJSON = "json"
+	FormatM3U  = "m3u"
+	FormatXSPF = "xspf"
+)
+
+// ErrUnknownFormat is returned when a playlist is exported in a format other
+// than FormatJSON, FormatM3U or FormatXSPF.
+var ErrUnknownFormat = errors.New("unknown format, expected one of json, m3u or xspf")
+
+var contentTypes = map[string]string{
+	FormatJSON: "application/json",
+	FormatM3U:  "audio/x-mpegurl",
+	FormatXSPF: "application/xspf+xml",
+}
+
+// ContentType returns the media type of the format, or ErrUnknownFormat.
+func ContentType(format string) (string, error) {
+	contentType, ok := contentTypes[format]
+	if !ok {
+		return "", ErrUnknownFormat
+	}
+	return contentType, nil
+}
+
+// Encode writes the entries of the playlist in the format. The entries are
+// located by their track in the REST API at baseURL, or by their album when
+// they have no track.
+func Encode(w io.Writer, format string, playlist *models.Playlist, entries []*models.PlaylistEntry, baseURL string) error {
+	switch format {
+	case FormatJSON:
+		return encodeJSON(w, playlist, entries)
+	case FormatM3U:
+		return encodeM3U(w, playlist, entries, baseURL)
+	case FormatXSPF:
+		return encodeXSPF(w, playlist, entries, baseURL)
+	}
+	return ErrUnknownFormat
+}
+
+func location(entry *models.PlaylistEntry, baseURL string) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	if entry.TrackId == 0 {
+		return fmt.Sprintf("%s/api/v1/albums/%d", baseURL, entry.AlbumId)
+	}
+	return fmt.Sprintf("%s/api/v1/albums/%d/tracks/%d", baseURL, entry.AlbumId, entry.TrackId)
+}
+
+// title returns the title of the track of the entry, or of its album when it
+// has no track.
+func title(entry *models.PlaylistEntry) string {
+	if entry.TrackId == 0 {
+		return entry.AlbumTitle
+	}
+	return entry.TrackTitle
+}
+
+type jsonPlaylist struct {
+	Id          int
+	Name        string
+	Description string
+	Entries     []*models.PlaylistEntry
+}
+
+func encodeJSON(w io.Writer, playlist *models.Playlist, entries []*models.PlaylistEntry) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(jsonPlaylist{
+		Id:          playlist.Id,
+		Name:        playlist.Name,
+		Description: playlist.Description,
+		Entries:     entries,
+	})
+}
+
+// m3uLine keeps a name on a single line of an M3U playlist.
+var m3uLine = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ")
+
+// encodeM3U writes an extended M3U playlist, with the duration in seconds, or
+// -1 when it is unknown, and the artist and title of every entry.
+func encodeM3U(w io.Writer, playlist *models.Playlist, entries []*models.PlaylistEntry, baseURL string) error {
+	var b strings.Builder
+	b.WriteString("#EXTM3U\n")
+	fmt.Fprintf(&b, "#PLAYLIST:%s\n", m3uLine.Replace(playlist.Name))
+	for _, entry := range entries {
+		seconds := -1
+		if entry.DurationMs > 0 {
+			seconds = (entry.DurationMs + 500) / 1000
+		}
+		fmt.Fprintf(&b, "#EXTINF:%d,%s - %s\n", seconds, m3uLine.Replace(entry.Artist), m3uLine.Replace(title(entry)))
+		fmt.Fprintf(&b, "%s\n", location(entry, baseURL))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// xspfPlaylist is an XML Shareable Playlist, see https://www.xspf.org/spec.
+type xspfPlaylist struct {
+	XMLName    xml.Name      `xml:"http://xspf.org/ns/0/ playlist"`
+	Version    string        `xml:"version,attr"`
+	Title      string        `xml:"title"`
+	Annotation string        `xml:"annotation,omitempty"`
+	TrackList  xspfTrackList `xml:"trackList"`
+}
+
+type xspfTrackList struct {
+	Tracks []xspfTrack `xml:"track"`
+}
+
+type xspfTrack struct {
+	Location string `xml:"location"`
+	Title    string `xml:"title"`
+	Creator  string `xml:"creator"`
+	Album    string `xml:"album"`
+	TrackNum int    `xml:"trackNum"`
+	Duration int    `xml:"duration,omitempty"`
+}
+
+func encodeXSPF(w io.Writer, playlist *models.Playlist, entries []*models.PlaylistEntry, baseURL string) error {
+	document := xspfPlaylist{
+		Version:    "1",
+		Title:      playlist.Name,
+		Annotation: playlist.Description,
+		TrackList:  xspfTrackList{Tracks: make([]xspfTrack, len(entries))},
+	}
+	for i, entry := range entries {
+		document.TrackList.Tracks[i] = xspfTrack{
+			Location: location(entry, baseURL),
+			Title:    title(entry),
+			Creator:  entry.Artist,
+			Album:    entry.AlbumTitle,
+			TrackNum: i + 1,
+			Duration: entry.DurationMs,
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,69 @@
+package playlists
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"music-service/internal/models"
+)
+
+var (
+	exportPlaylist = &models.Playlist{Id: 4, Name: "Road\ntrip", Description: "Long drives"}
+	exportEntries  = []*models.PlaylistEntry{
+		{ItemId: 1, Position: 1, AlbumId: 7, AlbumTitle: "Kind of Blue", Artist: "Miles Davis", TrackId: 11, TrackTitle: "So What", DurationMs: 562400},
+		{ItemId: 2, Position: 2, AlbumId: 8, AlbumTitle: "Blue Train", Artist: "John Coltrane"},
+	}
+)
+
+func TestEncode_M3U(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, Encode(&b, FormatM3U, exportPlaylist, exportEntries, "http://localhost:3000/"))
+
+	assert.Equal(t, "#EXTM3U\n"+
+		"#PLAYLIST:Road trip\n"+
+		"#EXTINF:562,Miles Davis - So What\n"+
+		"http://localhost:3000/api/v1/albums/7/tracks/11\n"+
+		"#EXTINF:-1,John Coltrane - Blue Train\n"+
+		"http://localhost:3000/api/v1/albums/8\n", b.String())
+}
+
+func TestEncode_XSPF(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, Encode(&b, FormatXSPF, exportPlaylist, exportEntries, ""))
+
+	var document xspfPlaylist
+	require.NoError(t, xml.Unmarshal(b.Bytes(), &document))
+	assert.Equal(t, "http://xspf.org/ns/0/", document.XMLName.Space)
+	assert.Equal(t, "1", document.Version)
+	require.Len(t, document.TrackList.Tracks, 2)
+	assert.Equal(t, xspfTrack{
+		Location: "/api/v1/albums/7/tracks/11",
+		Title:    "So What",
+		Creator:  "Miles Davis",
+		Album:    "Kind of Blue",
+		TrackNum: 1,
+		Duration: 562400,
+	}, document.TrackList.Tracks[0])
+	assert.Equal(t, "Blue Train", document.TrackList.Tracks[1].Title)
+}
+
+func TestEncode_JSON(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, Encode(&b, FormatJSON, exportPlaylist, exportEntries, ""))
+
+	var document jsonPlaylist
+	require.NoError(t, json.Unmarshal(b.Bytes(), &document))
+	assert.Equal(t, 4, document.Id)
+	assert.Equal(t, exportEntries, document.Entries)
+}
+
+func TestEncode_UnknownFormat(t *testing.T) {
+	assert.ErrorIs(t, Encode(&bytes.Buffer{}, "pls", exportPlaylist, exportEntries, ""), ErrUnknownFormat)
+	_, err := ContentType("pls")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
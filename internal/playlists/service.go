@@ -0,0 +1,207 @@
+package playlists
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+)
+
+var (
+	// ErrForbidden is returned when a user who can read a playlist changes
+	// what only its owner, or its owner and collaborators, can change.
+	ErrForbidden = errors.New("not allowed to change the playlist")
+	// ErrOwnerCollaborator is returned when the owner of a playlist is added
+	// as a collaborator on it.
+	ErrOwnerCollaborator = errors.New("the owner cannot be a collaborator")
+)
+
+// Service reads and changes playlists on behalf of a user, see
+// models.Playlist.CanView and models.Playlist.CanEdit. Playlists the user
+// cannot read are reported as pg.ErrNoRows, so their existence is not
+// revealed. Only the owner changes the name, visibility, share token and
+// collaborators of a playlist, while collaborators also change its items. A
+// user id of zero is an anonymous user.
+type Service struct {
+	playlists orm.PlaylistRepository
+}
+
+func NewService(playlists orm.PlaylistRepository) *Service {
+	return &Service{playlists: playlists}
+}
+
+// Create creates the normalized, valid playlist owned by the user.
+func (s *Service) Create(playlist *models.Playlist, userId int) error {
+	playlist.Id = 0
+	playlist.OwnerId = userId
+	return s.playlists.Create(playlist)
+}
+
+// Get returns the playlist when the user can read it by its id.
+func (s *Service) Get(id, userId int) (*models.Playlist, error) {
+	playlist, err := s.playlists.GetById(id)
+	if err != nil {
+		return nil, err
+	}
+	if !playlist.CanView(userId) {
+		return nil, pg.ErrNoRows
+	}
+	return playlist, nil
+}
+
+// GetShared returns the public or unlisted playlist with the share token.
+func (s *Service) GetShared(token string) (*models.Playlist, error) {
+	playlist, err := s.playlists.GetByShareToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if playlist.Visibility == models.PlaylistPrivate {
+		return nil, pg.ErrNoRows
+	}
+	return playlist, nil
+}
+
+func (s *Service) GetPublic() ([]*models.Playlist, error) {
+	return s.playlists.GetPublic()
+}
+
+// GetByUser returns the playlists the owner owns or collaborates on that the
+// user can read.
+func (s *Service) GetByUser(ownerId, userId int) ([]*models.Playlist, error) {
+	playlists, err := s.playlists.GetByUser(ownerId)
+	if err != nil {
+		return nil, err
+	}
+	visible := make([]*models.Playlist, 0, len(playlists))
+	for _, playlist := range playlists {
+		if playlist.CanView(userId) {
+			visible = append(visible, playlist)
+		}
+	}
+	return visible, nil
+}
+
+// Update sets the name, description and visibility of the playlist to those
+// of the normalized, valid playlist.
+func (s *Service) Update(playlist *models.Playlist, userId int) error {
+	current, err := s.owned(playlist.Id, userId)
+	if err != nil {
+		return err
+	}
+	if err := s.playlists.Update(playlist); err != nil {
+		return err
+	}
+	playlist.Collaborators = current.Collaborators
+	playlist.Items = current.Items
+	return nil
+}
+
+func (s *Service) Delete(id, userId int) error {
+	if _, err := s.owned(id, userId); err != nil {
+		return err
+	}
+	return s.playlists.Delete(id)
+}
+
+// RotateShareToken replaces the share token of the playlist, revoking the
+// links shared so far.
+func (s *Service) RotateShareToken(id, userId int) (*models.Playlist, error) {
+	if _, err := s.owned(id, userId); err != nil {
+		return nil, err
+	}
+	return s.playlists.RotateShareToken(id)
+}
+
+// AddItem adds the valid item to its playlist as added by the user.
+func (s *Service) AddItem(item *models.PlaylistItem, userId int) error {
+	if _, err := s.editable(item.PlaylistId, userId); err != nil {
+		return err
+	}
+	item.AddedBy = userId
+	return s.playlists.AddItem(item)
+}
+
+func (s *Service) RemoveItem(playlistId, itemId, userId int) error {
+	if _, err := s.editable(playlistId, userId); err != nil {
+		return err
+	}
+	return s.playlists.RemoveItem(playlistId, itemId)
+}
+
+// MoveItem moves the item to the position, or the end when the position is
+// past it.
+func (s *Service) MoveItem(playlistId, itemId, position, userId int) (*models.PlaylistItem, error) {
+	if _, err := s.editable(playlistId, userId); err != nil {
+		return nil, err
+	}
+	return s.playlists.MoveItem(playlistId, itemId, position)
+}
+
+func (s *Service) AddCollaborator(playlistId, collaboratorId, userId int) error {
+	playlist, err := s.owned(playlistId, userId)
+	if err != nil {
+		return err
+	}
+	if collaboratorId == playlist.OwnerId {
+		return ErrOwnerCollaborator
+	}
+	return s.playlists.AddCollaborator(playlistId, collaboratorId)
+}
+
+// RemoveCollaborator removes the collaborator from the playlist, which the
+// owner and the collaborator themselves can do.
+func (s *Service) RemoveCollaborator(playlistId, collaboratorId, userId int) error {
+	if collaboratorId == userId {
+		if _, err := s.editable(playlistId, userId); err != nil {
+			return err
+		}
+	} else if _, err := s.owned(playlistId, userId); err != nil {
+		return err
+	}
+	return s.playlists.RemoveCollaborator(playlistId, collaboratorId)
+}
+
+// Export returns the playlist, which the caller can read, in the format and
+// the media type of the format, see Encode.
+func (s *Service) Export(playlist *models.Playlist, format, baseURL string) ([]byte, string, error) {
+	contentType, err := ContentType(format)
+	if err != nil {
+		return nil, "", err
+	}
+	entries, err := s.playlists.GetEntries(playlist.Id)
+	if err != nil {
+		return nil, "", err
+	}
+	var b bytes.Buffer
+	if err := Encode(&b, format, playlist, entries, baseURL); err != nil {
+		return nil, "", err
+	}
+	return b.Bytes(), contentType, nil
+}
+
+// editable returns the playlist when the user owns or collaborates on it.
+func (s *Service) editable(id, userId int) (*models.Playlist, error) {
+	playlist, err := s.Get(id, userId)
+	if err != nil {
+		return nil, err
+	}
+	if !playlist.CanEdit(userId) {
+		return nil, ErrForbidden
+	}
+	return playlist, nil
+}
+
+// owned returns the playlist when the user owns it.
+func (s *Service) owned(id, userId int) (*models.Playlist, error) {
+	playlist, err := s.Get(id, userId)
+	if err != nil {
+		return nil, err
+	}
+	if playlist.OwnerId != userId {
+		return nil, ErrForbidden
+	}
+	return playlist, nil
+}
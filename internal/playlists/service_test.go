@@ -0,0 +1,214 @@
+package playlists
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"music-service/internal/models"
+	"music-service/internal/repository/postgres/orm"
+)
+
+// fakePlaylistRepository keeps the playlists in memory
+type fakePlaylistRepository struct {
+	orm.PlaylistRepository
+	playlists map[int]*models.Playlist
+	added     []*models.PlaylistItem
+	removed   []int
+}
+
+func newFakePlaylistRepository(playlists ...*models.Playlist) *fakePlaylistRepository {
+	r := &fakePlaylistRepository{playlists: map[int]*models.Playlist{}}
+	for _, playlist := range playlists {
+		r.playlists[playlist.Id] = playlist
+	}
+	return r
+}
+
+func (r *fakePlaylistRepository) GetById(id int) (*models.Playlist, error) {
+	playlist, ok := r.playlists[id]
+	if !ok {
+		return nil, pg.ErrNoRows
+	}
+	return playlist, nil
+}
+
+func (r *fakePlaylistRepository) GetByShareToken(token string) (*models.Playlist, error) {
+	for _, playlist := range r.playlists {
+		if playlist.ShareToken == token {
+			return playlist, nil
+		}
+	}
+	return nil, pg.ErrNoRows
+}
+
+func (r *fakePlaylistRepository) GetByUser(userId int) ([]*models.Playlist, error) {
+	var playlists []*models.Playlist
+	for _, playlist := range r.playlists {
+		if playlist.CanEdit(userId) {
+			playlists = append(playlists, playlist)
+		}
+	}
+	return playlists, nil
+}
+
+func (r *fakePlaylistRepository) Update(playlist *models.Playlist) error {
+	return nil
+}
+
+func (r *fakePlaylistRepository) AddItem(item *models.PlaylistItem) error {
+	r.added = append(r.added, item)
+	return nil
+}
+
+func (r *fakePlaylistRepository) AddCollaborator(playlistId, userId int) error {
+	return nil
+}
+
+func (r *fakePlaylistRepository) RemoveCollaborator(playlistId, userId int) error {
+	r.removed = append(r.removed, userId)
+	playlist := r.playlists[playlistId]
+	playlist.Collaborators = slices.DeleteFunc(playlist.Collaborators, func(id int) bool { return id == userId })
+	return nil
+}
+
+func (r *fakePlaylistRepository) GetEntries(playlistId int) ([]*models.PlaylistEntry, error) {
+	return []*models.PlaylistEntry{}, nil
+}
+
+// Playlists of owner 1 with collaborator 2.
+func newTestPlaylists() (*models.Playlist, *models.Playlist, *models.Playlist) {
+	public := &models.Playlist{Id: 1, OwnerId: 1, Visibility: models.PlaylistPublic, ShareToken: "a", Collaborators: []int{2}}
+	unlisted := &models.Playlist{Id: 2, OwnerId: 1, Visibility: models.PlaylistUnlisted, ShareToken: "b", Collaborators: []int{2}}
+	private := &models.Playlist{Id: 3, OwnerId: 1, Visibility: models.PlaylistPrivate, ShareToken: "c", Collaborators: []int{2}}
+	return public, unlisted, private
+}
+
+func TestService_Read(t *testing.T) {
+	public, unlisted, private := newTestPlaylists()
+	service := NewService(newFakePlaylistRepository(public, unlisted, private))
+
+	_, err := service.Get(public.Id, 0)
+	assert.NoError(t, err)
+	_, err = service.Get(unlisted.Id, 3)
+	assert.ErrorIs(t, err, pg.ErrNoRows, "unlisted playlists are hidden by id")
+	_, err = service.Get(private.Id, 2)
+	assert.NoError(t, err, "collaborators read private playlists")
+
+	_, err = service.GetShared("b")
+	assert.NoError(t, err)
+	_, err = service.GetShared("c")
+	assert.ErrorIs(t, err, pg.ErrNoRows, "private playlists are not shared")
+
+	playlists, err := service.GetByUser(1, 3)
+	require.NoError(t, err)
+	require.Len(t, playlists, 1)
+	assert.Equal(t, public.Id, playlists[0].Id)
+
+	playlists, err = service.GetByUser(1, 1)
+	require.NoError(t, err)
+	assert.Len(t, playlists, 3)
+}
+
+func TestService_Change(t *testing.T) {
+	public, _, private := newTestPlaylists()
+	repository := newFakePlaylistRepository(public, private)
+	service := NewService(repository)
+
+	item := &models.PlaylistItem{PlaylistId: private.Id, AlbumId: 7}
+	require.NoError(t, service.AddItem(item, 2))
+	assert.Equal(t, 2, repository.added[0].AddedBy)
+	assert.ErrorIs(t, service.AddItem(&models.PlaylistItem{PlaylistId: public.Id, AlbumId: 7}, 3), ErrForbidden)
+	assert.ErrorIs(t, service.AddItem(&models.PlaylistItem{PlaylistId: private.Id, AlbumId: 7}, 3), pg.ErrNoRows)
+
+	assert.ErrorIs(t, service.Update(&models.Playlist{Id: private.Id, Name: "Mine"}, 2), ErrForbidden, "only the owner renames")
+	assert.NoError(t, service.Update(&models.Playlist{Id: private.Id, Name: "Mine"}, 1))
+
+	assert.ErrorIs(t, service.AddCollaborator(private.Id, 4, 2), ErrForbidden)
+	assert.ErrorIs(t, service.AddCollaborator(private.Id, 1, 1), ErrOwnerCollaborator)
+	assert.NoError(t, service.AddCollaborator(private.Id, 4, 1))
+
+	assert.NoError(t, service.RemoveCollaborator(private.Id, 2, 2), "collaborators leave")
+	assert.ErrorIs(t, service.RemoveCollaborator(public.Id, 4, 2), ErrForbidden)
+	assert.Equal(t, []int{2}, repository.removed)
+}
+
+func TestService_Export(t *testing.T) {
+	public, _, _ := newTestPlaylists()
+	service := NewService(newFakePlaylistRepository(public))
+
+	data, contentType, err := service.Export(public, FormatM3U, "")
+	require.NoError(t, err)
+	assert.Equal(t, "audio/x-mpegurl", contentType)
+	assert.Equal(t, "#EXTM3U\n#PLAYLIST:\n", string(data))
+
+	_, _, err = service.Export(public, "pls", "")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+// TestService_CollaboratorDenied tests that collaborators only change the
+// items of a playlist, and that nobody else, including former collaborators,
+// changes anything, before the repository is written.
+func TestService_CollaboratorDenied(t *testing.T) {
+	public, _, private := newTestPlaylists()
+	repository := newFakePlaylistRepository(public, private)
+	service := NewService(repository)
+
+	tests := []struct {
+		name   string
+		userId int
+		change func(userId int) error
+	}{
+		{name: "collaborator renames", userId: 2, change: func(userId int) error {
+			return service.Update(&models.Playlist{Id: public.Id, Name: "Mine"}, userId)
+		}},
+		{name: "collaborator deletes", userId: 2, change: func(userId int) error {
+			return service.Delete(public.Id, userId)
+		}},
+		{name: "collaborator rotates share token", userId: 2, change: func(userId int) error {
+			_, err := service.RotateShareToken(public.Id, userId)
+			return err
+		}},
+		{name: "collaborator adds collaborator", userId: 2, change: func(userId int) error {
+			return service.AddCollaborator(public.Id, 4, userId)
+		}},
+		{name: "collaborator removes other collaborator", userId: 2, change: func(userId int) error {
+			return service.RemoveCollaborator(public.Id, 4, userId)
+		}},
+		{name: "other user adds item", userId: 3, change: func(userId int) error {
+			return service.AddItem(&models.PlaylistItem{PlaylistId: public.Id, AlbumId: 7}, userId)
+		}},
+		{name: "other user removes item", userId: 3, change: func(userId int) error {
+			return service.RemoveItem(public.Id, 1, userId)
+		}},
+		{name: "other user moves item", userId: 3, change: func(userId int) error {
+			_, err := service.MoveItem(public.Id, 1, 2, userId)
+			return err
+		}},
+		{name: "other user leaves", userId: 3, change: func(userId int) error {
+			return service.RemoveCollaborator(public.Id, userId, userId)
+		}},
+		{name: "anonymous user adds item", userId: 0, change: func(userId int) error {
+			return service.AddItem(&models.PlaylistItem{PlaylistId: public.Id, AlbumId: 7}, userId)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.change(tt.userId), ErrForbidden)
+		})
+	}
+	assert.Empty(t, repository.added)
+	assert.Empty(t, repository.removed)
+
+	t.Run("former collaborator adds item", func(t *testing.T) {
+		require.NoError(t, service.RemoveCollaborator(private.Id, 2, 1))
+		err := service.AddItem(&models.PlaylistItem{PlaylistId: private.Id, AlbumId: 7}, 2)
+		assert.ErrorIs(t, err, pg.ErrNoRows, "former collaborators no longer see private playlists")
+		err = service.AddItem(&models.PlaylistItem{PlaylistId: public.Id, AlbumId: 7}, 2)
+		assert.NoError(t, err, "collaborators of other playlists keep changing them")
+	})
+}
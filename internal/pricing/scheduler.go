@@ -0,0 +1,50 @@
+package pricing
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"music-service/internal/repository/postgres/orm"
+)
+
+// Scheduler applies the scheduled price changes once they are due. Several
+// schedulers may run at once since postgres hands every change to only one of
+// them, see music.apply_scheduled_prices.
+type Scheduler struct {
+	prices   orm.PriceRepository
+	interval time.Duration
+}
+
+func NewScheduler(prices orm.PriceRepository, interval time.Duration) *Scheduler {
+	return &Scheduler{prices: prices, interval: interval}
+}
+
+// Run applies the due changes right away and then every interval until the
+// context is done. A change is applied at most one interval after it is due.
+// Failures are logged and the changes are retried at the next tick.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		s.Apply()
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Apply applies the changes that are due and returns how many were applied.
+func (s *Scheduler) Apply() int {
+	applied, err := s.prices.ApplyScheduled()
+	if err != nil {
+		log.Printf("failed to apply scheduled prices: %v", err)
+		return 0
+	}
+	if applied > 0 {
+		log.Printf("applied %d scheduled prices", applied)
+	}
+	return applied
+}
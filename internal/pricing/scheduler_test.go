@@ -0,0 +1,51 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"music-service/internal/repository/postgres/orm"
+)
+
+// fakePriceRepository counts the calls of ApplyScheduled
+type fakePriceRepository struct {
+	orm.PriceRepository
+	calls atomic.Int32
+	err   error
+}
+
+func (r *fakePriceRepository) ApplyScheduled() (int, error) {
+	r.calls.Add(1)
+	return 2, r.err
+}
+
+func TestScheduler_Apply(t *testing.T) {
+	prices := &fakePriceRepository{}
+	assert.Equal(t, 2, NewScheduler(prices, time.Minute).Apply())
+
+	prices.err = errors.New("connection refused")
+	assert.Equal(t, 0, NewScheduler(prices, time.Minute).Apply())
+}
+
+func TestScheduler_Run(t *testing.T) {
+	prices := &fakePriceRepository{}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		NewScheduler(prices, time.Millisecond).Run(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool { return prices.calls.Load() >= 3 }, time.Second, time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected Run to return once the context is done")
+	}
+}
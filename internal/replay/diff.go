@@ -0,0 +1,69 @@
+package replay
+
+import (
+	"slices"
+
+	"music-service/internal/models"
+)
+
+// Report compares the albums rebuilt from the event log with the live
+// albums. Prices are not compared because the consumer does not take them
+// from the events.
+type Report struct {
+	Live     int      `json:"live"`
+	Replayed int      `json:"replayed"`
+	Missing  []int    `json:"missing"`
+	Extra    []int    `json:"extra"`
+	Changed  []Change `json:"changed"`
+}
+
+// Change is an album whose replayed state differs from the live one.
+type Change struct {
+	Id       int          `json:"id"`
+	Live     models.Album `json:"live"`
+	Replayed models.Album `json:"replayed"`
+}
+
+// Consistent reports whether every live album was rebuilt unchanged and
+// nothing else was.
+func (r Report) Consistent() bool {
+	return len(r.Missing) == 0 && len(r.Extra) == 0 && len(r.Changed) == 0
+}
+
+// Diff lists the albums missing from the replay, the replayed albums that
+// are not live and the albums whose title or artist differ, sorted by id.
+func Diff(live, replayed []*models.Album) Report {
+	report := Report{
+		Live:     len(live),
+		Replayed: len(replayed),
+		Missing:  []int{},
+		Extra:    []int{},
+		Changed:  []Change{},
+	}
+
+	replayedById := make(map[int]*models.Album, len(replayed))
+	for _, album := range replayed {
+		replayedById[album.Id] = album
+	}
+
+	for _, album := range live {
+		other, ok := replayedById[album.Id]
+		if !ok {
+			report.Missing = append(report.Missing, album.Id)
+			continue
+		}
+		delete(replayedById, album.Id)
+
+		if album.Title != other.Title || album.Artist != other.Artist {
+			report.Changed = append(report.Changed, Change{Id: album.Id, Live: *album, Replayed: *other})
+		}
+	}
+	for id := range replayedById {
+		report.Extra = append(report.Extra, id)
+	}
+
+	slices.Sort(report.Missing)
+	slices.Sort(report.Extra)
+	slices.SortFunc(report.Changed, func(a, b Change) int { return a.Id - b.Id })
+	return report
+}
@@ -0,0 +1,48 @@
+package replay
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"music-service/internal/models"
+)
+
+func TestDiff(t *testing.T) {
+	live := []*models.Album{
+		{Id: 1, Title: "Blue Train", Artist: "John Coltrane", Price: decimal.NewFromFloat(9.99)},
+		{Id: 2, Title: "Kind of Blue", Artist: "Miles Davis"},
+		{Id: 3, Title: "Giant Steps", Artist: "John Coltrane"},
+		{Id: 5, Title: "Time Out", Artist: "Dave Brubeck"},
+	}
+	replayed := []*models.Album{
+		{Id: 5, Title: "Time Out", Artist: "Dave Brubeck"},
+		{Id: 4, Title: "Mingus Ah Um", Artist: "Charles Mingus"},
+		{Id: 1, Title: "Blue Train", Artist: "John Coltrane", Price: decimal.NewFromFloat(0.42)},
+		{Id: 2, Title: "Kind Of Blue", Artist: "Miles Davis"},
+	}
+
+	report := Diff(live, replayed)
+
+	assert.Equal(t, 4, report.Live)
+	assert.Equal(t, 4, report.Replayed)
+	assert.Equal(t, []int{3}, report.Missing)
+	assert.Equal(t, []int{4}, report.Extra)
+	if assert.Len(t, report.Changed, 1) {
+		assert.Equal(t, 2, report.Changed[0].Id)
+		assert.Equal(t, "Kind Of Blue", report.Changed[0].Replayed.Title)
+	}
+	assert.False(t, report.Consistent())
+}
+
+func TestDiff_Consistent(t *testing.T) {
+	albums := []*models.Album{{Id: 1, Title: "Blue Train", Artist: "John Coltrane"}}
+
+	report := Diff(albums, albums)
+
+	assert.True(t, report.Consistent())
+	assert.Empty(t, report.Missing)
+	assert.Empty(t, report.Extra)
+	assert.Empty(t, report.Changed)
+}
@@ -0,0 +1,75 @@
+package orm
+
+import (
+	"time"
+
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+// APIKeyRepository keeps the API keys, see sql/ddl/create_table_api_keys.sql.
+type APIKeyRepository interface {
+	Create(key *models.APIKey) error
+	Get() ([]*models.APIKey, error)
+	GetByPrefix(prefix string) (*models.APIKey, error)
+	Revoke(id int) (*models.APIKey, error)
+	Touch(id int, interval time.Duration) error
+}
+
+type apiKeyRepository struct {
+	db *pg.DB
+}
+
+func NewAPIKeyRepository(db *pg.DB) APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+// Create inserts the key and sets its id and creation time. It returns a
+// unique violation when the prefix is taken.
+func (r *apiKeyRepository) Create(key *models.APIKey) error {
+	_, err := r.db.Model(key).
+		ExcludeColumn("id", "last_used_at", "revoked_at", "created_at").
+		Returning("id, created_at").
+		Insert()
+	return err
+}
+
+// Get returns every key, revoked and expired ones included, the latest first.
+func (r *apiKeyRepository) Get() ([]*models.APIKey, error) {
+	keys := []*models.APIKey{}
+	err := r.db.Model(&keys).Order("created_at DESC", "id DESC").Select()
+	return keys, err
+}
+
+// GetByPrefix returns pg.ErrNoRows when no key has the prefix.
+func (r *apiKeyRepository) GetByPrefix(prefix string) (*models.APIKey, error) {
+	key := &models.APIKey{}
+	err := r.db.Model(key).Where("prefix = ?", prefix).Select()
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Revoke revokes the key, keeping the time of an earlier revocation. It
+// returns pg.ErrNoRows when the key does not exist.
+func (r *apiKeyRepository) Revoke(id int) (*models.APIKey, error) {
+	key := &models.APIKey{}
+	_, err := r.db.QueryOne(key,
+		"UPDATE music.api_keys SET revoked_at = coalesce(revoked_at, now()) WHERE id = ? RETURNING *",
+		id)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Touch records that the key was used now, unless it already was within the
+// interval, which spares a write on every request of a busy client.
+func (r *apiKeyRepository) Touch(id int, interval time.Duration) error {
+	_, err := r.db.Exec(
+		"UPDATE music.api_keys SET last_used_at = now() WHERE id = ? AND (last_used_at IS NULL OR last_used_at < now() - ? * interval '1 second')",
+		id, interval.Seconds())
+	return err
+}
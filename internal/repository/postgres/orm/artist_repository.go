@@ -0,0 +1,74 @@
+package orm
+
+import (
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+type ArtistRepository interface {
+	Create(artist *models.Artist) error
+	GetById(id int) (*models.Artist, error)
+	Get() ([]*models.Artist, error)
+	Update(artist models.Artist) error
+	Delete(id int) error
+	GetAlbums(id int) ([]*models.Album, error)
+}
+
+type artistRepository struct {
+	db *pg.DB
+}
+
+func NewArtistRepository(db *pg.DB) ArtistRepository {
+	return &artistRepository{db: db}
+}
+
+// Create inserts the artist and sets its id.
+func (r *artistRepository) Create(artist *models.Artist) error {
+	_, err := r.db.Model(artist).Returning("id").Insert()
+	return err
+}
+
+func (r *artistRepository) GetById(id int) (*models.Artist, error) {
+	artist := &models.Artist{Id: id}
+	err := r.db.Model(artist).WherePK().Select()
+	return artist, err
+}
+
+func (r *artistRepository) Get() ([]*models.Artist, error) {
+	artists := []*models.Artist{}
+	err := r.db.Model(&artists).Order("id").Select()
+	return artists, err
+}
+
+// Update returns pg.ErrNoRows when the artist does not exist. Renaming an
+// artist renames the artist of its albums.
+func (r *artistRepository) Update(artist models.Artist) error {
+	result, err := r.db.Model(&artist).WherePK().Update()
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pg.ErrNoRows
+	}
+	return nil
+}
+
+// Delete returns pg.ErrNoRows when the artist does not exist and a foreign
+// key violation while the artist has albums.
+func (r *artistRepository) Delete(id int) error {
+	result, err := r.db.Model(&models.Artist{Id: id}).WherePK().Delete()
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pg.ErrNoRows
+	}
+	return nil
+}
+
+func (r *artistRepository) GetAlbums(id int) ([]*models.Album, error) {
+	albums := []*models.Album{}
+	err := r.db.Model(&albums).Where("artist_id = ?", id).Order("id").Select()
+	return albums, err
+}
@@ -0,0 +1,242 @@
+package orm
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+func TestNewArtistRepository(t *testing.T) {
+	t.Run("creates repository successfully", func(t *testing.T) {
+		db := &pg.DB{}
+		repo := NewArtistRepository(db)
+
+		if repo == nil {
+			t.Error("Expected repository to be created, got nil")
+		}
+	})
+
+	t.Run("repository has db field", func(t *testing.T) {
+		db := &pg.DB{}
+		repo := &artistRepository{db: db}
+
+		var _ ArtistRepository = repo
+		if repo.db != db {
+			t.Error("Expected db field to match provided db")
+		}
+	})
+}
+
+func TestArtistRepository_Integration(t *testing.T) {
+	db := testDB(t)
+	repo := NewArtistRepository(db)
+
+	artist := &models.Artist{Name: uniqueName("Artist"), Country: "US"}
+	if err := repo.Create(artist); err != nil {
+		t.Fatalf("Failed to create artist: %v", err)
+	}
+	t.Cleanup(func() { repo.Delete(artist.Id) })
+	if artist.Id == 0 {
+		t.Fatal("Expected Create to set the id")
+	}
+
+	t.Run("rejects a name differing only in case", func(t *testing.T) {
+		err := repo.Create(&models.Artist{Name: strings.ToUpper(artist.Name)})
+		if !IsConflict(err) {
+			t.Errorf("Expected a conflict, got %v", err)
+		}
+	})
+
+	t.Run("reads the artist", func(t *testing.T) {
+		got, err := repo.GetById(artist.Id)
+		if err != nil {
+			t.Fatalf("Failed to get artist: %v", err)
+		}
+		if got.Name != artist.Name || got.Country != "US" {
+			t.Errorf("Expected %s, got %s", artist.String(), got.String())
+		}
+
+		if _, err := repo.GetById(-1); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows, got %v", err)
+		}
+	})
+
+	album := createTestAlbum(t, db, &models.Album{Title: uniqueName("Album"), ArtistId: artist.Id})
+
+	t.Run("lists the albums of the artist", func(t *testing.T) {
+		albums, err := repo.GetAlbums(artist.Id)
+		if err != nil {
+			t.Fatalf("Failed to get albums: %v", err)
+		}
+		if len(albums) != 1 || albums[0].Id != album.Id {
+			t.Errorf("Expected album %d, got %v", album.Id, albums)
+		}
+	})
+
+	t.Run("renames the albums of the artist", func(t *testing.T) {
+		artist.Name = uniqueName("Renamed")
+		if err := repo.Update(*artist); err != nil {
+			t.Fatalf("Failed to update artist: %v", err)
+		}
+		got, err := NewRepository(db).GetById(album.Id)
+		if err != nil {
+			t.Fatalf("Failed to get album: %v", err)
+		}
+		if got.Artist != artist.Name {
+			t.Errorf("Expected album artist %q, got %q", artist.Name, got.Artist)
+		}
+	})
+
+	t.Run("does not update an unknown artist", func(t *testing.T) {
+		if err := repo.Update(models.Artist{Id: -1, Name: uniqueName("Unknown")}); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows, got %v", err)
+		}
+	})
+
+	t.Run("does not delete an artist with albums", func(t *testing.T) {
+		if err := repo.Delete(artist.Id); !IsForeignKeyViolation(err) {
+			t.Errorf("Expected a foreign key violation, got %v", err)
+		}
+	})
+
+	t.Run("does not delete an unknown artist", func(t *testing.T) {
+		if err := repo.Delete(-1); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows, got %v", err)
+		}
+	})
+}
+
+// TestResolveAlbumArtist tests the albums_artist trigger, which relates albums
+// written with only an artist name to their artist.
+func TestResolveAlbumArtist(t *testing.T) {
+	db := testDB(t)
+	artists := NewArtistRepository(db)
+	albums := NewRepository(db)
+
+	artist := &models.Artist{Name: uniqueName("Resolved")}
+	if err := artists.Create(artist); err != nil {
+		t.Fatalf("Failed to create artist: %v", err)
+	}
+	t.Cleanup(func() { artists.Delete(artist.Id) })
+
+	t.Run("relates the album to the artist ignoring case and whitespace", func(t *testing.T) {
+		album := createTestAlbum(t, db, &models.Album{Title: "Resolved", Artist: "  " + strings.ToLower(artist.Name) + " "})
+
+		got, err := albums.GetById(album.Id)
+		if err != nil {
+			t.Fatalf("Failed to get album: %v", err)
+		}
+		if got.ArtistId != artist.Id || got.Artist != artist.Name {
+			t.Errorf("Expected artist %d %q, got %d %q", artist.Id, artist.Name, got.ArtistId, got.Artist)
+		}
+	})
+
+	t.Run("creates the artist of an unknown name", func(t *testing.T) {
+		name := uniqueName("Created")
+		album := createTestAlbum(t, db, &models.Album{Title: "Created", Artist: name})
+
+		created, err := artists.GetById(album.ArtistId)
+		if err != nil {
+			t.Fatalf("Failed to get the created artist: %v", err)
+		}
+		if created.Name != name {
+			t.Errorf("Expected artist %q, got %q", name, created.Name)
+		}
+	})
+
+	t.Run("copies the name of the artist of the id", func(t *testing.T) {
+		album := createTestAlbum(t, db, &models.Album{Title: "Copied", ArtistId: artist.Id, Artist: "Someone Else"})
+
+		got, err := albums.GetById(album.Id)
+		if err != nil {
+			t.Fatalf("Failed to get album: %v", err)
+		}
+		if got.Artist != artist.Name {
+			t.Errorf("Expected artist %q, got %q", artist.Name, got.Artist)
+		}
+	})
+
+	t.Run("relates the album to the artist of a changed name", func(t *testing.T) {
+		other := &models.Artist{Name: uniqueName("Other")}
+		if err := artists.Create(other); err != nil {
+			t.Fatalf("Failed to create artist: %v", err)
+		}
+		t.Cleanup(func() { artists.Delete(other.Id) })
+		album := createTestAlbum(t, db, &models.Album{Title: "Changed", ArtistId: artist.Id})
+
+		if _, err := db.Exec(`UPDATE music.albums SET artist = ? WHERE id = ?`, other.Name, album.Id); err != nil {
+			t.Fatalf("Failed to update album: %v", err)
+		}
+		got, err := albums.GetById(album.Id)
+		if err != nil {
+			t.Fatalf("Failed to get album: %v", err)
+		}
+		if got.ArtistId != other.Id {
+			t.Errorf("Expected artist %d, got %d", other.Id, got.ArtistId)
+		}
+	})
+}
+
+// TestArtistMigration_Dedup tests that sql/ddl/alter_table_albums_artist_id.sql
+// creates one artist per spelling of an artist name, named after the most
+// common spelling. The migration runs in a transaction that is rolled back.
+func TestArtistMigration_Dedup(t *testing.T) {
+	db := testDB(t)
+
+	migration, err := os.ReadFile("../../../../sql/ddl/alter_table_albums_artist_id.sql")
+	if err != nil {
+		t.Fatalf("Failed to read migration: %v", err)
+	}
+	statements := strings.NewReplacer("BEGIN;", "", "COMMIT;", "").Replace(string(migration))
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	// Albums written before the migration have no artist id.
+	if _, err := tx.Exec(`ALTER TABLE music.albums DISABLE TRIGGER albums_artist`); err != nil {
+		t.Fatalf("Failed to disable trigger: %v", err)
+	}
+	name := uniqueName("Dedup")
+	spellings := []string{name, " " + name + " ", strings.ToLower(name), name}
+	ids := make([]int, len(spellings))
+	for i, spelling := range spellings {
+		_, err := tx.QueryOne(pg.Scan(&ids[i]),
+			`INSERT INTO music.albums (title, artist) VALUES (?, ?) RETURNING id`, "Dedup", spelling)
+		if err != nil {
+			t.Fatalf("Failed to insert album: %v", err)
+		}
+	}
+
+	if _, err := tx.Exec(statements); err != nil {
+		t.Fatalf("Failed to run migration: %v", err)
+	}
+
+	var count int
+	if _, err := tx.QueryOne(pg.Scan(&count), `SELECT count(*) FROM music.artists WHERE lower(name) = lower(?)`, name); err != nil {
+		t.Fatalf("Failed to count artists: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 artist, got %d", count)
+	}
+
+	var migrated []models.Album
+	if err := tx.Model(&migrated).Where("id IN (?)", pg.In(ids)).Select(); err != nil {
+		t.Fatalf("Failed to get albums: %v", err)
+	}
+	for _, album := range migrated {
+		if album.ArtistId == 0 || album.ArtistId != migrated[0].ArtistId {
+			t.Errorf("Expected every album to have artist %d, got %d", migrated[0].ArtistId, album.ArtistId)
+		}
+		if album.Artist != name {
+			t.Errorf("Expected artist %q, got %q", name, album.Artist)
+		}
+	}
+}
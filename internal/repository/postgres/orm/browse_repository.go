@@ -0,0 +1,42 @@
+package orm
+
+import (
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+// BrowseRepository filters music.albums and counts the filtered albums for
+// browsing.
+type BrowseRepository interface {
+	Find(filter models.AlbumFilter) ([]*models.Album, error)
+	Facets(filter models.AlbumFilter) (*models.Facets, error)
+}
+
+type browseRepository struct {
+	db *pg.DB
+}
+
+func NewBrowseRepository(db *pg.DB) BrowseRepository {
+	return &browseRepository{db: db}
+}
+
+// Find returns the albums of the filter ordered by id, see
+// sql/ddl/create_function_browse_albums.sql.
+func (r *browseRepository) Find(filter models.AlbumFilter) ([]*models.Album, error) {
+	albums := []*models.Album{}
+	_, err := r.db.Query(&albums, "SELECT * FROM music.find_albums(?, ?, ?, ?, ?)",
+		filter.ArtistId, filter.GenreId, filter.Tag, filter.MinPrice, filter.MaxPrice)
+	return albums, err
+}
+
+// Facets counts the albums of the filter per genre, tag, artist and price.
+func (r *browseRepository) Facets(filter models.AlbumFilter) (*models.Facets, error) {
+	rows := []models.FacetRow{}
+	_, err := r.db.Query(&rows, "SELECT * FROM music.album_facets(?, ?, ?, ?, ?)",
+		filter.ArtistId, filter.GenreId, filter.Tag, filter.MinPrice, filter.MaxPrice)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewFacets(rows), nil
+}
@@ -0,0 +1,86 @@
+package orm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+	"music-service/pkg/postgres"
+	orm_db "music-service/pkg/postgres/orm/db"
+)
+
+// testDB connects to the local practice database with the schema of sql/ddl
+// and skips the integration test when it is not available.
+func testDB(t *testing.T) *pg.DB {
+	t.Helper()
+
+	db := orm_db.NewDB(postgres.Config{
+		User:   "ryandayrit",
+		DBName: "practice",
+		Host:   "localhost",
+	})
+	if err := db.Ping(context.Background()); err != nil {
+		db.Close()
+		t.Skipf("Skipping integration test: database connection failed: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// uniqueName returns a name no other test run uses, so that tests sharing the
+// database do not collide on unique names.
+func uniqueName(prefix string) string {
+	return fmt.Sprintf("%s %d", prefix, time.Now().UnixNano())
+}
+
+// createTestAlbum inserts the album, deleting it, and with it the rows that
+// cascade from it and its price history, when the test ends. Without an artist id the artist is
+// resolved by name, and deleted with the album when no other album has it.
+func createTestAlbum(t *testing.T, db *pg.DB, album *models.Album) *models.Album {
+	t.Helper()
+
+	resolved := album.ArtistId == 0
+	if _, err := db.Model(album).ExcludeColumn(ratingColumns...).Insert(); err != nil {
+		t.Fatalf("Failed to create album: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := db.Model(&models.Album{Id: album.Id}).WherePK().Delete(); err != nil {
+			t.Errorf("Failed to delete album %d: %v", album.Id, err)
+		}
+		if _, err := db.Exec("DELETE FROM music.price_history WHERE album_id = ?", album.Id); err != nil {
+			t.Errorf("Failed to delete price history of album %d: %v", album.Id, err)
+		}
+		if !resolved {
+			return
+		}
+		_, err := db.Exec(`DELETE FROM music.artists
+			WHERE id = ? AND NOT EXISTS (SELECT 1 FROM music.albums WHERE artist_id = ?)`,
+			album.ArtistId, album.ArtistId)
+		if err != nil {
+			t.Errorf("Failed to delete artist %d: %v", album.ArtistId, err)
+		}
+	})
+	return album
+}
+
+// createTestUser creates a user with a unique email, deleting it, and with it
+// the rows that cascade from it, when the test ends.
+func createTestUser(t *testing.T, db *pg.DB, currency string) *models.User {
+	t.Helper()
+
+	user := &models.User{Name: "Jane", Email: strings.ReplaceAll(uniqueName("jane"), " ", "") + "@example.com", Currency: currency}
+	if err := NewUserRepository(db).Create(user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := db.Model(user).WherePK().Delete(); err != nil {
+			t.Errorf("Failed to delete user %d: %v", user.Id, err)
+		}
+	})
+	return user
+}
@@ -0,0 +1,65 @@
+package orm
+
+import (
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+type EditionRepository interface {
+	Create(edition *models.Edition) error
+	GetById(albumId, id int) (*models.Edition, error)
+	GetByAlbum(albumId int) ([]*models.Edition, error)
+	Update(edition models.Edition) error
+	Delete(albumId, id int) error
+}
+
+type editionRepository struct {
+	db *pg.DB
+}
+
+func NewEditionRepository(db *pg.DB) EditionRepository {
+	return &editionRepository{db: db}
+}
+
+// Create inserts the edition and sets its id.
+func (r *editionRepository) Create(edition *models.Edition) error {
+	_, err := r.db.Model(edition).Returning("id").Insert()
+	return err
+}
+
+func (r *editionRepository) GetById(albumId, id int) (*models.Edition, error) {
+	edition := &models.Edition{}
+	err := r.db.Model(edition).Where("id = ? AND album_id = ?", id, albumId).Select()
+	return edition, err
+}
+
+func (r *editionRepository) GetByAlbum(albumId int) ([]*models.Edition, error) {
+	editions := []*models.Edition{}
+	err := r.db.Model(&editions).Where("album_id = ?", albumId).Order("id").Select()
+	return editions, err
+}
+
+// Update returns pg.ErrNoRows when the album has no edition with the id.
+func (r *editionRepository) Update(edition models.Edition) error {
+	result, err := r.db.Model(&edition).Where("id = ? AND album_id = ?", edition.Id, edition.AlbumId).Update()
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pg.ErrNoRows
+	}
+	return nil
+}
+
+// Delete returns pg.ErrNoRows when the album has no edition with the id.
+func (r *editionRepository) Delete(albumId, id int) error {
+	result, err := r.db.Model((*models.Edition)(nil)).Where("id = ? AND album_id = ?", id, albumId).Delete()
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pg.ErrNoRows
+	}
+	return nil
+}
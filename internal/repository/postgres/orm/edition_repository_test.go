@@ -0,0 +1,102 @@
+package orm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/shopspring/decimal"
+
+	"music-service/internal/models"
+)
+
+func TestNewEditionRepository(t *testing.T) {
+	t.Run("creates repository successfully", func(t *testing.T) {
+		db := &pg.DB{}
+		var repo EditionRepository = NewEditionRepository(db)
+
+		if repo == nil {
+			t.Error("Expected repository to be created, got nil")
+		}
+	})
+}
+
+func TestEditionRepository_Integration(t *testing.T) {
+	db := testDB(t)
+	repo := NewEditionRepository(db)
+	album := createTestAlbum(t, db, &models.Album{Title: "Blue Train", Artist: uniqueName("Editions")})
+	other := createTestAlbum(t, db, &models.Album{Title: "Giant Steps", Artist: album.Artist})
+
+	// SKUs and barcodes are unique across albums, so the test makes its own.
+	sku := uniqueName("BLP")
+	vinyl := &models.Edition{AlbumId: album.Id, Format: models.FormatVinyl, Sku: sku, Price: decimal.RequireFromString("29.99")}
+	if err := repo.Create(vinyl); err != nil {
+		t.Fatalf("Failed to create edition: %v", err)
+	}
+
+	t.Run("reads the edition of the album", func(t *testing.T) {
+		got, err := repo.GetById(album.Id, vinyl.Id)
+		if err != nil {
+			t.Fatalf("Failed to get edition: %v", err)
+		}
+		if got.Sku != sku || !got.Price.Equal(vinyl.Price) {
+			t.Errorf("Expected %s, got %s", vinyl.String(), got.String())
+		}
+
+		editions, err := repo.GetByAlbum(album.Id)
+		if err != nil || len(editions) != 1 {
+			t.Errorf("Expected 1 edition of the album, got %v, %v", editions, err)
+		}
+	})
+
+	t.Run("rejects a taken sku", func(t *testing.T) {
+		err := repo.Create(&models.Edition{AlbumId: other.Id, Format: models.FormatCD, Sku: sku})
+		if !IsConflict(err) {
+			t.Errorf("Expected a conflict, got %v", err)
+		}
+	})
+
+	t.Run("rejects a barcode that is not a UPC or EAN", func(t *testing.T) {
+		err := repo.Create(&models.Edition{AlbumId: album.Id, Format: models.FormatCD, Sku: uniqueName("CDP"), Barcode: "12345"})
+		if !IsInvalid(err) {
+			t.Errorf("Expected an invalid edition, got %v", err)
+		}
+	})
+
+	t.Run("rejects an unknown format", func(t *testing.T) {
+		err := repo.Create(&models.Edition{AlbumId: album.Id, Format: "8-track", Sku: uniqueName("8T")})
+		if !IsInvalid(err) {
+			t.Errorf("Expected an invalid edition, got %v", err)
+		}
+	})
+
+	t.Run("rejects an edition of an unknown album", func(t *testing.T) {
+		err := repo.Create(&models.Edition{AlbumId: -1, Format: models.FormatCD, Sku: uniqueName("CDP")})
+		if !IsForeignKeyViolation(err) {
+			t.Errorf("Expected a foreign key violation, got %v", err)
+		}
+	})
+
+	t.Run("does not read, update or delete the edition through another album", func(t *testing.T) {
+		if _, err := repo.GetById(other.Id, vinyl.Id); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows reading, got %v", err)
+		}
+		moved := *vinyl
+		moved.AlbumId = other.Id
+		if err := repo.Update(moved); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows updating, got %v", err)
+		}
+		if err := repo.Delete(other.Id, vinyl.Id); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows deleting, got %v", err)
+		}
+	})
+
+	t.Run("deletes the edition", func(t *testing.T) {
+		if err := repo.Delete(album.Id, vinyl.Id); err != nil {
+			t.Fatalf("Failed to delete edition: %v", err)
+		}
+		if _, err := repo.GetById(album.Id, vinyl.Id); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package orm
+
+import (
+	"errors"
+
+	"github.com/go-pg/pg/v10"
+)
+
+// IsConflict reports whether the error violates a unique or foreign key
+// constraint, such as a duplicate artist name or deleting an artist that
+// still has albums.
+func IsConflict(err error) bool {
+	code := sqlState(err)
+	return code == "23505" || code == "23503"
+}
+
+// IsForeignKeyViolation reports whether the error violates a foreign key
+// constraint, such as a track of an album that does not exist.
+func IsForeignKeyViolation(err error) bool {
+	return sqlState(err) == "23503"
+}
+
+// IsInvalid reports whether the error violates a not-null or check constraint.
+func IsInvalid(err error) bool {
+	code := sqlState(err)
+	return code == "23502" || code == "23514"
+}
+
+func sqlState(err error) string {
+	var pgErr pg.Error
+	if errors.As(err, &pgErr) {
+		return pgErr.Field('C')
+	}
+	return ""
+}
@@ -0,0 +1,95 @@
+package orm
+
+import (
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+type GenreRepository interface {
+	Create(genre *models.Genre) error
+	GetById(id int) (*models.Genre, error)
+	Get() ([]*models.Genre, error)
+	Update(genre models.Genre) error
+	Delete(id int) error
+	GetByAlbum(albumId int) ([]*models.Genre, error)
+	SetAlbumGenres(albumId int, genreIds []int) error
+}
+
+type genreRepository struct {
+	db *pg.DB
+}
+
+func NewGenreRepository(db *pg.DB) GenreRepository {
+	return &genreRepository{db: db}
+}
+
+// Create inserts the genre and sets its id.
+func (r *genreRepository) Create(genre *models.Genre) error {
+	_, err := r.db.Model(genre).Returning("id").Insert()
+	return err
+}
+
+func (r *genreRepository) GetById(id int) (*models.Genre, error) {
+	genre := &models.Genre{Id: id}
+	err := r.db.Model(genre).WherePK().Select()
+	return genre, err
+}
+
+// Get returns every genre ordered by id, so parents usually come first.
+func (r *genreRepository) Get() ([]*models.Genre, error) {
+	genres := []*models.Genre{}
+	err := r.db.Model(&genres).Order("id").Select()
+	return genres, err
+}
+
+// Update returns pg.ErrNoRows when there is no genre with the id.
+func (r *genreRepository) Update(genre models.Genre) error {
+	result, err := r.db.Model(&genre).WherePK().Update()
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pg.ErrNoRows
+	}
+	return nil
+}
+
+// Delete returns pg.ErrNoRows when there is no genre with the id. Genres with
+// genres below them cannot be deleted.
+func (r *genreRepository) Delete(id int) error {
+	result, err := r.db.Model(&models.Genre{Id: id}).WherePK().Delete()
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pg.ErrNoRows
+	}
+	return nil
+}
+
+func (r *genreRepository) GetByAlbum(albumId int) ([]*models.Genre, error) {
+	genres := []*models.Genre{}
+	err := r.db.Model(&genres).
+		Where("id IN (SELECT genre_id FROM music.album_genres WHERE album_id = ?)", albumId).
+		Order("id").
+		Select()
+	return genres, err
+}
+
+// SetAlbumGenres replaces the genres of the album.
+func (r *genreRepository) SetAlbumGenres(albumId int, genreIds []int) error {
+	return r.db.RunInTransaction(r.db.Context(), func(tx *pg.Tx) error {
+		_, err := tx.Model((*models.AlbumGenre)(nil)).Where("album_id = ?", albumId).Delete()
+		if err != nil || len(genreIds) == 0 {
+			return err
+		}
+
+		albumGenres := make([]models.AlbumGenre, len(genreIds))
+		for i, genreId := range genreIds {
+			albumGenres[i] = models.AlbumGenre{AlbumId: albumId, GenreId: genreId}
+		}
+		_, err = tx.Model(&albumGenres).OnConflict("DO NOTHING").Insert()
+		return err
+	})
+}
@@ -0,0 +1,121 @@
+package orm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+func TestNewGenreRepository(t *testing.T) {
+	t.Run("creates repository successfully", func(t *testing.T) {
+		db := &pg.DB{}
+		var repo GenreRepository = NewGenreRepository(db)
+
+		if repo == nil {
+			t.Error("Expected repository to be created, got nil")
+		}
+	})
+}
+
+// createTestGenre creates the genre, deleting it when the test ends.
+func createTestGenre(t *testing.T, repo GenreRepository, genre *models.Genre) *models.Genre {
+	t.Helper()
+
+	if err := repo.Create(genre); err != nil {
+		t.Fatalf("Failed to create genre: %v", err)
+	}
+	t.Cleanup(func() { repo.Delete(genre.Id) })
+	return genre
+}
+
+func TestGenreRepository_Integration(t *testing.T) {
+	db := testDB(t)
+	repo := NewGenreRepository(db)
+
+	jazz := createTestGenre(t, repo, &models.Genre{Name: uniqueName("Jazz")})
+	hardBop := createTestGenre(t, repo, &models.Genre{Name: "Hard Bop", ParentId: jazz.Id})
+
+	t.Run("rejects a sibling differing only in case", func(t *testing.T) {
+		err := repo.Create(&models.Genre{Name: "hard bop", ParentId: jazz.Id})
+		if !IsConflict(err) {
+			t.Errorf("Expected a conflict, got %v", err)
+		}
+	})
+
+	t.Run("rejects a genre below itself", func(t *testing.T) {
+		moved := *jazz
+		moved.ParentId = hardBop.Id
+		if err := repo.Update(moved); !IsInvalid(err) {
+			t.Errorf("Expected an invalid genre, got %v", err)
+		}
+	})
+
+	t.Run("does not delete a genre with genres below it", func(t *testing.T) {
+		if err := repo.Delete(jazz.Id); !IsForeignKeyViolation(err) {
+			t.Errorf("Expected a foreign key violation, got %v", err)
+		}
+	})
+
+	t.Run("does not update or delete an unknown genre", func(t *testing.T) {
+		if err := repo.Update(models.Genre{Id: -1, Name: "Unknown"}); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows updating, got %v", err)
+		}
+		if err := repo.Delete(-1); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows deleting, got %v", err)
+		}
+	})
+
+	album := createTestAlbum(t, db, &models.Album{Title: "Blue Train", Artist: uniqueName("Genres")})
+
+	t.Run("replaces the genres of the album", func(t *testing.T) {
+		if err := repo.SetAlbumGenres(album.Id, []int{jazz.Id, hardBop.Id, hardBop.Id}); err != nil {
+			t.Fatalf("Failed to set genres: %v", err)
+		}
+		if err := repo.SetAlbumGenres(album.Id, []int{hardBop.Id}); err != nil {
+			t.Fatalf("Failed to set genres: %v", err)
+		}
+		genres, err := repo.GetByAlbum(album.Id)
+		if err != nil {
+			t.Fatalf("Failed to get genres: %v", err)
+		}
+		if len(genres) != 1 || genres[0].Id != hardBop.Id {
+			t.Errorf("Expected genre %d, got %v", hardBop.Id, genres)
+		}
+	})
+
+	t.Run("rejects an unknown genre of the album", func(t *testing.T) {
+		if err := repo.SetAlbumGenres(album.Id, []int{-1}); !IsForeignKeyViolation(err) {
+			t.Errorf("Expected a foreign key violation, got %v", err)
+		}
+		genres, err := repo.GetByAlbum(album.Id)
+		if err != nil {
+			t.Fatalf("Failed to get genres: %v", err)
+		}
+		if len(genres) != 1 {
+			t.Errorf("Expected the failed change to be rolled back, got %v", genres)
+		}
+	})
+
+	t.Run("browses the albums of the genres below the genre", func(t *testing.T) {
+		albums, err := NewBrowseRepository(db).Find(models.AlbumFilter{GenreId: jazz.Id})
+		if err != nil {
+			t.Fatalf("Failed to find albums: %v", err)
+		}
+		if len(albums) != 1 || albums[0].Id != album.Id {
+			t.Errorf("Expected album %d, got %v", album.Id, albums)
+		}
+
+		facets, err := NewBrowseRepository(db).Facets(models.AlbumFilter{GenreId: jazz.Id})
+		if err != nil {
+			t.Fatalf("Failed to count albums: %v", err)
+		}
+		for _, genre := range facets.Genres {
+			if genre.Id == jazz.Id && genre.Count != 1 {
+				t.Errorf("Expected 1 album of %s, got %d", genre.Name, genre.Count)
+			}
+		}
+	})
+}
@@ -0,0 +1,258 @@
+package orm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+var (
+	// ErrInsufficientStock is returned when fewer units are available than
+	// requested.
+	ErrInsufficientStock = errors.New("insufficient stock")
+	// ErrReservationClosed is returned when a reservation is no longer
+	// pending, or has expired, and cannot be committed or released, or is not
+	// committed and cannot be returned.
+	ErrReservationClosed = errors.New("reservation is no longer pending")
+)
+
+// InventoryRepository keeps the stock of album formats per warehouse, see
+// sql/ddl/create_table_inventory.sql. Reservations change the stock with
+// conditional updates, which postgres serializes per stock row, so concurrent
+// reservations never hold more units than are on hand.
+type InventoryRepository interface {
+	CreateWarehouse(warehouse *models.Warehouse) error
+	GetWarehouses() ([]*models.Warehouse, error)
+	GetStock(albumId int) ([]*models.Stock, error)
+	SetStock(stock *models.Stock) error
+	Reserve(reservation *models.Reservation, ttl time.Duration) (*models.Stock, error)
+	GetReservation(id int) (*models.Reservation, error)
+	Commit(id int) (*models.Reservation, error)
+	CommitAll(ids []int) ([]*models.Reservation, error)
+	Release(id int) (*models.Reservation, error)
+	ReturnAll(ids []int) ([]*models.Reservation, error)
+	ExpireReservations() (int, error)
+}
+
+type inventoryRepository struct {
+	db *pg.DB
+}
+
+func NewInventoryRepository(db *pg.DB) InventoryRepository {
+	return &inventoryRepository{db: db}
+}
+
+// CreateWarehouse inserts the warehouse and sets its id.
+func (r *inventoryRepository) CreateWarehouse(warehouse *models.Warehouse) error {
+	_, err := r.db.Model(warehouse).Returning("id").Insert()
+	return err
+}
+
+func (r *inventoryRepository) GetWarehouses() ([]*models.Warehouse, error) {
+	warehouses := []*models.Warehouse{}
+	err := r.db.Model(&warehouses).Order("id").Select()
+	return warehouses, err
+}
+
+// GetStock returns the stock of every format of the album in every warehouse.
+func (r *inventoryRepository) GetStock(albumId int) ([]*models.Stock, error) {
+	stock := []*models.Stock{}
+	err := r.db.Model(&stock).
+		Where("album_id = ?", albumId).
+		Order("format", "warehouse_id").
+		Select()
+	return stock, err
+}
+
+// SetStock sets the units on hand and the low-stock threshold, keeping the
+// reserved units, which it reads back into the stock. It returns a
+// foreign key violation when the album or the warehouse does not exist and a
+// check violation when fewer units would be on hand than are reserved.
+func (r *inventoryRepository) SetStock(stock *models.Stock) error {
+	_, err := r.db.Model(stock).
+		ExcludeColumn("reserved").
+		OnConflict("(album_id, format, warehouse_id) DO UPDATE").
+		Set("on_hand = EXCLUDED.on_hand").
+		Set("low_stock_threshold = EXCLUDED.low_stock_threshold").
+		Returning("*").
+		Insert()
+	return err
+}
+
+// Reserve holds the quantity of the reservation for the ttl, and sets the id,
+// warehouse, status and times of the reservation. A reservation without a
+// warehouse is held in the warehouse with the most available units. It
+// returns the stock after the reservation, pg.ErrNoRows when the album format
+// is not stocked in the warehouse and ErrInsufficientStock when fewer units
+// are available.
+func (r *inventoryRepository) Reserve(reservation *models.Reservation, ttl time.Duration) (*models.Stock, error) {
+	stock := &models.Stock{}
+	err := r.db.RunInTransaction(r.db.Context(), func(tx *pg.Tx) error {
+		_, err := tx.QueryOne(stock, `
+			UPDATE music.stock SET reserved = reserved + ?0
+			WHERE (album_id, format, warehouse_id) = (
+				SELECT album_id, format, warehouse_id FROM music.stock
+				WHERE album_id = ?1 AND format = ?2 AND (?3 = 0 OR warehouse_id = ?3)
+				  AND on_hand - reserved >= ?0
+				ORDER BY on_hand - reserved DESC, warehouse_id
+				LIMIT 1
+				FOR UPDATE)
+			  AND on_hand - reserved >= ?0
+			RETURNING *`,
+			reservation.Quantity, reservation.AlbumId, reservation.Format, reservation.WarehouseId)
+		if errors.Is(err, pg.ErrNoRows) {
+			stocked, err := tx.Model((*models.Stock)(nil)).
+				Where("album_id = ?0 AND format = ?1 AND (?2 = 0 OR warehouse_id = ?2)", reservation.AlbumId, reservation.Format, reservation.WarehouseId).
+				Exists()
+			if err != nil {
+				return err
+			}
+			if !stocked {
+				return pg.ErrNoRows
+			}
+			return ErrInsufficientStock
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.QueryOne(reservation,
+			"INSERT INTO music.stock_reservations (album_id, format, warehouse_id, quantity, status, expires_at) "+
+				"VALUES (?, ?, ?, ?, ?, now() + ? * interval '1 millisecond') RETURNING *",
+			stock.AlbumId, stock.Format, stock.WarehouseId, reservation.Quantity,
+			models.ReservationPending, ttl.Milliseconds())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stock, nil
+}
+
+func (r *inventoryRepository) GetReservation(id int) (*models.Reservation, error) {
+	reservation := &models.Reservation{Id: id}
+	err := r.db.Model(reservation).WherePK().Select()
+	return reservation, err
+}
+
+// Commit ships the units of a pending reservation that has not expired,
+// taking them off hand. It returns pg.ErrNoRows when the reservation does not
+// exist and ErrReservationClosed when it is not pending or has expired.
+func (r *inventoryRepository) Commit(id int) (*models.Reservation, error) {
+	reservations, err := r.CommitAll([]int{id})
+	if err != nil {
+		return nil, err
+	}
+	return reservations[0], nil
+}
+
+// CommitAll commits the reservations in one transaction, so either all of
+// them are committed or none, such as the reservations of an order.
+func (r *inventoryRepository) CommitAll(ids []int) ([]*models.Reservation, error) {
+	return r.close(ids, models.ReservationPending, models.ReservationCommitted,
+		"expires_at > now()",
+		"on_hand = on_hand - ?0, reserved = reserved - ?0")
+}
+
+// Release returns the units of a pending reservation to the available units.
+// It returns pg.ErrNoRows when the reservation does not exist and
+// ErrReservationClosed when it is not pending.
+func (r *inventoryRepository) Release(id int) (*models.Reservation, error) {
+	reservations, err := r.close([]int{id}, models.ReservationPending, models.ReservationReleased,
+		"true",
+		"reserved = reserved - ?0")
+	if err != nil {
+		return nil, err
+	}
+	return reservations[0], nil
+}
+
+// ReturnAll puts the units of committed reservations back on hand in one
+// transaction, such as the reservations of an order refunded before it
+// shipped. It returns pg.ErrNoRows when a reservation does not exist and
+// ErrReservationClosed when it is not committed, so units are returned at
+// most once.
+func (r *inventoryRepository) ReturnAll(ids []int) ([]*models.Reservation, error) {
+	return r.close(ids, models.ReservationCommitted, models.ReservationReturned,
+		"true",
+		"on_hand = on_hand + ?0")
+}
+
+// close changes the status of the reservations in the status it is changed
+// from and matching the condition, and applies the assignments, with ?0 as
+// the quantity of a reservation, to their stock. Updating a reservation first
+// locks it, so a reservation is closed at most once.
+func (r *inventoryRepository) close(ids []int, from, to, condition, assignments string) ([]*models.Reservation, error) {
+	reservations := make([]*models.Reservation, 0, len(ids))
+	err := r.db.RunInTransaction(r.db.Context(), func(tx *pg.Tx) error {
+		for _, id := range ids {
+			reservation := &models.Reservation{}
+			_, err := tx.QueryOne(reservation,
+				"UPDATE music.stock_reservations SET status = ? "+
+					"WHERE id = ? AND status = ? AND "+condition+" RETURNING *",
+				to, id, from)
+			if errors.Is(err, pg.ErrNoRows) {
+				exists, err := tx.Model(&models.Reservation{Id: id}).WherePK().Exists()
+				if err != nil {
+					return err
+				}
+				if !exists {
+					return pg.ErrNoRows
+				}
+				return ErrReservationClosed
+			}
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.Exec("UPDATE music.stock SET "+assignments+" "+
+				"WHERE album_id = ?1 AND format = ?2 AND warehouse_id = ?3",
+				reservation.Quantity, reservation.AlbumId, reservation.Format, reservation.WarehouseId)
+			if err != nil {
+				return err
+			}
+			reservations = append(reservations, reservation)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+// ExpireReservations expires the pending reservations past their expiry,
+// releasing their units, and returns how many expired. Reservations being
+// committed or released at the same time are skipped, as are those another
+// caller is expiring.
+func (r *inventoryRepository) ExpireReservations() (int, error) {
+	var expired int
+	_, err := r.db.QueryOne(pg.Scan(&expired), `
+		WITH expired AS (
+			UPDATE music.stock_reservations
+			SET status = ?
+			WHERE id IN (
+				SELECT id FROM music.stock_reservations
+				WHERE status = ? AND expires_at <= now()
+				ORDER BY id
+				FOR UPDATE SKIP LOCKED)
+			RETURNING album_id, format, warehouse_id, quantity
+		), released AS (
+			UPDATE music.stock
+			SET reserved = stock.reserved - totals.quantity
+			FROM (
+				SELECT album_id, format, warehouse_id, sum(quantity)::integer AS quantity
+				FROM expired
+				GROUP BY album_id, format, warehouse_id
+			) totals
+			WHERE stock.album_id = totals.album_id
+			  AND stock.format = totals.format
+			  AND stock.warehouse_id = totals.warehouse_id
+		)
+		SELECT count(*) FROM expired`,
+		models.ReservationExpired, models.ReservationPending)
+	return expired, err
+}
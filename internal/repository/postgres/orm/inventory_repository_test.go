@@ -0,0 +1,213 @@
+package orm
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+func TestNewInventoryRepository(t *testing.T) {
+	t.Run("creates repository successfully", func(t *testing.T) {
+		db := &pg.DB{}
+		var repo InventoryRepository = NewInventoryRepository(db)
+
+		if repo == nil {
+			t.Error("Expected repository to be created, got nil")
+		}
+	})
+}
+
+// createTestWarehouse creates the warehouse, deleting it when the test ends.
+// Register it before the albums stocked in it, whose stock has to be deleted
+// first.
+func createTestWarehouse(t *testing.T, db *pg.DB, repo InventoryRepository, code string) *models.Warehouse {
+	t.Helper()
+
+	warehouse := &models.Warehouse{Code: uniqueName(code), Name: code}
+	if err := repo.CreateWarehouse(warehouse); err != nil {
+		t.Fatalf("Failed to create warehouse: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := db.Model(warehouse).WherePK().Delete(); err != nil {
+			t.Errorf("Failed to delete warehouse %d: %v", warehouse.Id, err)
+		}
+	})
+	return warehouse
+}
+
+func TestInventoryRepository_Integration(t *testing.T) {
+	db := testDB(t)
+	repo := NewInventoryRepository(db)
+
+	east := createTestWarehouse(t, db, repo, "EAST")
+	west := createTestWarehouse(t, db, repo, "WEST")
+	album := createTestAlbum(t, db, &models.Album{Title: "Blue Train", Artist: uniqueName("Inventory")})
+
+	for _, stock := range []*models.Stock{
+		{AlbumId: album.Id, Format: models.FormatVinyl, WarehouseId: east.Id, OnHand: 5},
+		{AlbumId: album.Id, Format: models.FormatVinyl, WarehouseId: west.Id, OnHand: 2},
+	} {
+		if err := repo.SetStock(stock); err != nil {
+			t.Fatalf("Failed to set stock: %v", err)
+		}
+	}
+
+	reserve := func(warehouseId, quantity int, ttl time.Duration) (*models.Reservation, *models.Stock, error) {
+		reservation := &models.Reservation{AlbumId: album.Id, Format: models.FormatVinyl, WarehouseId: warehouseId, Quantity: quantity}
+		stock, err := repo.Reserve(reservation, ttl)
+		return reservation, stock, err
+	}
+
+	t.Run("reserves in the warehouse with the most available units", func(t *testing.T) {
+		reservation, stock, err := reserve(0, 3, time.Hour)
+		if err != nil {
+			t.Fatalf("Failed to reserve: %v", err)
+		}
+		if reservation.WarehouseId != east.Id || reservation.Status != models.ReservationPending {
+			t.Errorf("Expected a pending reservation in warehouse %d, got %v", east.Id, reservation)
+		}
+		if stock.Available() != 2 {
+			t.Errorf("Expected 2 available units, got %d", stock.Available())
+		}
+		if _, err := repo.Release(reservation.Id); err != nil {
+			t.Fatalf("Failed to release: %v", err)
+		}
+	})
+
+	t.Run("does not oversell", func(t *testing.T) {
+		if _, _, err := reserve(west.Id, 3, time.Hour); !errors.Is(err, ErrInsufficientStock) {
+			t.Errorf("Expected ErrInsufficientStock in the warehouse, got %v", err)
+		}
+		if _, _, err := reserve(0, 6, time.Hour); !errors.Is(err, ErrInsufficientStock) {
+			t.Errorf("Expected ErrInsufficientStock in any warehouse, got %v", err)
+		}
+		_, err := repo.Reserve(&models.Reservation{AlbumId: album.Id, Format: models.FormatCassette, Quantity: 1}, time.Hour)
+		if !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows for an unstocked format, got %v", err)
+		}
+	})
+
+	t.Run("does not oversell to concurrent reservations", func(t *testing.T) {
+		var (
+			wg           sync.WaitGroup
+			mu           sync.Mutex
+			reservations []*models.Reservation
+		)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				reservation, _, err := reserve(west.Id, 1, time.Hour)
+				if err != nil {
+					if !errors.Is(err, ErrInsufficientStock) {
+						t.Errorf("Expected ErrInsufficientStock, got %v", err)
+					}
+					return
+				}
+				mu.Lock()
+				reservations = append(reservations, reservation)
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+
+		if len(reservations) != 2 {
+			t.Errorf("Expected 2 reservations of the 2 units on hand, got %d", len(reservations))
+		}
+		for _, reservation := range reservations {
+			if _, err := repo.Release(reservation.Id); err != nil {
+				t.Fatalf("Failed to release: %v", err)
+			}
+		}
+	})
+
+	t.Run("keeps reserved units on hand", func(t *testing.T) {
+		reservation, _, err := reserve(west.Id, 2, time.Hour)
+		if err != nil {
+			t.Fatalf("Failed to reserve: %v", err)
+		}
+		defer repo.Release(reservation.Id)
+
+		err = repo.SetStock(&models.Stock{AlbumId: album.Id, Format: models.FormatVinyl, WarehouseId: west.Id, OnHand: 1})
+		if !IsInvalid(err) {
+			t.Errorf("Expected an invalid stock, got %v", err)
+		}
+	})
+
+	t.Run("expires reservations past their expiry", func(t *testing.T) {
+		reservation, _, err := reserve(east.Id, 4, 0)
+		if err != nil {
+			t.Fatalf("Failed to reserve: %v", err)
+		}
+
+		if _, err := repo.Commit(reservation.Id); !errors.Is(err, ErrReservationClosed) {
+			t.Errorf("Expected ErrReservationClosed committing an expired reservation, got %v", err)
+		}
+
+		expired, err := repo.ExpireReservations()
+		if err != nil {
+			t.Fatalf("Failed to expire reservations: %v", err)
+		}
+		if expired < 1 {
+			t.Errorf("Expected at least 1 expired reservation, got %d", expired)
+		}
+
+		reservation, err = repo.GetReservation(reservation.Id)
+		if err != nil {
+			t.Fatalf("Failed to get reservation: %v", err)
+		}
+		if reservation.Status != models.ReservationExpired {
+			t.Errorf("Expected status %s, got %s", models.ReservationExpired, reservation.Status)
+		}
+		if _, err := repo.Release(reservation.Id); !errors.Is(err, ErrReservationClosed) {
+			t.Errorf("Expected ErrReservationClosed releasing an expired reservation, got %v", err)
+		}
+
+		stock, err := repo.GetStock(album.Id)
+		if err != nil {
+			t.Fatalf("Failed to get stock: %v", err)
+		}
+		for _, s := range stock {
+			if s.WarehouseId == east.Id && s.Reserved != 0 {
+				t.Errorf("Expected the expired units released, got %d reserved", s.Reserved)
+			}
+		}
+	})
+
+	t.Run("commits and returns a reservation once", func(t *testing.T) {
+		reservation, _, err := reserve(east.Id, 2, time.Hour)
+		if err != nil {
+			t.Fatalf("Failed to reserve: %v", err)
+		}
+		if _, err := repo.Commit(reservation.Id); err != nil {
+			t.Fatalf("Failed to commit: %v", err)
+		}
+		if _, err := repo.Commit(reservation.Id); !errors.Is(err, ErrReservationClosed) {
+			t.Errorf("Expected ErrReservationClosed committing again, got %v", err)
+		}
+		if _, err := repo.ReturnAll([]int{reservation.Id}); err != nil {
+			t.Fatalf("Failed to return: %v", err)
+		}
+		if _, err := repo.ReturnAll([]int{reservation.Id}); !errors.Is(err, ErrReservationClosed) {
+			t.Errorf("Expected ErrReservationClosed returning again, got %v", err)
+		}
+		if _, err := repo.Commit(-1); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows for an unknown reservation, got %v", err)
+		}
+
+		stock, err := repo.GetStock(album.Id)
+		if err != nil {
+			t.Fatalf("Failed to get stock: %v", err)
+		}
+		for _, s := range stock {
+			if s.WarehouseId == east.Id && s.OnHand != 5 {
+				t.Errorf("Expected the returned units back on hand, got %d", s.OnHand)
+			}
+		}
+	})
+}
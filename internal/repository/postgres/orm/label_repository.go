@@ -0,0 +1,74 @@
+package orm
+
+import (
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+type LabelRepository interface {
+	Create(label *models.Label) error
+	GetById(id int) (*models.Label, error)
+	Get() ([]*models.Label, error)
+	Update(label models.Label) error
+	Delete(id int) error
+	GetAlbums(id int) ([]*models.Album, error)
+}
+
+type labelRepository struct {
+	db *pg.DB
+}
+
+func NewLabelRepository(db *pg.DB) LabelRepository {
+	return &labelRepository{db: db}
+}
+
+// Create inserts the label and sets its id.
+func (r *labelRepository) Create(label *models.Label) error {
+	_, err := r.db.Model(label).Returning("id").Insert()
+	return err
+}
+
+func (r *labelRepository) GetById(id int) (*models.Label, error) {
+	label := &models.Label{Id: id}
+	err := r.db.Model(label).WherePK().Select()
+	return label, err
+}
+
+func (r *labelRepository) Get() ([]*models.Label, error) {
+	labels := []*models.Label{}
+	err := r.db.Model(&labels).Order("id").Select()
+	return labels, err
+}
+
+// Update returns pg.ErrNoRows when the label does not exist.
+func (r *labelRepository) Update(label models.Label) error {
+	result, err := r.db.Model(&label).WherePK().Update()
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pg.ErrNoRows
+	}
+	return nil
+}
+
+// Delete returns pg.ErrNoRows when the label does not exist and a foreign
+// key violation while the label has albums.
+func (r *labelRepository) Delete(id int) error {
+	result, err := r.db.Model(&models.Label{Id: id}).WherePK().Delete()
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pg.ErrNoRows
+	}
+	return nil
+}
+
+// GetAlbums returns the albums of the label ordered by release date.
+func (r *labelRepository) GetAlbums(id int) ([]*models.Album, error) {
+	albums := []*models.Album{}
+	err := r.db.Model(&albums).Where("label_id = ?", id).OrderExpr("release_date NULLS LAST, id").Select()
+	return albums, err
+}
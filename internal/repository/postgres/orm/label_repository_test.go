@@ -0,0 +1,134 @@
+package orm
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+func TestNewLabelRepository(t *testing.T) {
+	t.Run("creates repository successfully", func(t *testing.T) {
+		db := &pg.DB{}
+		repo := NewLabelRepository(db)
+
+		if repo == nil {
+			t.Error("Expected repository to be created, got nil")
+		}
+	})
+
+	t.Run("repository has db field", func(t *testing.T) {
+		db := &pg.DB{}
+		repo := &labelRepository{db: db}
+
+		var _ LabelRepository = repo
+		if repo.db != db {
+			t.Error("Expected db field to match provided db")
+		}
+	})
+}
+
+func TestLabelRepository_Integration(t *testing.T) {
+	db := testDB(t)
+	repo := NewLabelRepository(db)
+
+	label := &models.Label{Name: uniqueName("Label"), Country: "UK"}
+	if err := repo.Create(label); err != nil {
+		t.Fatalf("Failed to create label: %v", err)
+	}
+	t.Cleanup(func() { repo.Delete(label.Id) })
+	if label.Id == 0 {
+		t.Fatal("Expected Create to set the id")
+	}
+
+	t.Run("rejects a name differing only in case", func(t *testing.T) {
+		err := repo.Create(&models.Label{Name: strings.ToLower(label.Name)})
+		if !IsConflict(err) {
+			t.Errorf("Expected a conflict, got %v", err)
+		}
+	})
+
+	t.Run("rejects a name with surrounding whitespace", func(t *testing.T) {
+		err := repo.Create(&models.Label{Name: " " + uniqueName("Label")})
+		if !IsInvalid(err) {
+			t.Errorf("Expected an invalid label, got %v", err)
+		}
+	})
+
+	t.Run("reads and lists the label", func(t *testing.T) {
+		got, err := repo.GetById(label.Id)
+		if err != nil {
+			t.Fatalf("Failed to get label: %v", err)
+		}
+		if got.Name != label.Name || got.Country != "UK" {
+			t.Errorf("Expected %s, got %s", label.String(), got.String())
+		}
+
+		labels, err := repo.Get()
+		if err != nil {
+			t.Fatalf("Failed to list labels: %v", err)
+		}
+		found := false
+		for _, l := range labels {
+			found = found || l.Id == label.Id
+		}
+		if !found {
+			t.Errorf("Expected label %d to be listed", label.Id)
+		}
+	})
+
+	artist := uniqueName("Label Artist")
+	unreleased := createTestAlbum(t, db, &models.Album{Title: "Unreleased", Artist: artist, LabelId: label.Id})
+	later := createTestAlbum(t, db, &models.Album{Title: "Later", Artist: artist, LabelId: label.Id, ReleaseDate: models.Date{Time: time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)}})
+	earlier := createTestAlbum(t, db, &models.Album{Title: "Earlier", Artist: artist, LabelId: label.Id, ReleaseDate: models.Date{Time: time.Date(1959, 8, 17, 0, 0, 0, 0, time.UTC)}})
+
+	t.Run("lists the albums of the label by release date", func(t *testing.T) {
+		albums, err := repo.GetAlbums(label.Id)
+		if err != nil {
+			t.Fatalf("Failed to get albums: %v", err)
+		}
+		want := []int{earlier.Id, later.Id, unreleased.Id}
+		if len(albums) != len(want) {
+			t.Fatalf("Expected %d albums, got %d", len(want), len(albums))
+		}
+		for i, album := range albums {
+			if album.Id != want[i] {
+				t.Errorf("Expected album %d at %d, got %d", want[i], i, album.Id)
+			}
+		}
+	})
+
+	t.Run("updates the label", func(t *testing.T) {
+		label.Country = "US"
+		if err := repo.Update(*label); err != nil {
+			t.Fatalf("Failed to update label: %v", err)
+		}
+		got, err := repo.GetById(label.Id)
+		if err != nil {
+			t.Fatalf("Failed to get label: %v", err)
+		}
+		if got.Country != "US" {
+			t.Errorf("Expected country US, got %s", got.Country)
+		}
+
+		if err := repo.Update(models.Label{Id: -1, Name: uniqueName("Unknown")}); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows, got %v", err)
+		}
+	})
+
+	t.Run("does not delete a label with albums", func(t *testing.T) {
+		if err := repo.Delete(label.Id); !IsForeignKeyViolation(err) {
+			t.Errorf("Expected a foreign key violation, got %v", err)
+		}
+	})
+
+	t.Run("does not delete an unknown label", func(t *testing.T) {
+		if err := repo.Delete(-1); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,305 @@
+package orm
+
+import (
+	"errors"
+
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+var (
+	// ErrCartClosed is returned when a cart is changed or checked out after it
+	// was checked out.
+	ErrCartClosed = errors.New("cart is checked out")
+	// ErrCartChanged is returned when the items of a cart changed while it
+	// was checked out.
+	ErrCartChanged = errors.New("cart changed during checkout")
+	// ErrNoPrice is returned when an album added to a cart does not exist or
+	// has no price in the currency of the cart.
+	ErrNoPrice = errors.New("album has no price in the currency of the cart")
+	// ErrOrderStatus is returned when an order is not in the status it is
+	// changed from.
+	ErrOrderStatus = errors.New("order cannot change to the status")
+)
+
+// OrderRepository keeps carts and orders, see sql/ddl/create_table_orders.sql.
+type OrderRepository interface {
+	CreateCart(cart *models.Cart) error
+	GetCart(id int) (*models.Cart, error)
+	AddCartItem(item *models.CartItem) error
+	RemoveCartItem(cartId, albumId int, format string) error
+	CreateOrder(order *models.Order) error
+	GetOrder(id int) (*models.Order, error)
+	GetOrders(customer string) ([]*models.Order, error)
+	UpdateStatus(id int, from, to string) (*models.Order, error)
+	SetPayment(id int, paymentId string) (*models.Order, error)
+}
+
+type orderRepository struct {
+	db *pg.DB
+}
+
+func NewOrderRepository(db *pg.DB) OrderRepository {
+	return &orderRepository{db: db}
+}
+
+// CreateCart inserts an open cart and sets its id, status and creation time.
+func (r *orderRepository) CreateCart(cart *models.Cart) error {
+	cart.Status = models.CartOpen
+	_, err := r.db.Model(cart).Returning("*").Insert()
+	return err
+}
+
+// GetCart returns the cart with its items.
+func (r *orderRepository) GetCart(id int) (*models.Cart, error) {
+	cart := &models.Cart{Id: id}
+	if err := r.db.Model(cart).WherePK().Select(); err != nil {
+		return nil, err
+	}
+	cart.Items = []*models.CartItem{}
+	err := r.db.Model(&cart.Items).
+		Where("cart_id = ?", id).
+		Order("album_id", "format").
+		Select()
+	if err != nil {
+		return nil, err
+	}
+	return cart, nil
+}
+
+// AddCartItem adds the quantity of the item to the cart, at the price of the
+// album in the currency of the cart. An album format already in the cart
+// keeps the price it was first added at. It sets the quantity and unit price
+// of the item, and returns pg.ErrNoRows when the cart does not exist,
+// ErrCartClosed when it is checked out and ErrNoPrice when the album has no
+// price in its currency.
+func (r *orderRepository) AddCartItem(item *models.CartItem) error {
+	return r.db.RunInTransaction(r.db.Context(), func(tx *pg.Tx) error {
+		// The shared lock conflicts with the update that checks out the cart,
+		// so the item is either added before the checkout, which then finds
+		// the cart changed, or finds the cart checked out.
+		var status string
+		_, err := tx.QueryOne(pg.Scan(&status),
+			"SELECT status FROM music.carts WHERE id = ? FOR SHARE", item.CartId)
+		if err != nil {
+			return err
+		}
+		if status != models.CartOpen {
+			return ErrCartClosed
+		}
+
+		_, err = tx.QueryOne(item, `
+			INSERT INTO music.cart_items (cart_id, album_id, format, quantity, unit_price)
+			SELECT carts.id, prices.album_id, ?2, ?3, prices.price
+			FROM music.carts
+			JOIN (
+				SELECT id AS album_id, currency, price FROM music.albums WHERE id = ?1
+				UNION ALL
+				SELECT album_id, currency, price FROM music.album_prices WHERE album_id = ?1
+			) prices ON prices.currency = carts.currency
+			WHERE carts.id = ?0
+			ON CONFLICT (cart_id, album_id, format) DO UPDATE
+			SET quantity = cart_items.quantity + EXCLUDED.quantity
+			RETURNING *`,
+			item.CartId, item.AlbumId, item.Format, item.Quantity)
+		if errors.Is(err, pg.ErrNoRows) {
+			return ErrNoPrice
+		}
+		return err
+	})
+}
+
+// RemoveCartItem removes an album format from the cart. It returns
+// pg.ErrNoRows when the cart does not exist or the album format is not in it
+// and ErrCartClosed when it is checked out.
+func (r *orderRepository) RemoveCartItem(cartId, albumId int, format string) error {
+	return r.db.RunInTransaction(r.db.Context(), func(tx *pg.Tx) error {
+		var status string
+		_, err := tx.QueryOne(pg.Scan(&status),
+			"SELECT status FROM music.carts WHERE id = ? FOR SHARE", cartId)
+		if err != nil {
+			return err
+		}
+		if status != models.CartOpen {
+			return ErrCartClosed
+		}
+
+		result, err := tx.Model(&models.CartItem{CartId: cartId, AlbumId: albumId, Format: format}).
+			WherePK().
+			Delete()
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected() == 0 {
+			return pg.ErrNoRows
+		}
+		return nil
+	})
+}
+
+// CreateOrder checks out the cart of the order and inserts the pending order
+// with its items, setting the id, status and times of the order. It returns
+// pg.ErrNoRows when the cart does not exist, ErrCartClosed when it is
+// already checked out and ErrCartChanged when its items are no longer those
+// of the order, leaving the cart open.
+func (r *orderRepository) CreateOrder(order *models.Order) error {
+	return r.db.RunInTransaction(r.db.Context(), func(tx *pg.Tx) error {
+		result, err := tx.Exec("UPDATE music.carts SET status = ? WHERE id = ? AND status = ?",
+			models.CartCheckedOut, order.CartId, models.CartOpen)
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected() == 0 {
+			exists, err := tx.Model(&models.Cart{Id: order.CartId}).WherePK().Exists()
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return pg.ErrNoRows
+			}
+			return ErrCartClosed
+		}
+
+		// Checking out locks the cart until the transaction ends, so its
+		// items cannot change after they are compared.
+		items := []*models.CartItem{}
+		err = tx.Model(&items).
+			Where("cart_id = ?", order.CartId).
+			Order("album_id", "format").
+			Select()
+		if err != nil {
+			return err
+		}
+		if !sameItems(items, order.Items) {
+			return ErrCartChanged
+		}
+
+		order.Status = models.OrderPending
+		if _, err := tx.Model(order).Returning("*").Insert(); err != nil {
+			return err
+		}
+		for _, item := range order.Items {
+			item.OrderId = order.Id
+		}
+		if len(order.Items) == 0 {
+			return nil
+		}
+		_, err = tx.Model(&order.Items).Insert()
+		return err
+	})
+}
+
+// sameItems reports whether the order items are the cart items, in the same
+// order, quantities and prices.
+func sameItems(cartItems []*models.CartItem, orderItems []*models.OrderItem) bool {
+	if len(cartItems) != len(orderItems) {
+		return false
+	}
+	for i, item := range cartItems {
+		ordered := orderItems[i]
+		if item.AlbumId != ordered.AlbumId || item.Format != ordered.Format ||
+			item.Quantity != ordered.Quantity || !item.UnitPrice.Equal(ordered.UnitPrice) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetOrder returns the order with its items.
+func (r *orderRepository) GetOrder(id int) (*models.Order, error) {
+	order := &models.Order{Id: id}
+	if err := r.db.Model(order).WherePK().Select(); err != nil {
+		return nil, err
+	}
+	if err := r.selectItems(order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// GetOrders returns the orders of the customer with their items, the latest
+// first.
+func (r *orderRepository) GetOrders(customer string) ([]*models.Order, error) {
+	orders := []*models.Order{}
+	err := r.db.Model(&orders).
+		Where("customer = ?", customer).
+		Order("id DESC").
+		Select()
+	if err != nil || len(orders) == 0 {
+		return orders, err
+	}
+
+	byId := make(map[int]*models.Order, len(orders))
+	ids := make([]int, 0, len(orders))
+	for _, order := range orders {
+		order.Items = []*models.OrderItem{}
+		byId[order.Id] = order
+		ids = append(ids, order.Id)
+	}
+	items := []*models.OrderItem{}
+	err = r.db.Model(&items).
+		Where("order_id IN (?)", pg.In(ids)).
+		Order("order_id", "album_id", "format").
+		Select()
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		byId[item.OrderId].Items = append(byId[item.OrderId].Items, item)
+	}
+	return orders, nil
+}
+
+// UpdateStatus changes the status of the order when it is in the status it is
+// changed from, so of concurrent changes of an order only one succeeds. It
+// does not check the transition, see models.CanTransition. It returns the
+// order with its items, pg.ErrNoRows when the order does not exist and
+// ErrOrderStatus when it is in another status.
+func (r *orderRepository) UpdateStatus(id int, from, to string) (*models.Order, error) {
+	order := &models.Order{}
+	_, err := r.db.QueryOne(order,
+		"UPDATE music.orders SET status = ?, updated_at = now() WHERE id = ? AND status = ? RETURNING *",
+		to, id, from)
+	if errors.Is(err, pg.ErrNoRows) {
+		exists, err := r.db.Model(&models.Order{Id: id}).WherePK().Exists()
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, pg.ErrNoRows
+		}
+		return nil, ErrOrderStatus
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := r.selectItems(order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// SetPayment sets the id of the payment of the order with the provider. It
+// returns the order with its items.
+func (r *orderRepository) SetPayment(id int, paymentId string) (*models.Order, error) {
+	order := &models.Order{}
+	_, err := r.db.QueryOne(order,
+		"UPDATE music.orders SET payment_id = ?, updated_at = now() WHERE id = ? RETURNING *",
+		paymentId, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.selectItems(order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (r *orderRepository) selectItems(order *models.Order) error {
+	order.Items = []*models.OrderItem{}
+	return r.db.Model(&order.Items).
+		Where("order_id = ?", order.Id).
+		Order("album_id", "format").
+		Select()
+}
@@ -0,0 +1,54 @@
+package orm
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"music-service/internal/models"
+)
+
+func TestSameItems(t *testing.T) {
+	price := decimal.RequireFromString("24.99")
+	cartItems := []*models.CartItem{
+		{AlbumId: 1, Format: models.FormatVinyl, Quantity: 2, UnitPrice: price},
+	}
+
+	tests := []struct {
+		name  string
+		items []*models.OrderItem
+		want  bool
+	}{
+		{
+			name:  "same",
+			items: []*models.OrderItem{{AlbumId: 1, Format: models.FormatVinyl, Quantity: 2, UnitPrice: decimal.RequireFromString("24.990"), WarehouseId: 3}},
+			want:  true,
+		},
+		{
+			name:  "quantity added",
+			items: []*models.OrderItem{{AlbumId: 1, Format: models.FormatVinyl, Quantity: 1, UnitPrice: price}},
+		},
+		{
+			name: "item added",
+			items: []*models.OrderItem{
+				{AlbumId: 1, Format: models.FormatVinyl, Quantity: 2, UnitPrice: price},
+				{AlbumId: 2, Format: models.FormatCD, Quantity: 1, UnitPrice: price},
+			},
+		},
+		{
+			name:  "other format",
+			items: []*models.OrderItem{{AlbumId: 1, Format: models.FormatCD, Quantity: 2, UnitPrice: price}},
+		},
+		{
+			name: "no items",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sameItems(cartItems, tt.items); got != tt.want {
+				t.Errorf("Expected sameItems to be %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package orm
+
+import (
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+// PlaylistRepository keeps the playlists, their collaborators and their
+// items, see sql/ddl/create_table_playlists.sql. Changes to the items of a
+// playlist lock the playlist, so concurrent changes keep the positions
+// numbered from 1 without gaps.
+type PlaylistRepository interface {
+	Create(playlist *models.Playlist) error
+	GetById(id int) (*models.Playlist, error)
+	GetByShareToken(token string) (*models.Playlist, error)
+	GetPublic() ([]*models.Playlist, error)
+	GetByUser(userId int) ([]*models.Playlist, error)
+	Update(playlist *models.Playlist) error
+	RotateShareToken(id int) (*models.Playlist, error)
+	Delete(id int) error
+	AddItem(item *models.PlaylistItem) error
+	RemoveItem(playlistId, itemId int) error
+	MoveItem(playlistId, itemId, position int) (*models.PlaylistItem, error)
+	AddCollaborator(playlistId, userId int) error
+	RemoveCollaborator(playlistId, userId int) error
+	GetEntries(playlistId int) ([]*models.PlaylistEntry, error)
+}
+
+type playlistRepository struct {
+	db *pg.DB
+}
+
+func NewPlaylistRepository(db *pg.DB) PlaylistRepository {
+	return &playlistRepository{db: db}
+}
+
+// Create inserts the playlist and sets its id, share token and times. It
+// returns a foreign key violation when the owner does not exist.
+func (r *playlistRepository) Create(playlist *models.Playlist) error {
+	_, err := r.db.Model(playlist).
+		ExcludeColumn("share_token").
+		Returning("*").
+		Insert()
+	return err
+}
+
+// GetById returns the playlist with its collaborators and items.
+func (r *playlistRepository) GetById(id int) (*models.Playlist, error) {
+	return r.get("id = ?", id)
+}
+
+// GetByShareToken returns the playlist with its collaborators and items.
+func (r *playlistRepository) GetByShareToken(token string) (*models.Playlist, error) {
+	return r.get("share_token = ?", token)
+}
+
+func (r *playlistRepository) get(condition string, param interface{}) (*models.Playlist, error) {
+	playlist := &models.Playlist{}
+	if err := r.db.Model(playlist).Where(condition, param).Select(); err != nil {
+		return nil, err
+	}
+	if err := r.loadCollaborators([]*models.Playlist{playlist}); err != nil {
+		return nil, err
+	}
+	playlist.Items = []*models.PlaylistItem{}
+	err := r.db.Model(&playlist.Items).
+		Where("playlist_id = ?", playlist.Id).
+		Order("position").
+		Select()
+	if err != nil {
+		return nil, err
+	}
+	return playlist, nil
+}
+
+// GetPublic returns the public playlists with their collaborators, the
+// latest changed first.
+func (r *playlistRepository) GetPublic() ([]*models.Playlist, error) {
+	playlists := []*models.Playlist{}
+	err := r.db.Model(&playlists).
+		Where("visibility = ?", models.PlaylistPublic).
+		Order("updated_at DESC", "id").
+		Select()
+	if err != nil {
+		return nil, err
+	}
+	return playlists, r.loadCollaborators(playlists)
+}
+
+// GetByUser returns the playlists the user owns or collaborates on with their
+// collaborators, the latest changed first.
+func (r *playlistRepository) GetByUser(userId int) ([]*models.Playlist, error) {
+	playlists := []*models.Playlist{}
+	err := r.db.Model(&playlists).
+		Where("owner_id = ?0 OR id IN (SELECT playlist_id FROM music.playlist_collaborators WHERE user_id = ?0)", userId).
+		Order("updated_at DESC", "id").
+		Select()
+	if err != nil {
+		return nil, err
+	}
+	return playlists, r.loadCollaborators(playlists)
+}
+
+func (r *playlistRepository) loadCollaborators(playlists []*models.Playlist) error {
+	if len(playlists) == 0 {
+		return nil
+	}
+	byId := make(map[int]*models.Playlist, len(playlists))
+	ids := make([]int, len(playlists))
+	for i, playlist := range playlists {
+		playlist.Collaborators = []int{}
+		byId[playlist.Id] = playlist
+		ids[i] = playlist.Id
+	}
+
+	collaborators := []*models.PlaylistCollaborator{}
+	err := r.db.Model(&collaborators).
+		Where("playlist_id IN (?)", pg.In(ids)).
+		Order("added_at", "user_id").
+		Select()
+	if err != nil {
+		return err
+	}
+	for _, collaborator := range collaborators {
+		playlist := byId[collaborator.PlaylistId]
+		playlist.Collaborators = append(playlist.Collaborators, collaborator.UserId)
+	}
+	return nil
+}
+
+// Update sets the name, description and visibility of the playlist and reads
+// back the rest of it, but not its collaborators and items. It returns
+// pg.ErrNoRows when the playlist does not exist.
+func (r *playlistRepository) Update(playlist *models.Playlist) error {
+	_, err := r.db.QueryOne(playlist,
+		"UPDATE music.playlists SET name = ?, description = ?, visibility = ?, updated_at = now() WHERE id = ? RETURNING *",
+		playlist.Name, playlist.Description, playlist.Visibility, playlist.Id)
+	return err
+}
+
+// RotateShareToken replaces the share token of the playlist, so links with
+// the old token stop working. It returns pg.ErrNoRows when the playlist does
+// not exist.
+func (r *playlistRepository) RotateShareToken(id int) (*models.Playlist, error) {
+	_, err := r.db.Exec(
+		"UPDATE music.playlists SET share_token = replace(gen_random_uuid()::text, '-', ''), updated_at = now() WHERE id = ?",
+		id)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetById(id)
+}
+
+// Delete returns pg.ErrNoRows when the playlist does not exist.
+func (r *playlistRepository) Delete(id int) error {
+	result, err := r.db.Model(&models.Playlist{Id: id}).WherePK().Delete()
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pg.ErrNoRows
+	}
+	return nil
+}
+
+// AddItem inserts the item at its position, moving the items from there on
+// down, or appends it when it has no position or one past the end. It sets
+// the id, position and time of the item. It returns pg.ErrNoRows when the
+// playlist does not exist and a foreign key violation when the album, track
+// or user adding it does not exist.
+func (r *playlistRepository) AddItem(item *models.PlaylistItem) error {
+	return r.db.RunInTransaction(r.db.Context(), func(tx *pg.Tx) error {
+		count, err := lockPlaylist(tx, item.PlaylistId)
+		if err != nil {
+			return err
+		}
+		if item.Position < 1 || item.Position > count {
+			item.Position = count + 1
+		}
+
+		_, err = tx.Exec(`UPDATE music.playlist_items SET "position" = "position" + 1 WHERE playlist_id = ? AND "position" >= ?`,
+			item.PlaylistId, item.Position)
+		if err != nil {
+			return err
+		}
+		item.Id = 0
+		if _, err := tx.Model(item).Returning("*").Insert(); err != nil {
+			return err
+		}
+		return touchPlaylist(tx, item.PlaylistId)
+	})
+}
+
+// RemoveItem deletes the item, moving the items after it up. It returns
+// pg.ErrNoRows when the item is not in the playlist.
+func (r *playlistRepository) RemoveItem(playlistId, itemId int) error {
+	return r.db.RunInTransaction(r.db.Context(), func(tx *pg.Tx) error {
+		if _, err := lockPlaylist(tx, playlistId); err != nil {
+			return err
+		}
+		// music.renumber_playlist_items closes the gap.
+		result, err := tx.Exec("DELETE FROM music.playlist_items WHERE id = ? AND playlist_id = ?", itemId, playlistId)
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected() == 0 {
+			return pg.ErrNoRows
+		}
+		return touchPlaylist(tx, playlistId)
+	})
+}
+
+// MoveItem moves the item to the position, or the end when the position is
+// past it, shifting the items in between. It returns pg.ErrNoRows when the
+// item is not in the playlist.
+func (r *playlistRepository) MoveItem(playlistId, itemId, position int) (*models.PlaylistItem, error) {
+	item := &models.PlaylistItem{}
+	err := r.db.RunInTransaction(r.db.Context(), func(tx *pg.Tx) error {
+		count, err := lockPlaylist(tx, playlistId)
+		if err != nil {
+			return err
+		}
+		err = tx.Model(item).Where("id = ? AND playlist_id = ?", itemId, playlistId).Select()
+		if err != nil {
+			return err
+		}
+		if position < 1 || position > count {
+			position = count
+		}
+		if position == item.Position {
+			return nil
+		}
+
+		if position < item.Position {
+			_, err = tx.Exec(`UPDATE music.playlist_items SET "position" = "position" + 1 WHERE playlist_id = ? AND "position" >= ? AND "position" < ?`,
+				playlistId, position, item.Position)
+		} else {
+			_, err = tx.Exec(`UPDATE music.playlist_items SET "position" = "position" - 1 WHERE playlist_id = ? AND "position" > ? AND "position" <= ?`,
+				playlistId, item.Position, position)
+		}
+		if err != nil {
+			return err
+		}
+		_, err = tx.QueryOne(item, `UPDATE music.playlist_items SET "position" = ? WHERE id = ? RETURNING *`, position, itemId)
+		if err != nil {
+			return err
+		}
+		return touchPlaylist(tx, playlistId)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// lockPlaylist locks the playlist until the end of the transaction and
+// returns the number of its items, or pg.ErrNoRows when it does not exist.
+func lockPlaylist(tx *pg.Tx, playlistId int) (int, error) {
+	var count int
+	_, err := tx.QueryOne(pg.Scan(&count), `
+		SELECT (SELECT count(*) FROM music.playlist_items WHERE playlist_id = p.id)::integer
+		FROM music.playlists p
+		WHERE p.id = ?
+		FOR UPDATE`,
+		playlistId)
+	return count, err
+}
+
+func touchPlaylist(tx *pg.Tx, playlistId int) error {
+	_, err := tx.Exec("UPDATE music.playlists SET updated_at = now() WHERE id = ?", playlistId)
+	return err
+}
+
+// AddCollaborator lets the user change the items of the playlist, unless they
+// can already. It returns a foreign key violation when the playlist or the
+// user does not exist.
+func (r *playlistRepository) AddCollaborator(playlistId, userId int) error {
+	_, err := r.db.Model(&models.PlaylistCollaborator{PlaylistId: playlistId, UserId: userId}).
+		OnConflict("DO NOTHING").
+		Insert()
+	return err
+}
+
+// RemoveCollaborator returns pg.ErrNoRows when the user does not collaborate
+// on the playlist.
+func (r *playlistRepository) RemoveCollaborator(playlistId, userId int) error {
+	result, err := r.db.Model(&models.PlaylistCollaborator{PlaylistId: playlistId, UserId: userId}).WherePK().Delete()
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pg.ErrNoRows
+	}
+	return nil
+}
+
+// GetEntries returns the tracks of the playlist in the order they are
+// played, expanding album items into their tracks by disc and number.
+func (r *playlistRepository) GetEntries(playlistId int) ([]*models.PlaylistEntry, error) {
+	entries := []*models.PlaylistEntry{}
+	_, err := r.db.Query(&entries, `
+		SELECT i.id AS item_id, i."position", a.id AS album_id, a.title AS album_title, a.artist,
+		       coalesce(t.id, 0) AS track_id, coalesce(t.title, '') AS track_title,
+		       coalesce(t.duration_ms, 0) AS duration_ms, coalesce(t.isrc, '') AS isrc
+		FROM music.playlist_items i
+		LEFT JOIN music.tracks t ON t.id = i.track_id OR (i.track_id IS NULL AND t.album_id = i.album_id)
+		JOIN music.albums a ON a.id = coalesce(i.album_id, t.album_id)
+		WHERE i.playlist_id = ?
+		ORDER BY i."position", t.disc, t."number"`,
+		playlistId)
+	return entries, err
+}
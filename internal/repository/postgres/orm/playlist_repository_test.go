@@ -0,0 +1,187 @@
+package orm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+func TestNewPlaylistRepository(t *testing.T) {
+	t.Run("creates repository successfully", func(t *testing.T) {
+		db := &pg.DB{}
+		var repo PlaylistRepository = NewPlaylistRepository(db)
+
+		if repo == nil {
+			t.Error("Expected repository to be created, got nil")
+		}
+	})
+}
+
+func TestPlaylistRepository_Integration(t *testing.T) {
+	db := testDB(t)
+	repo := NewPlaylistRepository(db)
+
+	owner := createTestUser(t, db, "USD")
+	collaborator := createTestUser(t, db, "USD")
+	stranger := createTestUser(t, db, "USD")
+	blueTrain := createTestAlbum(t, db, &models.Album{Title: "Blue Train", Artist: uniqueName("Playlists")})
+	giantSteps := createTestAlbum(t, db, &models.Album{Title: "Giant Steps", Artist: uniqueName("Playlists")})
+
+	playlist := &models.Playlist{OwnerId: owner.Id, Name: "Coltrane", Visibility: models.PlaylistPrivate}
+	if err := repo.Create(playlist); err != nil {
+		t.Fatalf("Failed to create playlist: %v", err)
+	}
+	// Deleting the owner deletes the playlist, this only keeps a failed test
+	// from leaving it behind.
+	t.Cleanup(func() { repo.Delete(playlist.Id) })
+
+	// positions returns the albums of the playlist in the order of their
+	// positions, checking the positions are numbered from 1.
+	positions := func(t *testing.T) []int {
+		t.Helper()
+
+		current, err := repo.GetById(playlist.Id)
+		if err != nil {
+			t.Fatalf("Failed to get playlist: %v", err)
+		}
+		albums := make([]int, len(current.Items))
+		for i, item := range current.Items {
+			if item.Position != i+1 {
+				t.Errorf("Expected position %d, got %d", i+1, item.Position)
+			}
+			albums[i] = item.AlbumId
+		}
+		return albums
+	}
+
+	t.Run("lets a collaborator change the items", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			if err := repo.AddCollaborator(playlist.Id, collaborator.Id); err != nil {
+				t.Fatalf("Failed to add collaborator: %v", err)
+			}
+		}
+
+		current, err := repo.GetById(playlist.Id)
+		if err != nil {
+			t.Fatalf("Failed to get playlist: %v", err)
+		}
+		if len(current.Collaborators) != 1 || current.Collaborators[0] != collaborator.Id {
+			t.Errorf("Expected collaborator %d, got %v", collaborator.Id, current.Collaborators)
+		}
+		if !current.CanEdit(collaborator.Id) || current.CanEdit(stranger.Id) {
+			t.Errorf("Expected only the owner and collaborator %d to change the playlist", collaborator.Id)
+		}
+
+		playlists, err := repo.GetByUser(collaborator.Id)
+		if err != nil {
+			t.Fatalf("Failed to get playlists: %v", err)
+		}
+		if len(playlists) != 1 || playlists[0].Id != playlist.Id {
+			t.Errorf("Expected playlist %d of the collaborator, got %v", playlist.Id, playlists)
+		}
+	})
+
+	t.Run("does not add an unknown collaborator", func(t *testing.T) {
+		if err := repo.AddCollaborator(playlist.Id, -1); !IsForeignKeyViolation(err) {
+			t.Errorf("Expected a foreign key violation, got %v", err)
+		}
+	})
+
+	t.Run("keeps the positions numbered from 1", func(t *testing.T) {
+		items := []*models.PlaylistItem{
+			{PlaylistId: playlist.Id, AlbumId: blueTrain.Id, AddedBy: owner.Id},
+			{PlaylistId: playlist.Id, AlbumId: giantSteps.Id, AddedBy: collaborator.Id, Position: 1},
+			{PlaylistId: playlist.Id, AlbumId: blueTrain.Id, AddedBy: collaborator.Id, Position: 9},
+		}
+		for _, item := range items {
+			if err := repo.AddItem(item); err != nil {
+				t.Fatalf("Failed to add item: %v", err)
+			}
+		}
+		if got := positions(t); len(got) != 3 || got[0] != giantSteps.Id || got[1] != blueTrain.Id || got[2] != blueTrain.Id {
+			t.Errorf("Expected Giant Steps before Blue Train twice, got %v", got)
+		}
+
+		if _, err := repo.MoveItem(playlist.Id, items[1].Id, 3); err != nil {
+			t.Fatalf("Failed to move item: %v", err)
+		}
+		if err := repo.RemoveItem(playlist.Id, items[0].Id); err != nil {
+			t.Fatalf("Failed to remove item: %v", err)
+		}
+		if got := positions(t); len(got) != 2 || got[0] != blueTrain.Id || got[1] != giantSteps.Id {
+			t.Errorf("Expected Blue Train before Giant Steps, got %v", got)
+		}
+	})
+
+	t.Run("does not change the items of another playlist", func(t *testing.T) {
+		if err := repo.RemoveItem(-1, 1); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows removing from an unknown playlist, got %v", err)
+		}
+		if err := repo.AddItem(&models.PlaylistItem{PlaylistId: -1, AlbumId: blueTrain.Id}); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows adding to an unknown playlist, got %v", err)
+		}
+		current, err := repo.GetById(playlist.Id)
+		if err != nil {
+			t.Fatalf("Failed to get playlist: %v", err)
+		}
+		if _, err := repo.MoveItem(-1, current.Items[0].Id, 1); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows moving an item of another playlist, got %v", err)
+		}
+	})
+
+	t.Run("denies a removed collaborator", func(t *testing.T) {
+		if err := repo.RemoveCollaborator(playlist.Id, collaborator.Id); err != nil {
+			t.Fatalf("Failed to remove collaborator: %v", err)
+		}
+		if err := repo.RemoveCollaborator(playlist.Id, collaborator.Id); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows removing again, got %v", err)
+		}
+
+		current, err := repo.GetById(playlist.Id)
+		if err != nil {
+			t.Fatalf("Failed to get playlist: %v", err)
+		}
+		if current.CanEdit(collaborator.Id) || current.CanView(collaborator.Id) {
+			t.Errorf("Expected the former collaborator %d denied, got collaborators %v", collaborator.Id, current.Collaborators)
+		}
+
+		playlists, err := repo.GetByUser(collaborator.Id)
+		if err != nil {
+			t.Fatalf("Failed to get playlists: %v", err)
+		}
+		if len(playlists) != 0 {
+			t.Errorf("Expected no playlists of the former collaborator, got %v", playlists)
+		}
+
+		// The items they added stay.
+		if got := positions(t); len(got) != 2 {
+			t.Errorf("Expected 2 items, got %v", got)
+		}
+	})
+
+	t.Run("revokes the share token", func(t *testing.T) {
+		token := playlist.ShareToken
+		rotated, err := repo.RotateShareToken(playlist.Id)
+		if err != nil {
+			t.Fatalf("Failed to rotate share token: %v", err)
+		}
+		if rotated.ShareToken == "" || rotated.ShareToken == token {
+			t.Errorf("Expected a new share token, got %q", rotated.ShareToken)
+		}
+		if _, err := repo.GetByShareToken(token); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows for the old share token, got %v", err)
+		}
+	})
+
+	t.Run("deletes the playlist", func(t *testing.T) {
+		if err := repo.Delete(playlist.Id); err != nil {
+			t.Fatalf("Failed to delete playlist: %v", err)
+		}
+		if err := repo.Delete(playlist.Id); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows deleting again, got %v", err)
+		}
+	})
+}
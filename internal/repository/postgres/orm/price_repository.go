@@ -0,0 +1,137 @@
+package orm
+
+import (
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+// PriceRepository changes the prices of albums through the functions of
+// sql/ddl/create_function_prices.sql, which record every change in
+// music.price_history.
+type PriceRepository interface {
+	GetByAlbum(albumId int) ([]*models.Price, error)
+	SetPrice(price models.Price, changedBy string) error
+	SaveAlbum(album models.Album, changedBy string) error
+	RemovePrice(albumId int, currency string, changedBy string) error
+	GetHistory(albumId int, currency string) ([]*models.PriceChange, error)
+	Schedule(change *models.ScheduledPrice) error
+	GetScheduled(albumId int) ([]*models.ScheduledPrice, error)
+	CancelScheduled(albumId, id int) error
+	ApplyScheduled() (int, error)
+}
+
+type priceRepository struct {
+	db *pg.DB
+}
+
+func NewPriceRepository(db *pg.DB) PriceRepository {
+	return &priceRepository{db: db}
+}
+
+// GetByAlbum returns the price in the currency of the album followed by the
+// prices in other currencies, and no prices when the album does not exist.
+func (r *priceRepository) GetByAlbum(albumId int) ([]*models.Price, error) {
+	prices := []*models.Price{}
+	_, err := r.db.Query(&prices,
+		"SELECT id AS album_id, currency, price FROM music.albums WHERE id = ? "+
+			"UNION ALL (SELECT album_id, currency, price FROM music.album_prices WHERE album_id = ? ORDER BY currency)",
+		albumId, albumId)
+	return prices, err
+}
+
+// SetPrice returns a foreign key violation when the album does not exist.
+func (r *priceRepository) SetPrice(price models.Price, changedBy string) error {
+	_, err := r.db.Exec("SELECT music.set_album_price(?, ?, ?, ?)",
+		price.AlbumId, price.Currency, price.Price, changedBy)
+	return err
+}
+
+// SaveAlbum creates or updates the album, recording changedBy as the writer of
+// its price and currency. The price of an existing album is set through
+// music.set_album_price, so an unchanged price records no change.
+func (r *priceRepository) SaveAlbum(album models.Album, changedBy string) error {
+	return r.db.RunInTransaction(r.db.Context(), func(tx *pg.Tx) error {
+		if _, err := tx.Exec("SELECT set_config('music.changed_by', ?, true)", changedBy); err != nil {
+			return err
+		}
+		_, err := tx.Model(&album).ExcludeColumn(ratingColumns...).
+			OnConflict("(id) DO UPDATE").
+			Set("title = EXCLUDED.title, artist_id = EXCLUDED.artist_id, artist = EXCLUDED.artist, " +
+				"currency = EXCLUDED.currency, label_id = EXCLUDED.label_id, release_date = EXCLUDED.release_date").
+			Insert()
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec("SELECT music.set_album_price(?, ?, ?, ?)",
+			album.Id, album.Currency, album.Price, changedBy)
+		return err
+	})
+}
+
+// RemovePrice returns pg.ErrNoRows when the album has no price in the
+// currency and a check violation for the currency of the album.
+func (r *priceRepository) RemovePrice(albumId int, currency string, changedBy string) error {
+	var removed bool
+	_, err := r.db.QueryOne(pg.Scan(&removed), "SELECT music.remove_album_price(?, ?, ?)", albumId, currency, changedBy)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return pg.ErrNoRows
+	}
+	return nil
+}
+
+// GetHistory returns the price changes of the album in the order they took
+// effect, only those in the currency unless it is empty. The history of
+// deleted albums is kept.
+func (r *priceRepository) GetHistory(albumId int, currency string) ([]*models.PriceChange, error) {
+	changes := []*models.PriceChange{}
+	query := r.db.Model(&changes).Where("album_id = ?", albumId)
+	if currency != "" {
+		query = query.Where("currency = ?", currency)
+	}
+	err := query.Order("effective_at", "id").Select()
+	return changes, err
+}
+
+// Schedule inserts the change and sets its id and creation time.
+func (r *priceRepository) Schedule(change *models.ScheduledPrice) error {
+	_, err := r.db.Model(change).ExcludeColumn("applied_at").Returning("id, created_at").Insert()
+	return err
+}
+
+// GetScheduled returns the pending price changes of the album in the order
+// they take effect.
+func (r *priceRepository) GetScheduled(albumId int) ([]*models.ScheduledPrice, error) {
+	changes := []*models.ScheduledPrice{}
+	err := r.db.Model(&changes).
+		Where("album_id = ? AND applied_at IS NULL", albumId).
+		Order("effective_at", "id").
+		Select()
+	return changes, err
+}
+
+// CancelScheduled returns pg.ErrNoRows when the album has no pending change
+// with the id.
+func (r *priceRepository) CancelScheduled(albumId, id int) error {
+	result, err := r.db.Model((*models.ScheduledPrice)(nil)).
+		Where("id = ? AND album_id = ? AND applied_at IS NULL", id, albumId).
+		Delete()
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pg.ErrNoRows
+	}
+	return nil
+}
+
+// ApplyScheduled applies the price changes that are due and returns how many
+// were applied.
+func (r *priceRepository) ApplyScheduled() (int, error) {
+	var applied int
+	_, err := r.db.QueryOne(pg.Scan(&applied), "SELECT music.apply_scheduled_prices()")
+	return applied, err
+}
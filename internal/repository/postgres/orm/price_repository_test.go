@@ -0,0 +1,193 @@
+package orm
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/shopspring/decimal"
+
+	"music-service/internal/models"
+)
+
+func TestNewPriceRepository(t *testing.T) {
+	t.Run("creates repository successfully", func(t *testing.T) {
+		db := &pg.DB{}
+		var repo PriceRepository = NewPriceRepository(db)
+
+		if repo == nil {
+			t.Error("Expected repository to be created, got nil")
+		}
+	})
+}
+
+func TestPriceRepository_Integration(t *testing.T) {
+	db := testDB(t)
+	repo := NewPriceRepository(db)
+
+	album := createTestAlbum(t, db, &models.Album{Title: "Blue Train", Artist: uniqueName("Prices"), Price: decimal.RequireFromString("10.00"), Currency: "USD"})
+
+	t.Run("sets the prices of the album", func(t *testing.T) {
+		if err := repo.SetPrice(models.Price{AlbumId: album.Id, Currency: "USD", Price: decimal.RequireFromString("12.00")}, "jane"); err != nil {
+			t.Fatalf("Failed to set price: %v", err)
+		}
+		if err := repo.SetPrice(models.Price{AlbumId: album.Id, Currency: "EUR", Price: decimal.RequireFromString("11.00")}, "jane"); err != nil {
+			t.Fatalf("Failed to set price: %v", err)
+		}
+
+		prices, err := repo.GetByAlbum(album.Id)
+		if err != nil {
+			t.Fatalf("Failed to get prices: %v", err)
+		}
+		if len(prices) != 2 || prices[0].Currency != "USD" || prices[1].Currency != "EUR" {
+			t.Fatalf("Expected the USD price followed by the EUR price, got %v", prices)
+		}
+		if !prices[0].Price.Equal(decimal.RequireFromString("12.00")) {
+			t.Errorf("Expected price 12.00, got %s", prices[0].Price)
+		}
+	})
+
+	t.Run("records who changed the price", func(t *testing.T) {
+		changes, err := repo.GetHistory(album.Id, "USD")
+		if err != nil {
+			t.Fatalf("Failed to get history: %v", err)
+		}
+		if len(changes) != 2 {
+			t.Fatalf("Expected the new price and its change, got %d changes", len(changes))
+		}
+		change := changes[1]
+		if !change.OldPrice.Decimal.Equal(decimal.RequireFromString("10.00")) || !change.NewPrice.Decimal.Equal(decimal.RequireFromString("12.00")) {
+			t.Errorf("Expected a change from 10.00 to 12.00, got %s to %s", change.OldPrice.Decimal, change.NewPrice.Decimal)
+		}
+		if change.ChangedBy != "jane" {
+			t.Errorf("Expected the change by jane, got %s", change.ChangedBy)
+		}
+	})
+
+	t.Run("records no change for an unchanged price", func(t *testing.T) {
+		saved := *album
+		saved.Price = decimal.RequireFromString("12.00")
+		if err := repo.SaveAlbum(saved, "john"); err != nil {
+			t.Fatalf("Failed to save album: %v", err)
+		}
+		changes, err := repo.GetHistory(album.Id, "USD")
+		if err != nil {
+			t.Fatalf("Failed to get history: %v", err)
+		}
+		if len(changes) != 2 {
+			t.Errorf("Expected 2 changes, got %d", len(changes))
+		}
+	})
+
+	t.Run("removes the price in another currency", func(t *testing.T) {
+		if err := repo.RemovePrice(album.Id, "EUR", "jane"); err != nil {
+			t.Fatalf("Failed to remove price: %v", err)
+		}
+		if err := repo.RemovePrice(album.Id, "EUR", "jane"); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows removing the price again, got %v", err)
+		}
+
+		changes, err := repo.GetHistory(album.Id, "EUR")
+		if err != nil {
+			t.Fatalf("Failed to get history: %v", err)
+		}
+		if len(changes) != 2 || changes[1].NewPrice.Valid {
+			t.Errorf("Expected the removal recorded, got %v", changes)
+		}
+	})
+
+	t.Run("does not remove the price in the currency of the album", func(t *testing.T) {
+		if err := repo.RemovePrice(album.Id, "USD", "jane"); !IsInvalid(err) {
+			t.Errorf("Expected an invalid price, got %v", err)
+		}
+	})
+
+	t.Run("does not set the price of an unknown album", func(t *testing.T) {
+		err := repo.SetPrice(models.Price{AlbumId: -1, Currency: "USD", Price: decimal.RequireFromString("12.00")}, "jane")
+		if !IsForeignKeyViolation(err) {
+			t.Errorf("Expected a foreign key violation, got %v", err)
+		}
+	})
+
+	t.Run("applies the scheduled changes that are due", func(t *testing.T) {
+		due := &models.ScheduledPrice{
+			AlbumId:     album.Id,
+			Currency:    "USD",
+			Price:       decimal.RequireFromString("9.00"),
+			EffectiveAt: time.Now().Add(-time.Minute).Truncate(time.Microsecond),
+			CreatedBy:   "jane",
+		}
+		future := &models.ScheduledPrice{
+			AlbumId:     album.Id,
+			Currency:    "USD",
+			Price:       decimal.RequireFromString("15.00"),
+			EffectiveAt: time.Now().Add(time.Hour),
+			CreatedBy:   "jane",
+		}
+		for _, change := range []*models.ScheduledPrice{due, future} {
+			if err := repo.Schedule(change); err != nil {
+				t.Fatalf("Failed to schedule price: %v", err)
+			}
+		}
+
+		applied, err := repo.ApplyScheduled()
+		if err != nil {
+			t.Fatalf("Failed to apply scheduled prices: %v", err)
+		}
+		if applied < 1 {
+			t.Errorf("Expected at least 1 applied change, got %d", applied)
+		}
+
+		prices, err := repo.GetByAlbum(album.Id)
+		if err != nil {
+			t.Fatalf("Failed to get prices: %v", err)
+		}
+		if len(prices) != 1 || !prices[0].Price.Equal(due.Price) {
+			t.Errorf("Expected price %s, got %v", due.Price, prices)
+		}
+
+		changes, err := repo.GetHistory(album.Id, "USD")
+		if err != nil {
+			t.Fatalf("Failed to get history: %v", err)
+		}
+		// The change is recorded when it took effect, before the changes made
+		// since it was due.
+		var recorded *models.PriceChange
+		for _, change := range changes {
+			if change.NewPrice.Decimal.Equal(due.Price) {
+				recorded = change
+			}
+		}
+		if recorded == nil {
+			t.Fatalf("Expected the change to %s recorded, got %v", due.Price, changes)
+		}
+		if !recorded.EffectiveAt.Equal(due.EffectiveAt) || recorded.ChangedBy != "jane" {
+			t.Errorf("Expected the change by jane effective at %s, got %s by %s", due.EffectiveAt, recorded.EffectiveAt, recorded.ChangedBy)
+		}
+
+		pending, err := repo.GetScheduled(album.Id)
+		if err != nil {
+			t.Fatalf("Failed to get scheduled prices: %v", err)
+		}
+		if len(pending) != 1 || pending[0].Id != future.Id {
+			t.Errorf("Expected only change %d pending, got %v", future.Id, pending)
+		}
+	})
+
+	t.Run("cancels a pending change", func(t *testing.T) {
+		pending, err := repo.GetScheduled(album.Id)
+		if err != nil {
+			t.Fatalf("Failed to get scheduled prices: %v", err)
+		}
+		if len(pending) != 1 {
+			t.Fatalf("Expected 1 pending change, got %d", len(pending))
+		}
+		if err := repo.CancelScheduled(album.Id, pending[0].Id); err != nil {
+			t.Fatalf("Failed to cancel scheduled price: %v", err)
+		}
+		if err := repo.CancelScheduled(album.Id, pending[0].Id); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows cancelling again, got %v", err)
+		}
+	})
+}
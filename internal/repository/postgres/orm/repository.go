@@ -14,6 +14,10 @@ type Repository interface {
 	Upsert(album models.Album) error
 }
 
+// ratingColumns are kept by postgres from the reviews, so albums are written
+// without them.
+var ratingColumns = []string{"average_rating", "rating_count"}
+
 type repository struct {
 	db *pg.DB
 }
@@ -23,7 +27,7 @@ func NewRepository(db *pg.DB) Repository {
 }
 
 func (r *repository) Create(album models.Album) error {
-	_, err := r.db.Model(&album).Insert()
+	_, err := r.db.Model(&album).ExcludeColumn(ratingColumns...).Insert()
 	return err
 }
 
@@ -40,11 +44,11 @@ func (r *repository) Get() ([]*models.Album, error) {
 }
 
 func (r *repository) Update(album models.Album) error {
-	_, err := r.db.Model(&album).WherePK().Update()
+	_, err := r.db.Model(&album).ExcludeColumn(ratingColumns...).WherePK().Update()
 	return err
 }
 
 func (r *repository) Upsert(album models.Album) error {
-	_, err := r.db.Model(&album).OnConflict("(id) DO UPDATE").Insert()
+	_, err := r.db.Model(&album).ExcludeColumn(ratingColumns...).OnConflict("(id) DO UPDATE").Insert()
 	return err
 }
@@ -0,0 +1,88 @@
+package orm
+
+import (
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+// ReviewRepository keeps the reviews of albums. Postgres keeps the average
+// rating and the number of ratings of the albums, see
+// sql/ddl/create_table_users.sql.
+type ReviewRepository interface {
+	Set(review *models.Review) error
+	GetByAlbum(albumId int, status string) ([]*models.Review, error)
+	GetByUser(userId int) ([]*models.Review, error)
+	Moderate(userId, albumId int, status string) (*models.Review, error)
+	Delete(userId, albumId int) error
+}
+
+type reviewRepository struct {
+	db *pg.DB
+}
+
+func NewReviewRepository(db *pg.DB) ReviewRepository {
+	return &reviewRepository{db: db}
+}
+
+// Set creates or replaces the review of the album by the user and reads back
+// its status and times. A review whose text changed is moderated again. It
+// returns a foreign key violation when the user or the album does not exist.
+func (r *reviewRepository) Set(review *models.Review) error {
+	_, err := r.db.Model(review).
+		Column("user_id", "album_id", "rating", "body").
+		OnConflict("(user_id, album_id) DO UPDATE").
+		Set("rating = EXCLUDED.rating").
+		Set("body = EXCLUDED.body").
+		Set("updated_at = now()").
+		Returning("*").
+		Insert()
+	return err
+}
+
+// GetByAlbum returns the reviews of the album in the status, the latest
+// first.
+func (r *reviewRepository) GetByAlbum(albumId int, status string) ([]*models.Review, error) {
+	reviews := []*models.Review{}
+	err := r.db.Model(&reviews).
+		Where("album_id = ? AND status = ?", albumId, status).
+		Order("updated_at DESC", "user_id").
+		Select()
+	return reviews, err
+}
+
+// GetByUser returns the reviews of the user in every status, the latest
+// first.
+func (r *reviewRepository) GetByUser(userId int) ([]*models.Review, error) {
+	reviews := []*models.Review{}
+	err := r.db.Model(&reviews).
+		Where("user_id = ?", userId).
+		Order("updated_at DESC", "album_id").
+		Select()
+	return reviews, err
+}
+
+// Moderate sets the status of the review. It returns pg.ErrNoRows when the
+// review does not exist.
+func (r *reviewRepository) Moderate(userId, albumId int, status string) (*models.Review, error) {
+	review := &models.Review{}
+	_, err := r.db.QueryOne(review,
+		"UPDATE music.reviews SET status = ?, updated_at = now() WHERE user_id = ? AND album_id = ? RETURNING *",
+		status, userId, albumId)
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// Delete returns pg.ErrNoRows when the review does not exist.
+func (r *reviewRepository) Delete(userId, albumId int) error {
+	result, err := r.db.Model(&models.Review{UserId: userId, AlbumId: albumId}).WherePK().Delete()
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pg.ErrNoRows
+	}
+	return nil
+}
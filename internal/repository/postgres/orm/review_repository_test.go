@@ -0,0 +1,130 @@
+package orm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/shopspring/decimal"
+
+	"music-service/internal/models"
+)
+
+func TestNewReviewRepository(t *testing.T) {
+	t.Run("creates repository successfully", func(t *testing.T) {
+		db := &pg.DB{}
+		var repo ReviewRepository = NewReviewRepository(db)
+
+		if repo == nil {
+			t.Error("Expected repository to be created, got nil")
+		}
+	})
+}
+
+func TestReviewRepository_Integration(t *testing.T) {
+	db := testDB(t)
+	repo := NewReviewRepository(db)
+
+	album := createTestAlbum(t, db, &models.Album{Title: "Blue Train", Artist: uniqueName("Reviews")})
+	jane := createTestUser(t, db, "USD")
+	john := createTestUser(t, db, "USD")
+
+	// rating reads back the average rating and the number of ratings postgres
+	// keeps for the album.
+	rating := func(t *testing.T) (decimal.Decimal, int) {
+		t.Helper()
+
+		rated := &models.Album{Id: album.Id}
+		if err := db.Model(rated).WherePK().Select(); err != nil {
+			t.Fatalf("Failed to get album: %v", err)
+		}
+		return rated.AverageRating, rated.RatingCount
+	}
+
+	t.Run("approves a rating without a text", func(t *testing.T) {
+		review := &models.Review{UserId: jane.Id, AlbumId: album.Id, Rating: 4}
+		if err := repo.Set(review); err != nil {
+			t.Fatalf("Failed to set review: %v", err)
+		}
+		if review.Status != models.ReviewApproved {
+			t.Errorf("Expected status %s, got %s", models.ReviewApproved, review.Status)
+		}
+	})
+
+	t.Run("moderates a review with a text", func(t *testing.T) {
+		review := &models.Review{UserId: john.Id, AlbumId: album.Id, Rating: 2, Body: "Too long"}
+		if err := repo.Set(review); err != nil {
+			t.Fatalf("Failed to set review: %v", err)
+		}
+		if review.Status != models.ReviewPending {
+			t.Errorf("Expected status %s, got %s", models.ReviewPending, review.Status)
+		}
+
+		pending, err := repo.GetByAlbum(album.Id, models.ReviewPending)
+		if err != nil {
+			t.Fatalf("Failed to get reviews: %v", err)
+		}
+		if len(pending) != 1 || pending[0].UserId != john.Id {
+			t.Errorf("Expected the review of user %d pending, got %v", john.Id, pending)
+		}
+	})
+
+	t.Run("counts the ratings of reviews that are not rejected", func(t *testing.T) {
+		average, count := rating(t)
+		if count != 2 || !average.Equal(decimal.RequireFromString("3.00")) {
+			t.Errorf("Expected an average of 3.00 of 2 ratings, got %s of %d", average, count)
+		}
+
+		review, err := repo.Moderate(john.Id, album.Id, models.ReviewRejected)
+		if err != nil {
+			t.Fatalf("Failed to moderate review: %v", err)
+		}
+		if review.Status != models.ReviewRejected {
+			t.Errorf("Expected status %s, got %s", models.ReviewRejected, review.Status)
+		}
+
+		average, count = rating(t)
+		if count != 1 || !average.Equal(decimal.RequireFromString("4.00")) {
+			t.Errorf("Expected an average of 4.00 of 1 rating, got %s of %d", average, count)
+		}
+	})
+
+	t.Run("moderates a changed text again", func(t *testing.T) {
+		review := &models.Review{UserId: john.Id, AlbumId: album.Id, Rating: 3, Body: "Grew on me"}
+		if err := repo.Set(review); err != nil {
+			t.Fatalf("Failed to set review: %v", err)
+		}
+		if review.Status != models.ReviewPending {
+			t.Errorf("Expected status %s, got %s", models.ReviewPending, review.Status)
+		}
+	})
+
+	t.Run("rejects an invalid review", func(t *testing.T) {
+		if err := repo.Set(&models.Review{UserId: jane.Id, AlbumId: album.Id, Rating: 6}); !IsInvalid(err) {
+			t.Errorf("Expected an invalid review, got %v", err)
+		}
+		if err := repo.Set(&models.Review{UserId: jane.Id, AlbumId: -1, Rating: 4}); !IsForeignKeyViolation(err) {
+			t.Errorf("Expected a foreign key violation, got %v", err)
+		}
+	})
+
+	t.Run("deletes a review", func(t *testing.T) {
+		if err := repo.Delete(jane.Id, album.Id); err != nil {
+			t.Fatalf("Failed to delete review: %v", err)
+		}
+		if err := repo.Delete(jane.Id, album.Id); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows deleting again, got %v", err)
+		}
+		if _, err := repo.Moderate(jane.Id, album.Id, models.ReviewApproved); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows moderating a deleted review, got %v", err)
+		}
+
+		reviews, err := repo.GetByUser(jane.Id)
+		if err != nil {
+			t.Fatalf("Failed to get reviews: %v", err)
+		}
+		if len(reviews) != 0 {
+			t.Errorf("Expected no reviews, got %v", reviews)
+		}
+	})
+}
@@ -0,0 +1,28 @@
+package orm
+
+import (
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+// SearchRepository searches music.albums by title and artist name.
+type SearchRepository interface {
+	Search(query string, limit int) ([]*models.SearchResult, error)
+}
+
+type searchRepository struct {
+	db *pg.DB
+}
+
+func NewSearchRepository(db *pg.DB) SearchRepository {
+	return &searchRepository{db: db}
+}
+
+// Search returns at most limit albums matching the query, best first, see
+// sql/ddl/create_function_search_albums.sql.
+func (r *searchRepository) Search(query string, limit int) ([]*models.SearchResult, error) {
+	results := []*models.SearchResult{}
+	_, err := r.db.Query(&results, "SELECT * FROM music.search_albums(?, ?)", query, limit)
+	return results, err
+}
@@ -0,0 +1,78 @@
+package orm
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+func TestNewSearchRepository(t *testing.T) {
+	t.Run("creates repository successfully", func(t *testing.T) {
+		db := &pg.DB{}
+		var repo SearchRepository = NewSearchRepository(db)
+
+		if repo == nil {
+			t.Error("Expected repository to be created, got nil")
+		}
+	})
+}
+
+func TestSearchRepository_Integration(t *testing.T) {
+	db := testDB(t)
+	repo := NewSearchRepository(db)
+
+	// A word no other album has, so that the search finds only the albums of
+	// the test.
+	word := fmt.Sprintf("%d", time.Now().UnixNano())
+	album := createTestAlbum(t, db, &models.Album{Title: `<b>Blue</b> & "Train" 'Live' ` + word, Artist: "Coltrane " + word})
+	createTestAlbum(t, db, &models.Album{Title: "Giant Steps " + word, Artist: album.Artist})
+
+	t.Run("escapes the highlights for HTML and marks the matching words", func(t *testing.T) {
+		results, err := repo.Search(word+" blue", 10)
+		if err != nil {
+			t.Fatalf("Failed to search: %v", err)
+		}
+		if len(results) == 0 || results[0].Id != album.Id {
+			t.Fatalf("Expected album %d first, got %v", album.Id, results)
+		}
+
+		result := results[0]
+		if result.Title != album.Title {
+			t.Errorf("Expected the title %q unescaped, got %q", album.Title, result.Title)
+		}
+		want := `&lt;b&gt;<mark>Blue</mark>&lt;/b&gt; &amp; &quot;Train&quot; &#39;Live&#39; <mark>` + word + `</mark>`
+		if result.TitleHighlight != want {
+			t.Errorf("Expected title highlight %q, got %q", want, result.TitleHighlight)
+		}
+		if want := "Coltrane <mark>" + word + "</mark>"; result.ArtistHighlight != want {
+			t.Errorf("Expected artist highlight %q, got %q", want, result.ArtistHighlight)
+		}
+		if result.Rank <= 0 {
+			t.Errorf("Expected a positive rank, got %f", result.Rank)
+		}
+	})
+
+	t.Run("returns at most limit albums", func(t *testing.T) {
+		results, err := repo.Search(word, 1)
+		if err != nil {
+			t.Fatalf("Failed to search: %v", err)
+		}
+		if len(results) != 1 {
+			t.Errorf("Expected 1 album, got %d", len(results))
+		}
+	})
+
+	t.Run("finds nothing for a blank query", func(t *testing.T) {
+		results, err := repo.Search("   ", 10)
+		if err != nil {
+			t.Fatalf("Failed to search: %v", err)
+		}
+		if len(results) != 0 {
+			t.Errorf("Expected no albums, got %d", len(results))
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package orm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+// LiveSchema is the schema of the tables the models are mapped to, LiveTable
+// the table of the album models.
+const (
+	LiveSchema = "music"
+	LiveTable  = LiveSchema + ".albums"
+)
+
+// tableRepository stores albums in a table with the layout of music.albums,
+// such as the shadow tables written by kafka-replay. go-pg maps models to a
+// single table, so the queries name the table explicitly.
+type tableRepository struct {
+	db    *pg.DB
+	table pg.Ident
+}
+
+func NewTableRepository(db *pg.DB, table string) Repository {
+	return &tableRepository{db: db, table: pg.Ident(table)}
+}
+
+func (r *tableRepository) Create(album models.Album) error {
+	_, err := r.db.Exec(
+		"INSERT INTO ? (id, title, artist_id, artist, price, currency, label_id, release_date) VALUES (?, ?, NULLIF(?, 0), ?, ?, ?, NULLIF(?, 0), ?)",
+		r.table, album.Id, album.Title, album.ArtistId, album.Artist, album.Price, album.Currency, album.LabelId, album.ReleaseDate,
+	)
+	return err
+}
+
+func (r *tableRepository) GetById(id int) (*models.Album, error) {
+	album := &models.Album{}
+	_, err := r.db.QueryOne(album, "SELECT id, title, artist_id, artist, price, currency, label_id, release_date FROM ? WHERE id = ?", r.table, id)
+	return album, err
+}
+
+func (r *tableRepository) Get() ([]*models.Album, error) {
+	albums := []*models.Album{}
+	_, err := r.db.Query(&albums, "SELECT id, title, artist_id, artist, price, currency, label_id, release_date FROM ? ORDER BY id", r.table)
+	return albums, err
+}
+
+func (r *tableRepository) Update(album models.Album) error {
+	_, err := r.db.Exec(
+		"UPDATE ? SET title = ?, artist_id = NULLIF(?, 0), artist = ?, price = ?, currency = ?, label_id = NULLIF(?, 0), release_date = ? WHERE id = ?",
+		r.table, album.Title, album.ArtistId, album.Artist, album.Price, album.Currency, album.LabelId, album.ReleaseDate, album.Id,
+	)
+	return err
+}
+
+func (r *tableRepository) Upsert(album models.Album) error {
+	_, err := r.db.Exec(
+		"INSERT INTO ? (id, title, artist_id, artist, price, currency, label_id, release_date) VALUES (?, ?, NULLIF(?, 0), ?, ?, ?, NULLIF(?, 0), ?) "+
+			"ON CONFLICT (id) DO UPDATE SET title = EXCLUDED.title, artist_id = EXCLUDED.artist_id, artist = EXCLUDED.artist, price = EXCLUDED.price, "+
+			"currency = EXCLUDED.currency, label_id = EXCLUDED.label_id, release_date = EXCLUDED.release_date",
+		r.table, album.Id, album.Title, album.ArtistId, album.Artist, album.Price, album.Currency, album.LabelId, album.ReleaseDate,
+	)
+	return err
+}
+
+// CreateShadowTable creates an empty table with the columns and constraints
+// of music.albums, in its own schema. The name must be schema-qualified with
+// a schema other than music, so that no live table is replaced, whatever the
+// search path. The id is a plain column so that replayed albums keep their
+// ids. An existing table is only replaced when replace is set.
+func CreateShadowTable(db *pg.DB, table string, replace bool) error {
+	schema, name, ok := strings.Cut(table, ".")
+	switch {
+	case !ok || schema == "" || name == "":
+		return fmt.Errorf("%q is not schema-qualified, such as replay.albums", table)
+	case schema == LiveSchema:
+		return fmt.Errorf("refusing to replay into the %s schema", LiveSchema)
+	}
+
+	return db.RunInTransaction(db.Context(), func(tx *pg.Tx) error {
+		if _, err := tx.Exec("CREATE SCHEMA IF NOT EXISTS ?", pg.Ident(schema)); err != nil {
+			return err
+		}
+		if replace {
+			if _, err := tx.Exec("DROP TABLE IF EXISTS ?", pg.Ident(table)); err != nil {
+				return err
+			}
+		}
+		_, err := tx.Exec(
+			"CREATE TABLE ? (LIKE ? INCLUDING DEFAULTS INCLUDING CONSTRAINTS INCLUDING INDEXES)",
+			pg.Ident(table), pg.Ident(LiveTable),
+		)
+		return err
+	})
+}
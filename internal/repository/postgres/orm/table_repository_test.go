@@ -0,0 +1,22 @@
+package orm
+
+import "testing"
+
+func TestCreateShadowTable_Rejected(t *testing.T) {
+	tests := []string{
+		"albums",
+		".albums",
+		"replay.",
+		"music.albums",
+		"music.albums_replay",
+		"music.album_prices",
+	}
+
+	for _, table := range tests {
+		t.Run(table, func(t *testing.T) {
+			if err := CreateShadowTable(nil, table, true); err == nil {
+				t.Errorf("Expected %s to be rejected", table)
+			}
+		})
+	}
+}
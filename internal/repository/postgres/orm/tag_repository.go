@@ -0,0 +1,107 @@
+package orm
+
+import (
+	"strings"
+
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+type TagRepository interface {
+	Create(tag *models.Tag) error
+	Get() ([]*models.Tag, error)
+	Update(tag models.Tag) error
+	Delete(id int) error
+	GetByAlbum(albumId int) ([]*models.Tag, error)
+	SetAlbumTags(albumId int, names []string) ([]*models.Tag, error)
+}
+
+type tagRepository struct {
+	db *pg.DB
+}
+
+func NewTagRepository(db *pg.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+// Create inserts the tag and sets its id.
+func (r *tagRepository) Create(tag *models.Tag) error {
+	_, err := r.db.Model(tag).Returning("id").Insert()
+	return err
+}
+
+func (r *tagRepository) Get() ([]*models.Tag, error) {
+	tags := []*models.Tag{}
+	err := r.db.Model(&tags).Order("name").Select()
+	return tags, err
+}
+
+// Update returns pg.ErrNoRows when there is no tag with the id.
+func (r *tagRepository) Update(tag models.Tag) error {
+	result, err := r.db.Model(&tag).WherePK().Update()
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pg.ErrNoRows
+	}
+	return nil
+}
+
+// Delete returns pg.ErrNoRows when there is no tag with the id. The tag is
+// removed from its albums.
+func (r *tagRepository) Delete(id int) error {
+	result, err := r.db.Model(&models.Tag{Id: id}).WherePK().Delete()
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pg.ErrNoRows
+	}
+	return nil
+}
+
+func (r *tagRepository) GetByAlbum(albumId int) ([]*models.Tag, error) {
+	tags := []*models.Tag{}
+	err := r.db.Model(&tags).
+		Where("id IN (SELECT tag_id FROM music.album_tags WHERE album_id = ?)", albumId).
+		Order("name").
+		Select()
+	return tags, err
+}
+
+// SetAlbumTags replaces the tags of the album, creating the tags that do not
+// exist yet, and returns them. Names are matched ignoring case.
+func (r *tagRepository) SetAlbumTags(albumId int, names []string) ([]*models.Tag, error) {
+	tags := []*models.Tag{}
+	err := r.db.RunInTransaction(r.db.Context(), func(tx *pg.Tx) error {
+		_, err := tx.Model((*models.AlbumTag)(nil)).Where("album_id = ?", albumId).Delete()
+		if err != nil || len(names) == 0 {
+			return err
+		}
+
+		newTags := make([]models.Tag, len(names))
+		lowerNames := make([]string, len(names))
+		for i, name := range names {
+			newTags[i] = models.Tag{Name: name}
+			lowerNames[i] = strings.ToLower(name)
+		}
+		_, err = tx.Model(&newTags).OnConflict("((lower(name))) DO NOTHING").Insert()
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&tags).Where("lower(name) IN (?)", pg.In(lowerNames)).Order("name").Select()
+		if err != nil {
+			return err
+		}
+
+		albumTags := make([]models.AlbumTag, len(tags))
+		for i, tag := range tags {
+			albumTags[i] = models.AlbumTag{AlbumId: albumId, TagId: tag.Id}
+		}
+		_, err = tx.Model(&albumTags).Insert()
+		return err
+	})
+	return tags, err
+}
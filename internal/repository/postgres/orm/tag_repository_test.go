@@ -0,0 +1,107 @@
+package orm
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+func TestNewTagRepository(t *testing.T) {
+	t.Run("creates repository successfully", func(t *testing.T) {
+		db := &pg.DB{}
+		var repo TagRepository = NewTagRepository(db)
+
+		if repo == nil {
+			t.Error("Expected repository to be created, got nil")
+		}
+	})
+}
+
+func TestTagRepository_Integration(t *testing.T) {
+	db := testDB(t)
+	repo := NewTagRepository(db)
+
+	live := &models.Tag{Name: uniqueName("Live")}
+	if err := repo.Create(live); err != nil {
+		t.Fatalf("Failed to create tag: %v", err)
+	}
+	t.Cleanup(func() { repo.Delete(live.Id) })
+
+	t.Run("rejects a name differing only in case", func(t *testing.T) {
+		if err := repo.Create(&models.Tag{Name: strings.ToUpper(live.Name)}); !IsConflict(err) {
+			t.Errorf("Expected a conflict, got %v", err)
+		}
+	})
+
+	t.Run("does not update or delete an unknown tag", func(t *testing.T) {
+		if err := repo.Update(models.Tag{Id: -1, Name: uniqueName("Unknown")}); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows updating, got %v", err)
+		}
+		if err := repo.Delete(-1); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows deleting, got %v", err)
+		}
+	})
+
+	album := createTestAlbum(t, db, &models.Album{Title: "Live at Birdland", Artist: uniqueName("Tags")})
+	remastered := uniqueName("Remastered")
+	t.Cleanup(func() { db.Model((*models.Tag)(nil)).Where("name = ?", remastered).Delete() })
+
+	t.Run("sets the tags of the album creating the new ones", func(t *testing.T) {
+		tags, err := repo.SetAlbumTags(album.Id, []string{strings.ToLower(live.Name), remastered})
+		if err != nil {
+			t.Fatalf("Failed to set tags: %v", err)
+		}
+		if len(tags) != 2 {
+			t.Fatalf("Expected 2 tags, got %v", tags)
+		}
+		if tags[0].Id != live.Id || tags[0].Name != live.Name {
+			t.Errorf("Expected the existing tag %s, got %s", live.String(), tags[0].String())
+		}
+
+		got, err := repo.GetByAlbum(album.Id)
+		if err != nil {
+			t.Fatalf("Failed to get tags: %v", err)
+		}
+		if len(got) != 2 {
+			t.Errorf("Expected 2 tags of the album, got %v", got)
+		}
+	})
+
+	t.Run("browses the albums of the tag ignoring case", func(t *testing.T) {
+		albums, err := NewBrowseRepository(db).Find(models.AlbumFilter{Tag: " " + strings.ToUpper(remastered) + " "})
+		if err != nil {
+			t.Fatalf("Failed to find albums: %v", err)
+		}
+		if len(albums) != 1 || albums[0].Id != album.Id {
+			t.Errorf("Expected album %d, got %v", album.Id, albums)
+		}
+	})
+
+	t.Run("removes a deleted tag from its albums", func(t *testing.T) {
+		if err := repo.Delete(live.Id); err != nil {
+			t.Fatalf("Failed to delete tag: %v", err)
+		}
+		got, err := repo.GetByAlbum(album.Id)
+		if err != nil {
+			t.Fatalf("Failed to get tags: %v", err)
+		}
+		if len(got) != 1 || got[0].Name != remastered {
+			t.Errorf("Expected only %s, got %v", remastered, got)
+		}
+	})
+
+	t.Run("clears the tags of the album", func(t *testing.T) {
+		tags, err := repo.SetAlbumTags(album.Id, nil)
+		if err != nil || len(tags) != 0 {
+			t.Fatalf("Expected no tags, got %v, %v", tags, err)
+		}
+		got, err := repo.GetByAlbum(album.Id)
+		if err != nil || len(got) != 0 {
+			t.Errorf("Expected the album to have no tags, got %v, %v", got, err)
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package orm
+
+import (
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+type TrackRepository interface {
+	Create(track *models.Track) error
+	GetById(albumId, id int) (*models.Track, error)
+	GetByAlbum(albumId int) ([]*models.Track, error)
+	Get() ([]*models.Track, error)
+	Update(track models.Track) error
+	Delete(albumId, id int) error
+	Save(albumId int, tracks []models.Track, removed []models.Track) error
+}
+
+type trackRepository struct {
+	db *pg.DB
+}
+
+func NewTrackRepository(db *pg.DB) TrackRepository {
+	return &trackRepository{db: db}
+}
+
+// Create inserts the track and sets its id.
+func (r *trackRepository) Create(track *models.Track) error {
+	_, err := r.db.Model(track).Returning("id").Insert()
+	return err
+}
+
+func (r *trackRepository) GetById(albumId, id int) (*models.Track, error) {
+	track := &models.Track{}
+	err := r.db.Model(track).Where("id = ? AND album_id = ?", id, albumId).Select()
+	return track, err
+}
+
+func (r *trackRepository) GetByAlbum(albumId int) ([]*models.Track, error) {
+	tracks := []*models.Track{}
+	err := r.db.Model(&tracks).Where("album_id = ?", albumId).Order("disc", "number").Select()
+	return tracks, err
+}
+
+// Get returns the tracks of every album ordered by album.
+func (r *trackRepository) Get() ([]*models.Track, error) {
+	tracks := []*models.Track{}
+	err := r.db.Model(&tracks).Order("album_id", "disc", "number").Select()
+	return tracks, err
+}
+
+// Update returns pg.ErrNoRows when the album has no track with the id.
+func (r *trackRepository) Update(track models.Track) error {
+	result, err := r.db.Model(&track).Where("id = ? AND album_id = ?", track.Id, track.AlbumId).Update()
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pg.ErrNoRows
+	}
+	return nil
+}
+
+// Delete returns pg.ErrNoRows when the album has no track with the id.
+func (r *trackRepository) Delete(albumId, id int) error {
+	result, err := r.db.Model((*models.Track)(nil)).Where("id = ? AND album_id = ?", id, albumId).Delete()
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pg.ErrNoRows
+	}
+	return nil
+}
+
+// Save applies the track changes of an album event in one transaction. Tracks
+// are created or updated and removed tracks deleted by their disc and number.
+func (r *trackRepository) Save(albumId int, tracks []models.Track, removed []models.Track) error {
+	return r.db.RunInTransaction(r.db.Context(), func(tx *pg.Tx) error {
+		for _, track := range removed {
+			_, err := tx.Model((*models.Track)(nil)).
+				Where("album_id = ? AND disc = ? AND number = ?", albumId, track.Disc, track.Number).
+				Delete()
+			if err != nil {
+				return err
+			}
+		}
+		for _, track := range tracks {
+			track.Id = 0
+			track.AlbumId = albumId
+			_, err := tx.Model(&track).
+				OnConflict("(album_id, disc, number) DO UPDATE").
+				Set("title = EXCLUDED.title, duration_ms = EXCLUDED.duration_ms, isrc = EXCLUDED.isrc").
+				Insert()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
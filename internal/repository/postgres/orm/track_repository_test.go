@@ -0,0 +1,106 @@
+package orm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+func TestNewTrackRepository(t *testing.T) {
+	t.Run("creates repository successfully", func(t *testing.T) {
+		db := &pg.DB{}
+		var repo TrackRepository = NewTrackRepository(db)
+
+		if repo == nil {
+			t.Error("Expected repository to be created, got nil")
+		}
+	})
+}
+
+func TestTrackRepository_Integration(t *testing.T) {
+	db := testDB(t)
+	repo := NewTrackRepository(db)
+	album := createTestAlbum(t, db, &models.Album{Title: "Blue Train", Artist: uniqueName("Tracks")})
+	other := createTestAlbum(t, db, &models.Album{Title: "Giant Steps", Artist: album.Artist})
+
+	second := &models.Track{AlbumId: album.Id, Disc: 1, Number: 2, Title: "Moment's Notice"}
+	first := &models.Track{AlbumId: album.Id, Disc: 1, Number: 1, Title: "Blue Train", Isrc: "USBN15700001"}
+	for _, track := range []*models.Track{second, first} {
+		if err := repo.Create(track); err != nil {
+			t.Fatalf("Failed to create track: %v", err)
+		}
+	}
+
+	t.Run("lists the tracks of the album in order", func(t *testing.T) {
+		tracks, err := repo.GetByAlbum(album.Id)
+		if err != nil {
+			t.Fatalf("Failed to get tracks: %v", err)
+		}
+		if len(tracks) != 2 || tracks[0].Id != first.Id || tracks[1].Id != second.Id {
+			t.Errorf("Expected tracks %d and %d, got %v", first.Id, second.Id, tracks)
+		}
+	})
+
+	t.Run("rejects a second track at the same position", func(t *testing.T) {
+		err := repo.Create(&models.Track{AlbumId: album.Id, Disc: 1, Number: 1, Title: "Blue Train"})
+		if !IsConflict(err) {
+			t.Errorf("Expected a conflict, got %v", err)
+		}
+	})
+
+	t.Run("rejects an invalid ISRC", func(t *testing.T) {
+		err := repo.Create(&models.Track{AlbumId: album.Id, Disc: 1, Number: 3, Title: "Locomotion", Isrc: "BN157"})
+		if !IsInvalid(err) {
+			t.Errorf("Expected an invalid track, got %v", err)
+		}
+	})
+
+	t.Run("rejects a track of an unknown album", func(t *testing.T) {
+		err := repo.Create(&models.Track{AlbumId: -1, Disc: 1, Number: 1, Title: "Nowhere"})
+		if !IsForeignKeyViolation(err) {
+			t.Errorf("Expected a foreign key violation, got %v", err)
+		}
+	})
+
+	t.Run("does not read, update or delete the track through another album", func(t *testing.T) {
+		if _, err := repo.GetById(other.Id, first.Id); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows reading, got %v", err)
+		}
+		moved := *first
+		moved.AlbumId = other.Id
+		if err := repo.Update(moved); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows updating, got %v", err)
+		}
+		if err := repo.Delete(other.Id, first.Id); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows deleting, got %v", err)
+		}
+	})
+
+	t.Run("saves the tracks of an album event by position", func(t *testing.T) {
+		tracks := []models.Track{
+			{Disc: 1, Number: 1, Title: "Blue Train (Remastered)", DurationMs: 643000},
+			{Disc: 1, Number: 3, Title: "Locomotion"},
+		}
+		removed := []models.Track{{Disc: 1, Number: 2}}
+		if err := repo.Save(album.Id, tracks, removed); err != nil {
+			t.Fatalf("Failed to save tracks: %v", err)
+		}
+
+		got, err := repo.GetByAlbum(album.Id)
+		if err != nil {
+			t.Fatalf("Failed to get tracks: %v", err)
+		}
+		if len(got) != 2 {
+			t.Fatalf("Expected 2 tracks, got %v", got)
+		}
+		if got[0].Id != first.Id || got[0].Title != "Blue Train (Remastered)" || got[0].DurationMs != 643000 {
+			t.Errorf("Expected track %d to be updated in place, got %s", first.Id, got[0].String())
+		}
+		if got[1].Number != 3 || got[1].Title != "Locomotion" {
+			t.Errorf("Expected track 3 to be created, got %s", got[1].String())
+		}
+	})
+}
@@ -0,0 +1,34 @@
+package orm
+
+import (
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+// UserRepository keeps the users, see sql/ddl/create_table_users.sql.
+type UserRepository interface {
+	Create(user *models.User) error
+	GetById(id int) (*models.User, error)
+}
+
+type userRepository struct {
+	db *pg.DB
+}
+
+func NewUserRepository(db *pg.DB) UserRepository {
+	return &userRepository{db: db}
+}
+
+// Create inserts the user and sets its id and creation time. It returns a
+// unique violation when the email is taken.
+func (r *userRepository) Create(user *models.User) error {
+	_, err := r.db.Model(user).Returning("id, created_at").Insert()
+	return err
+}
+
+func (r *userRepository) GetById(id int) (*models.User, error) {
+	user := &models.User{Id: id}
+	err := r.db.Model(user).WherePK().Select()
+	return user, err
+}
@@ -0,0 +1,84 @@
+package orm
+
+import (
+	"github.com/go-pg/pg/v10"
+
+	"music-service/internal/models"
+)
+
+// WishlistRepository keeps the wishlists of the users and the notifications
+// of price drops postgres writes for them, see sql/ddl/create_table_users.sql.
+type WishlistRepository interface {
+	Add(userId, albumId int) error
+	Remove(userId, albumId int) error
+	GetAlbums(userId int) ([]*models.Album, error)
+	GetNotifications(userId int, unreadOnly bool) ([]*models.PriceDropNotification, error)
+	MarkRead(userId int, id int64) (*models.PriceDropNotification, error)
+}
+
+type wishlistRepository struct {
+	db *pg.DB
+}
+
+func NewWishlistRepository(db *pg.DB) WishlistRepository {
+	return &wishlistRepository{db: db}
+}
+
+// Add adds the album to the wishlist of the user, unless it is on it already.
+// It returns a foreign key violation when the user or the album does not
+// exist.
+func (r *wishlistRepository) Add(userId, albumId int) error {
+	_, err := r.db.Model(&models.WishlistItem{UserId: userId, AlbumId: albumId}).
+		OnConflict("DO NOTHING").
+		Insert()
+	return err
+}
+
+// Remove returns pg.ErrNoRows when the album is not on the wishlist.
+func (r *wishlistRepository) Remove(userId, albumId int) error {
+	result, err := r.db.Model(&models.WishlistItem{UserId: userId, AlbumId: albumId}).WherePK().Delete()
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pg.ErrNoRows
+	}
+	return nil
+}
+
+// GetAlbums returns the albums on the wishlist of the user, the latest added
+// first.
+func (r *wishlistRepository) GetAlbums(userId int) ([]*models.Album, error) {
+	albums := []*models.Album{}
+	err := r.db.Model(&albums).
+		Join("JOIN music.wishlists ON wishlists.album_id = album.id").
+		Where("wishlists.user_id = ?", userId).
+		OrderExpr("wishlists.added_at DESC, album.id").
+		Select()
+	return albums, err
+}
+
+// GetNotifications returns the price drop notifications of the user, the
+// latest first.
+func (r *wishlistRepository) GetNotifications(userId int, unreadOnly bool) ([]*models.PriceDropNotification, error) {
+	notifications := []*models.PriceDropNotification{}
+	query := r.db.Model(&notifications).Where("user_id = ?", userId)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	err := query.Order("id DESC").Select()
+	return notifications, err
+}
+
+// MarkRead marks the notification of the user read, keeping the time it was
+// first read. It returns pg.ErrNoRows when the user has no such notification.
+func (r *wishlistRepository) MarkRead(userId int, id int64) (*models.PriceDropNotification, error) {
+	notification := &models.PriceDropNotification{}
+	_, err := r.db.QueryOne(notification,
+		"UPDATE music.price_drop_notifications SET read_at = coalesce(read_at, now()) WHERE id = ? AND user_id = ? RETURNING *",
+		id, userId)
+	if err != nil {
+		return nil, err
+	}
+	return notification, nil
+}
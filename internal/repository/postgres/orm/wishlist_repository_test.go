@@ -0,0 +1,125 @@
+package orm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/shopspring/decimal"
+
+	"music-service/internal/models"
+)
+
+func TestNewWishlistRepository(t *testing.T) {
+	t.Run("creates repository successfully", func(t *testing.T) {
+		db := &pg.DB{}
+		var repo WishlistRepository = NewWishlistRepository(db)
+
+		if repo == nil {
+			t.Error("Expected repository to be created, got nil")
+		}
+	})
+}
+
+func TestWishlistRepository_Integration(t *testing.T) {
+	db := testDB(t)
+	repo := NewWishlistRepository(db)
+	prices := NewPriceRepository(db)
+
+	album := createTestAlbum(t, db, &models.Album{Title: "Blue Train", Artist: uniqueName("Wishlists"), Price: decimal.RequireFromString("10.00"), Currency: "USD"})
+	jane := createTestUser(t, db, "USD")
+	john := createTestUser(t, db, "EUR")
+
+	t.Run("adds an album once", func(t *testing.T) {
+		for _, user := range []*models.User{jane, jane, john} {
+			if err := repo.Add(user.Id, album.Id); err != nil {
+				t.Fatalf("Failed to add album: %v", err)
+			}
+		}
+		albums, err := repo.GetAlbums(jane.Id)
+		if err != nil {
+			t.Fatalf("Failed to get albums: %v", err)
+		}
+		if len(albums) != 1 || albums[0].Id != album.Id {
+			t.Errorf("Expected album %d, got %v", album.Id, albums)
+		}
+	})
+
+	t.Run("does not add an unknown album", func(t *testing.T) {
+		if err := repo.Add(jane.Id, -1); !IsForeignKeyViolation(err) {
+			t.Errorf("Expected a foreign key violation, got %v", err)
+		}
+	})
+
+	t.Run("notifies of a price drop in the currency of the user", func(t *testing.T) {
+		for _, price := range []string{"12.00", "8.00"} {
+			err := prices.SetPrice(models.Price{AlbumId: album.Id, Currency: "USD", Price: decimal.RequireFromString(price)}, "jane")
+			if err != nil {
+				t.Fatalf("Failed to set price: %v", err)
+			}
+		}
+
+		notifications, err := repo.GetNotifications(jane.Id, true)
+		if err != nil {
+			t.Fatalf("Failed to get notifications: %v", err)
+		}
+		if len(notifications) != 1 {
+			t.Fatalf("Expected 1 notification of the drop, got %v", notifications)
+		}
+		notification := notifications[0]
+		if !notification.OldPrice.Equal(decimal.RequireFromString("12.00")) || !notification.NewPrice.Equal(decimal.RequireFromString("8.00")) {
+			t.Errorf("Expected a drop from 12.00 to 8.00, got %s to %s", notification.OldPrice, notification.NewPrice)
+		}
+
+		notifications, err = repo.GetNotifications(john.Id, false)
+		if err != nil {
+			t.Fatalf("Failed to get notifications: %v", err)
+		}
+		if len(notifications) != 0 {
+			t.Errorf("Expected no notifications in another currency, got %v", notifications)
+		}
+	})
+
+	t.Run("marks a notification read once", func(t *testing.T) {
+		notifications, err := repo.GetNotifications(jane.Id, true)
+		if err != nil {
+			t.Fatalf("Failed to get notifications: %v", err)
+		}
+		if len(notifications) != 1 {
+			t.Fatalf("Expected 1 unread notification, got %d", len(notifications))
+		}
+		id := notifications[0].Id
+
+		if _, err := repo.MarkRead(john.Id, id); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows marking the notification of another user, got %v", err)
+		}
+		read, err := repo.MarkRead(jane.Id, id)
+		if err != nil {
+			t.Fatalf("Failed to mark read: %v", err)
+		}
+		again, err := repo.MarkRead(jane.Id, id)
+		if err != nil {
+			t.Fatalf("Failed to mark read: %v", err)
+		}
+		if read.ReadAt == nil || again.ReadAt == nil || !again.ReadAt.Equal(*read.ReadAt) {
+			t.Errorf("Expected the time it was first read kept, got %v and %v", read.ReadAt, again.ReadAt)
+		}
+
+		notifications, err = repo.GetNotifications(jane.Id, true)
+		if err != nil {
+			t.Fatalf("Failed to get notifications: %v", err)
+		}
+		if len(notifications) != 0 {
+			t.Errorf("Expected no unread notifications, got %v", notifications)
+		}
+	})
+
+	t.Run("removes an album", func(t *testing.T) {
+		if err := repo.Remove(jane.Id, album.Id); err != nil {
+			t.Fatalf("Failed to remove album: %v", err)
+		}
+		if err := repo.Remove(jane.Id, album.Id); !errors.Is(err, pg.ErrNoRows) {
+			t.Errorf("Expected pg.ErrNoRows removing again, got %v", err)
+		}
+	})
+}
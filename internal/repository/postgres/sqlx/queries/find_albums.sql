@@ -0,0 +1 @@
+SELECT id, title, artist_id, artist, price, currency, COALESCE(label_id, 0) AS label_id, release_date, average_rating, rating_count FROM music.find_albums($1, $2, $3, $4, $5)
\ No newline at end of file
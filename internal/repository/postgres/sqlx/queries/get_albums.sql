@@ -1 +1 @@
-SELECT id, title, artist, price FROM music.albums
\ No newline at end of file
+SELECT id, title, artist_id, artist, price, currency, COALESCE(label_id, 0) AS label_id, release_date, average_rating, rating_count FROM music.albums
\ No newline at end of file
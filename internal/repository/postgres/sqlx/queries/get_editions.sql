@@ -0,0 +1 @@
+SELECT id, album_id, format, COALESCE(name, '') AS name, sku, COALESCE(barcode, '') AS barcode, COALESCE(catalog_number, '') AS catalog_number, price FROM music.editions WHERE album_id = $1 ORDER BY id
\ No newline at end of file
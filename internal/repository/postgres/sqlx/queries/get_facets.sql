@@ -0,0 +1 @@
+SELECT facet, id, name, parent_id, lower_price, upper_price, count FROM music.album_facets($1, $2, $3, $4, $5)
\ No newline at end of file
@@ -0,0 +1 @@
+SELECT id AS album_id, currency, price FROM music.albums WHERE id = $1 UNION ALL (SELECT album_id, currency, price FROM music.album_prices WHERE album_id = $1 ORDER BY currency)
\ No newline at end of file
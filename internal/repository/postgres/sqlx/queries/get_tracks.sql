@@ -0,0 +1 @@
+SELECT id, album_id, disc, number, title, duration_ms, COALESCE(isrc, '') AS isrc FROM music.tracks WHERE album_id = $1 ORDER BY disc, number
\ No newline at end of file
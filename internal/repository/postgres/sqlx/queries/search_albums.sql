@@ -0,0 +1 @@
+SELECT id, title, artist_id, artist, price, currency, COALESCE(label_id, 0) AS label_id, release_date, average_rating, rating_count, rank, title_highlight, artist_highlight FROM music.search_albums($1, $2)
\ No newline at end of file
@@ -10,6 +10,13 @@ import (
 
 type Repository interface {
 	Read() ([]models.Album, error)
+	ReadById(id int) (models.Album, error)
+	ReadTracks(albumId int) ([]models.Track, error)
+	ReadEditions(albumId int) ([]models.Edition, error)
+	ReadPrices(albumId int) ([]models.Price, error)
+	ReadFiltered(filter models.AlbumFilter) ([]models.Album, error)
+	ReadFacets(filter models.AlbumFilter) (*models.Facets, error)
+	Search(query string, limit int) ([]models.SearchResult, error)
 }
 
 type repository struct {
@@ -36,3 +43,78 @@ func (r *repository) Read() ([]models.Album, error) {
 	}
 	return albums, nil
 }
+
+//go:embed queries/get_album.sql
+var getAlbumQuery string
+
+// ReadById returns sql.ErrNoRows when there is no album with the id.
+func (r *repository) ReadById(id int) (models.Album, error) {
+	album := models.Album{}
+	err := r.db.Get(&album, getAlbumQuery, id)
+	return album, err
+}
+
+//go:embed queries/get_tracks.sql
+var getTracksQuery string
+
+func (r *repository) ReadTracks(albumId int) ([]models.Track, error) {
+	tracks := []models.Track{}
+	err := r.db.Select(&tracks, getTracksQuery, albumId)
+	return tracks, err
+}
+
+//go:embed queries/get_editions.sql
+var getEditionsQuery string
+
+func (r *repository) ReadEditions(albumId int) ([]models.Edition, error) {
+	editions := []models.Edition{}
+	err := r.db.Select(&editions, getEditionsQuery, albumId)
+	return editions, err
+}
+
+//go:embed queries/get_prices.sql
+var getPricesQuery string
+
+// ReadPrices returns the price in the currency of the album followed by the
+// prices in other currencies.
+func (r *repository) ReadPrices(albumId int) ([]models.Price, error) {
+	prices := []models.Price{}
+	err := r.db.Select(&prices, getPricesQuery, albumId)
+	return prices, err
+}
+
+//go:embed queries/find_albums.sql
+var findAlbumsQuery string
+
+// ReadFiltered returns the albums of the filter ordered by id, see
+// sql/ddl/create_function_browse_albums.sql.
+func (r *repository) ReadFiltered(filter models.AlbumFilter) ([]models.Album, error) {
+	albums := []models.Album{}
+	err := r.db.Select(&albums, findAlbumsQuery,
+		filter.ArtistId, filter.GenreId, filter.Tag, filter.MinPrice, filter.MaxPrice)
+	return albums, err
+}
+
+//go:embed queries/get_facets.sql
+var getFacetsQuery string
+
+func (r *repository) ReadFacets(filter models.AlbumFilter) (*models.Facets, error) {
+	rows := []models.FacetRow{}
+	err := r.db.Select(&rows, getFacetsQuery,
+		filter.ArtistId, filter.GenreId, filter.Tag, filter.MinPrice, filter.MaxPrice)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewFacets(rows), nil
+}
+
+//go:embed queries/search_albums.sql
+var searchAlbumsQuery string
+
+// Search returns at most limit albums matching the query, best first, see
+// sql/ddl/create_function_search_albums.sql.
+func (r *repository) Search(query string, limit int) ([]models.SearchResult, error) {
+	results := []models.SearchResult{}
+	err := r.db.Select(&results, searchAlbumsQuery, query, limit)
+	return results, err
+}
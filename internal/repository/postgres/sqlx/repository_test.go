@@ -2,10 +2,13 @@ package sqlx
 
 import (
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jmoiron/sqlx"
 	"github.com/shopspring/decimal"
+
+	"music-service/internal/models"
 )
 
 func TestNewRepository(t *testing.T) {
@@ -34,7 +37,7 @@ func TestRepository_Read(t *testing.T) {
 		AddRow(1, "Blue Train", "John Coltrane", decimal.NewFromFloat(56.99)).
 		AddRow(2, "Giant Steps", "John Coltrane", decimal.NewFromFloat(63.99))
 
-	mock.ExpectQuery("SELECT id, title, artist, price FROM music.albums").
+	mock.ExpectQuery("SELECT id, title, artist_id, artist, price, (.+) FROM music.albums").
 		WillReturnRows(rows)
 
 	albums, err := repo.Read()
@@ -68,7 +71,7 @@ func TestRepository_Read_EmptyResult(t *testing.T) {
 	// Set up expected query with no results
 	rows := sqlmock.NewRows([]string{"id", "title", "artist", "price"})
 
-	mock.ExpectQuery("SELECT id, title, artist, price FROM music.albums").
+	mock.ExpectQuery("SELECT id, title, artist_id, artist, price, (.+) FROM music.albums").
 		WillReturnRows(rows)
 
 	albums, err := repo.Read()
@@ -102,7 +105,7 @@ func TestRepository_Read_ScanError(t *testing.T) {
 	rows := sqlmock.NewRows([]string{"id", "title", "artist", "price"}).
 		AddRow("invalid", "Blue Train", "John Coltrane", decimal.NewFromFloat(56.99))
 
-	mock.ExpectQuery("SELECT id, title, artist, price FROM music.albums").
+	mock.ExpectQuery("SELECT id, title, artist_id, artist, price, (.+) FROM music.albums").
 		WillReturnRows(rows)
 
 	_, err = repo.Read()
@@ -115,6 +118,197 @@ func TestRepository_Read_ScanError(t *testing.T) {
 	}
 }
 
+func TestRepository_ReadById(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	repo := NewRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "title", "artist_id", "artist", "price", "label_id", "release_date"}).
+		AddRow(1, "Blue Train", 7, "John Coltrane", decimal.NewFromFloat(56.99), 4, time.Date(1958, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	mock.ExpectQuery(`SELECT id, title, artist_id, artist, price, (.+) FROM music.albums WHERE id = \$1`).
+		WithArgs(1).
+		WillReturnRows(rows)
+
+	album, err := repo.ReadById(1)
+	if err != nil {
+		t.Fatalf("ReadById() returned unexpected error: %v", err)
+	}
+	if album.Title != "Blue Train" || album.ArtistId != 7 {
+		t.Errorf("Expected album 'Blue Train' of artist 7, got %v", album)
+	}
+	if album.LabelId != 4 || album.ReleaseDate.String() != "1958-01-01" {
+		t.Errorf("Expected label 4 and release date 1958-01-01, got %d and %s", album.LabelId, album.ReleaseDate)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRepository_ReadTracks(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	repo := NewRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "album_id", "disc", "number", "title", "duration_ms", "isrc"}).
+		AddRow(1, 1, 1, 1, "Blue Train", 643000, "USBN15700001").
+		AddRow(2, 1, 1, 2, "Moment's Notice", 551000, "")
+
+	mock.ExpectQuery("SELECT (.+) FROM music.tracks WHERE album_id").
+		WithArgs(1).
+		WillReturnRows(rows)
+
+	tracks, err := repo.ReadTracks(1)
+	if err != nil {
+		t.Fatalf("ReadTracks() returned unexpected error: %v", err)
+	}
+	if len(tracks) != 2 || tracks[1].Title != "Moment's Notice" || tracks[1].Number != 2 {
+		t.Errorf("Expected the two tracks of the album, got %v", tracks)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRepository_ReadEditions(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	repo := NewRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "album_id", "format", "name", "sku", "barcode", "catalog_number", "price"}).
+		AddRow(1, 1, "vinyl", "Mono", "BN-1577-LP", "0602537335138", "BLP 1577", decimal.NewFromFloat(29.99)).
+		AddRow(2, 1, "digital", "", "BN-1577-DL", "", "", decimal.NewFromFloat(9.99))
+
+	mock.ExpectQuery("SELECT (.+) FROM music.editions WHERE album_id").
+		WithArgs(1).
+		WillReturnRows(rows)
+
+	editions, err := repo.ReadEditions(1)
+	if err != nil {
+		t.Fatalf("ReadEditions() returned unexpected error: %v", err)
+	}
+	if len(editions) != 2 || editions[0].Barcode != "0602537335138" || editions[1].Format != "digital" {
+		t.Errorf("Expected the two editions of the album, got %v", editions)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRepository_ReadPrices(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	repo := NewRepository(db)
+
+	rows := sqlmock.NewRows([]string{"album_id", "currency", "price"}).
+		AddRow(1, "USD", decimal.NewFromFloat(56.99)).
+		AddRow(1, "EUR", decimal.NewFromFloat(52.99))
+
+	mock.ExpectQuery("SELECT (.+) FROM music.albums WHERE id = \\$1 UNION ALL (.+) FROM music.album_prices").
+		WithArgs(1).
+		WillReturnRows(rows)
+
+	prices, err := repo.ReadPrices(1)
+	if err != nil {
+		t.Fatalf("ReadPrices() returned unexpected error: %v", err)
+	}
+	if len(prices) != 2 || prices[0].Currency != "USD" || prices[1].Currency != "EUR" {
+		t.Errorf("Expected the USD and EUR prices of the album, got %v", prices)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRepository_ReadFiltered(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	repo := NewRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "title", "artist_id", "artist", "price", "label_id", "release_date"}).
+		AddRow(1, "Blue Train", 7, "John Coltrane", decimal.NewFromFloat(56.99), 4, time.Date(1958, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	mock.ExpectQuery(`SELECT (.+) FROM music.find_albums\(\$1, \$2, \$3, \$4, \$5\)`).
+		WithArgs(7, 0, "live", decimal.Zero, decimal.NewFromInt(60)).
+		WillReturnRows(rows)
+
+	albums, err := repo.ReadFiltered(models.AlbumFilter{ArtistId: 7, Tag: "live", MaxPrice: decimal.NewFromInt(60)})
+	if err != nil {
+		t.Fatalf("ReadFiltered() returned unexpected error: %v", err)
+	}
+	if len(albums) != 1 || albums[0].Title != "Blue Train" {
+		t.Errorf("Expected the album 'Blue Train', got %v", albums)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRepository_ReadFacets(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	repo := NewRepository(db)
+
+	rows := sqlmock.NewRows([]string{"facet", "id", "name", "parent_id", "lower_price", "upper_price", "count"}).
+		AddRow("artist", 7, "John Coltrane", 0, decimal.Zero, decimal.Zero, 2).
+		AddRow("genre", 2, "Hard Bop", 1, decimal.Zero, decimal.Zero, 2).
+		AddRow("price", 4, "", 0, decimal.NewFromInt(50), decimal.NewFromInt(100), 2)
+
+	mock.ExpectQuery(`SELECT (.+) FROM music.album_facets`).
+		WithArgs(0, 1, "", decimal.Zero, decimal.Zero).
+		WillReturnRows(rows)
+
+	facets, err := repo.ReadFacets(models.AlbumFilter{GenreId: 1})
+	if err != nil {
+		t.Fatalf("ReadFacets() returned unexpected error: %v", err)
+	}
+	if len(facets.Artists) != 1 || len(facets.Genres) != 1 || len(facets.Tags) != 0 {
+		t.Errorf("Expected one artist and one genre, got %v", facets)
+	}
+	if len(facets.Prices) != 1 || !facets.Prices[0].Max.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("Expected the price bucket up to 100, got %v", facets.Prices)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
 func TestRepository_Interface(t *testing.T) {
 	db := &sqlx.DB{}
 	repo := NewRepository(db)
@@ -144,7 +338,7 @@ func BenchmarkRepository_Read(b *testing.B) {
 			AddRow(1, "Blue Train", "John Coltrane", decimal.NewFromFloat(56.99)).
 			AddRow(2, "Giant Steps", "John Coltrane", decimal.NewFromFloat(63.99))
 
-		mock.ExpectQuery("SELECT id, title, artist, price FROM music.albums").
+		mock.ExpectQuery("SELECT id, title, artist_id, artist, price, (.+) FROM music.albums").
 			WillReturnRows(rows)
 	}
 
@@ -153,3 +347,33 @@ func BenchmarkRepository_Read(b *testing.B) {
 		_, _ = repo.Read()
 	}
 }
+
+func TestRepository_Search(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	repo := NewRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "title", "artist_id", "artist", "price", "label_id", "release_date", "rank", "title_highlight", "artist_highlight"}).
+		AddRow(2, "Giant Steps", 7, "John Coltrane", decimal.NewFromFloat(63.99), 0, nil, 0.6, "<mark>Giant</mark> Steps", "John Coltrane")
+
+	mock.ExpectQuery(`SELECT (.+) FROM music.search_albums\(\$1, \$2\)`).
+		WithArgs("giant", 20).
+		WillReturnRows(rows)
+
+	results, err := repo.Search("giant", 20)
+	if err != nil {
+		t.Fatalf("Search() returned unexpected error: %v", err)
+	}
+	if len(results) != 1 || results[0].Title != "Giant Steps" || results[0].TitleHighlight != "<mark>Giant</mark> Steps" {
+		t.Errorf("Expected 'Giant Steps' with its highlight, got %v", results)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
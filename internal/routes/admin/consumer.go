@@ -0,0 +1,16 @@
+package admin
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/handler/rest/admin"
+	"music-service/pkg/kafka"
+)
+
+func RegisterConsumerRoutes(router fiber.Router, controller kafka.ConsumerController) {
+	consumerHandler := admin.NewConsumerHandler(controller)
+	router.Get("/consumer", consumerHandler.GetStatus)
+	router.Post("/consumer/pause", consumerHandler.Pause)
+	router.Post("/consumer/resume", consumerHandler.Resume)
+	router.Post("/consumer/offsets", consumerHandler.ResetOffsets)
+}
@@ -0,0 +1,85 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/pkg/kafka"
+)
+
+type MockConsumerController struct {
+}
+
+func (m *MockConsumerController) Pause(topic string, partitions []int32) error {
+	return nil
+}
+
+func (m *MockConsumerController) Resume(topic string, partitions []int32) error {
+	return nil
+}
+
+func (m *MockConsumerController) Status(ctx context.Context) ([]kafka.PartitionStatus, error) {
+	return []kafka.PartitionStatus{}, nil
+}
+
+func (m *MockConsumerController) ResetOffsets(ctx context.Context, reset kafka.OffsetReset) error {
+	return nil
+}
+
+func TestRegisterConsumerRoutes(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{
+			name:           "GET /consumer route is registered",
+			method:         "GET",
+			path:           "/consumer",
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "POST /consumer/pause route is registered",
+			method:         "POST",
+			path:           "/consumer/pause",
+			expectedStatus: fiber.StatusBadRequest, // Will fail validation, but route exists
+		},
+		{
+			name:           "POST /consumer/resume route is registered",
+			method:         "POST",
+			path:           "/consumer/resume",
+			expectedStatus: fiber.StatusBadRequest, // Will fail validation, but route exists
+		},
+		{
+			name:           "POST /consumer/offsets route is registered",
+			method:         "POST",
+			path:           "/consumer/offsets",
+			expectedStatus: fiber.StatusBadRequest, // Will fail validation, but route exists
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			RegisterConsumerRoutes(app.Group(""), &MockConsumerController{})
+
+			req, err := http.NewRequest(tt.method, tt.path, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package v1
+
+import (
+	"music-service/internal/authz"
+	v1 "music-service/internal/handler/rest/v1"
+	"music-service/internal/repository/postgres/orm"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterArtistRoutes(router fiber.Router, repository orm.ArtistRepository, authorizer *authz.Authorizer) {
+	read := authz.Require(authorizer, authz.CatalogRead)
+	write := authz.Require(authorizer, authz.CatalogWrite)
+	remove := authz.Require(authorizer, authz.CatalogDelete)
+	artistsHandler := v1.NewArtistsHandler(repository)
+	router.Post("/artists", write, artistsHandler.CreateArtist)
+	router.Get("/artists", read, artistsHandler.GetArtists)
+	router.Get("/artists/:id", read, artistsHandler.GetArtist)
+	router.Put("/artists/:id", write, artistsHandler.UpdateArtist)
+	router.Delete("/artists/:id", remove, artistsHandler.DeleteArtist)
+	router.Get("/artists/:id/albums", read, artistsHandler.GetArtistAlbums)
+}
@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/authz"
+	"music-service/pkg/auth"
+)
+
+func TestCatalogReadRoutes_Authorization(t *testing.T) {
+	paths := []string{
+		"/search?q=blue",
+		"/albums/1/tracks",
+		"/albums/1/tracks/1",
+		"/albums/1/editions",
+		"/albums/1/editions/1",
+		"/albums/1/prices",
+		"/albums/1/prices/history",
+		"/albums/1/prices/scheduled",
+		"/artists",
+		"/artists/1",
+		"/artists/1/albums",
+		"/labels",
+		"/labels/1",
+		"/labels/1/albums",
+		"/genres",
+		"/genres/1",
+		"/albums/1/genres",
+		"/tags",
+		"/albums/1/tags",
+	}
+
+	tests := []struct {
+		name           string
+		identity       *auth.Identity
+		expectedStatus int
+	}{
+		{name: "unauthenticated", expectedStatus: fiber.StatusUnauthorized},
+		{name: "no roles", identity: &auth.Identity{Subject: "jane"}, expectedStatus: fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		app := fiber.New()
+		app.Use(func(ctx *fiber.Ctx) error {
+			if tt.identity != nil {
+				ctx.SetUserContext(auth.NewContext(ctx.UserContext(), tt.identity))
+			}
+			return ctx.Next()
+		})
+		// Without a default role, callers without roles have no permissions.
+		authorizer := authz.NewAuthorizer(&authz.Policy{
+			Roles: map[string][]authz.Permission{authz.RoleViewer: {authz.CatalogRead}},
+		})
+		router := app.Group("")
+		RegisterSearchRoutes(router, nil, authorizer)
+		RegisterTrackRoutes(router, nil, nil, authorizer)
+		RegisterEditionRoutes(router, nil, nil, authorizer)
+		RegisterPriceRoutes(router, nil, authorizer)
+		RegisterArtistRoutes(router, nil, authorizer)
+		RegisterLabelRoutes(router, nil, authorizer)
+		RegisterGenreRoutes(router, nil, nil, authorizer)
+		RegisterTagRoutes(router, nil, nil, authorizer)
+
+		for _, path := range paths {
+			t.Run(tt.name+" "+path, func(t *testing.T) {
+				req, _ := http.NewRequest("GET", path, nil)
+				resp, err := app.Test(req)
+				if err != nil {
+					t.Fatalf("Failed to test request: %v", err)
+				}
+				if resp.StatusCode != tt.expectedStatus {
+					t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package v1
+
+import (
+	"music-service/internal/authz"
+	v1 "music-service/internal/handler/rest/v1"
+	"music-service/internal/repository/postgres/orm"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterEditionRoutes(router fiber.Router, albums orm.Repository, editions orm.EditionRepository, authorizer *authz.Authorizer) {
+	read := authz.Require(authorizer, authz.CatalogRead)
+	write := authz.Require(authorizer, authz.CatalogWrite)
+	remove := authz.Require(authorizer, authz.CatalogDelete)
+	editionsHandler := v1.NewEditionsHandler(albums, editions)
+	router.Get("/albums/:id/editions", read, editionsHandler.GetEditions)
+	router.Post("/albums/:id/editions", write, editionsHandler.CreateEdition)
+	router.Get("/albums/:id/editions/:editionId", read, editionsHandler.GetEdition)
+	router.Put("/albums/:id/editions/:editionId", write, editionsHandler.UpdateEdition)
+	router.Delete("/albums/:id/editions/:editionId", remove, editionsHandler.DeleteEdition)
+}
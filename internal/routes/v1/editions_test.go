@@ -0,0 +1,144 @@
+package v1
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/authz"
+	"music-service/internal/models"
+	"music-service/pkg/auth"
+)
+
+// fakeEditionRepository is an in-memory orm.EditionRepository holding the
+// vinyl edition 1 of album 1
+type fakeEditionRepository struct {
+	editions map[int]*models.Edition
+}
+
+func newFakeEditionRepository() *fakeEditionRepository {
+	return &fakeEditionRepository{editions: map[int]*models.Edition{
+		1: {Id: 1, AlbumId: 1, Format: models.FormatVinyl, Sku: "BLP-1577"},
+	}}
+}
+
+func (f *fakeEditionRepository) Create(edition *models.Edition) error {
+	for _, e := range f.editions {
+		if e.Sku == edition.Sku {
+			return pgError("23505")
+		}
+	}
+	edition.Id = len(f.editions) + 1
+	f.editions[edition.Id] = edition
+	return nil
+}
+
+func (f *fakeEditionRepository) GetById(albumId, id int) (*models.Edition, error) {
+	edition, ok := f.editions[id]
+	if !ok || edition.AlbumId != albumId {
+		return nil, pg.ErrNoRows
+	}
+	return edition, nil
+}
+
+func (f *fakeEditionRepository) GetByAlbum(albumId int) ([]*models.Edition, error) {
+	editions := []*models.Edition{}
+	for _, edition := range f.editions {
+		if edition.AlbumId == albumId {
+			editions = append(editions, edition)
+		}
+	}
+	return editions, nil
+}
+
+func (f *fakeEditionRepository) Update(edition models.Edition) error {
+	if _, err := f.GetById(edition.AlbumId, edition.Id); err != nil {
+		return err
+	}
+	f.editions[edition.Id] = &edition
+	return nil
+}
+
+func (f *fakeEditionRepository) Delete(albumId, id int) error {
+	if _, err := f.GetById(albumId, id); err != nil {
+		return err
+	}
+	delete(f.editions, id)
+	return nil
+}
+
+func TestRegisterEditionRoutes(t *testing.T) {
+	tests := []struct {
+		name            string
+		roles           []string
+		method          string
+		path            string
+		body            string
+		expectedStatus  int
+		expectedBarcode string
+	}{
+		{name: "viewer lists editions", roles: []string{authz.RoleViewer}, method: "GET", path: "/albums/1/editions", expectedStatus: fiber.StatusOK},
+		{name: "viewer gets edition", roles: []string{authz.RoleViewer}, method: "GET", path: "/albums/1/editions/1", expectedStatus: fiber.StatusOK},
+		{name: "viewer gets edition of other album", roles: []string{authz.RoleViewer}, method: "GET", path: "/albums/2/editions/1", expectedStatus: fiber.StatusNotFound},
+		{name: "viewer creates edition", roles: []string{authz.RoleViewer}, method: "POST", path: "/albums/1/editions", body: `{"format":"cd","sku":"CDP-7465"}`, expectedStatus: fiber.StatusForbidden},
+		{name: "editor creates edition", roles: []string{authz.RoleEditor}, method: "POST", path: "/albums/1/editions", body: `{"format":"CD","sku":"CDP-7465","price":"12.99"}`, expectedStatus: fiber.StatusCreated},
+		{name: "editor creates edition with UPC-A", roles: []string{authz.RoleEditor}, method: "POST", path: "/albums/1/editions", body: `{"format":"cd","sku":"CDP-7465","barcode":"036000291452"}`, expectedStatus: fiber.StatusCreated, expectedBarcode: "036000291452"},
+		{name: "editor creates edition with hyphenated UPC-A", roles: []string{authz.RoleEditor}, method: "POST", path: "/albums/1/editions", body: `{"format":"cd","sku":"CDP-7465","barcode":"0-36000-29145-2"}`, expectedStatus: fiber.StatusCreated, expectedBarcode: "036000291452"},
+		{name: "editor creates edition with EAN-13", roles: []string{authz.RoleEditor}, method: "POST", path: "/albums/1/editions", body: `{"format":"cd","sku":"CDP-7465","barcode":"4006381333931"}`, expectedStatus: fiber.StatusCreated, expectedBarcode: "4006381333931"},
+		{name: "editor creates edition with EAN-8", roles: []string{authz.RoleEditor}, method: "POST", path: "/albums/1/editions", body: `{"format":"cd","sku":"CDP-7465","barcode":"73513537"}`, expectedStatus: fiber.StatusCreated, expectedBarcode: "73513537"},
+		{name: "editor creates edition with wrong UPC check digit", roles: []string{authz.RoleEditor}, method: "POST", path: "/albums/1/editions", body: `{"format":"cd","sku":"CDP-7465","barcode":"036000291453"}`, expectedStatus: fiber.StatusBadRequest},
+		{name: "editor creates edition with barcode of wrong length", roles: []string{authz.RoleEditor}, method: "POST", path: "/albums/1/editions", body: `{"format":"cd","sku":"CDP-7465","barcode":"03600029145"}`, expectedStatus: fiber.StatusBadRequest},
+		{name: "editor creates edition with letters in barcode", roles: []string{authz.RoleEditor}, method: "POST", path: "/albums/1/editions", body: `{"format":"cd","sku":"CDP-7465","barcode":"03600029145A"}`, expectedStatus: fiber.StatusBadRequest},
+		{name: "editor creates edition with unknown format", roles: []string{authz.RoleEditor}, method: "POST", path: "/albums/1/editions", body: `{"format":"8-track","sku":"CDP-7465"}`, expectedStatus: fiber.StatusBadRequest},
+		{name: "editor creates edition without sku", roles: []string{authz.RoleEditor}, method: "POST", path: "/albums/1/editions", body: `{"format":"cd"}`, expectedStatus: fiber.StatusBadRequest},
+		{name: "editor creates edition with negative price", roles: []string{authz.RoleEditor}, method: "POST", path: "/albums/1/editions", body: `{"format":"cd","sku":"CDP-7465","price":"-1"}`, expectedStatus: fiber.StatusBadRequest},
+		{name: "editor creates edition with taken sku", roles: []string{authz.RoleEditor}, method: "POST", path: "/albums/1/editions", body: `{"format":"vinyl","sku":"BLP-1577"}`, expectedStatus: fiber.StatusConflict},
+		{name: "editor updates edition with wrong UPC check digit", roles: []string{authz.RoleEditor}, method: "PUT", path: "/albums/1/editions/1", body: `{"format":"vinyl","sku":"BLP-1577","barcode":"036000291450"}`, expectedStatus: fiber.StatusBadRequest},
+		{name: "editor updates edition", roles: []string{authz.RoleEditor}, method: "PUT", path: "/albums/1/editions/1", body: `{"format":"vinyl","sku":"BLP-1577","barcode":"036000291452"}`, expectedStatus: fiber.StatusOK, expectedBarcode: "036000291452"},
+		{name: "editor updates unknown edition", roles: []string{authz.RoleEditor}, method: "PUT", path: "/albums/1/editions/9", body: `{"format":"vinyl","sku":"BLP-1577"}`, expectedStatus: fiber.StatusNotFound},
+		{name: "editor deletes edition", roles: []string{authz.RoleEditor}, method: "DELETE", path: "/albums/1/editions/1", expectedStatus: fiber.StatusForbidden},
+		{name: "admin deletes edition", roles: []string{authz.RoleAdmin}, method: "DELETE", path: "/albums/1/editions/1", expectedStatus: fiber.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(func(ctx *fiber.Ctx) error {
+				ctx.SetUserContext(auth.NewContext(ctx.UserContext(), &auth.Identity{Subject: "jane", Roles: tt.roles}))
+				return ctx.Next()
+			})
+			editions := newFakeEditionRepository()
+			RegisterEditionRoutes(app.Group(""), &MockRepository{}, editions, authz.NewAuthorizer(authz.DefaultPolicy()))
+
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req, _ := http.NewRequest(tt.method, tt.path, body)
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if tt.expectedBarcode == "" {
+				return
+			}
+			found := false
+			for _, edition := range editions.editions {
+				found = found || edition.Barcode == tt.expectedBarcode
+			}
+			if !found {
+				t.Errorf("Expected an edition with barcode %s", tt.expectedBarcode)
+			}
+		})
+	}
+}
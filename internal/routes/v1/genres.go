@@ -0,0 +1,23 @@
+package v1
+
+import (
+	"music-service/internal/authz"
+	v1 "music-service/internal/handler/rest/v1"
+	"music-service/internal/repository/postgres/orm"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterGenreRoutes(router fiber.Router, genres orm.GenreRepository, albums orm.Repository, authorizer *authz.Authorizer) {
+	read := authz.Require(authorizer, authz.CatalogRead)
+	write := authz.Require(authorizer, authz.CatalogWrite)
+	remove := authz.Require(authorizer, authz.CatalogDelete)
+	genresHandler := v1.NewGenresHandler(genres, albums)
+	router.Post("/genres", write, genresHandler.CreateGenre)
+	router.Get("/genres", read, genresHandler.GetGenres)
+	router.Get("/genres/:id", read, genresHandler.GetGenre)
+	router.Put("/genres/:id", write, genresHandler.UpdateGenre)
+	router.Delete("/genres/:id", remove, genresHandler.DeleteGenre)
+	router.Get("/albums/:id/genres", read, genresHandler.GetAlbumGenres)
+	router.Put("/albums/:id/genres", write, genresHandler.SetAlbumGenres)
+}
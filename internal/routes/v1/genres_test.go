@@ -0,0 +1,174 @@
+package v1
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/authz"
+	"music-service/internal/models"
+	"music-service/pkg/auth"
+)
+
+// pgError is a pg.Error with the SQLSTATE code, such as 23503 for a foreign
+// key violation
+type pgError string
+
+func (e pgError) Error() string {
+	return "ERROR #" + string(e)
+}
+
+func (e pgError) Field(field byte) string {
+	if field == 'C' {
+		return string(e)
+	}
+	return ""
+}
+
+func (e pgError) IntegrityViolation() bool {
+	return strings.HasPrefix(string(e), "23")
+}
+
+// fakeGenreRepository is an in-memory orm.GenreRepository holding Jazz and
+// Hard Bop below it
+type fakeGenreRepository struct {
+	genres map[int]*models.Genre
+	albums map[int][]int
+}
+
+func newFakeGenreRepository() *fakeGenreRepository {
+	return &fakeGenreRepository{
+		genres: map[int]*models.Genre{
+			1: {Id: 1, Name: "Jazz"},
+			2: {Id: 2, Name: "Hard Bop", ParentId: 1},
+		},
+		albums: map[int][]int{},
+	}
+}
+
+func (f *fakeGenreRepository) Create(genre *models.Genre) error {
+	for _, g := range f.genres {
+		if g.ParentId == genre.ParentId && strings.EqualFold(g.Name, genre.Name) {
+			return pgError("23505")
+		}
+	}
+	genre.Id = len(f.genres) + 1
+	f.genres[genre.Id] = genre
+	return nil
+}
+
+func (f *fakeGenreRepository) GetById(id int) (*models.Genre, error) {
+	genre, ok := f.genres[id]
+	if !ok {
+		return nil, pg.ErrNoRows
+	}
+	return genre, nil
+}
+
+func (f *fakeGenreRepository) Get() ([]*models.Genre, error) {
+	genres := []*models.Genre{}
+	for _, genre := range f.genres {
+		genres = append(genres, genre)
+	}
+	return genres, nil
+}
+
+func (f *fakeGenreRepository) Update(genre models.Genre) error {
+	if _, ok := f.genres[genre.Id]; !ok {
+		return pg.ErrNoRows
+	}
+	f.genres[genre.Id] = &genre
+	return nil
+}
+
+func (f *fakeGenreRepository) Delete(id int) error {
+	if _, ok := f.genres[id]; !ok {
+		return pg.ErrNoRows
+	}
+	for _, genre := range f.genres {
+		if genre.ParentId == id {
+			return pgError("23503")
+		}
+	}
+	delete(f.genres, id)
+	return nil
+}
+
+func (f *fakeGenreRepository) GetByAlbum(albumId int) ([]*models.Genre, error) {
+	genres := []*models.Genre{}
+	for _, id := range f.albums[albumId] {
+		genres = append(genres, f.genres[id])
+	}
+	return genres, nil
+}
+
+func (f *fakeGenreRepository) SetAlbumGenres(albumId int, genreIds []int) error {
+	for _, id := range genreIds {
+		if _, ok := f.genres[id]; !ok {
+			return pgError("23503")
+		}
+	}
+	f.albums[albumId] = genreIds
+	return nil
+}
+
+func TestRegisterGenreRoutes(t *testing.T) {
+	tests := []struct {
+		name           string
+		roles          []string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "viewer lists genres", roles: []string{authz.RoleViewer}, method: "GET", path: "/genres", expectedStatus: fiber.StatusOK},
+		{name: "viewer gets genre", roles: []string{authz.RoleViewer}, method: "GET", path: "/genres/2", expectedStatus: fiber.StatusOK},
+		{name: "viewer gets unknown genre", roles: []string{authz.RoleViewer}, method: "GET", path: "/genres/9", expectedStatus: fiber.StatusNotFound},
+		{name: "viewer gets genre with invalid id", roles: []string{authz.RoleViewer}, method: "GET", path: "/genres/x", expectedStatus: fiber.StatusBadRequest},
+		{name: "viewer creates genre", roles: []string{authz.RoleViewer}, method: "POST", path: "/genres", body: `{"name":"Bebop","parentId":1}`, expectedStatus: fiber.StatusForbidden},
+		{name: "editor creates genre", roles: []string{authz.RoleEditor}, method: "POST", path: "/genres", body: `{"name":"Bebop","parentId":1}`, expectedStatus: fiber.StatusCreated},
+		{name: "editor creates genre without name", roles: []string{authz.RoleEditor}, method: "POST", path: "/genres", body: `{"name":"  ","parentId":1}`, expectedStatus: fiber.StatusBadRequest},
+		{name: "editor creates sibling genre differing in case", roles: []string{authz.RoleEditor}, method: "POST", path: "/genres", body: `{"name":"hard bop","parentId":1}`, expectedStatus: fiber.StatusConflict},
+		{name: "editor updates genre", roles: []string{authz.RoleEditor}, method: "PUT", path: "/genres/2", body: `{"name":"Hard bop","parentId":1}`, expectedStatus: fiber.StatusOK},
+		{name: "editor updates unknown genre", roles: []string{authz.RoleEditor}, method: "PUT", path: "/genres/9", body: `{"name":"Bebop"}`, expectedStatus: fiber.StatusNotFound},
+		{name: "editor deletes genre", roles: []string{authz.RoleEditor}, method: "DELETE", path: "/genres/2", expectedStatus: fiber.StatusForbidden},
+		{name: "admin deletes genre", roles: []string{authz.RoleAdmin}, method: "DELETE", path: "/genres/2", expectedStatus: fiber.StatusNoContent},
+		{name: "admin deletes genre with genres below it", roles: []string{authz.RoleAdmin}, method: "DELETE", path: "/genres/1", expectedStatus: fiber.StatusConflict},
+		{name: "viewer gets album genres", roles: []string{authz.RoleViewer}, method: "GET", path: "/albums/1/genres", expectedStatus: fiber.StatusOK},
+		{name: "viewer sets album genres", roles: []string{authz.RoleViewer}, method: "PUT", path: "/albums/1/genres", body: `[2]`, expectedStatus: fiber.StatusForbidden},
+		{name: "editor sets album genres", roles: []string{authz.RoleEditor}, method: "PUT", path: "/albums/1/genres", body: `[1,2]`, expectedStatus: fiber.StatusOK},
+		{name: "editor sets unknown album genre", roles: []string{authz.RoleEditor}, method: "PUT", path: "/albums/1/genres", body: `[9]`, expectedStatus: fiber.StatusBadRequest},
+		{name: "editor sets album genres with invalid JSON", roles: []string{authz.RoleEditor}, method: "PUT", path: "/albums/1/genres", body: `{"genres":[2]}`, expectedStatus: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(func(ctx *fiber.Ctx) error {
+				ctx.SetUserContext(auth.NewContext(ctx.UserContext(), &auth.Identity{Subject: "jane", Roles: tt.roles}))
+				return ctx.Next()
+			})
+			RegisterGenreRoutes(app.Group(""), newFakeGenreRepository(), &MockRepository{}, authz.NewAuthorizer(authz.DefaultPolicy()))
+
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req, _ := http.NewRequest(tt.method, tt.path, body)
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
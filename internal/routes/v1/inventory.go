@@ -0,0 +1,24 @@
+package v1
+
+import (
+	"music-service/internal/authz"
+	v1 "music-service/internal/handler/rest/v1"
+	"music-service/internal/repository/postgres/orm"
+	"music-service/pkg/kafka"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterInventoryRoutes(router fiber.Router, inventory orm.InventoryRepository, albums orm.Repository, producer kafka.ProducerHandler, authorizer *authz.Authorizer) {
+	read := authz.Require(authorizer, authz.CatalogRead)
+	write := authz.Require(authorizer, authz.CatalogWrite)
+	inventoryHandler := v1.NewInventoryHandler(inventory, albums, producer)
+	router.Post("/warehouses", write, inventoryHandler.CreateWarehouse)
+	router.Get("/warehouses", read, inventoryHandler.GetWarehouses)
+	router.Get("/albums/:id/stock", read, inventoryHandler.GetStock)
+	router.Put("/albums/:id/stock/:format/:warehouseId", write, inventoryHandler.SetStock)
+	router.Post("/inventory/reservations", write, inventoryHandler.Reserve)
+	router.Get("/inventory/reservations/:id", read, inventoryHandler.GetReservation)
+	router.Post("/inventory/reservations/:id/commit", write, inventoryHandler.CommitReservation)
+	router.Post("/inventory/reservations/:id/release", write, inventoryHandler.ReleaseReservation)
+}
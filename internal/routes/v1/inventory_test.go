@@ -0,0 +1,48 @@
+package v1
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/authz"
+	"music-service/pkg/auth"
+)
+
+func TestRegisterInventoryRoutes_Authorization(t *testing.T) {
+	tests := []struct {
+		name           string
+		roles          []string
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{name: "viewer sets stock", roles: []string{authz.RoleViewer}, method: "PUT", path: "/albums/1/stock/vinyl/1", expectedStatus: fiber.StatusForbidden},
+		{name: "viewer creates warehouse", roles: []string{authz.RoleViewer}, method: "POST", path: "/warehouses", expectedStatus: fiber.StatusForbidden},
+		{name: "viewer reserves stock", roles: []string{authz.RoleViewer}, method: "POST", path: "/inventory/reservations", expectedStatus: fiber.StatusForbidden},
+		{name: "viewer commits reservation", roles: []string{authz.RoleViewer}, method: "POST", path: "/inventory/reservations/1/commit", expectedStatus: fiber.StatusForbidden},
+		{name: "viewer releases reservation", roles: []string{authz.RoleViewer}, method: "POST", path: "/inventory/reservations/1/release", expectedStatus: fiber.StatusForbidden},
+		{name: "editor sets stock", roles: []string{authz.RoleEditor}, method: "PUT", path: "/albums/x/stock/vinyl/1", expectedStatus: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(func(ctx *fiber.Ctx) error {
+				ctx.SetUserContext(auth.NewContext(ctx.UserContext(), &auth.Identity{Subject: "jane", Roles: tt.roles}))
+				return ctx.Next()
+			})
+			RegisterInventoryRoutes(app.Group(""), nil, nil, nil, authz.NewAuthorizer(authz.DefaultPolicy()))
+
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
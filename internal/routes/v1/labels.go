@@ -0,0 +1,22 @@
+package v1
+
+import (
+	"music-service/internal/authz"
+	v1 "music-service/internal/handler/rest/v1"
+	"music-service/internal/repository/postgres/orm"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterLabelRoutes(router fiber.Router, repository orm.LabelRepository, authorizer *authz.Authorizer) {
+	read := authz.Require(authorizer, authz.CatalogRead)
+	write := authz.Require(authorizer, authz.CatalogWrite)
+	remove := authz.Require(authorizer, authz.CatalogDelete)
+	labelsHandler := v1.NewLabelsHandler(repository)
+	router.Post("/labels", write, labelsHandler.CreateLabel)
+	router.Get("/labels", read, labelsHandler.GetLabels)
+	router.Get("/labels/:id", read, labelsHandler.GetLabel)
+	router.Put("/labels/:id", write, labelsHandler.UpdateLabel)
+	router.Delete("/labels/:id", remove, labelsHandler.DeleteLabel)
+	router.Get("/labels/:id/albums", read, labelsHandler.GetLabelAlbums)
+}
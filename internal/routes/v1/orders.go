@@ -0,0 +1,28 @@
+package v1
+
+import (
+	"music-service/internal/authz"
+	v1 "music-service/internal/handler/rest/v1"
+	"music-service/internal/orders"
+	"music-service/internal/repository/postgres/orm"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterOrderRoutes(router fiber.Router, repository orm.OrderRepository, service *orders.Service, authorizer *authz.Authorizer) {
+	read := authz.Require(authorizer, authz.CatalogRead)
+	write := authz.Require(authorizer, authz.CatalogWrite)
+	admin := authz.Require(authorizer, authz.Admin)
+	ordersHandler := v1.NewOrdersHandler(repository, service)
+	router.Post("/orders/carts", read, ordersHandler.CreateCart)
+	router.Get("/orders/carts/:id", read, ordersHandler.GetCart)
+	router.Post("/orders/carts/:id/items", read, ordersHandler.AddCartItem)
+	router.Delete("/orders/carts/:id/items/:albumId/:format", read, ordersHandler.RemoveCartItem)
+	router.Post("/orders", read, ordersHandler.Checkout)
+	router.Get("/orders", read, ordersHandler.GetOrders)
+	router.Get("/orders/:id", read, ordersHandler.GetOrder)
+	router.Post("/orders/:id/pay", read, ordersHandler.Pay)
+	router.Post("/orders/:id/ship", write, ordersHandler.Ship)
+	router.Post("/orders/:id/cancel", read, ordersHandler.Cancel)
+	router.Post("/orders/:id/refund", admin, ordersHandler.Refund)
+}
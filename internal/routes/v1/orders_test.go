@@ -0,0 +1,46 @@
+package v1
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/authz"
+	"music-service/pkg/auth"
+)
+
+func TestRegisterOrderRoutes_Authorization(t *testing.T) {
+	tests := []struct {
+		name           string
+		roles          []string
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{name: "viewer ships order", roles: []string{authz.RoleViewer}, method: "POST", path: "/orders/1/ship", expectedStatus: fiber.StatusForbidden},
+		{name: "editor ships order", roles: []string{authz.RoleEditor}, method: "POST", path: "/orders/x/ship", expectedStatus: fiber.StatusBadRequest},
+		{name: "editor refunds order", roles: []string{authz.RoleEditor}, method: "POST", path: "/orders/1/refund", expectedStatus: fiber.StatusForbidden},
+		{name: "admin refunds order", roles: []string{authz.RoleAdmin}, method: "POST", path: "/orders/x/refund", expectedStatus: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(func(ctx *fiber.Ctx) error {
+				ctx.SetUserContext(auth.NewContext(ctx.UserContext(), &auth.Identity{Subject: "jane", Roles: tt.roles}))
+				return ctx.Next()
+			})
+			RegisterOrderRoutes(app.Group(""), nil, nil, authz.NewAuthorizer(authz.DefaultPolicy()))
+
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
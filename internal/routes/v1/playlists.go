@@ -0,0 +1,29 @@
+package v1
+
+import (
+	"music-service/internal/authz"
+	v1 "music-service/internal/handler/rest/v1"
+	"music-service/internal/playlists"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterPlaylistRoutes(router fiber.Router, service *playlists.Service, authorizer *authz.Authorizer) {
+	read := authz.Require(authorizer, authz.CatalogRead)
+	playlistsHandler := v1.NewPlaylistsHandler(service)
+	router.Get("/playlists", read, playlistsHandler.GetPublicPlaylists)
+	router.Post("/playlists", read, playlistsHandler.CreatePlaylist)
+	router.Get("/playlists/shared/:token", read, playlistsHandler.GetSharedPlaylist)
+	router.Get("/playlists/shared/:token/export", read, playlistsHandler.ExportSharedPlaylist)
+	router.Get("/playlists/:id", read, playlistsHandler.GetPlaylist)
+	router.Put("/playlists/:id", read, playlistsHandler.UpdatePlaylist)
+	router.Delete("/playlists/:id", read, playlistsHandler.DeletePlaylist)
+	router.Get("/playlists/:id/export", read, playlistsHandler.ExportPlaylist)
+	router.Post("/playlists/:id/share-token", read, playlistsHandler.RotateShareToken)
+	router.Post("/playlists/:id/items", read, playlistsHandler.AddItem)
+	router.Delete("/playlists/:id/items/:itemId", read, playlistsHandler.RemoveItem)
+	router.Put("/playlists/:id/items/:itemId/position", read, playlistsHandler.MoveItem)
+	router.Put("/playlists/:id/collaborators/:collaboratorId", read, playlistsHandler.AddCollaborator)
+	router.Delete("/playlists/:id/collaborators/:collaboratorId", read, playlistsHandler.RemoveCollaborator)
+	router.Get("/users/:id/playlists", read, playlistsHandler.GetUserPlaylists)
+}
@@ -0,0 +1,21 @@
+package v1
+
+import (
+	"music-service/internal/authz"
+	v1 "music-service/internal/handler/rest/v1"
+	"music-service/internal/repository/postgres/orm"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterPriceRoutes(router fiber.Router, prices orm.PriceRepository, authorizer *authz.Authorizer) {
+	read := authz.Require(authorizer, authz.CatalogRead)
+	write := authz.Require(authorizer, authz.PricesWrite)
+	pricesHandler := v1.NewPricesHandler(prices)
+	router.Get("/albums/:id/prices", read, pricesHandler.GetPrices)
+	router.Get("/albums/:id/prices/history", read, pricesHandler.GetPriceHistory)
+	router.Get("/albums/:id/prices/scheduled", read, pricesHandler.GetScheduledPrices)
+	router.Delete("/albums/:id/prices/scheduled/:scheduleId", write, pricesHandler.CancelScheduledPrice)
+	router.Put("/albums/:id/prices/:currency", write, pricesHandler.SetPrice)
+	router.Delete("/albums/:id/prices/:currency", write, pricesHandler.RemovePrice)
+}
@@ -1,6 +1,8 @@
 package v1
 
 import (
+	"music-service/gen/pb"
+	"music-service/internal/authz"
 	v1 "music-service/internal/handler/rest/v1"
 	"music-service/internal/repository/postgres/orm"
 	"music-service/pkg/kafka"
@@ -8,13 +10,39 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func RegisterPublicRoutes(router fiber.Router, producerHandler kafka.ProducerHandler, repository orm.Repository) {
+func RegisterPublicRoutes(router fiber.Router, producerHandler kafka.ProducerHandler, repository orm.Repository, browse orm.BrowseRepository, authorizer *authz.Authorizer) {
+	read := authz.Require(authorizer, authz.CatalogRead)
+	write := authz.Require(authorizer, authz.CatalogWrite)
+	// Albums without a price keep theirs, so only setting one is a price change.
+	albumPrice := authz.RequireIf(authorizer, authz.PricesWrite, setsAlbumPrice)
+	albumsPrice := authz.RequireIf(authorizer, authz.PricesWrite, setsAlbumsPrice)
 	albumHandler := v1.NewAlbumHandler(producerHandler)
-	router.Post("/album", albumHandler.CreateAlbum)
-	router.Put("/album", albumHandler.CreateAlbum)
+	router.Post("/album", write, albumPrice, albumHandler.CreateAlbum)
+	router.Put("/album", write, albumPrice, albumHandler.CreateAlbum)
 
-	albumsHandler := v1.NewAlbumsHandler(producerHandler, repository)
-	router.Post("/albums", albumsHandler.CreateAlbums)
-	router.Put("/albums", albumsHandler.CreateAlbums)
-	router.Get("/albums", albumsHandler.GetAlbums)
+	albumsHandler := v1.NewAlbumsHandler(producerHandler, repository, browse)
+	router.Post("/albums", write, albumsPrice, albumsHandler.CreateAlbums)
+	router.Put("/albums", write, albumsPrice, albumsHandler.CreateAlbums)
+	router.Get("/albums", read, albumsHandler.GetAlbums)
+}
+
+// setsAlbumPrice reports whether the album in the body has a price. Bodies
+// that do not parse are left to the handler to reject.
+func setsAlbumPrice(ctx *fiber.Ctx) bool {
+	album := &pb.Album{}
+	return ctx.BodyParser(album) == nil && album.Price != 0
+}
+
+// setsAlbumsPrice reports whether any album in the body has a price.
+func setsAlbumsPrice(ctx *fiber.Ctx) bool {
+	albums := []*pb.Album{}
+	if ctx.BodyParser(&albums) != nil {
+		return false
+	}
+	for _, album := range albums {
+		if album.Price != 0 {
+			return true
+		}
+	}
+	return false
 }
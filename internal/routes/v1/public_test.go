@@ -4,13 +4,17 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
 
 	"music-service/gen/pb"
+	"music-service/internal/authz"
 	"music-service/internal/models"
+	"music-service/pkg/auth"
 )
 
 // MockProducer is a mock implementation of kafka.Producer for testing
@@ -25,6 +29,12 @@ func (m *MockProducer) Produce(ctx context.Context, album *pb.Album) {
 	m.lastAlbum = album
 }
 
+func (m *MockProducer) ProduceStockEvent(ctx context.Context, event *pb.StockEvent) {
+}
+
+func (m *MockProducer) ProduceOrderEvent(ctx context.Context, event *pb.OrderEvent) {
+}
+
 type MockRepository struct {
 }
 
@@ -75,7 +85,7 @@ func TestRegisterPublicRoutes(t *testing.T) {
 			router := app.Group("")
 			mockProducer := &MockProducer{}
 			mockRepostory := &MockRepository{}
-			RegisterPublicRoutes(router, mockProducer, mockRepostory)
+			RegisterPublicRoutes(router, mockProducer, mockRepostory, nil, nil)
 
 			req, err := http.NewRequest(tt.method, tt.path, nil)
 			if err != nil {
@@ -101,7 +111,7 @@ func TestRegisterPublicRoutes_WithRouterGroup(t *testing.T) {
 		v1Router := app.Group("/v1")
 		mockProducer := &MockProducer{}
 		mockRepository := &MockRepository{}
-		RegisterPublicRoutes(v1Router, mockProducer, mockRepository)
+		RegisterPublicRoutes(v1Router, mockProducer, mockRepository, nil, nil)
 
 		req, err := http.NewRequest("POST", "/v1/album", nil)
 		if err != nil {
@@ -127,7 +137,7 @@ func TestRegisterPublicRoutes_BothMethodsUseSameHandler(t *testing.T) {
 		mockProducer := &MockProducer{}
 		mockRepository := &MockRepository{}
 
-		RegisterPublicRoutes(router, mockProducer, mockRepository)
+		RegisterPublicRoutes(router, mockProducer, mockRepository, nil, nil)
 
 		// Test POST
 		reqPost, err := http.NewRequest("POST", "/album", nil)
@@ -168,7 +178,7 @@ func TestRegisterPublicRoutes_WithValidPayload(t *testing.T) {
 		mockProducer := &MockProducer{}
 		mockRepository := &MockRepository{}
 
-		RegisterPublicRoutes(router, mockProducer, mockRepository)
+		RegisterPublicRoutes(router, mockProducer, mockRepository, nil, nil)
 
 		payload := map[string]interface{}{
 			"id":     "1",
@@ -207,7 +217,7 @@ func TestRegisterPublicRoutes_OtherMethodsNotAllowed(t *testing.T) {
 		mockProducer := &MockProducer{}
 		mockRepository := &MockRepository{}
 
-		RegisterPublicRoutes(router, mockProducer, mockRepository)
+		RegisterPublicRoutes(router, mockProducer, mockRepository, nil, nil)
 
 		methods := []string{"GET", "DELETE", "PATCH"}
 		for _, method := range methods {
@@ -242,7 +252,7 @@ func TestRegisterPublicRoutes_ProducerInjection(t *testing.T) {
 			}
 		}()
 
-		RegisterPublicRoutes(router, mockProducer, mockRepository)
+		RegisterPublicRoutes(router, mockProducer, mockRepository, nil, nil)
 
 		// Make a request to verify handler was created successfully
 		req, err := http.NewRequest("POST", "/album", nil)
@@ -265,8 +275,8 @@ func TestRegisterPublicRoutes_MultipleRegistrations(t *testing.T) {
 		mockProducer := &MockProducer{}
 		mockRepository := &MockRepository{}
 
-		RegisterPublicRoutes(v1Router, mockProducer, mockRepository)
-		RegisterPublicRoutes(v2Router, mockProducer, mockRepository)
+		RegisterPublicRoutes(v1Router, mockProducer, mockRepository, nil, nil)
+		RegisterPublicRoutes(v2Router, mockProducer, mockRepository, nil, nil)
 
 		// Test v1
 		req1, err := http.NewRequest("POST", "/v1/album", nil)
@@ -299,3 +309,52 @@ func TestRegisterPublicRoutes_MultipleRegistrations(t *testing.T) {
 		}
 	})
 }
+
+func TestRegisterPublicRoutes_Authorization(t *testing.T) {
+	tests := []struct {
+		name           string
+		roles          []string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "viewer lists albums", roles: []string{authz.RoleViewer}, method: "GET", path: "/albums", expectedStatus: fiber.StatusOK},
+		{name: "viewer creates album", roles: []string{authz.RoleViewer}, method: "POST", path: "/album", expectedStatus: fiber.StatusForbidden},
+		{name: "viewer replaces albums", roles: []string{authz.RoleViewer}, method: "PUT", path: "/albums", expectedStatus: fiber.StatusForbidden},
+		{name: "editor creates album", roles: []string{authz.RoleEditor}, method: "POST", path: "/album", expectedStatus: fiber.StatusBadRequest},
+		{name: "admin creates albums", roles: []string{authz.RoleAdmin}, method: "POST", path: "/albums", expectedStatus: fiber.StatusBadRequest},
+		{name: "editor updates album without price", roles: []string{authz.RoleEditor}, method: "PUT", path: "/album", body: `{"id":1,"title":"Blue Train"}`, expectedStatus: fiber.StatusCreated},
+		{name: "editor sets album price", roles: []string{authz.RoleEditor}, method: "PUT", path: "/album", body: `{"id":1,"title":"Blue Train","price":9.99}`, expectedStatus: fiber.StatusForbidden},
+		{name: "editor sets price of one of the albums", roles: []string{authz.RoleEditor}, method: "POST", path: "/albums", body: `[{"id":1},{"id":2,"price":9.99}]`, expectedStatus: fiber.StatusForbidden},
+		{name: "editor creates albums without price", roles: []string{authz.RoleEditor}, method: "POST", path: "/albums", body: `[{"id":1},{"id":2}]`, expectedStatus: fiber.StatusCreated},
+		{name: "admin sets album price", roles: []string{authz.RoleAdmin}, method: "PUT", path: "/album", body: `{"id":1,"price":9.99}`, expectedStatus: fiber.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(func(ctx *fiber.Ctx) error {
+				ctx.SetUserContext(auth.NewContext(ctx.UserContext(), &auth.Identity{Subject: "jane", Roles: tt.roles}))
+				return ctx.Next()
+			})
+			RegisterPublicRoutes(app.Group(""), &MockProducer{}, &MockRepository{}, nil, authz.NewAuthorizer(authz.DefaultPolicy()))
+
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req, _ := http.NewRequest(tt.method, tt.path, body)
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package v1
+
+import (
+	"music-service/internal/authz"
+	v1 "music-service/internal/handler/rest/v1"
+	"music-service/internal/repository/postgres/orm"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterSearchRoutes(router fiber.Router, repository orm.SearchRepository, authorizer *authz.Authorizer) {
+	read := authz.Require(authorizer, authz.CatalogRead)
+	searchHandler := v1.NewSearchHandler(repository)
+	router.Get("/search", read, searchHandler.SearchAlbums)
+}
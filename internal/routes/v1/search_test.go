@@ -0,0 +1,122 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/authz"
+	"music-service/internal/models"
+	"music-service/pkg/auth"
+)
+
+// fakeSearchRepository finds one album whose title has markup, highlighted
+// the way music.search_albums does
+type fakeSearchRepository struct {
+	query string
+	limit int
+	err   error
+}
+
+func (f *fakeSearchRepository) Search(query string, limit int) ([]*models.SearchResult, error) {
+	f.query = query
+	f.limit = limit
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []*models.SearchResult{{
+		Album:           models.Album{Id: 1, Title: "<b>Blue</b> Train & Co", Artist: "John Coltrane"},
+		Rank:            0.6,
+		TitleHighlight:  "&lt;b&gt;<mark>Blue</mark>&lt;/b&gt; Train &amp; Co",
+		ArtistHighlight: "John Coltrane",
+	}}, nil
+}
+
+func TestRegisterSearchRoutes(t *testing.T) {
+	tests := []struct {
+		name           string
+		roles          []string
+		path           string
+		err            error
+		expectedStatus int
+		expectedQuery  string
+		expectedLimit  int
+	}{
+		{name: "viewer searches", roles: []string{authz.RoleViewer}, path: "/search?q=blue", expectedStatus: fiber.StatusOK, expectedQuery: "blue", expectedLimit: models.DefaultSearchLimit},
+		{name: "viewer searches with surrounding whitespace", roles: []string{authz.RoleViewer}, path: "/search?q=%20blue%20train%20", expectedStatus: fiber.StatusOK, expectedQuery: "blue train", expectedLimit: models.DefaultSearchLimit},
+		{name: "viewer searches with limit", roles: []string{authz.RoleViewer}, path: "/search?q=blue&limit=5", expectedStatus: fiber.StatusOK, expectedQuery: "blue", expectedLimit: 5},
+		{name: "viewer searches with too large limit", roles: []string{authz.RoleViewer}, path: "/search?q=blue&limit=1000", expectedStatus: fiber.StatusOK, expectedQuery: "blue", expectedLimit: models.MaxSearchLimit},
+		{name: "viewer searches with invalid limit", roles: []string{authz.RoleViewer}, path: "/search?q=blue&limit=0", expectedStatus: fiber.StatusBadRequest},
+		{name: "viewer searches without query", roles: []string{authz.RoleViewer}, path: "/search?q=%20", expectedStatus: fiber.StatusBadRequest},
+		{name: "viewer searches while the database fails", roles: []string{authz.RoleViewer}, path: "/search?q=blue", err: errors.New("connection refused"), expectedStatus: fiber.StatusInternalServerError, expectedQuery: "blue", expectedLimit: models.DefaultSearchLimit},
+		{name: "caller without roles searches", path: "/search?q=blue", expectedStatus: fiber.StatusOK, expectedQuery: "blue", expectedLimit: models.DefaultSearchLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(func(ctx *fiber.Ctx) error {
+				ctx.SetUserContext(auth.NewContext(ctx.UserContext(), &auth.Identity{Subject: "jane", Roles: tt.roles}))
+				return ctx.Next()
+			})
+			repository := &fakeSearchRepository{err: tt.err}
+			RegisterSearchRoutes(app.Group(""), repository, authz.NewAuthorizer(authz.DefaultPolicy()))
+
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if repository.query != tt.expectedQuery || repository.limit != tt.expectedLimit {
+				t.Errorf("Expected search for %q with limit %d, got %q with limit %d", tt.expectedQuery, tt.expectedLimit, repository.query, repository.limit)
+			}
+		})
+	}
+}
+
+// TestRegisterSearchRoutes_Highlights tests that the highlights reach clients
+// escaped for HTML, with only the <mark> tags as markup, and that the response
+// has no markup of album titles a browser could run
+func TestRegisterSearchRoutes_Highlights(t *testing.T) {
+	app := fiber.New()
+	RegisterSearchRoutes(app.Group(""), &fakeSearchRepository{}, nil)
+
+	req, _ := http.NewRequest("GET", "/search?q=blue", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("Expected status %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+	if contentType := resp.Header.Get("Content-Type"); !strings.HasPrefix(contentType, fiber.MIMEApplicationJSON) {
+		t.Errorf("Expected JSON, got %s", contentType)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	if strings.Contains(string(body), "<b>") || strings.Contains(string(body), "<mark>") {
+		t.Errorf("Expected no raw markup in the response, got %s", body)
+	}
+
+	var results []models.SearchResult
+	if err := json.Unmarshal(body, &results); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(results))
+	}
+	if want := "&lt;b&gt;<mark>Blue</mark>&lt;/b&gt; Train &amp; Co"; results[0].TitleHighlight != want {
+		t.Errorf("Expected title highlight %q, got %q", want, results[0].TitleHighlight)
+	}
+	if want := "<b>Blue</b> Train & Co"; results[0].Title != want {
+		t.Errorf("Expected the title %q unescaped, got %q", want, results[0].Title)
+	}
+}
@@ -0,0 +1,22 @@
+package v1
+
+import (
+	"music-service/internal/authz"
+	v1 "music-service/internal/handler/rest/v1"
+	"music-service/internal/repository/postgres/orm"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterTagRoutes(router fiber.Router, tags orm.TagRepository, albums orm.Repository, authorizer *authz.Authorizer) {
+	read := authz.Require(authorizer, authz.CatalogRead)
+	write := authz.Require(authorizer, authz.CatalogWrite)
+	remove := authz.Require(authorizer, authz.CatalogDelete)
+	tagsHandler := v1.NewTagsHandler(tags, albums)
+	router.Post("/tags", write, tagsHandler.CreateTag)
+	router.Get("/tags", read, tagsHandler.GetTags)
+	router.Put("/tags/:id", write, tagsHandler.UpdateTag)
+	router.Delete("/tags/:id", remove, tagsHandler.DeleteTag)
+	router.Get("/albums/:id/tags", read, tagsHandler.GetAlbumTags)
+	router.Put("/albums/:id/tags", write, tagsHandler.SetAlbumTags)
+}
@@ -0,0 +1,132 @@
+package v1
+
+import (
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/authz"
+	"music-service/internal/models"
+	"music-service/pkg/auth"
+)
+
+// fakeTagRepository is an in-memory orm.TagRepository holding the live tag
+type fakeTagRepository struct {
+	tags  map[int]*models.Tag
+	names []string
+}
+
+func newFakeTagRepository() *fakeTagRepository {
+	return &fakeTagRepository{tags: map[int]*models.Tag{1: {Id: 1, Name: "live"}}}
+}
+
+func (f *fakeTagRepository) Create(tag *models.Tag) error {
+	for _, t := range f.tags {
+		if strings.EqualFold(t.Name, tag.Name) {
+			return pgError("23505")
+		}
+	}
+	tag.Id = len(f.tags) + 1
+	f.tags[tag.Id] = tag
+	return nil
+}
+
+func (f *fakeTagRepository) Get() ([]*models.Tag, error) {
+	tags := []*models.Tag{}
+	for _, tag := range f.tags {
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+func (f *fakeTagRepository) Update(tag models.Tag) error {
+	if _, ok := f.tags[tag.Id]; !ok {
+		return pg.ErrNoRows
+	}
+	f.tags[tag.Id] = &tag
+	return nil
+}
+
+func (f *fakeTagRepository) Delete(id int) error {
+	if _, ok := f.tags[id]; !ok {
+		return pg.ErrNoRows
+	}
+	delete(f.tags, id)
+	return nil
+}
+
+func (f *fakeTagRepository) GetByAlbum(albumId int) ([]*models.Tag, error) {
+	return []*models.Tag{}, nil
+}
+
+func (f *fakeTagRepository) SetAlbumTags(albumId int, names []string) ([]*models.Tag, error) {
+	f.names = names
+	tags := make([]*models.Tag, len(names))
+	for i, name := range names {
+		tags[i] = &models.Tag{Id: i + 1, Name: name}
+	}
+	return tags, nil
+}
+
+func TestRegisterTagRoutes(t *testing.T) {
+	tests := []struct {
+		name           string
+		roles          []string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedNames  []string
+	}{
+		{name: "viewer lists tags", roles: []string{authz.RoleViewer}, method: "GET", path: "/tags", expectedStatus: fiber.StatusOK},
+		{name: "viewer creates tag", roles: []string{authz.RoleViewer}, method: "POST", path: "/tags", body: `{"name":"remastered"}`, expectedStatus: fiber.StatusForbidden},
+		{name: "editor creates tag", roles: []string{authz.RoleEditor}, method: "POST", path: "/tags", body: `{"name":"remastered"}`, expectedStatus: fiber.StatusCreated},
+		{name: "editor creates tag without name", roles: []string{authz.RoleEditor}, method: "POST", path: "/tags", body: `{"name":""}`, expectedStatus: fiber.StatusBadRequest},
+		{name: "editor creates tag differing in case", roles: []string{authz.RoleEditor}, method: "POST", path: "/tags", body: `{"name":"Live"}`, expectedStatus: fiber.StatusConflict},
+		{name: "editor renames tag", roles: []string{authz.RoleEditor}, method: "PUT", path: "/tags/1", body: `{"name":"live recording"}`, expectedStatus: fiber.StatusOK},
+		{name: "editor renames unknown tag", roles: []string{authz.RoleEditor}, method: "PUT", path: "/tags/9", body: `{"name":"live recording"}`, expectedStatus: fiber.StatusNotFound},
+		{name: "editor deletes tag", roles: []string{authz.RoleEditor}, method: "DELETE", path: "/tags/1", expectedStatus: fiber.StatusForbidden},
+		{name: "admin deletes tag", roles: []string{authz.RoleAdmin}, method: "DELETE", path: "/tags/1", expectedStatus: fiber.StatusNoContent},
+		{name: "admin deletes unknown tag", roles: []string{authz.RoleAdmin}, method: "DELETE", path: "/tags/9", expectedStatus: fiber.StatusNotFound},
+		{name: "viewer gets album tags", roles: []string{authz.RoleViewer}, method: "GET", path: "/albums/1/tags", expectedStatus: fiber.StatusOK},
+		{name: "viewer sets album tags", roles: []string{authz.RoleViewer}, method: "PUT", path: "/albums/1/tags", body: `["live"]`, expectedStatus: fiber.StatusForbidden},
+		{name: "editor sets album tags", roles: []string{authz.RoleEditor}, method: "PUT", path: "/albums/1/tags", body: `[" live ","Remastered","LIVE",""]`, expectedStatus: fiber.StatusOK, expectedNames: []string{"live", "Remastered"}},
+		{name: "editor sets album tags with invalid JSON", roles: []string{authz.RoleEditor}, method: "PUT", path: "/albums/1/tags", body: `"live"`, expectedStatus: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(func(ctx *fiber.Ctx) error {
+				ctx.SetUserContext(auth.NewContext(ctx.UserContext(), &auth.Identity{Subject: "jane", Roles: tt.roles}))
+				return ctx.Next()
+			})
+			tags := newFakeTagRepository()
+			RegisterTagRoutes(app.Group(""), tags, &MockRepository{}, authz.NewAuthorizer(authz.DefaultPolicy()))
+
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req, _ := http.NewRequest(tt.method, tt.path, body)
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if tt.expectedNames != nil && !reflect.DeepEqual(tags.names, tt.expectedNames) {
+				t.Errorf("Expected tags %v, got %v", tt.expectedNames, tags.names)
+			}
+		})
+	}
+}
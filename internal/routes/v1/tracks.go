@@ -0,0 +1,21 @@
+package v1
+
+import (
+	"music-service/internal/authz"
+	v1 "music-service/internal/handler/rest/v1"
+	"music-service/internal/repository/postgres/orm"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterTrackRoutes(router fiber.Router, albums orm.Repository, tracks orm.TrackRepository, authorizer *authz.Authorizer) {
+	read := authz.Require(authorizer, authz.CatalogRead)
+	write := authz.Require(authorizer, authz.CatalogWrite)
+	remove := authz.Require(authorizer, authz.CatalogDelete)
+	tracksHandler := v1.NewTracksHandler(albums, tracks)
+	router.Get("/albums/:id/tracks", read, tracksHandler.GetTracks)
+	router.Post("/albums/:id/tracks", write, tracksHandler.CreateTrack)
+	router.Get("/albums/:id/tracks/:trackId", read, tracksHandler.GetTrack)
+	router.Put("/albums/:id/tracks/:trackId", write, tracksHandler.UpdateTrack)
+	router.Delete("/albums/:id/tracks/:trackId", remove, tracksHandler.DeleteTrack)
+}
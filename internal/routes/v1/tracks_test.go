@@ -0,0 +1,128 @@
+package v1
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/authz"
+	"music-service/internal/models"
+	"music-service/pkg/auth"
+)
+
+// fakeTrackRepository is an in-memory orm.TrackRepository holding track 1 of
+// album 1
+type fakeTrackRepository struct {
+	tracks map[int]*models.Track
+}
+
+func newFakeTrackRepository() *fakeTrackRepository {
+	return &fakeTrackRepository{tracks: map[int]*models.Track{
+		1: {Id: 1, AlbumId: 1, Disc: 1, Number: 1, Title: "Blue Train"},
+	}}
+}
+
+func (f *fakeTrackRepository) Create(track *models.Track) error {
+	track.Id = len(f.tracks) + 1
+	f.tracks[track.Id] = track
+	return nil
+}
+
+func (f *fakeTrackRepository) GetById(albumId, id int) (*models.Track, error) {
+	track, ok := f.tracks[id]
+	if !ok || track.AlbumId != albumId {
+		return nil, pg.ErrNoRows
+	}
+	return track, nil
+}
+
+func (f *fakeTrackRepository) GetByAlbum(albumId int) ([]*models.Track, error) {
+	tracks := []*models.Track{}
+	for _, track := range f.tracks {
+		if track.AlbumId == albumId {
+			tracks = append(tracks, track)
+		}
+	}
+	return tracks, nil
+}
+
+func (f *fakeTrackRepository) Get() ([]*models.Track, error) {
+	return f.GetByAlbum(1)
+}
+
+func (f *fakeTrackRepository) Update(track models.Track) error {
+	if _, err := f.GetById(track.AlbumId, track.Id); err != nil {
+		return err
+	}
+	f.tracks[track.Id] = &track
+	return nil
+}
+
+func (f *fakeTrackRepository) Delete(albumId, id int) error {
+	if _, err := f.GetById(albumId, id); err != nil {
+		return err
+	}
+	delete(f.tracks, id)
+	return nil
+}
+
+func (f *fakeTrackRepository) Save(albumId int, tracks []models.Track, removed []models.Track) error {
+	return nil
+}
+
+func TestRegisterTrackRoutes(t *testing.T) {
+	tests := []struct {
+		name           string
+		roles          []string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "viewer lists tracks", roles: []string{authz.RoleViewer}, method: "GET", path: "/albums/1/tracks", expectedStatus: fiber.StatusOK},
+		{name: "viewer gets track", roles: []string{authz.RoleViewer}, method: "GET", path: "/albums/1/tracks/1", expectedStatus: fiber.StatusOK},
+		{name: "viewer gets track of other album", roles: []string{authz.RoleViewer}, method: "GET", path: "/albums/2/tracks/1", expectedStatus: fiber.StatusNotFound},
+		{name: "viewer gets track with invalid id", roles: []string{authz.RoleViewer}, method: "GET", path: "/albums/1/tracks/x", expectedStatus: fiber.StatusBadRequest},
+		{name: "viewer creates track", roles: []string{authz.RoleViewer}, method: "POST", path: "/albums/1/tracks", body: `{"number":2,"title":"Moment's Notice"}`, expectedStatus: fiber.StatusForbidden},
+		{name: "editor creates track", roles: []string{authz.RoleEditor}, method: "POST", path: "/albums/1/tracks", body: `{"number":2,"title":"Moment's Notice","isrc":"US-BN1-57-00002"}`, expectedStatus: fiber.StatusCreated},
+		{name: "editor creates track without title", roles: []string{authz.RoleEditor}, method: "POST", path: "/albums/1/tracks", body: `{"number":2}`, expectedStatus: fiber.StatusBadRequest},
+		{name: "editor creates track with invalid ISRC", roles: []string{authz.RoleEditor}, method: "POST", path: "/albums/1/tracks", body: `{"number":2,"title":"Moment's Notice","isrc":"BN157"}`, expectedStatus: fiber.StatusBadRequest},
+		{name: "editor creates track with invalid JSON", roles: []string{authz.RoleEditor}, method: "POST", path: "/albums/1/tracks", body: `{"number":`, expectedStatus: fiber.StatusBadRequest},
+		{name: "editor updates track", roles: []string{authz.RoleEditor}, method: "PUT", path: "/albums/1/tracks/1", body: `{"number":1,"title":"Blue Train","durationMs":643000}`, expectedStatus: fiber.StatusOK},
+		{name: "editor updates unknown track", roles: []string{authz.RoleEditor}, method: "PUT", path: "/albums/1/tracks/9", body: `{"number":1,"title":"Blue Train"}`, expectedStatus: fiber.StatusNotFound},
+		{name: "editor deletes track", roles: []string{authz.RoleEditor}, method: "DELETE", path: "/albums/1/tracks/1", expectedStatus: fiber.StatusForbidden},
+		{name: "admin deletes track", roles: []string{authz.RoleAdmin}, method: "DELETE", path: "/albums/1/tracks/1", expectedStatus: fiber.StatusNoContent},
+		{name: "admin deletes unknown track", roles: []string{authz.RoleAdmin}, method: "DELETE", path: "/albums/1/tracks/9", expectedStatus: fiber.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(func(ctx *fiber.Ctx) error {
+				ctx.SetUserContext(auth.NewContext(ctx.UserContext(), &auth.Identity{Subject: "jane", Roles: tt.roles}))
+				return ctx.Next()
+			})
+			RegisterTrackRoutes(app.Group(""), &MockRepository{}, newFakeTrackRepository(), authz.NewAuthorizer(authz.DefaultPolicy()))
+
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req, _ := http.NewRequest(tt.method, tt.path, body)
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package v1
+
+import (
+	"music-service/internal/authz"
+	v1 "music-service/internal/handler/rest/v1"
+	"music-service/internal/repository/postgres/orm"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterUserRoutes(router fiber.Router, users orm.UserRepository, reviews orm.ReviewRepository, wishlists orm.WishlistRepository, albums orm.Repository, authorizer *authz.Authorizer) {
+	read := authz.Require(authorizer, authz.CatalogRead)
+	admin := authz.Require(authorizer, authz.Admin)
+	usersHandler := v1.NewUsersHandler(users, reviews, wishlists, albums)
+	router.Post("/users", read, usersHandler.CreateUser)
+	router.Get("/users/:id", read, usersHandler.GetUser)
+	router.Get("/users/:id/reviews", read, usersHandler.GetUserReviews)
+	router.Get("/users/:id/wishlist", read, usersHandler.GetWishlist)
+	router.Put("/users/:id/wishlist/:albumId", read, usersHandler.AddToWishlist)
+	router.Delete("/users/:id/wishlist/:albumId", read, usersHandler.RemoveFromWishlist)
+	router.Get("/users/:id/notifications", read, usersHandler.GetNotifications)
+	router.Post("/users/:id/notifications/:notificationId/read", read, usersHandler.MarkNotificationRead)
+	router.Get("/albums/:id/reviews", read, usersHandler.GetAlbumReviews)
+	router.Put("/albums/:id/reviews/:userId", read, usersHandler.SetReview)
+	router.Delete("/albums/:id/reviews/:userId", read, usersHandler.DeleteReview)
+	router.Put("/albums/:id/reviews/:userId/status", admin, usersHandler.ModerateReview)
+}
@@ -0,0 +1,44 @@
+package v1
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"music-service/internal/authz"
+	"music-service/pkg/auth"
+)
+
+func TestRegisterUserRoutes_Authorization(t *testing.T) {
+	tests := []struct {
+		name           string
+		roles          []string
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{name: "editor moderates review", roles: []string{authz.RoleEditor}, method: "PUT", path: "/albums/1/reviews/1/status", expectedStatus: fiber.StatusForbidden},
+		{name: "admin moderates review", roles: []string{authz.RoleAdmin}, method: "PUT", path: "/albums/x/reviews/1/status", expectedStatus: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(func(ctx *fiber.Ctx) error {
+				ctx.SetUserContext(auth.NewContext(ctx.UserContext(), &auth.Identity{Subject: "jane", Roles: tt.roles}))
+				return ctx.Next()
+			})
+			RegisterUserRoutes(app.Group(""), nil, nil, nil, nil, authz.NewAuthorizer(authz.DefaultPolicy()))
+
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultPublicRoutes are the REST routes served without a token when the
+// config lists none.
+var DefaultPublicRoutes = []string{"/api/v1/health", "/swagger"}
+
+// DefaultPublicMethods are the gRPC methods served without a token when the
+// config lists none: health checks and reflection.
+var DefaultPublicMethods = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.v1.ServerReflection/",
+	"/grpc.reflection.v1alpha.ServerReflection/",
+}
+
+// Config enables JWT bearer authentication of the REST and gRPC servers.
+// Tokens signed with HS256 are verified with the secret, which can be read
+// from SecretFile, e.g. a mounted Kubernetes secret, which wins when both are
+// set. Tokens signed with RS256 are verified with the keys of a JWKS, loaded
+// once from JWKSFile or fetched from JWKSURL and refetched every JWKSRefresh
+// seconds, an hour by default.
+//
+// Tokens must not be expired and must have a subject, and the issuer and
+// audience when they are configured. Leeway is the clock skew, in seconds,
+// allowed when checking the times of a token. PublicRoutes are path prefixes
+// and PublicMethods full gRPC method names, or service prefixes ending with a
+// slash, that are served without a token.
+//
+// The roles of a caller are read from the RolesClaim of its token, "roles"
+// by default, which holds a role or a list of them. PolicyFile is the policy
+// granting permissions to the roles, see internal/authz.
+//
+// APIKeys also accepts the API keys kept in Postgres, for service-to-service
+// clients that cannot get tokens, see internal/apikeys.
+type Config struct {
+	Enabled       bool     `yaml:"enabled"`
+	Secret        string   `yaml:"secret"`
+	SecretFile    string   `yaml:"secret_file"`
+	JWKSFile      string   `yaml:"jwks_file"`
+	JWKSURL       string   `yaml:"jwks_url"`
+	JWKSRefresh   int      `yaml:"jwks_refresh"`
+	Issuer        string   `yaml:"issuer"`
+	Audience      string   `yaml:"audience"`
+	Leeway        int      `yaml:"leeway"`
+	PublicRoutes  []string `yaml:"public_routes"`
+	PublicMethods []string `yaml:"public_methods"`
+	RolesClaim    string   `yaml:"roles_claim"`
+	PolicyFile    string   `yaml:"policy_file"`
+	APIKeys       bool     `yaml:"api_keys"`
+}
+
+func (c Config) Validate() error {
+	switch {
+	case !c.Enabled:
+		return nil
+	case c.Secret == "" && c.SecretFile == "" && c.JWKSFile == "" && c.JWKSURL == "" && !c.APIKeys:
+		return errors.New("auth requires a secret, a JWKS file, a JWKS URL or API keys")
+	case c.JWKSFile != "" && c.JWKSURL != "":
+		return errors.New("auth takes either a JWKS file or a JWKS URL")
+	case c.JWKSRefresh < 0 || c.Leeway < 0:
+		return errors.New("auth jwks_refresh and leeway must not be negative")
+	}
+	return nil
+}
+
+// LoadSecret returns the HS256 secret, nil when none is configured.
+func (c Config) LoadSecret() ([]byte, error) {
+	if c.SecretFile == "" {
+		if c.Secret == "" {
+			return nil, nil
+		}
+		return []byte(c.Secret), nil
+	}
+	data, err := os.ReadFile(c.SecretFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read auth secret file: %w", err)
+	}
+	secret := strings.TrimSpace(string(data))
+	if secret == "" {
+		return nil, fmt.Errorf("auth secret file %s is empty", c.SecretFile)
+	}
+	return []byte(secret), nil
+}
+
+// Routes returns the public REST routes, DefaultPublicRoutes when none are
+// configured.
+func (c Config) Routes() []string {
+	if c.PublicRoutes == nil {
+		return DefaultPublicRoutes
+	}
+	return c.PublicRoutes
+}
+
+// Methods returns the public gRPC methods, DefaultPublicMethods when none are
+// configured.
+func (c Config) Methods() []string {
+	if c.PublicMethods == nil {
+		return DefaultPublicMethods
+	}
+	return c.PublicMethods
+}
+
+// Claim returns the claim holding the roles of a caller.
+func (c Config) Claim() string {
+	if c.RolesClaim == "" {
+		return "roles"
+	}
+	return c.RolesClaim
+}
+
+func (c Config) refresh() time.Duration {
+	if c.JWKSRefresh == 0 {
+		return time.Hour
+	}
+	return time.Duration(c.JWKSRefresh) * time.Second
+}
+
+// IsPublicRoute reports whether the path is one of the routes or below one.
+func IsPublicRoute(path string, routes []string) bool {
+	for _, route := range routes {
+		route = strings.TrimSuffix(route, "/")
+		if path == route || strings.HasPrefix(path, route+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPublicMethod reports whether the full gRPC method, such as
+// /service.MusicService/GetAlbumList, is one of the methods or belongs to one
+// of the services ending with a slash.
+func IsPublicMethod(fullMethod string, methods []string) bool {
+	for _, method := range methods {
+		if fullMethod == method || (strings.HasSuffix(method, "/") && strings.HasPrefix(fullMethod, method)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{name: "disabled", config: Config{}},
+		{name: "secret", config: Config{Enabled: true, Secret: "s3cret"}},
+		{name: "jwks url", config: Config{Enabled: true, JWKSURL: "https://issuer/jwks.json"}},
+		{name: "api keys", config: Config{Enabled: true, APIKeys: true}},
+		{name: "secret and jwks", config: Config{Enabled: true, SecretFile: "/secret", JWKSFile: "/jwks.json"}},
+		{name: "no keys", config: Config{Enabled: true}, wantErr: true},
+		{name: "jwks file and url", config: Config{Enabled: true, JWKSFile: "/jwks.json", JWKSURL: "https://issuer/jwks.json"}, wantErr: true},
+		{name: "negative leeway", config: Config{Enabled: true, Secret: "s3cret", Leeway: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_LoadSecret(t *testing.T) {
+	secret, err := Config{}.LoadSecret()
+	require.NoError(t, err)
+	assert.Nil(t, secret)
+
+	file := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(file, []byte("from-file\n"), 0600))
+	secret, err = Config{Secret: "inline", SecretFile: file}.LoadSecret()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("from-file"), secret)
+
+	_, err = Config{SecretFile: filepath.Join(t.TempDir(), "missing")}.LoadSecret()
+	assert.Error(t, err)
+}
+
+func TestConfig_Unmarshal(t *testing.T) {
+	var cfg Config
+	err := yaml.Unmarshal([]byte(`
+enabled: true
+jwks_url: https://issuer/.well-known/jwks.json
+issuer: https://issuer/
+audience: music-service
+public_routes: [/api/v1/health]
+`), &cfg)
+	require.NoError(t, err)
+	assert.True(t, cfg.Enabled)
+	assert.Equal(t, "https://issuer/.well-known/jwks.json", cfg.JWKSURL)
+	assert.Equal(t, []string{"/api/v1/health"}, cfg.Routes())
+	assert.Equal(t, DefaultPublicMethods, cfg.Methods())
+}
+
+func TestIsPublicRoute(t *testing.T) {
+	routes := []string{"/api/v1/health", "/swagger/"}
+	assert.True(t, IsPublicRoute("/api/v1/health", routes))
+	assert.True(t, IsPublicRoute("/swagger/index.html", routes))
+	assert.True(t, IsPublicRoute("/swagger", routes))
+	assert.False(t, IsPublicRoute("/api/v1/healthz", routes))
+	assert.False(t, IsPublicRoute("/api/v1/albums", routes))
+}
+
+func TestIsPublicMethod(t *testing.T) {
+	methods := []string{"/grpc.health.v1.Health/", "/service.MusicService/GetAlbumList"}
+	assert.True(t, IsPublicMethod("/grpc.health.v1.Health/Check", methods))
+	assert.True(t, IsPublicMethod("/service.MusicService/GetAlbumList", methods))
+	assert.False(t, IsPublicMethod("/service.MusicService/CreateAlbum", methods))
+}
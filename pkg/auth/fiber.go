@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// NewMiddleware returns Fiber middleware that authenticates requests with an
+// API key in the X-API-Key header, if keys are accepted, or else a bearer
+// token in the Authorization header, and places the identity of the caller in
+// the user context of the request, see FromContext. Requests to the public
+// routes are served without credentials, others without valid ones are
+// answered with 401.
+func NewMiddleware(verifier *Verifier, keys KeyAuthenticator, publicRoutes []string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if IsPublicRoute(ctx.Path(), publicRoutes) {
+			return ctx.Next()
+		}
+
+		identity, err := authenticate(ctx.UserContext(), verifier, keys, ctx.Get(fiber.HeaderAuthorization), ctx.Get(APIKeyHeader))
+		if err != nil {
+			message, ok := unauthenticated(err)
+			if !ok {
+				log.Printf("failed to authenticate %s %s: %v", ctx.Method(), ctx.Path(), err)
+				return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error": "failed to authenticate",
+				})
+			}
+			ctx.Set(fiber.HeaderWWWAuthenticate, "Bearer")
+			return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": message,
+			})
+		}
+
+		ctx.SetUserContext(NewContext(ctx.UserContext(), identity))
+		return ctx.Next()
+	}
+}
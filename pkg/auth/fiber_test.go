@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeKeys struct{}
+
+func (fakeKeys) Authenticate(ctx context.Context, key string) (*Identity, error) {
+	switch key {
+	case "msk_valid":
+		return &Identity{Subject: "apikey:valid", Roles: []string{"editor"}}, nil
+	case "msk_broken":
+		return nil, errors.New("connection refused")
+	}
+	return nil, fmt.Errorf("%w: revoked", ErrInvalidKey)
+}
+
+func TestNewMiddleware(t *testing.T) {
+	app := fiber.New()
+	app.Use(NewMiddleware(newVerifier(testSecret, nil, Config{}), fakeKeys{}, DefaultPublicRoutes))
+	app.Get("/api/v1/health", func(ctx *fiber.Ctx) error {
+		return ctx.SendString("ok")
+	})
+	app.Get("/api/v1/albums", func(ctx *fiber.Ctx) error {
+		identity, ok := FromContext(ctx.UserContext())
+		if !ok {
+			return ctx.SendStatus(fiber.StatusInternalServerError)
+		}
+		return ctx.SendString(identity.Subject)
+	})
+
+	tests := []struct {
+		name          string
+		path          string
+		authorization string
+		key           string
+		wantStatus    int
+		wantBody      string
+	}{
+		{name: "public route", path: "/api/v1/health", wantStatus: fiber.StatusOK, wantBody: "ok"},
+		{name: "valid token", path: "/api/v1/albums", authorization: "Bearer " + signHS256(t, testSecret, validClaims()), wantStatus: fiber.StatusOK, wantBody: "user-1"},
+		{name: "missing token", path: "/api/v1/albums", wantStatus: fiber.StatusUnauthorized, wantBody: `{"error":"missing bearer token"}`},
+		{name: "invalid token", path: "/api/v1/albums", authorization: "Bearer not-a-token", wantStatus: fiber.StatusUnauthorized, wantBody: `{"error":"invalid token"}`},
+		{name: "valid key", path: "/api/v1/albums", key: "msk_valid", wantStatus: fiber.StatusOK, wantBody: "apikey:valid"},
+		{name: "key wins over token", path: "/api/v1/albums", authorization: "Bearer " + signHS256(t, testSecret, validClaims()), key: "msk_valid", wantStatus: fiber.StatusOK, wantBody: "apikey:valid"},
+		{name: "invalid key", path: "/api/v1/albums", key: "msk_revoked", wantStatus: fiber.StatusUnauthorized, wantBody: `{"error":"invalid API key"}`},
+		{name: "key lookup fails", path: "/api/v1/albums", key: "msk_broken", wantStatus: fiber.StatusInternalServerError, wantBody: `{"error":"failed to authenticate"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tt.authorization)
+			}
+			if tt.key != "" {
+				req.Header.Set(APIKeyHeader, tt.key)
+			}
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			body, _ := io.ReadAll(resp.Body)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantBody, string(body))
+			if tt.wantStatus == fiber.StatusUnauthorized {
+				assert.Equal(t, "Bearer", resp.Header.Get(fiber.HeaderWWWAuthenticate))
+			}
+		})
+	}
+}
+
+func TestNewMiddleware_WithoutKeys(t *testing.T) {
+	app := fiber.New()
+	app.Use(NewMiddleware(newVerifier(testSecret, nil, Config{}), nil, nil))
+	app.Get("/", func(ctx *fiber.Ctx) error {
+		return ctx.SendString("ok")
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(APIKeyHeader, "msk_valid")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
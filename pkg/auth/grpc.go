@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor authenticates unary calls with an API key in the
+// x-api-key metadata, if keys are accepted, or else a bearer token in the
+// authorization metadata, and places the identity of the caller in the
+// context of the call, see FromContext. Calls to the public methods are served
+// without credentials, others without valid ones fail with Unauthenticated.
+func UnaryServerInterceptor(verifier *Verifier, keys KeyAuthenticator, publicMethods []string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticateCall(ctx, verifier, keys, info.FullMethod, publicMethods)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming calls.
+func StreamServerInterceptor(verifier *Verifier, keys KeyAuthenticator, publicMethods []string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticateCall(stream.Context(), verifier, keys, info.FullMethod, publicMethods)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authenticatedStream is a server stream whose context carries the identity
+// of the caller.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func authenticateCall(ctx context.Context, verifier *Verifier, keys KeyAuthenticator, fullMethod string, publicMethods []string) (context.Context, error) {
+	if IsPublicMethod(fullMethod, publicMethods) {
+		return ctx, nil
+	}
+
+	var authorization, key string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			authorization = values[0]
+		}
+		if values := md.Get(strings.ToLower(APIKeyHeader)); len(values) > 0 {
+			key = values[0]
+		}
+	}
+	identity, err := authenticate(ctx, verifier, keys, authorization, key)
+	if err != nil {
+		message, ok := unauthenticated(err)
+		if !ok {
+			log.Printf("failed to authenticate %s: %v", fullMethod, err)
+			return nil, status.Error(codes.Internal, "failed to authenticate")
+		}
+		return nil, status.Error(codes.Unauthenticated, message)
+	}
+	return NewContext(ctx, identity), nil
+}
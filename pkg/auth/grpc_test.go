@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor(newVerifier(testSecret, nil, Config{}), fakeKeys{}, DefaultPublicMethods)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		identity, ok := FromContext(ctx)
+		if !ok {
+			return "anonymous", nil
+		}
+		return identity.Subject, nil
+	}
+	withToken := func(authorization string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", authorization))
+	}
+	withKey := func(key string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", key))
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		method   string
+		want     interface{}
+		wantCode codes.Code
+	}{
+		{name: "public method", ctx: context.Background(), method: "/grpc.health.v1.Health/Check", want: "anonymous"},
+		{name: "valid token", ctx: withToken("Bearer " + signHS256(t, testSecret, validClaims())), method: "/service.MusicService/GetAlbumList", want: "user-1"},
+		{name: "missing token", ctx: context.Background(), method: "/service.MusicService/GetAlbumList", wantCode: codes.Unauthenticated},
+		{name: "invalid token", ctx: withToken("Bearer not-a-token"), method: "/service.MusicService/GetAlbumList", wantCode: codes.Unauthenticated},
+		{name: "valid key", ctx: withKey("msk_valid"), method: "/service.MusicService/GetAlbumList", want: "apikey:valid"},
+		{name: "invalid key", ctx: withKey("msk_revoked"), method: "/service.MusicService/GetAlbumList", wantCode: codes.Unauthenticated},
+		{name: "key lookup fails", ctx: withKey("msk_broken"), method: "/service.MusicService/GetAlbumList", wantCode: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := interceptor(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantCode, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	interceptor := StreamServerInterceptor(newVerifier(testSecret, nil, Config{}), fakeKeys{}, DefaultPublicMethods)
+	info := &grpc.StreamServerInfo{FullMethod: "/service.MusicService/StreamAlbums"}
+
+	var subject string
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		identity, _ := FromContext(stream.Context())
+		subject = identity.Subject
+		return nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+signHS256(t, testSecret, validClaims())))
+	require.NoError(t, interceptor(nil, &fakeServerStream{ctx: ctx}, info, handler))
+	assert.Equal(t, "user-1", subject)
+
+	err := interceptor(nil, &fakeServerStream{ctx: context.Background()}, info, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"context"
+	"strings"
+)
+
+// Identity is the authenticated caller of a request, the subject of its
+// token, with the roles granted to it. Claims holds every claim of the token.
+type Identity struct {
+	Subject string
+	Issuer  string
+	Roles   []string
+	Claims  map[string]interface{}
+}
+
+type identityKey struct{}
+
+// NewContext returns a copy of the context carrying the identity.
+func NewContext(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// FromContext returns the identity of the caller, if the request was
+// authenticated.
+func FromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok
+}
+
+// claimRoles returns the roles held by the claim, either a space separated
+// string or a list of strings.
+func claimRoles(claim interface{}) []string {
+	switch v := claim.(type) {
+	case string:
+		return strings.Fields(v)
+	case []interface{}:
+		roles := make([]string, 0, len(v))
+		for _, role := range v {
+			if role, ok := role.(string); ok && role != "" {
+				roles = append(roles, role)
+			}
+		}
+		return roles
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+// APIKeyHeader is the header carrying the API key of a REST request, and in
+// lower case the metadata entry carrying the key of a gRPC call.
+const APIKeyHeader = "X-API-Key"
+
+// ErrInvalidKey is returned, wrapped with the reason, when an API key is
+// unknown, revoked or expired, or API keys are not accepted.
+var ErrInvalidKey = errors.New("invalid API key")
+
+// KeyAuthenticator authenticates the API keys of service-to-service clients,
+// which act with the roles of their key.
+type KeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (*Identity, error)
+}
+
+// authenticate returns the identity of the caller presenting the API key,
+// when there is one, or else the bearer token of the authorization.
+func authenticate(ctx context.Context, verifier *Verifier, keys KeyAuthenticator, authorization, key string) (*Identity, error) {
+	if key != "" {
+		if keys == nil {
+			return nil, ErrInvalidKey
+		}
+		return keys.Authenticate(ctx, key)
+	}
+	token, err := BearerToken(authorization)
+	if err != nil {
+		return nil, err
+	}
+	if verifier == nil {
+		return nil, ErrInvalidToken
+	}
+	return verifier.Verify(ctx, token)
+}
+
+// unauthenticated returns the message telling the caller why it was not
+// authenticated, and whether it was not its fault.
+func unauthenticated(err error) (string, bool) {
+	switch {
+	case errors.Is(err, ErrMissingToken):
+		return ErrMissingToken.Error(), true
+	case errors.Is(err, ErrInvalidToken):
+		return ErrInvalidToken.Error(), true
+	case errors.Is(err, ErrInvalidKey):
+		return ErrInvalidKey.Error(), true
+	}
+	return "", false
+}
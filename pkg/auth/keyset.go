@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+)
+
+// minRefetch is how long a key set fetched from a URL waits before fetching
+// again for a token signed with an unknown key, so such tokens cannot make it
+// hammer the URL.
+const minRefetch = 30 * time.Second
+
+// KeySet holds the RSA signing keys of a JSON Web Key Set. A key set fetched
+// from a URL is refetched once it is older than its refresh interval, and
+// when a token is signed with a key it does not hold, which picks up rotated
+// keys. The keys fetched last are kept when a fetch fails.
+type KeySet struct {
+	url     string
+	refresh time.Duration
+	client  *http.Client
+	now     func() time.Time
+
+	mu        sync.Mutex
+	keys      jose.JSONWebKeySet
+	fetchedAt time.Time
+}
+
+// LoadKeySet reads the key set from the file.
+func LoadKeySet(file string) (*KeySet, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+	s := &KeySet{now: time.Now}
+	if err := json.Unmarshal(data, &s.keys); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS file %s: %w", file, err)
+	}
+	return s, nil
+}
+
+// FetchKeySet fetches the key set from the URL.
+func FetchKeySet(ctx context.Context, url string, refresh time.Duration) (*KeySet, error) {
+	s := &KeySet{
+		url:     url,
+		refresh: refresh,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		now:     time.Now,
+	}
+	if err := s.fetch(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *KeySet) fetch(ctx context.Context) error {
+	s.fetchedAt = s.now()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch JWKS: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+
+	var keys jose.JSONWebKeySet
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return fmt.Errorf("failed to parse JWKS from %s: %w", s.url, err)
+	}
+	s.keys = keys
+	return nil
+}
+
+// Key returns the RSA public key with the key id. A token without a key id
+// is verified with the only key of a key set holding one.
+func (s *KeySet) Key(ctx context.Context, keyId string) (*rsa.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.url != "" && s.now().Sub(s.fetchedAt) >= s.refresh {
+		if err := s.fetch(ctx); err != nil {
+			log.Printf("failed to refresh JWKS, keeping the previous keys: %v", err)
+		}
+	}
+	key := s.find(keyId)
+	if key == nil && s.url != "" && s.now().Sub(s.fetchedAt) >= minRefetch {
+		if err := s.fetch(ctx); err != nil {
+			log.Printf("failed to refetch JWKS for key %q: %v", keyId, err)
+		}
+		key = s.find(keyId)
+	}
+	if key == nil {
+		return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidToken, keyId)
+	}
+	return key, nil
+}
+
+func (s *KeySet) find(keyId string) *rsa.PublicKey {
+	var keys []*rsa.PublicKey
+	for _, jwk := range s.keys.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		if keyId != "" && jwk.KeyID != keyId {
+			continue
+		}
+		if key, ok := jwk.Public().Key.(*rsa.PublicKey); ok {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 || (keyId == "" && len(keys) > 1) {
+		return nil
+	}
+	return keys[0]
+}
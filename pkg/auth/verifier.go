@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+)
+
+var (
+	// ErrMissingToken is returned when a request carries no bearer token.
+	ErrMissingToken = errors.New("missing bearer token")
+	// ErrInvalidToken is returned, wrapped with the reason, when a token is
+	// malformed, badly signed, expired or not meant for this service.
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// Verifier verifies JWT bearer tokens signed with HS256, RS256 or both,
+// depending on the keys it is configured with.
+type Verifier struct {
+	secret     []byte
+	keys       *KeySet
+	algorithms []jose.SignatureAlgorithm
+	expected   jwt.Expected
+	leeway     time.Duration
+	rolesClaim string
+	now        func() time.Time
+}
+
+// NewVerifier returns a verifier for the config, fetching the JWKS when it
+// is configured with a URL.
+func NewVerifier(ctx context.Context, cfg Config) (*Verifier, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	secret, err := cfg.LoadSecret()
+	if err != nil {
+		return nil, err
+	}
+	var keys *KeySet
+	switch {
+	case cfg.JWKSFile != "":
+		keys, err = LoadKeySet(cfg.JWKSFile)
+	case cfg.JWKSURL != "":
+		keys, err = FetchKeySet(ctx, cfg.JWKSURL, cfg.refresh())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newVerifier(secret, keys, cfg), nil
+}
+
+func newVerifier(secret []byte, keys *KeySet, cfg Config) *Verifier {
+	v := &Verifier{
+		secret: secret,
+		keys:   keys,
+		expected: jwt.Expected{
+			Issuer: cfg.Issuer,
+		},
+		leeway:     time.Duration(cfg.Leeway) * time.Second,
+		rolesClaim: cfg.Claim(),
+		now:        time.Now,
+	}
+	if cfg.Audience != "" {
+		v.expected.AnyAudience = jwt.Audience{cfg.Audience}
+	}
+	if secret != nil {
+		v.algorithms = append(v.algorithms, jose.HS256)
+	}
+	if keys != nil {
+		v.algorithms = append(v.algorithms, jose.RS256)
+	}
+	return v
+}
+
+// Verify returns the identity of the subject of the token.
+func (v *Verifier) Verify(ctx context.Context, token string) (*Identity, error) {
+	parsed, err := jwt.ParseSigned(token, v.algorithms)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	var key interface{}
+	header := parsed.Headers[0]
+	switch jose.SignatureAlgorithm(header.Algorithm) {
+	case jose.HS256:
+		key = v.secret
+	case jose.RS256:
+		if key, err = v.keys.Key(ctx, header.KeyID); err != nil {
+			return nil, err
+		}
+	}
+
+	var claims jwt.Claims
+	var all map[string]interface{}
+	if err := parsed.Claims(key, &claims, &all); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	switch {
+	case claims.Expiry == nil:
+		return nil, fmt.Errorf("%w: no expiry", ErrInvalidToken)
+	case claims.Subject == "":
+		return nil, fmt.Errorf("%w: no subject", ErrInvalidToken)
+	}
+	if err := claims.ValidateWithLeeway(v.expected.WithTime(v.now()), v.leeway); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	return &Identity{
+		Subject: claims.Subject,
+		Issuer:  claims.Issuer,
+		Roles:   claimRoles(all[v.rolesClaim]),
+		Claims:  all,
+	}, nil
+}
+
+// BearerToken returns the token of an Authorization header value.
+func BearerToken(authorization string) (string, error) {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(authorization), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", ErrMissingToken
+	}
+	return strings.TrimSpace(token), nil
+}
@@ -0,0 +1,261 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func signHS256(t *testing.T, secret []byte, claims jwt.Claims) string {
+	t.Helper()
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: secret}, nil)
+	require.NoError(t, err)
+	token, err := jwt.Signed(signer).Claims(claims).Claims(map[string]interface{}{"name": "Jane"}).Serialize()
+	require.NoError(t, err)
+	return token
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, keyId string, claims jwt.Claims) string {
+	t.Helper()
+	opts := (&jose.SignerOptions{}).WithType("JWT")
+	if keyId != "" {
+		opts = opts.WithHeader(jose.HeaderKey("kid"), keyId)
+	}
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, opts)
+	require.NoError(t, err)
+	token, err := jwt.Signed(signer).Claims(claims).Serialize()
+	require.NoError(t, err)
+	return token
+}
+
+func validClaims() jwt.Claims {
+	return jwt.Claims{
+		Subject: "user-1",
+		Issuer:  "https://issuer/",
+		Expiry:  jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+}
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+func keySetJSON(t *testing.T, keys map[string]*rsa.PrivateKey) []byte {
+	t.Helper()
+	var set jose.JSONWebKeySet
+	for kid, key := range keys {
+		set.Keys = append(set.Keys, jose.JSONWebKey{Key: &key.PublicKey, KeyID: kid, Algorithm: string(jose.RS256), Use: "sig"})
+	}
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+	return data
+}
+
+func TestVerifier_HS256(t *testing.T) {
+	verifier := newVerifier(testSecret, nil, Config{Issuer: "https://issuer/"})
+
+	identity, err := verifier.Verify(context.Background(), signHS256(t, testSecret, validClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", identity.Subject)
+	assert.Equal(t, "https://issuer/", identity.Issuer)
+	assert.Equal(t, "Jane", identity.Claims["name"])
+
+	expired := validClaims()
+	expired.Expiry = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+	noExpiry := validClaims()
+	noExpiry.Expiry = nil
+	noSubject := validClaims()
+	noSubject.Subject = ""
+	otherIssuer := validClaims()
+	otherIssuer.Issuer = "https://other/"
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "malformed", token: "not-a-token"},
+		{name: "wrong secret", token: signHS256(t, []byte("another-secret-another-secret-00"), validClaims())},
+		{name: "expired", token: signHS256(t, testSecret, expired)},
+		{name: "no expiry", token: signHS256(t, testSecret, noExpiry)},
+		{name: "no subject", token: signHS256(t, testSecret, noSubject)},
+		{name: "other issuer", token: signHS256(t, testSecret, otherIssuer)},
+		{name: "rs256 not configured", token: signRS256(t, newRSAKey(t), "", validClaims())},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verifier.Verify(context.Background(), tt.token)
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+}
+
+func TestVerifier_Leeway(t *testing.T) {
+	claims := validClaims()
+	claims.Expiry = jwt.NewNumericDate(time.Now().Add(-30 * time.Second))
+	token := signHS256(t, testSecret, claims)
+
+	_, err := newVerifier(testSecret, nil, Config{}).Verify(context.Background(), token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	_, err = newVerifier(testSecret, nil, Config{Leeway: 60}).Verify(context.Background(), token)
+	assert.NoError(t, err)
+}
+
+func TestVerifier_Audience(t *testing.T) {
+	verifier := newVerifier(testSecret, nil, Config{Audience: "music-service"})
+
+	claims := validClaims()
+	claims.Audience = jwt.Audience{"music-service", "other"}
+	_, err := verifier.Verify(context.Background(), signHS256(t, testSecret, claims))
+	assert.NoError(t, err)
+
+	claims.Audience = jwt.Audience{"other"}
+	_, err = verifier.Verify(context.Background(), signHS256(t, testSecret, claims))
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestVerifier_RS256File(t *testing.T) {
+	key, other := newRSAKey(t), newRSAKey(t)
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(file, keySetJSON(t, map[string]*rsa.PrivateKey{"key-1": key}), 0600))
+
+	verifier, err := NewVerifier(context.Background(), Config{Enabled: true, JWKSFile: file})
+	require.NoError(t, err)
+
+	identity, err := verifier.Verify(context.Background(), signRS256(t, key, "key-1", validClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", identity.Subject)
+
+	// The only key of the set verifies tokens without a key id.
+	_, err = verifier.Verify(context.Background(), signRS256(t, key, "", validClaims()))
+	assert.NoError(t, err)
+
+	_, err = verifier.Verify(context.Background(), signRS256(t, key, "key-2", validClaims()))
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	_, err = verifier.Verify(context.Background(), signRS256(t, other, "key-1", validClaims()))
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	_, err = verifier.Verify(context.Background(), signHS256(t, testSecret, validClaims()))
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestVerifier_RS256URL(t *testing.T) {
+	first, second := newRSAKey(t), newRSAKey(t)
+
+	var mu sync.Mutex
+	keys := map[string]*rsa.PrivateKey{"key-1": first}
+	fetches, failing := 0, false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		fetches++
+		if failing {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write(keySetJSON(t, keys))
+	}))
+	defer server.Close()
+
+	verifier, err := NewVerifier(context.Background(), Config{Enabled: true, JWKSURL: server.URL})
+	require.NoError(t, err)
+	now := time.Now()
+	verifier.keys.now = func() time.Time { return now }
+
+	_, err = verifier.Verify(context.Background(), signRS256(t, first, "key-1", validClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, 1, fetches)
+
+	// The issuer rotates to a new key.
+	mu.Lock()
+	keys = map[string]*rsa.PrivateKey{"key-1": first, "key-2": second}
+	mu.Unlock()
+	token := signRS256(t, second, "key-2", validClaims())
+
+	// Unknown keys are not refetched right after a fetch.
+	_, err = verifier.Verify(context.Background(), token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	assert.Equal(t, 1, fetches)
+
+	now = now.Add(minRefetch)
+	_, err = verifier.Verify(context.Background(), token)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fetches)
+
+	// Stale key sets are refreshed, keeping the previous keys on failure.
+	mu.Lock()
+	failing = true
+	mu.Unlock()
+	now = now.Add(time.Hour)
+	_, err = verifier.Verify(context.Background(), token)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, fetches)
+}
+
+func TestNewVerifier_FetchFails(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	_, err := NewVerifier(context.Background(), Config{Enabled: true, JWKSURL: server.URL})
+	assert.Error(t, err)
+}
+
+func TestBearerToken(t *testing.T) {
+	token, err := BearerToken("Bearer abc.def.ghi")
+	require.NoError(t, err)
+	assert.Equal(t, "abc.def.ghi", token)
+
+	token, err = BearerToken("bearer  abc")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", token)
+
+	for _, header := range []string{"", "Bearer", "Bearer ", "Basic dXNlcjpwYXNz"} {
+		_, err := BearerToken(header)
+		assert.ErrorIs(t, err, ErrMissingToken, header)
+	}
+}
+
+func TestVerifier_Roles(t *testing.T) {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: testSecret}, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		config Config
+		claims map[string]interface{}
+		want   []string
+	}{
+		{name: "list", claims: map[string]interface{}{"roles": []string{"editor", "admin"}}, want: []string{"editor", "admin"}},
+		{name: "string", claims: map[string]interface{}{"roles": "viewer editor"}, want: []string{"viewer", "editor"}},
+		{name: "custom claim", config: Config{RolesClaim: "groups"}, claims: map[string]interface{}{"groups": []string{"admin"}, "roles": "viewer"}, want: []string{"admin"}},
+		{name: "none", claims: map[string]interface{}{}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := jwt.Signed(signer).Claims(validClaims()).Claims(tt.claims).Serialize()
+			require.NoError(t, err)
+
+			identity, err := newVerifier(testSecret, nil, tt.config).Verify(context.Background(), token)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, identity.Roles)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package kafka
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// TopicSpec describes a topic to create. Zero Partitions and
+// ReplicationFactor use the broker defaults.
+type TopicSpec struct {
+	Name              string            `yaml:"name" json:"name"`
+	Partitions        int32             `yaml:"partitions" json:"partitions"`
+	ReplicationFactor int16             `yaml:"replication_factor" json:"replication_factor"`
+	Config            map[string]string `yaml:"config" json:"config,omitempty"`
+}
+
+// TopicDescription is the state of an existing topic. Config only holds the
+// settings that override the broker defaults.
+type TopicDescription struct {
+	Name              string            `json:"name"`
+	Partitions        int32             `json:"partitions"`
+	ReplicationFactor int16             `json:"replication_factor"`
+	Config            map[string]string `json:"config,omitempty"`
+}
+
+// GroupStatus is the state of a consumer group with the committed offsets and
+// lag of every partition it has committed.
+type GroupStatus struct {
+	Group      string            `json:"group"`
+	State      string            `json:"state"`
+	Members    int               `json:"members"`
+	Partitions []PartitionStatus `json:"partitions"`
+}
+
+// Lag returns the total lag of the group.
+func (s GroupStatus) Lag() int64 {
+	var lag int64
+	for _, p := range s.Partitions {
+		lag += p.Lag
+	}
+	return lag
+}
+
+// TopicSpec returns the spec configured for the topic, or a spec with the
+// broker defaults.
+func (c Config) TopicSpec(name string) TopicSpec {
+	for _, spec := range c.TopicSpecs {
+		if spec.Name == name {
+			return spec
+		}
+	}
+	return TopicSpec{Name: name}
+}
+
+// SnapshotTopicSpec returns the spec of the snapshot topic, which is compacted
+// unless its spec sets another cleanup policy.
+func (c Config) SnapshotTopicSpec() TopicSpec {
+	spec := c.TopicSpec(c.SnapshotTopic)
+	if _, ok := spec.Config["cleanup.policy"]; !ok {
+		config := maps.Clone(spec.Config)
+		if config == nil {
+			config = make(map[string]string)
+		}
+		config["cleanup.policy"] = "compact"
+		spec.Config = config
+	}
+	return spec
+}
+
+// EnsuredTopics returns the specs of the consumed and produced topics and of
+// the snapshot, stock and order topics followed by the remaining configured
+// specs.
+func (c Config) EnsuredTopics() []TopicSpec {
+	specs := []TopicSpec{}
+	names := []string{}
+	for _, name := range strings.Split(c.Topics, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || slices.Contains(names, name) {
+			continue
+		}
+		names = append(names, name)
+		specs = append(specs, c.TopicSpec(name))
+	}
+	if c.SnapshotTopic != "" && !slices.Contains(names, c.SnapshotTopic) {
+		names = append(names, c.SnapshotTopic)
+		specs = append(specs, c.SnapshotTopicSpec())
+	}
+	if c.StockTopic != "" && !slices.Contains(names, c.StockTopic) {
+		names = append(names, c.StockTopic)
+		specs = append(specs, c.TopicSpec(c.StockTopic))
+	}
+	if c.OrderTopic != "" && !slices.Contains(names, c.OrderTopic) {
+		names = append(names, c.OrderTopic)
+		specs = append(specs, c.TopicSpec(c.OrderTopic))
+	}
+	for _, spec := range c.TopicSpecs {
+		if !slices.Contains(names, spec.Name) {
+			names = append(names, spec.Name)
+			specs = append(specs, spec)
+		}
+	}
+	return specs
+}
@@ -0,0 +1,74 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_TopicSpec(t *testing.T) {
+	cfg := Config{TopicSpecs: []TopicSpec{{Name: "albums", Partitions: 6, ReplicationFactor: 3}}}
+
+	assert.Equal(t, TopicSpec{Name: "albums", Partitions: 6, ReplicationFactor: 3}, cfg.TopicSpec("albums"))
+	assert.Equal(t, TopicSpec{Name: "other"}, cfg.TopicSpec("other"))
+}
+
+func TestConfig_EnsuredTopics(t *testing.T) {
+	cfg := Config{
+		Topics: "albums, artists,albums",
+		TopicSpecs: []TopicSpec{
+			{Name: "album-snapshots", Config: map[string]string{"cleanup.policy": "compact"}},
+			{Name: "albums", Partitions: 6},
+		},
+	}
+
+	assert.Equal(t, []TopicSpec{
+		{Name: "albums", Partitions: 6},
+		{Name: "artists"},
+		{Name: "album-snapshots", Config: map[string]string{"cleanup.policy": "compact"}},
+	}, cfg.EnsuredTopics())
+}
+
+func TestConfig_EnsuredTopics_SnapshotTopic(t *testing.T) {
+	cfg := Config{
+		Topics:        "albums",
+		SnapshotTopic: "album-snapshots",
+		TopicSpecs: []TopicSpec{
+			{Name: "album-snapshots", Partitions: 3, Config: map[string]string{"min.compaction.lag.ms": "60000"}},
+		},
+	}
+
+	assert.Equal(t, []TopicSpec{
+		{Name: "albums"},
+		{Name: "album-snapshots", Partitions: 3, Config: map[string]string{"min.compaction.lag.ms": "60000", "cleanup.policy": "compact"}},
+	}, cfg.EnsuredTopics())
+	assert.Equal(t, map[string]string{"min.compaction.lag.ms": "60000"}, cfg.TopicSpecs[0].Config)
+}
+
+func TestConfig_EnsuredTopics_EventTopics(t *testing.T) {
+	cfg := Config{
+		Topics:        "albums",
+		SnapshotTopic: "album-snapshots",
+		StockTopic:    "stock-events",
+		OrderTopic:    "order-events",
+		TopicSpecs: []TopicSpec{
+			{Name: "stock-events", Partitions: 3},
+		},
+	}
+
+	assert.Equal(t, []TopicSpec{
+		{Name: "albums"},
+		{Name: "album-snapshots", Config: map[string]string{"cleanup.policy": "compact"}},
+		{Name: "stock-events", Partitions: 3},
+		{Name: "order-events"},
+	}, cfg.EnsuredTopics())
+}
+
+func TestConfig_EnsuredTopics_Empty(t *testing.T) {
+	assert.Empty(t, Config{}.EnsuredTopics())
+}
+
+func TestGroupStatus_Lag(t *testing.T) {
+	status := GroupStatus{Partitions: []PartitionStatus{{Lag: 3}, {Lag: 0}, {Lag: 4}}}
+	assert.Equal(t, int64(7), status.Lag())
+}
@@ -0,0 +1,438 @@
+package codec
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var errAvroTruncated = errors.New("avro value is truncated")
+
+// avroCodec encodes messages in the Avro binary encoding of the schema
+// returned by AvroSchema, which is derived from the protobuf descriptor.
+// Fields are written in field declaration order, proto3 optional fields and
+// singular messages are unions with null, and unsigned and fixed integers
+// that do not fit an Avro int are longs.
+type avroCodec struct{}
+
+func (avroCodec) ContentType() string {
+	return ContentTypeAvro
+}
+
+func (avroCodec) Encode(msg proto.Message) ([]byte, error) {
+	w := &avroWriter{}
+	w.message(msg.ProtoReflect())
+	return w.buf, nil
+}
+
+func (avroCodec) Decode(data []byte, msg proto.Message) error {
+	r := &avroReader{data: data}
+	proto.Reset(msg)
+	r.message(msg.ProtoReflect())
+	return r.err
+}
+
+// AvroSchema returns the Avro schema, as JSON, of the values written by the
+// avro codec for the message, for consumers that need to register or
+// configure it.
+func AvroSchema(desc protoreflect.MessageDescriptor) string {
+	s := &avroSchemaBuilder{}
+	data, _ := json.MarshalIndent(s.record(desc), "", "  ")
+	return string(data)
+}
+
+type avroSchemaBuilder struct {
+	defined []protoreflect.FullName
+}
+
+func (s *avroSchemaBuilder) named(desc protoreflect.Descriptor) (string, bool) {
+	if slices.Contains(s.defined, desc.FullName()) {
+		return string(desc.FullName()), true
+	}
+	s.defined = append(s.defined, desc.FullName())
+	return "", false
+}
+
+func (s *avroSchemaBuilder) record(desc protoreflect.MessageDescriptor) any {
+	if name, ok := s.named(desc); ok {
+		return name
+	}
+
+	fields := []map[string]any{}
+	for i := 0; i < desc.Fields().Len(); i++ {
+		fd := desc.Fields().Get(i)
+		field := map[string]any{"name": string(fd.Name())}
+
+		switch {
+		case fd.IsMap():
+			field["type"] = map[string]any{"type": "map", "values": s.value(fd.MapValue())}
+			field["default"] = map[string]any{}
+		case fd.IsList():
+			field["type"] = map[string]any{"type": "array", "items": s.value(fd)}
+			field["default"] = []any{}
+		case isUnion(fd):
+			field["type"] = []any{"null", s.value(fd)}
+			field["default"] = nil
+		default:
+			field["type"] = s.value(fd)
+			field["default"] = avroDefault(fd)
+		}
+		fields = append(fields, field)
+	}
+
+	return map[string]any{
+		"type":      "record",
+		"name":      string(desc.Name()),
+		"namespace": string(desc.ParentFile().Package()),
+		"fields":    fields,
+	}
+}
+
+func (s *avroSchemaBuilder) value(fd protoreflect.FieldDescriptor) any {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return s.record(fd.Message())
+	case protoreflect.EnumKind:
+		if name, ok := s.named(fd.Enum()); ok {
+			return name
+		}
+		symbols := []string{}
+		for i := 0; i < fd.Enum().Values().Len(); i++ {
+			symbols = append(symbols, string(fd.Enum().Values().Get(i).Name()))
+		}
+		return map[string]any{
+			"type":      "enum",
+			"name":      string(fd.Enum().Name()),
+			"namespace": string(fd.Enum().ParentFile().Package()),
+			"symbols":   symbols,
+		}
+	default:
+		return avroPrimitive(fd.Kind())
+	}
+}
+
+func avroPrimitive(kind protoreflect.Kind) string {
+	switch kind {
+	case protoreflect.BoolKind:
+		return "boolean"
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return "int"
+	case protoreflect.FloatKind:
+		return "float"
+	case protoreflect.DoubleKind:
+		return "double"
+	case protoreflect.StringKind:
+		return "string"
+	case protoreflect.BytesKind:
+		return "bytes"
+	default:
+		return "long"
+	}
+}
+
+func avroDefault(fd protoreflect.FieldDescriptor) any {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return false
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		return ""
+	case protoreflect.EnumKind:
+		return string(fd.Enum().Values().Get(0).Name())
+	default:
+		return 0
+	}
+}
+
+// isUnion reports whether the field is written as a union with null because
+// it tracks presence.
+func isUnion(fd protoreflect.FieldDescriptor) bool {
+	return !fd.IsList() && !fd.IsMap() && fd.HasPresence()
+}
+
+type avroWriter struct {
+	buf []byte
+}
+
+func (w *avroWriter) long(v int64) {
+	w.buf = binary.AppendVarint(w.buf, v)
+}
+
+func (w *avroWriter) bytes(b []byte) {
+	w.long(int64(len(b)))
+	w.buf = append(w.buf, b...)
+}
+
+func (w *avroWriter) message(m protoreflect.Message) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+
+		switch {
+		case fd.IsMap():
+			mp := m.Get(fd).Map()
+			keys := []protoreflect.MapKey{}
+			mp.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+				keys = append(keys, k)
+				return true
+			})
+			slices.SortFunc(keys, func(a, b protoreflect.MapKey) int {
+				return strings.Compare(a.String(), b.String())
+			})
+
+			if len(keys) > 0 {
+				w.long(int64(len(keys)))
+				for _, k := range keys {
+					w.bytes([]byte(k.String()))
+					w.value(fd.MapValue(), mp.Get(k))
+				}
+			}
+			w.long(0)
+		case fd.IsList():
+			list := m.Get(fd).List()
+			if list.Len() > 0 {
+				w.long(int64(list.Len()))
+				for j := 0; j < list.Len(); j++ {
+					w.value(fd, list.Get(j))
+				}
+			}
+			w.long(0)
+		case isUnion(fd):
+			if !m.Has(fd) {
+				w.long(0)
+				continue
+			}
+			w.long(1)
+			w.value(fd, m.Get(fd))
+		default:
+			w.value(fd, m.Get(fd))
+		}
+	}
+}
+
+func (w *avroWriter) value(fd protoreflect.FieldDescriptor, v protoreflect.Value) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if v.Bool() {
+			w.buf = append(w.buf, 1)
+		} else {
+			w.buf = append(w.buf, 0)
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		w.long(v.Int())
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		w.long(int64(v.Uint()))
+	case protoreflect.FloatKind:
+		w.buf = binary.LittleEndian.AppendUint32(w.buf, math.Float32bits(float32(v.Float())))
+	case protoreflect.DoubleKind:
+		w.buf = binary.LittleEndian.AppendUint64(w.buf, math.Float64bits(v.Float()))
+	case protoreflect.StringKind:
+		w.bytes([]byte(v.String()))
+	case protoreflect.BytesKind:
+		w.bytes(v.Bytes())
+	case protoreflect.EnumKind:
+		index := 0
+		if value := fd.Enum().Values().ByNumber(v.Enum()); value != nil {
+			index = value.Index()
+		}
+		w.long(int64(index))
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		w.message(v.Message())
+	}
+}
+
+type avroReader struct {
+	data []byte
+	err  error
+}
+
+func (r *avroReader) long() int64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(r.data)
+	if n <= 0 {
+		r.err = errAvroTruncated
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+func (r *avroReader) fixed(size int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if size < 0 || len(r.data) < size {
+		r.err = errAvroTruncated
+		return nil
+	}
+	b := r.data[:size]
+	r.data = r.data[size:]
+	return b
+}
+
+func (r *avroReader) bytes() []byte {
+	return slices.Clone(r.fixed(int(r.long())))
+}
+
+// blockCount returns the item count of the next array or map block. A
+// negative count is followed by the block size in bytes, which is skipped.
+func (r *avroReader) blockCount() int64 {
+	n := r.long()
+	if n < 0 {
+		n = -n
+		r.long()
+	}
+	return n
+}
+
+func (r *avroReader) message(m protoreflect.Message) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len() && r.err == nil; i++ {
+		fd := fields.Get(i)
+
+		switch {
+		case fd.IsMap():
+			mp := m.Mutable(fd).Map()
+			for n := r.blockCount(); n > 0 && r.err == nil; n = r.blockCount() {
+				for j := int64(0); j < n && r.err == nil; j++ {
+					key := r.mapKey(fd.MapKey(), string(r.bytes()))
+					if fd.MapValue().Message() != nil {
+						v := mp.NewValue()
+						r.message(v.Message())
+						mp.Set(key, v)
+					} else {
+						mp.Set(key, r.value(fd.MapValue()))
+					}
+				}
+			}
+		case fd.IsList():
+			list := m.Mutable(fd).List()
+			for n := r.blockCount(); n > 0 && r.err == nil; n = r.blockCount() {
+				for j := int64(0); j < n && r.err == nil; j++ {
+					if fd.Message() != nil {
+						v := list.NewElement()
+						r.message(v.Message())
+						list.Append(v)
+					} else {
+						list.Append(r.value(fd))
+					}
+				}
+			}
+		case isUnion(fd):
+			if r.long() == 0 {
+				continue
+			}
+			r.singular(m, fd)
+		default:
+			r.singular(m, fd)
+		}
+	}
+}
+
+func (r *avroReader) singular(m protoreflect.Message, fd protoreflect.FieldDescriptor) {
+	if fd.Message() != nil {
+		v := m.NewField(fd)
+		r.message(v.Message())
+		m.Set(fd, v)
+		return
+	}
+
+	v := r.value(fd)
+	if r.err == nil {
+		m.Set(fd, v)
+	}
+}
+
+func (r *avroReader) value(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		b := r.fixed(1)
+		return protoreflect.ValueOfBool(len(b) == 1 && b[0] != 0)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(int32(r.long()))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(r.long())
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(uint32(r.long()))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(uint64(r.long()))
+	case protoreflect.FloatKind:
+		b := r.fixed(4)
+		if b == nil {
+			return protoreflect.ValueOfFloat32(0)
+		}
+		return protoreflect.ValueOfFloat32(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+	case protoreflect.DoubleKind:
+		b := r.fixed(8)
+		if b == nil {
+			return protoreflect.ValueOfFloat64(0)
+		}
+		return protoreflect.ValueOfFloat64(math.Float64frombits(binary.LittleEndian.Uint64(b)))
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(string(r.bytes()))
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes(r.bytes())
+	case protoreflect.EnumKind:
+		index := int(r.long())
+		values := fd.Enum().Values()
+		if index < 0 || index >= values.Len() {
+			if r.err == nil {
+				r.err = fmt.Errorf("avro enum index %d out of range for %s", index, fd.Enum().FullName())
+			}
+			return protoreflect.ValueOfEnum(0)
+		}
+		return protoreflect.ValueOfEnum(values.Get(index).Number())
+	default:
+		if r.err == nil {
+			r.err = fmt.Errorf("unsupported avro field kind %s", fd.Kind())
+		}
+		return protoreflect.Value{}
+	}
+}
+
+// mapKey converts an Avro map key, which is always a string, back to the
+// protobuf key kind.
+func (r *avroReader) mapKey(fd protoreflect.FieldDescriptor, key string) protoreflect.MapKey {
+	var (
+		v   protoreflect.Value
+		err error
+	)
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		v = protoreflect.ValueOfString(key)
+	case protoreflect.BoolKind:
+		var b bool
+		b, err = strconv.ParseBool(key)
+		v = protoreflect.ValueOfBool(b)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		var i int64
+		i, err = strconv.ParseInt(key, 10, 32)
+		v = protoreflect.ValueOfInt32(int32(i))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		var i int64
+		i, err = strconv.ParseInt(key, 10, 64)
+		v = protoreflect.ValueOfInt64(i)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		var u uint64
+		u, err = strconv.ParseUint(key, 10, 32)
+		v = protoreflect.ValueOfUint32(uint32(u))
+	default:
+		var u uint64
+		u, err = strconv.ParseUint(key, 10, 64)
+		v = protoreflect.ValueOfUint64(u)
+	}
+	if err != nil && r.err == nil {
+		r.err = fmt.Errorf("invalid avro map key %q: %w", key, err)
+	}
+	return v.MapKey()
+}
@@ -0,0 +1,113 @@
+package codec
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"music-service/pkg/kafka"
+	"music-service/pkg/kafka/registry"
+)
+
+// ContentTypeHeader carries the content type of the value, so consumers can
+// decode topics with mixed encodings, e.g. during a migration.
+const ContentTypeHeader = "content-type"
+
+const (
+	EncodingProtobuf = "protobuf"
+	EncodingJSON     = "json"
+	EncodingAvro     = "avro"
+)
+
+const (
+	ContentTypeProtobuf = "application/x-protobuf"
+	ContentTypeJSON     = "application/json"
+	ContentTypeAvro     = "application/avro"
+)
+
+var ErrUnsupportedContentType = errors.New("unsupported content type")
+
+// Codec encodes and decodes message values in one encoding.
+type Codec interface {
+	ContentType() string
+	Encode(msg proto.Message) ([]byte, error)
+	Decode(data []byte, msg proto.Message) error
+}
+
+// NewEncoder returns the codec configured for the topic. Protobuf values are
+// framed with their schema id when the schema registry is enabled.
+func NewEncoder(cfg kafka.Config, topic string, msg proto.Message) (Codec, error) {
+	switch encoding := strings.ToLower(cfg.Encodings[topic]); encoding {
+	case "", EncodingProtobuf:
+		serializer, err := registry.NewSerializer(cfg, topic, msg)
+		if err != nil {
+			return nil, err
+		}
+		return &protobufCodec{serializer: serializer}, nil
+	case EncodingJSON:
+		return jsonCodec{}, nil
+	case EncodingAvro:
+		return avroCodec{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported encoding %q for topic %s", encoding, topic)
+	}
+}
+
+// Decoder decodes values of any supported encoding, selected by the content
+// type header. Values without the header are protobuf, the encoding used
+// before the header was introduced.
+type Decoder struct {
+	codecs map[string]Codec
+}
+
+func NewDecoder(cfg kafka.Config) (*Decoder, error) {
+	deserializer, err := registry.NewDeserializer(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return newDecoder(deserializer), nil
+}
+
+func newDecoder(deserializer *registry.Deserializer) *Decoder {
+	codecs := map[string]Codec{}
+	for _, c := range []Codec{&protobufCodec{deserializer: deserializer}, jsonCodec{}, avroCodec{}} {
+		codecs[c.ContentType()] = c
+	}
+	return &Decoder{codecs: codecs}
+}
+
+// Decode decodes data into msg. A nil decoder decodes protobuf without
+// schema registry checks.
+func (d *Decoder) Decode(contentType string, data []byte, msg proto.Message) error {
+	if d == nil {
+		d = newDecoder(nil)
+	}
+
+	if contentType == "" {
+		contentType = ContentTypeProtobuf
+	}
+	c, ok := d.codecs[contentType]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnsupportedContentType, contentType)
+	}
+	return c.Decode(data, msg)
+}
+
+type protobufCodec struct {
+	serializer   *registry.Serializer
+	deserializer *registry.Deserializer
+}
+
+func (c *protobufCodec) ContentType() string {
+	return ContentTypeProtobuf
+}
+
+func (c *protobufCodec) Encode(msg proto.Message) ([]byte, error) {
+	return c.serializer.Serialize(msg)
+}
+
+func (c *protobufCodec) Decode(data []byte, msg proto.Message) error {
+	return c.deserializer.Deserialize(data, msg)
+}
@@ -0,0 +1,137 @@
+package codec
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"music-service/gen/pb"
+	"music-service/pkg/kafka"
+)
+
+func TestCodecs_RoundTrip(t *testing.T) {
+	messages := []struct {
+		name string
+		msg  proto.Message
+		zero proto.Message
+	}{
+		{
+			name: "album",
+			msg:  &pb.Album{Id: 7, Title: "Kind of Blue", Artist: "Miles Davis", Price: 9.99},
+			zero: &pb.Album{},
+		},
+		{
+			name: "repeated messages",
+			msg: &pb.GetConsumerStatusResponse{Partitions: []*pb.PartitionStatus{
+				{Topic: "albums", Partition: 0, Offset: 12, HighWatermark: 15, Lag: 3},
+				{Topic: "albums", Partition: 1, Offset: -1, Paused: true},
+			}},
+			zero: &pb.GetConsumerStatusResponse{},
+		},
+		{
+			name: "repeated scalars",
+			msg:  &pb.ResetConsumerOffsetsRequest{Topic: "albums", Partitions: []int32{0, 2, 300}, TimestampMs: 1700000000000},
+			zero: &pb.ResetConsumerOffsetsRequest{},
+		},
+	}
+
+	for _, c := range []Codec{&protobufCodec{}, jsonCodec{}, avroCodec{}} {
+		for _, m := range messages {
+			t.Run(c.ContentType()+"/"+m.name, func(t *testing.T) {
+				data, err := c.Encode(m.msg)
+				assert.NoError(t, err)
+
+				decoded := proto.Clone(m.zero)
+				assert.NoError(t, c.Decode(data, decoded))
+				assert.True(t, proto.Equal(m.msg, decoded), "got %v", decoded)
+			})
+		}
+	}
+}
+
+func TestJSONCodec_UsesProtoNames(t *testing.T) {
+	data, err := jsonCodec{}.Encode(&pb.PartitionStatus{Topic: "albums", HighWatermark: 5})
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"high_watermark"`)
+
+	var status pb.PartitionStatus
+	assert.NoError(t, jsonCodec{}.Decode([]byte(`{"topic":"albums","added_later":true}`), &status))
+	assert.Equal(t, "albums", status.Topic)
+}
+
+func TestAvroCodec_Truncated(t *testing.T) {
+	data, err := avroCodec{}.Encode(&pb.Album{Id: 7, Title: "Kind of Blue"})
+	assert.NoError(t, err)
+
+	err = avroCodec{}.Decode(data[:len(data)-3], &pb.Album{})
+	assert.Error(t, err)
+}
+
+func TestAvroSchema(t *testing.T) {
+	var schema struct {
+		Type   string `json:"type"`
+		Name   string `json:"name"`
+		Fields []struct {
+			Name string `json:"name"`
+			Type any    `json:"type"`
+		} `json:"fields"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(AvroSchema((&pb.Album{}).ProtoReflect().Descriptor())), &schema))
+
+	assert.Equal(t, "record", schema.Type)
+	assert.Equal(t, "Album", schema.Name)
+	names := make([]string, 0, len(schema.Fields))
+	for _, f := range schema.Fields {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"id", "title", "artist", "price", "artist_id", "tracks", "removed_tracks", "release_date", "label_id", "editions", "currency", "prices", "average_rating", "rating_count"}, names)
+	assert.Equal(t, "int", schema.Fields[0].Type)
+	assert.Equal(t, "float", schema.Fields[3].Type)
+}
+
+func TestNewEncoder(t *testing.T) {
+	tests := []struct {
+		encoding    string
+		contentType string
+	}{
+		{encoding: "", contentType: ContentTypeProtobuf},
+		{encoding: "protobuf", contentType: ContentTypeProtobuf},
+		{encoding: "JSON", contentType: ContentTypeJSON},
+		{encoding: "avro", contentType: ContentTypeAvro},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			cfg := kafka.Config{Topics: "albums", Encodings: map[string]string{"albums": tt.encoding}}
+			encoder, err := NewEncoder(cfg, "albums", &pb.Album{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.contentType, encoder.ContentType())
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		cfg := kafka.Config{Topics: "albums", Encodings: map[string]string{"albums": "xml"}}
+		_, err := NewEncoder(cfg, "albums", &pb.Album{})
+		assert.Error(t, err)
+	})
+}
+
+func TestDecoder_Decode(t *testing.T) {
+	album := &pb.Album{Id: 7, Title: "Kind of Blue"}
+	raw, err := proto.Marshal(album)
+	assert.NoError(t, err)
+
+	t.Run("missing content type is protobuf", func(t *testing.T) {
+		var decoded pb.Album
+		assert.NoError(t, (*Decoder)(nil).Decode("", raw, &decoded))
+		assert.True(t, proto.Equal(album, &decoded))
+	})
+
+	t.Run("unsupported content type", func(t *testing.T) {
+		err := newDecoder(nil).Decode("application/xml", raw, &pb.Album{})
+		assert.True(t, errors.Is(err, ErrUnsupportedContentType))
+	})
+}
@@ -1,9 +1,86 @@
 package kafka
 
+import "time"
+
+// DriverMemory selects the in-process broker instead of a Kafka cluster, so
+// the whole pipeline can run in tests and locally without a broker.
+const DriverMemory = "memory"
+
 type Config struct {
-	Brokers       string `yaml:"brokers"`
-	Topics        string `yaml:"topics"`
-	ConsumerGroup string `yaml:"consumer_group"`
-	Assignor      string `yaml:"assignor"`
-	Oldest        bool   `yaml:"oldest"`
+	Driver        string         `yaml:"driver"`
+	Brokers       string         `yaml:"brokers"`
+	Topics        string         `yaml:"topics"`
+	ConsumerGroup string         `yaml:"consumer_group"`
+	Assignor      string         `yaml:"assignor"`
+	Oldest        bool           `yaml:"oldest"`
+	Security      SecurityConfig `yaml:"security"`
+	Control       ControlConfig  `yaml:"control"`
+
+	SchemaRegistry SchemaRegistryConfig `yaml:"schema_registry"`
+	// Encodings selects the encoding of the values produced to a topic:
+	// protobuf (the default), json or avro.
+	Encodings map[string]string `yaml:"encodings"`
+
+	// Properties are passed as is to librdkafka by the confluent clients.
+	// Settings derived from the fields above take precedence.
+	Properties map[string]string `yaml:"properties"`
+	Sarama     SaramaConfig      `yaml:"sarama"`
+
+	// EnsureTopics creates the missing topics of Topics and TopicSpecs when a
+	// producer or consumer starts.
+	EnsureTopics bool `yaml:"ensure_topics"`
+	// TopicSpecs describe the topics created by kafka-admin and EnsureTopics.
+	// Topics without a spec use the broker defaults.
+	TopicSpecs []TopicSpec `yaml:"topic_specs"`
+
+	// SnapshotTopic is the compacted topic kafka-cdc publishes the current
+	// state of every album to, keyed by id.
+	SnapshotTopic string `yaml:"snapshot_topic"`
+
+	// StockTopic is the topic low-stock events are published to, keyed by
+	// album id.
+	StockTopic string `yaml:"stock_topic"`
+	// OrderTopic is the topic order events are published to, keyed by order
+	// id.
+	OrderTopic string `yaml:"order_topic"`
+}
+
+// ControlConfig holds the listen addresses of the consumer control API. Each
+// server is only started when its address is set.
+type ControlConfig struct {
+	HttpUrl  string `yaml:"http_url"`
+	GrpcPort string `yaml:"grpc_port"`
+}
+
+// SchemaRegistryConfig enables Confluent wire-format framing of message values.
+// Url points at a Confluent compatible schema registry, File at the local
+// file-backed stand-in used when Url is empty.
+type SchemaRegistryConfig struct {
+	Url          string `yaml:"url"`
+	Username     string `yaml:"username"`
+	Password     string `yaml:"password"`
+	PasswordFile string `yaml:"password_file"`
+	File         string `yaml:"file"`
+	AutoRegister bool   `yaml:"auto_register"`
+}
+
+func (c SchemaRegistryConfig) Enabled() bool {
+	return c.Url != "" || c.File != ""
+}
+
+// SaramaConfig tunes the sarama clients. Zero values keep the sarama
+// defaults, except Retries which keeps the producer default of 10.
+type SaramaConfig struct {
+	Version           string        `yaml:"version"`
+	Retries           *int          `yaml:"retries"`
+	FetchMinBytes     int32         `yaml:"fetch_min_bytes"`
+	FetchDefaultBytes int32         `yaml:"fetch_default_bytes"`
+	FetchMaxBytes     int32         `yaml:"fetch_max_bytes"`
+	DialTimeout       time.Duration `yaml:"dial_timeout"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	SessionTimeout    time.Duration `yaml:"session_timeout"`
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval"`
+	RebalanceTimeout  time.Duration `yaml:"rebalance_timeout"`
+	MaxProcessingTime time.Duration `yaml:"max_processing_time"`
 }
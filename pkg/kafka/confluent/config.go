@@ -0,0 +1,61 @@
+package confluent
+
+import (
+	"maps"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+	music_kafka "music-service/pkg/kafka"
+)
+
+// consumerDefaults can be overridden through Config.Properties.
+var consumerDefaults = kafka.ConfigMap{
+	"session.timeout.ms":   30000,
+	"max.poll.interval.ms": 300000,
+}
+
+// NewConsumerConfig builds the librdkafka settings of the consumer. The
+// settings the consumer relies on, such as manual offset storage and
+// cooperative rebalancing, cannot be overridden through Config.Properties.
+func NewConsumerConfig(cfg music_kafka.Config) (*kafka.ConfigMap, error) {
+	configMap := newConfigMap(consumerDefaults, cfg.Properties)
+
+	autoOffsetReset := "latest"
+	if cfg.Oldest {
+		autoOffsetReset = "earliest"
+	}
+
+	configMap["bootstrap.servers"] = cfg.Brokers
+	configMap["group.id"] = cfg.ConsumerGroup
+	configMap["auto.offset.reset"] = autoOffsetReset
+	configMap["enable.auto.commit"] = false
+	configMap["enable.auto.offset.store"] = false
+	configMap["partition.assignment.strategy"] = "cooperative-sticky"
+	configMap["go.application.rebalance.enable"] = true
+
+	if err := setSecurity(configMap, cfg.Security); err != nil {
+		return nil, err
+	}
+	return &configMap, nil
+}
+
+func NewProducerConfig(cfg music_kafka.Config) (*kafka.ConfigMap, error) {
+	configMap := newConfigMap(nil, cfg.Properties)
+	configMap["bootstrap.servers"] = cfg.Brokers
+
+	if err := setSecurity(configMap, cfg.Security); err != nil {
+		return nil, err
+	}
+	return &configMap, nil
+}
+
+func newConfigMap(defaults kafka.ConfigMap, properties map[string]string) kafka.ConfigMap {
+	configMap := maps.Clone(defaults)
+	if configMap == nil {
+		configMap = kafka.ConfigMap{}
+	}
+	for key, value := range properties {
+		configMap[key] = value
+	}
+	return configMap
+}
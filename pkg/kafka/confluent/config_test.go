@@ -0,0 +1,108 @@
+package confluent
+
+import (
+	"testing"
+
+	music_kafka "music-service/pkg/kafka"
+)
+
+// TestNewConsumerConfig_Defaults tests the defaults and the offset reset derived from Oldest
+func TestNewConsumerConfig_Defaults(t *testing.T) {
+	tests := []struct {
+		oldest bool
+		want   string
+	}{
+		{oldest: true, want: "earliest"},
+		{oldest: false, want: "latest"},
+	}
+
+	for _, tt := range tests {
+		configMap, err := NewConsumerConfig(music_kafka.Config{
+			Brokers:       "localhost:9092",
+			ConsumerGroup: "test-group",
+			Oldest:        tt.oldest,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		values := *configMap
+		if values["auto.offset.reset"] != tt.want {
+			t.Errorf("Expected auto.offset.reset %s, got %v", tt.want, values["auto.offset.reset"])
+		}
+		if values["session.timeout.ms"] != 30000 {
+			t.Errorf("Expected default session.timeout.ms, got %v", values["session.timeout.ms"])
+		}
+		if values["group.id"] != "test-group" {
+			t.Errorf("Expected group.id test-group, got %v", values["group.id"])
+		}
+	}
+}
+
+// TestNewConsumerConfig_Properties tests that properties override defaults but not config fields
+func TestNewConsumerConfig_Properties(t *testing.T) {
+	configMap, err := NewConsumerConfig(music_kafka.Config{
+		Brokers:       "localhost:9092",
+		ConsumerGroup: "test-group",
+		Oldest:        true,
+		Properties: map[string]string{
+			"session.timeout.ms": "45000",
+			"fetch.min.bytes":    "1024",
+			"group.id":           "other-group",
+			"auto.offset.reset":  "latest",
+			"enable.auto.commit": "true",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := map[string]any{
+		"session.timeout.ms":   "45000",
+		"max.poll.interval.ms": 300000,
+		"fetch.min.bytes":      "1024",
+		"group.id":             "test-group",
+		"auto.offset.reset":    "earliest",
+		"enable.auto.commit":   false,
+	}
+	for key, value := range want {
+		if (*configMap)[key] != value {
+			t.Errorf("Expected %s=%v, got %v", key, value, (*configMap)[key])
+		}
+	}
+}
+
+// TestNewProducerConfig_Properties tests passing producer properties through
+func TestNewProducerConfig_Properties(t *testing.T) {
+	configMap, err := NewProducerConfig(music_kafka.Config{
+		Brokers: "localhost:9092",
+		Properties: map[string]string{
+			"linger.ms":         "5",
+			"bootstrap.servers": "other:9092",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if (*configMap)["linger.ms"] != "5" {
+		t.Errorf("Expected linger.ms 5, got %v", (*configMap)["linger.ms"])
+	}
+	if (*configMap)["bootstrap.servers"] != "localhost:9092" {
+		t.Errorf("Expected brokers from config, got %v", (*configMap)["bootstrap.servers"])
+	}
+}
+
+// TestNewConsumerConfig_DefaultsNotShared tests that building a config does not mutate the defaults
+func TestNewConsumerConfig_DefaultsNotShared(t *testing.T) {
+	_, err := NewConsumerConfig(music_kafka.Config{
+		Properties: map[string]string{"session.timeout.ms": "10000"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if consumerDefaults["session.timeout.ms"] != 30000 {
+		t.Errorf("Expected defaults to be unchanged, got %v", consumerDefaults["session.timeout.ms"])
+	}
+}
@@ -3,24 +3,41 @@ package confluent
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
 	"log"
 	"sync"
 	"time"
 
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 
+	"music-service/pkg/kafka/codec"
 	"music-service/pkg/kafka/message"
+	"music-service/pkg/kafka/metrics"
 )
 
+const revokeDrainTimeout = 10 * time.Second
+
+// task is a polled message with the generation of its partition's assignment.
+type task struct {
+	msg        *kafka.Message
+	generation uint64
+}
+
 type ack struct {
-	tp  kafka.TopicPartition
-	off kafka.Offset
+	tp         kafka.TopicPartition
+	off        kafka.Offset
+	generation uint64
 }
 
 type consumer struct {
 	confluentConsumer     *kafka.Consumer
 	messageValueProcessor message.MessageValueProcessor
 	parallelWorkers       int
+	tracker               *offsetTracker
+	controls              chan func()
+
+	mu     sync.Mutex
+	paused map[partitionKey]bool
 }
 
 func NewConsumer(confluentConsumer *kafka.Consumer, messageValueProcessor message.MessageValueProcessor, parallelWorkers int) *consumer {
@@ -28,15 +45,22 @@ func NewConsumer(confluentConsumer *kafka.Consumer, messageValueProcessor messag
 		confluentConsumer:     confluentConsumer,
 		messageValueProcessor: messageValueProcessor,
 		parallelWorkers:       parallelWorkers,
+		tracker:               newOffsetTracker(),
+		controls:              make(chan func()),
+		paused:                make(map[partitionKey]bool),
 	}
 }
 
 func (c *consumer) Consume(ctx context.Context) error {
-	tasks := make(chan *kafka.Message, 1000)
+	workers := max(c.parallelWorkers, 1)
+	tasks := make([]chan task, workers)
+	for i := range tasks {
+		tasks[i] = make(chan task, 1000)
+	}
 	acks := make(chan ack, 1000)
 
 	var wg sync.WaitGroup
-	for i := 0; i < c.parallelWorkers; i++ {
+	for i := 0; i < workers; i++ {
 		wg.Add(1)
 		go func(workerID int) {
 			defer wg.Done()
@@ -46,10 +70,17 @@ func (c *consumer) Consume(ctx context.Context) error {
 				select {
 				case <-ctx.Done():
 					return
-				case msg, ok := <-tasks:
+				case task, ok := <-tasks[workerID]:
 					if !ok {
 						return
 					}
+					msg := task.msg
+					metrics.SetWorkerQueueDepth(workerID, len(tasks[workerID]))
+
+					if c.tracker.isRevoked(msg.TopicPartition, task.generation) {
+						c.tracker.drop(msg.TopicPartition, task.generation)
+						continue
+					}
 
 					log.Printf(
 						"Processing message from %s[%d]@%d",
@@ -58,11 +89,14 @@ func (c *consumer) Consume(ctx context.Context) error {
 						msg.TopicPartition.Offset,
 					)
 
-					c.messageValueProcessor.Process(msg.Value)
+					start := time.Now()
+					err := c.messageValueProcessor.Process(msg.Value, contentType(msg), header(msg, message.PrincipalHeader))
+					metrics.ObserveMessage(*msg.TopicPartition.Topic, start, err)
 
 					acks <- ack{
-						tp:  msg.TopicPartition,
-						off: msg.TopicPartition.Offset,
+						tp:         msg.TopicPartition,
+						off:        msg.TopicPartition.Offset,
+						generation: task.generation,
 					}
 				}
 			}
@@ -73,36 +107,44 @@ func (c *consumer) Consume(ctx context.Context) error {
 		ticker := time.NewTicker(5 * time.Second)
 		defer ticker.Stop()
 
-		pending := make(map[kafka.TopicPartition]kafka.Offset)
+		pending := false
 
 		for {
 			select {
 			case <-ctx.Done():
 				return
 			case ack := <-acks:
-				nextOffset := ack.off + 1
+				nextOffset, advanced := c.tracker.processed(ack.tp, ack.generation)
+				if !advanced {
+					continue
+				}
+
 				tp := kafka.TopicPartition{
 					Topic:     ack.tp.Topic,
 					Partition: ack.tp.Partition,
 					Offset:    nextOffset,
 				}
 
-				pending[ack.tp] = nextOffset
-
-				_, err := c.confluentConsumer.StoreOffsets([]kafka.TopicPartition{tp})
+				stored, err := c.tracker.store(tp, ack.generation, c.confluentConsumer.StoreOffsets)
 				if err != nil {
 					log.Printf("Failed to store offset: %v", err)
+					continue
+				}
+				if stored {
+					pending = true
 				}
 
 			case <-ticker.C:
-				if len(pending) > 0 {
+				if pending {
+					start := time.Now()
 					offsets, err := c.confluentConsumer.Commit()
+					metrics.ObserveCommit(start, err)
 					if err != nil {
 						log.Printf("Commit failed: %v", err)
 					} else {
 						log.Printf("Committed %d partitions", len(offsets))
 					}
-					pending = make(map[kafka.TopicPartition]kafka.Offset)
+					pending = false
 				}
 			}
 		}
@@ -114,16 +156,23 @@ func (c *consumer) Consume(ctx context.Context) error {
 		select {
 		case <-ctx.Done():
 			log.Println("shutting down consumer...")
-			close(tasks)
+			for _, t := range tasks {
+				close(t)
+			}
 			wg.Wait()
 
+			start := time.Now()
 			_, err := c.confluentConsumer.Commit()
+			metrics.ObserveCommit(start, err)
 			if err != nil {
 				log.Printf("final commit failed: %v", err)
 			}
 
 			return c.confluentConsumer.Close()
 
+		case control := <-c.controls:
+			control()
+
 		default:
 			ev := c.confluentConsumer.Poll(100)
 			if ev == nil {
@@ -132,11 +181,14 @@ func (c *consumer) Consume(ctx context.Context) error {
 
 			switch e := ev.(type) {
 			case *kafka.Message:
+				generation := c.tracker.dispatched(e.TopicPartition)
+				worker := workerFor(e, workers)
 				select {
 				case <-ctx.Done():
 					return ctx.Err()
-				case tasks <- e:
+				case tasks[worker] <- task{msg: e, generation: generation}:
 				}
+				metrics.SetWorkerQueueDepth(worker, len(tasks[worker]))
 
 			case kafka.Error:
 				log.Printf("consumer error: %v", e)
@@ -146,14 +198,18 @@ func (c *consumer) Consume(ctx context.Context) error {
 
 			case kafka.AssignedPartitions:
 				log.Printf("partitions assigned: %v", e)
-				err := c.confluentConsumer.Assign(e.Partitions)
+				metrics.Rebalanced(metrics.RebalanceAssigned)
+				err := c.confluentConsumer.IncrementalAssign(e.Partitions)
 				if err != nil {
 					log.Printf("failed to assign partitions: %v", err)
 				}
 
 			case kafka.RevokedPartitions:
 				log.Printf("partitions revoked: %v", e)
-				err := c.confluentConsumer.Unassign()
+				metrics.Rebalanced(metrics.RebalanceRevoked)
+				c.drainRevoked(e.Partitions)
+				c.unpause(e.Partitions)
+				err := c.confluentConsumer.IncrementalUnassign(e.Partitions)
 				if err != nil {
 					log.Printf("failed to unassign partitions: %v", err)
 				}
@@ -164,3 +220,68 @@ func (c *consumer) Consume(ctx context.Context) error {
 		}
 	}
 }
+
+// drainRevoked waits for the in-flight messages of the revoked partitions to
+// finish, dropping the ones that have not started, and synchronously commits
+// their offsets so the next owner resumes exactly after them.
+func (c *consumer) drainRevoked(partitions []kafka.TopicPartition) {
+	offsets := c.drain(partitions)
+	if len(offsets) == 0 {
+		return
+	}
+
+	start := time.Now()
+	committed, err := c.confluentConsumer.CommitOffsets(offsets)
+	metrics.ObserveCommit(start, err)
+	if err != nil {
+		log.Printf("failed to commit revoked partitions: %v", err)
+		return
+	}
+	log.Printf("committed revoked partitions: %v", committed)
+}
+
+// drain stops the workers from starting new messages of the partitions, waits
+// up to revokeDrainTimeout for the running ones and returns the offsets that
+// are safe to commit.
+func (c *consumer) drain(partitions []kafka.TopicPartition) []kafka.TopicPartition {
+	c.tracker.revoke(partitions)
+
+	deadline := time.Now().Add(revokeDrainTimeout)
+	for !c.tracker.drained(partitions) {
+		if time.Now().After(deadline) {
+			log.Printf("timed out after %v waiting for partitions to drain", revokeDrainTimeout)
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	return c.tracker.remove(partitions)
+}
+
+// workerFor picks the worker for a message by hashing its key, so messages for
+// the same key are always handled in order by a single worker. Messages without
+// a key are sharded by partition, which keeps partition order instead.
+func workerFor(msg *kafka.Message, workers int) int {
+	h := fnv.New32a()
+	if len(msg.Key) > 0 {
+		h.Write(msg.Key)
+	} else {
+		fmt.Fprintf(h, "%d", msg.TopicPartition.Partition)
+	}
+	return int(h.Sum32() % uint32(workers))
+}
+
+func contentType(msg *kafka.Message) string {
+	return header(msg, codec.ContentTypeHeader)
+}
+
+// header returns the value of the header, or an empty string for messages
+// produced without it.
+func header(msg *kafka.Message, key string) string {
+	for _, header := range msg.Headers {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
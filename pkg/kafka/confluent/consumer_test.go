@@ -14,9 +14,10 @@ type MockMessageValueProcessor struct {
 	ProcessCount      int
 }
 
-func (m *MockMessageValueProcessor) Process(msg []byte) {
+func (m *MockMessageValueProcessor) Process(msg []byte, contentType, principal string) error {
 	m.ProcessedMessages = append(m.ProcessedMessages, msg)
 	m.ProcessCount++
+	return nil
 }
 
 // TestNewConsumer tests the constructor
@@ -247,7 +248,7 @@ func TestMockMessageValueProcessor(t *testing.T) {
 	}
 
 	for _, msg := range testMessages {
-		mock.Process(msg)
+		mock.Process(msg, "", "")
 	}
 
 	if mock.ProcessCount != 3 {
@@ -270,8 +271,8 @@ func TestMockMessageValueProcessor(t *testing.T) {
 func TestMockMessageValueProcessor_EmptyMessage(t *testing.T) {
 	mock := &MockMessageValueProcessor{}
 
-	mock.Process([]byte{})
-	mock.Process(nil)
+	mock.Process([]byte{}, "", "")
+	mock.Process(nil, "", "")
 
 	if mock.ProcessCount != 2 {
 		t.Errorf("Expected ProcessCount=2, got %d", mock.ProcessCount)
@@ -292,7 +293,7 @@ func TestMockMessageValueProcessor_LargeMessage(t *testing.T) {
 		largeMsg[i] = byte(i % 256)
 	}
 
-	mock.Process(largeMsg)
+	mock.Process(largeMsg, "", "")
 
 	if mock.ProcessCount != 1 {
 		t.Errorf("Expected ProcessCount=1, got %d", mock.ProcessCount)
@@ -348,3 +349,56 @@ func TestNewConsumer_ReturnsNonNilPointer(t *testing.T) {
 		})
 	}
 }
+
+// TestWorkerFor_SameKeySameWorker tests that a key is always routed to the same worker
+func TestWorkerFor_SameKeySameWorker(t *testing.T) {
+	topic := "test-topic"
+	workers := 5
+
+	first := workerFor(&kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: 0},
+		Key:            []byte("42"),
+	}, workers)
+
+	for partition := int32(0); partition < 10; partition++ {
+		got := workerFor(&kafka.Message{
+			TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: partition},
+			Key:            []byte("42"),
+		}, workers)
+		if got != first {
+			t.Errorf("Expected key to map to worker %d, got %d", first, got)
+		}
+	}
+}
+
+// TestWorkerFor_NoKeyUsesPartition tests that keyless messages are sharded by partition
+func TestWorkerFor_NoKeyUsesPartition(t *testing.T) {
+	topic := "test-topic"
+	workers := 3
+
+	for partition := int32(0); partition < 10; partition++ {
+		msg := &kafka.Message{TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: partition}}
+		first := workerFor(msg, workers)
+		if got := workerFor(msg, workers); got != first {
+			t.Errorf("Expected partition %d to map to worker %d, got %d", partition, first, got)
+		}
+		if first < 0 || first >= workers {
+			t.Errorf("Expected worker in range [0, %d), got %d", workers, first)
+		}
+	}
+}
+
+// TestWorkerFor_SingleWorker tests that a single worker receives everything
+func TestWorkerFor_SingleWorker(t *testing.T) {
+	topic := "test-topic"
+
+	for _, key := range []string{"", "1", "2", "album-3"} {
+		msg := &kafka.Message{
+			TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: 7},
+			Key:            []byte(key),
+		}
+		if got := workerFor(msg, 1); got != 0 {
+			t.Errorf("Expected worker 0 for key %q, got %d", key, got)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package confluent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+	music_kafka "music-service/pkg/kafka"
+)
+
+const controlTimeout = 5 * time.Second
+
+func (c *consumer) Pause(topic string, partitions []int32) error {
+	tps, err := c.selectAssigned(topic, partitions)
+	if err != nil {
+		return err
+	}
+
+	if err := c.confluentConsumer.Pause(tps); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, tp := range tps {
+		c.paused[keyOf(tp)] = true
+	}
+	return nil
+}
+
+func (c *consumer) Resume(topic string, partitions []int32) error {
+	tps, err := c.selectAssigned(topic, partitions)
+	if err != nil {
+		return err
+	}
+
+	if err := c.confluentConsumer.Resume(tps); err != nil {
+		return err
+	}
+
+	c.unpause(tps)
+	return nil
+}
+
+func (c *consumer) Status(ctx context.Context) ([]music_kafka.PartitionStatus, error) {
+	if c.confluentConsumer == nil {
+		return nil, music_kafka.ErrConsumerNotRunning
+	}
+
+	assigned, err := c.confluentConsumer.Assignment()
+	if err != nil {
+		return nil, err
+	}
+	if len(assigned) == 0 {
+		return []music_kafka.PartitionStatus{}, nil
+	}
+
+	committed, err := c.confluentConsumer.Committed(assigned, timeoutMs(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	statuses := make([]music_kafka.PartitionStatus, 0, len(committed))
+	for _, tp := range committed {
+		low, high, err := c.confluentConsumer.QueryWatermarkOffsets(*tp.Topic, tp.Partition, timeoutMs(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		offset := int64(tp.Offset)
+		if offset < 0 {
+			offset = low
+		}
+
+		statuses = append(statuses, music_kafka.PartitionStatus{
+			Topic:         *tp.Topic,
+			Partition:     tp.Partition,
+			Offset:        offset,
+			HighWatermark: high,
+			Lag:           max(high-offset, 0),
+			Paused:        c.paused[keyOf(tp)],
+		})
+	}
+	return statuses, nil
+}
+
+// ResetOffsets hands the reset to the polling loop, which owns the worker
+// pool, so in-flight messages of the partitions are drained before seeking.
+func (c *consumer) ResetOffsets(ctx context.Context, reset music_kafka.OffsetReset) error {
+	done := make(chan error, 1)
+	control := func() { done <- c.resetOffsets(ctx, reset) }
+
+	select {
+	case c.controls <- control:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *consumer) resetOffsets(ctx context.Context, reset music_kafka.OffsetReset) error {
+	tps, err := c.selectAssigned(reset.Topic, reset.Partitions)
+	if err != nil {
+		return err
+	}
+
+	targets := make([]kafka.TopicPartition, len(tps))
+	for i, tp := range tps {
+		tp.Offset = kafka.Offset(reset.Offset)
+		if !reset.Timestamp.IsZero() {
+			tp.Offset = kafka.Offset(reset.Timestamp.UnixMilli())
+		}
+		targets[i] = tp
+	}
+
+	if !reset.Timestamp.IsZero() {
+		targets, err = c.confluentConsumer.OffsetsForTimes(targets, timeoutMs(ctx))
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, tp := range targets {
+		if tp.Offset >= 0 {
+			continue
+		}
+		low, high, err := c.confluentConsumer.QueryWatermarkOffsets(*tp.Topic, tp.Partition, timeoutMs(ctx))
+		if err != nil {
+			return err
+		}
+		if tp.Offset == kafka.OffsetBeginning {
+			targets[i].Offset = kafka.Offset(low)
+		} else {
+			targets[i].Offset = kafka.Offset(high)
+		}
+	}
+
+	c.drain(tps)
+
+	for _, tp := range targets {
+		if err := c.confluentConsumer.Seek(tp, timeoutMs(ctx)); err != nil {
+			return fmt.Errorf("failed to seek %s[%d] to %d: %w", *tp.Topic, tp.Partition, tp.Offset, err)
+		}
+	}
+
+	_, err = c.confluentConsumer.CommitOffsets(targets)
+	return err
+}
+
+func (c *consumer) selectAssigned(topic string, partitions []int32) ([]kafka.TopicPartition, error) {
+	if c.confluentConsumer == nil {
+		return nil, music_kafka.ErrConsumerNotRunning
+	}
+
+	assigned, err := c.confluentConsumer.Assignment()
+	if err != nil {
+		return nil, err
+	}
+
+	assignment := make(map[string][]int32)
+	for _, tp := range assigned {
+		assignment[*tp.Topic] = append(assignment[*tp.Topic], tp.Partition)
+	}
+
+	tps := []kafka.TopicPartition{}
+	for t, ps := range music_kafka.SelectPartitions(assignment, topic, partitions) {
+		for _, p := range ps {
+			tps = append(tps, kafka.TopicPartition{Topic: &t, Partition: p})
+		}
+	}
+	if len(tps) == 0 {
+		return nil, fmt.Errorf("no assigned partitions match topic %q partitions %v", topic, partitions)
+	}
+	return tps, nil
+}
+
+func (c *consumer) unpause(tps []kafka.TopicPartition) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, tp := range tps {
+		delete(c.paused, keyOf(tp))
+	}
+}
+
+func timeoutMs(ctx context.Context) int {
+	if deadline, ok := ctx.Deadline(); ok {
+		return int(time.Until(deadline).Milliseconds())
+	}
+	return int(controlTimeout.Milliseconds())
+}
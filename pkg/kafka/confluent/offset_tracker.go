@@ -0,0 +1,202 @@
+package confluent
+
+import (
+	"sync"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+type partitionKey struct {
+	topic     string
+	partition int32
+}
+
+func keyOf(tp kafka.TopicPartition) partitionKey {
+	key := partitionKey{partition: tp.Partition}
+	if tp.Topic != nil {
+		key.topic = *tp.Topic
+	}
+	return key
+}
+
+// partitionOffsets holds the offsets dispatched for one partition in the order
+// they were polled, so that the committable offset only ever advances past a
+// contiguous run of processed messages. Offsets dropped during a revocation
+// are never processed and therefore hold the committable offset back.
+//
+// Every assignment of a partition is tracked under a new generation, so that
+// messages still queued or in flight from an earlier assignment are told apart
+// from those of the current one after the partition is revoked and assigned
+// again.
+type partitionOffsets struct {
+	generation uint64
+	inFlight   []kafka.Offset
+	done       map[kafka.Offset]bool
+	dropped    map[kafka.Offset]bool
+	next       kafka.Offset
+	revoked    bool
+}
+
+type offsetTracker struct {
+	mu         sync.Mutex
+	generation uint64
+	partitions map[partitionKey]*partitionOffsets
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{partitions: make(map[partitionKey]*partitionOffsets)}
+}
+
+// dispatched tracks the offset and returns the generation of the partition's
+// assignment, which the message carries until it is processed or dropped.
+func (t *offsetTracker) dispatched(tp kafka.TopicPartition) uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := keyOf(tp)
+	p, ok := t.partitions[key]
+	if !ok {
+		t.generation++
+		p = &partitionOffsets{
+			generation: t.generation,
+			done:       make(map[kafka.Offset]bool),
+			dropped:    make(map[kafka.Offset]bool),
+			next:       kafka.OffsetInvalid,
+		}
+		t.partitions[key] = p
+	}
+	p.inFlight = append(p.inFlight, tp.Offset)
+	return p.generation
+}
+
+// current returns the partition when it is tracked under the generation.
+// The caller must hold t.mu.
+func (t *offsetTracker) current(tp kafka.TopicPartition, generation uint64) (*partitionOffsets, bool) {
+	p, ok := t.partitions[keyOf(tp)]
+	if !ok || p.generation != generation {
+		return nil, false
+	}
+	return p, true
+}
+
+// processed marks the offset as handled and returns the offset that can be
+// stored for the partition, which is one past the highest offset whose
+// predecessors have all been processed. ok is false when the committable
+// offset did not move or the message is of an earlier generation.
+func (t *offsetTracker) processed(tp kafka.TopicPartition, generation uint64) (next kafka.Offset, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, found := t.current(tp, generation)
+	if !found {
+		return kafka.OffsetInvalid, false
+	}
+	p.done[tp.Offset] = true
+
+	advanced := false
+	for len(p.inFlight) > 0 && p.done[p.inFlight[0]] {
+		delete(p.done, p.inFlight[0])
+		p.next = p.inFlight[0] + 1
+		p.inFlight = p.inFlight[1:]
+		advanced = true
+	}
+	return p.next, advanced
+}
+
+// revoke flags the partitions so that queued messages for them are dropped
+// instead of processed.
+func (t *offsetTracker) revoke(tps []kafka.TopicPartition) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, tp := range tps {
+		if p, ok := t.partitions[keyOf(tp)]; ok {
+			p.revoked = true
+		}
+	}
+}
+
+// store calls storeOffsets with the offset while the partition is still
+// tracked under the generation, and holds the tracker until it returns so
+// that the partition cannot be removed in between. stored is false when the
+// partition was revoked or assigned again since.
+func (t *offsetTracker) store(tp kafka.TopicPartition, generation uint64, storeOffsets func([]kafka.TopicPartition) ([]kafka.TopicPartition, error)) (stored bool, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.current(tp, generation); !ok {
+		return false, nil
+	}
+	if _, err := storeOffsets([]kafka.TopicPartition{tp}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// isRevoked reports whether a message for the partition should be dropped,
+// either because the partition is being revoked, is no longer tracked or was
+// assigned again since the message was dispatched.
+func (t *offsetTracker) isRevoked(tp kafka.TopicPartition, generation uint64) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.current(tp, generation)
+	return !ok || p.revoked
+}
+
+// drop marks the offset as never to be processed. Messages of an earlier
+// generation are not tracked anymore and are ignored.
+func (t *offsetTracker) drop(tp kafka.TopicPartition, generation uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if p, ok := t.current(tp, generation); ok {
+		p.dropped[tp.Offset] = true
+	}
+}
+
+// drained reports whether every dispatched message of the partitions has
+// either been processed or dropped.
+func (t *offsetTracker) drained(tps []kafka.TopicPartition) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, tp := range tps {
+		p, ok := t.partitions[keyOf(tp)]
+		if !ok {
+			continue
+		}
+		for _, off := range p.inFlight {
+			if !p.done[off] && !p.dropped[off] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// remove stops tracking the partitions and returns the offsets to commit for
+// those that have processed at least one message.
+func (t *offsetTracker) remove(tps []kafka.TopicPartition) []kafka.TopicPartition {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	offsets := []kafka.TopicPartition{}
+	for _, tp := range tps {
+		key := keyOf(tp)
+		p, ok := t.partitions[key]
+		if !ok {
+			continue
+		}
+		delete(t.partitions, key)
+
+		if p.next != kafka.OffsetInvalid {
+			offsets = append(offsets, kafka.TopicPartition{
+				Topic:     tp.Topic,
+				Partition: tp.Partition,
+				Offset:    p.next,
+			})
+		}
+	}
+	return offsets
+}
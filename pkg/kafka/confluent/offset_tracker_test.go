@@ -0,0 +1,249 @@
+package confluent
+
+import (
+	"testing"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+func topicPartition(topic string, partition int32, offset kafka.Offset) kafka.TopicPartition {
+	return kafka.TopicPartition{Topic: &topic, Partition: partition, Offset: offset}
+}
+
+// TestOffsetTracker_InOrder tests that processing in order advances every time
+func TestOffsetTracker_InOrder(t *testing.T) {
+	tracker := newOffsetTracker()
+
+	var generation uint64
+	for off := kafka.Offset(10); off < 13; off++ {
+		generation = tracker.dispatched(topicPartition("test-topic", 0, off))
+	}
+
+	for off := kafka.Offset(10); off < 13; off++ {
+		next, ok := tracker.processed(topicPartition("test-topic", 0, off), generation)
+		if !ok {
+			t.Fatalf("Expected offset %d to advance the partition", off)
+		}
+		if next != off+1 {
+			t.Errorf("Expected next offset %d, got %d", off+1, next)
+		}
+	}
+}
+
+// TestOffsetTracker_OutOfOrder tests that a gap holds back the committable offset
+func TestOffsetTracker_OutOfOrder(t *testing.T) {
+	tracker := newOffsetTracker()
+
+	var generation uint64
+	for off := kafka.Offset(0); off < 3; off++ {
+		generation = tracker.dispatched(topicPartition("test-topic", 0, off))
+	}
+
+	if _, ok := tracker.processed(topicPartition("test-topic", 0, 2), generation); ok {
+		t.Error("Expected offset 2 not to advance while 0 and 1 are in flight")
+	}
+	if _, ok := tracker.processed(topicPartition("test-topic", 0, 1), generation); ok {
+		t.Error("Expected offset 1 not to advance while 0 is in flight")
+	}
+
+	next, ok := tracker.processed(topicPartition("test-topic", 0, 0), generation)
+	if !ok {
+		t.Fatal("Expected offset 0 to advance the partition")
+	}
+	if next != 3 {
+		t.Errorf("Expected next offset 3, got %d", next)
+	}
+}
+
+// TestOffsetTracker_NonContiguousOffsets tests offsets with gaps such as compacted topics
+func TestOffsetTracker_NonContiguousOffsets(t *testing.T) {
+	tracker := newOffsetTracker()
+
+	generation := tracker.dispatched(topicPartition("test-topic", 0, 5))
+	tracker.dispatched(topicPartition("test-topic", 0, 9))
+
+	next, ok := tracker.processed(topicPartition("test-topic", 0, 5), generation)
+	if !ok || next != 6 {
+		t.Errorf("Expected next offset 6, got %d (advanced=%v)", next, ok)
+	}
+
+	next, ok = tracker.processed(topicPartition("test-topic", 0, 9), generation)
+	if !ok || next != 10 {
+		t.Errorf("Expected next offset 10, got %d (advanced=%v)", next, ok)
+	}
+}
+
+// TestOffsetTracker_PartitionsAreIndependent tests that partitions do not block each other
+func TestOffsetTracker_PartitionsAreIndependent(t *testing.T) {
+	tracker := newOffsetTracker()
+
+	tracker.dispatched(topicPartition("test-topic", 0, 0))
+	partition1 := tracker.dispatched(topicPartition("test-topic", 1, 0))
+	otherTopic := tracker.dispatched(topicPartition("other-topic", 0, 0))
+
+	next, ok := tracker.processed(topicPartition("test-topic", 1, 0), partition1)
+	if !ok || next != 1 {
+		t.Errorf("Expected partition 1 to advance to 1, got %d (advanced=%v)", next, ok)
+	}
+
+	next, ok = tracker.processed(topicPartition("other-topic", 0, 0), otherTopic)
+	if !ok || next != 1 {
+		t.Errorf("Expected other-topic to advance to 1, got %d (advanced=%v)", next, ok)
+	}
+}
+
+// TestOffsetTracker_UnknownPartition tests processing an offset that was never dispatched
+func TestOffsetTracker_UnknownPartition(t *testing.T) {
+	tracker := newOffsetTracker()
+
+	next, ok := tracker.processed(topicPartition("test-topic", 0, 0), 1)
+	if ok {
+		t.Error("Expected unknown partition not to advance")
+	}
+	if next != kafka.OffsetInvalid {
+		t.Errorf("Expected OffsetInvalid, got %d", next)
+	}
+}
+
+// TestOffsetTracker_RevokeDropsQueuedMessages tests that revoked partitions drop queued messages
+func TestOffsetTracker_RevokeDropsQueuedMessages(t *testing.T) {
+	tracker := newOffsetTracker()
+	revoked := []kafka.TopicPartition{topicPartition("test-topic", 0, kafka.OffsetInvalid)}
+
+	var generation uint64
+	for off := kafka.Offset(0); off < 3; off++ {
+		generation = tracker.dispatched(topicPartition("test-topic", 0, off))
+	}
+	partition1 := tracker.dispatched(topicPartition("test-topic", 1, 0))
+
+	if tracker.isRevoked(topicPartition("test-topic", 0, 0), generation) {
+		t.Fatal("Expected partition 0 not to be revoked yet")
+	}
+
+	tracker.revoke(revoked)
+
+	if !tracker.isRevoked(topicPartition("test-topic", 0, 1), generation) {
+		t.Error("Expected partition 0 to be revoked")
+	}
+	if tracker.isRevoked(topicPartition("test-topic", 1, 0), partition1) {
+		t.Error("Expected partition 1 not to be revoked")
+	}
+
+	tracker.processed(topicPartition("test-topic", 0, 0), generation)
+	if tracker.drained(revoked) {
+		t.Error("Expected partition 0 not to be drained while offsets 1 and 2 are queued")
+	}
+
+	tracker.drop(topicPartition("test-topic", 0, 1), generation)
+	tracker.processed(topicPartition("test-topic", 0, 2), generation)
+	if !tracker.drained(revoked) {
+		t.Error("Expected partition 0 to be drained")
+	}
+
+	offsets := tracker.remove(revoked)
+	if len(offsets) != 1 {
+		t.Fatalf("Expected 1 offset to commit, got %d", len(offsets))
+	}
+	if offsets[0].Offset != 1 {
+		t.Errorf("Expected commit offset 1 before the dropped message, got %d", offsets[0].Offset)
+	}
+
+	if !tracker.isRevoked(topicPartition("test-topic", 0, 3), generation) {
+		t.Error("Expected removed partition to be treated as revoked")
+	}
+}
+
+// TestOffsetTracker_RemoveWithoutProgress tests removing a partition that processed nothing
+func TestOffsetTracker_RemoveWithoutProgress(t *testing.T) {
+	tracker := newOffsetTracker()
+	partitions := []kafka.TopicPartition{topicPartition("test-topic", 0, kafka.OffsetInvalid)}
+
+	generation := tracker.dispatched(topicPartition("test-topic", 0, 0))
+	tracker.revoke(partitions)
+	tracker.drop(topicPartition("test-topic", 0, 0), generation)
+
+	if offsets := tracker.remove(partitions); len(offsets) != 0 {
+		t.Errorf("Expected no offsets to commit, got %v", offsets)
+	}
+}
+
+// TestOffsetTracker_DrainedUnknownPartition tests draining partitions that were never dispatched
+func TestOffsetTracker_DrainedUnknownPartition(t *testing.T) {
+	tracker := newOffsetTracker()
+
+	if !tracker.drained([]kafka.TopicPartition{topicPartition("test-topic", 5, kafka.OffsetInvalid)}) {
+		t.Error("Expected unknown partition to be drained")
+	}
+}
+
+// TestOffsetTracker_Reassigned tests that messages queued before a partition
+// was revoked and assigned again are dropped and never acked against the new
+// assignment
+func TestOffsetTracker_Reassigned(t *testing.T) {
+	tracker := newOffsetTracker()
+	partitions := []kafka.TopicPartition{topicPartition("test-topic", 0, kafka.OffsetInvalid)}
+
+	stale := tracker.dispatched(topicPartition("test-topic", 0, 0))
+	tracker.dispatched(topicPartition("test-topic", 0, 1))
+	tracker.revoke(partitions)
+	tracker.remove(partitions)
+
+	generation := tracker.dispatched(topicPartition("test-topic", 0, 1))
+	if generation == stale {
+		t.Fatal("Expected the new assignment to have a new generation")
+	}
+
+	if !tracker.isRevoked(topicPartition("test-topic", 0, 1), stale) {
+		t.Error("Expected the message of the earlier assignment to be dropped")
+	}
+	if tracker.isRevoked(topicPartition("test-topic", 0, 1), generation) {
+		t.Error("Expected the message of the new assignment not to be dropped")
+	}
+
+	tracker.drop(topicPartition("test-topic", 0, 1), stale)
+	if tracker.drained(partitions) {
+		t.Error("Expected the stale drop not to drop the message of the new assignment")
+	}
+	if _, ok := tracker.processed(topicPartition("test-topic", 0, 1), stale); ok {
+		t.Error("Expected the stale ack not to advance the new assignment")
+	}
+
+	next, ok := tracker.processed(topicPartition("test-topic", 0, 1), generation)
+	if !ok || next != 2 {
+		t.Errorf("Expected next offset 2, got %d (advanced=%v)", next, ok)
+	}
+}
+
+// TestOffsetTracker_Store tests that offsets are only stored for the current
+// assignment of the partition
+func TestOffsetTracker_Store(t *testing.T) {
+	tracker := newOffsetTracker()
+	partitions := []kafka.TopicPartition{topicPartition("test-topic", 0, kafka.OffsetInvalid)}
+
+	var stored []kafka.TopicPartition
+	storeOffsets := func(offsets []kafka.TopicPartition) ([]kafka.TopicPartition, error) {
+		stored = append(stored, offsets...)
+		return offsets, nil
+	}
+
+	generation := tracker.dispatched(topicPartition("test-topic", 0, 0))
+	tracker.processed(topicPartition("test-topic", 0, 0), generation)
+	if ok, err := tracker.store(topicPartition("test-topic", 0, 1), generation, storeOffsets); !ok || err != nil {
+		t.Fatalf("Expected the offset to be stored, got %v, %v", ok, err)
+	}
+
+	tracker.revoke(partitions)
+	tracker.remove(partitions)
+	if ok, _ := tracker.store(topicPartition("test-topic", 0, 1), generation, storeOffsets); ok {
+		t.Error("Expected no offset to be stored for a removed partition")
+	}
+
+	tracker.dispatched(topicPartition("test-topic", 0, 1))
+	if ok, _ := tracker.store(topicPartition("test-topic", 0, 1), generation, storeOffsets); ok {
+		t.Error("Expected no offset to be stored for an earlier assignment")
+	}
+
+	if len(stored) != 1 {
+		t.Errorf("Expected 1 stored offset, got %v", stored)
+	}
+}
@@ -0,0 +1,46 @@
+package confluent
+
+import (
+	"strings"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+	music_kafka "music-service/pkg/kafka"
+)
+
+// setSecurity maps the TLS and SASL settings onto librdkafka properties.
+func setSecurity(configMap kafka.ConfigMap, cfg music_kafka.SecurityConfig) error {
+	if err := cfg.SASL.Validate(); err != nil {
+		return err
+	}
+
+	configMap["security.protocol"] = cfg.Protocol()
+
+	if cfg.TLS.Enabled {
+		if cfg.TLS.CAFile != "" {
+			configMap["ssl.ca.location"] = cfg.TLS.CAFile
+		}
+		if cfg.TLS.CertFile != "" {
+			configMap["ssl.certificate.location"] = cfg.TLS.CertFile
+		}
+		if cfg.TLS.KeyFile != "" {
+			configMap["ssl.key.location"] = cfg.TLS.KeyFile
+		}
+		if cfg.TLS.InsecureSkipVerify {
+			configMap["enable.ssl.certificate.verification"] = false
+		}
+	}
+
+	if cfg.SASL.Enabled() {
+		password, err := cfg.SASL.LoadPassword()
+		if err != nil {
+			return err
+		}
+
+		configMap["sasl.mechanisms"] = strings.ToUpper(cfg.SASL.Mechanism)
+		configMap["sasl.username"] = cfg.SASL.Username
+		configMap["sasl.password"] = password
+	}
+
+	return nil
+}
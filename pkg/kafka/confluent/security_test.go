@@ -0,0 +1,70 @@
+package confluent
+
+import (
+	"testing"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+
+	music_kafka "music-service/pkg/kafka"
+)
+
+// TestSetSecurity_Plaintext tests that no credentials are set without security settings
+func TestSetSecurity_Plaintext(t *testing.T) {
+	configMap := kafka.ConfigMap{}
+
+	if err := setSecurity(configMap, music_kafka.SecurityConfig{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if configMap["security.protocol"] != "PLAINTEXT" {
+		t.Errorf("Expected PLAINTEXT, got %v", configMap["security.protocol"])
+	}
+	if _, ok := configMap["sasl.username"]; ok {
+		t.Error("Expected no SASL username")
+	}
+}
+
+// TestSetSecurity_SASLSSL tests mapping TLS and SCRAM onto librdkafka properties
+func TestSetSecurity_SASLSSL(t *testing.T) {
+	configMap := kafka.ConfigMap{}
+
+	err := setSecurity(configMap, music_kafka.SecurityConfig{
+		TLS: music_kafka.TLSConfig{
+			Enabled:            true,
+			CAFile:             "/etc/kafka/ca.pem",
+			InsecureSkipVerify: true,
+		},
+		SASL: music_kafka.SASLConfig{
+			Mechanism: "scram-sha-512",
+			Username:  "user",
+			Password:  "secret",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := kafka.ConfigMap{
+		"security.protocol":                   "SASL_SSL",
+		"ssl.ca.location":                     "/etc/kafka/ca.pem",
+		"enable.ssl.certificate.verification": false,
+		"sasl.mechanisms":                     "SCRAM-SHA-512",
+		"sasl.username":                       "user",
+		"sasl.password":                       "secret",
+	}
+	for key, value := range want {
+		if configMap[key] != value {
+			t.Errorf("Expected %s=%v, got %v", key, value, configMap[key])
+		}
+	}
+}
+
+// TestSetSecurity_InvalidMechanism tests that unsupported mechanisms are rejected
+func TestSetSecurity_InvalidMechanism(t *testing.T) {
+	err := setSecurity(kafka.ConfigMap{}, music_kafka.SecurityConfig{
+		SASL: music_kafka.SASLConfig{Mechanism: "GSSAPI", Username: "user"},
+	})
+	if err == nil {
+		t.Error("Expected error for unsupported mechanism")
+	}
+}
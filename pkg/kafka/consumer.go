@@ -5,5 +5,6 @@ import (
 )
 
 type ConsumerHandler interface {
+	ConsumerController
 	Consume(ctx context.Context) error
 }
@@ -0,0 +1,56 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+var ErrConsumerNotRunning = errors.New("consumer is not running")
+
+type PartitionStatus struct {
+	Topic         string `json:"topic"`
+	Partition     int32  `json:"partition"`
+	Offset        int64  `json:"offset"`
+	HighWatermark int64  `json:"high_watermark"`
+	Lag           int64  `json:"lag"`
+	Paused        bool   `json:"paused"`
+}
+
+// OffsetReset moves the consumer group position of the selected partitions.
+// An empty Topic selects every assigned partition and empty Partitions selects
+// every assigned partition of Topic. When Timestamp is set it takes precedence
+// over Offset and resolves to the first offset at or after it. Negative
+// offsets follow the Kafka convention of -1 for the end and -2 for the
+// beginning of the partition.
+type OffsetReset struct {
+	Topic      string    `json:"topic"`
+	Partitions []int32   `json:"partitions"`
+	Offset     int64     `json:"offset"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+type ConsumerController interface {
+	Pause(topic string, partitions []int32) error
+	Resume(topic string, partitions []int32) error
+	Status(ctx context.Context) ([]PartitionStatus, error)
+	ResetOffsets(ctx context.Context, reset OffsetReset) error
+}
+
+// SelectPartitions filters the assignment down to the requested topic and
+// partitions using the same rules as OffsetReset.
+func SelectPartitions(assignment map[string][]int32, topic string, partitions []int32) map[string][]int32 {
+	selected := make(map[string][]int32)
+	for t, assigned := range assignment {
+		if topic != "" && t != topic {
+			continue
+		}
+		for _, p := range assigned {
+			if len(partitions) == 0 || slices.Contains(partitions, p) {
+				selected[t] = append(selected[t], p)
+			}
+		}
+	}
+	return selected
+}
@@ -0,0 +1,58 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectPartitions(t *testing.T) {
+	assignment := map[string][]int32{
+		"topic1": {0, 1, 2},
+		"topic2": {0, 3},
+	}
+
+	tests := []struct {
+		name       string
+		topic      string
+		partitions []int32
+		want       map[string][]int32
+	}{
+		{
+			name:  "empty topic selects everything",
+			topic: "",
+			want:  assignment,
+		},
+		{
+			name:  "topic selects all of its partitions",
+			topic: "topic1",
+			want:  map[string][]int32{"topic1": {0, 1, 2}},
+		},
+		{
+			name:       "topic and partitions",
+			topic:      "topic1",
+			partitions: []int32{1, 2, 7},
+			want:       map[string][]int32{"topic1": {1, 2}},
+		},
+		{
+			name:       "partitions across topics",
+			partitions: []int32{0},
+			want:       map[string][]int32{"topic1": {0}, "topic2": {0}},
+		},
+		{
+			name:  "unknown topic",
+			topic: "topic3",
+			want:  map[string][]int32{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SelectPartitions(assignment, tt.topic, tt.partitions))
+		})
+	}
+}
+
+func TestSelectPartitions_EmptyAssignment(t *testing.T) {
+	assert.Empty(t, SelectPartitions(nil, "", nil))
+}
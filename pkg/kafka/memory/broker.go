@@ -0,0 +1,356 @@
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"sync"
+	"time"
+)
+
+const DefaultPartitions = 3
+
+// ErrStaleGeneration is returned when a member commits for a partition it no
+// longer owns because the group rebalanced in the meantime.
+var ErrStaleGeneration = errors.New("member generation is stale")
+
+type Message struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   map[string]string
+	Timestamp time.Time
+}
+
+type partitionKey struct {
+	topic     string
+	partition int32
+}
+
+type group struct {
+	offsets    map[partitionKey]int64
+	members    []*Member
+	generation int
+}
+
+// Broker is an in-process stand-in for a Kafka cluster. Topics are created on
+// first use with the default number of partitions and consumer groups are
+// rebalanced whenever a member joins or leaves.
+type Broker struct {
+	mu         sync.Mutex
+	partitions int
+	topics     map[string][][]Message
+	groups     map[string]*group
+	changed    chan struct{}
+	nextMember int
+}
+
+func NewBroker(partitions int) *Broker {
+	return &Broker{
+		partitions: max(partitions, 1),
+		topics:     make(map[string][][]Message),
+		groups:     make(map[string]*group),
+		changed:    make(chan struct{}),
+	}
+}
+
+var (
+	defaultBroker     *Broker
+	defaultBrokerOnce sync.Once
+)
+
+// DefaultBroker returns the broker shared by the producers and consumers of
+// the process.
+func DefaultBroker() *Broker {
+	defaultBrokerOnce.Do(func() {
+		defaultBroker = NewBroker(DefaultPartitions)
+	})
+	return defaultBroker
+}
+
+// CreateTopic creates the topic with the given number of partitions. It is a
+// no-op when the topic exists.
+func (b *Broker) CreateTopic(topic string, partitions int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.createTopic(topic, partitions)
+}
+
+func (b *Broker) createTopic(topic string, partitions int) [][]Message {
+	log, ok := b.topics[topic]
+	if !ok {
+		log = make([][]Message, max(partitions, 1))
+		b.topics[topic] = log
+		b.rebalanceSubscribers(topic)
+	}
+	return log
+}
+
+// Produce appends the message to the partition chosen by hashing the key, or
+// to partition 0 when there is no key.
+func (b *Broker) Produce(topic string, key, value []byte, headers map[string]string) (int32, int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	log := b.createTopic(topic, b.partitions)
+
+	partition := int32(0)
+	if len(key) > 0 {
+		h := fnv.New32a()
+		h.Write(key)
+		partition = int32(h.Sum32() % uint32(len(log)))
+	}
+
+	offset := int64(len(log[partition]))
+	log[partition] = append(log[partition], Message{
+		Topic:     topic,
+		Partition: partition,
+		Offset:    offset,
+		Key:       key,
+		Value:     value,
+		Headers:   headers,
+		Timestamp: time.Now(),
+	})
+
+	b.notify()
+	return partition, offset
+}
+
+// Fetch returns the message at the offset, or false when the offset is past
+// the end of the partition.
+func (b *Broker) Fetch(topic string, partition int32, offset int64) (Message, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	log, ok := b.topics[topic]
+	if !ok || int(partition) >= len(log) || offset < 0 || offset >= int64(len(log[partition])) {
+		return Message{}, false
+	}
+	return log[partition][offset], true
+}
+
+// HighWatermark returns the offset of the next message of the partition.
+func (b *Broker) HighWatermark(topic string, partition int32) (int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	log, ok := b.topics[topic]
+	if !ok || int(partition) >= len(log) {
+		return 0, fmt.Errorf("unknown partition %s[%d]", topic, partition)
+	}
+	return int64(len(log[partition])), nil
+}
+
+// OffsetForTime returns the offset of the first message of the partition
+// produced at or after the timestamp, or the high watermark when there is none.
+func (b *Broker) OffsetForTime(topic string, partition int32, timestamp time.Time) (int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	log, ok := b.topics[topic]
+	if !ok || int(partition) >= len(log) {
+		return 0, fmt.Errorf("unknown partition %s[%d]", topic, partition)
+	}
+
+	messages := log[partition]
+	offset, _ := slices.BinarySearchFunc(messages, timestamp, func(m Message, t time.Time) int {
+		return m.Timestamp.Compare(t)
+	})
+	return int64(offset), nil
+}
+
+// Committed returns the committed offset of the group for the partition.
+func (b *Broker) Committed(groupID, topic string, partition int32) (int64, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	g, ok := b.groups[groupID]
+	if !ok {
+		return 0, false
+	}
+	offset, ok := g.offsets[partitionKey{topic: topic, partition: partition}]
+	return offset, ok
+}
+
+// Join adds a member subscribed to the topics to the group and rebalances it.
+func (b *Broker) Join(groupID string, topics []string) *Member {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, topic := range topics {
+		b.createTopic(topic, b.partitions)
+	}
+
+	g, ok := b.groups[groupID]
+	if !ok {
+		g = &group{offsets: make(map[partitionKey]int64)}
+		b.groups[groupID] = g
+	}
+
+	b.nextMember++
+	member := &Member{
+		broker: b,
+		group:  groupID,
+		id:     fmt.Sprintf("%s-%d", groupID, b.nextMember),
+		topics: topics,
+	}
+	g.members = append(g.members, member)
+	b.rebalance(g)
+	return member
+}
+
+// Rebalance forces a new generation of the group, as if a member had timed out
+// and rejoined. Partitions are reassigned and uncommitted work is fenced.
+func (b *Broker) Rebalance(groupID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if g, ok := b.groups[groupID]; ok {
+		b.rebalance(g)
+	}
+}
+
+// Changed returns a channel that is closed on the next produce, commit or
+// rebalance, so consumers can wait for work instead of polling.
+func (b *Broker) Changed() <-chan struct{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.changed
+}
+
+func (b *Broker) notify() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+func (b *Broker) rebalanceSubscribers(topic string) {
+	for _, g := range b.groups {
+		for _, m := range g.members {
+			if slices.Contains(m.topics, topic) {
+				b.rebalance(g)
+				break
+			}
+		}
+	}
+}
+
+// rebalance assigns the partitions of the subscribed topics round robin over
+// the members subscribed to them, in join order.
+func (b *Broker) rebalance(g *group) {
+	g.generation++
+
+	for _, m := range g.members {
+		m.generation = g.generation
+		m.assignment = make(map[string][]int32)
+	}
+
+	topics := []string{}
+	for _, m := range g.members {
+		for _, topic := range m.topics {
+			if !slices.Contains(topics, topic) {
+				topics = append(topics, topic)
+			}
+		}
+	}
+	slices.Sort(topics)
+
+	for _, topic := range topics {
+		subscribers := []*Member{}
+		for _, m := range g.members {
+			if slices.Contains(m.topics, topic) {
+				subscribers = append(subscribers, m)
+			}
+		}
+
+		for p := range b.topics[topic] {
+			m := subscribers[p%len(subscribers)]
+			m.assignment[topic] = append(m.assignment[topic], int32(p))
+		}
+	}
+
+	b.notify()
+}
+
+// Member is a consumer group member. Its assignment is replaced on every
+// rebalance of the group.
+type Member struct {
+	broker     *Broker
+	group      string
+	id         string
+	topics     []string
+	assignment map[string][]int32
+	generation int
+}
+
+func (m *Member) ID() string {
+	return m.id
+}
+
+// Assignment returns a copy of the partitions currently owned by the member
+// and the generation they were assigned in.
+func (m *Member) Assignment() (map[string][]int32, int) {
+	m.broker.mu.Lock()
+	defer m.broker.mu.Unlock()
+
+	assignment := make(map[string][]int32, len(m.assignment))
+	for topic, partitions := range m.assignment {
+		assignment[topic] = slices.Clone(partitions)
+	}
+	return assignment, m.generation
+}
+
+// Commit stores the offset for the partition. The commit is rejected when the
+// group rebalanced since generation or the member does not own the partition.
+func (m *Member) Commit(generation int, topic string, partition int32, offset int64) error {
+	m.broker.mu.Lock()
+	defer m.broker.mu.Unlock()
+
+	g, ok := m.broker.groups[m.group]
+	if !ok || generation != g.generation || !slices.Contains(m.assignment[topic], partition) {
+		return ErrStaleGeneration
+	}
+
+	g.offsets[partitionKey{topic: topic, partition: partition}] = offset
+	m.broker.notify()
+	return nil
+}
+
+// Advance commits the offset following a processed message, unless the
+// committed offset was moved away from the message in the meantime, e.g. by
+// an offset reset. It reports whether the commit was applied.
+func (m *Member) Advance(generation int, msg Message) (bool, error) {
+	m.broker.mu.Lock()
+	defer m.broker.mu.Unlock()
+
+	g, ok := m.broker.groups[m.group]
+	if !ok || generation != g.generation || !slices.Contains(m.assignment[msg.Topic], msg.Partition) {
+		return false, ErrStaleGeneration
+	}
+
+	key := partitionKey{topic: msg.Topic, partition: msg.Partition}
+	if offset, ok := g.offsets[key]; ok && offset != msg.Offset {
+		return false, nil
+	}
+
+	g.offsets[key] = msg.Offset + 1
+	m.broker.notify()
+	return true, nil
+}
+
+// Leave removes the member from the group and rebalances the remaining members.
+func (m *Member) Leave() {
+	m.broker.mu.Lock()
+	defer m.broker.mu.Unlock()
+
+	g, ok := m.broker.groups[m.group]
+	if !ok {
+		return
+	}
+
+	g.members = slices.DeleteFunc(g.members, func(member *Member) bool { return member == m })
+	m.assignment = nil
+	m.broker.rebalance(g)
+}
@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestBroker_ProduceSameKeySamePartition tests that a key always maps to the same partition
+func TestBroker_ProduceSameKeySamePartition(t *testing.T) {
+	broker := NewBroker(4)
+
+	first, offset := broker.Produce("test-topic", []byte("42"), []byte("a"), nil)
+	if offset != 0 {
+		t.Errorf("Expected first offset 0, got %d", offset)
+	}
+
+	second, offset := broker.Produce("test-topic", []byte("42"), []byte("b"), nil)
+	if second != first {
+		t.Errorf("Expected partition %d for the same key, got %d", first, second)
+	}
+	if offset != 1 {
+		t.Errorf("Expected second offset 1, got %d", offset)
+	}
+
+	msg, ok := broker.Fetch("test-topic", first, 1)
+	if !ok || string(msg.Value) != "b" {
+		t.Errorf("Expected to fetch message b, got %q (found=%v)", msg.Value, ok)
+	}
+	if _, ok := broker.Fetch("test-topic", first, 2); ok {
+		t.Error("Expected no message past the high watermark")
+	}
+}
+
+// TestBroker_OffsetForTime tests resolving timestamps to offsets
+func TestBroker_OffsetForTime(t *testing.T) {
+	broker := NewBroker(1)
+
+	broker.Produce("test-topic", nil, []byte("old"), nil)
+	time.Sleep(time.Millisecond)
+	since := time.Now()
+	broker.Produce("test-topic", nil, []byte("new"), nil)
+
+	offset, err := broker.OffsetForTime("test-topic", 0, since)
+	if err != nil || offset != 1 {
+		t.Errorf("Expected offset 1, got %d (err=%v)", offset, err)
+	}
+
+	offset, err = broker.OffsetForTime("test-topic", 0, time.Now().Add(time.Hour))
+	if err != nil || offset != 2 {
+		t.Errorf("Expected high watermark 2, got %d (err=%v)", offset, err)
+	}
+
+	if _, err := broker.OffsetForTime("missing", 0, since); err == nil {
+		t.Error("Expected error for unknown topic")
+	}
+}
+
+// TestBroker_JoinRebalancesPartitions tests that joining and leaving members split the partitions
+func TestBroker_JoinRebalancesPartitions(t *testing.T) {
+	broker := NewBroker(4)
+
+	first := broker.Join("test-group", []string{"test-topic"})
+	assignment, generation := first.Assignment()
+	if len(assignment["test-topic"]) != 4 {
+		t.Fatalf("Expected single member to own 4 partitions, got %v", assignment)
+	}
+
+	second := broker.Join("test-group", []string{"test-topic"})
+	firstAssignment, firstGeneration := first.Assignment()
+	secondAssignment, _ := second.Assignment()
+	if len(firstAssignment["test-topic"]) != 2 || len(secondAssignment["test-topic"]) != 2 {
+		t.Errorf("Expected 2 partitions each, got %v and %v", firstAssignment, secondAssignment)
+	}
+	if firstGeneration <= generation {
+		t.Errorf("Expected generation to advance past %d, got %d", generation, firstGeneration)
+	}
+
+	second.Leave()
+	firstAssignment, _ = first.Assignment()
+	if len(firstAssignment["test-topic"]) != 4 {
+		t.Errorf("Expected remaining member to own 4 partitions, got %v", firstAssignment)
+	}
+}
+
+// TestMember_CommitFencedAfterRebalance tests that commits from an old generation are rejected
+func TestMember_CommitFencedAfterRebalance(t *testing.T) {
+	broker := NewBroker(1)
+	member := broker.Join("test-group", []string{"test-topic"})
+	_, generation := member.Assignment()
+
+	if err := member.Commit(generation, "test-topic", 0, 3); err != nil {
+		t.Fatalf("Expected commit to succeed, got %v", err)
+	}
+
+	broker.Rebalance("test-group")
+
+	if err := member.Commit(generation, "test-topic", 0, 5); !errors.Is(err, ErrStaleGeneration) {
+		t.Errorf("Expected ErrStaleGeneration, got %v", err)
+	}
+
+	offset, ok := broker.Committed("test-group", "test-topic", 0)
+	if !ok || offset != 3 {
+		t.Errorf("Expected committed offset 3, got %d (found=%v)", offset, ok)
+	}
+}
+
+// TestMember_AdvanceSkipsMovedOffset tests that processing does not overwrite an offset reset
+func TestMember_AdvanceSkipsMovedOffset(t *testing.T) {
+	broker := NewBroker(1)
+	broker.Produce("test-topic", nil, []byte("a"), nil)
+	member := broker.Join("test-group", []string{"test-topic"})
+	_, generation := member.Assignment()
+
+	msg, _ := broker.Fetch("test-topic", 0, 0)
+	if err := member.Commit(generation, "test-topic", 0, 0); err != nil {
+		t.Fatalf("Expected commit to succeed, got %v", err)
+	}
+
+	applied, err := member.Advance(generation, msg)
+	if err != nil || !applied {
+		t.Fatalf("Expected advance to apply, got %v (err=%v)", applied, err)
+	}
+
+	if err := member.Commit(generation, "test-topic", 0, 0); err != nil {
+		t.Fatalf("Expected reset to succeed, got %v", err)
+	}
+	msg.Offset = 5
+	applied, err = member.Advance(generation, msg)
+	if err != nil || applied {
+		t.Errorf("Expected advance to be skipped after reset, got %v (err=%v)", applied, err)
+	}
+}
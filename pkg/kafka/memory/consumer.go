@@ -0,0 +1,175 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	music_kafka "music-service/pkg/kafka"
+	"music-service/pkg/kafka/codec"
+	"music-service/pkg/kafka/message"
+	"music-service/pkg/kafka/metrics"
+)
+
+type consumer struct {
+	broker                *Broker
+	cfg                   music_kafka.Config
+	messageValueProcessor message.MessageValueProcessor
+
+	mu     sync.Mutex
+	member *Member
+	paused map[partitionKey]bool
+
+	// generation is the last assignment generation seen by poll.
+	generation int
+}
+
+func NewConsumer(broker *Broker, cfg music_kafka.Config, messageValueProcessor message.MessageValueProcessor) *consumer {
+	return &consumer{
+		broker:                broker,
+		cfg:                   cfg,
+		messageValueProcessor: messageValueProcessor,
+		paused:                make(map[partitionKey]bool),
+	}
+}
+
+// Consume joins the consumer group and processes the assigned partitions one
+// message at a time, committing after each message like the sarama consumer.
+// It returns when the context is done.
+func (c *consumer) Consume(ctx context.Context) error {
+	member := c.broker.Join(c.cfg.ConsumerGroup, strings.Split(c.cfg.Topics, ","))
+	log.Printf("member %s joined group %s", member.ID(), c.cfg.ConsumerGroup)
+
+	c.mu.Lock()
+	c.member = member
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		c.member = nil
+		clear(c.paused)
+		c.mu.Unlock()
+		member.Leave()
+	}()
+
+	for {
+		// A steady stream of messages never reaches the select below, so the
+		// context is checked before every poll.
+		if ctx.Err() != nil {
+			return nil
+		}
+		changed := c.broker.Changed()
+
+		processed, err := c.poll(member)
+		if err != nil {
+			return err
+		}
+		if processed {
+			continue
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// poll processes at most one message per assigned partition and reports
+// whether any message was processed.
+func (c *consumer) poll(member *Member) (bool, error) {
+	assignment, generation := member.Assignment()
+	if generation != c.generation {
+		metrics.Rebalanced(metrics.RebalanceAssigned)
+		c.generation = generation
+	}
+
+	processed := false
+	for topic, partitions := range assignment {
+		for _, partition := range partitions {
+			if c.isPaused(topic, partition) {
+				continue
+			}
+
+			offset, err := c.position(member, generation, topic, partition)
+			if err != nil {
+				return false, err
+			}
+
+			msg, ok := c.broker.Fetch(topic, partition, offset)
+			if !ok {
+				continue
+			}
+
+			log.Printf("Processing message from %s[%d]@%d", msg.Topic, msg.Partition, msg.Offset)
+			start := time.Now()
+			err = c.messageValueProcessor.Process(msg.Value, msg.Headers[codec.ContentTypeHeader], msg.Headers[message.PrincipalHeader])
+			metrics.ObserveMessage(msg.Topic, start, err)
+			processed = true
+
+			start = time.Now()
+			_, err = member.Advance(generation, msg)
+			metrics.ObserveCommit(start, err)
+			if err != nil {
+				if errors.Is(err, ErrStaleGeneration) {
+					return true, nil
+				}
+				return false, err
+			}
+		}
+	}
+	return processed, nil
+}
+
+// position returns the committed offset of the partition, initialising it
+// from the oldest setting when the group has not committed one yet.
+func (c *consumer) position(member *Member, generation int, topic string, partition int32) (int64, error) {
+	if offset, ok := c.broker.Committed(c.cfg.ConsumerGroup, topic, partition); ok {
+		return offset, nil
+	}
+
+	offset := int64(0)
+	if !c.cfg.Oldest {
+		high, err := c.broker.HighWatermark(topic, partition)
+		if err != nil {
+			return 0, err
+		}
+		offset = high
+	}
+
+	if err := member.Commit(generation, topic, partition, offset); err != nil && !errors.Is(err, ErrStaleGeneration) {
+		return 0, err
+	}
+	return offset, nil
+}
+
+func (c *consumer) isPaused(topic string, partition int32) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.paused[partitionKey{topic: topic, partition: partition}]
+}
+
+func (c *consumer) running() *Member {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.member
+}
+
+func (c *consumer) selectAssigned(topic string, partitions []int32) (*Member, map[string][]int32, int, error) {
+	member := c.running()
+	if member == nil {
+		return nil, nil, 0, music_kafka.ErrConsumerNotRunning
+	}
+
+	assignment, generation := member.Assignment()
+	selected := music_kafka.SelectPartitions(assignment, topic, partitions)
+	if len(selected) == 0 {
+		return nil, nil, 0, fmt.Errorf("no assigned partitions match topic %q partitions %v", topic, partitions)
+	}
+	return member, selected, generation, nil
+}
@@ -0,0 +1,174 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	music_kafka "music-service/pkg/kafka"
+)
+
+type recordingProcessor struct {
+	mu     sync.Mutex
+	values []string
+}
+
+func (p *recordingProcessor) Process(msg []byte, contentType, principal string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.values = append(p.values, string(msg))
+	return nil
+}
+
+func (p *recordingProcessor) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.values)
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func startConsumer(t *testing.T, broker *Broker, processor *recordingProcessor) *consumer {
+	t.Helper()
+	cfg := music_kafka.Config{Topics: "test-topic", ConsumerGroup: "test-group", Oldest: true}
+	c := NewConsumer(broker, cfg, processor)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.Consume(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	waitFor(t, func() bool { return c.running() != nil })
+	return c
+}
+
+// TestConsumer_ProcessesAndCommits tests that produced messages are processed and committed
+func TestConsumer_ProcessesAndCommits(t *testing.T) {
+	broker := NewBroker(2)
+	broker.Produce("test-topic", []byte("1"), []byte("before"), nil)
+
+	processor := &recordingProcessor{}
+	c := startConsumer(t, broker, processor)
+
+	broker.Produce("test-topic", []byte("2"), []byte("after"), nil)
+	waitFor(t, func() bool { return processor.count() == 2 })
+
+	statuses, err := c.Status(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, status := range statuses {
+		if status.Lag != 0 {
+			t.Errorf("Expected no lag on %s[%d], got %d", status.Topic, status.Partition, status.Lag)
+		}
+	}
+}
+
+// TestConsumer_StopsWhenCancelled tests that a consumer with messages left returns once the context is done
+func TestConsumer_StopsWhenCancelled(t *testing.T) {
+	broker := NewBroker(1)
+	for _, value := range []string{"a", "b", "c"} {
+		broker.Produce("test-topic", nil, []byte(value), nil)
+	}
+
+	processor := &recordingProcessor{}
+	cfg := music_kafka.Config{Topics: "test-topic", ConsumerGroup: "test-group", Oldest: true}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := NewConsumer(broker, cfg, processor).Consume(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if processor.count() != 0 {
+		t.Errorf("Expected no messages after the context was cancelled, got %d", processor.count())
+	}
+}
+
+// TestConsumer_PauseAndResume tests that paused partitions are not consumed until resumed
+func TestConsumer_PauseAndResume(t *testing.T) {
+	broker := NewBroker(1)
+	processor := &recordingProcessor{}
+	c := startConsumer(t, broker, processor)
+
+	if err := c.Pause("test-topic", nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	broker.Produce("test-topic", nil, []byte("a"), nil)
+	time.Sleep(50 * time.Millisecond)
+	if processor.count() != 0 {
+		t.Fatalf("Expected no messages while paused, got %d", processor.count())
+	}
+
+	statuses, _ := c.Status(context.Background())
+	if len(statuses) != 1 || !statuses[0].Paused || statuses[0].Lag != 1 {
+		t.Errorf("Expected paused partition with lag 1, got %+v", statuses)
+	}
+
+	if err := c.Resume("test-topic", nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	waitFor(t, func() bool { return processor.count() == 1 })
+}
+
+// TestConsumer_ResetOffsets tests replaying a partition from the beginning
+func TestConsumer_ResetOffsets(t *testing.T) {
+	broker := NewBroker(1)
+	processor := &recordingProcessor{}
+	c := startConsumer(t, broker, processor)
+
+	broker.Produce("test-topic", nil, []byte("a"), nil)
+	broker.Produce("test-topic", nil, []byte("b"), nil)
+	waitFor(t, func() bool { return processor.count() == 2 })
+
+	err := c.ResetOffsets(context.Background(), music_kafka.OffsetReset{Topic: "test-topic", Offset: -2})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	waitFor(t, func() bool { return processor.count() == 4 })
+}
+
+// TestConsumer_RebalanceSplitsWork tests that two consumers in a group share the partitions
+func TestConsumer_RebalanceSplitsWork(t *testing.T) {
+	broker := NewBroker(4)
+	first := &recordingProcessor{}
+	second := &recordingProcessor{}
+	startConsumer(t, broker, first)
+	startConsumer(t, broker, second)
+
+	for _, key := range []string{"1", "2", "3", "4", "5", "6", "7", "8"} {
+		broker.Produce("test-topic", []byte(key), []byte(key), nil)
+	}
+
+	waitFor(t, func() bool { return first.count()+second.count() == 8 })
+	if first.count() == 0 || second.count() == 0 {
+		t.Errorf("Expected both members to process messages, got %d and %d", first.count(), second.count())
+	}
+}
+
+// TestConsumer_NotRunning tests the control API before Consume is called
+func TestConsumer_NotRunning(t *testing.T) {
+	c := NewConsumer(NewBroker(1), music_kafka.Config{}, &recordingProcessor{})
+
+	if _, err := c.Status(context.Background()); err != music_kafka.ErrConsumerNotRunning {
+		t.Errorf("Expected ErrConsumerNotRunning, got %v", err)
+	}
+	if err := c.Pause("", nil); err != music_kafka.ErrConsumerNotRunning {
+		t.Errorf("Expected ErrConsumerNotRunning, got %v", err)
+	}
+}
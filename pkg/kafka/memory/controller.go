@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	music_kafka "music-service/pkg/kafka"
+)
+
+func (c *consumer) Pause(topic string, partitions []int32) error {
+	_, selected, _, err := c.selectAssigned(topic, partitions)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for t, ps := range selected {
+		for _, p := range ps {
+			c.paused[partitionKey{topic: t, partition: p}] = true
+		}
+	}
+	return nil
+}
+
+func (c *consumer) Resume(topic string, partitions []int32) error {
+	_, selected, _, err := c.selectAssigned(topic, partitions)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	for t, ps := range selected {
+		for _, p := range ps {
+			delete(c.paused, partitionKey{topic: t, partition: p})
+		}
+	}
+	c.mu.Unlock()
+
+	c.broker.mu.Lock()
+	c.broker.notify()
+	c.broker.mu.Unlock()
+	return nil
+}
+
+func (c *consumer) Status(ctx context.Context) ([]music_kafka.PartitionStatus, error) {
+	member := c.running()
+	if member == nil {
+		return nil, music_kafka.ErrConsumerNotRunning
+	}
+
+	assignment, _ := member.Assignment()
+
+	statuses := []music_kafka.PartitionStatus{}
+	for t, ps := range assignment {
+		for _, p := range ps {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			high, err := c.broker.HighWatermark(t, p)
+			if err != nil {
+				return nil, err
+			}
+
+			offset, ok := c.broker.Committed(c.cfg.ConsumerGroup, t, p)
+			if !ok {
+				offset = high
+			}
+
+			statuses = append(statuses, music_kafka.PartitionStatus{
+				Topic:         t,
+				Partition:     p,
+				Offset:        offset,
+				HighWatermark: high,
+				Lag:           max(high-offset, 0),
+				Paused:        c.isPaused(t, p),
+			})
+		}
+	}
+	return statuses, nil
+}
+
+// ResetOffsets commits the new position directly. The consume loop reads the
+// committed offset before every fetch, so it picks the reset up immediately.
+func (c *consumer) ResetOffsets(ctx context.Context, reset music_kafka.OffsetReset) error {
+	member, selected, generation, err := c.selectAssigned(reset.Topic, reset.Partitions)
+	if err != nil {
+		return err
+	}
+
+	for t, ps := range selected {
+		for _, p := range ps {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			offset, err := c.resolveOffset(t, p, reset)
+			if err != nil {
+				return fmt.Errorf("failed to resolve offset for %s[%d]: %w", t, p, err)
+			}
+
+			if err := member.Commit(generation, t, p, offset); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *consumer) resolveOffset(topic string, partition int32, reset music_kafka.OffsetReset) (int64, error) {
+	switch {
+	case !reset.Timestamp.IsZero():
+		return c.broker.OffsetForTime(topic, partition, reset.Timestamp)
+	case reset.Offset == -2:
+		return 0, nil
+	case reset.Offset < 0:
+		return c.broker.HighWatermark(topic, partition)
+	default:
+		return reset.Offset, nil
+	}
+}
@@ -0,0 +1,21 @@
+package message
+
+import (
+	"context"
+
+	"music-service/pkg/auth"
+)
+
+// PrincipalHeader carries the subject of the authenticated caller a message
+// was produced for, so consumers can record who made a change.
+const PrincipalHeader = "principal"
+
+// Principal returns the subject of the caller of the context, empty when the
+// context carries no identity.
+func Principal(ctx context.Context) string {
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	return identity.Subject
+}
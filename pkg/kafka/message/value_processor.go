@@ -1,5 +1,9 @@
 package message
 
 type MessageValueProcessor interface {
-	Process(msg []byte)
+	// Process handles a message value encoded as described by the content
+	// type header, which is empty for values produced without one, on behalf
+	// of the principal of the principal header, empty when it is missing. It
+	// returns an error for values that were skipped instead of stored.
+	Process(msg []byte, contentType, principal string) error
 }
@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"music-service/pkg/kafka"
+)
+
+const lagTimeout = 5 * time.Second
+
+var (
+	lagDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "lag"),
+		"Messages between the committed offset and the high watermark of each assigned partition.",
+		[]string{"topic", "partition"}, nil,
+	)
+	pausedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "partition_paused"),
+		"Whether consumption of each assigned partition is paused.",
+		[]string{"topic", "partition"}, nil,
+	)
+)
+
+// lagCollector reads the lag from the consumer status on every scrape, so
+// it reflects the assignment at the time of the scrape and needs no
+// bookkeeping in the consumers.
+type lagCollector struct {
+	controller kafka.ConsumerController
+}
+
+func NewLagCollector(controller kafka.ConsumerController) prometheus.Collector {
+	return &lagCollector{controller: controller}
+}
+
+func (c *lagCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- lagDesc
+	ch <- pausedDesc
+}
+
+func (c *lagCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), lagTimeout)
+	defer cancel()
+
+	statuses, err := c.controller.Status(ctx)
+	if err != nil {
+		if !errors.Is(err, kafka.ErrConsumerNotRunning) {
+			log.Printf("failed to collect consumer lag: %v", err)
+		}
+		return
+	}
+
+	for _, s := range statuses {
+		partition := strconv.Itoa(int(s.Partition))
+		ch <- prometheus.MustNewConstMetric(lagDesc, prometheus.GaugeValue, float64(s.Lag), s.Topic, partition)
+
+		paused := 0.0
+		if s.Paused {
+			paused = 1
+		}
+		ch <- prometheus.MustNewConstMetric(pausedDesc, prometheus.GaugeValue, paused, s.Topic, partition)
+	}
+}
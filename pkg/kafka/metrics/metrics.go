@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"music-service/pkg/kafka/codec"
+	"music-service/pkg/kafka/registry"
+)
+
+const namespace = "kafka_consumer"
+
+const (
+	ReasonIncompatibleSchema     = "incompatible_schema"
+	ReasonUnsupportedContentType = "unsupported_content_type"
+	ReasonProcessing             = "processing"
+)
+
+const (
+	RebalanceAssigned = "assigned"
+	RebalanceRevoked  = "revoked"
+)
+
+var (
+	messagesProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messages_processed_total",
+		Help:      "Messages processed successfully.",
+	}, []string{"topic"})
+
+	messagesFailed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messages_failed_total",
+		Help:      "Messages that failed to process, by reason.",
+	}, []string{"topic", "reason"})
+
+	processingDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "processing_duration_seconds",
+		Help:      "Time spent processing a message.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+	}, []string{"topic"})
+
+	commitDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "commit_duration_seconds",
+		Help:      "Time spent committing offsets, by result.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+	}, []string{"result"})
+
+	rebalances = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rebalances_total",
+		Help:      "Partition assignment changes, by event.",
+	}, []string{"event"})
+
+	workerQueueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "worker_queue_depth",
+		Help:      "Messages waiting in the queue of each worker.",
+	}, []string{"worker"})
+)
+
+// ObserveMessage records the outcome and duration of processing a message
+// that started at start.
+func ObserveMessage(topic string, start time.Time, err error) {
+	processingDuration.WithLabelValues(topic).Observe(time.Since(start).Seconds())
+	if err != nil {
+		messagesFailed.WithLabelValues(topic, Reason(err)).Inc()
+		return
+	}
+	messagesProcessed.WithLabelValues(topic).Inc()
+}
+
+// ObserveCommit records the latency of an offset commit that started at start.
+func ObserveCommit(start time.Time, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	commitDuration.WithLabelValues(result).Observe(time.Since(start).Seconds())
+}
+
+func Rebalanced(event string) {
+	rebalances.WithLabelValues(event).Inc()
+}
+
+func SetWorkerQueueDepth(worker int, depth int) {
+	workerQueueDepth.WithLabelValues(strconv.Itoa(worker)).Set(float64(depth))
+}
+
+// Reason classifies a processing error into a low cardinality label value.
+func Reason(err error) string {
+	switch {
+	case errors.Is(err, registry.ErrIncompatible):
+		return ReasonIncompatibleSchema
+	case errors.Is(err, codec.ErrUnsupportedContentType):
+		return ReasonUnsupportedContentType
+	default:
+		return ReasonProcessing
+	}
+}
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"music-service/pkg/kafka"
+	"music-service/pkg/kafka/codec"
+	"music-service/pkg/kafka/registry"
+)
+
+func TestReason(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{err: fmt.Errorf("%w: schema 3", registry.ErrIncompatible), want: ReasonIncompatibleSchema},
+		{err: fmt.Errorf("%w: %q", codec.ErrUnsupportedContentType, "text/xml"), want: ReasonUnsupportedContentType},
+		{err: errors.New("boom"), want: ReasonProcessing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, Reason(tt.err))
+		})
+	}
+}
+
+func TestObserveMessage(t *testing.T) {
+	processed := testutil.ToFloat64(messagesProcessed.WithLabelValues("metrics-topic"))
+	failed := testutil.ToFloat64(messagesFailed.WithLabelValues("metrics-topic", ReasonIncompatibleSchema))
+
+	ObserveMessage("metrics-topic", time.Now(), nil)
+	ObserveMessage("metrics-topic", time.Now(), nil)
+	ObserveMessage("metrics-topic", time.Now(), registry.ErrIncompatible)
+
+	assert.Equal(t, processed+2, testutil.ToFloat64(messagesProcessed.WithLabelValues("metrics-topic")))
+	assert.Equal(t, failed+1, testutil.ToFloat64(messagesFailed.WithLabelValues("metrics-topic", ReasonIncompatibleSchema)))
+}
+
+func TestSetWorkerQueueDepth(t *testing.T) {
+	SetWorkerQueueDepth(3, 42)
+	assert.Equal(t, 42.0, testutil.ToFloat64(workerQueueDepth.WithLabelValues("3")))
+}
+
+type statusController struct {
+	kafka.ConsumerController
+	statuses []kafka.PartitionStatus
+	err      error
+}
+
+func (c statusController) Status(ctx context.Context) ([]kafka.PartitionStatus, error) {
+	return c.statuses, c.err
+}
+
+func TestLagCollector(t *testing.T) {
+	collector := NewLagCollector(statusController{statuses: []kafka.PartitionStatus{
+		{Topic: "albums", Partition: 0, Offset: 10, HighWatermark: 15, Lag: 5},
+		{Topic: "albums", Partition: 1, Offset: 7, HighWatermark: 7, Paused: true},
+	}})
+
+	expected := `
+# HELP kafka_consumer_lag Messages between the committed offset and the high watermark of each assigned partition.
+# TYPE kafka_consumer_lag gauge
+kafka_consumer_lag{partition="0",topic="albums"} 5
+kafka_consumer_lag{partition="1",topic="albums"} 0
+# HELP kafka_consumer_partition_paused Whether consumption of each assigned partition is paused.
+# TYPE kafka_consumer_partition_paused gauge
+kafka_consumer_partition_paused{partition="0",topic="albums"} 0
+kafka_consumer_partition_paused{partition="1",topic="albums"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+}
+
+func TestLagCollector_NotRunning(t *testing.T) {
+	collector := NewLagCollector(statusController{err: kafka.ErrConsumerNotRunning})
+	assert.Equal(t, 0, testutil.CollectAndCount(collector))
+}
@@ -8,4 +8,21 @@ import (
 
 type ProducerHandler interface {
 	Produce(ctx context.Context, album *pb.Album)
+	// ProduceStockEvent publishes the event to the stock topic, keyed by
+	// album id. Failures are logged rather than raised since the stock has
+	// already changed, and events are dropped when no stock topic is set.
+	ProduceStockEvent(ctx context.Context, event *pb.StockEvent)
+	// ProduceOrderEvent publishes the event to the order topic, keyed by
+	// order id, like ProduceStockEvent.
+	ProduceOrderEvent(ctx context.Context, event *pb.OrderEvent)
+}
+
+// SnapshotProducer publishes the current state of albums, keyed by id, to the
+// compacted snapshot topic. A nil album publishes a tombstone for the id.
+// Published reads the topic and returns the ids of the albums in it, those
+// whose latest message is not a tombstone.
+type SnapshotProducer interface {
+	Publish(ctx context.Context, id int32, album *pb.Album) error
+	Published(ctx context.Context) ([]int32, error)
+	Close() error
 }
@@ -0,0 +1,148 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const contentType = "application/vnd.schemaregistry.v1+json"
+
+// Error codes of the Confluent schema registry API.
+const (
+	errorSubjectNotFound = 40401
+	errorVersionNotFound = 40402
+	errorSchemaNotFound  = 40403
+)
+
+type client struct {
+	baseUrl    string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// NewClient returns a client of the Confluent schema registry REST API.
+func NewClient(baseUrl, username, password string) Registry {
+	return &client{
+		baseUrl:    strings.TrimSuffix(baseUrl, "/"),
+		username:   username,
+		password:   password,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type apiError struct {
+	StatusCode int    `json:"-"`
+	ErrorCode  int    `json:"error_code"`
+	Message    string `json:"message"`
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("schema registry returned %d (%d): %s", e.StatusCode, e.ErrorCode, e.Message)
+}
+
+func (c *client) Register(ctx context.Context, subject string, schema Schema) (int, error) {
+	var response struct {
+		Id int `json:"id"`
+	}
+	err := c.do(ctx, http.MethodPost, "/subjects/"+url.PathEscape(subject)+"/versions", schema, &response)
+	if apiErr, ok := err.(*apiError); ok && apiErr.StatusCode == http.StatusConflict {
+		return 0, fmt.Errorf("%w: %s", ErrIncompatible, apiErr.Message)
+	}
+	return response.Id, err
+}
+
+func (c *client) Lookup(ctx context.Context, subject string, schema Schema) (int, error) {
+	var response struct {
+		Id int `json:"id"`
+	}
+	err := c.do(ctx, http.MethodPost, "/subjects/"+url.PathEscape(subject), schema, &response)
+	if isNotFound(err) {
+		return 0, fmt.Errorf("%w: subject %s", ErrNotFound, subject)
+	}
+	return response.Id, err
+}
+
+func (c *client) Compatible(ctx context.Context, subject string, schema Schema) (bool, error) {
+	var response struct {
+		IsCompatible bool `json:"is_compatible"`
+	}
+	err := c.do(ctx, http.MethodPost, "/compatibility/subjects/"+url.PathEscape(subject)+"/versions/latest", schema, &response)
+	if isNotFound(err) {
+		return true, nil
+	}
+	return response.IsCompatible, err
+}
+
+func (c *client) Schema(ctx context.Context, id int) (Schema, error) {
+	var schema Schema
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/schemas/ids/%d", id), nil, &schema)
+	if isNotFound(err) {
+		return Schema{}, fmt.Errorf("%w: id %d", ErrNotFound, id)
+	}
+	if err != nil {
+		return Schema{}, err
+	}
+	if schema.Type == "" {
+		// The registry omits the type of Avro schemas, its default.
+		schema.Type = "AVRO"
+	}
+	return schema, nil
+}
+
+func (c *client) do(ctx context.Context, method, path string, body any, response any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, c.baseUrl+path, reader)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", contentType)
+	if body != nil {
+		request.Header.Set("Content-Type", contentType)
+	}
+	if c.username != "" {
+		request.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiErr := &apiError{StatusCode: resp.StatusCode}
+		if err := json.NewDecoder(resp.Body).Decode(apiErr); err != nil {
+			apiErr.Message = resp.Status
+		}
+		return apiErr
+	}
+	return json.NewDecoder(resp.Body).Decode(response)
+}
+
+func isNotFound(err error) bool {
+	apiErr, ok := err.(*apiError)
+	if !ok {
+		return false
+	}
+	switch apiErr.ErrorCode {
+	case errorSubjectNotFound, errorVersionNotFound, errorSchemaNotFound:
+		return true
+	}
+	return apiErr.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,91 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /subjects/albums-value/versions", func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "user", user)
+		assert.Equal(t, "secret", password)
+
+		var schema Schema
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&schema))
+		if schema == albumV3 {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error_code": 409, "message": "Schema being registered is incompatible"}`))
+			return
+		}
+		w.Write([]byte(`{"id": 12}`))
+	})
+	mux.HandleFunc("POST /compatibility/subjects/albums-value/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"is_compatible": false}`))
+	})
+	mux.HandleFunc("POST /compatibility/subjects/new-value/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error_code": 40401, "message": "Subject not found"}`))
+	})
+	mux.HandleFunc("GET /schemas/ids/12", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"schemaType": "PROTOBUF", "schema": "message Album {}"}`))
+	})
+	mux.HandleFunc("GET /schemas/ids/13", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"schema": "{\"type\": \"record\"}"}`))
+	})
+	mux.HandleFunc("GET /schemas/ids/99", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error_code": 40403, "message": "Schema not found"}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_Register(t *testing.T) {
+	reg := NewClient(newTestServer(t).URL+"/", "user", "secret")
+
+	id, err := reg.Register(context.Background(), "albums-value", albumV1)
+	assert.NoError(t, err)
+	assert.Equal(t, 12, id)
+
+	_, err = reg.Register(context.Background(), "albums-value", albumV3)
+	assert.ErrorIs(t, err, ErrIncompatible)
+}
+
+func TestClient_Compatible(t *testing.T) {
+	reg := NewClient(newTestServer(t).URL, "", "")
+
+	compatible, err := reg.Compatible(context.Background(), "albums-value", albumV3)
+	assert.NoError(t, err)
+	assert.False(t, compatible)
+
+	compatible, err = reg.Compatible(context.Background(), "new-value", albumV3)
+	assert.NoError(t, err)
+	assert.True(t, compatible)
+}
+
+func TestClient_Schema(t *testing.T) {
+	reg := NewClient(newTestServer(t).URL, "", "")
+
+	schema, err := reg.Schema(context.Background(), 12)
+	assert.NoError(t, err)
+	assert.Equal(t, Schema{Type: SchemaTypeProtobuf, Schema: "message Album {}"}, schema)
+
+	schema, err = reg.Schema(context.Background(), 13)
+	assert.NoError(t, err)
+	assert.Equal(t, "AVRO", schema.Type)
+
+	_, err = reg.Schema(context.Background(), 99)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,195 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+type registeredSchema struct {
+	Id      int    `json:"id"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+	Schema
+}
+
+// fileRegistry is a schema registry stand-in that keeps the schemas in a JSON
+// file, so producers and consumers on the same machine share the ids without
+// a registry server. The file is re-read on every call.
+type fileRegistry struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileRegistry(path string) Registry {
+	return &fileRegistry{path: path}
+}
+
+func (r *fileRegistry) Register(ctx context.Context, subject string, schema Schema) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	schemas, err := r.load()
+	if err != nil {
+		return 0, err
+	}
+
+	if existing, ok := find(schemas, subject, schema); ok {
+		return existing.Id, nil
+	}
+
+	latest, ok := latestVersion(schemas, subject)
+	if ok {
+		if issues := issues(latest.Schema, schema); len(issues) > 0 {
+			return 0, fmt.Errorf("%w: %s", ErrIncompatible, strings.Join(issues, "; "))
+		}
+	}
+
+	registered := registeredSchema{Id: nextId(schemas), Subject: subject, Version: latest.Version + 1, Schema: schema}
+	if existing, ok := findSchema(schemas, schema); ok {
+		// Like the registry server, identical schemas share an id across subjects.
+		registered.Id = existing.Id
+	}
+
+	if err := r.save(append(schemas, registered)); err != nil {
+		return 0, err
+	}
+	return registered.Id, nil
+}
+
+func (r *fileRegistry) Lookup(ctx context.Context, subject string, schema Schema) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	schemas, err := r.load()
+	if err != nil {
+		return 0, err
+	}
+
+	if existing, ok := find(schemas, subject, schema); ok {
+		return existing.Id, nil
+	}
+	return 0, fmt.Errorf("%w: subject %s", ErrNotFound, subject)
+}
+
+func (r *fileRegistry) Compatible(ctx context.Context, subject string, schema Schema) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	schemas, err := r.load()
+	if err != nil {
+		return false, err
+	}
+
+	latest, ok := latestVersion(schemas, subject)
+	if !ok {
+		return true, nil
+	}
+	return len(issues(latest.Schema, schema)) == 0, nil
+}
+
+func (r *fileRegistry) Schema(ctx context.Context, id int) (Schema, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	schemas, err := r.load()
+	if err != nil {
+		return Schema{}, err
+	}
+
+	for _, s := range schemas {
+		if s.Id == id {
+			return s.Schema, nil
+		}
+	}
+	return Schema{}, fmt.Errorf("%w: id %d", ErrNotFound, id)
+}
+
+func (r *fileRegistry) load() ([]registeredSchema, error) {
+	data, err := os.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema registry file: %w", err)
+	}
+
+	schemas := []registeredSchema{}
+	if err := json.Unmarshal(data, &schemas); err != nil {
+		return nil, fmt.Errorf("failed to parse schema registry file: %w", err)
+	}
+	return schemas, nil
+}
+
+// save writes through a temporary file so that concurrent readers in other
+// processes never see a partially written file.
+func (r *fileRegistry) save(schemas []registeredSchema) error {
+	data, err := json.MarshalIndent(schemas, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if dir := filepath.Dir(r.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create schema registry directory: %w", err)
+		}
+	}
+
+	tmp := r.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write schema registry file: %w", err)
+	}
+	return os.Rename(tmp, r.path)
+}
+
+func find(schemas []registeredSchema, subject string, schema Schema) (registeredSchema, bool) {
+	for _, s := range schemas {
+		if s.Subject == subject && s.Schema == schema {
+			return s, true
+		}
+	}
+	return registeredSchema{}, false
+}
+
+func findSchema(schemas []registeredSchema, schema Schema) (registeredSchema, bool) {
+	for _, s := range schemas {
+		if s.Schema == schema {
+			return s, true
+		}
+	}
+	return registeredSchema{}, false
+}
+
+func latestVersion(schemas []registeredSchema, subject string) (registeredSchema, bool) {
+	latest := registeredSchema{}
+	found := false
+	for _, s := range schemas {
+		if s.Subject == subject && s.Version > latest.Version {
+			latest = s
+			found = true
+		}
+	}
+	return latest, found
+}
+
+func nextId(schemas []registeredSchema) int {
+	id := 0
+	for _, s := range schemas {
+		id = max(id, s.Id)
+	}
+	return id + 1
+}
+
+// issues checks protobuf schemas only; other schema types are treated as
+// compatible by the stand-in.
+func issues(previous, next Schema) []string {
+	if previous.Type != SchemaTypeProtobuf || next.Type != SchemaTypeProtobuf {
+		return nil
+	}
+	return compatibilityIssues(previous.Schema, next.Schema)
+}
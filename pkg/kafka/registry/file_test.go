@@ -0,0 +1,72 @@
+package registry
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	albumV1 = Schema{Type: SchemaTypeProtobuf, Schema: "message Album {\n  int32 id = 1;\n}\n"}
+	albumV2 = Schema{Type: SchemaTypeProtobuf, Schema: "message Album {\n  int32 id = 1;\n  string title = 2;\n}\n"}
+	albumV3 = Schema{Type: SchemaTypeProtobuf, Schema: "message Album {\n  string id = 1;\n}\n"}
+)
+
+func TestFileRegistry_Register(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "registry", "schemas.json")
+	reg := NewFileRegistry(path)
+
+	id, err := reg.Register(ctx, "albums-value", albumV1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+
+	again, err := reg.Register(ctx, "albums-value", albumV1)
+	assert.NoError(t, err)
+	assert.Equal(t, id, again)
+
+	next, err := reg.Register(ctx, "albums-value", albumV2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, next)
+
+	// A second instance sees the schemas written by the first one
+	schema, err := NewFileRegistry(path).Schema(ctx, next)
+	assert.NoError(t, err)
+	assert.Equal(t, albumV2, schema)
+
+	lookedUp, err := NewFileRegistry(path).Lookup(ctx, "albums-value", albumV1)
+	assert.NoError(t, err)
+	assert.Equal(t, id, lookedUp)
+}
+
+func TestFileRegistry_Incompatible(t *testing.T) {
+	ctx := context.Background()
+	reg := NewFileRegistry(filepath.Join(t.TempDir(), "schemas.json"))
+
+	compatible, err := reg.Compatible(ctx, "albums-value", albumV3)
+	assert.NoError(t, err)
+	assert.True(t, compatible, "a new subject accepts any schema")
+
+	_, err = reg.Register(ctx, "albums-value", albumV1)
+	assert.NoError(t, err)
+
+	compatible, err = reg.Compatible(ctx, "albums-value", albumV3)
+	assert.NoError(t, err)
+	assert.False(t, compatible)
+
+	_, err = reg.Register(ctx, "albums-value", albumV3)
+	assert.ErrorIs(t, err, ErrIncompatible)
+}
+
+func TestFileRegistry_NotFound(t *testing.T) {
+	ctx := context.Background()
+	reg := NewFileRegistry(filepath.Join(t.TempDir(), "schemas.json"))
+
+	_, err := reg.Schema(ctx, 42)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = reg.Lookup(ctx, "albums-value", albumV1)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,222 @@
+package registry
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const SchemaTypeProtobuf = "PROTOBUF"
+
+// ProtobufSchema renders the message as a self-contained proto3 schema. The
+// message is printed first, so its message index is always 0, followed by
+// the messages and enums of the same package it references. Types from other
+// packages, such as the well-known types, are imported.
+func ProtobufSchema(desc protoreflect.MessageDescriptor) Schema {
+	p := &schemaPrinter{pkg: desc.ParentFile().Package()}
+	p.message(desc)
+
+	var b strings.Builder
+	b.WriteString("syntax = \"proto3\";\n")
+	if p.pkg != "" {
+		fmt.Fprintf(&b, "package %s;\n", p.pkg)
+	}
+	for _, imp := range p.imports {
+		fmt.Fprintf(&b, "import %q;\n", imp)
+	}
+	b.WriteString(p.body.String())
+
+	return Schema{Type: SchemaTypeProtobuf, Schema: b.String()}
+}
+
+type schemaPrinter struct {
+	pkg     protoreflect.FullName
+	body    strings.Builder
+	printed []protoreflect.FullName
+	imports []string
+	pending []protoreflect.Descriptor
+}
+
+func (p *schemaPrinter) message(desc protoreflect.MessageDescriptor) {
+	p.pending = append(p.pending, desc)
+	for len(p.pending) > 0 {
+		next := p.pending[0]
+		p.pending = p.pending[1:]
+		if slices.Contains(p.printed, next.FullName()) {
+			continue
+		}
+		p.printed = append(p.printed, next.FullName())
+
+		switch d := next.(type) {
+		case protoreflect.MessageDescriptor:
+			p.printMessage(d)
+		case protoreflect.EnumDescriptor:
+			p.printEnum(d)
+		}
+	}
+}
+
+func (p *schemaPrinter) printMessage(desc protoreflect.MessageDescriptor) {
+	fmt.Fprintf(&p.body, "\nmessage %s {\n", desc.Name())
+
+	oneofs := desc.Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		oneof := oneofs.Get(i)
+		if oneof.IsSynthetic() {
+			continue
+		}
+		fmt.Fprintf(&p.body, "  oneof %s {\n", oneof.Name())
+		for j := 0; j < oneof.Fields().Len(); j++ {
+			p.printField(oneof.Fields().Get(j), "    ")
+		}
+		p.body.WriteString("  }\n")
+	}
+
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if oneof := field.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+			continue
+		}
+		p.printField(field, "  ")
+	}
+
+	p.body.WriteString("}\n")
+}
+
+func (p *schemaPrinter) printField(field protoreflect.FieldDescriptor, indent string) {
+	label := ""
+	switch {
+	case field.IsMap():
+	case field.IsList():
+		label = "repeated "
+	case field.HasOptionalKeyword():
+		label = "optional "
+	}
+
+	typeName := p.typeName(field)
+	if field.IsMap() {
+		typeName = fmt.Sprintf("map<%s, %s>", p.typeName(field.MapKey()), p.typeName(field.MapValue()))
+	}
+
+	fmt.Fprintf(&p.body, "%s%s%s %s = %d;\n", indent, label, typeName, field.Name(), field.Number())
+}
+
+func (p *schemaPrinter) typeName(field protoreflect.FieldDescriptor) string {
+	var ref protoreflect.Descriptor
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		ref = field.Message()
+	case protoreflect.EnumKind:
+		ref = field.Enum()
+	default:
+		return field.Kind().String()
+	}
+
+	if ref.ParentFile().Package() != p.pkg {
+		if path := ref.ParentFile().Path(); !slices.Contains(p.imports, path) {
+			p.imports = append(p.imports, path)
+		}
+		return string(ref.FullName())
+	}
+
+	p.pending = append(p.pending, ref)
+	return string(ref.Name())
+}
+
+func (p *schemaPrinter) printEnum(desc protoreflect.EnumDescriptor) {
+	fmt.Fprintf(&p.body, "\nenum %s {\n", desc.Name())
+	values := desc.Values()
+	for i := 0; i < values.Len(); i++ {
+		fmt.Fprintf(&p.body, "  %s = %d;\n", values.Get(i).Name(), values.Get(i).Number())
+	}
+	p.body.WriteString("}\n")
+}
+
+var (
+	messagePattern = regexp.MustCompile(`^\s*message\s+(\w+)\s*\{`)
+	fieldPattern   = regexp.MustCompile(`^\s*(repeated\s+|optional\s+)?(map<[^>]+>|[\w.]+)\s+(\w+)\s*=\s*(\d+)`)
+)
+
+// firstMessage returns the name of the message at index 0 of the schema.
+func firstMessage(schema string) string {
+	for _, line := range strings.Split(schema, "\n") {
+		if m := messagePattern.FindStringSubmatch(line); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+type schemaField struct {
+	label    string
+	typeName string
+	name     string
+}
+
+// schemaFields extracts the fields of every message of a schema rendered by
+// ProtobufSchema, keyed by message name and field number. It is not a full
+// proto parser, which is enough to compare schemas printed the same way.
+func schemaFields(schema string) map[string]map[string]schemaField {
+	messages := make(map[string]map[string]schemaField)
+
+	stack := []string{}
+	for _, line := range strings.Split(schema, "\n") {
+		if m := messagePattern.FindStringSubmatch(line); m != nil {
+			stack = append(stack, m[1])
+			messages[m[1]] = make(map[string]schemaField)
+			continue
+		}
+
+		current := ""
+		if len(stack) > 0 {
+			current = stack[len(stack)-1]
+		}
+
+		if m := fieldPattern.FindStringSubmatch(line); m != nil && current != "" {
+			messages[current][m[4]] = schemaField{
+				label:    strings.TrimSpace(m[1]),
+				typeName: strings.ReplaceAll(m[2], " ", ""),
+				name:     m[3],
+			}
+		}
+
+		// oneof and enum blocks keep the fields in the enclosing message
+		if strings.Contains(line, "{") {
+			stack = append(stack, current)
+		}
+		if strings.Contains(line, "}") && len(stack) > 0 {
+			stack = stack[:len(stack)-1]
+		}
+	}
+	return messages
+}
+
+// compatibilityIssues lists the changes that prevent data written with one
+// schema from being read with the other: a field number reused with a
+// different type or cardinality. Added and removed fields are compatible in
+// proto3.
+func compatibilityIssues(previous, next string) []string {
+	before, after := schemaFields(previous), schemaFields(next)
+
+	issues := []string{}
+	for message, fields := range after {
+		for number, field := range fields {
+			old, ok := before[message][number]
+			if !ok {
+				continue
+			}
+			if old.typeName != field.typeName || old.label != field.label {
+				issues = append(issues, fmt.Sprintf(
+					"%s field %s changed from %s to %s",
+					message, number, strings.TrimSpace(old.label+" "+old.typeName), strings.TrimSpace(field.label+" "+field.typeName),
+				))
+			}
+		}
+	}
+	slices.Sort(issues)
+	return issues
+}
@@ -0,0 +1,98 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"music-service/gen/pb"
+)
+
+func TestProtobufSchema_Album(t *testing.T) {
+	schema := ProtobufSchema((&pb.Album{}).ProtoReflect().Descriptor())
+
+	assert.Equal(t, SchemaTypeProtobuf, schema.Type)
+	assert.Contains(t, schema.Schema, "package service;")
+	assert.Contains(t, schema.Schema, "message Album {")
+	assert.Contains(t, schema.Schema, "  int32 id = 1;")
+	assert.Contains(t, schema.Schema, "  float price = 4;")
+	assert.Equal(t, "Album", firstMessage(schema.Schema))
+}
+
+func TestProtobufSchema_IncludesReferencedMessages(t *testing.T) {
+	schema := ProtobufSchema((&pb.GetAlbumsResponse{}).ProtoReflect().Descriptor())
+
+	assert.Equal(t, "GetAlbumsResponse", firstMessage(schema.Schema))
+	assert.Contains(t, schema.Schema, "  repeated Album albums = 1;")
+	assert.Contains(t, schema.Schema, "message Album {")
+}
+
+func TestCompatibilityIssues(t *testing.T) {
+	previous := `syntax = "proto3";
+message Album {
+  int32 id = 1;
+  string title = 2;
+  oneof source {
+    string label = 5;
+  }
+}
+`
+
+	tests := []struct {
+		name   string
+		next   string
+		issues int
+	}{
+		{
+			name: "added field",
+			next: `message Album {
+  int32 id = 1;
+  string title = 2;
+  string artist = 3;
+}
+`,
+		},
+		{
+			name: "removed field",
+			next: `message Album {
+  int32 id = 1;
+}
+`,
+		},
+		{
+			name: "changed type",
+			next: `message Album {
+  int64 id = 1;
+  string title = 2;
+}
+`,
+			issues: 1,
+		},
+		{
+			name: "changed cardinality",
+			next: `message Album {
+  int32 id = 1;
+  repeated string title = 2;
+}
+`,
+			issues: 1,
+		},
+		{
+			name: "changed oneof field type",
+			next: `message Album {
+  int32 id = 1;
+  oneof source {
+    int32 label = 5;
+  }
+}
+`,
+			issues: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Len(t, compatibilityIssues(previous, tt.next), tt.issues)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"music-service/pkg/kafka"
+)
+
+var (
+	ErrNotFound     = errors.New("schema not found")
+	ErrIncompatible = errors.New("schema is incompatible")
+)
+
+type Schema struct {
+	Type   string `json:"schemaType,omitempty"`
+	Schema string `json:"schema"`
+}
+
+// Registry is the subset of the Confluent schema registry API used to frame
+// and verify message values.
+type Registry interface {
+	// Register registers the schema under the subject, or returns the id of
+	// the identical schema already registered. It fails with ErrIncompatible
+	// when the schema is incompatible with the latest version.
+	Register(ctx context.Context, subject string, schema Schema) (int, error)
+	// Lookup returns the id of the schema registered under the subject.
+	Lookup(ctx context.Context, subject string, schema Schema) (int, error)
+	// Compatible reports whether the schema is compatible with the latest
+	// version of the subject. A subject without versions accepts any schema.
+	Compatible(ctx context.Context, subject string, schema Schema) (bool, error)
+	// Schema returns the schema registered with the id.
+	Schema(ctx context.Context, id int) (Schema, error)
+}
+
+// New returns the registry client configured by cfg, or nil when the schema
+// registry is disabled.
+func New(cfg kafka.SchemaRegistryConfig) (Registry, error) {
+	switch {
+	case cfg.Url != "":
+		password := cfg.Password
+		if cfg.PasswordFile != "" {
+			data, err := os.ReadFile(cfg.PasswordFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read schema registry password file: %w", err)
+			}
+			password = strings.TrimSpace(string(data))
+		}
+		return NewClient(cfg.Url, cfg.Username, password), nil
+	case cfg.File != "":
+		return NewFileRegistry(cfg.File), nil
+	default:
+		return nil, nil
+	}
+}
+
+// Subject returns the subject of the values of the topic under the default
+// TopicNameStrategy.
+func Subject(topic string) string {
+	return topic + "-value"
+}
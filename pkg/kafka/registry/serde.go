@@ -0,0 +1,150 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"music-service/pkg/kafka"
+)
+
+const requestTimeout = 10 * time.Second
+
+// Serializer encodes protobuf values, framed with the id of their schema when
+// the schema registry is enabled and as raw protobuf otherwise.
+type Serializer struct {
+	schemaID int
+	framed   bool
+}
+
+// NewSerializer resolves the id of the schema of msg under the subject of the
+// topic, registering the schema when auto registration is enabled. It fails
+// when the schema is incompatible with the one registered for the topic.
+func NewSerializer(cfg kafka.Config, topic string, msg proto.Message) (*Serializer, error) {
+	reg, err := New(cfg.SchemaRegistry)
+	if err != nil || reg == nil {
+		return &Serializer{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	subject := Subject(topic)
+	schema := ProtobufSchema(msg.ProtoReflect().Descriptor())
+
+	compatible, err := reg.Compatible(ctx, subject, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check schema compatibility for %s: %w", subject, err)
+	}
+	if !compatible {
+		return nil, fmt.Errorf("%w: %s", ErrIncompatible, subject)
+	}
+
+	var id int
+	if cfg.SchemaRegistry.AutoRegister {
+		id, err = reg.Register(ctx, subject, schema)
+	} else {
+		id, err = reg.Lookup(ctx, subject, schema)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve schema for %s: %w", subject, err)
+	}
+
+	return &Serializer{schemaID: id, framed: true}, nil
+}
+
+// Serialize encodes msg. A nil serializer encodes raw protobuf.
+func (s *Serializer) Serialize(msg proto.Message) ([]byte, error) {
+	payload, err := proto.Marshal(msg)
+	if err != nil || s == nil || !s.framed {
+		return payload, err
+	}
+	return Frame(s.schemaID, []int{0}, payload), nil
+}
+
+// Deserializer decodes protobuf values. Framed values are checked against
+// the schema they were written with, resolved once per schema id, before they
+// are decoded, so values from incompatible producers are rejected instead of
+// silently misread.
+type Deserializer struct {
+	registry Registry
+
+	mu       sync.Mutex
+	verified map[verifiedKey]error
+}
+
+type verifiedKey struct {
+	schemaID int
+	message  string
+}
+
+// NewDeserializer returns a deserializer using the registry configured by
+// cfg. Without a registry, framed values are unwrapped without verification.
+func NewDeserializer(cfg kafka.Config) (*Deserializer, error) {
+	reg, err := New(cfg.SchemaRegistry)
+	if err != nil {
+		return nil, err
+	}
+	return &Deserializer{registry: reg, verified: make(map[verifiedKey]error)}, nil
+}
+
+func (d *Deserializer) Deserialize(data []byte, msg proto.Message) error {
+	if !IsFramed(data) {
+		return proto.Unmarshal(data, msg)
+	}
+
+	schemaID, messageIndexes, payload, err := Unframe(data)
+	if err != nil {
+		return err
+	}
+	if len(messageIndexes) != 1 || messageIndexes[0] != 0 {
+		return fmt.Errorf("unsupported message indexes %v in schema %d", messageIndexes, schemaID)
+	}
+
+	if err := d.verify(schemaID, msg); err != nil {
+		return err
+	}
+	return proto.Unmarshal(payload, msg)
+}
+
+func (d *Deserializer) verify(schemaID int, msg proto.Message) error {
+	if d == nil || d.registry == nil {
+		return nil
+	}
+
+	reader := ProtobufSchema(msg.ProtoReflect().Descriptor())
+	key := verifiedKey{schemaID: schemaID, message: string(msg.ProtoReflect().Descriptor().FullName())}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err, ok := d.verified[key]; ok {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	writer, err := d.registry.Schema(ctx, schemaID)
+	if err != nil {
+		// Lookup failures are not cached so that they are retried.
+		return fmt.Errorf("failed to resolve schema %d: %w", schemaID, err)
+	}
+
+	switch {
+	case writer.Type != SchemaTypeProtobuf:
+		err = fmt.Errorf("%w: schema %d is %s, not protobuf", ErrIncompatible, schemaID, writer.Type)
+	case firstMessage(writer.Schema) != string(msg.ProtoReflect().Descriptor().Name()):
+		err = fmt.Errorf("%w: schema %d does not define %s", ErrIncompatible, schemaID, key.message)
+	default:
+		if issues := compatibilityIssues(writer.Schema, reader.Schema); len(issues) > 0 {
+			err = fmt.Errorf("%w: schema %d: %s", ErrIncompatible, schemaID, strings.Join(issues, "; "))
+		}
+	}
+	d.verified[key] = err
+	return err
+}
@@ -0,0 +1,118 @@
+package registry
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"music-service/gen/pb"
+	"music-service/pkg/kafka"
+)
+
+func TestSerializer_Disabled(t *testing.T) {
+	serializer, err := NewSerializer(kafka.Config{Topics: "albums"}, "albums", &pb.Album{})
+	assert.NoError(t, err)
+
+	album := &pb.Album{Id: 1, Title: "Blue Train"}
+	data, err := serializer.Serialize(album)
+	assert.NoError(t, err)
+
+	raw, _ := proto.Marshal(album)
+	assert.Equal(t, raw, data)
+}
+
+func TestSerializer_RoundTrip(t *testing.T) {
+	cfg := kafka.Config{
+		Topics: "albums",
+		SchemaRegistry: kafka.SchemaRegistryConfig{
+			File:         filepath.Join(t.TempDir(), "schemas.json"),
+			AutoRegister: true,
+		},
+	}
+
+	serializer, err := NewSerializer(cfg, "albums", &pb.Album{})
+	assert.NoError(t, err)
+
+	data, err := serializer.Serialize(&pb.Album{Id: 1, Title: "Blue Train"})
+	assert.NoError(t, err)
+	assert.True(t, IsFramed(data))
+
+	deserializer, err := NewDeserializer(cfg)
+	assert.NoError(t, err)
+
+	album := &pb.Album{}
+	assert.NoError(t, deserializer.Deserialize(data, album))
+	assert.Equal(t, "Blue Train", album.Title)
+}
+
+func TestSerializer_SubjectPerTopic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schemas.json")
+	cfg := kafka.Config{
+		Topics:     "albums",
+		StockTopic: "stock-events",
+		SchemaRegistry: kafka.SchemaRegistryConfig{
+			File:         path,
+			AutoRegister: true,
+		},
+	}
+
+	_, err := NewSerializer(cfg, "albums", &pb.Album{})
+	assert.NoError(t, err)
+	_, err = NewSerializer(cfg, "stock-events", &pb.StockEvent{})
+	assert.NoError(t, err)
+
+	reg := NewFileRegistry(path)
+	for subject, msg := range map[string]proto.Message{"albums-value": &pb.Album{}, "stock-events-value": &pb.StockEvent{}} {
+		_, err := reg.Lookup(context.Background(), subject, ProtobufSchema(msg.ProtoReflect().Descriptor()))
+		assert.NoError(t, err, subject)
+	}
+	_, err = reg.Lookup(context.Background(), "albums-value", ProtobufSchema((&pb.StockEvent{}).ProtoReflect().Descriptor()))
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestSerializer_NotRegistered(t *testing.T) {
+	cfg := kafka.Config{
+		Topics:         "albums",
+		SchemaRegistry: kafka.SchemaRegistryConfig{File: filepath.Join(t.TempDir(), "schemas.json")},
+	}
+
+	_, err := NewSerializer(cfg, "albums", &pb.Album{})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestDeserializer_RejectsIncompatibleSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schemas.json")
+	id, err := NewFileRegistry(path).Register(context.Background(), "other-value", albumV3)
+	assert.NoError(t, err)
+
+	deserializer, err := NewDeserializer(kafka.Config{SchemaRegistry: kafka.SchemaRegistryConfig{File: path}})
+	assert.NoError(t, err)
+
+	err = deserializer.Deserialize(Frame(id, []int{0}, nil), &pb.Album{})
+	assert.ErrorIs(t, err, ErrIncompatible)
+}
+
+func TestDeserializer_RejectsOtherMessage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schemas.json")
+	schema := ProtobufSchema((&pb.PartitionStatus{}).ProtoReflect().Descriptor())
+	id, err := NewFileRegistry(path).Register(context.Background(), "status-value", schema)
+	assert.NoError(t, err)
+
+	deserializer, err := NewDeserializer(kafka.Config{SchemaRegistry: kafka.SchemaRegistryConfig{File: path}})
+	assert.NoError(t, err)
+
+	err = deserializer.Deserialize(Frame(id, []int{0}, nil), &pb.Album{})
+	assert.ErrorIs(t, err, ErrIncompatible)
+}
+
+func TestDeserializer_WithoutRegistry(t *testing.T) {
+	var deserializer *Deserializer
+	payload, _ := proto.Marshal(&pb.Album{Id: 3})
+
+	album := &pb.Album{}
+	assert.NoError(t, deserializer.Deserialize(Frame(5, []int{0}, payload), album))
+	assert.Equal(t, int32(3), album.Id)
+}
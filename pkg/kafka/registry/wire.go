@@ -0,0 +1,69 @@
+package registry
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// magicByte starts every value in the Confluent wire format. A protobuf
+// message can never start with it because field number 0 is invalid, so raw
+// protobuf values can still be told apart from framed ones.
+const magicByte byte = 0
+
+var ErrMalformedFrame = errors.New("malformed schema registry frame")
+
+// IsFramed reports whether the value starts with the Confluent wire-format
+// header.
+func IsFramed(data []byte) bool {
+	return len(data) >= 5 && data[0] == magicByte
+}
+
+// Frame prepends the magic byte, the schema id and, for protobuf, the message
+// indexes of the message within the schema. The common case of the first
+// message is encoded as a single 0 like the Confluent serializers do.
+func Frame(schemaID int, messageIndexes []int, payload []byte) []byte {
+	data := make([]byte, 5, 6+len(payload))
+	data[0] = magicByte
+	binary.BigEndian.PutUint32(data[1:5], uint32(schemaID))
+
+	if len(messageIndexes) == 1 && messageIndexes[0] == 0 {
+		data = append(data, 0)
+	} else {
+		data = binary.AppendVarint(data, int64(len(messageIndexes)))
+		for _, index := range messageIndexes {
+			data = binary.AppendVarint(data, int64(index))
+		}
+	}
+	return append(data, payload...)
+}
+
+// Unframe splits a framed protobuf value into its schema id, message indexes
+// and payload.
+func Unframe(data []byte) (schemaID int, messageIndexes []int, payload []byte, err error) {
+	if !IsFramed(data) {
+		return 0, nil, nil, ErrMalformedFrame
+	}
+	schemaID = int(binary.BigEndian.Uint32(data[1:5]))
+	rest := data[5:]
+
+	count, n := binary.Varint(rest)
+	if n <= 0 || count < 0 {
+		return 0, nil, nil, ErrMalformedFrame
+	}
+	rest = rest[n:]
+
+	if count == 0 {
+		return schemaID, []int{0}, rest, nil
+	}
+
+	messageIndexes = make([]int, 0, count)
+	for i := int64(0); i < count; i++ {
+		index, n := binary.Varint(rest)
+		if n <= 0 {
+			return 0, nil, nil, ErrMalformedFrame
+		}
+		messageIndexes = append(messageIndexes, int(index))
+		rest = rest[n:]
+	}
+	return schemaID, messageIndexes, rest, nil
+}
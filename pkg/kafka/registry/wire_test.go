@@ -0,0 +1,57 @@
+package registry
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrame_FirstMessage(t *testing.T) {
+	data := Frame(7, []int{0}, []byte("payload"))
+
+	assert.Equal(t, []byte{0, 0, 0, 0, 7, 0}, data[:6])
+	assert.True(t, IsFramed(data))
+
+	schemaID, indexes, payload, err := Unframe(data)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, schemaID)
+	assert.Equal(t, []int{0}, indexes)
+	assert.Equal(t, []byte("payload"), payload)
+}
+
+func TestFrame_NestedMessageIndexes(t *testing.T) {
+	data := Frame(300, []int{1, 2}, []byte("payload"))
+
+	schemaID, indexes, payload, err := Unframe(data)
+	assert.NoError(t, err)
+	assert.Equal(t, 300, schemaID)
+	assert.Equal(t, []int{1, 2}, indexes)
+	assert.Equal(t, []byte("payload"), payload)
+}
+
+func TestUnframe_Malformed(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "raw protobuf", data: []byte{0x08, 0x01}},
+		{name: "too short", data: []byte{0, 0, 0}},
+		{name: "truncated indexes", data: []byte{0, 0, 0, 0, 1, 0x04}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, _, err := Unframe(tt.data)
+			assert.ErrorIs(t, err, ErrMalformedFrame)
+		})
+	}
+}
+
+func TestIsFramed_RawProtobuf(t *testing.T) {
+	// field 1, varint 150
+	raw := []byte{0x08, 0x96, 0x01, 0x12, 0x00}
+
+	assert.False(t, IsFramed(raw))
+	assert.False(t, IsFramed(bytes.Repeat([]byte{0}, 4)))
+}
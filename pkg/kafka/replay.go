@@ -0,0 +1,46 @@
+package kafka
+
+import "time"
+
+// ReplayRange selects the messages of a topic to replay. A replay starts at
+// From, or at the first message at or after FromTime when it is set, and
+// stops before To, or before the first message at or after ToTime. Without
+// bounds it covers each partition from its beginning to the end it had when
+// the replay started. Empty Partitions selects every partition.
+type ReplayRange struct {
+	Partitions []int32
+	From       int64
+	FromTime   time.Time
+	To         int64
+	ToTime     time.Time
+}
+
+// ReplayResult counts the messages replayed from each partition.
+type ReplayResult struct {
+	Partitions []PartitionReplay `json:"partitions"`
+}
+
+type PartitionReplay struct {
+	Topic     string `json:"topic"`
+	Partition int32  `json:"partition"`
+	From      int64  `json:"from"`
+	To        int64  `json:"to"`
+	Processed int    `json:"processed"`
+	Skipped   int    `json:"skipped"`
+}
+
+func (r ReplayResult) Processed() int {
+	n := 0
+	for _, p := range r.Partitions {
+		n += p.Processed
+	}
+	return n
+}
+
+func (r ReplayResult) Skipped() int {
+	n := 0
+	for _, p := range r.Partitions {
+		n += p.Skipped
+	}
+	return n
+}
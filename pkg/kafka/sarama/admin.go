@@ -0,0 +1,313 @@
+package sarama
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+
+	"github.com/IBM/sarama"
+
+	"music-service/pkg/kafka"
+)
+
+// Admin manages topics and consumer groups of the cluster.
+type Admin struct {
+	client sarama.Client
+	admin  sarama.ClusterAdmin
+}
+
+func NewAdmin(cfg kafka.Config) (*Admin, error) {
+	client, err := NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	admin, err := sarama.NewClusterAdminFromClient(client)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("Error creating cluster admin: %v", err)
+	}
+	return &Admin{client: client, admin: admin}, nil
+}
+
+// Close closes the admin and its client.
+func (a *Admin) Close() error {
+	return a.admin.Close()
+}
+
+func (a *Admin) CreateTopic(spec kafka.TopicSpec) error {
+	return a.admin.CreateTopic(spec.Name, topicDetail(spec), false)
+}
+
+func (a *Admin) DeleteTopic(name string) error {
+	return a.admin.DeleteTopic(name)
+}
+
+// EnsureTopics creates the topics that do not exist yet and returns their
+// names.
+func (a *Admin) EnsureTopics(specs []kafka.TopicSpec) ([]string, error) {
+	existing, err := a.admin.ListTopics()
+	if err != nil {
+		return nil, err
+	}
+
+	created := []string{}
+	for _, spec := range specs {
+		if _, ok := existing[spec.Name]; ok {
+			continue
+		}
+
+		err := a.CreateTopic(spec)
+		if errors.Is(err, sarama.ErrTopicAlreadyExists) {
+			// Another producer or consumer created it first.
+			continue
+		}
+		if err != nil {
+			return created, fmt.Errorf("failed to create topic %s: %w", spec.Name, err)
+		}
+		created = append(created, spec.Name)
+	}
+	return created, nil
+}
+
+func (a *Admin) DescribeTopics(names []string) ([]kafka.TopicDescription, error) {
+	metadata, err := a.admin.DescribeTopics(names)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptions := []kafka.TopicDescription{}
+	for _, m := range metadata {
+		if !errors.Is(m.Err, sarama.ErrNoError) {
+			return nil, fmt.Errorf("failed to describe topic %s: %w", m.Name, m.Err)
+		}
+
+		description := kafka.TopicDescription{
+			Name:       m.Name,
+			Partitions: int32(len(m.Partitions)),
+		}
+		if len(m.Partitions) > 0 {
+			description.ReplicationFactor = int16(len(m.Partitions[0].Replicas))
+		}
+
+		entries, err := a.admin.DescribeConfig(sarama.ConfigResource{Type: sarama.TopicResource, Name: m.Name})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe config of topic %s: %w", m.Name, err)
+		}
+		description.Config = topicConfig(entries)
+
+		descriptions = append(descriptions, description)
+	}
+	return descriptions, nil
+}
+
+// ListGroups returns every consumer group with the lag of its committed
+// partitions, sorted by group.
+func (a *Admin) ListGroups() ([]kafka.GroupStatus, error) {
+	groups, err := a.admin.ListConsumerGroups()
+	if err != nil {
+		return nil, err
+	}
+	names := slices.Sorted(maps.Keys(groups))
+	if len(names) == 0 {
+		return []kafka.GroupStatus{}, nil
+	}
+
+	descriptions, err := a.admin.DescribeConsumerGroups(names)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []kafka.GroupStatus{}
+	for _, d := range descriptions {
+		partitions, err := a.groupPartitions(d.GroupId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read offsets of group %s: %w", d.GroupId, err)
+		}
+		statuses = append(statuses, kafka.GroupStatus{
+			Group:      d.GroupId,
+			State:      d.State,
+			Members:    len(d.Members),
+			Partitions: partitions,
+		})
+	}
+	slices.SortFunc(statuses, func(a, b kafka.GroupStatus) int {
+		return cmp.Compare(a.Group, b.Group)
+	})
+	return statuses, nil
+}
+
+// ResetGroupOffsets commits new offsets for the selected partitions of an
+// inactive group and returns the new positions. An empty Topic selects every
+// partition the group has committed; a Topic without Partitions selects all
+// of its partitions. Offsets of running consumers are reset through the
+// consumer control API instead.
+func (a *Admin) ResetGroupOffsets(group string, reset kafka.OffsetReset) ([]kafka.PartitionStatus, error) {
+	descriptions, err := a.admin.DescribeConsumerGroups([]string{group})
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range descriptions {
+		if len(d.Members) > 0 {
+			return nil, fmt.Errorf("group %s has %d active members, stop its consumers or use the consumer control API", group, len(d.Members))
+		}
+	}
+
+	committed, err := a.committedPartitions(group)
+	if err != nil {
+		return nil, err
+	}
+	if reset.Topic != "" {
+		partitions, err := a.client.Partitions(reset.Topic)
+		if err != nil {
+			return nil, err
+		}
+		committed[reset.Topic] = partitions
+	}
+
+	selected := kafka.SelectPartitions(committed, reset.Topic, reset.Partitions)
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no partitions of group %s match topic %q partitions %v", group, reset.Topic, reset.Partitions)
+	}
+
+	request := &sarama.OffsetCommitRequest{
+		Version:                 2,
+		ConsumerGroup:           group,
+		ConsumerGroupGeneration: -1,
+		RetentionTime:           -1,
+	}
+	statuses := []kafka.PartitionStatus{}
+	for t, ps := range selected {
+		for _, p := range ps {
+			offset, err := ResolveOffset(a.client, t, p, reset)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve offset for %s[%d]: %w", t, p, err)
+			}
+			high, err := a.client.GetOffset(t, p, sarama.OffsetNewest)
+			if err != nil {
+				return nil, err
+			}
+
+			request.AddBlock(t, p, offset, 0, "")
+			statuses = append(statuses, partitionStatus(t, p, offset, high))
+		}
+	}
+
+	coordinator, err := a.client.Coordinator(group)
+	if err != nil {
+		return nil, err
+	}
+	response, err := coordinator.CommitOffset(request)
+	if err != nil {
+		return nil, err
+	}
+	for t, errs := range response.Errors {
+		for p, kerr := range errs {
+			if !errors.Is(kerr, sarama.ErrNoError) {
+				return nil, fmt.Errorf("failed to commit offset for %s[%d]: %w", t, p, kerr)
+			}
+		}
+	}
+
+	sortPartitions(statuses)
+	log.Printf("reset offsets of group %s: %v", group, statuses)
+	return statuses, nil
+}
+
+func (a *Admin) groupPartitions(group string) ([]kafka.PartitionStatus, error) {
+	offsets, err := a.admin.ListConsumerGroupOffsets(group, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !errors.Is(offsets.Err, sarama.ErrNoError) {
+		return nil, offsets.Err
+	}
+
+	statuses := []kafka.PartitionStatus{}
+	for t, blocks := range offsets.Blocks {
+		for p, block := range blocks {
+			if !errors.Is(block.Err, sarama.ErrNoError) {
+				return nil, fmt.Errorf("%s[%d]: %w", t, p, block.Err)
+			}
+
+			high, err := a.client.GetOffset(t, p, sarama.OffsetNewest)
+			if err != nil {
+				return nil, err
+			}
+			statuses = append(statuses, partitionStatus(t, p, block.Offset, high))
+		}
+	}
+	sortPartitions(statuses)
+	return statuses, nil
+}
+
+func (a *Admin) committedPartitions(group string) (map[string][]int32, error) {
+	partitions, err := a.groupPartitions(group)
+	if err != nil {
+		return nil, err
+	}
+
+	committed := make(map[string][]int32)
+	for _, p := range partitions {
+		if p.Offset >= 0 {
+			committed[p.Topic] = append(committed[p.Topic], p.Partition)
+		}
+	}
+	return committed, nil
+}
+
+// partitionStatus reports no lag for partitions without a committed offset,
+// because where the group starts depends on its consumers' settings.
+func partitionStatus(topic string, partition int32, offset, high int64) kafka.PartitionStatus {
+	status := kafka.PartitionStatus{
+		Topic:         topic,
+		Partition:     partition,
+		Offset:        offset,
+		HighWatermark: high,
+	}
+	if offset >= 0 {
+		status.Lag = max(high-offset, 0)
+	}
+	return status
+}
+
+func topicDetail(spec kafka.TopicSpec) *sarama.TopicDetail {
+	detail := &sarama.TopicDetail{
+		NumPartitions:     -1,
+		ReplicationFactor: -1,
+		ConfigEntries:     make(map[string]*string),
+	}
+	if spec.Partitions > 0 {
+		detail.NumPartitions = spec.Partitions
+	}
+	if spec.ReplicationFactor > 0 {
+		detail.ReplicationFactor = spec.ReplicationFactor
+	}
+	for name, value := range spec.Config {
+		detail.ConfigEntries[name] = &value
+	}
+	return detail
+}
+
+// topicConfig keeps the entries set on the topic itself.
+func topicConfig(entries []sarama.ConfigEntry) map[string]string {
+	config := make(map[string]string)
+	for _, entry := range entries {
+		if entry.Source == sarama.SourceTopic && !entry.Sensitive {
+			config[entry.Name] = entry.Value
+		}
+	}
+	return config
+}
+
+func sortPartitions(statuses []kafka.PartitionStatus) {
+	slices.SortFunc(statuses, func(a, b kafka.PartitionStatus) int {
+		if c := cmp.Compare(a.Topic, b.Topic); c != 0 {
+			return c
+		}
+		return int(a.Partition - b.Partition)
+	})
+}
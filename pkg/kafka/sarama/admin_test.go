@@ -0,0 +1,136 @@
+package sarama
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+
+	"music-service/pkg/kafka"
+)
+
+func newMockAdmin(t *testing.T, handlers map[string]sarama.MockResponse) *Admin {
+	t.Helper()
+	broker := sarama.NewMockBroker(t, 1)
+	t.Cleanup(broker.Close)
+
+	metadata := sarama.NewMockMetadataResponse(t).
+		SetController(broker.BrokerID()).
+		SetBroker(broker.Addr(), broker.BrokerID()).
+		SetLeader("albums", 0, broker.BrokerID()).
+		SetLeader("albums", 1, broker.BrokerID())
+	handlers["ApiVersionsRequest"] = sarama.NewMockApiVersionsResponse(t)
+	handlers["MetadataRequest"] = metadata
+	handlers["FindCoordinatorRequest"] = sarama.NewMockFindCoordinatorResponse(t).
+		SetCoordinator(sarama.CoordinatorGroup, "test-group", broker)
+	broker.SetHandlerByMap(handlers)
+
+	admin, err := NewAdmin(kafka.Config{Brokers: broker.Addr()})
+	if err != nil {
+		t.Fatalf("failed to create admin: %v", err)
+	}
+	t.Cleanup(func() { admin.Close() })
+	return admin
+}
+
+// TestAdmin_EnsureTopics tests that only missing topics are created
+func TestAdmin_EnsureTopics(t *testing.T) {
+	admin := newMockAdmin(t, map[string]sarama.MockResponse{
+		"DescribeConfigsRequest": sarama.NewMockDescribeConfigsResponse(t),
+		"CreateTopicsRequest":    sarama.NewMockCreateTopicsResponse(t),
+	})
+
+	created, err := admin.EnsureTopics([]kafka.TopicSpec{
+		{Name: "albums"},
+		{Name: "album-snapshots", Partitions: 3, Config: map[string]string{"cleanup.policy": "compact"}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"album-snapshots"}, created)
+}
+
+// TestAdmin_ListGroups tests that groups are listed with the lag of their committed partitions
+func TestAdmin_ListGroups(t *testing.T) {
+	admin := newMockAdmin(t, map[string]sarama.MockResponse{
+		"ListGroupsRequest": sarama.NewMockListGroupsResponse(t).AddGroup("test-group", "consumer"),
+		"DescribeGroupsRequest": sarama.NewMockDescribeGroupsResponse(t).
+			AddGroupDescription("test-group", &sarama.GroupDescription{GroupId: "test-group", State: "Empty"}),
+		"OffsetFetchRequest": sarama.NewMockOffsetFetchResponse(t).
+			SetOffset("test-group", "albums", 0, 40, "", sarama.ErrNoError).
+			SetOffset("test-group", "albums", 1, -1, "", sarama.ErrNoError),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).
+			SetOffset("albums", 0, sarama.OffsetNewest, 50).
+			SetOffset("albums", 1, sarama.OffsetNewest, 8),
+	})
+
+	groups, err := admin.ListGroups()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []kafka.GroupStatus{{
+		Group: "test-group",
+		State: "Empty",
+		Partitions: []kafka.PartitionStatus{
+			{Topic: "albums", Partition: 0, Offset: 40, HighWatermark: 50, Lag: 10},
+			{Topic: "albums", Partition: 1, Offset: -1, HighWatermark: 8},
+		},
+	}}, groups)
+	assert.Equal(t, int64(10), groups[0].Lag())
+}
+
+// TestAdmin_ResetGroupOffsets tests that offsets of an inactive group are committed
+func TestAdmin_ResetGroupOffsets(t *testing.T) {
+	admin := newMockAdmin(t, map[string]sarama.MockResponse{
+		"DescribeGroupsRequest": sarama.NewMockDescribeGroupsResponse(t).
+			AddGroupDescription("test-group", &sarama.GroupDescription{GroupId: "test-group", State: "Empty"}),
+		"OffsetFetchRequest": sarama.NewMockOffsetFetchResponse(t).
+			SetOffset("test-group", "albums", 0, 40, "", sarama.ErrNoError),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).
+			SetOffset("albums", 0, sarama.OffsetOldest, 5).
+			SetOffset("albums", 0, sarama.OffsetNewest, 50).
+			SetOffset("albums", 1, sarama.OffsetOldest, 0).
+			SetOffset("albums", 1, sarama.OffsetNewest, 8),
+		"OffsetCommitRequest": sarama.NewMockOffsetCommitResponse(t),
+	})
+
+	statuses, err := admin.ResetGroupOffsets("test-group", kafka.OffsetReset{Topic: "albums", Offset: sarama.OffsetOldest})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []kafka.PartitionStatus{
+		{Topic: "albums", Partition: 0, Offset: 5, HighWatermark: 50, Lag: 45},
+		{Topic: "albums", Partition: 1, Offset: 0, HighWatermark: 8, Lag: 8},
+	}, statuses)
+}
+
+// TestAdmin_ResetGroupOffsets_ActiveGroup tests that groups with members are rejected
+func TestAdmin_ResetGroupOffsets_ActiveGroup(t *testing.T) {
+	admin := newMockAdmin(t, map[string]sarama.MockResponse{
+		"DescribeGroupsRequest": sarama.NewMockDescribeGroupsResponse(t).
+			AddGroupDescription("test-group", &sarama.GroupDescription{
+				GroupId: "test-group",
+				State:   "Stable",
+				Members: map[string]*sarama.GroupMemberDescription{"member-1": {}},
+			}),
+	})
+
+	_, err := admin.ResetGroupOffsets("test-group", kafka.OffsetReset{Topic: "albums"})
+
+	assert.ErrorContains(t, err, "active members")
+}
+
+func TestTopicDetail(t *testing.T) {
+	detail := topicDetail(kafka.TopicSpec{Name: "album-snapshots", Partitions: 3, Config: map[string]string{"cleanup.policy": "compact"}})
+
+	assert.Equal(t, int32(3), detail.NumPartitions)
+	assert.Equal(t, int16(-1), detail.ReplicationFactor)
+	assert.Equal(t, "compact", *detail.ConfigEntries["cleanup.policy"])
+}
+
+func TestTopicConfig(t *testing.T) {
+	config := topicConfig([]sarama.ConfigEntry{
+		{Name: "cleanup.policy", Value: "compact", Source: sarama.SourceTopic},
+		{Name: "retention.ms", Value: "604800000", Source: sarama.SourceDefault},
+		{Name: "sasl.password", Value: "secret", Source: sarama.SourceTopic, Sensitive: true},
+	})
+
+	assert.Equal(t, map[string]string{"cleanup.policy": "compact"}, config)
+}
@@ -0,0 +1,23 @@
+package sarama
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/IBM/sarama"
+
+	"music-service/pkg/kafka"
+)
+
+func NewClient(cfg kafka.Config) (sarama.Client, error) {
+	saramaCfg, err := newConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating client: %v", err)
+	}
+
+	client, err := sarama.NewClient(strings.Split(cfg.Brokers, ","), saramaCfg)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating client: %v", err)
+	}
+	return client, nil
+}
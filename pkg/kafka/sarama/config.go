@@ -0,0 +1,61 @@
+package sarama
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	"music-service/pkg/kafka"
+)
+
+const defaultProducerRetries = 10
+
+func newConfig(cfg kafka.Config) (*sarama.Config, error) {
+	saramaCfg := sarama.NewConfig()
+
+	if err := applySettings(saramaCfg, cfg.Sarama); err != nil {
+		return nil, err
+	}
+	if err := applySecurity(saramaCfg, cfg.Security); err != nil {
+		return nil, err
+	}
+	return saramaCfg, nil
+}
+
+func applySettings(saramaCfg *sarama.Config, settings kafka.SaramaConfig) error {
+	saramaCfg.Version = sarama.DefaultVersion
+	if settings.Version != "" {
+		version, err := sarama.ParseKafkaVersion(settings.Version)
+		if err != nil {
+			return fmt.Errorf("invalid Kafka version %q: %w", settings.Version, err)
+		}
+		saramaCfg.Version = version
+	}
+
+	saramaCfg.Producer.Retry.Max = defaultProducerRetries
+	if settings.Retries != nil {
+		saramaCfg.Producer.Retry.Max = *settings.Retries
+		saramaCfg.Metadata.Retry.Max = *settings.Retries
+	}
+
+	setIfPositive(&saramaCfg.Consumer.Fetch.Min, settings.FetchMinBytes)
+	setIfPositive(&saramaCfg.Consumer.Fetch.Default, settings.FetchDefaultBytes)
+	setIfPositive(&saramaCfg.Consumer.Fetch.Max, settings.FetchMaxBytes)
+
+	setIfPositive(&saramaCfg.Net.DialTimeout, settings.DialTimeout)
+	setIfPositive(&saramaCfg.Net.ReadTimeout, settings.ReadTimeout)
+	setIfPositive(&saramaCfg.Net.WriteTimeout, settings.WriteTimeout)
+	setIfPositive(&saramaCfg.Consumer.Group.Session.Timeout, settings.SessionTimeout)
+	setIfPositive(&saramaCfg.Consumer.Group.Heartbeat.Interval, settings.HeartbeatInterval)
+	setIfPositive(&saramaCfg.Consumer.Group.Rebalance.Timeout, settings.RebalanceTimeout)
+	setIfPositive(&saramaCfg.Consumer.MaxProcessingTime, settings.MaxProcessingTime)
+
+	return saramaCfg.Validate()
+}
+
+func setIfPositive[T int32 | time.Duration](target *T, value T) {
+	if value > 0 {
+		*target = value
+	}
+}
@@ -0,0 +1,84 @@
+package sarama
+
+import (
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+
+	"music-service/pkg/kafka"
+)
+
+func TestNewConfig_Defaults(t *testing.T) {
+	saramaCfg, err := newConfig(kafka.Config{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, sarama.DefaultVersion, saramaCfg.Version)
+	assert.Equal(t, defaultProducerRetries, saramaCfg.Producer.Retry.Max)
+	assert.Equal(t, sarama.NewConfig().Consumer.Fetch.Default, saramaCfg.Consumer.Fetch.Default)
+}
+
+func TestNewConfig_Settings(t *testing.T) {
+	retries := 0
+
+	saramaCfg, err := newConfig(kafka.Config{
+		Sarama: kafka.SaramaConfig{
+			Version:           "3.6.0",
+			Retries:           &retries,
+			FetchMinBytes:     10,
+			FetchDefaultBytes: 2 << 20,
+			FetchMaxBytes:     8 << 20,
+			DialTimeout:       5 * time.Second,
+			ReadTimeout:       15 * time.Second,
+			WriteTimeout:      15 * time.Second,
+			SessionTimeout:    45 * time.Second,
+			HeartbeatInterval: 5 * time.Second,
+			RebalanceTimeout:  90 * time.Second,
+			MaxProcessingTime: 500 * time.Millisecond,
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, sarama.V3_6_0_0, saramaCfg.Version)
+	assert.Equal(t, 0, saramaCfg.Producer.Retry.Max)
+	assert.Equal(t, int32(10), saramaCfg.Consumer.Fetch.Min)
+	assert.Equal(t, int32(2<<20), saramaCfg.Consumer.Fetch.Default)
+	assert.Equal(t, int32(8<<20), saramaCfg.Consumer.Fetch.Max)
+	assert.Equal(t, 5*time.Second, saramaCfg.Net.DialTimeout)
+	assert.Equal(t, 15*time.Second, saramaCfg.Net.ReadTimeout)
+	assert.Equal(t, 15*time.Second, saramaCfg.Net.WriteTimeout)
+	assert.Equal(t, 45*time.Second, saramaCfg.Consumer.Group.Session.Timeout)
+	assert.Equal(t, 5*time.Second, saramaCfg.Consumer.Group.Heartbeat.Interval)
+	assert.Equal(t, 90*time.Second, saramaCfg.Consumer.Group.Rebalance.Timeout)
+	assert.Equal(t, 500*time.Millisecond, saramaCfg.Consumer.MaxProcessingTime)
+}
+
+func TestNewConfig_InvalidVersion(t *testing.T) {
+	_, err := newConfig(kafka.Config{Sarama: kafka.SaramaConfig{Version: "not-a-version"}})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid Kafka version")
+}
+
+func TestNewConfig_InvalidSettings(t *testing.T) {
+	_, err := newConfig(kafka.Config{
+		Sarama: kafka.SaramaConfig{
+			SessionTimeout:    10 * time.Second,
+			HeartbeatInterval: 20 * time.Second,
+		},
+	})
+
+	assert.Error(t, err)
+}
+
+func TestNewConsumerGroup_InvalidSaramaSettings(t *testing.T) {
+	_, err := NewConsumerGroup(kafka.Config{
+		Brokers:  "localhost:9092",
+		Assignor: "range",
+		Sarama:   kafka.SaramaConfig{Version: "bogus"},
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Error creating consumer group")
+}
@@ -10,8 +10,10 @@ import (
 )
 
 func NewConsumerGroup(cfg kafka.Config) (sarama.ConsumerGroup, error) {
-	saramaCfg := sarama.NewConfig()
-	saramaCfg.Version, _ = sarama.ParseKafkaVersion(sarama.DefaultVersion.String())
+	saramaCfg, err := newConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating consumer group: %v", err)
+	}
 
 	switch cfg.Assignor {
 	case "sticky":
@@ -27,6 +29,8 @@ func NewConsumerGroup(cfg kafka.Config) (sarama.ConsumerGroup, error) {
 	if cfg.Oldest {
 		saramaCfg.Consumer.Offsets.Initial = sarama.OffsetOldest
 	}
+	// Offsets are committed by the consumer group handler.
+	saramaCfg.Consumer.Offsets.AutoCommit.Enable = false
 
 	consumerGroup, err := sarama.NewConsumerGroup(strings.Split(cfg.Brokers, ","), cfg.ConsumerGroup, saramaCfg)
 	if err != nil {
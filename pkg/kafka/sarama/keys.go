@@ -0,0 +1,70 @@
+package sarama
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/IBM/sarama"
+
+	"music-service/pkg/kafka"
+)
+
+// LiveKeys reads the topic up to its end and returns the keys whose latest
+// message has a value, which for a compacted topic are the keys that were not
+// deleted by a tombstone. The keys are sorted.
+func LiveKeys(ctx context.Context, cfg kafka.Config, topic string) ([]string, error) {
+	client, err := NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating consumer: %v", err)
+	}
+	defer consumer.Close()
+
+	partitions, err := client.Partitions(topic)
+	if err != nil {
+		return nil, err
+	}
+
+	live := make(map[string]bool)
+	for _, partition := range partitions {
+		from, to, err := replayBounds(client, topic, partition, kafka.ReplayRange{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve the offsets of %s[%d]: %w", topic, partition, err)
+		}
+		if from >= to {
+			continue
+		}
+		if err := readKeys(ctx, consumer, topic, partition, from, to, live); err != nil {
+			return nil, fmt.Errorf("failed to read %s[%d]: %w", topic, partition, err)
+		}
+	}
+
+	keys := make([]string, 0, len(live))
+	for key, ok := range live {
+		if ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys, nil
+}
+
+// readKeys records for the key of every message from offset from to offset
+// to whether its latest message has a value.
+func readKeys(ctx context.Context, consumer sarama.Consumer, topic string, partition int32, from, to int64, live map[string]bool) error {
+	pc, err := consumer.ConsumePartition(topic, partition, from)
+	if err != nil {
+		return err
+	}
+	defer pc.Close()
+
+	return consumeRange(ctx, pc, to, func(msg *sarama.ConsumerMessage) {
+		live[string(msg.Key)] = msg.Value != nil
+	})
+}
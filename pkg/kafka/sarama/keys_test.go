@@ -0,0 +1,27 @@
+package sarama
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestReadKeys tests that keys whose latest message is a tombstone are not live
+func TestReadKeys(t *testing.T) {
+	consumer := mocks.NewConsumer(t, nil)
+	consumer.ExpectConsumePartition("album-snapshots", 0, 0).
+		YieldMessage(&sarama.ConsumerMessage{Key: []byte("1"), Value: []byte("a")}).
+		YieldMessage(&sarama.ConsumerMessage{Key: []byte("2"), Value: []byte("b")}).
+		YieldMessage(&sarama.ConsumerMessage{Key: []byte("1")}).
+		YieldMessage(&sarama.ConsumerMessage{Key: []byte("3")}).
+		YieldMessage(&sarama.ConsumerMessage{Key: []byte("3"), Value: []byte("c")})
+
+	live := map[string]bool{}
+	err := readKeys(context.Background(), consumer, "album-snapshots", 0, 0, 5, live)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"1": false, "2": true, "3": true}, live)
+}
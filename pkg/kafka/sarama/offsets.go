@@ -0,0 +1,24 @@
+package sarama
+
+import (
+	"github.com/IBM/sarama"
+
+	"music-service/pkg/kafka"
+)
+
+// ResolveOffset returns the offset the reset moves the partition to. A
+// timestamp after the last message resolves to the end of the partition.
+func ResolveOffset(client sarama.Client, topic string, partition int32, reset kafka.OffsetReset) (int64, error) {
+	switch {
+	case !reset.Timestamp.IsZero():
+		offset, err := client.GetOffset(topic, partition, reset.Timestamp.UnixMilli())
+		if err != nil || offset >= 0 {
+			return offset, err
+		}
+		return client.GetOffset(topic, partition, sarama.OffsetNewest)
+	case reset.Offset < 0:
+		return client.GetOffset(topic, partition, reset.Offset)
+	default:
+		return reset.Offset, nil
+	}
+}
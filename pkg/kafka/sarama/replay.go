@@ -0,0 +1,179 @@
+package sarama
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	"music-service/pkg/kafka"
+	"music-service/pkg/kafka/codec"
+	"music-service/pkg/kafka/message"
+)
+
+// replayIdleTimeout bounds the wait for offsets that hold no message, such as
+// compacted messages and transaction markers at the end of a range.
+const replayIdleTimeout = 2 * time.Second
+
+// Replay passes the messages of the range to the processor, one partition
+// after the other and in offset order. It reads with a standalone consumer,
+// so it neither joins nor commits for any consumer group.
+func Replay(ctx context.Context, cfg kafka.Config, topic string, rng kafka.ReplayRange, processor message.MessageValueProcessor) (kafka.ReplayResult, error) {
+	result := kafka.ReplayResult{Partitions: []kafka.PartitionReplay{}}
+
+	saramaCfg, err := newConfig(cfg)
+	if err != nil {
+		return result, fmt.Errorf("Error creating replay consumer: %v", err)
+	}
+	saramaCfg.Consumer.Return.Errors = true
+
+	client, err := sarama.NewClient(strings.Split(cfg.Brokers, ","), saramaCfg)
+	if err != nil {
+		return result, fmt.Errorf("Error creating replay consumer: %v", err)
+	}
+	defer client.Close()
+
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return result, fmt.Errorf("Error creating replay consumer: %v", err)
+	}
+	defer consumer.Close()
+
+	partitions := rng.Partitions
+	if len(partitions) == 0 {
+		if partitions, err = client.Partitions(topic); err != nil {
+			return result, err
+		}
+	}
+
+	for _, partition := range partitions {
+		from, to, err := replayBounds(client, topic, partition, rng)
+		if err != nil {
+			return result, fmt.Errorf("failed to resolve replay range of %s[%d]: %w", topic, partition, err)
+		}
+
+		replay := kafka.PartitionReplay{Topic: topic, Partition: partition, From: from, To: to}
+		if from < to {
+			if err := replayPartition(ctx, consumer, &replay, processor); err != nil {
+				return result, fmt.Errorf("failed to replay %s[%d]: %w", topic, partition, err)
+			}
+		}
+		result.Partitions = append(result.Partitions, replay)
+	}
+	return result, nil
+}
+
+func replayPartition(ctx context.Context, consumer sarama.Consumer, replay *kafka.PartitionReplay, processor message.MessageValueProcessor) error {
+	pc, err := consumer.ConsumePartition(replay.Topic, replay.Partition, replay.From)
+	if err != nil {
+		return err
+	}
+	defer pc.Close()
+
+	return consumeRange(ctx, pc, replay.To, func(msg *sarama.ConsumerMessage) {
+		if err := processor.Process(msg.Value, ContentType(msg.Headers), Principal(msg.Headers)); err != nil {
+			replay.Skipped++
+		} else {
+			replay.Processed++
+		}
+	})
+}
+
+// consumeRange passes the messages of the partition consumer before offset to
+// to handle, in offset order.
+func consumeRange(ctx context.Context, pc sarama.PartitionConsumer, to int64, handle func(msg *sarama.ConsumerMessage)) error {
+	idle := time.NewTimer(replayIdleTimeout)
+	defer idle.Stop()
+
+	for {
+		select {
+		case msg := <-pc.Messages():
+			if msg.Offset >= to {
+				return nil
+			}
+
+			handle(msg)
+			if msg.Offset+1 >= to {
+				return nil
+			}
+			idle.Reset(replayIdleTimeout)
+
+		case err := <-pc.Errors():
+			return err
+
+		case <-idle.C:
+			if pc.HighWaterMarkOffset() >= to {
+				// The remaining offsets of the range hold no messages.
+				return nil
+			}
+			idle.Reset(replayIdleTimeout)
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// replayBounds resolves the range to the offsets of the first message to
+// replay and of the first message after the replay.
+func replayBounds(client sarama.Client, topic string, partition int32, rng kafka.ReplayRange) (int64, int64, error) {
+	oldest, err := client.GetOffset(topic, partition, sarama.OffsetOldest)
+	if err != nil {
+		return 0, 0, err
+	}
+	high, err := client.GetOffset(topic, partition, sarama.OffsetNewest)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	from := max(rng.From, oldest)
+	if !rng.FromTime.IsZero() {
+		from, err = client.GetOffset(topic, partition, rng.FromTime.UnixMilli())
+		if err != nil {
+			return 0, 0, err
+		}
+		if from < 0 {
+			from = high
+		}
+	}
+
+	to := high
+	if rng.To > 0 {
+		to = min(rng.To, high)
+	}
+	if !rng.ToTime.IsZero() {
+		offset, err := client.GetOffset(topic, partition, rng.ToTime.UnixMilli())
+		if err != nil {
+			return 0, 0, err
+		}
+		if offset >= 0 {
+			to = min(to, offset)
+		}
+	}
+	return from, to, nil
+}
+
+// ContentType returns the value of the content type header, or an empty
+// string for messages produced without one.
+func ContentType(headers []*sarama.RecordHeader) string {
+	return Header(headers, codec.ContentTypeHeader)
+}
+
+// Principal returns the value of the principal header, or an empty string for
+// messages produced without one.
+func Principal(headers []*sarama.RecordHeader) string {
+	return Header(headers, message.PrincipalHeader)
+}
+
+// Header returns the value of the header, or an empty string for messages
+// produced without it.
+func Header(headers []*sarama.RecordHeader, key string) string {
+	for _, header := range headers {
+		if header != nil && string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,57 @@
+package sarama
+
+import (
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+
+	"music-service/pkg/kafka"
+)
+
+// TestReplayBounds tests that offsets and timestamps are resolved within the partition
+func TestReplayBounds(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(t),
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader("albums", 0, broker.BrokerID()),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).
+			SetOffset("albums", 0, sarama.OffsetOldest, 5).
+			SetOffset("albums", 0, sarama.OffsetNewest, 50).
+			SetOffset("albums", 0, from.UnixMilli(), 12).
+			SetOffset("albums", 0, to.UnixMilli(), -1),
+	})
+
+	client, err := sarama.NewClient([]string{broker.Addr()}, sarama.NewConfig())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	tests := []struct {
+		name     string
+		rng      kafka.ReplayRange
+		from, to int64
+	}{
+		{name: "whole partition", from: 5, to: 50},
+		{name: "offsets", rng: kafka.ReplayRange{From: 10, To: 20}, from: 10, to: 20},
+		{name: "offsets beyond the partition", rng: kafka.ReplayRange{From: 1, To: 90}, from: 5, to: 50},
+		{name: "timestamps", rng: kafka.ReplayRange{FromTime: from, ToTime: to}, from: 12, to: 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := replayBounds(client, "albums", 0, tt.rng)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.from, from)
+			assert.Equal(t, tt.to, to)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package sarama
+
+import (
+	"strings"
+
+	"github.com/IBM/sarama"
+	"github.com/xdg-go/scram"
+
+	"music-service/pkg/kafka"
+)
+
+func applySecurity(saramaCfg *sarama.Config, cfg kafka.SecurityConfig) error {
+	if cfg.TLS.Enabled {
+		tlsCfg, err := cfg.TLS.Load()
+		if err != nil {
+			return err
+		}
+		saramaCfg.Net.TLS.Enable = true
+		saramaCfg.Net.TLS.Config = tlsCfg
+	}
+
+	if !cfg.SASL.Enabled() {
+		return nil
+	}
+	if err := cfg.SASL.Validate(); err != nil {
+		return err
+	}
+
+	password, err := cfg.SASL.LoadPassword()
+	if err != nil {
+		return err
+	}
+
+	saramaCfg.Net.SASL.Enable = true
+	saramaCfg.Net.SASL.User = cfg.SASL.Username
+	saramaCfg.Net.SASL.Password = password
+
+	switch strings.ToUpper(cfg.SASL.Mechanism) {
+	case kafka.SASLMechanismPlain:
+		saramaCfg.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+	case kafka.SASLMechanismScramSHA256:
+		saramaCfg.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA256
+		saramaCfg.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+			return &scramClient{hashGenerator: scram.SHA256}
+		}
+	case kafka.SASLMechanismScramSHA512:
+		saramaCfg.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
+		saramaCfg.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+			return &scramClient{hashGenerator: scram.SHA512}
+		}
+	}
+	return nil
+}
+
+// scramClient adapts xdg-go/scram to sarama.SCRAMClient, which sarama leaves
+// to the application.
+type scramClient struct {
+	*scram.Client
+	*scram.ClientConversation
+	hashGenerator scram.HashGeneratorFcn
+}
+
+func (c *scramClient) Begin(userName, password, authzID string) error {
+	client, err := c.hashGenerator.NewClient(userName, password, authzID)
+	if err != nil {
+		return err
+	}
+	c.Client = client
+	c.ClientConversation = client.NewConversation()
+	return nil
+}
+
+func (c *scramClient) Step(challenge string) (string, error) {
+	return c.ClientConversation.Step(challenge)
+}
+
+func (c *scramClient) Done() bool {
+	return c.ClientConversation.Done()
+}
@@ -0,0 +1,77 @@
+package sarama
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+
+	"music-service/pkg/kafka"
+)
+
+func TestApplySecurity_Disabled(t *testing.T) {
+	saramaCfg := sarama.NewConfig()
+
+	err := applySecurity(saramaCfg, kafka.SecurityConfig{})
+
+	assert.NoError(t, err)
+	assert.False(t, saramaCfg.Net.TLS.Enable)
+	assert.False(t, saramaCfg.Net.SASL.Enable)
+}
+
+func TestApplySecurity_SASLMechanisms(t *testing.T) {
+	tests := []struct {
+		mechanism string
+		want      sarama.SASLMechanism
+		scram     bool
+	}{
+		{mechanism: "PLAIN", want: sarama.SASLTypePlaintext},
+		{mechanism: "SCRAM-SHA-256", want: sarama.SASLTypeSCRAMSHA256, scram: true},
+		{mechanism: "scram-sha-512", want: sarama.SASLTypeSCRAMSHA512, scram: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mechanism, func(t *testing.T) {
+			saramaCfg := sarama.NewConfig()
+
+			err := applySecurity(saramaCfg, kafka.SecurityConfig{
+				SASL: kafka.SASLConfig{Mechanism: tt.mechanism, Username: "user", Password: "secret"},
+			})
+
+			assert.NoError(t, err)
+			assert.True(t, saramaCfg.Net.SASL.Enable)
+			assert.Equal(t, tt.want, saramaCfg.Net.SASL.Mechanism)
+			assert.Equal(t, "user", saramaCfg.Net.SASL.User)
+			assert.Equal(t, "secret", saramaCfg.Net.SASL.Password)
+
+			if tt.scram {
+				client := saramaCfg.Net.SASL.SCRAMClientGeneratorFunc()
+				assert.NoError(t, client.Begin("user", "secret", ""))
+				first, err := client.Step("")
+				assert.NoError(t, err)
+				assert.Contains(t, first, "n=user")
+				assert.False(t, client.Done())
+			}
+		})
+	}
+}
+
+func TestApplySecurity_InvalidMechanism(t *testing.T) {
+	err := applySecurity(sarama.NewConfig(), kafka.SecurityConfig{
+		SASL: kafka.SASLConfig{Mechanism: "GSSAPI", Username: "user"},
+	})
+
+	assert.Error(t, err)
+}
+
+func TestApplySecurity_TLS(t *testing.T) {
+	saramaCfg := sarama.NewConfig()
+
+	err := applySecurity(saramaCfg, kafka.SecurityConfig{
+		TLS: kafka.TLSConfig{Enabled: true, InsecureSkipVerify: true},
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, saramaCfg.Net.TLS.Enable)
+	assert.True(t, saramaCfg.Net.TLS.Config.InsecureSkipVerify)
+}
@@ -10,10 +10,11 @@ import (
 )
 
 func NewSyncProducer(cfg kafka.Config) (sarama.SyncProducer, error) {
-	saramaCfg := sarama.NewConfig()
-	saramaCfg.Version, _ = sarama.ParseKafkaVersion(sarama.DefaultVersion.String())
+	saramaCfg, err := newConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating sync producer: %v", err)
+	}
 	saramaCfg.Producer.RequiredAcks = sarama.WaitForAll
-	saramaCfg.Producer.Retry.Max = 10
 	saramaCfg.Producer.Return.Successes = true
 
 	syncProducer, err := sarama.NewSyncProducer(strings.Split(cfg.Brokers, ","), saramaCfg)
@@ -0,0 +1,112 @@
+package kafka
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	SASLMechanismPlain       = "PLAIN"
+	SASLMechanismScramSHA256 = "SCRAM-SHA-256"
+	SASLMechanismScramSHA512 = "SCRAM-SHA-512"
+)
+
+type SecurityConfig struct {
+	TLS  TLSConfig  `yaml:"tls"`
+	SASL SASLConfig `yaml:"sasl"`
+}
+
+type TLSConfig struct {
+	Enabled            bool   `yaml:"enabled"`
+	CAFile             string `yaml:"ca_file"`
+	CertFile           string `yaml:"cert_file"`
+	KeyFile            string `yaml:"key_file"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+}
+
+// SASLConfig holds the SASL credentials. The password can be given inline or
+// read from PasswordFile, e.g. a mounted Kubernetes secret, which wins when
+// both are set.
+type SASLConfig struct {
+	Mechanism    string `yaml:"mechanism"`
+	Username     string `yaml:"username"`
+	Password     string `yaml:"password"`
+	PasswordFile string `yaml:"password_file"`
+}
+
+func (c SASLConfig) Enabled() bool {
+	return c.Mechanism != ""
+}
+
+func (c SASLConfig) Validate() error {
+	switch strings.ToUpper(c.Mechanism) {
+	case "":
+		return nil
+	case SASLMechanismPlain, SASLMechanismScramSHA256, SASLMechanismScramSHA512:
+	default:
+		return fmt.Errorf("unsupported SASL mechanism: %s", c.Mechanism)
+	}
+
+	if c.Username == "" {
+		return fmt.Errorf("SASL mechanism %s requires a username", c.Mechanism)
+	}
+	return nil
+}
+
+func (c SASLConfig) LoadPassword() (string, error) {
+	if c.PasswordFile == "" {
+		return c.Password, nil
+	}
+
+	data, err := os.ReadFile(c.PasswordFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read SASL password file: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// Protocol returns the Kafka security.protocol matching the settings.
+func (c SecurityConfig) Protocol() string {
+	switch {
+	case c.TLS.Enabled && c.SASL.Enabled():
+		return "SASL_SSL"
+	case c.TLS.Enabled:
+		return "SSL"
+	case c.SASL.Enabled():
+		return "SASL_PLAINTEXT"
+	default:
+		return "PLAINTEXT"
+	}
+}
+
+func (c TLSConfig) Load() (*tls.Config, error) {
+	tlsCfg := &tls.Config{
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	if c.CAFile != "" {
+		ca, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read TLS CA file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates found in TLS CA file %s", c.CAFile)
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsCfg, nil
+}
@@ -0,0 +1,106 @@
+package kafka
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecurityConfig_Protocol(t *testing.T) {
+	tests := []struct {
+		name   string
+		config SecurityConfig
+		want   string
+	}{
+		{
+			name:   "no security",
+			config: SecurityConfig{},
+			want:   "PLAINTEXT",
+		},
+		{
+			name:   "tls only",
+			config: SecurityConfig{TLS: TLSConfig{Enabled: true}},
+			want:   "SSL",
+		},
+		{
+			name:   "sasl only",
+			config: SecurityConfig{SASL: SASLConfig{Mechanism: SASLMechanismPlain}},
+			want:   "SASL_PLAINTEXT",
+		},
+		{
+			name: "tls and sasl",
+			config: SecurityConfig{
+				TLS:  TLSConfig{Enabled: true},
+				SASL: SASLConfig{Mechanism: SASLMechanismScramSHA512},
+			},
+			want: "SASL_SSL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.config.Protocol())
+		})
+	}
+}
+
+func TestSASLConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  SASLConfig
+		wantErr bool
+	}{
+		{name: "disabled", config: SASLConfig{}},
+		{name: "plain", config: SASLConfig{Mechanism: "PLAIN", Username: "user"}},
+		{name: "lower case scram", config: SASLConfig{Mechanism: "scram-sha-256", Username: "user"}},
+		{name: "missing username", config: SASLConfig{Mechanism: "PLAIN"}, wantErr: true},
+		{name: "unsupported mechanism", config: SASLConfig{Mechanism: "GSSAPI", Username: "user"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSASLConfig_LoadPassword(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "password")
+	err := os.WriteFile(path, []byte("from-file\n"), 0o600)
+	assert.NoError(t, err)
+
+	password, err := SASLConfig{Password: "inline"}.LoadPassword()
+	assert.NoError(t, err)
+	assert.Equal(t, "inline", password)
+
+	password, err = SASLConfig{Password: "inline", PasswordFile: path}.LoadPassword()
+	assert.NoError(t, err)
+	assert.Equal(t, "from-file", password)
+
+	_, err = SASLConfig{PasswordFile: filepath.Join(t.TempDir(), "missing")}.LoadPassword()
+	assert.Error(t, err)
+}
+
+func TestTLSConfig_Load(t *testing.T) {
+	tlsCfg, err := TLSConfig{Enabled: true, InsecureSkipVerify: true}.Load()
+	assert.NoError(t, err)
+	assert.True(t, tlsCfg.InsecureSkipVerify)
+	assert.Nil(t, tlsCfg.RootCAs)
+
+	_, err = TLSConfig{Enabled: true, CAFile: filepath.Join(t.TempDir(), "missing.pem")}.Load()
+	assert.Error(t, err)
+
+	invalid := filepath.Join(t.TempDir(), "ca.pem")
+	err = os.WriteFile(invalid, []byte("not a certificate"), 0o600)
+	assert.NoError(t, err)
+
+	_, err = TLSConfig{Enabled: true, CAFile: invalid}.Load()
+	assert.Error(t, err)
+}
@@ -0,0 +1,7 @@
+package payment
+
+// Config selects the payment provider. The fake provider, the default, is for
+// local testing and charges nobody.
+type Config struct {
+	Provider string `yaml:"provider"`
+}
@@ -0,0 +1,71 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// DeclinedToken is the token the fake provider declines, every other token is
+// charged.
+const DeclinedToken = "tok_declined"
+
+// FakeProvider keeps payments in memory. It is for local testing.
+type FakeProvider struct {
+	mu       sync.Mutex
+	next     int
+	payments map[string]*FakePayment
+}
+
+// FakePayment is a payment made with the fake provider.
+type FakePayment struct {
+	Charge
+	Refunded bool
+}
+
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{payments: map[string]*FakePayment{}}
+}
+
+func (p *FakeProvider) Charge(ctx context.Context, charge Charge) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if charge.Token == DeclinedToken {
+		return "", ErrDeclined
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.next++
+	id := fmt.Sprintf("fake_%d", p.next)
+	p.payments[id] = &FakePayment{Charge: charge}
+	return id, nil
+}
+
+// Refund refunds the payment. Refunding it again does nothing.
+func (p *FakeProvider) Refund(ctx context.Context, paymentId string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	payment, ok := p.payments[paymentId]
+	if !ok {
+		return ErrUnknownPayment
+	}
+	payment.Refunded = true
+	return nil
+}
+
+// Payment returns a copy of the payment, or false when it was not made.
+func (p *FakeProvider) Payment(paymentId string) (FakePayment, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	payment, ok := p.payments[paymentId]
+	if !ok {
+		return FakePayment{}, false
+	}
+	return *payment, true
+}
@@ -0,0 +1,47 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// ProviderFake is the name of the fake provider in the config.
+const ProviderFake = "fake"
+
+var (
+	// ErrDeclined is returned when the provider declines a charge.
+	ErrDeclined = errors.New("payment declined")
+	// ErrUnknownPayment is returned when a refunded payment was not made with
+	// the provider.
+	ErrUnknownPayment = errors.New("unknown payment")
+)
+
+// Charge is an amount to charge a customer, who authorized it with the token
+// of a payment method, for a reference such as an order.
+type Charge struct {
+	Reference string
+	Amount    decimal.Decimal
+	Currency  string
+	Token     string
+}
+
+// Provider charges and refunds customers. Implementations return ErrDeclined
+// when a charge is declined.
+type Provider interface {
+	// Charge charges the customer and returns the id of the payment.
+	Charge(ctx context.Context, charge Charge) (string, error)
+	// Refund refunds a payment in full.
+	Refund(ctx context.Context, paymentId string) error
+}
+
+// NewProvider returns the provider selected by the config.
+func NewProvider(cfg Config) (Provider, error) {
+	switch cfg.Provider {
+	case "", ProviderFake:
+		return NewFakeProvider(), nil
+	}
+	return nil, fmt.Errorf("unsupported payment provider %q", cfg.Provider)
+}
@@ -0,0 +1,59 @@
+package payment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	for _, name := range []string{"", ProviderFake} {
+		provider, err := NewProvider(Config{Provider: name})
+		require.NoError(t, err)
+		assert.IsType(t, &FakeProvider{}, provider)
+	}
+
+	_, err := NewProvider(Config{Provider: "stripe"})
+	assert.EqualError(t, err, `unsupported payment provider "stripe"`)
+}
+
+func TestFakeProvider_Charge(t *testing.T) {
+	provider := NewFakeProvider()
+	charge := Charge{Reference: "order-1", Amount: decimal.RequireFromString("19.99"), Currency: "USD", Token: "tok_visa"}
+
+	first, err := provider.Charge(context.Background(), charge)
+	require.NoError(t, err)
+	second, err := provider.Charge(context.Background(), charge)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+
+	payment, ok := provider.Payment(first)
+	require.True(t, ok)
+	assert.Equal(t, "order-1", payment.Reference)
+	assert.True(t, payment.Amount.Equal(charge.Amount))
+	assert.False(t, payment.Refunded)
+}
+
+func TestFakeProvider_Charge_Declined(t *testing.T) {
+	provider := NewFakeProvider()
+
+	_, err := provider.Charge(context.Background(), Charge{Amount: decimal.NewFromInt(5), Currency: "USD", Token: DeclinedToken})
+
+	assert.ErrorIs(t, err, ErrDeclined)
+}
+
+func TestFakeProvider_Refund(t *testing.T) {
+	provider := NewFakeProvider()
+	id, err := provider.Charge(context.Background(), Charge{Amount: decimal.NewFromInt(5), Currency: "USD", Token: "tok_visa"})
+	require.NoError(t, err)
+
+	require.NoError(t, provider.Refund(context.Background(), id))
+	require.NoError(t, provider.Refund(context.Background(), id))
+	payment, _ := provider.Payment(id)
+	assert.True(t, payment.Refunded)
+
+	assert.ErrorIs(t, provider.Refund(context.Background(), "fake_404"), ErrUnknownPayment)
+}
@@ -1,5 +1,7 @@
 package postgres
 
+import "fmt"
+
 type Config struct {
 	DriverName string `yaml:"driver_name"`
 	User       string `yaml:"user"`
@@ -8,3 +10,8 @@ type Config struct {
 	Password   string `yaml:"password"`
 	Host       string `yaml:"host"`
 }
+
+// DataSourceName returns the connection string of lib/pq.
+func (c Config) DataSourceName() string {
+	return fmt.Sprintf("user=%s dbname=%s sslmode=%s password=%s host=%s", c.User, c.DBName, c.SSLMode, c.Password, c.Host)
+}
@@ -102,3 +102,18 @@ func TestConfig_ValidPostgresConfig(t *testing.T) {
 		t.Error("Essential config fields should not be empty")
 	}
 }
+
+func TestConfig_DataSourceName(t *testing.T) {
+	cfg := Config{
+		User:     "user",
+		DBName:   "db",
+		SSLMode:  "disable",
+		Password: "pass",
+		Host:     "host",
+	}
+
+	expected := "user=user dbname=db sslmode=disable password=pass host=host"
+	if dsn := cfg.DataSourceName(); dsn != expected {
+		t.Errorf("Expected data source name '%s', got '%s'", expected, dsn)
+	}
+}
@@ -1,8 +1,6 @@
 package db
 
 import (
-	"fmt"
-
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 
@@ -10,17 +8,7 @@ import (
 )
 
 func NewDB(cfg postgres.Config) (*sqlx.DB, error) {
-	dataSourceNameTemplate := "user=%s dbname=%s sslmode=%s password=%s host=%s"
-	dataSourceName :=
-		fmt.Sprintf(
-			dataSourceNameTemplate,
-			cfg.User,
-			cfg.DBName,
-			cfg.SSLMode,
-			cfg.Password,
-			cfg.Host,
-		)
-	db, err := sqlx.Connect(cfg.DriverName, dataSourceName)
+	db, err := sqlx.Connect(cfg.DriverName, cfg.DataSourceName())
 	if err != nil {
 		return nil, err
 	}
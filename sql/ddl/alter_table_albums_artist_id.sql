@@ -0,0 +1,74 @@
+-- Migration: relate music.albums to music.artists
+-- Requires sql/ddl/create_table_artists.sql.
+
+BEGIN;
+
+-- One artist per artist string, ignoring case and surrounding whitespace,
+-- named after the most common spelling.
+INSERT INTO music.artists (name)
+SELECT DISTINCT ON (lower(name)) name
+FROM (
+    SELECT btrim(artist) AS name, count(*) AS albums
+    FROM music.albums
+    WHERE btrim(artist) <> ''
+    GROUP BY btrim(artist)
+) spellings
+ORDER BY lower(name), albums DESC, name
+ON CONFLICT ((lower(name))) DO NOTHING;
+
+ALTER TABLE IF EXISTS music.albums
+    ADD COLUMN IF NOT EXISTS artist_id integer
+    CONSTRAINT albums_artist_id_fkey REFERENCES music.artists (id) ON DELETE RESTRICT;
+
+UPDATE music.albums
+SET artist_id = artists.id, artist = artists.name
+FROM music.artists
+WHERE lower(artists.name) = lower(btrim(albums.artist));
+
+CREATE INDEX IF NOT EXISTS albums_artist_id_idx
+    ON music.albums (artist_id);
+
+-- Writers that only know the artist name, such as the Kafka consumer and
+-- postgres-insert, keep working: the artist is looked up by name, or created,
+-- and the name is always copied from the artist.
+CREATE OR REPLACE FUNCTION music.resolve_album_artist()
+    RETURNS trigger
+    LANGUAGE plpgsql
+AS $$
+BEGIN
+    IF NEW.artist_id IS NULL OR (TG_OP = 'UPDATE' AND NEW.artist_id = OLD.artist_id AND NEW.artist IS DISTINCT FROM OLD.artist) THEN
+        INSERT INTO music.artists (name) VALUES (btrim(NEW.artist))
+        ON CONFLICT ((lower(name))) DO NOTHING;
+        SELECT id INTO NEW.artist_id FROM music.artists WHERE lower(name) = lower(btrim(NEW.artist));
+    END IF;
+    SELECT name INTO STRICT NEW.artist FROM music.artists WHERE id = NEW.artist_id;
+    RETURN NEW;
+END;
+$$;
+
+ALTER FUNCTION music.resolve_album_artist()
+    OWNER TO ryandayrit;
+
+CREATE OR REPLACE TRIGGER albums_artist
+    BEFORE INSERT OR UPDATE OF artist, artist_id ON music.albums
+    FOR EACH ROW EXECUTE FUNCTION music.resolve_album_artist();
+
+CREATE OR REPLACE FUNCTION music.rename_artist_albums()
+    RETURNS trigger
+    LANGUAGE plpgsql
+AS $$
+BEGIN
+    UPDATE music.albums SET artist = NEW.name WHERE artist_id = NEW.id;
+    RETURN NULL;
+END;
+$$;
+
+ALTER FUNCTION music.rename_artist_albums()
+    OWNER TO ryandayrit;
+
+CREATE OR REPLACE TRIGGER artists_rename
+    AFTER UPDATE OF name ON music.artists
+    FOR EACH ROW WHEN (NEW.name IS DISTINCT FROM OLD.name)
+    EXECUTE FUNCTION music.rename_artist_albums();
+
+COMMIT;
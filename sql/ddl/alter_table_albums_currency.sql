@@ -0,0 +1,16 @@
+-- Migration: currency of the price of music.albums
+--
+-- Existing prices are in US dollars. The services check that the currency is
+-- an ISO 4217 code in circulation.
+
+BEGIN;
+
+ALTER TABLE IF EXISTS music.albums
+    ADD COLUMN IF NOT EXISTS currency text COLLATE pg_catalog."default" NOT NULL DEFAULT 'USD'
+    CONSTRAINT albums_currency_check CHECK (currency ~ '^[A-Z]{3}$');
+
+-- music.search_albums returns the currency from now on; recreate it from
+-- sql/ddl/create_function_search_albums.sql.
+DROP FUNCTION IF EXISTS music.search_albums(text, integer);
+
+COMMIT;
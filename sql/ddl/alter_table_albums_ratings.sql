@@ -0,0 +1,16 @@
+-- Migration: average rating and number of ratings of music.albums
+--
+-- Both columns are kept by the triggers of sql/ddl/create_table_users.sql and
+-- are not written by the services.
+
+BEGIN;
+
+ALTER TABLE IF EXISTS music.albums
+    ADD COLUMN IF NOT EXISTS average_rating numeric(3,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS rating_count integer NOT NULL DEFAULT 0;
+
+-- music.search_albums returns the ratings from now on; recreate it from
+-- sql/ddl/create_function_search_albums.sql.
+DROP FUNCTION IF EXISTS music.search_albums(text, integer);
+
+COMMIT;
@@ -0,0 +1,14 @@
+-- Migration: release date and record label of music.albums
+-- Requires sql/ddl/create_table_labels.sql.
+
+BEGIN;
+
+ALTER TABLE IF EXISTS music.albums
+    ADD COLUMN IF NOT EXISTS release_date date,
+    ADD COLUMN IF NOT EXISTS label_id integer
+    CONSTRAINT albums_label_id_fkey REFERENCES music.labels (id) ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS albums_label_id_idx
+    ON music.albums (label_id);
+
+COMMIT;
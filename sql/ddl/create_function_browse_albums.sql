@@ -0,0 +1,98 @@
+-- Functions: music.find_albums, music.album_facets
+-- Requires sql/ddl/create_table_genres.sql and sql/ddl/create_table_tags.sql.
+--
+-- Both the ORM and the Sqlx repositories browse albums through these functions
+-- so that the REST and gRPC APIs filter and count alike. A zero or empty
+-- argument does not filter.
+
+CREATE OR REPLACE FUNCTION music.find_albums(
+    artist_id integer,
+    genre_id integer,
+    tag text,
+    min_price numeric,
+    max_price numeric)
+    RETURNS SETOF music.albums
+    LANGUAGE sql
+    STABLE
+AS $$
+    SELECT albums.*
+    FROM music.albums
+    WHERE (coalesce(find_albums.artist_id, 0) = 0 OR albums.artist_id = find_albums.artist_id)
+      AND (coalesce(find_albums.genre_id, 0) = 0 OR albums.id IN (
+          SELECT album_genres.album_id
+          FROM music.album_genres
+          WHERE album_genres.genre_id IN (SELECT music.genre_subtree(find_albums.genre_id))))
+      AND (coalesce(find_albums.tag, '') = '' OR albums.id IN (
+          SELECT album_tags.album_id
+          FROM music.album_tags
+          JOIN music.tags ON tags.id = album_tags.tag_id
+          WHERE lower(tags.name) = lower(btrim(find_albums.tag))))
+      AND (coalesce(find_albums.min_price, 0) = 0 OR albums.price >= find_albums.min_price)
+      AND (coalesce(find_albums.max_price, 0) = 0 OR albums.price <= find_albums.max_price)
+    ORDER BY albums.id;
+$$;
+
+ALTER FUNCTION music.find_albums(integer, integer, text, numeric, numeric)
+    OWNER TO ryandayrit;
+
+-- Counts the albums of music.find_albums per genre, including the albums of
+-- the genres below it, per tag, per artist and per price bucket. Price
+-- buckets are [lower_price, upper_price) with an upper_price of 0 for the
+-- last, open bucket.
+CREATE OR REPLACE FUNCTION music.album_facets(
+    artist_id integer,
+    genre_id integer,
+    tag text,
+    min_price numeric,
+    max_price numeric)
+    RETURNS TABLE (
+        facet text,
+        id integer,
+        name text,
+        parent_id integer,
+        lower_price numeric,
+        upper_price numeric,
+        count integer)
+    LANGUAGE sql
+    STABLE
+AS $$
+    WITH found AS (
+        SELECT * FROM music.find_albums(artist_id, genre_id, tag, min_price, max_price)
+    ),
+    bounds (bounds) AS (
+        VALUES ('{0,10,20,50,100}'::numeric[])
+    )
+    SELECT * FROM (
+        SELECT 'genre', genres.id, genres.name, coalesce(genres.parent_id, 0), 0::numeric, 0::numeric,
+               count(DISTINCT found.id)::integer
+        FROM music.genres
+        CROSS JOIN LATERAL music.genre_subtree(genres.id) AS subtree (genre_id)
+        JOIN music.album_genres ON album_genres.genre_id = subtree.genre_id
+        JOIN found ON found.id = album_genres.album_id
+        GROUP BY genres.id
+        UNION ALL
+        SELECT 'tag', tags.id, tags.name, 0, 0::numeric, 0::numeric, count(*)::integer
+        FROM music.tags
+        JOIN music.album_tags ON album_tags.tag_id = tags.id
+        JOIN found ON found.id = album_tags.album_id
+        GROUP BY tags.id
+        UNION ALL
+        SELECT 'artist', coalesce(found.artist_id, 0), min(found.artist), 0, 0::numeric, 0::numeric, count(*)::integer
+        FROM found
+        GROUP BY found.artist_id
+        UNION ALL
+        SELECT 'price', buckets.bucket, '', 0,
+               coalesce(bounds.bounds[buckets.bucket], 0), coalesce(bounds.bounds[buckets.bucket + 1], 0),
+               buckets.count
+        FROM (
+            SELECT width_bucket(found.price, bounds.bounds) AS bucket, count(*)::integer AS count
+            FROM found, bounds
+            WHERE found.price IS NOT NULL
+            GROUP BY 1
+        ) buckets, bounds
+    ) facets (facet, id, name, parent_id, lower_price, upper_price, count)
+    ORDER BY facet, count DESC, id;
+$$;
+
+ALTER FUNCTION music.album_facets(integer, integer, text, numeric, numeric)
+    OWNER TO ryandayrit;
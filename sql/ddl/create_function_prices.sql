@@ -0,0 +1,107 @@
+-- Functions: music.set_album_price, music.remove_album_price,
+-- music.apply_scheduled_prices
+-- Requires sql/ddl/create_table_prices.sql.
+--
+-- The services change prices through these functions so that the history
+-- records who changed a price and when the change took effect.
+
+-- Sets the price of an album in a currency, the price of the album itself when
+-- the currency is the currency of the album. Raises foreign_key_violation when
+-- the album does not exist.
+CREATE OR REPLACE FUNCTION music.set_album_price(
+    album_id integer,
+    currency text,
+    price numeric,
+    changed_by text,
+    effective_at timestamp with time zone DEFAULT now())
+    RETURNS void
+    LANGUAGE plpgsql
+AS $$
+DECLARE
+    album_currency text;
+BEGIN
+    SELECT albums.currency INTO album_currency
+    FROM music.albums
+    WHERE albums.id = set_album_price.album_id
+    FOR UPDATE;
+    IF NOT FOUND THEN
+        RAISE foreign_key_violation USING MESSAGE = format('album %s does not exist', set_album_price.album_id);
+    END IF;
+
+    PERFORM set_config('music.changed_by', set_album_price.changed_by, true);
+    PERFORM set_config('music.effective_at', set_album_price.effective_at::text, true);
+    IF album_currency = set_album_price.currency THEN
+        UPDATE music.albums
+        SET price = set_album_price.price
+        WHERE albums.id = set_album_price.album_id;
+    ELSE
+        INSERT INTO music.album_prices (album_id, currency, price)
+        VALUES (set_album_price.album_id, set_album_price.currency, set_album_price.price)
+        ON CONFLICT ON CONSTRAINT album_prices_pkey DO UPDATE SET price = EXCLUDED.price;
+    END IF;
+    PERFORM set_config('music.changed_by', '', true);
+    PERFORM set_config('music.effective_at', '', true);
+END;
+$$;
+
+ALTER FUNCTION music.set_album_price(integer, text, numeric, text, timestamp with time zone)
+    OWNER TO ryandayrit;
+
+-- Removes the price of an album in a currency and returns whether there was
+-- one. The price in the currency of the album cannot be removed.
+CREATE OR REPLACE FUNCTION music.remove_album_price(
+    album_id integer,
+    currency text,
+    changed_by text)
+    RETURNS boolean
+    LANGUAGE plpgsql
+AS $$
+BEGIN
+    IF EXISTS (
+        SELECT FROM music.albums
+        WHERE albums.id = remove_album_price.album_id AND albums.currency = remove_album_price.currency) THEN
+        RAISE check_violation USING MESSAGE = 'the price in the currency of the album cannot be removed';
+    END IF;
+
+    PERFORM set_config('music.changed_by', remove_album_price.changed_by, true);
+    DELETE FROM music.album_prices
+    WHERE album_prices.album_id = remove_album_price.album_id
+      AND album_prices.currency = remove_album_price.currency;
+    PERFORM set_config('music.changed_by', '', true);
+    RETURN FOUND;
+END;
+$$;
+
+ALTER FUNCTION music.remove_album_price(integer, text, text)
+    OWNER TO ryandayrit;
+
+-- Applies the scheduled price changes that are due, in the order they take
+-- effect, and returns how many were applied. Changes locked by a concurrent
+-- call are left to it.
+CREATE OR REPLACE FUNCTION music.apply_scheduled_prices()
+    RETURNS integer
+    LANGUAGE plpgsql
+AS $$
+DECLARE
+    due music.scheduled_prices;
+    applied integer := 0;
+BEGIN
+    FOR due IN
+        SELECT *
+        FROM music.scheduled_prices
+        WHERE scheduled_prices.applied_at IS NULL AND scheduled_prices.effective_at <= now()
+        ORDER BY scheduled_prices.effective_at, scheduled_prices.id
+        FOR UPDATE SKIP LOCKED
+    LOOP
+        PERFORM music.set_album_price(due.album_id, due.currency, due.price, due.created_by, due.effective_at);
+        UPDATE music.scheduled_prices
+        SET applied_at = now()
+        WHERE scheduled_prices.id = due.id;
+        applied := applied + 1;
+    END LOOP;
+    RETURN applied;
+END;
+$$;
+
+ALTER FUNCTION music.apply_scheduled_prices()
+    OWNER TO ryandayrit;
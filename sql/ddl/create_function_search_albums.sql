@@ -0,0 +1,104 @@
+-- Functions: music.album_search_vector, music.html_escape, music.search_albums
+--
+-- Both the ORM and the Sqlx repositories search albums through
+-- music.search_albums so that the REST and gRPC APIs rank alike. Titles and
+-- artist names are matched as words through a GIN index on their tsvector
+-- and, for typos and partial words, by trigram similarity through GIN
+-- trigram indexes.
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- The 'simple' configuration neither stems nor drops stop words, since titles
+-- and names are not prose in one language.
+CREATE OR REPLACE FUNCTION music.album_search_vector(title text, artist text)
+    RETURNS tsvector
+    LANGUAGE sql
+    IMMUTABLE
+    PARALLEL SAFE
+AS $$
+    SELECT setweight(to_tsvector('simple', coalesce(title, '')), 'A')
+        || setweight(to_tsvector('simple', coalesce(artist, '')), 'B');
+$$;
+
+ALTER FUNCTION music.album_search_vector(text, text)
+    OWNER TO ryandayrit;
+
+CREATE INDEX IF NOT EXISTS albums_search_vector_idx
+    ON music.albums USING gin (music.album_search_vector(title, artist));
+
+CREATE INDEX IF NOT EXISTS albums_title_trgm_idx
+    ON music.albums USING gin (title gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS albums_artist_trgm_idx
+    ON music.albums USING gin (artist gin_trgm_ops);
+
+-- Escapes the text for HTML, so that highlights mark up only the matching
+-- words and never markup in titles or names.
+CREATE OR REPLACE FUNCTION music.html_escape(text text)
+    RETURNS text
+    LANGUAGE sql
+    IMMUTABLE
+    PARALLEL SAFE
+AS $$
+    SELECT replace(replace(replace(replace(replace(html_escape.text,
+        '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;');
+$$;
+
+ALTER FUNCTION music.html_escape(text)
+    OWNER TO ryandayrit;
+
+-- Returns at most max_results albums matching the query, best first. The rank
+-- is the full-text rank or, when higher, the trigram similarity of the title
+-- or the artist name. The highlights are the title and the artist name,
+-- escaped for HTML, with the words matching the query in <mark> tags; albums
+-- found only by similarity have no marked words.
+CREATE OR REPLACE FUNCTION music.search_albums(
+    query text,
+    max_results integer)
+    RETURNS TABLE (
+        id integer,
+        title text,
+        artist_id integer,
+        artist text,
+        price numeric,
+        currency text,
+        label_id integer,
+        release_date date,
+        average_rating numeric,
+        rating_count integer,
+        rank real,
+        title_highlight text,
+        artist_highlight text)
+    LANGUAGE sql
+    STABLE
+AS $$
+    WITH search AS (
+        SELECT btrim(search_albums.query) AS text,
+               websearch_to_tsquery('simple', search_albums.query) AS tsquery
+    )
+    SELECT albums.id, albums.title, albums.artist_id, albums.artist, albums.price,
+           albums.currency, albums.label_id, albums.release_date,
+           albums.average_rating, albums.rating_count,
+           greatest(
+               ts_rank(music.album_search_vector(albums.title, albums.artist), search.tsquery),
+               similarity(albums.title, search.text),
+               word_similarity(search.text, albums.title),
+               similarity(albums.artist, search.text),
+               word_similarity(search.text, albums.artist))::real AS rank,
+           ts_headline('simple', music.html_escape(albums.title), search.tsquery,
+               'StartSel=<mark>, StopSel=</mark>, HighlightAll=true'),
+           ts_headline('simple', music.html_escape(albums.artist), search.tsquery,
+               'StartSel=<mark>, StopSel=</mark>, HighlightAll=true')
+    FROM music.albums, search
+    WHERE search.text <> ''
+      AND (music.album_search_vector(albums.title, albums.artist) @@ search.tsquery
+        OR albums.title % search.text
+        OR search.text <% albums.title
+        OR albums.artist % search.text
+        OR search.text <% albums.artist)
+    ORDER BY rank DESC, albums.id
+    LIMIT search_albums.max_results;
+$$;
+
+ALTER FUNCTION music.search_albums(text, integer)
+    OWNER TO ryandayrit;
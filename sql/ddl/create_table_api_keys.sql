@@ -0,0 +1,31 @@
+-- Table: music.api_keys
+
+-- DROP TABLE IF EXISTS music.api_keys;
+
+-- API keys of service-to-service clients that cannot get tokens, such as
+-- batch jobs. Only the SHA-256 hash of a key is kept, the key itself is shown
+-- once when it is created. A key is looked up by its prefix, the part after
+-- msk_, and acts with its scopes, the roles of policy.yaml. Revoked and
+-- expired keys are rejected but kept for auditing.
+CREATE TABLE IF NOT EXISTS music.api_keys
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY,
+    name text COLLATE pg_catalog."default" NOT NULL,
+    prefix text COLLATE pg_catalog."default" NOT NULL,
+    key_hash bytea NOT NULL,
+    scopes text[] COLLATE pg_catalog."default" NOT NULL,
+    expires_at timestamp with time zone,
+    last_used_at timestamp with time zone,
+    revoked_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT api_keys_pkey PRIMARY KEY (id),
+    CONSTRAINT api_keys_prefix_key UNIQUE (prefix),
+    CONSTRAINT api_keys_name_check CHECK (name = btrim(name) AND name <> ''),
+    CONSTRAINT api_keys_key_hash_check CHECK (octet_length(key_hash) = 32),
+    CONSTRAINT api_keys_scopes_check CHECK (cardinality(scopes) > 0)
+)
+
+TABLESPACE pg_default;
+
+ALTER TABLE IF EXISTS music.api_keys
+    OWNER to ryandayrit;
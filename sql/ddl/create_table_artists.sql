@@ -0,0 +1,22 @@
+-- Table: music.artists
+
+-- DROP TABLE IF EXISTS music.artists;
+
+CREATE TABLE IF NOT EXISTS music.artists
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY,
+    name text COLLATE pg_catalog."default" NOT NULL,
+    country text COLLATE pg_catalog."default",
+    bio text COLLATE pg_catalog."default",
+    CONSTRAINT artists_pkey PRIMARY KEY (id),
+    CONSTRAINT artists_name_check CHECK (name = btrim(name) AND name <> '')
+)
+
+TABLESPACE pg_default;
+
+-- Artists differing only in case are the same artist.
+CREATE UNIQUE INDEX IF NOT EXISTS artists_name_key
+    ON music.artists (lower(name));
+
+ALTER TABLE IF EXISTS music.artists
+    OWNER to ryandayrit;
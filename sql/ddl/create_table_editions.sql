@@ -0,0 +1,32 @@
+-- Table: music.editions
+
+-- DROP TABLE IF EXISTS music.editions;
+
+-- The formats an album is sold in. The barcode is a UPC-A, EAN-8 or EAN-13
+-- whose check digit is validated by the services.
+CREATE TABLE IF NOT EXISTS music.editions
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY,
+    album_id integer NOT NULL,
+    format text COLLATE pg_catalog."default" NOT NULL,
+    name text COLLATE pg_catalog."default",
+    sku text COLLATE pg_catalog."default" NOT NULL,
+    barcode text COLLATE pg_catalog."default",
+    catalog_number text COLLATE pg_catalog."default",
+    price numeric(10,2) NOT NULL DEFAULT 0.00,
+    CONSTRAINT editions_pkey PRIMARY KEY (id),
+    CONSTRAINT editions_album_id_fkey FOREIGN KEY (album_id) REFERENCES music.albums (id) ON DELETE CASCADE,
+    CONSTRAINT editions_sku_key UNIQUE (sku),
+    CONSTRAINT editions_barcode_key UNIQUE (barcode),
+    CONSTRAINT editions_format_check CHECK (format IN ('vinyl', 'cd', 'cassette', 'digital')),
+    CONSTRAINT editions_barcode_check CHECK (barcode ~ '^([0-9]{8}|[0-9]{12}|[0-9]{13})$'),
+    CONSTRAINT editions_price_check CHECK (price >= 0)
+)
+
+TABLESPACE pg_default;
+
+CREATE INDEX IF NOT EXISTS editions_album_id_idx
+    ON music.editions (album_id);
+
+ALTER TABLE IF EXISTS music.editions
+    OWNER to ryandayrit;
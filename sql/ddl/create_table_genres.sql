@@ -0,0 +1,79 @@
+-- Table: music.genres
+
+-- DROP TABLE IF EXISTS music.album_genres;
+-- DROP TABLE IF EXISTS music.genres;
+
+CREATE TABLE IF NOT EXISTS music.genres
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY,
+    name text COLLATE pg_catalog."default" NOT NULL,
+    parent_id integer,
+    CONSTRAINT genres_pkey PRIMARY KEY (id),
+    CONSTRAINT genres_parent_id_fkey FOREIGN KEY (parent_id) REFERENCES music.genres (id) ON DELETE RESTRICT,
+    CONSTRAINT genres_name_check CHECK (name = btrim(name) AND name <> '')
+)
+
+TABLESPACE pg_default;
+
+-- Sibling genres differing only in case are the same genre.
+CREATE UNIQUE INDEX IF NOT EXISTS genres_name_key
+    ON music.genres (coalesce(parent_id, 0), lower(name));
+
+ALTER TABLE IF EXISTS music.genres
+    OWNER to ryandayrit;
+
+CREATE TABLE IF NOT EXISTS music.album_genres
+(
+    album_id integer NOT NULL,
+    genre_id integer NOT NULL,
+    CONSTRAINT album_genres_pkey PRIMARY KEY (album_id, genre_id),
+    CONSTRAINT album_genres_album_id_fkey FOREIGN KEY (album_id) REFERENCES music.albums (id) ON DELETE CASCADE,
+    CONSTRAINT album_genres_genre_id_fkey FOREIGN KEY (genre_id) REFERENCES music.genres (id) ON DELETE CASCADE
+)
+
+TABLESPACE pg_default;
+
+CREATE INDEX IF NOT EXISTS album_genres_genre_id_idx
+    ON music.album_genres (genre_id);
+
+ALTER TABLE IF EXISTS music.album_genres
+    OWNER to ryandayrit;
+
+-- The genre and every genre below it, so that Jazz includes Hard Bop.
+CREATE OR REPLACE FUNCTION music.genre_subtree(root integer)
+    RETURNS SETOF integer
+    LANGUAGE sql
+    STABLE
+AS $$
+    WITH RECURSIVE subtree (id) AS (
+        SELECT root
+        UNION
+        SELECT genres.id FROM music.genres JOIN subtree ON genres.parent_id = subtree.id
+    )
+    SELECT id FROM subtree;
+$$;
+
+ALTER FUNCTION music.genre_subtree(integer)
+    OWNER TO ryandayrit;
+
+-- A genre cannot be moved below itself.
+CREATE OR REPLACE FUNCTION music.check_genre_parent()
+    RETURNS trigger
+    LANGUAGE plpgsql
+AS $$
+BEGIN
+    IF NEW.parent_id IN (SELECT music.genre_subtree(NEW.id)) THEN
+        RAISE EXCEPTION 'genre % cannot be below itself', NEW.id
+            USING ERRCODE = 'check_violation';
+    END IF;
+    RETURN NEW;
+END;
+$$;
+
+ALTER FUNCTION music.check_genre_parent()
+    OWNER TO ryandayrit;
+
+CREATE OR REPLACE TRIGGER genres_parent
+    BEFORE UPDATE OF parent_id ON music.genres
+    FOR EACH ROW WHEN (NEW.parent_id IS NOT NULL)
+    EXECUTE FUNCTION music.check_genre_parent();
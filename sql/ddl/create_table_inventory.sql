@@ -0,0 +1,79 @@
+-- Tables: music.warehouses, music.stock, music.stock_reservations
+
+-- DROP TABLE IF EXISTS music.stock_reservations;
+-- DROP TABLE IF EXISTS music.stock;
+-- DROP TABLE IF EXISTS music.warehouses;
+
+CREATE TABLE IF NOT EXISTS music.warehouses
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY,
+    code text COLLATE pg_catalog."default" NOT NULL,
+    name text COLLATE pg_catalog."default" NOT NULL,
+    CONSTRAINT warehouses_pkey PRIMARY KEY (id),
+    CONSTRAINT warehouses_code_key UNIQUE (code)
+)
+
+TABLESPACE pg_default;
+
+ALTER TABLE IF EXISTS music.warehouses
+    OWNER to ryandayrit;
+
+-- The stock of an album format in a warehouse. Reserved units are on hand but
+-- promised to a reservation, so only on_hand - reserved units can be
+-- reserved. The services reserve with conditional updates, the checks catch
+-- any write that would oversell.
+CREATE TABLE IF NOT EXISTS music.stock
+(
+    album_id integer NOT NULL,
+    format text COLLATE pg_catalog."default" NOT NULL,
+    warehouse_id integer NOT NULL,
+    on_hand integer NOT NULL DEFAULT 0,
+    reserved integer NOT NULL DEFAULT 0,
+    low_stock_threshold integer NOT NULL DEFAULT 0,
+    CONSTRAINT stock_pkey PRIMARY KEY (album_id, format, warehouse_id),
+    CONSTRAINT stock_album_id_fkey FOREIGN KEY (album_id) REFERENCES music.albums (id) ON DELETE CASCADE,
+    CONSTRAINT stock_warehouse_id_fkey FOREIGN KEY (warehouse_id) REFERENCES music.warehouses (id),
+    CONSTRAINT stock_format_check CHECK (format IN ('vinyl', 'cd', 'cassette', 'digital')),
+    CONSTRAINT stock_on_hand_check CHECK (on_hand >= 0),
+    CONSTRAINT stock_reserved_check CHECK (reserved >= 0 AND reserved <= on_hand),
+    CONSTRAINT stock_low_stock_threshold_check CHECK (low_stock_threshold >= 0)
+)
+
+TABLESPACE pg_default;
+
+CREATE INDEX IF NOT EXISTS stock_warehouse_id_idx
+    ON music.stock (warehouse_id);
+
+ALTER TABLE IF EXISTS music.stock
+    OWNER to ryandayrit;
+
+-- Units held for a customer until the reservation is committed, which ships
+-- them, or released. Pending reservations past expires_at are expired by
+-- inventory-expirer, which releases their units. Committed reservations of an
+-- order refunded before it shipped are returned, putting their units back on
+-- hand.
+CREATE TABLE IF NOT EXISTS music.stock_reservations
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY,
+    album_id integer NOT NULL,
+    format text COLLATE pg_catalog."default" NOT NULL,
+    warehouse_id integer NOT NULL,
+    quantity integer NOT NULL,
+    status text COLLATE pg_catalog."default" NOT NULL DEFAULT 'pending',
+    expires_at timestamp with time zone NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT stock_reservations_pkey PRIMARY KEY (id),
+    CONSTRAINT stock_reservations_stock_fkey FOREIGN KEY (album_id, format, warehouse_id)
+        REFERENCES music.stock (album_id, format, warehouse_id) ON DELETE CASCADE,
+    CONSTRAINT stock_reservations_quantity_check CHECK (quantity > 0),
+    CONSTRAINT stock_reservations_status_check CHECK (status IN ('pending', 'committed', 'released', 'expired', 'returned'))
+)
+
+TABLESPACE pg_default;
+
+CREATE INDEX IF NOT EXISTS stock_reservations_pending_idx
+    ON music.stock_reservations (expires_at)
+    WHERE status = 'pending';
+
+ALTER TABLE IF EXISTS music.stock_reservations
+    OWNER to ryandayrit;
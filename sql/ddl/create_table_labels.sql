@@ -0,0 +1,21 @@
+-- Table: music.labels
+
+-- DROP TABLE IF EXISTS music.labels;
+
+CREATE TABLE IF NOT EXISTS music.labels
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY,
+    name text COLLATE pg_catalog."default" NOT NULL,
+    country text COLLATE pg_catalog."default",
+    CONSTRAINT labels_pkey PRIMARY KEY (id),
+    CONSTRAINT labels_name_check CHECK (name = btrim(name) AND name <> '')
+)
+
+TABLESPACE pg_default;
+
+-- Labels differing only in case are the same label.
+CREATE UNIQUE INDEX IF NOT EXISTS labels_name_key
+    ON music.labels (lower(name));
+
+ALTER TABLE IF EXISTS music.labels
+    OWNER to ryandayrit;
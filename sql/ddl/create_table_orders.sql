@@ -0,0 +1,93 @@
+-- Tables: music.carts, music.cart_items, music.orders, music.order_items
+-- Requires sql/ddl/create_table_inventory.sql.
+
+-- DROP TABLE IF EXISTS music.order_items;
+-- DROP TABLE IF EXISTS music.orders;
+-- DROP TABLE IF EXISTS music.cart_items;
+-- DROP TABLE IF EXISTS music.carts;
+
+CREATE TABLE IF NOT EXISTS music.carts
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY,
+    customer text COLLATE pg_catalog."default" NOT NULL,
+    currency text COLLATE pg_catalog."default" NOT NULL DEFAULT 'USD',
+    status text COLLATE pg_catalog."default" NOT NULL DEFAULT 'open',
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT carts_pkey PRIMARY KEY (id),
+    CONSTRAINT carts_currency_check CHECK (currency ~ '^[A-Z]{3}$'),
+    CONSTRAINT carts_status_check CHECK (status IN ('open', 'checked_out'))
+)
+
+TABLESPACE pg_default;
+
+ALTER TABLE IF EXISTS music.carts
+    OWNER to ryandayrit;
+
+-- The unit price is the price of the album in the currency of the cart when
+-- the album was first added, so later price changes do not reprice carts.
+CREATE TABLE IF NOT EXISTS music.cart_items
+(
+    cart_id integer NOT NULL,
+    album_id integer NOT NULL,
+    format text COLLATE pg_catalog."default" NOT NULL,
+    quantity integer NOT NULL,
+    unit_price numeric(10,2) NOT NULL,
+    CONSTRAINT cart_items_pkey PRIMARY KEY (cart_id, album_id, format),
+    CONSTRAINT cart_items_cart_id_fkey FOREIGN KEY (cart_id) REFERENCES music.carts (id) ON DELETE CASCADE,
+    CONSTRAINT cart_items_album_id_fkey FOREIGN KEY (album_id) REFERENCES music.albums (id) ON DELETE CASCADE,
+    CONSTRAINT cart_items_format_check CHECK (format IN ('vinyl', 'cd', 'cassette', 'digital')),
+    CONSTRAINT cart_items_quantity_check CHECK (quantity > 0),
+    CONSTRAINT cart_items_unit_price_check CHECK (unit_price >= 0)
+)
+
+TABLESPACE pg_default;
+
+ALTER TABLE IF EXISTS music.cart_items
+    OWNER to ryandayrit;
+
+-- An order per checked out cart. Orders keep their items when albums are
+-- deleted, so the items do not reference music.albums.
+CREATE TABLE IF NOT EXISTS music.orders
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY,
+    cart_id integer NOT NULL,
+    customer text COLLATE pg_catalog."default" NOT NULL,
+    status text COLLATE pg_catalog."default" NOT NULL DEFAULT 'pending',
+    currency text COLLATE pg_catalog."default" NOT NULL,
+    total numeric(12,2) NOT NULL,
+    payment_id text COLLATE pg_catalog."default",
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT orders_pkey PRIMARY KEY (id),
+    CONSTRAINT orders_cart_id_key UNIQUE (cart_id),
+    CONSTRAINT orders_cart_id_fkey FOREIGN KEY (cart_id) REFERENCES music.carts (id),
+    CONSTRAINT orders_status_check CHECK (status IN ('pending', 'paid', 'shipped', 'cancelled', 'refunded')),
+    CONSTRAINT orders_total_check CHECK (total >= 0)
+)
+
+TABLESPACE pg_default;
+
+CREATE INDEX IF NOT EXISTS orders_customer_idx
+    ON music.orders (customer);
+
+ALTER TABLE IF EXISTS music.orders
+    OWNER to ryandayrit;
+
+CREATE TABLE IF NOT EXISTS music.order_items
+(
+    order_id integer NOT NULL,
+    album_id integer NOT NULL,
+    format text COLLATE pg_catalog."default" NOT NULL,
+    quantity integer NOT NULL,
+    unit_price numeric(10,2) NOT NULL,
+    warehouse_id integer NOT NULL,
+    reservation_id integer NOT NULL,
+    CONSTRAINT order_items_pkey PRIMARY KEY (order_id, album_id, format),
+    CONSTRAINT order_items_order_id_fkey FOREIGN KEY (order_id) REFERENCES music.orders (id) ON DELETE CASCADE,
+    CONSTRAINT order_items_quantity_check CHECK (quantity > 0)
+)
+
+TABLESPACE pg_default;
+
+ALTER TABLE IF EXISTS music.order_items
+    OWNER to ryandayrit;
@@ -0,0 +1,113 @@
+-- Tables: music.playlists, music.playlist_collaborators, music.playlist_items
+-- Requires sql/ddl/create_table_users.sql and sql/ddl/create_table_tracks.sql.
+
+-- DROP TABLE IF EXISTS music.playlist_items;
+-- DROP TABLE IF EXISTS music.playlist_collaborators;
+-- DROP TABLE IF EXISTS music.playlists;
+
+-- Anyone can read public playlists, and unlisted ones through their share
+-- token. Private playlists are only read by their owner and collaborators.
+-- The share token is random, so links cannot be guessed, and replaced to
+-- revoke a link.
+CREATE TABLE IF NOT EXISTS music.playlists
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY,
+    owner_id integer NOT NULL,
+    name text COLLATE pg_catalog."default" NOT NULL,
+    description text COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    visibility text COLLATE pg_catalog."default" NOT NULL DEFAULT 'private',
+    share_token text COLLATE pg_catalog."default" NOT NULL DEFAULT replace(gen_random_uuid()::text, '-', ''),
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT playlists_pkey PRIMARY KEY (id),
+    CONSTRAINT playlists_share_token_key UNIQUE (share_token),
+    CONSTRAINT playlists_owner_id_fkey FOREIGN KEY (owner_id) REFERENCES music.users (id) ON DELETE CASCADE,
+    CONSTRAINT playlists_name_check CHECK (name = btrim(name) AND name <> ''),
+    CONSTRAINT playlists_visibility_check CHECK (visibility IN ('public', 'unlisted', 'private'))
+)
+
+TABLESPACE pg_default;
+
+CREATE INDEX IF NOT EXISTS playlists_owner_id_idx
+    ON music.playlists (owner_id);
+
+CREATE INDEX IF NOT EXISTS playlists_public_idx
+    ON music.playlists (id)
+    WHERE visibility = 'public';
+
+ALTER TABLE IF EXISTS music.playlists
+    OWNER to ryandayrit;
+
+-- Users other than the owner who may change the items of a playlist.
+CREATE TABLE IF NOT EXISTS music.playlist_collaborators
+(
+    playlist_id integer NOT NULL,
+    user_id integer NOT NULL,
+    added_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT playlist_collaborators_pkey PRIMARY KEY (playlist_id, user_id),
+    CONSTRAINT playlist_collaborators_playlist_id_fkey FOREIGN KEY (playlist_id) REFERENCES music.playlists (id) ON DELETE CASCADE,
+    CONSTRAINT playlist_collaborators_user_id_fkey FOREIGN KEY (user_id) REFERENCES music.users (id) ON DELETE CASCADE
+)
+
+TABLESPACE pg_default;
+
+CREATE INDEX IF NOT EXISTS playlist_collaborators_user_id_idx
+    ON music.playlist_collaborators (user_id);
+
+ALTER TABLE IF EXISTS music.playlist_collaborators
+    OWNER to ryandayrit;
+
+-- An album or a track at a position of a playlist, counted from 1. The
+-- positions are unique at the end of a transaction, so items can be moved by
+-- shifting the items between the old and the new position.
+CREATE TABLE IF NOT EXISTS music.playlist_items
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY,
+    playlist_id integer NOT NULL,
+    "position" integer NOT NULL,
+    album_id integer,
+    track_id integer,
+    added_by integer,
+    added_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT playlist_items_pkey PRIMARY KEY (id),
+    CONSTRAINT playlist_items_position_key UNIQUE (playlist_id, "position") DEFERRABLE INITIALLY DEFERRED,
+    CONSTRAINT playlist_items_playlist_id_fkey FOREIGN KEY (playlist_id) REFERENCES music.playlists (id) ON DELETE CASCADE,
+    CONSTRAINT playlist_items_album_id_fkey FOREIGN KEY (album_id) REFERENCES music.albums (id) ON DELETE CASCADE,
+    CONSTRAINT playlist_items_track_id_fkey FOREIGN KEY (track_id) REFERENCES music.tracks (id) ON DELETE CASCADE,
+    CONSTRAINT playlist_items_added_by_fkey FOREIGN KEY (added_by) REFERENCES music.users (id) ON DELETE SET NULL,
+    CONSTRAINT playlist_items_position_check CHECK ("position" > 0),
+    CONSTRAINT playlist_items_item_check CHECK (num_nonnulls(album_id, track_id) = 1)
+)
+
+TABLESPACE pg_default;
+
+ALTER TABLE IF EXISTS music.playlist_items
+    OWNER to ryandayrit;
+
+-- Deleting an album or a track deletes its items, which would leave gaps in
+-- the positions of the playlists holding them, so the positions are renumbered.
+CREATE OR REPLACE FUNCTION music.renumber_playlist_items()
+    RETURNS trigger
+    LANGUAGE plpgsql
+AS $$
+BEGIN
+    UPDATE music.playlist_items i
+    SET "position" = numbered.position
+    FROM (
+        SELECT id, row_number() OVER (PARTITION BY playlist_id ORDER BY "position")::integer AS position
+        FROM music.playlist_items
+        WHERE playlist_id IN (SELECT DISTINCT playlist_id FROM deleted)
+    ) numbered
+    WHERE i.id = numbered.id
+      AND i."position" <> numbered.position;
+    RETURN NULL;
+END;
+$$;
+
+ALTER FUNCTION music.renumber_playlist_items()
+    OWNER TO ryandayrit;
+
+CREATE OR REPLACE TRIGGER playlist_items_renumber
+    AFTER DELETE ON music.playlist_items
+    REFERENCING OLD TABLE AS deleted
+    FOR EACH STATEMENT EXECUTE FUNCTION music.renumber_playlist_items();
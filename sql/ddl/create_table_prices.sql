@@ -0,0 +1,147 @@
+-- Tables: music.album_prices, music.price_history, music.scheduled_prices
+-- Requires sql/ddl/alter_table_albums_currency.sql.
+
+-- DROP TABLE IF EXISTS music.scheduled_prices;
+-- DROP TABLE IF EXISTS music.price_history;
+-- DROP TABLE IF EXISTS music.album_prices;
+
+-- The prices of albums in currencies other than the currency of the album.
+CREATE TABLE IF NOT EXISTS music.album_prices
+(
+    album_id integer NOT NULL,
+    currency text COLLATE pg_catalog."default" NOT NULL,
+    price numeric(10,2) NOT NULL,
+    CONSTRAINT album_prices_pkey PRIMARY KEY (album_id, currency),
+    CONSTRAINT album_prices_album_id_fkey FOREIGN KEY (album_id) REFERENCES music.albums (id) ON DELETE CASCADE,
+    CONSTRAINT album_prices_currency_check CHECK (currency ~ '^[A-Z]{3}$'),
+    CONSTRAINT album_prices_price_check CHECK (price >= 0)
+)
+
+TABLESPACE pg_default;
+
+ALTER TABLE IF EXISTS music.album_prices
+    OWNER to ryandayrit;
+
+-- Every change of a price, written by the triggers below. The old price is
+-- null for a new price and the new price is null for a removed price. There
+-- is no foreign key so that the history of deleted albums is kept for audits.
+CREATE TABLE IF NOT EXISTS music.price_history
+(
+    id bigint GENERATED ALWAYS AS IDENTITY,
+    album_id integer NOT NULL,
+    currency text COLLATE pg_catalog."default" NOT NULL,
+    old_price numeric(10,2),
+    new_price numeric(10,2),
+    effective_at timestamp with time zone NOT NULL,
+    changed_at timestamp with time zone NOT NULL DEFAULT now(),
+    changed_by text COLLATE pg_catalog."default" NOT NULL,
+    CONSTRAINT price_history_pkey PRIMARY KEY (id)
+)
+
+TABLESPACE pg_default;
+
+CREATE INDEX IF NOT EXISTS price_history_album_id_idx
+    ON music.price_history (album_id, effective_at);
+
+ALTER TABLE IF EXISTS music.price_history
+    OWNER to ryandayrit;
+
+-- Price changes taking effect at effective_at, applied by
+-- music.apply_scheduled_prices.
+CREATE TABLE IF NOT EXISTS music.scheduled_prices
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY,
+    album_id integer NOT NULL,
+    currency text COLLATE pg_catalog."default" NOT NULL,
+    price numeric(10,2) NOT NULL,
+    effective_at timestamp with time zone NOT NULL,
+    created_by text COLLATE pg_catalog."default" NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    applied_at timestamp with time zone,
+    CONSTRAINT scheduled_prices_pkey PRIMARY KEY (id),
+    CONSTRAINT scheduled_prices_album_id_fkey FOREIGN KEY (album_id) REFERENCES music.albums (id) ON DELETE CASCADE,
+    CONSTRAINT scheduled_prices_currency_check CHECK (currency ~ '^[A-Z]{3}$'),
+    CONSTRAINT scheduled_prices_price_check CHECK (price >= 0)
+)
+
+TABLESPACE pg_default;
+
+CREATE INDEX IF NOT EXISTS scheduled_prices_album_id_idx
+    ON music.scheduled_prices (album_id);
+
+CREATE INDEX IF NOT EXISTS scheduled_prices_due_idx
+    ON music.scheduled_prices (effective_at) WHERE applied_at IS NULL;
+
+ALTER TABLE IF EXISTS music.scheduled_prices
+    OWNER to ryandayrit;
+
+-- Records a price change. The writer and the time the change takes effect
+-- are read from the transaction settings music.changed_by and
+-- music.effective_at, see music.set_album_price, and default to the database
+-- user and now.
+CREATE OR REPLACE FUNCTION music.record_price_change(
+    album_id integer,
+    currency text,
+    old_price numeric,
+    new_price numeric)
+    RETURNS void
+    LANGUAGE sql
+AS $$
+    INSERT INTO music.price_history (album_id, currency, old_price, new_price, effective_at, changed_by)
+    VALUES (album_id, currency, old_price, new_price,
+            coalesce(nullif(current_setting('music.effective_at', true), '')::timestamp with time zone, now()),
+            coalesce(nullif(current_setting('music.changed_by', true), ''), session_user));
+$$;
+
+ALTER FUNCTION music.record_price_change(integer, text, numeric, numeric)
+    OWNER TO ryandayrit;
+
+CREATE OR REPLACE FUNCTION music.record_album_price_change()
+    RETURNS trigger
+    LANGUAGE plpgsql
+AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM music.record_price_change(NEW.id, NEW.currency, NULL, NEW.price);
+    ELSIF OLD.currency <> NEW.currency THEN
+        PERFORM music.record_price_change(NEW.id, OLD.currency, OLD.price, NULL);
+        PERFORM music.record_price_change(NEW.id, NEW.currency, NULL, NEW.price);
+        -- The price in the new currency of the album is the price of the album.
+        DELETE FROM music.album_prices
+        WHERE album_prices.album_id = NEW.id AND album_prices.currency = NEW.currency;
+    ELSIF OLD.price IS DISTINCT FROM NEW.price THEN
+        PERFORM music.record_price_change(NEW.id, NEW.currency, OLD.price, NEW.price);
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+ALTER FUNCTION music.record_album_price_change()
+    OWNER TO ryandayrit;
+
+CREATE OR REPLACE TRIGGER albums_price_history
+    AFTER INSERT OR UPDATE OF price, currency ON music.albums
+    FOR EACH ROW EXECUTE FUNCTION music.record_album_price_change();
+
+CREATE OR REPLACE FUNCTION music.record_album_prices_change()
+    RETURNS trigger
+    LANGUAGE plpgsql
+AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM music.record_price_change(NEW.album_id, NEW.currency, NULL, NEW.price);
+    ELSIF TG_OP = 'DELETE' THEN
+        PERFORM music.record_price_change(OLD.album_id, OLD.currency, OLD.price, NULL);
+    ELSIF OLD.price IS DISTINCT FROM NEW.price THEN
+        PERFORM music.record_price_change(NEW.album_id, NEW.currency, OLD.price, NEW.price);
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+ALTER FUNCTION music.record_album_prices_change()
+    OWNER TO ryandayrit;
+
+CREATE OR REPLACE TRIGGER album_prices_price_history
+    AFTER INSERT OR UPDATE OR DELETE ON music.album_prices
+    FOR EACH ROW EXECUTE FUNCTION music.record_album_prices_change();
@@ -0,0 +1,38 @@
+-- Table: music.tags
+
+-- DROP TABLE IF EXISTS music.album_tags;
+-- DROP TABLE IF EXISTS music.tags;
+
+CREATE TABLE IF NOT EXISTS music.tags
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY,
+    name text COLLATE pg_catalog."default" NOT NULL,
+    CONSTRAINT tags_pkey PRIMARY KEY (id),
+    CONSTRAINT tags_name_check CHECK (name = btrim(name) AND name <> '')
+)
+
+TABLESPACE pg_default;
+
+-- Tags differing only in case are the same tag.
+CREATE UNIQUE INDEX IF NOT EXISTS tags_name_key
+    ON music.tags (lower(name));
+
+ALTER TABLE IF EXISTS music.tags
+    OWNER to ryandayrit;
+
+CREATE TABLE IF NOT EXISTS music.album_tags
+(
+    album_id integer NOT NULL,
+    tag_id integer NOT NULL,
+    CONSTRAINT album_tags_pkey PRIMARY KEY (album_id, tag_id),
+    CONSTRAINT album_tags_album_id_fkey FOREIGN KEY (album_id) REFERENCES music.albums (id) ON DELETE CASCADE,
+    CONSTRAINT album_tags_tag_id_fkey FOREIGN KEY (tag_id) REFERENCES music.tags (id) ON DELETE CASCADE
+)
+
+TABLESPACE pg_default;
+
+CREATE INDEX IF NOT EXISTS album_tags_tag_id_idx
+    ON music.album_tags (tag_id);
+
+ALTER TABLE IF EXISTS music.album_tags
+    OWNER to ryandayrit;
@@ -0,0 +1,51 @@
+-- Table: music.tracks
+
+-- DROP TABLE IF EXISTS music.tracks;
+
+CREATE TABLE IF NOT EXISTS music.tracks
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY,
+    album_id integer NOT NULL,
+    disc smallint NOT NULL DEFAULT 1,
+    "number" smallint NOT NULL,
+    title text COLLATE pg_catalog."default" NOT NULL,
+    duration_ms integer NOT NULL DEFAULT 0,
+    isrc character(12) COLLATE pg_catalog."default",
+    CONSTRAINT tracks_pkey PRIMARY KEY (id),
+    CONSTRAINT tracks_album_id_fkey FOREIGN KEY (album_id) REFERENCES music.albums (id) ON DELETE CASCADE,
+    CONSTRAINT tracks_position_key UNIQUE (album_id, disc, "number"),
+    CONSTRAINT tracks_position_check CHECK (disc > 0 AND "number" > 0),
+    CONSTRAINT tracks_duration_ms_check CHECK (duration_ms >= 0),
+    CONSTRAINT tracks_isrc_check CHECK (isrc ~ '^[A-Z]{2}[A-Z0-9]{3}[0-9]{7}$')
+)
+
+TABLESPACE pg_default;
+
+ALTER TABLE IF EXISTS music.tracks
+    OWNER to ryandayrit;
+
+-- kafka-cdc republishes the album of a changed track, see
+-- sql/ddl/create_trigger_albums_changes.sql.
+CREATE OR REPLACE FUNCTION music.notify_track_change()
+    RETURNS trigger
+    LANGUAGE plpgsql
+AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        PERFORM pg_notify('album_changes', json_build_object('op', 'UPDATE', 'id', OLD.album_id)::text);
+    ELSE
+        PERFORM pg_notify('album_changes', json_build_object('op', 'UPDATE', 'id', NEW.album_id)::text);
+        IF TG_OP = 'UPDATE' AND NEW.album_id <> OLD.album_id THEN
+            PERFORM pg_notify('album_changes', json_build_object('op', 'UPDATE', 'id', OLD.album_id)::text);
+        END IF;
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+ALTER FUNCTION music.notify_track_change()
+    OWNER TO ryandayrit;
+
+CREATE OR REPLACE TRIGGER tracks_changes
+    AFTER INSERT OR UPDATE OR DELETE ON music.tracks
+    FOR EACH ROW EXECUTE FUNCTION music.notify_track_change();
@@ -0,0 +1,174 @@
+-- Tables: music.users, music.reviews, music.wishlists,
+-- music.price_drop_notifications
+-- Requires sql/ddl/alter_table_albums_ratings.sql and
+-- sql/ddl/create_table_prices.sql.
+
+-- DROP TABLE IF EXISTS music.price_drop_notifications;
+-- DROP TABLE IF EXISTS music.wishlists;
+-- DROP TABLE IF EXISTS music.reviews;
+-- DROP TABLE IF EXISTS music.users;
+
+CREATE TABLE IF NOT EXISTS music.users
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY,
+    name text COLLATE pg_catalog."default" NOT NULL,
+    email text COLLATE pg_catalog."default" NOT NULL,
+    currency text COLLATE pg_catalog."default" NOT NULL DEFAULT 'USD',
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT users_pkey PRIMARY KEY (id),
+    CONSTRAINT users_name_check CHECK (name = btrim(name) AND name <> ''),
+    CONSTRAINT users_currency_check CHECK (currency ~ '^[A-Z]{3}$')
+)
+
+TABLESPACE pg_default;
+
+-- Emails differing only in case are the same email.
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_key
+    ON music.users (lower(email));
+
+ALTER TABLE IF EXISTS music.users
+    OWNER to ryandayrit;
+
+-- A rating of an album per user, with an optional text. Reviews with a text
+-- are pending until they are approved or rejected, see music.reviews_status.
+CREATE TABLE IF NOT EXISTS music.reviews
+(
+    user_id integer NOT NULL,
+    album_id integer NOT NULL,
+    rating integer NOT NULL,
+    body text COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    status text COLLATE pg_catalog."default" NOT NULL DEFAULT 'pending',
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT reviews_pkey PRIMARY KEY (user_id, album_id),
+    CONSTRAINT reviews_user_id_fkey FOREIGN KEY (user_id) REFERENCES music.users (id) ON DELETE CASCADE,
+    CONSTRAINT reviews_album_id_fkey FOREIGN KEY (album_id) REFERENCES music.albums (id) ON DELETE CASCADE,
+    CONSTRAINT reviews_rating_check CHECK (rating BETWEEN 1 AND 5),
+    CONSTRAINT reviews_status_check CHECK (status IN ('pending', 'approved', 'rejected'))
+)
+
+TABLESPACE pg_default;
+
+CREATE INDEX IF NOT EXISTS reviews_album_id_idx
+    ON music.reviews (album_id, status);
+
+ALTER TABLE IF EXISTS music.reviews
+    OWNER to ryandayrit;
+
+CREATE TABLE IF NOT EXISTS music.wishlists
+(
+    user_id integer NOT NULL,
+    album_id integer NOT NULL,
+    added_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT wishlists_pkey PRIMARY KEY (user_id, album_id),
+    CONSTRAINT wishlists_user_id_fkey FOREIGN KEY (user_id) REFERENCES music.users (id) ON DELETE CASCADE,
+    CONSTRAINT wishlists_album_id_fkey FOREIGN KEY (album_id) REFERENCES music.albums (id) ON DELETE CASCADE
+)
+
+TABLESPACE pg_default;
+
+CREATE INDEX IF NOT EXISTS wishlists_album_id_idx
+    ON music.wishlists (album_id);
+
+ALTER TABLE IF EXISTS music.wishlists
+    OWNER to ryandayrit;
+
+-- Written by music.notify_price_drop for every drop of the price of an album
+-- on a wishlist in the currency of its user.
+CREATE TABLE IF NOT EXISTS music.price_drop_notifications
+(
+    id bigint GENERATED ALWAYS AS IDENTITY,
+    user_id integer NOT NULL,
+    album_id integer NOT NULL,
+    currency text COLLATE pg_catalog."default" NOT NULL,
+    old_price numeric(10,2) NOT NULL,
+    new_price numeric(10,2) NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    read_at timestamp with time zone,
+    CONSTRAINT price_drop_notifications_pkey PRIMARY KEY (id),
+    CONSTRAINT price_drop_notifications_user_id_fkey FOREIGN KEY (user_id) REFERENCES music.users (id) ON DELETE CASCADE,
+    CONSTRAINT price_drop_notifications_album_id_fkey FOREIGN KEY (album_id) REFERENCES music.albums (id) ON DELETE CASCADE
+)
+
+TABLESPACE pg_default;
+
+CREATE INDEX IF NOT EXISTS price_drop_notifications_user_id_idx
+    ON music.price_drop_notifications (user_id, id);
+
+ALTER TABLE IF EXISTS music.price_drop_notifications
+    OWNER to ryandayrit;
+
+-- Approves reviews without a text and sends reviews whose text changed back
+-- to moderation.
+CREATE OR REPLACE FUNCTION music.reviews_status()
+    RETURNS trigger
+    LANGUAGE plpgsql
+AS $$
+BEGIN
+    IF NEW.body = '' THEN
+        NEW.status := 'approved';
+    ELSIF TG_OP = 'INSERT' OR OLD.body IS DISTINCT FROM NEW.body THEN
+        NEW.status := 'pending';
+    END IF;
+    RETURN NEW;
+END;
+$$;
+
+ALTER FUNCTION music.reviews_status()
+    OWNER TO ryandayrit;
+
+CREATE OR REPLACE TRIGGER reviews_status
+    BEFORE INSERT OR UPDATE OF body ON music.reviews
+    FOR EACH ROW EXECUTE FUNCTION music.reviews_status();
+
+-- Keeps the average rating and the number of ratings of an album, not
+-- counting rejected reviews.
+CREATE OR REPLACE FUNCTION music.update_album_rating()
+    RETURNS trigger
+    LANGUAGE plpgsql
+AS $$
+DECLARE
+    album integer := CASE WHEN TG_OP = 'DELETE' THEN OLD.album_id ELSE NEW.album_id END;
+BEGIN
+    UPDATE music.albums
+    SET (average_rating, rating_count) = (
+        SELECT coalesce(round(avg(reviews.rating), 2), 0), count(*)
+        FROM music.reviews
+        WHERE reviews.album_id = album AND reviews.status <> 'rejected')
+    WHERE albums.id = album;
+    RETURN NULL;
+END;
+$$;
+
+ALTER FUNCTION music.update_album_rating()
+    OWNER TO ryandayrit;
+
+CREATE OR REPLACE TRIGGER reviews_album_rating
+    AFTER INSERT OR UPDATE OR DELETE ON music.reviews
+    FOR EACH ROW EXECUTE FUNCTION music.update_album_rating();
+
+-- Notifies the users with the album on their wishlist of a drop of its price
+-- in their currency. Every price change is recorded in music.price_history,
+-- whether it was made through the services, Kafka or a scheduled change.
+CREATE OR REPLACE FUNCTION music.notify_price_drop()
+    RETURNS trigger
+    LANGUAGE plpgsql
+AS $$
+BEGIN
+    IF NEW.new_price < NEW.old_price THEN
+        INSERT INTO music.price_drop_notifications (user_id, album_id, currency, old_price, new_price)
+        SELECT wishlists.user_id, NEW.album_id, NEW.currency, NEW.old_price, NEW.new_price
+        FROM music.wishlists
+        JOIN music.users ON users.id = wishlists.user_id
+        WHERE wishlists.album_id = NEW.album_id AND users.currency = NEW.currency;
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+ALTER FUNCTION music.notify_price_drop()
+    OWNER TO ryandayrit;
+
+CREATE OR REPLACE TRIGGER price_history_price_drop
+    AFTER INSERT ON music.price_history
+    FOR EACH ROW EXECUTE FUNCTION music.notify_price_drop();
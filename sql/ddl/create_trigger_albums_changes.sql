@@ -0,0 +1,32 @@
+-- Trigger: notify kafka-cdc of changes to music.albums
+
+-- DROP TRIGGER IF EXISTS albums_truncate ON music.albums;
+-- DROP TRIGGER IF EXISTS albums_changes ON music.albums;
+-- DROP FUNCTION IF EXISTS music.notify_album_change();
+
+CREATE OR REPLACE FUNCTION music.notify_album_change()
+    RETURNS trigger
+    LANGUAGE plpgsql
+AS $$
+BEGIN
+    IF TG_OP = 'TRUNCATE' THEN
+        PERFORM pg_notify('album_changes', json_build_object('op', TG_OP)::text);
+    ELSIF TG_OP = 'DELETE' THEN
+        PERFORM pg_notify('album_changes', json_build_object('op', TG_OP, 'id', OLD.id)::text);
+    ELSE
+        PERFORM pg_notify('album_changes', json_build_object('op', TG_OP, 'id', NEW.id)::text);
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+ALTER FUNCTION music.notify_album_change()
+    OWNER TO ryandayrit;
+
+CREATE OR REPLACE TRIGGER albums_changes
+    AFTER INSERT OR UPDATE OR DELETE ON music.albums
+    FOR EACH ROW EXECUTE FUNCTION music.notify_album_change();
+
+CREATE OR REPLACE TRIGGER albums_truncate
+    AFTER TRUNCATE ON music.albums
+    FOR EACH STATEMENT EXECUTE FUNCTION music.notify_album_change();